@@ -42,6 +42,10 @@ type DexClient interface {
 	RevokeRefresh(ctx context.Context, in *RevokeRefreshReq, opts ...grpc.CallOption) (*RevokeRefreshResp, error)
 	// VerifyPassword returns whether a password matches a hash for a specific email or not.
 	VerifyPassword(ctx context.Context, in *VerifyPasswordReq, opts ...grpc.CallOption) (*VerifyPasswordResp, error)
+	// GetLastLogin returns the time a user last logged in through a connector.
+	//
+	// Logins are only recorded if the server is configured to track them.
+	GetLastLogin(ctx context.Context, in *GetLastLoginReq, opts ...grpc.CallOption) (*GetLastLoginResp, error)
 }
 
 type dexClient struct {
@@ -151,6 +155,15 @@ func (c *dexClient) VerifyPassword(ctx context.Context, in *VerifyPasswordReq, o
 	return out, nil
 }
 
+func (c *dexClient) GetLastLogin(ctx context.Context, in *GetLastLoginReq, opts ...grpc.CallOption) (*GetLastLoginResp, error) {
+	out := new(GetLastLoginResp)
+	err := c.cc.Invoke(ctx, "/api.Dex/GetLastLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DexServer is the server API for Dex service.
 // All implementations must embed UnimplementedDexServer
 // for forward compatibility
@@ -179,6 +192,10 @@ type DexServer interface {
 	RevokeRefresh(context.Context, *RevokeRefreshReq) (*RevokeRefreshResp, error)
 	// VerifyPassword returns whether a password matches a hash for a specific email or not.
 	VerifyPassword(context.Context, *VerifyPasswordReq) (*VerifyPasswordResp, error)
+	// GetLastLogin returns the time a user last logged in through a connector.
+	//
+	// Logins are only recorded if the server is configured to track them.
+	GetLastLogin(context.Context, *GetLastLoginReq) (*GetLastLoginResp, error)
 	mustEmbedUnimplementedDexServer()
 }
 
@@ -219,6 +236,9 @@ func (UnimplementedDexServer) RevokeRefresh(context.Context, *RevokeRefreshReq)
 func (UnimplementedDexServer) VerifyPassword(context.Context, *VerifyPasswordReq) (*VerifyPasswordResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyPassword not implemented")
 }
+func (UnimplementedDexServer) GetLastLogin(context.Context, *GetLastLoginReq) (*GetLastLoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastLogin not implemented")
+}
 func (UnimplementedDexServer) mustEmbedUnimplementedDexServer() {}
 
 // UnsafeDexServer may be embedded to opt out of forward compatibility for this service.
@@ -430,6 +450,24 @@ func _Dex_VerifyPassword_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dex_GetLastLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastLoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DexServer).GetLastLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Dex/GetLastLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DexServer).GetLastLogin(ctx, req.(*GetLastLoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Dex_ServiceDesc is the grpc.ServiceDesc for Dex service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -481,6 +519,10 @@ var Dex_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyPassword",
 			Handler:    _Dex_VerifyPassword_Handler,
 		},
+		{
+			MethodName: "GetLastLogin",
+			Handler:    _Dex_GetLastLogin_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/api.proto",
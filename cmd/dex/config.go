@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/dexidp/dex/pkg/groups/membership"
 	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/server"
 	"github.com/dexidp/dex/storage"
@@ -49,6 +50,18 @@ type Config struct {
 	// querying the storage. Cannot be specified without enabling a passwords
 	// database.
 	StaticPasswords []password `json:"staticPasswords"`
+
+	// DenyList blocks the listed users. It's reloaded from the config file
+	// when dex receives a SIGHUP.
+	DenyList DenyList `json:"denyList"`
+}
+
+// DenyList is the configuration of the users blocked from logging in.
+type DenyList struct {
+	// Subjects as found in the "sub" claim of the ID tokens.
+	Subjects []string `json:"subjects"`
+	// Emails, compared case-insensitively.
+	Emails []string `json:"emails"`
 }
 
 // Validate the configuration
@@ -68,6 +81,7 @@ func (c Config) Validate() error {
 		{c.GRPC.TLSKey != "" && c.GRPC.Addr == "", "no address specified for gRPC"},
 		{(c.GRPC.TLSCert == "") != (c.GRPC.TLSKey == ""), "must specific both a gRPC TLS cert and key"},
 		{c.GRPC.TLSCert == "" && c.GRPC.TLSClientCA != "", "cannot specify gRPC TLS client CA without a gRPC TLS cert"},
+		{c.OAuth2.GroupsHashSalt == "" && hashesGroups(c.StaticClients), "clients with hashGroups enabled require oauth2.groupsHashSalt"},
 	}
 
 	var checkErrors []string
@@ -83,6 +97,15 @@ func (c Config) Validate() error {
 	return nil
 }
 
+func hashesGroups(clients []storage.Client) bool {
+	for _, client := range clients {
+		if client.HashGroups {
+			return true
+		}
+	}
+	return false
+}
+
 type password storage.Password
 
 func (p *password) UnmarshalJSON(b []byte) error {
@@ -137,6 +160,28 @@ type OAuth2 struct {
 	AlwaysShowLoginScreen bool `json:"alwaysShowLoginScreen"`
 	// This is the connector that can be used for password grant
 	PasswordConnector string `json:"passwordConnector"`
+	// Salt used to hash group names for clients with hashGroups enabled.
+	GroupsHashSalt string `json:"groupsHashSalt"`
+	// If specified, record the ID of every issued token in the storage.
+	TrackIssuedTokens bool `json:"trackIssuedTokens"`
+	// If specified, record the time of every login in the storage.
+	TrackLastLogin bool `json:"trackLastLogin"`
+	// If specified, warn about identities with more groups than this.
+	GroupsWarningThreshold int `json:"groupsWarningThreshold"`
+	// If specified, only pass groups matching this regular expression to clients.
+	GroupsFilter string `json:"groupsFilter"`
+	// If specified, deny logins missing claims a client requested as essential.
+	EnforceEssentialClaims bool `json:"enforceEssentialClaims"`
+	// If specified, add a "client_id" claim to issued tokens.
+	EmitClientIDClaim bool `json:"emitClientIDClaim"`
+	// If specified, accept redirect URIs matching a client's redirectURIPathPattern.
+	AllowRedirectURIPatterns bool `json:"allowRedirectURIPatterns"`
+	// Custom redirect URI schemes of native clients receiving login errors as redirects.
+	NativeErrorRedirectSchemes []string `json:"nativeErrorRedirectSchemes"`
+	// Format of the user codes of the device flow.
+	DeviceUserCode server.UserCodeConfig `json:"deviceUserCode"`
+	// URL prefixes request objects may be fetched from with request_uri.
+	RequestURIPrefixes []string `json:"requestURIPrefixes"`
 }
 
 // Web is the config format for the HTTP server.
@@ -186,13 +231,13 @@ var (
 	_ StorageConfig = (*ent.MySQL)(nil)
 )
 
-func getORMBasedSQLStorage(normal, entBased StorageConfig) func() StorageConfig {
+func getORMBasedSQLStorage(normal, entBased func() StorageConfig) func() StorageConfig {
 	return func() StorageConfig {
 		switch os.Getenv("DEX_ENT_ENABLED") {
 		case "true", "yes":
-			return entBased
+			return entBased()
 		default:
-			return normal
+			return normal()
 		}
 	}
 }
@@ -201,9 +246,18 @@ var storages = map[string]func() StorageConfig{
 	"etcd":       func() StorageConfig { return new(etcd.Etcd) },
 	"kubernetes": func() StorageConfig { return new(kubernetes.Config) },
 	"memory":     func() StorageConfig { return new(memory.Config) },
-	"sqlite3":    getORMBasedSQLStorage(&sql.SQLite3{}, &ent.SQLite3{}),
-	"postgres":   getORMBasedSQLStorage(&sql.Postgres{}, &ent.Postgres{}),
-	"mysql":      getORMBasedSQLStorage(&sql.MySQL{}, &ent.MySQL{}),
+	"sqlite3": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.SQLite3) },
+		func() StorageConfig { return new(ent.SQLite3) },
+	),
+	"postgres": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.Postgres) },
+		func() StorageConfig { return new(ent.Postgres) },
+	),
+	"mysql": getORMBasedSQLStorage(
+		func() StorageConfig { return new(sql.MySQL) },
+		func() StorageConfig { return new(ent.MySQL) },
+	),
 }
 
 // isExpandEnvEnabled returns if os.ExpandEnv should be used for each storage and connector config.
@@ -259,6 +313,17 @@ type Connector struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
 
+	// LogLevel overrides the global logging level for this connector only.
+	LogLevel string `json:"logLevel"`
+
+	// Membership configures an external gRPC service whose groups are merged
+	// into the groups reported by this connector.
+	Membership *membership.Config `json:"membership"`
+
+	// PreferredLanguages are the languages the preferred_language claim of
+	// users of this connector without a locale is negotiated from.
+	PreferredLanguages []string `json:"preferredLanguages"`
+
 	Config server.ConnectorConfig `json:"config"`
 }
 
@@ -266,9 +331,14 @@ type Connector struct {
 // dynamically determine the type of the connector config.
 func (c *Connector) UnmarshalJSON(b []byte) error {
 	var conn struct {
-		Type string `json:"type"`
-		Name string `json:"name"`
-		ID   string `json:"id"`
+		Type     string `json:"type"`
+		Name     string `json:"name"`
+		ID       string `json:"id"`
+		LogLevel string `json:"logLevel"`
+
+		Membership *membership.Config `json:"membership"`
+
+		PreferredLanguages []string `json:"preferredLanguages"`
 
 		Config json.RawMessage `json:"config"`
 	}
@@ -292,10 +362,13 @@ func (c *Connector) UnmarshalJSON(b []byte) error {
 		}
 	}
 	*c = Connector{
-		Type:   conn.Type,
-		Name:   conn.Name,
-		ID:     conn.ID,
-		Config: connConfig,
+		Type:               conn.Type,
+		Name:               conn.Name,
+		ID:                 conn.ID,
+		LogLevel:           conn.LogLevel,
+		Membership:         conn.Membership,
+		PreferredLanguages: conn.PreferredLanguages,
+		Config:             connConfig,
 	}
 	return nil
 }
@@ -329,6 +402,14 @@ type Expiry struct {
 	// DeviceRequests defines the duration of time for which the DeviceRequests will be valid.
 	DeviceRequests string `json:"deviceRequests"`
 
+	// RequestObjects defines the duration of time for which request objects
+	// fetched from request URIs are cached.
+	RequestObjects string `json:"requestObjects"`
+
+	// PushedAuthRequests defines the duration of time for which pushed
+	// authorization requests can be used.
+	PushedAuthRequests string `json:"pushedAuthRequests"`
+
 	// RefreshTokens defines refresh tokens expiry policy
 	RefreshTokens RefreshToken `json:"refreshTokens"`
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -9,8 +10,10 @@ import (
 
 	"github.com/dexidp/dex/connector/mock"
 	"github.com/dexidp/dex/connector/oidc"
+	"github.com/dexidp/dex/pkg/groups/membership"
 	"github.com/dexidp/dex/server"
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
 	"github.com/dexidp/dex/storage/sql"
 )
 
@@ -58,6 +61,34 @@ func TestInvalidConfiguration(t *testing.T) {
 	}
 }
 
+func TestHashGroupsRequiresSalt(t *testing.T) {
+	configuration := Config{
+		Issuer: "http://127.0.0.1:5556/dex",
+		Storage: Storage{
+			Type:   "memory",
+			Config: &memory.Config{},
+		},
+		Web: Web{
+			HTTP: "127.0.0.1:5556",
+		},
+		StaticClients: []storage.Client{
+			{ID: "example-app", HashGroups: true},
+		},
+	}
+	err := configuration.Validate()
+	if err == nil {
+		t.Fatal("hashGroups without oauth2.groupsHashSalt should be invalid")
+	}
+	if !strings.Contains(err.Error(), "groupsHashSalt") {
+		t.Errorf("expected error to mention groupsHashSalt, got %q", err)
+	}
+
+	configuration.OAuth2.GroupsHashSalt = "salt"
+	if err := configuration.Validate(); err != nil {
+		t.Fatalf("this configuration should have been valid: %v", err)
+	}
+}
+
 func TestUnmarshalConfig(t *testing.T) {
 	rawConfig := []byte(`
 issuer: http://127.0.0.1:5556/dex
@@ -70,6 +101,8 @@ storage:
     maxIdleConns: 3
     connMaxLifetime: 30
     connectionTimeout: 3
+    writeBatching:
+      window: 5ms
 web:
   http: 127.0.0.1:5556
 
@@ -92,6 +125,13 @@ connectors:
 - type: mockCallback
   id: mock
   name: Example
+  logLevel: debug
+  membership:
+    addr: membership.example.com:5557
+    tlsCert: /etc/dex/membership/tls.crt
+    tlsKey: /etc/dex/membership/tls.key
+    rootCA: /etc/dex/membership/ca.crt
+  preferredLanguages: [en, de]
 - type: oidc
   id: google
   name: Google
@@ -137,6 +177,7 @@ logger:
 					MaxIdleConns:      3,
 					ConnMaxLifetime:   30,
 					ConnectionTimeout: 3,
+					WriteBatching:     sql.WriteBatching{Window: "5ms"},
 				},
 			},
 		},
@@ -164,10 +205,18 @@ logger:
 		},
 		StaticConnectors: []Connector{
 			{
-				Type:   "mockCallback",
-				ID:     "mock",
-				Name:   "Example",
-				Config: &mock.CallbackConfig{},
+				Type:     "mockCallback",
+				ID:       "mock",
+				Name:     "Example",
+				LogLevel: "debug",
+				Membership: &membership.Config{
+					Addr:    "membership.example.com:5557",
+					TLSCert: "/etc/dex/membership/tls.crt",
+					TLSKey:  "/etc/dex/membership/tls.key",
+					RootCA:  "/etc/dex/membership/ca.crt",
+				},
+				PreferredLanguages: []string{"en", "de"},
+				Config:             &mock.CallbackConfig{},
 			},
 			{
 				Type: "oidc",
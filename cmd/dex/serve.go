@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
 	"syscall"
@@ -30,9 +31,12 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/dexidp/dex/api/v2"
+	"github.com/dexidp/dex/connector/oidc"
+	"github.com/dexidp/dex/pkg/groups/membership"
 	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/server"
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
 )
 
 type serveOptions struct {
@@ -127,6 +131,16 @@ func runServe(options serveOptions) error {
 		return fmt.Errorf("failed to register gRPC server metrics: %v", err)
 	}
 
+	err = prometheusRegistry.Register(oidc.Metrics)
+	if err != nil {
+		return fmt.Errorf("failed to register OIDC connector metrics: %v", err)
+	}
+
+	err = prometheusRegistry.Register(memory.Metrics)
+	if err != nil {
+		return fmt.Errorf("failed to register memory storage metrics: %v", err)
+	}
+
 	var grpcOptions []grpc.ServerOption
 
 	allowedTLSCiphers := []uint16{
@@ -222,21 +236,48 @@ func runServe(options serveOptions) error {
 	}
 
 	storageConnectors := make([]storage.Connector, len(c.StaticConnectors))
-	for i, c := range c.StaticConnectors {
-		if c.ID == "" || c.Name == "" || c.Type == "" {
+	connectorLoggers := make(map[string]log.Logger)
+	membershipClients := make(map[string]*membership.Client)
+	preferredLanguages := make(map[string][]string)
+	for i, conn := range c.StaticConnectors {
+		if conn.ID == "" || conn.Name == "" || conn.Type == "" {
 			return fmt.Errorf("invalid config: ID, Type and Name fields are required for a connector")
 		}
-		if c.Config == nil {
-			return fmt.Errorf("invalid config: no config field for connector %q", c.ID)
+		if conn.Config == nil {
+			return fmt.Errorf("invalid config: no config field for connector %q", conn.ID)
+		}
+		logger.Infof("config connector: %s", conn.ID)
+
+		if conn.LogLevel != "" {
+			connectorLogger, err := newLogger(conn.LogLevel, c.Logger.Format)
+			if err != nil {
+				return fmt.Errorf("invalid config: connector %q: %v", conn.ID, err)
+			}
+			logger.Infof("config connector %s using log level: %s", conn.ID, conn.LogLevel)
+			connectorLoggers[conn.ID] = connectorLogger
+		}
+
+		if conn.Membership != nil {
+			membershipClient, err := conn.Membership.Open()
+			if err != nil {
+				return fmt.Errorf("invalid config: connector %q: %v", conn.ID, err)
+			}
+			defer membershipClient.Close()
+			logger.Infof("config connector %s using membership service: %s", conn.ID, conn.Membership.Addr)
+			membershipClients[conn.ID] = membershipClient
+		}
+
+		if len(conn.PreferredLanguages) > 0 {
+			logger.Infof("config connector %s negotiating preferred languages: %s", conn.ID, strings.Join(conn.PreferredLanguages, ", "))
+			preferredLanguages[conn.ID] = conn.PreferredLanguages
 		}
-		logger.Infof("config connector: %s", c.ID)
 
 		// convert to a storage connector object
-		conn, err := ToStorageConnector(c)
+		storageConnector, err := ToStorageConnector(conn)
 		if err != nil {
 			return fmt.Errorf("failed to initialize storage connectors: %v", err)
 		}
-		storageConnectors[i] = conn
+		storageConnectors[i] = storageConnector
 	}
 
 	if c.EnablePasswordDB {
@@ -268,19 +309,37 @@ func runServe(options serveOptions) error {
 
 	healthChecker := gosundheit.New()
 
+	denyList := server.NewDenyList(c.DenyList.Subjects, c.DenyList.Emails)
+
 	serverConfig := server.Config{
 		SupportedResponseTypes: c.OAuth2.ResponseTypes,
 		SkipApprovalScreen:     c.OAuth2.SkipApprovalScreen,
 		AlwaysShowLoginScreen:  c.OAuth2.AlwaysShowLoginScreen,
 		PasswordConnector:      c.OAuth2.PasswordConnector,
+		GroupsHashSalt:         c.OAuth2.GroupsHashSalt,
+		TrackIssuedTokens:      c.OAuth2.TrackIssuedTokens,
+		GroupsWarningThreshold: c.OAuth2.GroupsWarningThreshold,
+		GroupsFilter:           c.OAuth2.GroupsFilter,
+		EnforceEssentialClaims: c.OAuth2.EnforceEssentialClaims,
+		DeviceUserCode:         c.OAuth2.DeviceUserCode,
+		EmitClientIDClaim:      c.OAuth2.EmitClientIDClaim,
 		AllowedOrigins:         c.Web.AllowedOrigins,
 		Issuer:                 c.Issuer,
 		Storage:                s,
 		Web:                    c.Frontend,
 		Logger:                 logger,
+		ConnectorLoggers:       connectorLoggers,
+		MembershipClients:      membershipClients,
+		PreferredLanguages:     preferredLanguages,
+		DenyList:               denyList,
 		Now:                    now,
 		PrometheusRegistry:     prometheusRegistry,
 		HealthChecker:          healthChecker,
+
+		AllowRedirectURIPatterns:   c.OAuth2.AllowRedirectURIPatterns,
+		TrackLastLogin:             c.OAuth2.TrackLastLogin,
+		NativeErrorRedirectSchemes: c.OAuth2.NativeErrorRedirectSchemes,
+		RequestURIPrefixes:         c.OAuth2.RequestURIPrefixes,
 	}
 	if c.Expiry.SigningKeys != "" {
 		signingKeys, err := time.ParseDuration(c.Expiry.SigningKeys)
@@ -314,6 +373,22 @@ func runServe(options serveOptions) error {
 		logger.Infof("config device requests valid for: %v", deviceRequests)
 		serverConfig.DeviceRequestsValidFor = deviceRequests
 	}
+	if c.Expiry.RequestObjects != "" {
+		requestObjects, err := time.ParseDuration(c.Expiry.RequestObjects)
+		if err != nil {
+			return fmt.Errorf("invalid config value %q for request objects expiry: %v", c.Expiry.RequestObjects, err)
+		}
+		logger.Infof("config request objects cached for: %v", requestObjects)
+		serverConfig.RequestObjectsCacheFor = requestObjects
+	}
+	if c.Expiry.PushedAuthRequests != "" {
+		pushedAuthRequests, err := time.ParseDuration(c.Expiry.PushedAuthRequests)
+		if err != nil {
+			return fmt.Errorf("invalid config value %q for pushed auth requests expiry: %v", c.Expiry.PushedAuthRequests, err)
+		}
+		logger.Infof("config pushed auth requests valid for: %v", pushedAuthRequests)
+		serverConfig.PushedAuthRequestsValidFor = pushedAuthRequests
+	}
 	refreshTokenPolicy, err := server.NewRefreshTokenPolicy(
 		logger,
 		c.Expiry.RefreshTokens.DisableRotation,
@@ -475,6 +550,16 @@ func runServe(options serveOptions) error {
 		})
 	}
 
+	// Reload the deny list on SIGHUP
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		group.Add(func() error {
+			return reloadDenyList(ctx, configFile, denyList, logger)
+		}, func(err error) {
+			cancel()
+		})
+	}
+
 	group.Add(run.SignalHandler(context.Background(), os.Interrupt, syscall.SIGTERM))
 	if err := group.Run(); err != nil {
 		if _, ok := err.(run.SignalError); !ok {
@@ -499,6 +584,38 @@ func (f *utcFormatter) Format(e *logrus.Entry) ([]byte, error) {
 	return f.f.Format(e)
 }
 
+// reloadDenyList loads the deny list from the config file whenever the process
+// receives a SIGHUP, until the context is canceled. The rest of the config
+// file is ignored.
+func reloadDenyList(ctx context.Context, configFile string, denyList *server.DenyList, logger log.Logger) error {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sighup:
+		}
+
+		configData, err := os.ReadFile(configFile)
+		if err != nil {
+			logger.Errorf("failed to reload deny list: failed to read config file %s: %v", configFile, err)
+			continue
+		}
+		var c struct {
+			DenyList DenyList `json:"denyList"`
+		}
+		if err := yaml.Unmarshal(configData, &c); err != nil {
+			logger.Errorf("failed to reload deny list: error parse config file %s: %v", configFile, err)
+			continue
+		}
+		denyList.Load(c.DenyList.Subjects, c.DenyList.Emails)
+		logger.Infof("reloaded deny list: %d subjects, %d emails", len(c.DenyList.Subjects), len(c.DenyList.Emails))
+	}
+}
+
 func newLogger(level string, format string) (log.Logger, error) {
 	var logLevel logrus.Level
 	switch strings.ToLower(level) {
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunServeInvalidConnectorLogLevel(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	rawConfig := []byte(`
+issuer: http://127.0.0.1:5556/dex
+storage:
+  type: memory
+web:
+  http: 127.0.0.1:0
+connectors:
+- type: mockCallback
+  id: mock
+  name: Example
+  logLevel: verbose
+  config: {}
+`)
+	if err := os.WriteFile(configFile, rawConfig, 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	err := runServe(serveOptions{config: configFile})
+	if err == nil {
+		t.Fatal("expected an invalid connector log level to be rejected")
+	}
+	if !strings.Contains(err.Error(), `connector "mock"`) {
+		t.Errorf("expected error to name the connector, got %q", err)
+	}
+}
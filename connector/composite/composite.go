@@ -0,0 +1,244 @@
+// Package composite implements a connector which authenticates users through
+// one connector and looks up their groups through another.
+package composite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// Keys of the identity attributes groups can be looked up by.
+const (
+	KeyUserID            = "userID"
+	KeyUsername          = "username"
+	KeyPreferredUsername = "preferredUsername"
+	KeyEmail             = "email"
+)
+
+// Subconnector is the configuration of a connector composed by a composite
+// connector.
+type Subconnector struct {
+	Type   string          `json:"type"`
+	Config json.RawMessage `json:"config"`
+}
+
+// Config holds the configuration parameters for a composite connector.
+//
+// An example config:
+//
+//	type: composite
+//	config:
+//	  primary:
+//	    type: saml
+//	    config:
+//	      ...
+//	  groups:
+//	    type: ldap
+//	    config:
+//	      ...
+//	  groupsKey: userID
+type Config struct {
+	// Primary authenticates users.
+	Primary Subconnector `json:"primary"`
+
+	// Groups looks up the groups of users authenticated by the primary
+	// connector. It must support group lookups, like the LDAP connector.
+	Groups Subconnector `json:"groups"`
+
+	// GroupsKey is the attribute of the primary identity the groups are looked
+	// up by: "userID" (the default, the NameID for SAML), "username",
+	// "preferredUsername" or "email".
+	GroupsKey string `json:"groupsKey"`
+
+	// OpenConnector opens the composed connectors. It is set by the server.
+	OpenConnector func(typ string, config []byte, id string, logger log.Logger) (connector.Connector, error) `json:"-"`
+}
+
+// Open returns a connector which takes the identity of users from the primary
+// connector and their groups from the groups connector.
+func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
+	if c.OpenConnector == nil {
+		return nil, errors.New("composite: no way to open connectors")
+	}
+	if c.Primary.Type == "" || c.Groups.Type == "" {
+		return nil, errors.New("composite: primary and groups connectors are required")
+	}
+
+	groupsKey := c.GroupsKey
+	switch groupsKey {
+	case "":
+		groupsKey = KeyUserID
+	case KeyUserID, KeyUsername, KeyPreferredUsername, KeyEmail:
+	default:
+		return nil, fmt.Errorf("composite: unknown groupsKey %q", groupsKey)
+	}
+
+	primary, err := c.OpenConnector(c.Primary.Type, c.Primary.Config, id, logger)
+	if err != nil {
+		return nil, fmt.Errorf("composite: primary connector: %v", err)
+	}
+	conn, err := c.OpenConnector(c.Groups.Type, c.Groups.Config, id, logger)
+	if err != nil {
+		return nil, fmt.Errorf("composite: groups connector: %v", err)
+	}
+	groups, ok := conn.(connector.GroupsConnector)
+	if !ok {
+		return nil, fmt.Errorf("composite: connector type %q does not support group lookups", c.Groups.Type)
+	}
+
+	base := &compositeConnector{
+		primary:   primary,
+		groups:    groups,
+		groupsKey: groupsKey,
+		logger:    logger,
+	}
+
+	// The server handles logins depending on the type of the connector, so the
+	// composite connector has to be of the same kind as the primary.
+	switch primary := primary.(type) {
+	case connector.StatefulCallbackConnector:
+		return &statefulCallbackConnector{&callbackConnector{base, primary}, primary}, nil
+	case connector.CallbackConnector:
+		return &callbackConnector{base, primary}, nil
+	case connector.PasswordConnector:
+		return &passwordConnector{base, primary}, nil
+	case connector.SAMLConnector:
+		return &samlConnector{base, primary}, nil
+	default:
+		return nil, fmt.Errorf("composite: unsupported primary connector type %q", c.Primary.Type)
+	}
+}
+
+var (
+	_ connector.CallbackConnector         = (*callbackConnector)(nil)
+	_ connector.RefreshConnector          = (*callbackConnector)(nil)
+	_ connector.StatefulCallbackConnector = (*statefulCallbackConnector)(nil)
+	_ connector.PasswordConnector         = (*passwordConnector)(nil)
+	_ connector.RefreshConnector          = (*passwordConnector)(nil)
+	_ connector.SAMLConnector             = (*samlConnector)(nil)
+	_ connector.RefreshConnector          = (*samlConnector)(nil)
+)
+
+type compositeConnector struct {
+	primary   connector.Connector
+	groups    connector.GroupsConnector
+	groupsKey string
+	logger    log.Logger
+}
+
+// withGroups replaces the groups of the identity by the ones of the groups
+// connector.
+func (c *compositeConnector) withGroups(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	if !s.Groups {
+		return identity, nil
+	}
+
+	var key string
+	switch c.groupsKey {
+	case KeyUserID:
+		key = identity.UserID
+	case KeyUsername:
+		key = identity.Username
+	case KeyPreferredUsername:
+		key = identity.PreferredUsername
+	case KeyEmail:
+		key = identity.Email
+	}
+	if key == "" {
+		return identity, fmt.Errorf("composite: identity has no %s to look up groups by", c.groupsKey)
+	}
+
+	groups, err := c.groups.Groups(ctx, key)
+	if err != nil {
+		return identity, fmt.Errorf("composite: failed to look up groups: %v", err)
+	}
+	identity.Groups = groups
+	return identity, nil
+}
+
+// Refresh refreshes the identity with the primary connector, if supported, and
+// always looks up the groups again.
+func (c *compositeConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	if primary, ok := c.primary.(connector.RefreshConnector); ok {
+		var err error
+		if identity, err = primary.Refresh(ctx, s, identity); err != nil {
+			return identity, err
+		}
+	}
+	return c.withGroups(ctx, s, identity)
+}
+
+type callbackConnector struct {
+	*compositeConnector
+	primary connector.CallbackConnector
+}
+
+func (c *callbackConnector) LoginURL(s connector.Scopes, callbackURL, state string) (string, error) {
+	return c.primary.LoginURL(s, callbackURL, state)
+}
+
+func (c *callbackConnector) HandleCallback(s connector.Scopes, r *http.Request) (connector.Identity, error) {
+	identity, err := c.primary.HandleCallback(s, r)
+	if err != nil {
+		return identity, err
+	}
+	return c.withGroups(r.Context(), s, identity)
+}
+
+type statefulCallbackConnector struct {
+	*callbackConnector
+	stateful connector.StatefulCallbackConnector
+}
+
+func (c *statefulCallbackConnector) LoginURLWithData(s connector.Scopes, callbackURL, state string) (string, []byte, error) {
+	return c.stateful.LoginURLWithData(s, callbackURL, state)
+}
+
+func (c *statefulCallbackConnector) HandleCallbackWithData(s connector.Scopes, connData []byte, r *http.Request) (connector.Identity, error) {
+	identity, err := c.stateful.HandleCallbackWithData(s, connData, r)
+	if err != nil {
+		return identity, err
+	}
+	return c.withGroups(r.Context(), s, identity)
+}
+
+type passwordConnector struct {
+	*compositeConnector
+	primary connector.PasswordConnector
+}
+
+func (c *passwordConnector) Prompt() string {
+	return c.primary.Prompt()
+}
+
+func (c *passwordConnector) Login(ctx context.Context, s connector.Scopes, username, password string) (connector.Identity, bool, error) {
+	identity, ok, err := c.primary.Login(ctx, s, username, password)
+	if err != nil || !ok {
+		return identity, ok, err
+	}
+	identity, err = c.withGroups(ctx, s, identity)
+	return identity, err == nil, err
+}
+
+type samlConnector struct {
+	*compositeConnector
+	primary connector.SAMLConnector
+}
+
+func (c *samlConnector) POSTData(s connector.Scopes, requestID string) (ssoURL, samlRequest string, err error) {
+	return c.primary.POSTData(s, requestID)
+}
+
+func (c *samlConnector) HandlePOST(s connector.Scopes, samlResponse, inResponseTo string) (connector.Identity, error) {
+	identity, err := c.primary.HandlePOST(s, samlResponse, inResponseTo)
+	if err != nil {
+		return identity, err
+	}
+	return c.withGroups(context.Background(), s, identity)
+}
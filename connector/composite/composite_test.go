@@ -0,0 +1,146 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/connector/mock"
+	"github.com/dexidp/dex/pkg/log"
+)
+
+var logger = &logrus.Logger{Out: os.Stderr, Formatter: &logrus.TextFormatter{DisableColors: true}, Level: logrus.DebugLevel}
+
+type fakeGroups struct {
+	groups map[string][]string
+	keys   []string
+}
+
+func (f *fakeGroups) Groups(ctx context.Context, key string) ([]string, error) {
+	f.keys = append(f.keys, key)
+	groups, ok := f.groups[key]
+	if !ok {
+		return nil, errors.New("no such user")
+	}
+	return groups, nil
+}
+
+type fakePassword struct {
+	identity connector.Identity
+}
+
+func (f *fakePassword) Prompt() string { return "" }
+
+func (f *fakePassword) Login(ctx context.Context, s connector.Scopes, username, password string) (connector.Identity, bool, error) {
+	return f.identity, password == "secret", nil
+}
+
+func newConfig(primary connector.Connector, groups connector.Connector) *Config {
+	return &Config{
+		Primary: Subconnector{Type: "primary"},
+		Groups:  Subconnector{Type: "groups"},
+		OpenConnector: func(typ string, config []byte, id string, logger log.Logger) (connector.Connector, error) {
+			switch typ {
+			case "primary":
+				return primary, nil
+			case "groups":
+				return groups, nil
+			}
+			return nil, errors.New("unknown connector type")
+		},
+	}
+}
+
+func TestCallbackGroups(t *testing.T) {
+	primary := mock.NewCallbackConnector(logger)
+	groups := &fakeGroups{groups: map[string][]string{"0-385-28089-0": {"admins", "ops"}}}
+
+	conn, err := newConfig(primary, groups).Open("composite", logger)
+	require.NoError(t, err)
+	callback, ok := conn.(connector.CallbackConnector)
+	require.True(t, ok, "composite connector of a callback connector must be a callback connector")
+
+	req := httptest.NewRequest("GET", "/callback", nil)
+
+	identity, err := callback.HandleCallback(connector.Scopes{Groups: true}, req)
+	require.NoError(t, err)
+	require.Equal(t, "0-385-28089-0", identity.UserID)
+	require.Equal(t, "Kilgore Trout", identity.Username)
+	require.Equal(t, []string{"admins", "ops"}, identity.Groups)
+	require.Equal(t, []string{"0-385-28089-0"}, groups.keys)
+
+	identity, err = callback.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"authors"}, identity.Groups)
+	require.Len(t, groups.keys, 1, "groups must not be looked up without the groups scope")
+
+	groups.groups["0-385-28089-0"] = []string{"admins"}
+	identity, err = conn.(connector.RefreshConnector).Refresh(context.Background(), connector.Scopes{Groups: true}, identity)
+	require.NoError(t, err)
+	require.Equal(t, []string{"admins"}, identity.Groups)
+}
+
+func TestPasswordGroups(t *testing.T) {
+	primary := &fakePassword{identity: connector.Identity{
+		UserID:   "0-385-28089-0",
+		Username: "Kilgore Trout",
+		Email:    "kilgore@kilgore.trout",
+	}}
+	groups := &fakeGroups{groups: map[string][]string{"kilgore@kilgore.trout": {"admins"}}}
+
+	c := newConfig(primary, groups)
+	c.GroupsKey = KeyEmail
+	conn, err := c.Open("composite", logger)
+	require.NoError(t, err)
+	password, ok := conn.(connector.PasswordConnector)
+	require.True(t, ok, "composite connector of a password connector must be a password connector")
+
+	identity, ok, err := password.Login(context.Background(), connector.Scopes{Groups: true}, "kilgore", "secret")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "0-385-28089-0", identity.UserID)
+	require.Equal(t, []string{"admins"}, identity.Groups)
+
+	_, ok, err = password.Login(context.Background(), connector.Scopes{Groups: true}, "kilgore", "wrong")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Len(t, groups.keys, 1, "groups must not be looked up for failed logins")
+
+	delete(groups.groups, "kilgore@kilgore.trout")
+	_, ok, err = password.Login(context.Background(), connector.Scopes{Groups: true}, "kilgore", "secret")
+	require.Error(t, err)
+	require.False(t, ok)
+}
+
+func TestOpen(t *testing.T) {
+	primary := mock.NewCallbackConnector(logger)
+	groups := &fakeGroups{}
+
+	tests := map[string]*Config{
+		"no groups connector": {Primary: Subconnector{Type: "primary"}, OpenConnector: newConfig(primary, groups).OpenConnector},
+		"unknown groups key": func() *Config {
+			c := newConfig(primary, groups)
+			c.GroupsKey = "sub"
+			return c
+		}(),
+		"no group lookups": newConfig(primary, primary),
+		"unknown type": func() *Config {
+			c := newConfig(primary, groups)
+			c.Groups.Type = "github"
+			return c
+		}(),
+		"no opener": {Primary: Subconnector{Type: "primary"}, Groups: Subconnector{Type: "groups"}},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Open("composite", logger)
+			require.Error(t, err)
+		})
+	}
+}
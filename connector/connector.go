@@ -3,7 +3,9 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"time"
 )
 
 // Connector is a mechanism for federating login to a remote identity service.
@@ -19,6 +21,30 @@ type Scopes struct {
 
 	// The client has requested group information about the end user.
 	Groups bool
+
+	// The client has requested the organizations the end user is a member of.
+	Organizations bool
+
+	// The client has asked for the end user to be able to pick a different
+	// account (prompt=select_account).
+	SelectAccount bool
+
+	// The client has asked for the end user to be logged in without any
+	// interaction (prompt=none).
+	PromptNone bool
+
+	// The authentication context class references the client requested
+	// (acr_values), in order of preference.
+	ACRValues []string
+
+	// The maximum time in seconds since the end user last actively
+	// authenticated (max_age), or nil if the client didn't set one.
+	MaxAge *int
+
+	// The scopes the client has asked the connector to request from the
+	// upstream provider, in addition to the configured ones. Connectors must
+	// only request the ones the operator allows.
+	UpstreamScopes []string
 }
 
 // Identity represents the ID Token claims supported by the server.
@@ -31,6 +57,23 @@ type Identity struct {
 
 	Groups []string
 
+	// Organizations the user is a member of, for connectors which tell them
+	// apart from groups.
+	Organizations []string
+
+	// Address is the postal address of the user, for connectors configured
+	// to map it from upstream attributes.
+	Address *Address
+
+	// CustomClaims are added to the tokens of the user, for connectors
+	// configured to emit claims the fields above don't cover. They never
+	// replace the standard claims of the tokens.
+	CustomClaims map[string]interface{}
+
+	// AuthTime is the time the user authenticated, for example the auth_time
+	// claim of an upstream provider. If unset, the time of the login is used.
+	AuthTime time.Time
+
 	// ConnectorData holds data used by the connector for subsequent requests after initial
 	// authentication, such as access tokens for upstream provides.
 	//
@@ -38,6 +81,19 @@ type Identity struct {
 	ConnectorData []byte
 }
 
+// Address is the postal address of a user, as described by the OpenID Connect
+// standard claims.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#AddressClaim
+type Address struct {
+	Formatted     string
+	StreetAddress string
+	Locality      string
+	Region        string
+	PostalCode    string
+	Country       string
+}
+
 // PasswordConnector is an interface implemented by connectors which take a
 // username and password.
 // Prompt() is used to inform the handler what to display in the password
@@ -47,6 +103,11 @@ type PasswordConnector interface {
 	Login(ctx context.Context, s Scopes, username, password string) (identity Identity, validPassword bool, err error)
 }
 
+// StateSeparator separates the state the server passes to LoginURL from the
+// data connectors append to it, like the time the login started at. The states
+// of the server never contain it.
+const StateSeparator = "."
+
 // CallbackConnector is an interface implemented by connectors which use an OAuth
 // style redirect flow to determine user information.
 type CallbackConnector interface {
@@ -63,12 +124,43 @@ type CallbackConnector interface {
 	// requested if one has already been issues. There's no good general answer
 	// for these kind of restrictions, and may require this package to become more
 	// aware of the global set of user/connector interactions.
+	//
+	// Connectors may append data to the state sent to the provider after a
+	// StateSeparator, which the server ignores when handling the callback.
 	LoginURL(s Scopes, callbackURL, state string) (string, error)
 
 	// Handle the callback to the server and return an identity.
 	HandleCallback(s Scopes, r *http.Request) (identity Identity, err error)
 }
 
+// StatefulCallbackConnector is a CallbackConnector which needs data from the
+// login redirect when handling the callback, for example a PKCE code verifier.
+// The server stores the data with the auth request, so the callback may be
+// handled by another server instance.
+type StatefulCallbackConnector interface {
+	CallbackConnector
+
+	// LoginURLWithData is used instead of LoginURL, and also returns the data
+	// to pass to HandleCallbackWithData.
+	LoginURLWithData(s Scopes, callbackURL, state string) (loginURL string, connData []byte, err error)
+
+	// HandleCallbackWithData is used instead of HandleCallback.
+	HandleCallbackWithData(s Scopes, connData []byte, r *http.Request) (identity Identity, err error)
+}
+
+// HintedCallbackConnector is a CallbackConnector which can add parameters
+// varying per login to the login URL, like the login_hint the client sent.
+type HintedCallbackConnector interface {
+	CallbackConnector
+
+	// LoginURLWithHints is used instead of LoginURL, or LoginURLWithData for
+	// a StatefulCallbackConnector, when there are hints. The returned data
+	// is passed to HandleCallbackWithData, and must be nil for connectors
+	// which aren't a StatefulCallbackConnector. Hints the connector can't add,
+	// for example because it sets the parameter itself, fail the login.
+	LoginURLWithHints(s Scopes, callbackURL, state string, hints map[string]string) (loginURL string, connData []byte, err error)
+}
+
 // SAMLConnector represents SAML connectors which implement the HTTP POST binding.
 //  RelayState is handled by the server.
 //
@@ -91,6 +183,52 @@ type SAMLConnector interface {
 	HandlePOST(s Scopes, samlResponse, inResponseTo string) (identity Identity, err error)
 }
 
+// LogoutConnector is implemented by connectors which can end the session of a
+// user with the upstream provider when the user logs out of dex.
+type LogoutConnector interface {
+	// LogoutURL returns the URL to redirect the user to in order to log out of
+	// the upstream provider, or an empty string if the user isn't logged out
+	// upstream. The identity holds the user ID and, if the server has it, the
+	// connector data of the user.
+	//
+	// The provider sends the user back to the logout callback of the server,
+	// which passes the request to HandleLogoutResponse. The connector must
+	// carry returnURL through the logout, for example in the SAML RelayState.
+	LogoutURL(identity Identity, returnURL string) (string, error)
+
+	// HandleLogoutResponse verifies the response of the provider to the
+	// logout, and returns the returnURL passed to LogoutURL. The server doesn't
+	// trust the returned URL, it only redirects to its own logout endpoint.
+	HandleLogoutResponse(r *http.Request) (returnURL string, err error)
+}
+
+// BackChannelLogoutConnector is implemented by connectors whose upstream
+// provider notifies the server when users log out of it, so the server revokes
+// the refresh tokens of their sessions.
+//
+// See: https://openid.net/specs/openid-connect-backchannel-1_0.html
+type BackChannelLogoutConnector interface {
+	// HandleBackChannelLogout verifies the logout notification the provider
+	// sent to the server, and returns the identity of the logged out session,
+	// which the server only passes to InSession. Connectors whose user IDs
+	// differ from the upstream ones identify the user in the connector data.
+	HandleBackChannelLogout(r *http.Request) (loggedOut Identity, err error)
+
+	// InSession reports whether a login, with the user ID and the connector
+	// data the server keeps for it, belongs to the logged out session.
+	InSession(loggedOut, identity Identity) bool
+}
+
+// SAMLMetadataConnector is implemented by SAML connectors which describe dex
+// as a service provider in SAML metadata, so it can be imported by the
+// identity provider.
+type SAMLMetadataConnector interface {
+	// Metadata returns the EntityDescriptor XML of dex. The logoutCallbackURL
+	// is the logout callback of the server for the connector, its single
+	// logout service.
+	Metadata(logoutCallbackURL string) ([]byte, error)
+}
+
 // RefreshConnector is a connector that can update the client claims.
 type RefreshConnector interface {
 	// Refresh is called when a client attempts to claim a refresh token. The
@@ -98,3 +236,50 @@ type RefreshConnector interface {
 	// changes since the token was last refreshed.
 	Refresh(ctx context.Context, s Scopes, identity Identity) (Identity, error)
 }
+
+// ErrReauthenticate is returned, possibly wrapped, by Refresh when the upstream
+// provider no longer accepts the user's session, for example because the
+// upstream refresh token was revoked. The user has to log in again.
+var ErrReauthenticate = errors.New("upstream session is no longer valid")
+
+// InteractionRequiredError is returned, possibly wrapped, by HandleCallback
+// when the upstream provider couldn't log in the user silently, as requested
+// with prompt=none. The client has to fall back to an interactive login.
+type InteractionRequiredError struct {
+	// Code is the error of the upstream provider, for example
+	// "login_required" or "interaction_required".
+	Code        string
+	Description string
+}
+
+func (e *InteractionRequiredError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// GroupsConnector is a connector that can look up the groups of a user who did
+// not log in through it.
+type GroupsConnector interface {
+	// Groups returns the groups of the user identified by key, for example a
+	// username.
+	Groups(ctx context.Context, key string) ([]string, error)
+}
+
+// KeySetCache persists the signing key sets fetched from upstream providers,
+// so that connectors can verify tokens while their provider is unreachable,
+// for example at startup.
+type KeySetCache interface {
+	// GetKeySet returns the cached JSON Web Key Set of the connector, or nil
+	// if none is cached.
+	GetKeySet(connectorID string) ([]byte, error)
+	// SetKeySet caches the JSON Web Key Set of the connector.
+	SetKeySet(connectorID string, keySet []byte) error
+}
+
+// KeySetCacheConfig is implemented by the configs of connectors which can
+// cache the key sets of their upstream provider.
+type KeySetCacheConfig interface {
+	SetKeySetCache(cache KeySetCache)
+}
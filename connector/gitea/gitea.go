@@ -163,8 +163,8 @@ func (c *giteaConnector) HandleCallback(s connector.Scopes, r *http.Request) (id
 	}
 
 	// Only set identity.Groups if 'orgs', 'org', or 'groups' scope are specified.
-	if c.groupsRequired() {
-		groups, err := c.getGroups(ctx, client)
+	if c.groupsRequired(s.Groups) {
+		groups, err := c.getGroups(ctx, client, s.Groups)
 		if err != nil {
 			return identity, err
 		}
@@ -263,8 +263,8 @@ func (c *giteaConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 	ident.Email = user.Email
 
 	// Only set identity.Groups if 'orgs', 'org', or 'groups' scope are specified.
-	if c.groupsRequired() {
-		groups, err := c.getGroups(ctx, client)
+	if c.groupsRequired(s.Groups) {
+		groups, err := c.getGroups(ctx, client, s.Groups)
 		if err != nil {
 			return ident, err
 		}
@@ -275,10 +275,10 @@ func (c *giteaConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 }
 
 // getGroups retrieves Gitea orgs and teams a user is in, if any.
-func (c *giteaConnector) getGroups(ctx context.Context, client *http.Client) ([]string, error) {
+func (c *giteaConnector) getGroups(ctx context.Context, client *http.Client, groupScope bool) ([]string, error) {
 	if len(c.orgs) > 0 {
 		return c.groupsForOrgs(ctx, client)
-	} else if c.loadAllGroups {
+	} else if groupScope && c.loadAllGroups {
 		return c.userGroups(ctx, client)
 	}
 	return nil, nil
@@ -332,35 +332,46 @@ type team struct {
 	Organization *organization `json:"organization"`
 }
 
-func (c *giteaConnector) userGroups(ctx context.Context, client *http.Client) ([]string, error) {
-	apiURL := c.baseURL + "/api/v1/user/teams"
-	groups := make([]string, 0)
-	page := 1
-	limit := 20
-	for {
-		var teams []team
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s?page=%d&limit=%d", apiURL, page, limit), nil)
-		if err != nil {
-			return groups, fmt.Errorf("gitea: new req: %v", err)
-		}
+// pageLimit is the number of results requested per page of API results.
+const pageLimit = 20
+
+// getPage queries a page of the results of the Gitea API endpoint, decoding
+// them into v.
+func (c *giteaConnector) getPage(ctx context.Context, client *http.Client, apiURL string, page int, v interface{}) error {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?page=%d&limit=%d", apiURL, page, pageLimit), nil)
+	if err != nil {
+		return fmt.Errorf("gitea: new req: %v", err)
+	}
 
-		req = req.WithContext(ctx)
-		resp, err := client.Do(req)
+	req = req.WithContext(ctx)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gitea: get URL %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return groups, fmt.Errorf("gitea: get URL %v", err)
+			return fmt.Errorf("gitea: read body: %v", err)
 		}
-		defer resp.Body.Close()
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return groups, fmt.Errorf("gitea: read body: %v", err)
-			}
-			return groups, fmt.Errorf("%s: %s", resp.Status, body)
-		}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
 
-		if err := json.NewDecoder(resp.Body).Decode(&teams); err != nil {
-			return groups, fmt.Errorf("failed to decode response: %v", err)
+// userGroups returns the orgs the user is a member of, and their teams the
+// user is a member of as "org:team" groups.
+func (c *giteaConnector) userGroups(ctx context.Context, client *http.Client) ([]string, error) {
+	groups := make([]string, 0)
+	for page := 1; ; page++ {
+		var teams []team
+		if err := c.getPage(ctx, client, c.baseURL+"/api/v1/user/teams", page, &teams); err != nil {
+			return groups, err
 		}
 
 		if len(teams) == 0 {
@@ -371,8 +382,23 @@ func (c *giteaConnector) userGroups(ctx context.Context, client *http.Client) ([
 			groups = append(groups, t.Organization.Name)
 			groups = append(groups, formatTeamName(t.Organization.Name, t.Name))
 		}
+	}
+
+	// Members of orgs aren't necessarily in any of their teams the user can
+	// see.
+	for page := 1; ; page++ {
+		var orgs []organization
+		if err := c.getPage(ctx, client, c.baseURL+"/api/v1/user/orgs", page, &orgs); err != nil {
+			return groups, err
+		}
 
-		page++
+		if len(orgs) == 0 {
+			break
+		}
+
+		for _, o := range orgs {
+			groups = append(groups, o.Name)
+		}
 	}
 
 	// remove duplicate slice variables
@@ -419,6 +445,6 @@ func (c *giteaConnector) user(ctx context.Context, client *http.Client) (giteaUs
 }
 
 // groupsRequired returns whether dex needs to request groups from Gitea.
-func (c *giteaConnector) groupsRequired() bool {
-	return len(c.orgs) > 0 || c.loadAllGroups
+func (c *giteaConnector) groupsRequired(groupScope bool) bool {
+	return len(c.orgs) > 0 || (groupScope && c.loadAllGroups)
 }
@@ -44,6 +44,52 @@ func TestUsernameIncludedInFederatedIdentity(t *testing.T) {
 	expectEquals(t, identity.UserID, "12345678")
 }
 
+func TestUserGroups(t *testing.T) {
+	s := newTestServer(map[string]interface{}{
+		"/api/v1/user": giteaUser{Email: "some@email.com", ID: 12345678},
+		"/login/oauth/access_token": map[string]interface{}{
+			"access_token": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9",
+			"expires_in":   "30",
+		},
+		"/api/v1/user/teams?page=1&limit=20": []team{
+			{Name: "team-1", Organization: &organization{Name: "org-1"}},
+			{Name: "team-2", Organization: &organization{Name: "org-1"}},
+		},
+		"/api/v1/user/teams?page=2&limit=20": []team{
+			{Name: "team-3", Organization: &organization{Name: "org-2"}},
+		},
+		"/api/v1/user/orgs?page=1&limit=20": []organization{{Name: "org-1"}},
+		"/api/v1/user/orgs?page=2&limit=20": []organization{{Name: "org-3"}},
+	})
+	defer s.Close()
+
+	req, err := http.NewRequest("GET", s.URL, nil)
+	expectNil(t, err)
+
+	c := giteaConnector{baseURL: s.URL, httpClient: newClient(), loadAllGroups: true}
+	identity, err := c.HandleCallback(connector.Scopes{Groups: true}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.Groups, []string{
+		"org-1",
+		"org-1:team-1",
+		"org-1:team-2",
+		"org-2",
+		"org-2:team-3",
+		"org-3",
+	})
+
+	// Groups are only loaded for the groups scope.
+	identity, err = c.HandleCallback(connector.Scopes{}, req)
+	expectNil(t, err)
+	expectEquals(t, len(identity.Groups), 0)
+
+	// Orgs the user isn't in any team of still authorize the user.
+	c = giteaConnector{baseURL: s.URL, httpClient: newClient(), orgs: []Org{{Name: "org-3"}}}
+	identity, err = c.HandleCallback(connector.Scopes{}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.Groups, []string{"org-3"})
+}
+
 func newTestServer(responses map[string]interface{}) *httptest.Server {
 	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := responses[r.RequestURI]
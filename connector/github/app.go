@@ -0,0 +1,255 @@
+package github
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// appTokenRefreshMargin is how long before they expire installation tokens
+// are replaced. GitHub issues them for an hour.
+const appTokenRefreshMargin = 5 * time.Minute
+
+// githubApp authenticates API requests as an installation of a GitHub App,
+// with installation access tokens it caches until shortly before they expire.
+//
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+type githubApp struct {
+	apiURL         string
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	// client sends the requests for installation tokens.
+	client *http.Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func newGitHubApp(apiURL string, appID, installationID int64, keyFile string, client *http.Client) (*githubApp, error) {
+	if appID == 0 || installationID == 0 || keyFile == "" {
+		return nil, errors.New("appID, installationID and appPrivateKeyFile must be set together")
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app private key: %v", err)
+	}
+	key, err := parseAppPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app private key %q: %v", keyFile, err)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &githubApp{
+		apiURL:         apiURL,
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		client:         client,
+		now:            time.Now,
+	}, nil
+}
+
+// parseAppPrivateKey parses the PEM encoded RSA key of a GitHub App. GitHub
+// issues PKCS #1 keys, which may have been converted to PKCS #8.
+func parseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// httpClient returns a client sending requests with installation tokens,
+// through the transport of the base client if set.
+func (a *githubApp) httpClient(base *http.Client) *http.Client {
+	var transport http.RoundTripper
+	if base != nil {
+		transport = base.Transport
+	}
+	return &http.Client{Transport: &oauth2.Transport{Source: a, Base: transport}}
+}
+
+// Token returns the cached installation token, or requests a new one if it
+// expires soon.
+func (a *githubApp) Token() (*oauth2.Token, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.token != nil && now.Add(appTokenRefreshMargin).Before(a.token.Expiry) {
+		return a.token, nil
+	}
+	token, err := a.installationToken(now)
+	if err != nil {
+		return nil, fmt.Errorf("github: get installation token: %v", err)
+	}
+	a.token = token
+	return token, nil
+}
+
+// appJWT returns the JWT authenticating requests as the app itself.
+func (a *githubApp) appJWT(now time.Time) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: a.key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		// Backdated for clock drift, as GitHub recommends.
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return jws.CompactSerialize()
+}
+
+// installationToken exchanges a JWT of the app for an installation token.
+//
+// https://docs.github.com/en/rest/apps/apps#create-an-installation-access-token-for-an-app
+func (a *githubApp) installationToken(now time.Time) (*oauth2.Token, error) {
+	jwt, err := a.appJWT(now)
+	if err != nil {
+		return nil, fmt.Errorf("sign app JWT: %v", err)
+	}
+
+	tokenURL := fmt.Sprintf("%s/app/installations/%s/access_tokens", a.apiURL, url.PathEscape(strconv.FormatInt(a.installationID, 10)))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read body: %v", err)
+		}
+		return nil, fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	var token struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	if token.Token == "" {
+		return nil, errors.New("no token in response")
+	}
+	return &oauth2.Token{AccessToken: token.Token, TokenType: "Bearer", Expiry: token.ExpiresAt}, nil
+}
+
+// teamMembership holds the membership of a user in a team as defined by
+// https://docs.github.com/en/rest/teams/members#get-team-membership-for-a-user
+type teamMembership struct {
+	State string `json:"state"`
+}
+
+// appUserTeamsInOrg returns the teams of the user within a specific
+// organization, as installation tokens can't list the teams of the user: the
+// teams of the organization are listed and the membership of the user checked
+// for each of them.
+func (c *githubConnector) appUserTeamsInOrg(ctx context.Context, client *http.Client, orgName, userName string, slugs []string) ([]team, error) {
+	apiURL, orgTeams := fmt.Sprintf("%s/orgs/%s/teams", c.apiURL, orgName), []team{}
+	for {
+		// https://docs.github.com/en/rest/teams/teams#list-teams
+		var (
+			teams []team
+			err   error
+		)
+		if apiURL, err = get(ctx, client, apiURL, &teams); err != nil {
+			return nil, fmt.Errorf("github: get teams: %v", err)
+		}
+		if len(slugs) > 0 {
+			teams = filterTeamSlugs(teams, slugs)
+		}
+
+		for _, t := range teams {
+			member, err := c.appUserInTeam(ctx, client, orgName, t.Slug, userName)
+			if err != nil {
+				return nil, err
+			}
+			if member {
+				t.Org = org{Login: orgName}
+				orgTeams = append(orgTeams, t)
+			}
+		}
+
+		if apiURL == "" {
+			break
+		}
+	}
+
+	return orgTeams, nil
+}
+
+// appUserInTeam queries the GitHub API for a users' active team membership.
+func (c *githubConnector) appUserInTeam(ctx context.Context, client *http.Client, orgName, teamSlug, userName string) (bool, error) {
+	apiURL := fmt.Sprintf("%s/orgs/%s/teams/%s/memberships/%s", c.apiURL, orgName, teamSlug, userName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("github: new req: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("github: get team membership: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("github: get team membership: unexpected return status: %q", resp.Status)
+	}
+	var membership teamMembership
+	if err := json.NewDecoder(resp.Body).Decode(&membership); err != nil {
+		return false, fmt.Errorf("github: failed to decode team membership: %v", err)
+	}
+	// Pending members haven't accepted the invitation yet.
+	return membership.State == "active", nil
+}
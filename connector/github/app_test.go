@@ -0,0 +1,219 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// appTestServer issues installation tokens to the app, and answers org and
+// team lookups authenticated with the latest of them. Its clock is ahead of
+// the real time by the offset.
+type appTestServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	tokenRequests int
+	offset        time.Duration
+}
+
+func newAppTestServer(t *testing.T, key *rsa.PrivateKey) *appTestServer {
+	s := &appTestServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		jws, err := jose.ParseSigned(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		payload, err := jws.Verify(&key.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		var claims struct {
+			Issuer string `json:"iss"`
+		}
+		if err := json.Unmarshal(payload, &claims); err != nil || claims.Issuer != "7" || r.Method != http.MethodPost {
+			http.Error(w, "unexpected app JWT", http.StatusUnauthorized)
+			return
+		}
+
+		s.mu.Lock()
+		s.tokenRequests++
+		token := fmt.Sprintf("installation-token-%d", s.tokenRequests)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      token,
+			"expires_at": s.now().Add(time.Hour).Format(time.RFC3339),
+		})
+	})
+
+	responses := map[string]testResponse{
+		"/orgs/acme/members/jane": {status: http.StatusNoContent},
+		"/orgs/acme/teams": {data: []team{
+			{Name: "Platform", Slug: "platform"},
+			{Name: "Web", Slug: "web"},
+			{Name: "Data", Slug: "data"},
+		}},
+		"/orgs/acme/teams/platform/memberships/jane": {data: teamMembership{State: "active"}},
+		"/orgs/acme/teams/web/memberships/jane":      {data: teamMembership{State: "pending"}},
+		"/orgs/acme/teams/data/memberships/jane":     {status: http.StatusNotFound},
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		token := fmt.Sprintf("Bearer installation-token-%d", s.tokenRequests)
+		s.mu.Unlock()
+		if r.Header.Get("Authorization") != token {
+			http.Error(w, "not authenticated as the installation", http.StatusUnauthorized)
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if response.status != 0 {
+			w.WriteHeader(response.status)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response.data)
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *appTestServer) now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Add(s.offset)
+}
+
+func (s *appTestServer) advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+func (s *appTestServer) requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokenRequests
+}
+
+func writeAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return key, keyFile
+}
+
+func TestAppInstallationGroups(t *testing.T) {
+	key, keyFile := writeAppKey(t)
+	s := newAppTestServer(t, key)
+	defer s.Close()
+
+	app, err := newGitHubApp(s.URL, 7, 42, keyFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.now = s.now
+	c := githubConnector{
+		apiURL:    s.URL,
+		logger:    logrus.New(),
+		orgs:      []Org{{Name: "acme"}},
+		appClient: app.httpClient(nil),
+	}
+
+	// The user's token isn't used for the lookups.
+	userClient := &http.Client{}
+	groups, err := c.getGroups(context.Background(), userClient, false, "jane")
+	expectNil(t, err)
+	expectEquals(t, groups, []string{"acme:Platform"})
+	expectEquals(t, s.requests(), 1)
+
+	// The installation token is cached until shortly before it expires.
+	c.orgs = []Org{{Name: "acme", TeamSlugs: []string{"platform", "data"}}}
+	groups, err = c.getGroups(context.Background(), userClient, false, "jane")
+	expectNil(t, err)
+	expectEquals(t, groups, []string{"acme:Platform"})
+	expectEquals(t, s.requests(), 1)
+
+	s.advance(time.Hour - appTokenRefreshMargin + time.Minute)
+	_, err = c.getGroups(context.Background(), userClient, false, "jane")
+	expectNil(t, err)
+	expectEquals(t, s.requests(), 2)
+}
+
+func TestAppConfig(t *testing.T) {
+	_, keyFile := writeAppKey(t)
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: Config{Org: "acme", AppID: 7, InstallationID: 42, AppPrivateKeyFile: keyFile},
+		},
+		{
+			name:    "no orgs",
+			config:  Config{AppID: 7, InstallationID: 42, AppPrivateKeyFile: keyFile},
+			wantErr: true,
+		},
+		{
+			name:    "no installation",
+			config:  Config{Orgs: []Org{{Name: "acme"}}, AppID: 7, AppPrivateKeyFile: keyFile},
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			config:  Config{Orgs: []Org{{Name: "acme"}}, AppID: 7, InstallationID: 42, AppPrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := tc.config.Open("github", logrus.New())
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The user's token doesn't need to read orgs.
+			scopes := conn.(*githubConnector).oauth2Config(connector.Scopes{Groups: true}).Scopes
+			expectEquals(t, scopes, []string{scopeEmail})
+		})
+	}
+}
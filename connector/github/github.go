@@ -53,6 +53,25 @@ type Config struct {
 	TeamNameField string `json:"teamNameField"`
 	LoadAllGroups bool   `json:"loadAllGroups"`
 	UseLoginAsID  bool   `json:"useLoginAsID"`
+
+	// EmitOrganizations returns the orgs of the user as organizations,
+	// separate from the groups, to clients requesting the "organizations"
+	// scope.
+	EmitOrganizations bool `json:"emitOrganizations"`
+
+	// RootCAs are PEM files of the certificate authorities trusted, along
+	// with rootCA, for the TLS connections to the API and OAuth endpoints of
+	// the GitHub Enterprise host. Requires hostName.
+	RootCAs []string `json:"rootCAs"`
+
+	// AppID, InstallationID and AppPrivateKeyFile authenticate the lookups of
+	// the orgs and teams of users as an installation of a GitHub App, with
+	// the "Members" organization permission, rather than as the users. The
+	// OAuth tokens of the users are then only used for their identity, and
+	// don't need the "read:org" scope. Requires 'org' or 'orgs'.
+	AppID             int64  `json:"appID"`
+	InstallationID    int64  `json:"installationID"`
+	AppPrivateKeyFile string `json:"appPrivateKeyFile"`
 }
 
 // Org holds org-team filters, in which teams are optional.
@@ -66,6 +85,11 @@ type Org struct {
 	// in the organization can authenticate if this field is omitted from the
 	// config file.
 	Teams []string `json:"teams,omitempty"`
+
+	// Slugs of teams in a github organization. Like teams, users must be
+	// members of at least one of these teams to authenticate, and only these
+	// teams are included in the groups claim, whatever the teamNameField is.
+	TeamSlugs []string `json:"teamSlugs,omitempty"`
 }
 
 // Open returns a strategy for logging in through GitHub.
@@ -87,6 +111,8 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		apiURL:       apiURL,
 		logger:       logger,
 		useLoginAsID: c.UseLoginAsID,
+
+		emitOrganizations: c.EmitOrganizations,
 	}
 
 	if c.HostName != "" {
@@ -100,13 +126,16 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 	}
 
 	if c.RootCA != "" {
+		g.rootCAs = append(g.rootCAs, c.RootCA)
+	}
+	g.rootCAs = append(g.rootCAs, c.RootCAs...)
+	if len(g.rootCAs) > 0 {
 		if c.HostName == "" {
 			return nil, errors.New("invalid connector config: Host name field required for a root certificate file")
 		}
-		g.rootCA = c.RootCA
 
 		var err error
-		if g.httpClient, err = newHTTPClient(g.rootCA); err != nil {
+		if g.httpClient, err = newHTTPClient(g.rootCAs); err != nil {
 			return nil, fmt.Errorf("failed to create HTTP client: %v", err)
 		}
 	}
@@ -119,6 +148,17 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		return nil, fmt.Errorf("invalid connector config: unsupported team name field value `%s`", c.TeamNameField)
 	}
 
+	if c.AppID != 0 || c.InstallationID != 0 || c.AppPrivateKeyFile != "" {
+		if c.Org == "" && len(c.Orgs) == 0 {
+			return nil, errors.New("invalid connector config: a GitHub App requires 'org' or 'orgs'")
+		}
+		app, err := newGitHubApp(g.apiURL, c.AppID, c.InstallationID, c.AppPrivateKeyFile, g.httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connector config: %v", err)
+		}
+		g.appClient = app.httpClient(g.httpClient)
+	}
+
 	return &g, nil
 }
 
@@ -144,8 +184,8 @@ type githubConnector struct {
 	// hostName of the GitHub enterprise account.
 	hostName string
 	// Used to support untrusted/self-signed CA certs.
-	rootCA string
-	// HTTP Client that trusts the custom declared rootCA cert.
+	rootCAs []string
+	// HTTP Client that trusts the custom declared rootCA certs.
 	httpClient *http.Client
 	// optional choice between 'name' (default) or 'slug'
 	teamNameField string
@@ -153,6 +193,11 @@ type githubConnector struct {
 	loadAllGroups bool
 	// if set to true will use the user's handle rather than their numeric id as the ID
 	useLoginAsID bool
+	// if set to true the user's orgs are returned as organizations
+	emitOrganizations bool
+	// HTTP Client authenticated as a GitHub App installation, which looks up
+	// the orgs and teams of users if set.
+	appClient *http.Client
 }
 
 // groupsRequired returns whether dex requires GitHub's 'read:org' scope. Dex
@@ -162,11 +207,17 @@ func (c *githubConnector) groupsRequired(groupScope bool) bool {
 	return len(c.orgs) > 0 || c.org != "" || groupScope
 }
 
+// organizationsRequired returns whether the orgs of the user are returned as
+// organizations.
+func (c *githubConnector) organizationsRequired(organizationsScope bool) bool {
+	return c.emitOrganizations && organizationsScope
+}
+
 func (c *githubConnector) oauth2Config(scopes connector.Scopes) *oauth2.Config {
 	// 'read:org' scope is required by the GitHub API, and thus for dex to ensure
 	// a user is a member of orgs and teams provided in configs.
 	githubScopes := []string{scopeEmail}
-	if c.groupsRequired(scopes.Groups) {
+	if (c.appClient == nil && c.groupsRequired(scopes.Groups)) || c.organizationsRequired(scopes.Organizations) {
 		githubScopes = append(githubScopes, scopeOrgs)
 	}
 
@@ -208,15 +259,17 @@ func (e *oauth2Error) Error() string {
 	return e.error + ": " + e.errorDescription
 }
 
-// newHTTPClient returns a new HTTP client that trusts the custom declared rootCA cert.
-func newHTTPClient(rootCA string) (*http.Client, error) {
+// newHTTPClient returns a new HTTP client that trusts the custom declared rootCA certs.
+func newHTTPClient(rootCAs []string) (*http.Client, error) {
 	tlsConfig := tls.Config{RootCAs: x509.NewCertPool()}
-	rootCABytes, err := os.ReadFile(rootCA)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read root-ca: %v", err)
-	}
-	if !tlsConfig.RootCAs.AppendCertsFromPEM(rootCABytes) {
-		return nil, fmt.Errorf("no certs found in root CA file %q", rootCA)
+	for _, rootCA := range rootCAs {
+		rootCABytes, err := os.ReadFile(rootCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read root-ca: %v", err)
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(rootCABytes) {
+			return nil, fmt.Errorf("no certs found in root CA file %q", rootCA)
+		}
 	}
 
 	return &http.Client{
@@ -272,7 +325,7 @@ func (c *githubConnector) HandleCallback(s connector.Scopes, r *http.Request) (i
 		Username:          username,
 		PreferredUsername: user.Login,
 		Email:             user.Email,
-		EmailVerified:     true,
+		EmailVerified:     user.EmailVerified,
 	}
 	if c.useLoginAsID {
 		identity.UserID = user.Login
@@ -287,6 +340,12 @@ func (c *githubConnector) HandleCallback(s connector.Scopes, r *http.Request) (i
 		identity.Groups = groups
 	}
 
+	if c.organizationsRequired(s.Organizations) {
+		if identity.Organizations, err = c.userOrgs(ctx, client); err != nil {
+			return identity, err
+		}
+	}
+
 	if s.OfflineAccess {
 		data := connectorData{AccessToken: token.AccessToken}
 		connData, err := json.Marshal(data)
@@ -309,6 +368,10 @@ func (c *githubConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 		return identity, fmt.Errorf("github: unmarshal access token: %v", err)
 	}
 
+	// GitHub Enterprise account
+	if c.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	}
 	client := c.oauth2Config(s).Client(ctx, &oauth2.Token{AccessToken: data.AccessToken})
 	user, err := c.user(ctx, client)
 	if err != nil {
@@ -322,6 +385,7 @@ func (c *githubConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 	identity.Username = username
 	identity.PreferredUsername = user.Login
 	identity.Email = user.Email
+	identity.EmailVerified = user.EmailVerified
 
 	// Only set identity.Groups if 'orgs', 'org', or 'groups' scope are specified.
 	if c.groupsRequired(s.Groups) {
@@ -332,16 +396,25 @@ func (c *githubConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 		identity.Groups = groups
 	}
 
+	if c.organizationsRequired(s.Organizations) {
+		if identity.Organizations, err = c.userOrgs(ctx, client); err != nil {
+			return identity, err
+		}
+	}
+
 	return identity, nil
 }
 
 // getGroups retrieves GitHub orgs and teams a user is in, if any.
 func (c *githubConnector) getGroups(ctx context.Context, client *http.Client, groupScope bool, userLogin string) ([]string, error) {
+	if c.appClient != nil {
+		client = c.appClient
+	}
 	switch {
 	case len(c.orgs) > 0:
 		return c.groupsForOrgs(ctx, client, userLogin)
 	case c.org != "":
-		return c.teamsForOrg(ctx, client, c.org)
+		return c.teamsForOrg(ctx, client, c.org, userLogin)
 	case groupScope && c.loadAllGroups:
 		return c.userGroups(ctx, client)
 	}
@@ -372,16 +445,27 @@ func (c *githubConnector) groupsForOrgs(ctx context.Context, client *http.Client
 			continue
 		}
 
-		teams, err := c.teamsForOrg(ctx, client, org.Name)
+		orgTeams, err := c.userTeamsInOrg(ctx, client, org.Name, userName, org.TeamSlugs)
 		if err != nil {
 			return nil, err
 		}
+		if len(org.TeamSlugs) > 0 {
+			orgTeams = filterTeamSlugs(orgTeams, org.TeamSlugs)
+		}
+		teams := []string{}
+		for _, t := range orgTeams {
+			teams = append(teams, c.teamGroupClaims(t)...)
+		}
 		// User is in at least one org. User is authorized if no teams are specified
 		// in config; include all teams in claim. Otherwise filter out teams not in
-		// 'teams' list in config.
-		if len(org.Teams) == 0 {
+		// 'teams' and 'teamSlugs' lists in config.
+		switch {
+		case len(org.Teams) == 0 && len(org.TeamSlugs) == 0:
 			inOrgNoTeams = true
-		} else if teams = groups_pkg.Filter(teams, org.Teams); len(teams) == 0 {
+		case len(org.Teams) > 0:
+			teams = groups_pkg.Filter(teams, org.Teams)
+		}
+		if len(teams) == 0 && !inOrgNoTeams {
 			c.logger.Infof("github: user %q in org %q but no teams", userName, org.Name)
 		}
 
@@ -505,8 +589,9 @@ func get(ctx context.Context, client *http.Client, apiURL string, v interface{})
 
 // getPagination checks the "Link" header field for "next" or "last" pagination URLs,
 // and returns "next" page URL or empty string to indicate that there are no more pages.
-// Non empty next pages' URL is returned if both "last" and "next" URLs are found and next page
-// URL is not equal to last.
+// Non empty next pages' URL is returned if the "next" URL is found and the current
+// URL is neither the next nor the last page's URL. GitHub leaves the "last" URL out of
+// some responses, so it isn't required.
 //
 // https://developer.github.com/v3/#pagination
 func getPagination(apiURL string, resp *http.Response) string {
@@ -520,12 +605,12 @@ func getPagination(apiURL string, resp *http.Response) string {
 		if apiURL == lastPageURL {
 			return ""
 		}
-	} else {
-		return ""
 	}
 
 	if len(reNext.FindStringSubmatch(links)) > 1 {
-		return reNext.FindStringSubmatch(links)[1]
+		if nextPageURL := reNext.FindStringSubmatch(links)[1]; nextPageURL != apiURL {
+			return nextPageURL
+		}
 	}
 
 	return ""
@@ -538,6 +623,9 @@ type user struct {
 	Login string `json:"login"`
 	ID    int    `json:"id"`
 	Email string `json:"email"`
+
+	// EmailVerified is whether GitHub verified the email.
+	EmailVerified bool `json:"-"`
 }
 
 // user queries the GitHub API for profile information using the provided client.
@@ -553,11 +641,14 @@ func (c *githubConnector) user(ctx context.Context, client *http.Client) (user,
 
 	// Only public user emails are returned by 'GET /user'. u.Email will be empty
 	// if a users' email is private. We must retrieve private emails explicitly.
+	// GitHub only lets users make verified emails public.
 	if u.Email == "" {
 		var err error
-		if u.Email, err = c.userEmail(ctx, client); err != nil {
+		if u.Email, u.EmailVerified, err = c.userEmail(ctx, client); err != nil {
 			return u, err
 		}
+	} else {
+		u.EmailVerified = true
 	}
 	return u, nil
 }
@@ -572,12 +663,12 @@ type userEmail struct {
 }
 
 // userEmail queries the GitHub API for a users' email information using the
-// provided client. Only returns the users' verified, primary email (private or
-// public).
+// provided client. Only returns the users' primary email (private or public),
+// and whether it's verified.
 //
 // The HTTP client is expected to be constructed by the golang.org/x/oauth2 package,
 // which inserts a bearer token as part of the request.
-func (c *githubConnector) userEmail(ctx context.Context, client *http.Client) (string, error) {
+func (c *githubConnector) userEmail(ctx context.Context, client *http.Client) (string, bool, error) {
 	apiURL := c.apiURL + "/user/emails"
 	for {
 		// https://developer.github.com/v3/users/emails/#list-email-addresses-for-a-user
@@ -586,7 +677,7 @@ func (c *githubConnector) userEmail(ctx context.Context, client *http.Client) (s
 			err    error
 		)
 		if apiURL, err = get(ctx, client, apiURL, &emails); err != nil {
-			return "", err
+			return "", false, err
 		}
 
 		for _, email := range emails {
@@ -604,8 +695,8 @@ func (c *githubConnector) userEmail(ctx context.Context, client *http.Client) (s
 				email.Verified = true
 			}
 
-			if email.Verified && email.Primary {
-				return email.Email, nil
+			if email.Primary {
+				return email.Email, email.Verified, nil
 			}
 		}
 
@@ -614,7 +705,7 @@ func (c *githubConnector) userEmail(ctx context.Context, client *http.Client) (s
 		}
 	}
 
-	return "", errors.New("github: user has no verified, primary email")
+	return "", false, errors.New("github: user has no primary email")
 }
 
 // userInOrg queries the GitHub API for a users' org membership.
@@ -667,8 +758,27 @@ type org struct {
 //
 // The HTTP passed client is expected to be constructed by the golang.org/x/oauth2 package,
 // which inserts a bearer token as part of the request.
-func (c *githubConnector) teamsForOrg(ctx context.Context, client *http.Client, orgName string) ([]string, error) {
-	apiURL, groups := c.apiURL+"/user/teams", []string{}
+func (c *githubConnector) teamsForOrg(ctx context.Context, client *http.Client, orgName, userName string) ([]string, error) {
+	teams, err := c.userTeamsInOrg(ctx, client, orgName, userName, nil)
+	if err != nil {
+		return nil, err
+	}
+	groups := []string{}
+	for _, t := range teams {
+		groups = append(groups, c.teamGroupClaims(t)...)
+	}
+	return groups, nil
+}
+
+// userTeamsInOrg returns the teams of the user within a specific organization,
+// going through all the pages of the user's teams. Only the teams with one of
+// the slugs, if any, need to be returned.
+func (c *githubConnector) userTeamsInOrg(ctx context.Context, client *http.Client, orgName, userName string, slugs []string) ([]team, error) {
+	if c.appClient != nil {
+		return c.appUserTeamsInOrg(ctx, client, orgName, userName, slugs)
+	}
+
+	apiURL, orgTeams := c.apiURL+"/user/teams", []team{}
 	for {
 		// https://developer.github.com/v3/orgs/teams/#list-user-teams
 		var (
@@ -681,7 +791,7 @@ func (c *githubConnector) teamsForOrg(ctx context.Context, client *http.Client,
 
 		for _, t := range teams {
 			if t.Org.Login == orgName {
-				groups = append(groups, c.teamGroupClaims(t)...)
+				orgTeams = append(orgTeams, t)
 			}
 		}
 
@@ -690,7 +800,21 @@ func (c *githubConnector) teamsForOrg(ctx context.Context, client *http.Client,
 		}
 	}
 
-	return groups, nil
+	return orgTeams, nil
+}
+
+// filterTeamSlugs returns the teams whose slug is one of the slugs.
+func filterTeamSlugs(teams []team, slugs []string) []team {
+	matches := []team{}
+	for _, t := range teams {
+		for _, slug := range slugs {
+			if t.Slug == slug {
+				matches = append(matches, t)
+				break
+			}
+		}
+	}
+	return matches
 }
 
 // teamGroupClaims returns team slug if 'teamNameField' option is set to
@@ -2,15 +2,27 @@ package github
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/dexidp/dex/connector"
 )
@@ -19,6 +31,7 @@ type testResponse struct {
 	data     interface{}
 	nextLink string
 	lastLink string
+	status   int
 }
 
 func TestUserGroups(t *testing.T) {
@@ -124,6 +137,63 @@ func TestUserGroupsWithTeamNameAndSlugFieldConfig(t *testing.T) {
 	})
 }
 
+func TestGroupsForOrgsWithTeamSlugs(t *testing.T) {
+	s := newTestServer(map[string]testResponse{
+		"/orgs/acme/members/jane":  {status: http.StatusNoContent},
+		"/orgs/beta/members/jane":  {status: http.StatusNoContent},
+		"/orgs/gamma/members/jane": {status: http.StatusNoContent},
+		"/user/teams": {
+			data: []team{
+				{Name: "Platform", Slug: "platform", Org: org{Login: "acme"}},
+				{Name: "Web", Slug: "web", Org: org{Login: "acme"}},
+			},
+			// GitHub leaves the last page out of some responses.
+			nextLink: "/user/teams?page=2",
+		},
+		"/user/teams?page=2": {
+			data: []team{
+				{Name: "SRE", Slug: "sre", Org: org{Login: "beta"}},
+				{Name: "Platform", Slug: "platform", Org: org{Login: "gamma"}},
+			},
+			nextLink: "/user/teams?page=3",
+			lastLink: "/user/teams?page=3",
+		},
+		"/user/teams?page=3": {
+			data: []team{
+				{Name: "Data", Slug: "data", Org: org{Login: "beta"}},
+			},
+			lastLink: "/user/teams?page=3",
+		},
+	})
+	defer s.Close()
+
+	c := githubConnector{apiURL: s.URL, logger: logrus.New(), orgs: []Org{
+		{Name: "acme", TeamSlugs: []string{"platform", "sre"}},
+		{Name: "beta", TeamSlugs: []string{"platform", "sre"}},
+	}}
+	groups, err := c.groupsForOrgs(context.Background(), newClient(), "jane")
+	expectNil(t, err)
+	expectEquals(t, groups, []string{"acme:Platform", "beta:SRE"})
+
+	// The slugs are emitted with the slug team name field, and combine with
+	// the team names.
+	c.teamNameField = "slug"
+	c.orgs = []Org{
+		{Name: "acme", TeamSlugs: []string{"platform", "web"}, Teams: []string{"web"}},
+		{Name: "beta", TeamSlugs: []string{"data"}},
+	}
+	groups, err = c.groupsForOrgs(context.Background(), newClient(), "jane")
+	expectNil(t, err)
+	expectEquals(t, groups, []string{"acme:web", "beta:data"})
+
+	// Users who aren't in any of the teams can't authenticate.
+	c.orgs = []Org{{Name: "gamma", TeamSlugs: []string{"sre"}}}
+	_, err = c.groupsForOrgs(context.Background(), newClient(), "jane")
+	if err == nil {
+		t.Error("expected an error for a user in none of the teams")
+	}
+}
+
 // tests that the users login is used as their username when they have no username set
 func TestUsernameIncludedInFederatedIdentity(t *testing.T) {
 	s := newTestServer(map[string]testResponse{
@@ -166,6 +236,54 @@ func TestUsernameIncludedInFederatedIdentity(t *testing.T) {
 	expectEquals(t, identity.Groups, []string{"org-1"})
 }
 
+func TestOrganizations(t *testing.T) {
+	s := newTestServer(map[string]testResponse{
+		"/user": {data: user{Login: "some-login", ID: 12345678}},
+		"/user/emails": {data: []userEmail{{
+			Email:    "some@email.com",
+			Verified: true,
+			Primary:  true,
+		}}},
+		"/login/oauth/access_token": {data: map[string]interface{}{
+			"access_token": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9",
+			"expires_in":   "30",
+		}},
+		"/user/orgs": {
+			data: []org{{Login: "org-1"}, {Login: "org-2"}},
+		},
+		"/user/teams": {
+			data: []team{{Name: "team-1", Org: org{Login: "org-1"}}},
+		},
+	})
+	defer s.Close()
+
+	hostURL, err := url.Parse(s.URL)
+	expectNil(t, err)
+
+	req, err := http.NewRequest("GET", hostURL.String(), nil)
+	expectNil(t, err)
+
+	c := githubConnector{apiURL: s.URL, hostName: hostURL.Host, httpClient: newClient(), loadAllGroups: true, emitOrganizations: true}
+	identity, err := c.HandleCallback(connector.Scopes{Organizations: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, identity.Organizations, []string{"org-1", "org-2"})
+	expectEquals(t, 0, len(identity.Groups))
+
+	identity, err = c.HandleCallback(connector.Scopes{Groups: true, Organizations: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, identity.Organizations, []string{"org-1", "org-2"})
+	expectEquals(t, identity.Groups, []string{"org-1", "org-1:team-1", "org-2"})
+
+	c = githubConnector{apiURL: s.URL, hostName: hostURL.Host, httpClient: newClient()}
+	identity, err = c.HandleCallback(connector.Scopes{Organizations: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, 0, len(identity.Organizations))
+	expectEquals(t, c.oauth2Config(connector.Scopes{Organizations: true}).Scopes, []string{scopeEmail})
+}
+
 func TestLoginUsedAsIDWhenConfigured(t *testing.T) {
 	s := newTestServer(map[string]testResponse{
 		"/user": {data: user{Login: "some-login", ID: 12345678, Name: "Joe Bloggs"}},
@@ -202,6 +320,10 @@ func newTestServer(responses map[string]testResponse) *httptest.Server {
 	var s *httptest.Server
 	s = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response := responses[r.RequestURI]
+		if response.status != 0 {
+			w.WriteHeader(response.status)
+			return
+		}
 		linkParts := make([]string, 0)
 		if response.nextLink != "" {
 			linkParts = append(linkParts, fmt.Sprintf("<%s%s>; rel=\"next\"", s.URL, response.nextLink))
@@ -218,6 +340,112 @@ func newTestServer(responses map[string]testResponse) *httptest.Server {
 	return s
 }
 
+func TestUserEmailVerified(t *testing.T) {
+	s := newTestServer(map[string]testResponse{
+		"/user": {data: user{Login: "some-login", ID: 12345678}},
+		"/user/emails": {data: []userEmail{
+			{Email: "other@email.com", Verified: true},
+			{Email: "some@email.com", Verified: false, Primary: true},
+		}},
+	})
+	defer s.Close()
+
+	c := githubConnector{apiURL: s.URL}
+	u, err := c.user(context.Background(), newClient())
+	expectNil(t, err)
+	expectEquals(t, u.Email, "some@email.com")
+	expectEquals(t, u.EmailVerified, false)
+
+	// GitHub Enterprise doesn't verify emails.
+	c.hostName = "github.example.com"
+	u, err = c.user(context.Background(), newClient())
+	expectNil(t, err)
+	expectEquals(t, u.EmailVerified, true)
+}
+
+func TestRootCAs(t *testing.T) {
+	s := newTestServer(map[string]testResponse{
+		"/api/v3/user": {data: user{Login: "some-login", ID: 12345678}},
+		"/api/v3/user/emails": {data: []userEmail{{
+			Email:    "some@email.com",
+			Verified: true,
+			Primary:  true,
+		}}},
+		"/login/oauth/access_token": {data: map[string]interface{}{
+			"access_token": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9",
+			"expires_in":   "30",
+		}},
+	})
+	defer s.Close()
+
+	hostURL, err := url.Parse(s.URL)
+	expectNil(t, err)
+
+	dir := t.TempDir()
+	writeCA := func(name string, cert *x509.Certificate) string {
+		caFile := filepath.Join(dir, name)
+		if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return caFile
+	}
+	otherCA := writeCA("other.crt", newCACert(t))
+	serverCA := writeCA("server.crt", s.Certificate())
+
+	open := func(rootCAs []string) connector.Connector {
+		c := Config{HostName: hostURL.Host, RootCA: otherCA, RootCAs: rootCAs}
+		conn, err := c.Open("github", logrus.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	req, err := http.NewRequest("GET", hostURL.String(), nil)
+	expectNil(t, err)
+
+	// The API and the OAuth endpoints are trusted with any of the CAs.
+	conn := open([]string{serverCA})
+	identity, err := conn.(connector.CallbackConnector).HandleCallback(connector.Scopes{OfflineAccess: true}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.UserID, "12345678")
+
+	identity, err = conn.(connector.RefreshConnector).Refresh(context.Background(), connector.Scopes{OfflineAccess: true}, identity)
+	expectNil(t, err)
+	expectEquals(t, identity.PreferredUsername, "some-login")
+
+	conn = open(nil)
+	if _, err := conn.(connector.CallbackConnector).HandleCallback(connector.Scopes{}, req); err == nil {
+		t.Error("expected an error for a server signed by an untrusted CA")
+	}
+}
+
+// newCACert returns the certificate of a CA other than the one of the test
+// servers.
+func newCACert(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Other CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
 func newClient() *http.Client {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"golang.org/x/oauth2"
 
@@ -33,6 +34,11 @@ type Config struct {
 	RedirectURI  string   `json:"redirectURI"`
 	Groups       []string `json:"groups"`
 	UseLoginAsID bool     `json:"useLoginAsID"`
+
+	// EmitOrganizations returns the top-level groups of the user as
+	// organizations, separate from the groups, to clients requesting the
+	// "organizations" scope.
+	EmitOrganizations bool `json:"emitOrganizations"`
 }
 
 type gitlabUser struct {
@@ -57,6 +63,8 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		logger:       logger,
 		groups:       c.Groups,
 		useLoginAsID: c.UseLoginAsID,
+
+		emitOrganizations: c.EmitOrganizations,
 	}, nil
 }
 
@@ -80,11 +88,13 @@ type gitlabConnector struct {
 	httpClient   *http.Client
 	// if set to true will use the user's handle rather than their numeric id as the ID
 	useLoginAsID bool
+	// if set to true the user's top-level groups are returned as organizations
+	emitOrganizations bool
 }
 
 func (c *gitlabConnector) oauth2Config(scopes connector.Scopes) *oauth2.Config {
 	gitlabScopes := []string{scopeUser}
-	if c.groupsRequired(scopes.Groups) {
+	if c.groupsRequired(scopes.Groups) || c.organizationsRequired(scopes.Organizations) {
 		gitlabScopes = []string{scopeUser, scopeOpenID}
 	}
 
@@ -165,6 +175,12 @@ func (c *gitlabConnector) HandleCallback(s connector.Scopes, r *http.Request) (i
 		identity.Groups = groups
 	}
 
+	if c.organizationsRequired(s.Organizations) {
+		if identity.Organizations, err = c.getOrganizations(ctx, client); err != nil {
+			return identity, fmt.Errorf("gitlab: get organizations: %v", err)
+		}
+	}
+
 	if s.OfflineAccess {
 		data := connectorData{AccessToken: token.AccessToken}
 		connData, err := json.Marshal(data)
@@ -208,6 +224,12 @@ func (c *gitlabConnector) Refresh(ctx context.Context, s connector.Scopes, ident
 		}
 		ident.Groups = groups
 	}
+
+	if c.organizationsRequired(s.Organizations) {
+		if ident.Organizations, err = c.getOrganizations(ctx, client); err != nil {
+			return ident, fmt.Errorf("gitlab: get organizations: %v", err)
+		}
+	}
 	return ident, nil
 }
 
@@ -215,6 +237,10 @@ func (c *gitlabConnector) groupsRequired(groupScope bool) bool {
 	return len(c.groups) > 0 || groupScope
 }
 
+func (c *gitlabConnector) organizationsRequired(organizationsScope bool) bool {
+	return c.emitOrganizations && organizationsScope
+}
+
 // user queries the GitLab API for profile information using the provided client. The HTTP
 // client is expected to be constructed by the golang.org/x/oauth2 package, which inserts
 // a bearer token as part of the request.
@@ -298,3 +324,23 @@ func (c *gitlabConnector) getGroups(ctx context.Context, client *http.Client, gr
 
 	return nil, nil
 }
+
+// getOrganizations returns the top-level groups of the user's groups, for
+// example "acme" for the "acme/infra/oncall" subgroup.
+func (c *gitlabConnector) getOrganizations(ctx context.Context, client *http.Client) ([]string, error) {
+	gitlabGroups, err := c.userGroups(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []string
+	seen := make(map[string]bool)
+	for _, group := range gitlabGroups {
+		org := strings.SplitN(group, "/", 2)[0]
+		if !seen[org] {
+			seen[org] = true
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs, nil
+}
@@ -100,6 +100,45 @@ func TestUsernameIncludedInFederatedIdentity(t *testing.T) {
 	expectEquals(t, identity.Groups, []string{"team-1"})
 }
 
+func TestOrganizations(t *testing.T) {
+	s := newTestServer(map[string]interface{}{
+		"/api/v4/user": gitlabUser{Email: "some@email.com", ID: 12345678},
+		"/oauth/token": map[string]interface{}{
+			"access_token": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9",
+			"expires_in":   "30",
+		},
+		"/oauth/userinfo": userInfo{
+			Groups: []string{"acme/infra", "acme/infra/oncall", "umbrella"},
+		},
+	})
+	defer s.Close()
+
+	hostURL, err := url.Parse(s.URL)
+	expectNil(t, err)
+
+	req, err := http.NewRequest("GET", hostURL.String(), nil)
+	expectNil(t, err)
+
+	c := gitlabConnector{baseURL: s.URL, httpClient: newClient(), emitOrganizations: true}
+	identity, err := c.HandleCallback(connector.Scopes{Organizations: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, identity.Organizations, []string{"acme", "umbrella"})
+	expectEquals(t, 0, len(identity.Groups))
+
+	identity, err = c.HandleCallback(connector.Scopes{Groups: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, 0, len(identity.Organizations))
+	expectEquals(t, identity.Groups, []string{"acme/infra", "acme/infra/oncall", "umbrella"})
+
+	c = gitlabConnector{baseURL: s.URL, httpClient: newClient()}
+	identity, err = c.HandleCallback(connector.Scopes{Organizations: true}, req)
+
+	expectNil(t, err)
+	expectEquals(t, 0, len(identity.Organizations))
+}
+
 func TestLoginUsedAsIDWhenConfigured(t *testing.T) {
 	s := newTestServer(map[string]interface{}{
 		"/api/v4/user": gitlabUser{Email: "some@email.com", ID: 12345678, Name: "Joe Bloggs", Username: "joebloggs"},
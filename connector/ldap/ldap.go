@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 
@@ -62,6 +63,28 @@ type UserMatcher struct {
 	GroupAttr string `json:"groupAttr"`
 }
 
+// AddressAttrs maps attributes on the user entry to the fields of the address
+// claim. Unset fields are left out of the claim.
+type AddressAttrs struct {
+	Formatted     string `json:"formatted"`
+	StreetAddress string `json:"streetAddress"`
+	Locality      string `json:"locality"`
+	Region        string `json:"region"`
+	PostalCode    string `json:"postalCode"`
+	Country       string `json:"country"`
+}
+
+// attrs returns the configured attribute names.
+func (a AddressAttrs) attrs() []string {
+	var attrs []string
+	for _, attr := range []string{a.Formatted, a.StreetAddress, a.Locality, a.Region, a.PostalCode, a.Country} {
+		if attr != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
 // Config holds configuration options for LDAP logins.
 type Config struct {
 	// The host and optional port of the LDAP server. If port isn't supplied, it will be
@@ -79,6 +102,16 @@ type Config struct {
 	// protocol.
 	StartTLS bool `json:"startTLS"`
 
+	// FollowReferrals chases the referrals returned by user and group
+	// searches, for example to the other domains of an Active Directory
+	// forest. The referred servers are connected to like the host, bound as
+	// the service account, so only the hosts listed in referralHosts are
+	// followed.
+	FollowReferrals bool `json:"followReferrals"`
+	// ReferralHosts lists the hosts referrals may point to, for example
+	// "child.example.com" for any port or "child.example.com:636".
+	ReferralHosts []string `json:"referralHosts"`
+
 	// Path to a trusted root certificate file.
 	RootCA string `json:"rootCA"`
 	// Path to a client cert file generated by rootCA.
@@ -88,6 +121,15 @@ type Config struct {
 	// Base64 encoded PEM data containing root CAs.
 	RootCAData []byte `json:"rootCAData"`
 
+	// MinTLSVersion rejects LDAPS and StartTLS connections using an older
+	// TLS version. One of "1.0", "1.1", "1.2" or "1.3". Defaults to the
+	// minimum version of Go's TLS client.
+	MinTLSVersion string `json:"minTLSVersion"`
+	// CipherSuites limits the cipher suites offered for TLS 1.0 to 1.2
+	// connections, for example "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". The
+	// TLS 1.3 cipher suites aren't configurable.
+	CipherSuites []string `json:"cipherSuites"`
+
 	// BindDN and BindPW for an application service account. The connector uses these
 	// credentials to search for users and groups.
 	BindDN string `json:"bindDN"`
@@ -98,10 +140,21 @@ type Config struct {
 	// "Username".
 	UsernamePrompt string `json:"usernamePrompt"`
 
+	// MaxConnections enables a pool of connections bound as the service
+	// account, reused across logins and refreshes, with at most this many
+	// connections open at once. If unset, every request opens a connection.
+	MaxConnections int `json:"maxConnections"`
+	// IdleTimeout closes pooled connections unused for this long, for example
+	// "1m". Defaults to 5 minutes.
+	IdleTimeout string `json:"idleTimeout"`
+
 	// User entry search configuration.
 	UserSearch struct {
 		// BaseDN to start the search from. For example "cn=users,dc=example,dc=com"
-		BaseDN string `json:"baseDN"`
+		//
+		// A list of base DNs may be given for users spread across several
+		// subtrees. Each base is searched, and the user must match in only one.
+		BaseDN BaseDNs `json:"baseDN"`
 
 		// Optional filter to apply when searching the directory. For example "(objectClass=person)"
 		Filter string `json:"filter"`
@@ -121,6 +174,16 @@ type Config struct {
 		NameAttr                  string `json:"nameAttr"`              // No default.
 		PreferredUsernameAttrAttr string `json:"preferredUsernameAttr"` // No default.
 
+		// Attributes of the user entry to map to the address claim. For example
+		// {"streetAddress": "street", "locality": "l", "postalCode": "postalCode"}.
+		AddressAttrs AddressAttrs `json:"addressAttrs"`
+
+		// ExtraAttrs are further attributes of the user entry kept in the
+		// connector data of the identity, for example "department", for
+		// policies based on attributes which aren't mapped to claims. All the
+		// values of multi-valued attributes are kept.
+		ExtraAttrs []string `json:"extraAttrs"`
+
 		// If this is set, the email claim of the id token will be constructed from the idAttr and
 		// value of emailSuffix. This should not include the @ character.
 		EmailSuffix string `json:"emailSuffix"` // No default.
@@ -129,7 +192,9 @@ type Config struct {
 	// Group search configuration.
 	GroupSearch struct {
 		// BaseDN to start the search from. For example "cn=groups,dc=example,dc=com"
-		BaseDN string `json:"baseDN"`
+		//
+		// A list of base DNs may be given, the groups found in each are added.
+		BaseDN BaseDNs `json:"baseDN"`
 
 		// Optional filter to apply when searching the directory. For example "(objectClass=posixGroup)"
 		Filter string `json:"filter"`
@@ -155,9 +220,46 @@ type Config struct {
 
 		// The attribute of the group that represents its name.
 		NameAttr string `json:"nameAttr"`
+
+		// RecursiveSearch adds the groups the user is a member of through
+		// other groups. The groups of a group are found with the userMatchers,
+		// the group taking the place of the user, for example groups with a
+		// "member" attribute holding the DN of the group.
+		RecursiveSearch bool `json:"recursiveSearch"`
+
+		// MaxDepth limits how many levels of nested groups are searched.
+		// Defaults to 10.
+		MaxDepth int `json:"maxDepth"`
+
+		// MatchingRuleInChain resolves nested groups with a single search using
+		// the LDAP_MATCHING_RULE_IN_CHAIN rule of Active Directory, instead of
+		// a search per level. Requires recursiveSearch.
+		MatchingRuleInChain bool `json:"matchingRuleInChain"`
 	} `json:"groupSearch"`
 }
 
+// BaseDNs is a list of base DNs, which can be configured as a single string
+// or as a list of strings.
+type BaseDNs []string
+
+// UnmarshalJSON accepts either a string or a list of strings.
+func (b *BaseDNs) UnmarshalJSON(data []byte) error {
+	var baseDN string
+	if err := json.Unmarshal(data, &baseDN); err == nil {
+		*b = nil
+		if baseDN != "" {
+			*b = BaseDNs{baseDN}
+		}
+		return nil
+	}
+	var baseDNs []string
+	if err := json.Unmarshal(data, &baseDNs); err != nil {
+		return fmt.Errorf("ldap: baseDN must be a string or a list of strings: %v", err)
+	}
+	*b = baseDNs
+	return nil
+}
+
 func scopeString(i int) string {
 	switch i {
 	case ldap.ScopeBaseObject:
@@ -183,6 +285,41 @@ func parseScope(s string) (int, bool) {
 	return 0, false
 }
 
+const (
+	// defaultGroupMaxDepth is the number of levels of nested groups searched
+	// if the config doesn't set a maximum depth.
+	defaultGroupMaxDepth = 10
+
+	// matchingRuleInChain is the OID of the LDAP_MATCHING_RULE_IN_CHAIN
+	// rule of Active Directory, which matches transitive memberships.
+	matchingRuleInChain = "1.2.840.113556.1.4.1941"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseCipherSuites returns the IDs of the named cipher suites.
+func parseCipherSuites(names []string) ([]uint16, error) {
+	ids := make(map[string]uint16)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		ids[suite.Name] = suite.ID
+	}
+
+	var suites []uint16
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	return suites, nil
+}
+
 // Build a list of group attr name to user attr value matchers.
 // Function exists here to allow backward compatibility between old and new
 // group to user matching implementations.
@@ -213,6 +350,9 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 type refreshData struct {
 	Username string     `json:"username"`
 	Entry    ldap.Entry `json:"entry"`
+
+	// Attributes holds the values of the extra attributes of the user entry.
+	Attributes map[string][]string `json:"attributes,omitempty"`
 }
 
 // OpenConnector is the same as Open but returns a type with all implemented connector interfaces.
@@ -220,6 +360,7 @@ func (c *Config) OpenConnector(logger log.Logger) (interface {
 	connector.Connector
 	connector.PasswordConnector
 	connector.RefreshConnector
+	connector.GroupsConnector
 }, error) {
 	return c.openConnector(logger)
 }
@@ -230,7 +371,6 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 		val  string
 	}{
 		{"host", c.Host},
-		{"userSearch.baseDN", c.UserSearch.BaseDN},
 		{"userSearch.username", c.UserSearch.Username},
 	}
 
@@ -239,6 +379,19 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 			return nil, fmt.Errorf("ldap: missing required field %q", field.name)
 		}
 	}
+	if len(c.UserSearch.BaseDN) == 0 {
+		return nil, fmt.Errorf("ldap: missing required field %q", "userSearch.baseDN")
+	}
+	for name, baseDNs := range map[string]BaseDNs{
+		"userSearch.baseDN":  c.UserSearch.BaseDN,
+		"groupSearch.baseDN": c.GroupSearch.BaseDN,
+	} {
+		for _, baseDN := range baseDNs {
+			if baseDN == "" {
+				return nil, fmt.Errorf("ldap: empty base DN in %q", name)
+			}
+		}
+	}
 
 	var (
 		host string
@@ -254,6 +407,18 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 	}
 
 	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: c.InsecureSkipVerify}
+	if c.MinTLSVersion != "" {
+		version, ok := tlsVersions[c.MinTLSVersion]
+		if !ok {
+			return nil, fmt.Errorf("ldap: unknown minTLSVersion %q, expected one of \"1.0\", \"1.1\", \"1.2\" or \"1.3\"", c.MinTLSVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+	if len(c.CipherSuites) > 0 {
+		if tlsConfig.CipherSuites, err = parseCipherSuites(c.CipherSuites); err != nil {
+			return nil, fmt.Errorf("ldap: invalid cipherSuites: %v", err)
+		}
+	}
 	if c.RootCA != "" || len(c.RootCAData) != 0 {
 		data := c.RootCAData
 		if len(data) == 0 {
@@ -285,9 +450,34 @@ func (c *Config) openConnector(logger log.Logger) (*ldapConnector, error) {
 		return nil, fmt.Errorf("groupSearch.Scope unknown value %q", c.GroupSearch.Scope)
 	}
 
+	if c.FollowReferrals && len(c.ReferralHosts) == 0 {
+		return nil, fmt.Errorf("ldap: followReferrals requires referralHosts")
+	}
+	if c.MaxConnections < 0 {
+		return nil, fmt.Errorf("ldap: invalid maxConnections %d", c.MaxConnections)
+	}
+	if c.GroupSearch.MaxDepth < 0 {
+		return nil, fmt.Errorf("ldap: invalid groupSearch.maxDepth %d", c.GroupSearch.MaxDepth)
+	}
+	if c.GroupSearch.MatchingRuleInChain && !c.GroupSearch.RecursiveSearch {
+		return nil, fmt.Errorf("ldap: groupSearch.matchingRuleInChain requires groupSearch.recursiveSearch")
+	}
+	idleTimeout := defaultIdleTimeout
+	if c.IdleTimeout != "" {
+		if idleTimeout, err = time.ParseDuration(c.IdleTimeout); err != nil {
+			return nil, fmt.Errorf("ldap: invalid idleTimeout: %v", err)
+		}
+	}
+
 	// TODO(nabokihms): remove it after deleting deprecated groupSearch options
 	c.GroupSearch.UserMatchers = userMatchers(c, logger)
-	return &ldapConnector{*c, userSearchScope, groupSearchScope, tlsConfig, logger}, nil
+	conn := &ldapConnector{*c, userSearchScope, groupSearchScope, tlsConfig, logger, nil}
+	if c.MaxConnections > 0 {
+		conn.pool = newConnPool(c.MaxConnections, idleTimeout, func() (pooledConn, error) {
+			return conn.dial()
+		})
+	}
+	return conn, nil
 }
 
 type ldapConnector struct {
@@ -299,6 +489,9 @@ type ldapConnector struct {
 	tlsConfig *tls.Config
 
 	logger log.Logger
+
+	// pool is nil unless connections are pooled.
+	pool *connPool
 }
 
 var (
@@ -309,31 +502,84 @@ var (
 // do initializes a connection to the LDAP directory and passes it to the
 // provided function. It then performs appropriate teardown or reuse before
 // returning.
-func (c *ldapConnector) do(_ context.Context, f func(c *ldap.Conn) error) error {
+func (c *ldapConnector) do(ctx context.Context, f func(c *ldap.Conn) error) error {
+	return c.doConn(ctx, false, f)
+}
+
+// doUserBind is like do for functions binding as another user. Pooled
+// connections are bound as the service account again before they're reused.
+func (c *ldapConnector) doUserBind(ctx context.Context, f func(c *ldap.Conn) error) error {
+	return c.doConn(ctx, true, f)
+}
+
+func (c *ldapConnector) doConn(ctx context.Context, userBind bool, f func(c *ldap.Conn) error) error {
 	// TODO(ericchiang): support context here
+	if c.pool == nil {
+		conn, err := c.dial()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		return f(conn)
+	}
+
+	pooled, err := c.pool.get(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.(*ldap.Conn)
+	err = f(conn)
+
+	reuse := err == nil
+	if reuse && userBind {
+		if bindErr := c.bind(conn); bindErr != nil {
+			c.logger.Errorf("ldap: failed to restore the bind of a pooled connection: %v", bindErr)
+			reuse = false
+		}
+	}
+	c.pool.put(conn, reuse)
+	return err
+}
+
+// dial opens a connection to the LDAP directory bound as the service account.
+func (c *ldapConnector) dial() (*ldap.Conn, error) {
+	return c.dialHost(c.Host, c.tlsConfig)
+}
+
+// dialHost opens a connection to the host bound as the service account.
+func (c *ldapConnector) dialHost(host string, tlsConfig *tls.Config) (*ldap.Conn, error) {
 	var (
 		conn *ldap.Conn
 		err  error
 	)
 	switch {
 	case c.InsecureNoSSL:
-		conn, err = ldap.Dial("tcp", c.Host)
+		conn, err = ldap.Dial("tcp", host)
 	case c.StartTLS:
-		conn, err = ldap.Dial("tcp", c.Host)
+		conn, err = ldap.Dial("tcp", host)
 		if err != nil {
-			return fmt.Errorf("failed to connect: %v", err)
+			return nil, fmt.Errorf("failed to connect: %v", err)
 		}
-		if err := conn.StartTLS(c.tlsConfig); err != nil {
-			return fmt.Errorf("start TLS failed: %v", err)
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start TLS failed: %v", err)
 		}
 	default:
-		conn, err = ldap.DialTLS("tcp", c.Host, c.tlsConfig)
+		conn, err = ldap.DialTLS("tcp", host, tlsConfig)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to connect: %v", err)
+		return nil, fmt.Errorf("failed to connect: %v", err)
 	}
-	defer conn.Close()
 
+	if err := c.bind(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// bind binds the connection as the service account.
+func (c *ldapConnector) bind(conn *ldap.Conn) error {
 	// If bindDN and bindPW are empty this will default to an anonymous bind.
 	if c.BindDN == "" && c.BindPW == "" {
 		if err := conn.UnauthenticatedBind(""); err != nil {
@@ -342,8 +588,7 @@ func (c *ldapConnector) do(_ context.Context, f func(c *ldap.Conn) error) error
 	} else if err := conn.Bind(c.BindDN, c.BindPW); err != nil {
 		return fmt.Errorf("ldap: initial bind for user %q failed: %v", c.BindDN, err)
 	}
-
-	return f(conn)
+	return nil
 }
 
 func getAttrs(e ldap.Entry, name string) []string {
@@ -396,6 +641,8 @@ func (c *ldapConnector) identityFromEntry(user ldap.Entry) (ident connector.Iden
 	// TODO(ericchiang): Let this value be set from an attribute.
 	ident.EmailVerified = true
 
+	ident.Address = c.addressFromEntry(user)
+
 	if len(missing) != 0 {
 		err := fmt.Errorf("ldap: entry %q missing following required attribute(s): %q", user.DN, missing)
 		return connector.Identity{}, err
@@ -403,6 +650,30 @@ func (c *ldapConnector) identityFromEntry(user ldap.Entry) (ident connector.Iden
 	return ident, nil
 }
 
+// addressFromEntry returns the address of the user from the configured
+// attributes, or nil if the entry has none of them.
+func (c *ldapConnector) addressFromEntry(user ldap.Entry) *connector.Address {
+	attrs := c.UserSearch.AddressAttrs
+	get := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return getAttr(user, name)
+	}
+	address := connector.Address{
+		Formatted:     get(attrs.Formatted),
+		StreetAddress: get(attrs.StreetAddress),
+		Locality:      get(attrs.Locality),
+		Region:        get(attrs.Region),
+		PostalCode:    get(attrs.PostalCode),
+		Country:       get(attrs.Country),
+	}
+	if address == (connector.Address{}) {
+		return nil
+	}
+	return &address
+}
+
 func (c *ldapConnector) userEntry(conn *ldap.Conn, username string) (user ldap.Entry, found bool, err error) {
 	filter := fmt.Sprintf("(%s=%s)", c.UserSearch.Username, ldap.EscapeFilter(username))
 	if c.UserSearch.Filter != "" {
@@ -411,7 +682,6 @@ func (c *ldapConnector) userEntry(conn *ldap.Conn, username string) (user ldap.E
 
 	// Initial search.
 	req := &ldap.SearchRequest{
-		BaseDN: c.UserSearch.BaseDN,
 		Filter: filter,
 		Scope:  c.userSearchScope,
 		// We only need to search for these specific requests.
@@ -434,24 +704,45 @@ func (c *ldapConnector) userEntry(conn *ldap.Conn, username string) (user ldap.E
 		req.Attributes = append(req.Attributes, c.UserSearch.PreferredUsernameAttrAttr)
 	}
 
-	c.logger.Infof("performing ldap search %s %s %s",
-		req.BaseDN, scopeString(req.Scope), req.Filter)
-	resp, err := conn.Search(req)
-	if err != nil {
-		return ldap.Entry{}, false, fmt.Errorf("ldap: search with filter %q failed: %v", req.Filter, err)
+	req.Attributes = append(req.Attributes, c.UserSearch.AddressAttrs.attrs()...)
+	req.Attributes = append(req.Attributes, c.UserSearch.ExtraAttrs...)
+
+	// Every base is searched, since a user matching in several bases is
+	// ambiguous.
+	var userBaseDN string
+	for _, baseDN := range c.UserSearch.BaseDN {
+		req.BaseDN = baseDN
+		c.logger.Infof("performing ldap search %s %s %s",
+			req.BaseDN, scopeString(req.Scope), req.Filter)
+		resp, err := c.search(conn, req)
+		if err != nil {
+			return ldap.Entry{}, false, fmt.Errorf("ldap: search with filter %q failed: %v", req.Filter, err)
+		}
+
+		switch n := len(resp.Entries); n {
+		case 0:
+			continue
+		case 1:
+		default:
+			return ldap.Entry{}, false, fmt.Errorf("ldap: filter returned multiple (%d) results: %q", n, filter)
+		}
+		entry := *resp.Entries[0]
+		if found {
+			// Overlapping bases return the same entry.
+			if entry.DN == user.DN {
+				continue
+			}
+			return ldap.Entry{}, false, fmt.Errorf("ldap: filter %q matched entries in base DNs %q and %q", filter, userBaseDN, baseDN)
+		}
+		user, found, userBaseDN = entry, true, baseDN
 	}
 
-	switch n := len(resp.Entries); n {
-	case 0:
+	if !found {
 		c.logger.Errorf("ldap: no results returned for filter: %q", filter)
 		return ldap.Entry{}, false, nil
-	case 1:
-		user = *resp.Entries[0]
-		c.logger.Infof("username %q mapped to entry %s", username, user.DN)
-		return user, true, nil
-	default:
-		return ldap.Entry{}, false, fmt.Errorf("ldap: filter returned multiple (%d) results: %q", n, filter)
 	}
+	c.logger.Infof("username %q mapped to entry %s", username, user.DN)
+	return user, true, nil
 }
 
 func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username, password string) (ident connector.Identity, validPass bool, err error) {
@@ -467,7 +758,7 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 		user          ldap.Entry
 	)
 
-	err = c.do(ctx, func(conn *ldap.Conn) error {
+	err = c.doUserBind(ctx, func(conn *ldap.Conn) error {
 		entry, found, err := c.userEntry(conn, username)
 		if err != nil {
 			return err
@@ -516,14 +807,10 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 		ident.Groups = groups
 	}
 
-	if s.OfflineAccess {
-		refresh := refreshData{
-			Username: username,
-			Entry:    user,
-		}
+	if s.OfflineAccess || len(c.UserSearch.ExtraAttrs) > 0 {
 		// Encode entry for follow up requests such as the groups query and
 		// refresh attempts.
-		if ident.ConnectorData, err = json.Marshal(refresh); err != nil {
+		if ident.ConnectorData, err = c.connectorData(username, user); err != nil {
 			return connector.Identity{}, false, fmt.Errorf("ldap: marshal entry: %v", err)
 		}
 	}
@@ -531,6 +818,23 @@ func (c *ldapConnector) Login(ctx context.Context, s connector.Scopes, username,
 	return ident, true, nil
 }
 
+// connectorData returns the connector data of the identity of the user.
+func (c *ldapConnector) connectorData(username string, user ldap.Entry) ([]byte, error) {
+	data := refreshData{
+		Username: username,
+		Entry:    user,
+	}
+	for _, name := range c.UserSearch.ExtraAttrs {
+		if values := getAttrs(user, name); len(values) > 0 {
+			if data.Attributes == nil {
+				data.Attributes = make(map[string][]string)
+			}
+			data.Attributes[name] = values
+		}
+	}
+	return json.Marshal(data)
+}
+
 func (c *ldapConnector) Refresh(ctx context.Context, s connector.Scopes, ident connector.Identity) (connector.Identity, error) {
 	var data refreshData
 	if err := json.Unmarshal(ident.ConnectorData, &data); err != nil {
@@ -561,6 +865,12 @@ func (c *ldapConnector) Refresh(ctx context.Context, s connector.Scopes, ident c
 		return ident, err
 	}
 	newIdent.ConnectorData = ident.ConnectorData
+	if len(c.UserSearch.ExtraAttrs) > 0 {
+		// Keep the current values of the extra attributes.
+		if newIdent.ConnectorData, err = c.connectorData(data.Username, user); err != nil {
+			return ident, fmt.Errorf("ldap: marshal entry: %v", err)
+		}
+	}
 
 	if s.Groups {
 		groups, err := c.groups(ctx, user)
@@ -572,63 +882,169 @@ func (c *ldapConnector) Refresh(ctx context.Context, s connector.Scopes, ident c
 	return newIdent, nil
 }
 
+// Groups looks up the groups of the user with the given username, for composite
+// connectors which authenticate users elsewhere.
+func (c *ldapConnector) Groups(ctx context.Context, username string) ([]string, error) {
+	var user ldap.Entry
+	err := c.do(ctx, func(conn *ldap.Conn) error {
+		entry, found, err := c.userEntry(conn, username)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("ldap: user not found %q", username)
+		}
+		user = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := c.groups(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: failed to query groups: %v", err)
+	}
+	return groups, nil
+}
+
 func (c *ldapConnector) groups(ctx context.Context, user ldap.Entry) ([]string, error) {
-	if c.GroupSearch.BaseDN == "" {
+	if len(c.GroupSearch.BaseDN) == 0 {
 		c.logger.Debugf("No groups returned for %q because no groups baseDN has been configured.", getAttr(user, c.UserSearch.NameAttr))
 		return nil, nil
 	}
 
+	groups, err := c.searchGroups(ctx, user, true)
+	if err != nil {
+		return nil, err
+	}
+	if c.GroupSearch.RecursiveSearch && !c.GroupSearch.MatchingRuleInChain {
+		if groups, err = c.nestedGroups(ctx, groups); err != nil {
+			return nil, err
+		}
+	}
+
+	groupNames := make([]string, 0, len(groups))
+	for _, group := range groups {
+		name := getAttr(*group, c.GroupSearch.NameAttr)
+		if name == "" {
+			// Be obnoxious about missing missing attributes. If the group entry is
+			// missing its name attribute, that indicates a misconfiguration.
+			//
+			// In the future we can add configuration options to just log these errors.
+			return nil, fmt.Errorf("ldap: group entity %q missing required attribute %q",
+				group.DN, c.GroupSearch.NameAttr)
+		}
+
+		groupNames = append(groupNames, name)
+	}
+	return groupNames, nil
+}
+
+// searchGroups returns the groups the entry, a user or a group, is a direct
+// member of. With matchingRuleInChain, the transitive memberships are returned
+// as well.
+func (c *ldapConnector) searchGroups(ctx context.Context, entry ldap.Entry, isUser bool) ([]*ldap.Entry, error) {
+	attributes := []string{c.GroupSearch.NameAttr}
+	if c.GroupSearch.RecursiveSearch {
+		// The attributes matching the groups of a group.
+		for _, matcher := range c.GroupSearch.UserMatchers {
+			if matcher.UserAttr != "DN" {
+				attributes = append(attributes, matcher.UserAttr)
+			}
+		}
+	}
+
 	var groups []*ldap.Entry
 	for _, matcher := range c.GroupSearch.UserMatchers {
-		for _, attr := range getAttrs(user, matcher.UserAttr) {
+		for _, attr := range getAttrs(entry, matcher.UserAttr) {
 			filter := fmt.Sprintf("(%s=%s)", matcher.GroupAttr, ldap.EscapeFilter(attr))
+			if c.GroupSearch.MatchingRuleInChain {
+				filter = fmt.Sprintf("(%s:%s:=%s)", matcher.GroupAttr, matchingRuleInChain, ldap.EscapeFilter(attr))
+			}
 			if c.GroupSearch.Filter != "" {
 				filter = fmt.Sprintf("(&%s%s)", c.GroupSearch.Filter, filter)
 			}
 
 			req := &ldap.SearchRequest{
-				BaseDN:     c.GroupSearch.BaseDN,
 				Filter:     filter,
 				Scope:      c.groupSearchScope,
-				Attributes: []string{c.GroupSearch.NameAttr},
+				Attributes: attributes,
 			}
 
 			gotGroups := false
 			if err := c.do(ctx, func(conn *ldap.Conn) error {
-				c.logger.Infof("performing ldap search %s %s %s",
-					req.BaseDN, scopeString(req.Scope), req.Filter)
-				resp, err := conn.Search(req)
-				if err != nil {
-					return fmt.Errorf("ldap: search failed: %v", err)
+				// Overlapping bases return the same groups.
+				seen := make(map[string]bool)
+				for _, baseDN := range c.GroupSearch.BaseDN {
+					req.BaseDN = baseDN
+					c.logger.Infof("performing ldap search %s %s %s",
+						req.BaseDN, scopeString(req.Scope), req.Filter)
+					resp, err := c.search(conn, req)
+					if err != nil {
+						return fmt.Errorf("ldap: search failed: %v", err)
+					}
+					for _, group := range resp.Entries {
+						if !seen[group.DN] {
+							seen[group.DN] = true
+							gotGroups = true
+							groups = append(groups, group)
+						}
+					}
 				}
-				gotGroups = len(resp.Entries) != 0
-				groups = append(groups, resp.Entries...)
 				return nil
 			}); err != nil {
 				return nil, err
 			}
-			if !gotGroups {
+			// Most groups aren't members of other groups.
+			if !gotGroups && isUser {
 				// TODO(ericchiang): Is this going to spam the logs?
 				c.logger.Errorf("ldap: groups search with filter %q returned no groups", filter)
 			}
 		}
 	}
+	return groups, nil
+}
 
-	groupNames := make([]string, 0, len(groups))
-	for _, group := range groups {
-		name := getAttr(*group, c.GroupSearch.NameAttr)
-		if name == "" {
-			// Be obnoxious about missing missing attributes. If the group entry is
-			// missing its name attribute, that indicates a misconfiguration.
-			//
-			// In the future we can add configuration options to just log these errors.
-			return nil, fmt.Errorf("ldap: group entity %q missing required attribute %q",
-				group.DN, c.GroupSearch.NameAttr)
+// nestedGroups adds the groups of the groups, level by level, up to the
+// maximum depth. Groups already found aren't searched again, which also ends
+// membership cycles.
+func (c *ldapConnector) nestedGroups(ctx context.Context, groups []*ldap.Entry) ([]*ldap.Entry, error) {
+	maxDepth := c.GroupSearch.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultGroupMaxDepth
+	}
+
+	seen := make(map[string]bool)
+	var all []*ldap.Entry
+	add := func(found []*ldap.Entry) (added []*ldap.Entry) {
+		for _, group := range found {
+			if !seen[group.DN] {
+				seen[group.DN] = true
+				added = append(added, group)
+			}
 		}
+		all = append(all, added...)
+		return added
+	}
 
-		groupNames = append(groupNames, name)
+	level := add(groups)
+	for depth := 1; len(level) > 0; depth++ {
+		if depth > maxDepth {
+			c.logger.Warnf("ldap: not searching the groups of %d groups nested deeper than the maximum depth %d", len(level), maxDepth)
+			break
+		}
+		var next []*ldap.Entry
+		for _, group := range level {
+			parents, err := c.searchGroups(ctx, *group, false)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, add(parents)...)
+		}
+		level = next
 	}
-	return groupNames, nil
+	return all, nil
 }
 
 func (c *ldapConnector) Prompt() string {
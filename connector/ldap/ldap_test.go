@@ -2,11 +2,14 @@ package ldap
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"testing"
 
+	"github.com/go-ldap/ldap/v3"
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/sirupsen/logrus"
 
@@ -42,7 +45,7 @@ type subtest struct {
 
 func TestQuery(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestQuery,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestQuery,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
@@ -88,9 +91,54 @@ func TestQuery(t *testing.T) {
 	runTests(t, connectLDAP, c, tests)
 }
 
+func TestPooledConnections(t *testing.T) {
+	c := &Config{}
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestQuery,dc=example,dc=org"}
+	c.UserSearch.NameAttr = "cn"
+	c.UserSearch.EmailAttr = "mail"
+	c.UserSearch.IDAttr = "DN"
+	c.UserSearch.Username = "cn"
+	c.MaxConnections = 1
+
+	// Logins bind as the user, so the pooled connection must be bound as the
+	// service account again for the searches of the following logins.
+	tests := []subtest{
+		{
+			name:      "invalidpassword",
+			username:  "jane",
+			password:  "badpassword",
+			wantBadPW: true,
+		},
+		{
+			name:     "validpassword",
+			username: "jane",
+			password: "foo",
+			want: connector.Identity{
+				UserID:        "cn=jane,ou=People,ou=TestQuery,dc=example,dc=org",
+				Username:      "jane",
+				Email:         "janedoe@example.com",
+				EmailVerified: true,
+			},
+		},
+		{
+			name:     "validpassword2",
+			username: "john",
+			password: "bar",
+			want: connector.Identity{
+				UserID:        "cn=john,ou=People,ou=TestQuery,dc=example,dc=org",
+				Username:      "john",
+				Email:         "johndoe@example.com",
+				EmailVerified: true,
+			},
+		},
+	}
+
+	runTests(t, connectLDAP, c, tests)
+}
+
 func TestQueryWithEmailSuffix(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestQueryWithEmailSuffix,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestQueryWithEmailSuffix,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailSuffix = "test.example.com"
 	c.UserSearch.IDAttr = "DN"
@@ -124,9 +172,58 @@ func TestQueryWithEmailSuffix(t *testing.T) {
 	runTests(t, connectLDAP, c, tests)
 }
 
+func TestAddressAttrs(t *testing.T) {
+	c := &Config{}
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestAddressAttrs,dc=example,dc=org"}
+	c.UserSearch.NameAttr = "cn"
+	c.UserSearch.EmailAttr = "mail"
+	c.UserSearch.IDAttr = "DN"
+	c.UserSearch.Username = "cn"
+	c.UserSearch.AddressAttrs = AddressAttrs{
+		StreetAddress: "street",
+		Locality:      "l",
+		Region:        "st",
+		PostalCode:    "postalCode",
+		Country:       "c",
+	}
+
+	tests := []subtest{
+		{
+			name:     "withaddress",
+			username: "jane",
+			password: "foo",
+			want: connector.Identity{
+				UserID:        "cn=jane,ou=People,ou=TestAddressAttrs,dc=example,dc=org",
+				Username:      "jane",
+				Email:         "janedoe@example.com",
+				EmailVerified: true,
+				Address: &connector.Address{
+					StreetAddress: "1 Main St",
+					Locality:      "Springfield",
+					Region:        "OR",
+					PostalCode:    "97477",
+				},
+			},
+		},
+		{
+			name:     "withoutaddress",
+			username: "john",
+			password: "bar",
+			want: connector.Identity{
+				UserID:        "cn=john,ou=People,ou=TestAddressAttrs,dc=example,dc=org",
+				Username:      "john",
+				Email:         "johndoe@example.com",
+				EmailVerified: true,
+			},
+		},
+	}
+
+	runTests(t, connectLDAP, c, tests)
+}
+
 func TestUserFilter(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=TestUserFilter,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=TestUserFilter,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
@@ -175,12 +272,12 @@ func TestUserFilter(t *testing.T) {
 
 func TestGroupQuery(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestGroupQuery,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestGroupQuery,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
 	c.UserSearch.Username = "cn"
-	c.GroupSearch.BaseDN = "ou=Groups,ou=TestGroupQuery,dc=example,dc=org"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=Groups,ou=TestGroupQuery,dc=example,dc=org"}
 	c.GroupSearch.UserMatchers = []UserMatcher{
 		{
 			UserAttr:  "DN",
@@ -221,14 +318,76 @@ func TestGroupQuery(t *testing.T) {
 	runTests(t, connectLDAP, c, tests)
 }
 
+func TestMultipleBaseDNs(t *testing.T) {
+	c := &Config{}
+	c.UserSearch.BaseDN = BaseDNs{
+		"ou=Staff,ou=TestMultipleBaseDNs,dc=example,dc=org",
+		"ou=Contractors,ou=TestMultipleBaseDNs,dc=example,dc=org",
+	}
+	c.UserSearch.NameAttr = "cn"
+	c.UserSearch.EmailAttr = "mail"
+	c.UserSearch.IDAttr = "DN"
+	c.UserSearch.Username = "cn"
+	// The last base overlaps with the second one.
+	c.GroupSearch.BaseDN = BaseDNs{
+		"ou=Groups,ou=Staff,ou=TestMultipleBaseDNs,dc=example,dc=org",
+		"ou=Groups,ou=Contractors,ou=TestMultipleBaseDNs,dc=example,dc=org",
+		"ou=Contractors,ou=TestMultipleBaseDNs,dc=example,dc=org",
+	}
+	c.GroupSearch.UserMatchers = []UserMatcher{
+		{
+			UserAttr:  "DN",
+			GroupAttr: "member",
+		},
+	}
+	c.GroupSearch.NameAttr = "cn"
+
+	tests := []subtest{
+		{
+			name:     "first base",
+			username: "jane",
+			password: "foo",
+			groups:   true,
+			want: connector.Identity{
+				UserID:        "cn=jane,ou=Staff,ou=TestMultipleBaseDNs,dc=example,dc=org",
+				Username:      "jane",
+				Email:         "janedoe@example.com",
+				EmailVerified: true,
+				Groups:        []string{"employees", "vendors"},
+			},
+		},
+		{
+			name:     "second base",
+			username: "john",
+			password: "bar",
+			groups:   true,
+			want: connector.Identity{
+				UserID:        "cn=john,ou=Contractors,ou=TestMultipleBaseDNs,dc=example,dc=org",
+				Username:      "john",
+				Email:         "johndoe@example.com",
+				EmailVerified: true,
+				Groups:        []string{"vendors"},
+			},
+		},
+		{
+			name:     "user in both bases",
+			username: "alex",
+			password: "baz",
+			wantErr:  true,
+		},
+	}
+
+	runTests(t, connectLDAP, c, tests)
+}
+
 func TestGroupsOnUserEntity(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestGroupsOnUserEntity,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestGroupsOnUserEntity,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
 	c.UserSearch.Username = "cn"
-	c.GroupSearch.BaseDN = "ou=Groups,ou=TestGroupsOnUserEntity,dc=example,dc=org"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=Groups,ou=TestGroupsOnUserEntity,dc=example,dc=org"}
 	c.GroupSearch.UserMatchers = []UserMatcher{
 		{
 			UserAttr:  "departmentNumber",
@@ -269,12 +428,12 @@ func TestGroupsOnUserEntity(t *testing.T) {
 
 func TestGroupFilter(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestGroupFilter,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestGroupFilter,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
 	c.UserSearch.Username = "cn"
-	c.GroupSearch.BaseDN = "ou=TestGroupFilter,dc=example,dc=org"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=TestGroupFilter,dc=example,dc=org"}
 	c.GroupSearch.UserMatchers = []UserMatcher{
 		{
 			UserAttr:  "DN",
@@ -318,12 +477,12 @@ func TestGroupFilter(t *testing.T) {
 
 func TestGroupToUserMatchers(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestGroupToUserMatchers,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestGroupToUserMatchers,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
 	c.UserSearch.Username = "cn"
-	c.GroupSearch.BaseDN = "ou=TestGroupToUserMatchers,dc=example,dc=org"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=TestGroupToUserMatchers,dc=example,dc=org"}
 	c.GroupSearch.UserMatchers = []UserMatcher{
 		{
 			UserAttr:  "DN",
@@ -369,17 +528,67 @@ func TestGroupToUserMatchers(t *testing.T) {
 	runTests(t, connectLDAP, c, tests)
 }
 
+func TestNestedGroups(t *testing.T) {
+	newConfig := func(recursive bool, maxDepth int) *Config {
+		c := &Config{}
+		c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestNestedGroups,dc=example,dc=org"}
+		c.UserSearch.NameAttr = "cn"
+		c.UserSearch.EmailAttr = "mail"
+		c.UserSearch.IDAttr = "DN"
+		c.UserSearch.Username = "cn"
+		c.GroupSearch.BaseDN = BaseDNs{"ou=Groups,ou=TestNestedGroups,dc=example,dc=org"}
+		c.GroupSearch.UserMatchers = []UserMatcher{
+			{
+				UserAttr:  "DN",
+				GroupAttr: "member",
+			},
+		}
+		c.GroupSearch.NameAttr = "cn"
+		c.GroupSearch.RecursiveSearch = recursive
+		c.GroupSearch.MaxDepth = maxDepth
+		return c
+	}
+	test := func(groups []string) []subtest {
+		return []subtest{
+			{
+				name:     "validpassword",
+				username: "jane",
+				password: "foo",
+				groups:   true,
+				want: connector.Identity{
+					UserID:        "cn=jane,ou=People,ou=TestNestedGroups,dc=example,dc=org",
+					Username:      "jane",
+					Email:         "janedoe@example.com",
+					EmailVerified: true,
+					Groups:        groups,
+				},
+			},
+		}
+	}
+
+	t.Run("direct groups", func(t *testing.T) {
+		runTests(t, connectLDAP, newConfig(false, 0), test([]string{"developers"}))
+	})
+	t.Run("nested groups", func(t *testing.T) {
+		// The cycle between engineering and staff is only followed once.
+		runTests(t, connectLDAP, newConfig(true, 0), test([]string{"developers", "engineering", "staff"}))
+	})
+	t.Run("max depth", func(t *testing.T) {
+		runTests(t, connectLDAP, newConfig(true, 1), test([]string{"developers", "engineering"}))
+	})
+}
+
 // Test deprecated group to user matching implementation
 // which was left for backward compatibility.
 // See "Config.GroupSearch.UserMatchers" comments for the details
 func TestDeprecatedGroupToUserMatcher(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestDeprecatedGroupToUserMatcher,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestDeprecatedGroupToUserMatcher,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
 	c.UserSearch.Username = "cn"
-	c.GroupSearch.BaseDN = "ou=TestDeprecatedGroupToUserMatcher,dc=example,dc=org"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=TestDeprecatedGroupToUserMatcher,dc=example,dc=org"}
 	c.GroupSearch.UserAttr = "DN"
 	c.GroupSearch.GroupAttr = "member"
 	c.GroupSearch.NameAttr = "cn"
@@ -419,7 +628,7 @@ func TestDeprecatedGroupToUserMatcher(t *testing.T) {
 
 func TestStartTLS(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestStartTLS,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestStartTLS,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
@@ -443,7 +652,7 @@ func TestStartTLS(t *testing.T) {
 
 func TestInsecureSkipVerify(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestInsecureSkipVerify,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestInsecureSkipVerify,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
@@ -467,7 +676,7 @@ func TestInsecureSkipVerify(t *testing.T) {
 
 func TestLDAPS(t *testing.T) {
 	c := &Config{}
-	c.UserSearch.BaseDN = "ou=People,ou=TestLDAPS,dc=example,dc=org"
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,ou=TestLDAPS,dc=example,dc=org"}
 	c.UserSearch.NameAttr = "cn"
 	c.UserSearch.EmailAttr = "mail"
 	c.UserSearch.IDAttr = "DN"
@@ -514,6 +723,150 @@ func TestUsernamePrompt(t *testing.T) {
 	}
 }
 
+func TestExtraAttrs(t *testing.T) {
+	c := &ldapConnector{}
+	c.UserSearch.ExtraAttrs = []string{"department", "employeeType", "manager"}
+
+	user := ldap.Entry{
+		DN: "cn=jane,ou=People,dc=example,dc=org",
+		Attributes: []*ldap.EntryAttribute{
+			{Name: "cn", Values: []string{"jane"}},
+			{Name: "department", Values: []string{"Engineering"}},
+			{Name: "employeeType", Values: []string{"contractor", "intern"}},
+		},
+	}
+	connData, err := c.connectorData("jane", user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data refreshData
+	if err := json.Unmarshal(connData, &data); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"department":   {"Engineering"},
+		"employeeType": {"contractor", "intern"},
+	}
+	if diff := pretty.Compare(want, data.Attributes); diff != "" {
+		t.Errorf("unexpected attributes: %s", diff)
+	}
+	if data.Username != "jane" || data.Entry.DN != user.DN {
+		t.Errorf("expected the user entry to be kept, got %q %q", data.Username, data.Entry.DN)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	tests := map[string]struct {
+		minTLSVersion string
+		cipherSuites  []string
+		wantVersion   uint16
+		wantSuites    []uint16
+		wantErr       bool
+	}{
+		"defaults": {},
+		"min version": {
+			minTLSVersion: "1.2",
+			wantVersion:   tls.VersionTLS12,
+		},
+		"cipher suites": {
+			minTLSVersion: "1.2",
+			cipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			wantVersion:   tls.VersionTLS12,
+			wantSuites:    []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		},
+		"unknown version": {
+			minTLSVersion: "TLS1.2",
+			wantErr:       true,
+		},
+		"unknown cipher suite": {
+			cipherSuites: []string{"TLS_FOO"},
+			wantErr:      true,
+		},
+	}
+
+	for n, d := range tests {
+		t.Run(n, func(t *testing.T) {
+			c := &Config{Host: "ldap.example.com", MinTLSVersion: d.minTLSVersion, CipherSuites: d.cipherSuites}
+			c.UserSearch.BaseDN = BaseDNs{"ou=People,dc=example,dc=org"}
+			c.UserSearch.Username = "cn"
+
+			logger := &logrus.Logger{Out: io.Discard, Formatter: &logrus.TextFormatter{}}
+			conn, err := c.openConnector(logger)
+			if d.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conn.tlsConfig.MinVersion != d.wantVersion {
+				t.Errorf("expected min TLS version %x, got %x", d.wantVersion, conn.tlsConfig.MinVersion)
+			}
+			if diff := pretty.Compare(d.wantSuites, conn.tlsConfig.CipherSuites); diff != "" {
+				t.Errorf("unexpected cipher suites: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBaseDNsUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		config  string
+		want    BaseDNs
+		wantErr bool
+	}{
+		"string": {
+			config: `{"userSearch": {"baseDN": "ou=People,dc=example,dc=org"}}`,
+			want:   BaseDNs{"ou=People,dc=example,dc=org"},
+		},
+		"list": {
+			config: `{"userSearch": {"baseDN": ["ou=Staff,dc=example,dc=org", "ou=Contractors,dc=example,dc=org"]}}`,
+			want:   BaseDNs{"ou=Staff,dc=example,dc=org", "ou=Contractors,dc=example,dc=org"},
+		},
+		"empty string": {
+			config: `{"userSearch": {"baseDN": ""}}`,
+		},
+		"number": {
+			config:  `{"userSearch": {"baseDN": 1}}`,
+			wantErr: true,
+		},
+	}
+
+	for n, d := range tests {
+		t.Run(n, func(t *testing.T) {
+			var c Config
+			err := json.Unmarshal([]byte(d.config), &c)
+			if d.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := pretty.Compare(d.want, c.UserSearch.BaseDN); diff != "" {
+				t.Errorf("unexpected base DNs: %s", diff)
+			}
+		})
+	}
+}
+
+func TestEmptyBaseDN(t *testing.T) {
+	c := &Config{Host: "ldap.example.com"}
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,dc=example,dc=org"}
+	c.UserSearch.Username = "cn"
+	c.GroupSearch.BaseDN = BaseDNs{"ou=Groups,dc=example,dc=org", ""}
+
+	logger := &logrus.Logger{Out: io.Discard, Formatter: &logrus.TextFormatter{}}
+	if _, err := c.openConnector(logger); err == nil {
+		t.Fatal("expected an error for an empty base DN")
+	}
+}
+
 func getenv(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -611,6 +964,17 @@ func runTests(t *testing.T, connMethod connectionMethod, config *Config, tests [
 			if diff := pretty.Compare(test.want, got); diff != "" {
 				t.Errorf("after refresh: %s", diff)
 			}
+
+			// Verify that groups can be looked up without logging in.
+			if test.groups {
+				groups, err := conn.Groups(context.Background(), test.username)
+				if err != nil {
+					t.Fatalf("groups lookup failed: %v", err)
+				}
+				if diff := pretty.Compare(test.want.Groups, groups); diff != "" {
+					t.Errorf("groups lookup: %s", diff)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,111 @@
+package ldap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultIdleTimeout is how long pooled connections are kept unused if the
+// config doesn't set an idle timeout.
+const defaultIdleTimeout = 5 * time.Minute
+
+// pooledConn is the part of *ldap.Conn used by the pool.
+type pooledConn interface {
+	Close()
+	IsClosing() bool
+}
+
+// connPool keeps connections bound as the service account for reuse by later
+// requests, and limits the number of open connections.
+type connPool struct {
+	// dial opens a new connection bound as the service account.
+	dial        func() (pooledConn, error)
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	// open holds a value for every connection in use or being dialed.
+	open chan struct{}
+
+	mu sync.Mutex
+	// idle connections, the least recently used first.
+	idle []idleConn
+}
+
+type idleConn struct {
+	conn  pooledConn
+	since time.Time
+}
+
+func newConnPool(maxConns int, idleTimeout time.Duration, dial func() (pooledConn, error)) *connPool {
+	return &connPool{
+		dial:        dial,
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+		open:        make(chan struct{}, maxConns),
+	}
+}
+
+// get returns an idle connection or dials a new one. If the maximum number of
+// connections is in use, it waits for one to be returned.
+func (p *connPool) get(ctx context.Context) (pooledConn, error) {
+	select {
+	case p.open <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if conn := p.takeIdle(); conn != nil {
+		return conn, nil
+	}
+	conn, err := p.dial()
+	if err != nil {
+		<-p.open
+		return nil, err
+	}
+	return conn, nil
+}
+
+// put returns a connection got from the pool. Connections which can't be
+// reused, for example because they may still be bound as another user, are
+// closed instead.
+func (p *connPool) put(conn pooledConn, reuse bool) {
+	defer func() { <-p.open }()
+
+	if !reuse || conn.IsClosing() {
+		conn.Close()
+		return
+	}
+	p.mu.Lock()
+	p.idle = append(p.idle, idleConn{conn, p.now()})
+	p.mu.Unlock()
+}
+
+// takeIdle returns the most recently used idle connection, closing the ones
+// idle for longer than the idle timeout or closed by the server. It returns
+// nil if there are none.
+func (p *connPool) takeIdle() pooledConn {
+	p.mu.Lock()
+	now := p.now()
+	var expired []pooledConn
+	for len(p.idle) > 0 && now.Sub(p.idle[0].since) > p.idleTimeout {
+		expired = append(expired, p.idle[0].conn)
+		p.idle = p.idle[1:]
+	}
+	var conn pooledConn
+	for conn == nil && len(p.idle) > 0 {
+		n := len(p.idle)
+		if c := p.idle[n-1].conn; c.IsClosing() {
+			expired = append(expired, c)
+		} else {
+			conn = c
+		}
+		p.idle = p.idle[:n-1]
+	}
+	p.mu.Unlock()
+
+	for _, c := range expired {
+		c.Close()
+	}
+	return conn
+}
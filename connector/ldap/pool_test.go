@@ -0,0 +1,99 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConn struct {
+	id      int
+	closing bool
+}
+
+func (c *fakeConn) Close()          { c.closing = true }
+func (c *fakeConn) IsClosing() bool { return c.closing }
+
+func newTestPool(maxConns int) (*connPool, *int) {
+	dialed := 0
+	p := newConnPool(maxConns, time.Minute, func() (pooledConn, error) {
+		dialed++
+		return &fakeConn{id: dialed}, nil
+	})
+	return p, &dialed
+}
+
+func TestConnPoolReuse(t *testing.T) {
+	ctx := context.Background()
+	p, dialed := newTestPool(2)
+
+	conn, err := p.get(ctx)
+	require.NoError(t, err)
+	p.put(conn, true)
+
+	reused, err := p.get(ctx)
+	require.NoError(t, err)
+	require.Same(t, conn, reused, "expected the idle connection to be reused")
+	require.Equal(t, 1, *dialed)
+
+	// A connection which can't be reused is closed rather than kept.
+	p.put(reused, false)
+	require.True(t, reused.IsClosing())
+
+	conn, err = p.get(ctx)
+	require.NoError(t, err)
+	require.NotSame(t, reused, conn)
+	require.Equal(t, 2, *dialed)
+	p.put(conn, true)
+}
+
+func TestConnPoolMaxConnections(t *testing.T) {
+	p, dialed := newTestPool(2)
+
+	conn1, err := p.get(context.Background())
+	require.NoError(t, err)
+	conn2, err := p.get(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.get(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "expected get to wait for a connection to be returned, got %v", err)
+	require.Equal(t, 2, *dialed)
+
+	got := make(chan pooledConn)
+	go func() {
+		conn, err := p.get(context.Background())
+		if err != nil {
+			close(got)
+			return
+		}
+		got <- conn
+	}()
+	p.put(conn1, true)
+	require.Same(t, conn1, <-got)
+	require.Equal(t, 2, *dialed)
+
+	p.put(conn1, true)
+	p.put(conn2, true)
+}
+
+func TestConnPoolIdleTimeout(t *testing.T) {
+	p, dialed := newTestPool(2)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	conn, err := p.get(context.Background())
+	require.NoError(t, err)
+	p.put(conn, true)
+
+	now = now.Add(2 * time.Minute)
+	fresh, err := p.get(context.Background())
+	require.NoError(t, err)
+	require.True(t, conn.IsClosing(), "expected the idle connection to be closed")
+	require.NotSame(t, conn, fresh)
+	require.Equal(t, 2, *dialed)
+}
@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// maxReferralHops limits how many referrals are chased one after another, in
+// case servers refer to each other.
+const maxReferralHops = 5
+
+// search performs the search and, with followReferrals, adds the entries of
+// the referrals returned by the server.
+func (c *ldapConnector) search(conn *ldap.Conn, req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	return c.searchReferrals(conn, req, 0)
+}
+
+func (c *ldapConnector) searchReferrals(conn *ldap.Conn, req *ldap.SearchRequest, hops int) (*ldap.SearchResult, error) {
+	resp, err := conn.Search(req)
+	if err != nil || !c.FollowReferrals {
+		return resp, err
+	}
+
+	for _, referral := range resp.Referrals {
+		if hops >= maxReferralHops {
+			c.logger.Warnf("ldap: not following referral %q, more than %d referrals were followed", referral, maxReferralHops)
+			continue
+		}
+		host, baseDN, err := c.referralTarget(referral)
+		if err != nil {
+			c.logger.Warnf("ldap: not following referral: %v", err)
+			continue
+		}
+
+		referred := *req
+		if baseDN != "" {
+			referred.BaseDN = baseDN
+		}
+		c.logger.Infof("following ldap referral to %s: %s %s %s",
+			host, referred.BaseDN, scopeString(referred.Scope), referred.Filter)
+
+		tlsConfig := c.tlsConfig.Clone()
+		tlsConfig.ServerName, _, _ = net.SplitHostPort(host)
+		referredConn, err := c.dialHost(host, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("ldap: referral to %s: %v", host, err)
+		}
+		referredResp, err := c.searchReferrals(referredConn, &referred, hops+1)
+		referredConn.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ldap: referral to %s: %v", host, err)
+		}
+		resp.Entries = append(resp.Entries, referredResp.Entries...)
+	}
+	resp.Referrals = nil
+	return resp, nil
+}
+
+// referralTarget returns the host and port, and the base DN if there's one,
+// of an LDAP URL returned as a referral. Hosts which aren't listed in the
+// referralHosts option are rejected, since the service account binds to them.
+func (c *ldapConnector) referralTarget(referral string) (host, baseDN string, err error) {
+	u, err := url.Parse(referral)
+	if err != nil {
+		return "", "", fmt.Errorf("malformed referral %q: %v", referral, err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return "", "", fmt.Errorf("referral %q isn't an LDAP URL", referral)
+	}
+	if u.Hostname() == "" {
+		return "", "", fmt.Errorf("referral %q has no host", referral)
+	}
+
+	// Referred servers are connected to like the configured host, so the
+	// port defaults to the one of the configured transport.
+	port := u.Port()
+	if port == "" {
+		port = "636"
+		if c.InsecureNoSSL || c.StartTLS {
+			port = "389"
+		}
+	}
+	host = net.JoinHostPort(u.Hostname(), port)
+	if !c.referralHostAllowed(u.Hostname(), port) {
+		return "", "", fmt.Errorf("referral host %s isn't listed in referralHosts", host)
+	}
+	return host, strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// referralHostAllowed reports whether the host is listed in referralHosts,
+// either with the port or without a port.
+func (c *ldapConnector) referralHostAllowed(hostname, port string) bool {
+	for _, allowed := range c.ReferralHosts {
+		allowedHost, allowedPort, err := net.SplitHostPort(allowed)
+		if err != nil {
+			allowedHost, allowedPort = allowed, ""
+		}
+		if strings.EqualFold(allowedHost, hostname) && (allowedPort == "" || allowedPort == port) {
+			return true
+		}
+	}
+	return false
+}
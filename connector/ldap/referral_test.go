@@ -0,0 +1,94 @@
+package ldap
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferralTarget(t *testing.T) {
+	c := &ldapConnector{Config: Config{
+		FollowReferrals: true,
+		ReferralHosts:   []string{"child.example.com", "other.example.com:3269"},
+	}}
+
+	tests := map[string]struct {
+		referral   string
+		startTLS   bool
+		wantHost   string
+		wantBaseDN string
+		wantErr    bool
+	}{
+		"allowed host": {
+			referral:   "ldap://child.example.com/DC=child,DC=example,DC=com",
+			wantHost:   "child.example.com:636",
+			wantBaseDN: "DC=child,DC=example,DC=com",
+		},
+		"allowed host with StartTLS": {
+			referral:   "ldap://CHILD.example.com/DC=child,DC=example,DC=com",
+			startTLS:   true,
+			wantHost:   "CHILD.example.com:389",
+			wantBaseDN: "DC=child,DC=example,DC=com",
+		},
+		"escaped base DN": {
+			referral:   "ldaps://child.example.com:1636/OU=Sales%20Team,DC=child,DC=example,DC=com??sub",
+			wantHost:   "child.example.com:1636",
+			wantBaseDN: "OU=Sales Team,DC=child,DC=example,DC=com",
+		},
+		"without base DN": {
+			referral: "ldap://child.example.com",
+			wantHost: "child.example.com:636",
+		},
+		"allowed port": {
+			referral:   "ldap://other.example.com:3269/DC=other,DC=example,DC=com",
+			wantHost:   "other.example.com:3269",
+			wantBaseDN: "DC=other,DC=example,DC=com",
+		},
+		"other port": {
+			referral: "ldap://other.example.com:389/DC=other,DC=example,DC=com",
+			wantErr:  true,
+		},
+		"unlisted host": {
+			referral: "ldap://attacker.example.net/DC=example,DC=com",
+			wantErr:  true,
+		},
+		"not an LDAP URL": {
+			referral: "https://child.example.com/",
+			wantErr:  true,
+		},
+		"no host": {
+			referral: "ldap:///DC=example,DC=com",
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c.StartTLS = tc.startTLS
+			host, baseDN, err := c.referralTarget(tc.referral)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHost, host)
+			require.Equal(t, tc.wantBaseDN, baseDN)
+		})
+	}
+}
+
+func TestFollowReferralsRequiresHosts(t *testing.T) {
+	c := &Config{Host: "ldap.example.com", FollowReferrals: true}
+	c.UserSearch.BaseDN = BaseDNs{"ou=People,dc=example,dc=org"}
+	c.UserSearch.Username = "cn"
+
+	logger := &logrus.Logger{Out: io.Discard, Formatter: &logrus.TextFormatter{}}
+	_, err := c.openConnector(logger)
+	require.Error(t, err)
+
+	c.ReferralHosts = []string{"child.example.com"}
+	_, err = c.openConnector(logger)
+	require.NoError(t, err)
+}
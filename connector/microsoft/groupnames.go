@@ -0,0 +1,163 @@
+package microsoft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// graphBatchLimit is the most requests Microsoft Graph accepts in a JSON
+	// batch.
+	//
+	// See: https://docs.microsoft.com/en-us/graph/json-batching
+	graphBatchLimit = 20
+
+	// groupNamesCacheFor is how long the names of groups are cached, so the
+	// logins of users of common groups don't resolve them again.
+	groupNamesCacheFor = 5 * time.Minute
+)
+
+// groupNameCache caches the display names of groups by ID.
+type groupNameCache struct {
+	mu    sync.Mutex
+	names map[string]cachedGroupName
+}
+
+type cachedGroupName struct {
+	name   string
+	expiry time.Time
+}
+
+func newGroupNameCache() *groupNameCache {
+	return &groupNameCache{names: make(map[string]cachedGroupName)}
+}
+
+// get returns the cached name of the group, if it hasn't expired. A nil cache
+// caches nothing.
+func (c *groupNameCache) get(id string, now time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.names[id]
+	if !ok || !now.Before(cached.expiry) {
+		return "", false
+	}
+	return cached.name, true
+}
+
+// set caches the names of the groups, and drops the expired ones.
+func (c *groupNameCache) set(names map[string]string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, cached := range c.names {
+		if !now.Before(cached.expiry) {
+			delete(c.names, id)
+		}
+	}
+	for id, name := range names {
+		c.names[id] = cachedGroupName{name: name, expiry: now.Add(groupNamesCacheFor)}
+	}
+}
+
+type batchRequest struct {
+	ID     string `json:"id"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type batchResponse struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Body   struct {
+		group
+		Error *graphError `json:"error"`
+	} `json:"body"`
+}
+
+// getGroupNames resolves the IDs of groups to their display names, in the
+// order of the IDs. The names which aren't cached are fetched in JSON batches
+// of at most graphBatchLimit requests. IDs which aren't groups, or which
+// were deleted since, are left out.
+func (c *microsoftConnector) getGroupNames(ctx context.Context, client *http.Client, ids []string) (groups []string, err error) {
+	now := time.Now()
+	names := make(map[string]string, len(ids))
+	var unresolved []string
+	for _, id := range ids {
+		if name, ok := c.groupNames.get(id, now); ok {
+			names[id] = name
+		} else {
+			unresolved = append(unresolved, id)
+		}
+	}
+
+	resolved := make(map[string]string, len(unresolved))
+	for start := 0; start < len(unresolved); start += graphBatchLimit {
+		end := start + graphBatchLimit
+		if end > len(unresolved) {
+			end = len(unresolved)
+		}
+		if err := c.getGroupNamesBatch(ctx, client, unresolved[start:end], resolved); err != nil {
+			return nil, err
+		}
+	}
+	c.groupNames.set(resolved, now)
+
+	for _, id := range ids {
+		if name, ok := names[id]; ok {
+			groups = append(groups, name)
+		} else if name, ok := resolved[id]; ok {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// getGroupNamesBatch fetches the names of the groups in a single JSON batch,
+// adding them to names.
+func (c *microsoftConnector) getGroupNamesBatch(ctx context.Context, client *http.Client, ids []string, names map[string]string) error {
+	// The IDs of the requests are the indexes of the group IDs.
+	in := struct {
+		Requests []batchRequest `json:"requests"`
+	}{}
+	for i, id := range ids {
+		in.Requests = append(in.Requests, batchRequest{
+			ID:     strconv.Itoa(i),
+			Method: http.MethodGet,
+			URL:    "/groups/" + url.PathEscape(id) + "?$select=id,displayName",
+		})
+	}
+
+	var out struct {
+		Responses []batchResponse `json:"responses"`
+	}
+	if err := c.postJSON(ctx, client, c.graphURL+"/v1.0/$batch", in, &out); err != nil {
+		return err
+	}
+	for _, resp := range out.Responses {
+		i, err := strconv.Atoi(resp.ID)
+		if err != nil || i < 0 || i >= len(ids) {
+			return fmt.Errorf("microsoft: unexpected batch response ID %q", resp.ID)
+		}
+		switch {
+		case resp.Status == http.StatusOK:
+			names[ids[i]] = resp.Body.Name
+		case resp.Status == http.StatusNotFound:
+			c.logger.Debugf("microsoft: group %q not found, leaving it out", ids[i])
+		case resp.Body.Error != nil:
+			return fmt.Errorf("microsoft: failed to get group %q: %v", ids[i], resp.Body.Error)
+		default:
+			return fmt.Errorf("microsoft: failed to get group %q: status %d", ids[i], resp.Status)
+		}
+	}
+	return nil
+}
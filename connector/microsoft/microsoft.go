@@ -4,6 +4,7 @@ package microsoft
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,8 @@ type GroupNameFormat string
 const (
 	GroupID   GroupNameFormat = "id"
 	GroupName GroupNameFormat = "name"
+	// GroupDisplayName is the same as GroupName.
+	GroupDisplayName GroupNameFormat = "displayName"
 )
 
 const (
@@ -40,6 +43,9 @@ const (
 	// Microsoft requires this scope to return a refresh token
 	// see https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-permissions-and-consent#offline_access
 	scopeOfflineAccess = "offline_access"
+	// Microsoft returns an ID token, with the tenant of the user, for this
+	// scope.
+	scopeOpenID = "openid"
 )
 
 // Config holds configuration options for microsoft logins.
@@ -57,6 +63,11 @@ type Config struct {
 	// PromptType is used for the prompt query parameter.
 	// For valid values, see https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-auth-code-flow#request-an-authorization-code.
 	PromptType string `json:"promptType"`
+
+	// Tenants restricts the logins to users of these tenants, by ID. The
+	// tenant still sets the endpoint users log in through, for example
+	// "organizations" for several work tenants.
+	Tenants []string `json:"tenants"`
 }
 
 // Open returns a strategy for logging in through Microsoft.
@@ -68,6 +79,7 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		clientID:             c.ClientID,
 		clientSecret:         c.ClientSecret,
 		tenant:               c.Tenant,
+		tenants:              c.Tenants,
 		onlySecurityGroups:   c.OnlySecurityGroups,
 		groups:               c.Groups,
 		groupNameFormat:      c.GroupNameFormat,
@@ -75,6 +87,7 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		logger:               logger,
 		emailToLowercase:     c.EmailToLowercase,
 		promptType:           c.PromptType,
+		groupNames:           newGroupNameCache(),
 	}
 	// By default allow logins from both personal and business/school
 	// accounts.
@@ -84,7 +97,7 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 
 	// By default, use group names
 	switch m.groupNameFormat {
-	case "":
+	case "", GroupDisplayName:
 		m.groupNameFormat = GroupName
 	case GroupID, GroupName:
 	default:
@@ -98,6 +111,8 @@ type connectorData struct {
 	AccessToken  string    `json:"accessToken"`
 	RefreshToken string    `json:"refreshToken"`
 	Expiry       time.Time `json:"expiry"`
+	// TenantID is the tenant of the user, if Microsoft returned it.
+	TenantID string `json:"tenantID,omitempty"`
 }
 
 var (
@@ -112,6 +127,7 @@ type microsoftConnector struct {
 	clientID             string
 	clientSecret         string
 	tenant               string
+	tenants              []string
 	onlySecurityGroups   bool
 	groupNameFormat      GroupNameFormat
 	groups               []string
@@ -119,6 +135,7 @@ type microsoftConnector struct {
 	logger               log.Logger
 	emailToLowercase     bool
 	promptType           string
+	groupNames           *groupNameCache
 }
 
 func (c *microsoftConnector) isOrgTenant() bool {
@@ -139,6 +156,10 @@ func (c *microsoftConnector) oauth2Config(scopes connector.Scopes) *oauth2.Confi
 		microsoftScopes = append(microsoftScopes, scopeOfflineAccess)
 	}
 
+	if len(c.tenants) > 0 {
+		microsoftScopes = append(microsoftScopes, scopeOpenID)
+	}
+
 	return &oauth2.Config{
 		ClientID:     c.clientID,
 		ClientSecret: c.clientSecret,
@@ -179,6 +200,14 @@ func (c *microsoftConnector) HandleCallback(s connector.Scopes, r *http.Request)
 		return identity, fmt.Errorf("microsoft: failed to get token: %v", err)
 	}
 
+	tenantID, err := idTokenTenant(token)
+	if err != nil {
+		return identity, fmt.Errorf("microsoft: %v", err)
+	}
+	if err := c.checkTenant(tenantID); err != nil {
+		return identity, err
+	}
+
 	client := oauth2Config.Client(ctx, token)
 
 	user, err := c.user(ctx, client)
@@ -205,11 +234,14 @@ func (c *microsoftConnector) HandleCallback(s connector.Scopes, r *http.Request)
 		identity.Groups = groups
 	}
 
-	if s.OfflineAccess {
-		data := connectorData{
-			AccessToken:  token.AccessToken,
-			RefreshToken: token.RefreshToken,
-			Expiry:       token.Expiry,
+	// The tenant is kept even without offline access, for the server to
+	// route on.
+	if s.OfflineAccess || tenantID != "" {
+		data := connectorData{TenantID: tenantID}
+		if s.OfflineAccess {
+			data.AccessToken = token.AccessToken
+			data.RefreshToken = token.RefreshToken
+			data.Expiry = token.Expiry
 		}
 		connData, err := json.Marshal(data)
 		if err != nil {
@@ -257,6 +289,10 @@ func (c *microsoftConnector) Refresh(ctx context.Context, s connector.Scopes, id
 	if err := json.Unmarshal(identity.ConnectorData, &data); err != nil {
 		return identity, fmt.Errorf("microsoft: unmarshal access token: %v", err)
 	}
+	// The allowed tenants may have changed since the login.
+	if err := c.checkTenant(data.TenantID); err != nil {
+		return identity, err
+	}
 	tok := &oauth2.Token{
 		AccessToken:  data.AccessToken,
 		RefreshToken: data.RefreshToken,
@@ -271,6 +307,7 @@ func (c *microsoftConnector) Refresh(ctx context.Context, s connector.Scopes, id
 				AccessToken:  tok.AccessToken,
 				RefreshToken: tok.RefreshToken,
 				Expiry:       tok.Expiry,
+				TenantID:     data.TenantID,
 			}
 			connData, err := json.Marshal(data)
 			if err != nil {
@@ -299,6 +336,50 @@ func (c *microsoftConnector) Refresh(ctx context.Context, s connector.Scopes, id
 	return identity, nil
 }
 
+// checkTenant fails unless the tenant is allowed, if the tenants are
+// restricted.
+func (c *microsoftConnector) checkTenant(tenantID string) error {
+	if len(c.tenants) == 0 {
+		return nil
+	}
+	if tenantID == "" {
+		return errors.New("microsoft: no tenant ID to check against the allowed tenants")
+	}
+	for _, tenant := range c.tenants {
+		if strings.EqualFold(tenant, tenantID) {
+			return nil
+		}
+	}
+	return fmt.Errorf("microsoft: tenant %q is not allowed", tenantID)
+}
+
+// idTokenTenant returns the "tid" claim of the ID token returned with the
+// token, if there's one. The ID token comes straight from the token endpoint
+// over TLS, so its signature isn't checked.
+//
+// See: https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+func idTokenTenant(token *oauth2.Token) (string, error) {
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return "", nil
+	}
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed ID token: %v", err)
+	}
+	var claims struct {
+		TenantID string `json:"tid"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("malformed ID token claims: %v", err)
+	}
+	return claims.TenantID, nil
+}
+
 // https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/resources/user
 // id                - The unique identifier for the user. Inherited from
 //                     directoryObject. Key. Not nullable. Read-only.
@@ -402,69 +483,45 @@ func (c *microsoftConnector) getGroupIDs(ctx context.Context, client *http.Clien
 	}
 }
 
-func (c *microsoftConnector) getGroupNames(ctx context.Context, client *http.Client, ids []string) (groups []string, err error) {
-	if len(ids) == 0 {
-		return
-	}
-
-	// https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/directoryobject_getbyids
-	in := &struct {
-		IDs   []string `json:"ids"`
-		Types []string `json:"types"`
-	}{ids, []string{"group"}}
-	reqURL := c.graphURL + "/v1.0/directoryObjects/getByIds"
-	for {
-		var out []group
-		var next string
-
-		next, err = c.post(ctx, client, reqURL, in, &out)
-		if err != nil {
-			return groups, err
-		}
-
-		for _, g := range out {
-			groups = append(groups, g.Name)
-		}
-		if next == "" {
-			return
-		}
-		reqURL = next
-	}
+// post posts to a paginated endpoint, decoding the value of the page into
+// out, and returns the link to the next page, if there's one.
+func (c *microsoftConnector) post(ctx context.Context, client *http.Client, reqURL string, in interface{}, out interface{}) (string, error) {
+	var next string
+	err := c.postJSON(ctx, client, reqURL, in, &struct {
+		NextLink *string     `json:"@odata.nextLink"`
+		Value    interface{} `json:"value"`
+	}{&next, out})
+	return next, err
 }
 
-func (c *microsoftConnector) post(ctx context.Context, client *http.Client, reqURL string, in interface{}, out interface{}) (string, error) {
+func (c *microsoftConnector) postJSON(ctx context.Context, client *http.Client, reqURL string, in interface{}, out interface{}) error {
 	var payload bytes.Buffer
 
 	err := json.NewEncoder(&payload).Encode(in)
 	if err != nil {
-		return "", fmt.Errorf("microsoft: JSON encode: %v", err)
+		return fmt.Errorf("microsoft: JSON encode: %v", err)
 	}
 
 	req, err := http.NewRequest("POST", reqURL, &payload)
 	if err != nil {
-		return "", fmt.Errorf("new req: %v", err)
+		return fmt.Errorf("new req: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
-		return "", fmt.Errorf("post URL %v", err)
+		return fmt.Errorf("post URL %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", newGraphError(resp.Body)
+		return newGraphError(resp.Body)
 	}
 
-	var next string
-	if err = json.NewDecoder(resp.Body).Decode(&struct {
-		NextLink *string     `json:"@odata.nextLink"`
-		Value    interface{} `json:"value"`
-	}{&next, out}); err != nil {
-		return "", fmt.Errorf("JSON decode: %v", err)
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("JSON decode: %v", err)
 	}
-
-	return next, nil
+	return nil
 }
 
 type graphError struct {
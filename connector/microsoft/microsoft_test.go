@@ -1,13 +1,19 @@
 package microsoft
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/dexidp/dex/connector"
 )
@@ -63,6 +69,139 @@ func TestUserGroupsFromGraphAPI(t *testing.T) {
 	expectEquals(t, identity.Groups, []string{"a", "b"})
 }
 
+func TestGroupNamesFromGraphAPI(t *testing.T) {
+	// The user is a member of more groups than fit in a batch, one of which
+	// was deleted since.
+	var ids []string
+	for i := 0; i < 25; i++ {
+		ids = append(ids, fmt.Sprintf("group-%d", i))
+	}
+	ids = append(ids, "deleted")
+	graph := newTestServer(map[string]testResponse{
+		"/v1.0/me?$select=id,displayName,userPrincipalName": {data: user{}},
+		"/v1.0/me/getMemberGroups":                          {data: map[string]interface{}{"value": ids}},
+		"/" + tenant + "/oauth2/v2.0/token":                 dummyToken,
+	})
+	defer graph.Close()
+
+	var batches []int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/$batch" {
+			graph.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		var in struct {
+			Requests []batchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, len(in.Requests))
+		var responses []map[string]interface{}
+		for _, req := range in.Requests {
+			id := strings.TrimSuffix(strings.TrimPrefix(req.URL, "/groups/"), "?$select=id,displayName")
+			if id == "deleted" {
+				responses = append(responses, map[string]interface{}{"id": req.ID, "status": http.StatusNotFound})
+				continue
+			}
+			responses = append(responses, map[string]interface{}{
+				"id":     req.ID,
+				"status": http.StatusOK,
+				"body":   map[string]string{"id": id, "displayName": "Name of " + id},
+			})
+		}
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer s.Close()
+
+	config := Config{GroupNameFormat: GroupDisplayName, Tenant: tenant}
+	conn, err := config.Open("microsoft", logrus.New())
+	expectNil(t, err)
+	c := conn.(*microsoftConnector)
+	c.apiURL, c.graphURL = s.URL, s.URL
+
+	var want []string
+	for _, id := range ids[:25] {
+		want = append(want, "Name of "+id)
+	}
+	req, _ := http.NewRequest("GET", s.URL, nil)
+	identity, err := c.HandleCallback(connector.Scopes{Groups: true}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.Groups, want)
+	expectEquals(t, batches, []int{20, 6})
+
+	// The names are cached, only the deleted group is looked up again.
+	identity, err = c.HandleCallback(connector.Scopes{Groups: true}, req)
+	expectNil(t, err)
+	expectEquals(t, identity.Groups, want)
+	expectEquals(t, batches, []int{20, 6, 1})
+
+	if _, ok := c.groupNames.get("group-0", time.Now().Add(groupNamesCacheFor)); ok {
+		t.Error("expected the cached group name to expire")
+	}
+}
+
+func TestTenants(t *testing.T) {
+	const otherTenant = "1a2b3c4d-0000-4e92-bb0d-0571d44ca965"
+	tokenWithTenant := func(tenantID string) testResponse {
+		claims, _ := json.Marshal(map[string]string{"tid": tenantID})
+		return testResponse{data: map[string]interface{}{
+			"access_token": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9",
+			"expires_in":   "30",
+			"id_token":     "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(claims) + ".",
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		tenants    []string
+		token      testResponse
+		wantErr    bool
+		wantTenant string
+	}{
+		{name: "allowed tenant", tenants: []string{otherTenant, tenant}, token: tokenWithTenant(tenant), wantTenant: tenant},
+		{name: "tenant IDs are case insensitive", tenants: []string{"9B1C3439-A67E-4E92-BB0D-0571D44CA965"}, token: tokenWithTenant(tenant), wantTenant: tenant},
+		{name: "other tenant", tenants: []string{otherTenant}, token: tokenWithTenant(tenant), wantErr: true},
+		{name: "no ID token", tenants: []string{tenant}, token: dummyToken, wantErr: true},
+		{name: "unrestricted", token: tokenWithTenant(otherTenant), wantTenant: otherTenant},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestServer(map[string]testResponse{
+				"/v1.0/me?$select=id,displayName,userPrincipalName": {data: user{ID: "S56767889"}},
+				"/" + tenant + "/oauth2/v2.0/token":                 tc.token,
+			})
+			defer s.Close()
+
+			req, _ := http.NewRequest("GET", s.URL, nil)
+
+			c := microsoftConnector{apiURL: s.URL, graphURL: s.URL, tenant: tenant, tenants: tc.tenants}
+			identity, err := c.HandleCallback(connector.Scopes{}, req)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			expectNil(t, err)
+
+			var data connectorData
+			expectNil(t, json.Unmarshal(identity.ConnectorData, &data))
+			expectEquals(t, data.TenantID, tc.wantTenant)
+			expectEquals(t, data.RefreshToken, "")
+		})
+	}
+
+	// Refreshes fail once the tenant of the user isn't allowed anymore.
+	c := microsoftConnector{tenant: tenant, tenants: []string{otherTenant}}
+	connData, _ := json.Marshal(connectorData{RefreshToken: "refresh-token", TenantID: tenant})
+	if _, err := c.Refresh(context.Background(), connector.Scopes{}, connector.Identity{ConnectorData: connData}); err == nil {
+		t.Fatal("expected an error for a tenant which isn't allowed")
+	}
+}
+
 func newTestServer(responses map[string]testResponse) *httptest.Server {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		response, found := responses[r.RequestURI]
@@ -30,6 +30,7 @@ func NewCallbackConnector(logger log.Logger) connector.Connector {
 
 var (
 	_ connector.CallbackConnector = &Callback{}
+	_ connector.LogoutConnector   = &Callback{}
 
 	_ connector.PasswordConnector = passwordConnector{}
 	_ connector.RefreshConnector  = passwordConnector{}
@@ -40,6 +41,9 @@ type Callback struct {
 	// The returned identity.
 	Identity connector.Identity
 	Logger   log.Logger
+
+	// Err is returned by HandleCallback instead of the identity, if set.
+	Err error
 }
 
 // LoginURL returns the URL to redirect the user to login with.
@@ -58,6 +62,10 @@ var connectorData = []byte("foobar")
 
 // HandleCallback parses the request and returns the user's identity
 func (m *Callback) HandleCallback(s connector.Scopes, r *http.Request) (connector.Identity, error) {
+	if m.Err != nil {
+		return connector.Identity{}, m.Err
+	}
+	m.Logger.Debugf("mock: returning identity for user %q", m.Identity.UserID)
 	return m.Identity, nil
 }
 
@@ -66,6 +74,20 @@ func (m *Callback) Refresh(ctx context.Context, s connector.Scopes, identity con
 	return m.Identity, nil
 }
 
+// LogoutURL returns the URL of a fake provider, which would send the user back
+// to the logout callback with the returnURL as the RelayState.
+func (m *Callback) LogoutURL(identity connector.Identity, returnURL string) (string, error) {
+	return "https://upstream.example.com/logout?" + url.Values{"RelayState": {returnURL}}.Encode(), nil
+}
+
+// HandleLogoutResponse returns the RelayState of the logout callback.
+func (m *Callback) HandleLogoutResponse(r *http.Request) (string, error) {
+	if m.Err != nil {
+		return "", m.Err
+	}
+	return r.URL.Query().Get("RelayState"), nil
+}
+
 // CallbackConfig holds the configuration parameters for a connector which requires no interaction.
 type CallbackConfig struct{}
 
@@ -27,6 +27,7 @@ type oauthConnector struct {
 	tokenURL             string
 	authorizationURL     string
 	userInfoURL          string
+	userInfoRootKey      string
 	scopes               []string
 	userIDKey            string
 	userNameKey          string
@@ -53,7 +54,16 @@ type Config struct {
 	RootCAs            []string `json:"rootCAs"`
 	InsecureSkipVerify bool     `json:"insecureSkipVerify"`
 	UserIDKey          string   `json:"userIDKey"` // defaults to "id"
-	ClaimMapping       struct {
+
+	// UserInfoRootKey is the path of the object holding the claims in the
+	// userinfo response, for providers nesting them, like "data.user". The
+	// keys of the claim mapping are relative to it.
+	UserInfoRootKey string `json:"userInfoRootKey"`
+
+	// The keys of the claim mapping may be dot separated paths of nested
+	// claims, like "data.attributes.groups". A "[]" suffix maps the rest of
+	// the path over the elements of an array, like "groups[].name".
+	ClaimMapping struct {
 		UserNameKey          string `json:"userNameKey"`          // defaults to "user_name"
 		PreferredUsernameKey string `json:"preferredUsernameKey"` // defaults to "preferred_username"
 		GroupsKey            string `json:"groupsKey"`            // defaults to "groups"
@@ -101,6 +111,7 @@ func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error)
 		tokenURL:             c.TokenURL,
 		authorizationURL:     c.AuthorizationURL,
 		userInfoURL:          c.UserInfoURL,
+		userInfoRootKey:      c.UserInfoRootKey,
 		scopes:               c.Scopes,
 		redirectURI:          c.RedirectURI,
 		logger:               logger,
@@ -209,16 +220,25 @@ func (c *oauthConnector) HandleCallback(s connector.Scopes, r *http.Request) (id
 		return identity, fmt.Errorf("OAuth Connector: failed to parse userinfo: %v", err)
 	}
 
-	userID, found := userInfoResult[c.userIDKey].(string)
+	if c.userInfoRootKey != "" {
+		root, _ := lookupPath(userInfoResult, c.userInfoRootKey)
+		var ok bool
+		if userInfoResult, ok = root.(map[string]interface{}); !ok {
+			return identity, fmt.Errorf("OAuth Connector: not found %v object in userinfo", c.userInfoRootKey)
+		}
+	}
+
+	userID, found := lookupString(userInfoResult, c.userIDKey)
 	if !found {
 		return identity, fmt.Errorf("OAuth Connector: not found %v claim", c.userIDKey)
 	}
 
 	identity.UserID = userID
-	identity.Username, _ = userInfoResult[c.userNameKey].(string)
-	identity.PreferredUsername, _ = userInfoResult[c.preferredUsernameKey].(string)
-	identity.Email, _ = userInfoResult[c.emailKey].(string)
-	identity.EmailVerified, _ = userInfoResult[c.emailVerifiedKey].(bool)
+	identity.Username, _ = lookupString(userInfoResult, c.userNameKey)
+	identity.PreferredUsername, _ = lookupString(userInfoResult, c.preferredUsernameKey)
+	identity.Email, _ = lookupString(userInfoResult, c.emailKey)
+	emailVerified, _ := lookupPath(userInfoResult, c.emailVerifiedKey)
+	identity.EmailVerified, _ = emailVerified.(bool)
 
 	if s.Groups {
 		groups := map[string]struct{}{}
@@ -244,7 +264,8 @@ func (c *oauthConnector) HandleCallback(s connector.Scopes, r *http.Request) (id
 }
 
 func (c *oauthConnector) addGroupsFromMap(groups map[string]struct{}, result map[string]interface{}) error {
-	groupsClaim, ok := result[c.groupsKey].([]interface{})
+	claim, _ := lookupPath(result, c.groupsKey)
+	groupsClaim, ok := claim.([]interface{})
 	if !ok {
 		return errors.New("cannot convert to slice")
 	}
@@ -283,6 +304,66 @@ func (c *oauthConnector) addGroupsFromToken(groups map[string]struct{}, token st
 	return c.addGroupsFromMap(groups, claimsMap)
 }
 
+// lookupPath returns the value at the dot separated path in the claims, like
+// "data.attributes.groups". A "[]" suffix on an element of the path, like in
+// "groups[].name", maps the rest of the path over the elements of an array.
+// Keys containing dots, like namespaced claims, are found as they are.
+func lookupPath(claims interface{}, path string) (interface{}, bool) {
+	object, ok := claims.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if value, ok := object[path]; ok {
+		return value, true
+	}
+
+	key, rest := path, ""
+	if i := strings.Index(path, "."); i >= 0 {
+		key, rest = path[:i], path[i+1:]
+	}
+	overArray := strings.HasSuffix(key, "[]")
+	value, ok := object[strings.TrimSuffix(key, "[]")]
+	if !ok {
+		return nil, false
+	}
+	if !overArray {
+		if rest == "" {
+			return value, true
+		}
+		return lookupPath(value, rest)
+	}
+
+	elements, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	if rest == "" {
+		return elements, true
+	}
+	values := []interface{}{}
+	for _, element := range elements {
+		value, ok := lookupPath(element, rest)
+		if !ok {
+			continue
+		}
+		// Arrays of the elements are flattened, like the groups of nested
+		// teams.
+		if array, ok := value.([]interface{}); ok {
+			values = append(values, array...)
+		} else {
+			values = append(values, value)
+		}
+	}
+	return values, true
+}
+
+// lookupString returns the string at the path in the claims.
+func lookupString(claims map[string]interface{}, path string) (string, bool) {
+	value, _ := lookupPath(claims, path)
+	s, ok := value.(string)
+	return s, ok
+}
+
 func decode(seg string) ([]byte, error) {
 	if l := len(seg) % 4; l > 0 {
 		seg += strings.Repeat("=", 4-l)
@@ -168,6 +168,82 @@ func TestHandleCallBackForGroupsInToken(t *testing.T) {
 	assert.Equal(t, identity.EmailVerified, false)
 }
 
+func TestHandleCallBackForNestedClaims(t *testing.T) {
+	tokenClaims := map[string]interface{}{
+		"https://example.com/groups": []string{"token-group"},
+	}
+
+	userInfoClaims := map[string]interface{}{
+		"data": map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":       "test-user-id",
+				"username": "test-username",
+			},
+			"mail":               "test-email",
+			"has_verified_email": true,
+			"attributes": map[string]interface{}{
+				"teams": []interface{}{
+					map[string]interface{}{"slug": "admins", "groups": []string{"admin-group"}},
+					map[string]interface{}{"slug": "users", "groups": []string{"user-group", "other-group"}},
+					map[string]interface{}{"slug": "no-groups"},
+				},
+			},
+		},
+	}
+
+	testServer := testSetup(t, tokenClaims, userInfoClaims)
+	defer testServer.Close()
+
+	tests := []struct {
+		name       string
+		groupsKey  string
+		wantGroups []string
+	}{
+		{
+			name:       "array of objects",
+			groupsKey:  "attributes.teams[].slug",
+			wantGroups: []string{"admins", "no-groups", "users"},
+		},
+		{
+			name:       "nested arrays",
+			groupsKey:  "attributes.teams[].groups",
+			wantGroups: []string{"admin-group", "other-group", "user-group"},
+		},
+		{
+			name:       "namespaced claim of the token",
+			groupsKey:  "https://example.com/groups",
+			wantGroups: []string{"token-group"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := newConnectorWithConfig(t, testServer.URL, func(c *Config) {
+				c.UserInfoRootKey = "data"
+				c.UserIDKey = "user.id"
+				c.ClaimMapping.UserNameKey = "user.username"
+				c.ClaimMapping.GroupsKey = tc.groupsKey
+			})
+			req := newRequestWithAuthCode(t, testServer.URL, "some-code")
+
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			assert.Equal(t, err, nil)
+
+			sort.Strings(identity.Groups)
+			assert.Equal(t, tc.wantGroups, identity.Groups)
+			assert.Equal(t, identity.UserID, "test-user-id")
+			assert.Equal(t, identity.Username, "test-username")
+			assert.Equal(t, identity.Email, "test-email")
+			assert.Equal(t, identity.EmailVerified, true)
+		})
+	}
+
+	conn := newConnectorWithConfig(t, testServer.URL, func(c *Config) {
+		c.UserInfoRootKey = "data.missing"
+	})
+	_, err := conn.HandleCallback(connector.Scopes{}, newRequestWithAuthCode(t, testServer.URL, "some-code"))
+	assert.Error(t, err)
+}
+
 func testSetup(t *testing.T, tokenClaims map[string]interface{}, userInfoClaims map[string]interface{}) *httptest.Server {
 	key, err := rsa.GenerateKey(rand.Reader, 1024)
 	if err != nil {
@@ -226,6 +302,12 @@ func newToken(key *jose.JSONWebKey, claims map[string]interface{}) (string, erro
 }
 
 func newConnector(t *testing.T, serverURL string) *oauthConnector {
+	return newConnectorWithConfig(t, serverURL, nil)
+}
+
+// newConnectorWithConfig returns a connector with the test config, updated
+// by the function if any.
+func newConnectorWithConfig(t *testing.T, serverURL string, update func(c *Config)) *oauthConnector {
 	testConfig := Config{
 		ClientID:         "testClient",
 		ClientSecret:     "testSecret",
@@ -241,6 +323,9 @@ func newConnector(t *testing.T, serverURL string) *oauthConnector {
 	testConfig.ClaimMapping.GroupsKey = "groups_key"
 	testConfig.ClaimMapping.EmailKey = "mail"
 	testConfig.ClaimMapping.EmailVerifiedKey = "has_verified_email"
+	if update != nil {
+		update(&testConfig)
+	}
 
 	log := logrus.New()
 
@@ -0,0 +1,133 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// backChannelLogoutEvent is the member of the events claim identifying a JWT
+// as a logout token.
+const backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// HandleBackChannelLogout verifies the logout token the provider posted, and
+// returns an identity whose connector data holds the subject and the session
+// ID of the logout token, whichever it has. The user ID is left empty, as the
+// one of logins may be mapped from other claims than the subject.
+//
+// See: https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation
+func (c *oidcConnector) HandleBackChannelLogout(r *http.Request) (connector.Identity, error) {
+	var loggedOut connector.Identity
+	if r.Method != http.MethodPost {
+		return loggedOut, fmt.Errorf("oidc: logout tokens must be posted, not sent with %s", r.Method)
+	}
+	rawToken := r.PostFormValue("logout_token")
+	if rawToken == "" {
+		return loggedOut, errors.New("oidc: no logout_token in request")
+	}
+	// Providers encrypt logout tokens like ID tokens, if they do at all.
+	if c.idTokenDecrypter != nil && strings.Count(rawToken, ".") == 4 {
+		var err error
+		if rawToken, err = c.idTokenDecrypter.decrypt(rawToken); err != nil {
+			return loggedOut, fmt.Errorf("oidc: failed to decrypt logout token: %v", err)
+		}
+	}
+
+	ctx := c.withCorrelationID(r.Context(), requestCorrelationID(r))
+	ctx = oidc.ClientContext(ctx, c.httpClient)
+	logger := c.loggerFrom(ctx)
+	logger.Debugf("oidc: verifying the logout token")
+	token, err := c.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return loggedOut, fmt.Errorf("oidc: failed to verify logout token: %v", err)
+	}
+	if !c.insecureSkipIssuerCheck && !issuersMatch(c.issuer, token.Issuer) {
+		return loggedOut, fmt.Errorf("oidc: failed to verify logout token: issued by a different provider, expected %q got %q", c.issuer, token.Issuer)
+	}
+	if len(c.allowedAudiences) > 0 && !c.audienceAllowed(token.Audience) {
+		return loggedOut, fmt.Errorf("oidc: failed to verify logout token: none of the audiences %q is allowed", token.Audience)
+	}
+
+	var claims map[string]interface{}
+	if err := token.Claims(&claims); err != nil {
+		return loggedOut, fmt.Errorf("oidc: failed to decode logout token claims: %v", err)
+	}
+	if err := c.checkLogoutTokenClaims(token, claims, time.Now()); err != nil {
+		return loggedOut, fmt.Errorf("oidc: invalid logout token: %v", err)
+	}
+
+	sid, _ := claims["sid"].(string)
+	if loggedOut.ConnectorData, err = json.Marshal(connectorData{Subject: token.Subject, SessionID: sid}); err != nil {
+		return loggedOut, fmt.Errorf("oidc: failed to encode connector data: %v", err)
+	}
+	logger.Debugf("oidc: logout of user %q, session %q", token.Subject, sid)
+	return loggedOut, nil
+}
+
+// checkLogoutTokenClaims checks the claims telling logout tokens apart from ID
+// tokens, and the claims naming the logged out user or session.
+func (c *oidcConnector) checkLogoutTokenClaims(token *oidc.IDToken, claims map[string]interface{}, now time.Time) error {
+	events, ok := claims["events"].(map[string]interface{})
+	if !ok {
+		return errors.New("no events claim")
+	}
+	if _, ok := events[backChannelLogoutEvent].(map[string]interface{}); !ok {
+		return fmt.Errorf("events claim has no %q member", backChannelLogoutEvent)
+	}
+	// Logout tokens must not be accepted as ID tokens, and the other way
+	// round.
+	if _, found := claims["nonce"]; found {
+		return errors.New("logout tokens must not have a nonce claim")
+	}
+	if sid, _ := claims["sid"].(string); token.Subject == "" && sid == "" {
+		return errors.New("neither a sub nor a sid claim")
+	}
+
+	if token.IssuedAt.IsZero() {
+		return errors.New("no iat claim")
+	}
+	if now.Add(c.clockSkew).Before(token.IssuedAt) {
+		return fmt.Errorf("issued in the future (Issued At: %v)", token.IssuedAt)
+	}
+	if _, found := claims["exp"]; found && token.Expiry.Before(now.Add(-c.clockSkew)) {
+		return fmt.Errorf("token is expired (Token Expiry: %v)", token.Expiry)
+	}
+	return nil
+}
+
+// InSession reports whether a login belongs to the user or the session of a
+// logout token. A logout token naming both only logs out that session of the
+// user.
+func (c *oidcConnector) InSession(loggedOut, identity connector.Identity) bool {
+	var session, login connectorData
+	if err := json.Unmarshal(loggedOut.ConnectorData, &session); err != nil {
+		return false
+	}
+	if session.Subject == "" && session.SessionID == "" {
+		return false
+	}
+	if len(identity.ConnectorData) > 0 {
+		if err := json.Unmarshal(identity.ConnectorData, &login); err != nil {
+			return false
+		}
+	}
+	if session.Subject != "" {
+		// Logins from before the subject was kept only have their user ID,
+		// which is the subject unless mapped from other claims.
+		subject := login.Subject
+		if subject == "" {
+			subject = identity.UserID
+		}
+		if subject != session.Subject {
+			return false
+		}
+	}
+	return session.SessionID == "" || login.SessionID == session.SessionID
+}
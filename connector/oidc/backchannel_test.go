@@ -0,0 +1,233 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestBackChannelLogout(t *testing.T) {
+	const issuer = "https://issuer.example.com"
+
+	newKey := func(keyID string) *jose.JSONWebKey {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		return &jose.JSONWebKey{Key: key, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+	}
+	signingKey, otherKey := newKey("current"), newKey("other")
+	keySet, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{signingKey.Public()}})
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := newToken(signingKey, map[string]interface{}{
+			"iss":            issuer,
+			"aud":            "clientID",
+			"sub":            "subvalue",
+			"sid":            "session-1",
+			"name":           "namevalue",
+			"email":          "emailvalue",
+			"email_verified": true,
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"id_token":     token,
+			"token_type":   "Bearer",
+		})
+	}))
+	defer testServer.Close()
+
+	config := Config{
+		Issuer:       issuer,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  testServer.URL + "/callback",
+		StaticKeys:   string(keySet),
+		Endpoints: Endpoints{
+			AuthURL:           testServer.URL + "/authorize",
+			TokenURL:          testServer.URL + "/token",
+			InsecureAllowHTTP: true,
+		},
+	}
+	c, err := config.Open("oidc", logrus.New())
+	require.NoError(t, err)
+	conn := c.(*oidcConnector)
+	defer conn.Close()
+
+	// The session ID of the login is kept in the connector data.
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	login, err := conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+
+	claims := func(update func(claims map[string]interface{})) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss": issuer,
+			"aud": "clientID",
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(2 * time.Minute).Unix(),
+			"jti": "logout-1",
+			"sub": "subvalue",
+			"sid": "session-1",
+			"events": map[string]interface{}{
+				backChannelLogoutEvent: map[string]interface{}{},
+			},
+		}
+		if update != nil {
+			update(claims)
+		}
+		return claims
+	}
+	logoutOf := func(conn *oidcConnector, key *jose.JSONWebKey, claims map[string]interface{}) (connector.Identity, error) {
+		token, err := newToken(key, claims)
+		require.NoError(t, err)
+		form := url.Values{"logout_token": {token}}
+		r := httptest.NewRequest(http.MethodPost, "/logout/backchannel/oidc", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return conn.HandleBackChannelLogout(r)
+	}
+	logout := func(key *jose.JSONWebKey, claims map[string]interface{}) (connector.Identity, error) {
+		return logoutOf(conn, key, claims)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		loggedOut, err := logout(signingKey, claims(nil))
+		require.NoError(t, err)
+		assert.Empty(t, loggedOut.UserID, "the user ID of logins may be mapped from other claims")
+		assert.True(t, conn.InSession(loggedOut, login))
+
+		otherSession := connector.Identity{UserID: "subvalue", ConnectorData: []byte(`{"SessionID":"session-2"}`)}
+		assert.False(t, conn.InSession(loggedOut, otherSession), "only the session of the logout token is logged out")
+	})
+
+	t.Run("session only", func(t *testing.T) {
+		loggedOut, err := logout(signingKey, claims(func(claims map[string]interface{}) {
+			delete(claims, "sub")
+		}))
+		require.NoError(t, err)
+		assert.Empty(t, loggedOut.UserID)
+		assert.True(t, conn.InSession(loggedOut, login))
+	})
+
+	t.Run("user only", func(t *testing.T) {
+		loggedOut, err := logout(signingKey, claims(func(claims map[string]interface{}) {
+			delete(claims, "sid")
+		}))
+		require.NoError(t, err)
+		assert.True(t, conn.InSession(loggedOut, login))
+		assert.True(t, conn.InSession(loggedOut, connector.Identity{UserID: "subvalue"}), "all sessions of the user are logged out")
+		assert.False(t, conn.InSession(loggedOut, connector.Identity{UserID: "othersub"}))
+	})
+
+	t.Run("mapped user ID", func(t *testing.T) {
+		config := config
+		config.UserIDKey = "email"
+		c, err := config.Open("oidc", logrus.New())
+		require.NoError(t, err)
+		conn := c.(*oidcConnector)
+		defer conn.Close()
+
+		req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+		require.NoError(t, err)
+		login, err := conn.HandleCallback(connector.Scopes{}, req)
+		require.NoError(t, err)
+		require.Equal(t, "emailvalue", login.UserID)
+
+		// The logout token names the upstream subject, not the user ID.
+		for _, update := range []func(claims map[string]interface{}){
+			nil,
+			func(claims map[string]interface{}) { delete(claims, "sid") },
+		} {
+			loggedOut, err := logoutOf(conn, signingKey, claims(update))
+			require.NoError(t, err)
+			assert.True(t, conn.InSession(loggedOut, login))
+
+			otherUser := connector.Identity{UserID: "emailvalue", ConnectorData: []byte(`{"Subject":"othersub","SessionID":"session-1"}`)}
+			assert.False(t, conn.InSession(loggedOut, otherUser))
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		key    *jose.JSONWebKey
+		update func(claims map[string]interface{})
+	}{
+		{
+			name: "signed with another key",
+			key:  otherKey,
+		},
+		{
+			name:   "other issuer",
+			update: func(claims map[string]interface{}) { claims["iss"] = "https://other.example.com" },
+		},
+		{
+			name:   "other audience",
+			update: func(claims map[string]interface{}) { claims["aud"] = "otherClientID" },
+		},
+		{
+			name:   "no events",
+			update: func(claims map[string]interface{}) { delete(claims, "events") },
+		},
+		{
+			name: "other event",
+			update: func(claims map[string]interface{}) {
+				claims["events"] = map[string]interface{}{"https://example.com/other-event": map[string]interface{}{}}
+			},
+		},
+		{
+			name:   "nonce",
+			update: func(claims map[string]interface{}) { claims["nonce"] = "abc" },
+		},
+		{
+			name: "neither sub nor sid",
+			update: func(claims map[string]interface{}) {
+				delete(claims, "sub")
+				delete(claims, "sid")
+			},
+		},
+		{
+			name:   "no iat",
+			update: func(claims map[string]interface{}) { delete(claims, "iat") },
+		},
+		{
+			name:   "expired",
+			update: func(claims map[string]interface{}) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			key := signingKey
+			if tc.key != nil {
+				key = tc.key
+			}
+			_, err := logout(key, claims(tc.update))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("not posted", func(t *testing.T) {
+		token, err := newToken(signingKey, claims(nil))
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodGet, "/logout/backchannel/oidc?logout_token="+token, nil)
+		_, err = conn.HandleBackChannelLogout(r)
+		require.Error(t, err)
+	})
+}
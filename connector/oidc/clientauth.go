@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// Methods of authenticating to the token endpoint of the upstream provider.
+const (
+	clientAuthMethodBasic      = "client_secret_basic"
+	clientAuthMethodPost       = "client_secret_post"
+	clientAuthMethodPrivateJWT = "private_key_jwt"
+)
+
+const (
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// clientAssertionLifetime is how long a client assertion is valid. A
+	// new one is signed for every token request.
+	clientAssertionLifetime = 5 * time.Minute
+)
+
+// clientAssertionClaims are the claims of a private_key_jwt client assertion.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#ClientAuthentication
+type clientAssertionClaims struct {
+	Issuer   string `json:"iss"`
+	Subject  string `json:"sub"`
+	Audience string `json:"aud"`
+	ID       string `json:"jti"`
+	IssuedAt int64  `json:"iat"`
+	Expiry   int64  `json:"exp"`
+}
+
+// clientAssertionSigner signs client assertions with the private key of the
+// client.
+type clientAssertionSigner struct {
+	clientID string
+	tokenURL string
+	signer   jose.Signer
+	now      func() time.Time
+}
+
+// newClientAssertionSigner loads the PEM encoded RSA or ECDSA private key
+// from keyFile.
+func newClientAssertionSigner(clientID, tokenURL, keyFile string) (*clientAssertionSigner, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in signing key file %q", keyFile)
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %v", err)
+	}
+
+	var alg jose.SignatureAlgorithm
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			alg = jose.ES256
+		case elliptic.P384():
+			alg = jose.ES384
+		case elliptic.P521():
+			alg = jose.ES512
+		default:
+			return nil, fmt.Errorf("unsupported ecdsa curve %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %v", err)
+	}
+	return &clientAssertionSigner{
+		clientID: clientID,
+		tokenURL: tokenURL,
+		signer:   signer,
+		now:      time.Now,
+	}, nil
+}
+
+func parsePrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("key is neither PKCS #8, PKCS #1 nor an EC private key")
+}
+
+// sign returns a new client assertion for the token endpoint.
+func (s *clientAssertionSigner) sign() (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := s.now()
+	payload, err := json.Marshal(clientAssertionClaims{
+		Issuer:   s.clientID,
+		Subject:  s.clientID,
+		Audience: s.tokenURL,
+		ID:       base64.RawURLEncoding.EncodeToString(jti),
+		IssuedAt: now.Unix(),
+		Expiry:   now.Add(clientAssertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	jws, err := s.signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return jws.CompactSerialize()
+}
+
+// clientAssertionTransport adds a client assertion to the requests to the
+// token endpoint, both for code exchanges and refreshes. Other requests are
+// sent unchanged.
+type clientAssertionTransport struct {
+	base   http.RoundTripper
+	signer *clientAssertionSigner
+}
+
+func (t *clientAssertionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || req.Body == nil || !sameEndpoint(req.URL, t.signer.tokenURL) {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to parse token request: %v", err)
+	}
+	assertion, err := t.signer.sign()
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to sign client assertion: %v", err)
+	}
+	form.Set("client_assertion_type", clientAssertionType)
+	form.Set("client_assertion", assertion)
+
+	body = []byte(form.Encode())
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return t.base.RoundTrip(req)
+}
+
+// sameEndpoint reports whether u points to the endpoint, ignoring the query.
+func sameEndpoint(u *url.URL, endpoint string) bool {
+	e, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == e.Scheme && u.Host == e.Host && u.Path == e.Path
+}
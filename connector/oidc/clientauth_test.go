@@ -0,0 +1,119 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestPrivateKeyJWT(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	upstream, err := setupServer(token)
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	type tokenRequest struct {
+		form          url.Values
+		authorization string
+	}
+	requests := make(chan tokenRequest, 1)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			r.ParseForm()
+			requests <- tokenRequest{r.PostForm, r.Header.Get("Authorization")}
+		}
+		upstream.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer testServer.Close()
+
+	basicAuthUnsupported := false
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		ClientAuthMethod:     "private_key_jwt",
+		SigningKeyFile:       keyFile,
+		BasicAuthUnsupported: &basicAuthUnsupported,
+	})
+	require.NoError(t, err)
+
+	checkRequest := func(t *testing.T, req tokenRequest) {
+		assert.Empty(t, req.authorization, "no basic auth expected")
+		assert.NotContains(t, req.form, "client_secret")
+		assert.Equal(t, "clientID", req.form.Get("client_id"))
+		assert.Equal(t, clientAssertionType, req.form.Get("client_assertion_type"))
+
+		jws, err := jose.ParseSigned(req.form.Get("client_assertion"))
+		require.NoError(t, err)
+		payload, err := jws.Verify(&key.PublicKey)
+		require.NoError(t, err)
+		var claims clientAssertionClaims
+		require.NoError(t, json.Unmarshal(payload, &claims))
+		assert.Equal(t, "clientID", claims.Issuer)
+		assert.Equal(t, "clientID", claims.Subject)
+		assert.Equal(t, testServer.URL+"/token", claims.Audience)
+		assert.NotEmpty(t, claims.ID)
+		assert.Equal(t, int64(clientAssertionLifetime/time.Second), claims.Expiry-claims.IssuedAt)
+	}
+
+	t.Run("callback", func(t *testing.T) {
+		req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+		require.NoError(t, err)
+		_, err = conn.HandleCallback(connector.Scopes{}, req)
+		tokenReq := <-requests
+		require.NoError(t, err)
+		assert.Equal(t, "authorization_code", tokenReq.form.Get("grant_type"))
+		checkRequest(t, tokenReq)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		connData, err := json.Marshal(connectorData{RefreshToken: []byte("refreshToken")})
+		require.NoError(t, err)
+		identity := connector.Identity{UserID: "subvalue", ConnectorData: connData}
+		_, err = conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true}, identity)
+		tokenReq := <-requests
+		require.NoError(t, err)
+		assert.Equal(t, "refresh_token", tokenReq.form.Get("grant_type"))
+		checkRequest(t, tokenReq)
+	})
+}
+
+func TestInvalidClientAuthMethod(t *testing.T) {
+	tests := map[string]Config{
+		"unknown method":   {ClientAuthMethod: "client_secret_jwt"},
+		"no key file":      {ClientAuthMethod: "private_key_jwt"},
+		"missing key file": {ClientAuthMethod: "private_key_jwt", SigningKeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+	}
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newConnector(config)
+			assert.Error(t, err)
+		})
+	}
+}
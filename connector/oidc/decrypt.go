@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// Key management algorithms ID tokens can be encrypted with, for each type of
+// decryption key.
+var (
+	rsaKeyAlgorithms = []jose.KeyAlgorithm{jose.RSA_OAEP, jose.RSA_OAEP_256}
+	ecKeyAlgorithms  = []jose.KeyAlgorithm{jose.ECDH_ES, jose.ECDH_ES_A128KW, jose.ECDH_ES_A192KW, jose.ECDH_ES_A256KW}
+)
+
+// idTokenDecrypter decrypts ID tokens encrypted by the provider as nested
+// JWTs, a signed JWT inside a JWE.
+//
+// See: https://openid.net/specs/openid-connect-core-1_0.html#SigningOrder
+type idTokenDecrypter struct {
+	key        interface{}
+	algorithms []jose.KeyAlgorithm
+}
+
+// newIDTokenDecrypter loads the PEM encoded RSA or ECDSA private key from
+// keyFile.
+func newIDTokenDecrypter(keyFile string) (*idTokenDecrypter, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decryption key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in decryption key file %q", keyFile)
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse decryption key: %v", err)
+	}
+
+	d := &idTokenDecrypter{key: key}
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		d.algorithms = rsaKeyAlgorithms
+	case *ecdsa.PrivateKey:
+		d.algorithms = ecKeyAlgorithms
+	default:
+		return nil, fmt.Errorf("unsupported decryption key type %T", key)
+	}
+	return d, nil
+}
+
+// decrypt returns the signed JWT inside the encrypted ID token. The signature
+// isn't verified.
+func (d *idTokenDecrypter) decrypt(rawIDToken string) (string, error) {
+	// The compact serialization of a JWE has five parts, the one of a JWS
+	// three.
+	if strings.Count(rawIDToken, ".") != 4 {
+		return "", errors.New("ID token isn't encrypted")
+	}
+	jwe, err := jose.ParseEncrypted(rawIDToken)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted ID token: %v", err)
+	}
+	alg := jose.KeyAlgorithm(jwe.Header.Algorithm)
+	if !containsKeyAlgorithm(d.algorithms, alg) {
+		return "", fmt.Errorf("unsupported key management algorithm %q", alg)
+	}
+	payload, err := jwe.Decrypt(d.key)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func containsKeyAlgorithm(algs []jose.KeyAlgorithm, alg jose.KeyAlgorithm) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+
+	"github.com/dexidp/dex/pkg/log"
+)
+
+const (
+	defaultDiscoveryBackoff    = time.Second
+	defaultDiscoveryMaxBackoff = 30 * time.Second
+)
+
+// DiscoveryRetry retries fetching the discovery document when the connector
+// is opened, for providers which may not be reachable yet when dex starts,
+// instead of failing the startup. Unlike the retries of the httpClientConfig,
+// it retries any failure, and only at startup.
+type DiscoveryRetry struct {
+	// MaxAttempts is the number of times the discovery document is fetched
+	// before giving up. Defaults to a single attempt.
+	MaxAttempts int `json:"maxAttempts"`
+
+	// Backoff is the delay before the second attempt, for example "2s". It
+	// doubles with every further attempt, and each delay is shortened by up
+	// to half at random, so replicas don't retry at once. Defaults to 1s.
+	Backoff string `json:"backoff"`
+
+	// MaxBackoff caps the delay between attempts. Defaults to 30s.
+	MaxBackoff string `json:"maxBackoff"`
+}
+
+// discoveryRetrier fetches the discovery document with the retry policy.
+type discoveryRetrier struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+func (c *DiscoveryRetry) newRetrier() (*discoveryRetrier, error) {
+	r := &discoveryRetrier{
+		maxAttempts: 1,
+		backoff:     defaultDiscoveryBackoff,
+		maxBackoff:  defaultDiscoveryMaxBackoff,
+	}
+	if c.MaxAttempts < 0 {
+		return nil, fmt.Errorf("invalid maxAttempts %d", c.MaxAttempts)
+	}
+	if c.MaxAttempts > 0 {
+		r.maxAttempts = c.MaxAttempts
+	}
+	var err error
+	if c.Backoff != "" {
+		if r.backoff, err = time.ParseDuration(c.Backoff); err != nil || r.backoff <= 0 {
+			return nil, fmt.Errorf("invalid backoff %q", c.Backoff)
+		}
+	}
+	if c.MaxBackoff != "" {
+		if r.maxBackoff, err = time.ParseDuration(c.MaxBackoff); err != nil || r.maxBackoff <= 0 {
+			return nil, fmt.Errorf("invalid maxBackoff %q", c.MaxBackoff)
+		}
+	}
+	return r, nil
+}
+
+// newProvider fetches the discovery document of the issuer, retrying failed
+// attempts. It returns the error of the last attempt once all failed.
+func (r *discoveryRetrier) newProvider(ctx context.Context, issuer string, logger log.Logger) (*oidc.Provider, error) {
+	backoff := r.backoff
+	for attempt := 1; ; attempt++ {
+		provider, err := oidc.NewProvider(ctx, issuer)
+		if err == nil || attempt >= r.maxAttempts {
+			return provider, err
+		}
+
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+		wait := backoff - time.Duration(rand.Int63n(int64(backoff)/2+1))
+		logger.Warnf("oidc: attempt %d of %d to fetch the discovery document of %q failed, retrying in %v: %v", attempt, r.maxAttempts, issuer, wait, err)
+		backoff *= 2
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
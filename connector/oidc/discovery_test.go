@@ -0,0 +1,78 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveryRetry(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue"}
+	handler, err := newTestProviderHandler(token, nil, false, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		failures     int32
+		retry        DiscoveryRetry
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "single attempt by default",
+			failures:     1,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "succeeds after failures",
+			failures:     2,
+			retry:        DiscoveryRetry{MaxAttempts: 3, Backoff: "1ms"},
+			wantAttempts: 3,
+		},
+		{
+			name:         "attempts exhausted",
+			failures:     5,
+			retry:        DiscoveryRetry{MaxAttempts: 3, Backoff: "1ms", MaxBackoff: "2ms"},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/.well-known/openid-configuration" && atomic.AddInt32(&attempts, 1) <= tc.failures {
+					http.Error(w, "provider starting", http.StatusServiceUnavailable)
+					return
+				}
+				handler.ServeHTTP(w, r)
+			}))
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:         testServer.URL,
+				ClientID:       "clientID",
+				ClientSecret:   "clientSecret",
+				RedirectURI:    fmt.Sprintf("%s/callback", testServer.URL),
+				DiscoveryRetry: tc.retry,
+			})
+			require.Equal(t, tc.wantAttempts, atomic.LoadInt32(&attempts))
+			if tc.wantErr {
+				// The error of the last attempt is returned.
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "provider starting")
+				return
+			}
+			require.NoError(t, err)
+			conn.Close()
+		})
+	}
+
+	_, err = newConnector(Config{Issuer: "https://issuer.example.com", DiscoveryRetry: DiscoveryRetry{Backoff: "soon"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxDistributedClaimsSize limits the size of the responses of distributed
+// claims endpoints.
+const maxDistributedClaimsSize = 1 << 20
+
+// resolveDistributedClaims fetches the groups claims the provider left out of
+// the ID token in favor of a reference to another endpoint, as Azure AD does
+// for users in too many groups. The endpoint is called with the access token
+// of the claim source or, if it has none, the one of the token response.
+//
+// Failures are returned rather than leaving the groups out.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#AggregatedDistributedClaims
+func (c *oidcConnector) resolveDistributedClaims(ctx context.Context, claims map[string]interface{}, accessToken string) error {
+	claimNames, _ := claims["_claim_names"].(map[string]interface{})
+	if len(claimNames) == 0 {
+		return nil
+	}
+	claimSources, _ := claims["_claim_sources"].(map[string]interface{})
+
+	for _, key := range append([]string{"groups"}, c.groupsKeys...) {
+		if _, found := claims[key]; found {
+			continue
+		}
+		sourceName, ok := claimNames[key].(string)
+		if !ok {
+			continue
+		}
+		source, ok := claimSources[sourceName].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("oidc: claim source %q of the %q claim not found", sourceName, key)
+		}
+		endpoint, _ := source["endpoint"].(string)
+		if endpoint == "" {
+			return fmt.Errorf("oidc: claim source %q of the %q claim has no endpoint, aggregated claims are not supported", sourceName, key)
+		}
+		token := accessToken
+		if sourceToken, ok := source["access_token"].(string); ok && sourceToken != "" {
+			token = sourceToken
+		}
+
+		values, err := c.fetchDistributedClaims(ctx, endpoint, token)
+		if err != nil {
+			return fmt.Errorf("oidc: failed to resolve the %q claim from %s: %v", key, endpoint, err)
+		}
+		v, found := values[key]
+		if !found {
+			// Microsoft Graph returns the groups as "value".
+			v, found = values["value"]
+		}
+		if !found {
+			return fmt.Errorf("oidc: claim source %s returned no %q claim", endpoint, key)
+		}
+		claims[key] = v
+	}
+	return nil
+}
+
+// fetchDistributedClaims returns the claims of a distributed claims endpoint,
+// either plain JSON or a JWT. The JWT is trusted as it's fetched from the
+// endpoint directly.
+func (c *oidcConnector) fetchDistributedClaims(ctx context.Context, endpoint, accessToken string) (map[string]interface{}, error) {
+	var req *http.Request
+	var err error
+	if strings.HasSuffix(endpoint, "/getMemberObjects") {
+		// The Azure AD Graph API only returns the groups for POST requests.
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(`{"securityEnabledOnly":false}`))
+		if req != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDistributedClaimsSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/jwt" {
+		parts := strings.Split(string(bytes.TrimSpace(body)), ".")
+		if len(parts) != 3 {
+			return nil, errors.New("malformed jwt")
+		}
+		if body, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+			return nil, fmt.Errorf("malformed jwt payload: %v", err)
+		}
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode claims: %v", err)
+	}
+	return values, nil
+}
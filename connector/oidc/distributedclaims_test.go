@@ -0,0 +1,170 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestDistributedClaims(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	upstream, err := setupServer(token)
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	var (
+		authorization string
+		status        int
+	)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/groups":
+			authorization = r.Header.Get("Authorization")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{"groups": []string{"group1", "group2"}})
+		case "/getMemberObjects":
+			authorization = r.Header.Get("Authorization")
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"value": []string{"group3"}})
+		default:
+			upstream.Config.Handler.ServeHTTP(w, r)
+		}
+	}))
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:                   testServer.URL,
+		ClientID:                 "clientID",
+		ClientSecret:             "clientSecret",
+		RedirectURI:              fmt.Sprintf("%s/callback", testServer.URL),
+		InsecureEnableGroups:     true,
+		ResolveDistributedClaims: true,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		groups         []string
+		source         map[string]interface{}
+		status         int
+		wantGroups     []string
+		wantAuthPrefix string
+		wantErr        bool
+	}{
+		{
+			name:           "endpoint",
+			source:         map[string]interface{}{"endpoint": testServer.URL + "/groups"},
+			wantGroups:     []string{"group1", "group2"},
+			wantAuthPrefix: "Bearer ey",
+		},
+		{
+			name:           "access token in source",
+			source:         map[string]interface{}{"endpoint": testServer.URL + "/groups", "access_token": "sourcetoken"},
+			wantGroups:     []string{"group1", "group2"},
+			wantAuthPrefix: "Bearer sourcetoken",
+		},
+		{
+			name:           "azure ad graph",
+			source:         map[string]interface{}{"endpoint": testServer.URL + "/getMemberObjects"},
+			wantGroups:     []string{"group3"},
+			wantAuthPrefix: "Bearer ey",
+		},
+		{
+			name:       "groups in the token",
+			groups:     []string{"group4"},
+			source:     map[string]interface{}{"endpoint": testServer.URL + "/groups"},
+			wantGroups: []string{"group4"},
+		},
+		{
+			name:    "endpoint failure",
+			source:  map[string]interface{}{"endpoint": testServer.URL + "/groups"},
+			status:  http.StatusUnauthorized,
+			wantErr: true,
+		},
+		{
+			name:    "aggregated claims",
+			source:  map[string]interface{}{"JWT": "header.payload.signature"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authorization = ""
+			status = http.StatusOK
+			if tc.status != 0 {
+				status = tc.status
+			}
+			token["_claim_names"] = map[string]interface{}{"groups": "src1"}
+			token["_claim_sources"] = map[string]interface{}{"src1": tc.source}
+			if tc.groups != nil {
+				token["groups"] = tc.groups
+			} else {
+				delete(token, "groups")
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			require.NoError(t, err)
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantGroups, identity.Groups)
+			if tc.wantAuthPrefix == "" {
+				assert.Empty(t, authorization, "no request to the claim source expected")
+			} else {
+				assert.Regexp(t, "^"+tc.wantAuthPrefix, authorization)
+			}
+		})
+	}
+}
+
+func TestDistributedClaimsDisabled(t *testing.T) {
+	var requests int
+	claimSource := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"groups": []string{"group1"}})
+	}))
+	defer claimSource.Close()
+
+	testServer, err := setupServer(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"_claim_names":   map[string]interface{}{"groups": "src1"},
+		"_claim_sources": map[string]interface{}{"src1": map[string]interface{}{"endpoint": claimSource.URL}},
+	})
+	require.NoError(t, err)
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		InsecureEnableGroups: true,
+	})
+	require.NoError(t, err)
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	require.NoError(t, err)
+	assert.Empty(t, identity.Groups)
+	assert.Zero(t, requests, "no request to the claim source expected")
+}
@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// maxUserInfoSize limits the size of the responses of the userinfo endpoint.
+const maxUserInfoSize = 1 << 20
+
+// Endpoints of the provider, used instead of the discovered ones. Endpoints
+// left empty are taken from the discovery document.
+type Endpoints struct {
+	AuthURL     string `json:"authURL"`
+	TokenURL    string `json:"tokenURL"`
+	JWKSURL     string `json:"jwksURL"`
+	UserInfoURL string `json:"userInfoURL"`
+
+	// InsecureAllowHTTP allows http URLs, for development only.
+	InsecureAllowHTTP bool `json:"insecureAllowHTTP"`
+}
+
+// validate checks that the configured endpoints are absolute https URLs.
+func (e Endpoints) validate() error {
+	for _, endpoint := range []struct {
+		name string
+		url  string
+	}{
+		{"authURL", e.AuthURL},
+		{"tokenURL", e.TokenURL},
+		{"jwksURL", e.JWKSURL},
+		{"userInfoURL", e.UserInfoURL},
+	} {
+		if endpoint.url == "" {
+			continue
+		}
+		u, err := url.Parse(endpoint.url)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return fmt.Errorf("%s %q is not an absolute URL", endpoint.name, endpoint.url)
+		}
+		if u.Scheme != "https" && !(e.InsecureAllowHTTP && u.Scheme == "http") {
+			return fmt.Errorf("%s %q is not an https URL", endpoint.name, endpoint.url)
+		}
+	}
+	return nil
+}
+
+// fetchUserInfo returns the claims of the configured userinfo endpoint. Signed
+// responses are verified with the key set of the connector.
+func (c *oidcConnector) fetchUserInfo(ctx context.Context, token *oauth2.Token) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.userInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxUserInfoSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/jwt" {
+		return c.keySet.VerifySignature(ctx, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestEndpoints(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	userInfo := map[string]interface{}{"sub": "subvalue", "locale": "en"}
+	upstream, err := setupServerWithUserInfo(token, userInfo, true)
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	// The discovery document publishes broken endpoints.
+	var brokenRequests, userInfoRequests int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		url := fmt.Sprintf("http://%s", r.Host)
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 url,
+				"authorization_endpoint": url + "/authorize",
+				"token_endpoint":         url + "/broken",
+				"userinfo_endpoint":      url + "/broken",
+				"jwks_uri":               url + "/broken",
+			})
+		case "/broken":
+			atomic.AddInt32(&brokenRequests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/custom/userinfo":
+			atomic.AddInt32(&userInfoRequests, 1)
+			r.URL.Path = "/userinfo"
+			upstream.Config.Handler.ServeHTTP(w, r)
+		default:
+			upstream.Config.Handler.ServeHTTP(w, r)
+		}
+	}))
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+		GetUserInfo:  true,
+		Endpoints: Endpoints{
+			TokenURL:          testServer.URL + "/token",
+			JWKSURL:           testServer.URL + "/keys",
+			UserInfoURL:       testServer.URL + "/custom/userinfo",
+			InsecureAllowHTTP: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testServer.URL+"/authorize", conn.oauth2Config.Endpoint.AuthURL, "endpoints which aren't configured are discovered")
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	identity, err := conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+	assert.Equal(t, "subvalue", identity.UserID)
+
+	assert.Zero(t, atomic.LoadInt32(&brokenRequests), "expected no requests to the discovered endpoints")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&userInfoRequests))
+}
+
+func TestEndpointsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints Endpoints
+		wantErr   bool
+	}{
+		{name: "none"},
+		{name: "https", endpoints: Endpoints{TokenURL: "https://example.com/token"}},
+		{name: "http", endpoints: Endpoints{TokenURL: "http://example.com/token"}, wantErr: true},
+		{name: "insecure http", endpoints: Endpoints{TokenURL: "http://example.com/token", InsecureAllowHTTP: true}},
+		{name: "relative", endpoints: Endpoints{JWKSURL: "/keys", InsecureAllowHTTP: true}, wantErr: true},
+		{name: "other scheme", endpoints: Endpoints{UserInfoURL: "ftp://example.com/userinfo", InsecureAllowHTTP: true}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.endpoints.validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
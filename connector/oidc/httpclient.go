@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// defaultRetryBackoff is the delay before the first retry unless configured
+// otherwise. It doubles with every further retry.
+const defaultRetryBackoff = time.Second
+
+// HTTPClientConfig configures the HTTP client used to talk to the upstream
+// provider.
+type HTTPClientConfig struct {
+	// Timeout of a single request, for example "10s". Defaults to no timeout.
+	Timeout string `json:"timeout"`
+
+	// MaxRetries is the number of times idempotent requests, like discovery
+	// and JWKS fetches, are retried after network errors, 429 and 5xx
+	// responses. Code exchanges are never retried.
+	MaxRetries int `json:"maxRetries"`
+
+	// RetryBackoff is the delay before the first retry, for example "500ms".
+	// It doubles with every further retry. A Retry-After header sent by the
+	// provider takes precedence. Defaults to 1s.
+	RetryBackoff string `json:"retryBackoff"`
+}
+
+// newTransport returns the transport of requests to the upstream provider,
+// which uses the configured proxy, root CAs and client certificate, if any.
+func (c *Config) newTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(c.RootCAs) > 0 || c.InsecureSkipVerify || c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		tlsConfig, err := c.newTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if c.ProxyURL == "" {
+		if len(c.NoProxy) > 0 {
+			return nil, errors.New("noProxy requires a proxyURL")
+		}
+		return transport, nil
+	}
+
+	if u, err := url.Parse(c.ProxyURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxyURL %q", c.ProxyURL)
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  c.ProxyURL,
+		HTTPSProxy: c.ProxyURL,
+		NoProxy:    strings.Join(c.NoProxy, ","),
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+	return transport, nil
+}
+
+// newTLSConfig returns the TLS config trusting the configured root CAs, in a
+// pool of their own rather than the one of the system, and presenting the
+// configured client certificate.
+func (c *Config) newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		return nil, errors.New("clientCertFile and clientKeyFile must be set together")
+	}
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(c.RootCAs) == 0 {
+		return tlsConfig, nil
+	}
+	tlsConfig.RootCAs = x509.NewCertPool()
+	for _, rootCA := range c.RootCAs {
+		data, err := os.ReadFile(rootCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rootCAs: %v", err)
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in rootCAs file %q", rootCA)
+		}
+	}
+	return tlsConfig, nil
+}
+
+// newHTTPClient returns the HTTP client described by the config, sending
+// requests with the transport.
+func (c *HTTPClientConfig) newHTTPClient(transport http.RoundTripper) (*http.Client, error) {
+	var timeout time.Duration
+	if c.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %v", c.Timeout, err)
+		}
+	}
+	if c.MaxRetries < 0 {
+		return nil, fmt.Errorf("invalid maxRetries %d", c.MaxRetries)
+	}
+	backoff := defaultRetryBackoff
+	if c.RetryBackoff != "" {
+		var err error
+		if backoff, err = time.ParseDuration(c.RetryBackoff); err != nil {
+			return nil, fmt.Errorf("invalid retryBackoff %q: %v", c.RetryBackoff, err)
+		}
+	}
+
+	if c.MaxRetries > 0 {
+		transport = &retryTransport{
+			base:       transport,
+			maxRetries: c.MaxRetries,
+			backoff:    backoff,
+		}
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// retryTransport retries GET requests which failed for reasons which may be
+// temporary.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	backoff := t.backoff
+	for retry := 0; ; retry++ {
+		resp, err := t.base.RoundTrip(req)
+		if retry == t.maxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := backoff
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = after
+			}
+			resp.Body.Close()
+		}
+		backoff *= 2
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
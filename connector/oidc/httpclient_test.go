@@ -0,0 +1,326 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestRetryTransport(t *testing.T) {
+	var attempts int
+	failures := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	c := HTTPClientConfig{Timeout: "5s", MaxRetries: 2, RetryBackoff: "1h"}
+	client, err := c.newHTTPClient(http.DefaultTransport)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, client.Timeout)
+
+	t.Run("retries GET", func(t *testing.T) {
+		attempts = 0
+		// The backoff would time out the test, so Retry-After must be honored.
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		attempts, failures = 0, 5
+		defer func() { failures = 2 }()
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry POST", func(t *testing.T) {
+		attempts = 0
+		resp, err := client.Post(server.URL, "application/x-www-form-urlencoded", strings.NewReader("code=someCode"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestHTTPClientConfigInvalid(t *testing.T) {
+	tests := map[string]HTTPClientConfig{
+		"bad timeout":  {Timeout: "soon"},
+		"bad backoff":  {MaxRetries: 1, RetryBackoff: "later"},
+		"bad retries":  {MaxRetries: -1},
+		"bad duration": {Timeout: "10"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.newHTTPClient(http.DefaultTransport)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestProxy(t *testing.T) {
+	// A forward proxy receives requests with absolute URLs.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.Host))
+	}))
+	defer proxy.Close()
+
+	c := Config{ProxyURL: proxy.URL, NoProxy: []string{"internal.example.com", "10.0.0.0/8"}}
+	transport, err := c.newTransport()
+	require.NoError(t, err)
+	client, err := c.HTTPClientConfig.newHTTPClient(transport)
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://idp.example.com/.well-known/openid-configuration")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "proxied idp.example.com", string(body))
+
+	for _, target := range []string{"https://internal.example.com/token", "https://login.internal.example.com/token", "http://10.1.2.3/keys"} {
+		proxyURL, err := transport.Proxy(httptest.NewRequest("GET", target, nil))
+		require.NoError(t, err)
+		assert.Nil(t, proxyURL, "request to %s must bypass the proxy", target)
+	}
+	proxyURL, err := transport.Proxy(httptest.NewRequest("GET", "https://idp.example.com/token", nil))
+	require.NoError(t, err)
+	assert.Equal(t, proxy.URL, proxyURL.String())
+}
+
+func TestProxyInvalid(t *testing.T) {
+	tests := map[string]Config{
+		"bad proxy URL":    {ProxyURL: "proxy.example.com:3128"},
+		"no proxy URL":     {NoProxy: []string{"internal.example.com"}},
+		"unparsable proxy": {ProxyURL: "http://[::1"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.newTransport()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := retryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Minute), float64(wait), float64(2*time.Second))
+
+	_, ok = retryAfter("")
+	assert.False(t, ok)
+	_, ok = retryAfter("soon")
+	assert.False(t, ok)
+}
+
+// testCA is a certificate authority issuing the certificates of test servers
+// and clients.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue returns a certificate signed by the CA for the template.
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(2)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// newCustomCATLSServer starts a TLS server with a certificate signed by a CA
+// of its own, and returns the PEM file of the CA.
+func newCustomCATLSServer(t *testing.T, handler http.Handler) (*httptest.Server, string) {
+	ca := newTestCA(t, "Test CA")
+	s := httptest.NewUnstartedServer(handler)
+	s.TLS = &tls.Config{Certificates: []tls.Certificate{ca.issue(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})}}
+	s.StartTLS()
+	return s, ca.file
+}
+
+func TestRootCAs(t *testing.T) {
+	var issuer string
+	s, caFile := newCustomCATLSServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+			"jwks_uri":               issuer + "/keys",
+		})
+	}))
+	defer s.Close()
+	issuer = s.URL
+
+	open := func(c Config) error {
+		c.Issuer = s.URL
+		c.RedirectURI = "https://dex.example.com/callback"
+		_, err := c.Open("id", logrus.New())
+		return err
+	}
+
+	// Discovery fails unless the CA of the provider is trusted.
+	assert.Error(t, open(Config{}))
+	assert.NoError(t, open(Config{RootCAs: []string{caFile}}))
+	assert.NoError(t, open(Config{InsecureSkipVerify: true}))
+
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+	for _, rootCA := range []string{notPEM, filepath.Join(t.TempDir(), "missing.pem")} {
+		assert.ErrorContains(t, open(Config{RootCAs: []string{caFile, rootCA}}), "rootCAs")
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	handler, err := newTestProviderHandler(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	}, nil, false, nil)
+	require.NoError(t, err)
+
+	// The provider only accepts clients with a certificate of the client CA.
+	serverCA, clientCA := newTestCA(t, "Server CA"), newTestCA(t, "Client CA")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.cert)
+	s := httptest.NewUnstartedServer(handler)
+	s.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCA.issue(t, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "127.0.0.1"},
+			IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})},
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	writeKeyPair := func(ca *testCA) (string, string) {
+		cert := ca.issue(t, &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "dex"},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+		require.NoError(t, err)
+		dir := t.TempDir()
+		certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+		require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+		require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+		return certFile, keyFile
+	}
+	certFile, keyFile := writeKeyPair(clientCA)
+	otherCertFile, otherKeyFile := writeKeyPair(serverCA)
+
+	open := func(certFile, keyFile string) (*oidcConnector, error) {
+		c := Config{
+			Issuer:         s.URL,
+			ClientID:       "clientID",
+			ClientSecret:   "clientSecret",
+			RedirectURI:    "https://dex.example.com/callback",
+			RootCAs:        []string{serverCA.file},
+			ClientCertFile: certFile,
+			ClientKeyFile:  keyFile,
+		}
+		conn, err := c.Open("id", logrus.New())
+		if err != nil {
+			return nil, err
+		}
+		return conn.(*oidcConnector), nil
+	}
+
+	// The code is exchanged presenting the client certificate.
+	conn, err := open(certFile, keyFile)
+	require.NoError(t, err)
+	req, err := newRequestWithAuthCode(s.URL, "someCode")
+	require.NoError(t, err)
+	identity, err := conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+	assert.Equal(t, "subvalue", identity.UserID)
+
+	// Without a certificate, or with one the provider doesn't trust, the
+	// provider rejects the connection.
+	_, err = open("", "")
+	assert.Error(t, err)
+	_, err = open(otherCertFile, otherKeyFile)
+	assert.Error(t, err)
+
+	for _, tc := range []struct{ certFile, keyFile string }{
+		{certFile: certFile},
+		{keyFile: keyFile},
+	} {
+		_, err := open(tc.certFile, tc.keyFile)
+		assert.ErrorContains(t, err, "clientCertFile and clientKeyFile must be set together")
+	}
+	_, err = open(keyFile, certFile)
+	assert.ErrorContains(t, err, "client certificate")
+}
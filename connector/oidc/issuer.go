@@ -0,0 +1,48 @@
+package oidc
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Google sometimes issues ID tokens for "accounts.google.com", without the
+// scheme of its issuer URL.
+const (
+	issuerGoogleAccounts         = "https://accounts.google.com"
+	issuerGoogleAccountsNoScheme = "accounts.google.com"
+)
+
+// normalizeIssuer returns the issuer URL with a lowercase scheme and host, and
+// without a trailing slash after its path. Issuers which aren't URLs are
+// returned as they are.
+func normalizeIssuer(issuer string) string {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return issuer
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+// issuersMatch reports whether the issuer identifies the configured issuer.
+// Issuer identifiers are compared as URLs, tolerating a single trailing slash
+// difference, as providers aren't consistent about it between the configured
+// issuer, the discovery document and the ID tokens, see
+// https://www.rfc-editor.org/rfc/rfc8414.html#section-3.3. Issuers with other
+// paths, hosts or query parameters don't match.
+func issuersMatch(configured, issuer string) bool {
+	if configured == issuer {
+		return true
+	}
+	if normalizeIssuer(configured) == issuerGoogleAccounts && issuer == issuerGoogleAccountsNoScheme {
+		return true
+	}
+	// Only a single trailing slash is tolerated.
+	if strings.HasSuffix(configured, "//") || strings.HasSuffix(issuer, "//") {
+		return false
+	}
+	return normalizeIssuer(configured) == normalizeIssuer(issuer)
+}
@@ -0,0 +1,188 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestIssuersMatch(t *testing.T) {
+	tests := []struct {
+		configured string
+		issuer     string
+		want       bool
+	}{
+		{"https://idp.example.com/oauth2/", "https://idp.example.com/oauth2/", true},
+		{"https://idp.example.com/oauth2/", "https://idp.example.com/oauth2", true},
+		{"https://idp.example.com/oauth2", "https://idp.example.com/oauth2/", true},
+		{"https://idp.example.com", "https://idp.example.com/", true},
+		{"https://IdP.example.com/oauth2", "https://idp.example.com/oauth2", true},
+		{"https://accounts.google.com", "accounts.google.com", true},
+		{"https://idp.example.com/oauth2", "https://idp.example.com/oauth2//", false},
+		{"https://idp.example.com/oauth2", "https://idp.example.com/OAuth2", false},
+		{"https://idp.example.com/oauth2", "https://idp.example.com/oauth", false},
+		{"https://idp.example.com/oauth2", "https://idp.example.com", false},
+		{"https://idp.example.com/oauth2", "https://other.example.com/oauth2", false},
+		{"https://idp.example.com/oauth2", "http://idp.example.com/oauth2", false},
+		{"https://idp.example.com/oauth2", "https://idp.example.com/oauth2?tenant=other", false},
+		{"https://idp.example.com", "idp.example.com", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, issuersMatch(tc.configured, tc.issuer), "%q and %q", tc.configured, tc.issuer)
+	}
+}
+
+// setupIssuerServer serves a provider under the /oauth2 path, whose discovery
+// document and ID tokens have the issuers at the paths of the server URL.
+func setupIssuerServer(t *testing.T, discoveryIssuerPath, tokenIssuerPath string) *httptest.Server {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	jwk := jose.JSONWebKey{Key: key, KeyID: "keyId", Algorithm: "RSA"}
+
+	var s *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.URL + discoveryIssuerPath,
+			"authorization_endpoint": s.URL + "/oauth2/authorize",
+			"token_endpoint":         s.URL + "/oauth2/token",
+			"jwks_uri":               s.URL + "/oauth2/keys",
+		})
+	})
+	mux.HandleFunc("/oauth2/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk.Public()}})
+	})
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		token, err := newToken(&jwk, map[string]interface{}{
+			"iss":   s.URL + tokenIssuerPath,
+			"sub":   "subvalue",
+			"aud":   "clientID",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"name":  "Jane Doe",
+			"email": "janedoe@example.com",
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": token,
+			"id_token":     token,
+			"token_type":   "Bearer",
+		})
+	})
+	s = httptest.NewServer(mux)
+	return s
+}
+
+func TestPathPrefixedIssuer(t *testing.T) {
+	tests := []struct {
+		name                    string
+		configuredIssuerPath    string
+		discoveryIssuerPath     string
+		tokenIssuerPath         string
+		insecureSkipIssuerCheck bool
+		wantOpenErr             bool
+		wantCallbackErr         bool
+	}{
+		{
+			name:                 "trailing slashes",
+			configuredIssuerPath: "/oauth2/",
+			discoveryIssuerPath:  "/oauth2/",
+			tokenIssuerPath:      "/oauth2/",
+		},
+		{
+			name:                 "no trailing slashes",
+			configuredIssuerPath: "/oauth2",
+			discoveryIssuerPath:  "/oauth2",
+			tokenIssuerPath:      "/oauth2",
+		},
+		{
+			name:                 "configured without trailing slash",
+			configuredIssuerPath: "/oauth2",
+			discoveryIssuerPath:  "/oauth2/",
+			tokenIssuerPath:      "/oauth2/",
+		},
+		{
+			name:                 "configured with trailing slash",
+			configuredIssuerPath: "/oauth2/",
+			discoveryIssuerPath:  "/oauth2",
+			tokenIssuerPath:      "/oauth2",
+		},
+		{
+			name:                 "token with trailing slash",
+			configuredIssuerPath: "/oauth2",
+			discoveryIssuerPath:  "/oauth2",
+			tokenIssuerPath:      "/oauth2/",
+		},
+		{
+			name:                 "other discovery issuer",
+			configuredIssuerPath: "/oauth2",
+			discoveryIssuerPath:  "/other",
+			tokenIssuerPath:      "/oauth2",
+			wantOpenErr:          true,
+		},
+		{
+			name:                 "other token issuer",
+			configuredIssuerPath: "/oauth2/",
+			discoveryIssuerPath:  "/oauth2/",
+			tokenIssuerPath:      "/oauth2/other",
+			wantCallbackErr:      true,
+		},
+		{
+			name:                 "token with two trailing slashes",
+			configuredIssuerPath: "/oauth2",
+			discoveryIssuerPath:  "/oauth2",
+			tokenIssuerPath:      "/oauth2//",
+			wantCallbackErr:      true,
+		},
+		{
+			name:                    "issuer check skipped",
+			configuredIssuerPath:    "/oauth2",
+			discoveryIssuerPath:     "/other",
+			tokenIssuerPath:         "/oauth2/other",
+			insecureSkipIssuerCheck: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := setupIssuerServer(t, tc.discoveryIssuerPath, tc.tokenIssuerPath)
+			defer s.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:                    s.URL + tc.configuredIssuerPath,
+				ClientID:                  "clientID",
+				ClientSecret:              "clientSecret",
+				RedirectURI:               s.URL + "/callback",
+				InsecureSkipEmailVerified: true,
+				InsecureSkipIssuerCheck:   tc.insecureSkipIssuerCheck,
+			})
+			if tc.wantOpenErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req, err := newRequestWithAuthCode(s.URL, "someCode")
+			require.NoError(t, err)
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.wantCallbackErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "subvalue", identity.UserID)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+type memKeySetCache struct {
+	mu      sync.Mutex
+	keySets map[string][]byte
+}
+
+func (c *memKeySetCache) GetKeySet(connectorID string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.keySets[connectorID], nil
+}
+
+func (c *memKeySetCache) SetKeySet(connectorID string, keySet []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keySets[connectorID] = keySet
+	return nil
+}
+
+func (c *memKeySetCache) get(connectorID string) []byte {
+	keySet, _ := c.GetKeySet(connectorID)
+	return keySet
+}
+
+func TestCachedKeySet(t *testing.T) {
+	const issuer = "https://issuer.example.com"
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	signingKey := &jose.JSONWebKey{Key: key, KeyID: "current", Algorithm: string(jose.RS256), Use: "sig"}
+	keySet, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{signingKey.Public()}})
+	require.NoError(t, err)
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := newToken(signingKey, map[string]interface{}{
+			"iss":            issuer,
+			"aud":            "clientID",
+			"sub":            "subvalue",
+			"name":           "namevalue",
+			"email":          "emailvalue",
+			"email_verified": true,
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"id_token":     token,
+			"token_type":   "Bearer",
+		})
+	}))
+	defer tokenServer.Close()
+
+	keysServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(keySet)
+	}))
+	defer keysServer.Close()
+
+	cache := &memKeySetCache{keySets: make(map[string][]byte)}
+	open := func(jwksURL string) *oidcConnector {
+		config := Config{
+			Issuer:       issuer,
+			ClientID:     "clientID",
+			ClientSecret: "clientSecret",
+			RedirectURI:  tokenServer.URL + "/callback",
+			CacheKeySet:  true,
+			Endpoints: Endpoints{
+				AuthURL:           tokenServer.URL + "/authorize",
+				TokenURL:          tokenServer.URL + "/token",
+				JWKSURL:           jwksURL,
+				InsecureAllowHTTP: true,
+			},
+		}
+		config.SetKeySetCache(cache)
+		conn, err := config.Open("cached", logrus.New())
+		require.NoError(t, err)
+		return conn.(*oidcConnector)
+	}
+	callback := func(conn *oidcConnector) (connector.Identity, error) {
+		req, err := newRequestWithAuthCode(tokenServer.URL, "someCode")
+		require.NoError(t, err)
+		return conn.HandleCallback(connector.Scopes{}, req)
+	}
+
+	// The key set is fetched and cached.
+	conn := open(keysServer.URL)
+	identity, err := callback(conn)
+	require.NoError(t, err)
+	require.Equal(t, "subvalue", identity.UserID)
+	require.Eventually(t, func() bool {
+		return cache.get("cached") != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.JSONEq(t, string(keySet), string(cache.get("cached")))
+	conn.Close()
+
+	// After a restart, tokens are verified with the cached key set while the
+	// provider's keys aren't reachable.
+	keysServer.Close()
+	conn = open(keysServer.URL)
+	defer conn.Close()
+	identity, err = callback(conn)
+	require.NoError(t, err)
+	require.Equal(t, "subvalue", identity.UserID)
+
+	// Without a cached key set, the tokens can't be verified.
+	cache.mu.Lock()
+	cache.keySets = make(map[string][]byte)
+	cache.mu.Unlock()
+	other := open(keysServer.URL)
+	defer other.Close()
+	_, err = callback(other)
+	require.Error(t, err)
+}
+
+func TestCacheKeySetWithStaticKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	keySet, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "static", Algorithm: string(jose.RS256), Use: "sig"}}})
+	require.NoError(t, err)
+
+	config := Config{
+		Issuer:      "https://issuer.example.com",
+		ClientID:    "clientID",
+		CacheKeySet: true,
+		StaticKeys:  string(keySet),
+	}
+	config.SetKeySetCache(&memKeySetCache{keySets: make(map[string][]byte)})
+	_, err = config.Open("cached", logrus.New())
+	require.EqualError(t, err, "oidc: cacheKeySet can't be combined with staticKeys or jwksFile")
+}
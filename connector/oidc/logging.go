@@ -0,0 +1,88 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// correlationIDHeader is the header of the ID a proxy in front of dex gave a
+// request, used as the correlation ID of the callback.
+const correlationIDHeader = "X-Request-Id"
+
+type requestLoggerKey struct{}
+
+// requestLogger adds the connector ID and the correlation ID of a callback or
+// refresh to the entries logged for it, so the entries of a login can be told
+// apart from the ones of other logins, across replicas.
+type requestLogger struct {
+	log.Logger
+	fields string
+}
+
+func (l *requestLogger) Debug(args ...interface{}) { l.Logger.Debug(fmt.Sprint(args...) + l.fields) }
+func (l *requestLogger) Info(args ...interface{})  { l.Logger.Info(fmt.Sprint(args...) + l.fields) }
+func (l *requestLogger) Warn(args ...interface{})  { l.Logger.Warn(fmt.Sprint(args...) + l.fields) }
+func (l *requestLogger) Error(args ...interface{}) { l.Logger.Error(fmt.Sprint(args...) + l.fields) }
+
+// The fields are passed as an argument, so the message is only formatted if
+// its level is enabled.
+func (l *requestLogger) Debugf(format string, args ...interface{}) {
+	l.Logger.Debugf(format+"%s", l.withFields(args)...)
+}
+
+func (l *requestLogger) Infof(format string, args ...interface{}) {
+	l.Logger.Infof(format+"%s", l.withFields(args)...)
+}
+
+func (l *requestLogger) Warnf(format string, args ...interface{}) {
+	l.Logger.Warnf(format+"%s", l.withFields(args)...)
+}
+
+func (l *requestLogger) Errorf(format string, args ...interface{}) {
+	l.Logger.Errorf(format+"%s", l.withFields(args)...)
+}
+
+func (l *requestLogger) withFields(args []interface{}) []interface{} {
+	return append(args[:len(args):len(args)], l.fields)
+}
+
+// withCorrelationID returns the context of a callback or refresh with the
+// logger adding the correlation ID, or a new one if it's empty, to the
+// entries of the connector.
+func (c *oidcConnector) withCorrelationID(ctx context.Context, correlationID string) context.Context {
+	if correlationID == "" {
+		correlationID = newCorrelationID()
+	}
+	return context.WithValue(ctx, requestLoggerKey{}, &requestLogger{
+		Logger: c.logger,
+		fields: fmt.Sprintf(" connector=%s correlation_id=%s", c.id, correlationID),
+	})
+}
+
+// requestCorrelationID returns the correlation ID of the callback request,
+// if a proxy set one.
+func requestCorrelationID(r *http.Request) string {
+	return r.Header.Get(correlationIDHeader)
+}
+
+// loggerFrom returns the logger of the callback or refresh of the context, or
+// the logger of the connector outside of them.
+func (c *oidcConnector) loggerFrom(ctx context.Context) log.Logger {
+	if logger, ok := ctx.Value(requestLoggerKey{}).(*requestLogger); ok {
+		return logger
+	}
+	return c.logger
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestCorrelationID(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	})
+	require.NoError(t, err)
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+	})
+	require.NoError(t, err)
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	conn.logger = logger
+
+	// requireCorrelationID checks every entry has the connector ID and the
+	// same correlation ID, and returns the correlation ID.
+	requireCorrelationID := func(t *testing.T) string {
+		entries := hook.AllEntries()
+		require.NotEmpty(t, entries)
+		var correlationID string
+		for _, entry := range entries {
+			assert.Contains(t, entry.Message, " connector=id ")
+			i := strings.Index(entry.Message, "correlation_id=")
+			require.True(t, i >= 0, "no correlation ID in %q", entry.Message)
+			id := entry.Message[i+len("correlation_id="):]
+			if correlationID == "" {
+				correlationID = id
+			}
+			assert.Equal(t, correlationID, id)
+		}
+		hook.Reset()
+		return correlationID
+	}
+
+	// The correlation ID of a callback is the ID the proxy gave the request.
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	req.Header.Set("X-Request-Id", "req-123")
+	_, err = conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", requireCorrelationID(t))
+
+	// Other callbacks and refreshes get one of their own.
+	req, err = newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	_, err = conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+	first := requireCorrelationID(t)
+
+	connData, err := json.Marshal(connectorData{RefreshToken: []byte("refresh-token")})
+	require.NoError(t, err)
+	_, err = conn.Refresh(context.Background(), connector.Scopes{}, connector.Identity{UserID: "subvalue", ConnectorData: connData})
+	require.NoError(t, err)
+	second := requireCorrelationID(t)
+	assert.NotEqual(t, first, second)
+
+	// Debug entries aren't logged at higher levels.
+	logger.SetLevel(logrus.InfoLevel)
+	_, err = conn.Refresh(context.Background(), connector.Scopes{}, connector.Identity{UserID: "subvalue", ConnectorData: connData})
+	require.NoError(t, err)
+	assert.Empty(t, hook.AllEntries())
+}
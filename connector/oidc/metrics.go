@@ -0,0 +1,90 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are the Prometheus metrics of all OIDC connectors, labeled with the
+// connector ID. They have to be registered to be exported.
+var Metrics prometheus.Collector = metrics
+
+var metrics = newConnectorMetrics()
+
+// connectorMetrics records the outcome of the requests of the connectors to
+// the upstream providers.
+type connectorMetrics struct {
+	callbacks     *prometheus.CounterVec
+	refreshes     *prometheus.CounterVec
+	tokenExchange *prometheus.HistogramVec
+	jwksFailures  *prometheus.CounterVec
+	userInfo      *prometheus.CounterVec
+}
+
+func newConnectorMetrics() *connectorMetrics {
+	return &connectorMetrics{
+		callbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oidc_connector_callbacks_total",
+			Help: "Count of callbacks handled by OIDC connectors by result.",
+		}, []string{"connector", "result"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oidc_connector_refreshes_total",
+			Help: "Count of refreshes handled by OIDC connectors by result.",
+		}, []string{"connector", "result"}),
+		tokenExchange: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "oidc_connector_token_exchange_duration_seconds",
+			Help:    "Latency of token requests of OIDC connectors to the upstream provider.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"connector", "grant_type"}),
+		jwksFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oidc_connector_jwks_refresh_failures_total",
+			Help: "Count of failed requests of OIDC connectors for the signing keys of the upstream provider.",
+		}, []string{"connector"}),
+		userInfo: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oidc_connector_userinfo_requests_total",
+			Help: "Count of userinfo requests of OIDC connectors by result.",
+		}, []string{"connector", "result"}),
+	}
+}
+
+func (m *connectorMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.callbacks.Describe(ch)
+	m.refreshes.Describe(ch)
+	m.tokenExchange.Describe(ch)
+	m.jwksFailures.Describe(ch)
+	m.userInfo.Describe(ch)
+}
+
+func (m *connectorMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.callbacks.Collect(ch)
+	m.refreshes.Collect(ch)
+	m.tokenExchange.Collect(ch)
+	m.jwksFailures.Collect(ch)
+	m.userInfo.Collect(ch)
+}
+
+// result is the value of the result label for an error.
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// jwksTransport counts the failed requests for the signing keys of the
+// provider. Requests are made with the transport before the URL of the keys
+// is known, so url is set once the discovery document is loaded.
+type jwksTransport struct {
+	base        http.RoundTripper
+	connectorID string
+	url         string
+}
+
+func (t *jwksTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if t.url != "" && req.URL.String() == t.url && (err != nil || resp.StatusCode != http.StatusOK) {
+		metrics.jwksFailures.WithLabelValues(t.connectorID).Inc()
+	}
+	return resp, err
+}
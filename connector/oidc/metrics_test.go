@@ -0,0 +1,103 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestMetrics(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	upstream, err := setupServerWithUserInfo(token, map[string]interface{}{"sub": "subvalue"}, false)
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	var failKeys int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/keys" && atomic.LoadInt32(&failKeys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		upstream.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer testServer.Close()
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(Metrics))
+
+	open := func(id string) *oidcConnector {
+		conn, err := (&Config{
+			Issuer:       testServer.URL,
+			ClientID:     "clientID",
+			ClientSecret: "clientSecret",
+			RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+			GetUserInfo:  true,
+		}).Open(id, logrus.New())
+		require.NoError(t, err)
+		return conn.(*oidcConnector)
+	}
+	value := func(name string, labels prometheus.Labels) float64 {
+		families, err := reg.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+		next:
+			for _, m := range family.GetMetric() {
+				for _, label := range m.GetLabel() {
+					if labels[label.GetName()] != label.GetValue() {
+						continue next
+					}
+				}
+				if h := m.GetHistogram(); h != nil {
+					return float64(h.GetSampleCount())
+				}
+				return m.GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+
+	conn := open("metrics")
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	require.NoError(t, err)
+	identity, err := conn.HandleCallback(connector.Scopes{}, req)
+	require.NoError(t, err)
+
+	require.Equal(t, 1.0, value("oidc_connector_callbacks_total", prometheus.Labels{"connector": "metrics", "result": "success"}))
+	require.Equal(t, 1.0, value("oidc_connector_token_exchange_duration_seconds", prometheus.Labels{"connector": "metrics", "grant_type": "authorization_code"}))
+	require.Equal(t, 1.0, value("oidc_connector_userinfo_requests_total", prometheus.Labels{"connector": "metrics", "result": "success"}))
+
+	identity.ConnectorData, err = json.Marshal(connectorData{RefreshToken: []byte("refresh-token")})
+	require.NoError(t, err)
+	_, err = conn.Refresh(context.Background(), connector.Scopes{}, identity)
+	require.NoError(t, err)
+	_, err = conn.Refresh(context.Background(), connector.Scopes{}, connector.Identity{})
+	require.Error(t, err)
+
+	require.Equal(t, 1.0, value("oidc_connector_refreshes_total", prometheus.Labels{"connector": "metrics", "result": "success"}))
+	require.Equal(t, 1.0, value("oidc_connector_refreshes_total", prometheus.Labels{"connector": "metrics", "result": "error"}))
+	require.Equal(t, 1.0, value("oidc_connector_token_exchange_duration_seconds", prometheus.Labels{"connector": "metrics", "grant_type": "refresh_token"}))
+
+	// The signing keys of the provider can't be loaded by another connector.
+	atomic.StoreInt32(&failKeys, 1)
+	other := open("metrics-jwks")
+	_, err = other.HandleCallback(connector.Scopes{}, req)
+	require.Error(t, err)
+
+	require.Equal(t, 1.0, value("oidc_connector_callbacks_total", prometheus.Labels{"connector": "metrics-jwks", "result": "error"}))
+	require.Equal(t, 1.0, value("oidc_connector_jwks_refresh_failures_total", prometheus.Labels{"connector": "metrics-jwks"}))
+	require.Equal(t, 0.0, value("oidc_connector_jwks_refresh_failures_total", prometheus.Labels{"connector": "metrics"}))
+	require.Equal(t, 1.0, value("oidc_connector_callbacks_total", prometheus.Labels{"connector": "metrics", "result": "success"}), "metrics are kept per connector")
+}
@@ -3,12 +3,19 @@ package oidc
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -30,40 +37,261 @@ type Config struct {
 	// providers require it.
 	//
 	// https://tools.ietf.org/html/rfc6749#section-2.3.1
+	//
+	// Ignored if clientAuthMethod is set.
 	BasicAuthUnsupported *bool `json:"basicAuthUnsupported"`
 
+	// ClientAuthMethod is the method of authenticating to the token endpoint:
+	// "client_secret_basic", "client_secret_post" or "private_key_jwt". If
+	// unset, client_secret_basic is used unless basicAuthUnsupported is set
+	// or the provider is known not to support it.
+	//
+	// https://openid.net/specs/openid-connect-core-1_0.html#ClientAuthentication
+	ClientAuthMethod string `json:"clientAuthMethod"`
+
+	// SigningKeyFile is the PEM encoded RSA or ECDSA private key signing the
+	// client assertions of the private_key_jwt method. The provider must know
+	// the matching public key.
+	SigningKeyFile string `json:"signingKeyFile"`
+
+	// DecryptionKeyFile is the PEM encoded RSA or ECDSA private key decrypting
+	// ID tokens, which the provider encrypts with the matching public key as
+	// nested JWTs. If set, ID tokens must be encrypted with RSA-OAEP or
+	// RSA-OAEP-256 for an RSA key, or with ECDH-ES, optionally with AES key
+	// wrapping, for an ECDSA key. Their signature is verified as usual.
+	DecryptionKeyFile string `json:"decryptionKeyFile"`
+
 	Scopes []string `json:"scopes"` // defaults to "profile" and "email"
 
+	// AllowedScopes are the scopes downstream clients may add to the scopes
+	// requested from the provider, by requesting them with the "upstream:"
+	// prefix, for example "upstream:groups". Other scopes clients request
+	// this way are dropped. If empty, clients can't add any.
+	AllowedScopes []string `json:"allowedScopes"`
+
 	// Optional list of whitelisted domains when using Google
 	// If this field is nonempty, only users from a listed domain will be allowed to log in
 	HostedDomains []string `json:"hostedDomains"`
 
+	// AllowedAudiences are accepted in the "aud" claim of ID tokens besides
+	// the client ID, for providers issuing tokens for several audiences, for
+	// example an API identifier. ID tokens must be issued for at least one
+	// of them.
+	AllowedAudiences []string `json:"allowedAudiences"`
+
+	// ValidateAZP rejects ID tokens with an "azp" (authorized party) claim
+	// other than the client ID, for example tokens issued to another client
+	// for the same audience.
+	ValidateAZP bool `json:"validateAZP"`
+
+	// Optional list of allowed tenant IDs when using a multi-tenant Azure AD application.
+	// If this field is nonempty, only users whose "tid" claim holds a listed tenant will be allowed to log in
+	AllowedTenants []string `json:"allowedTenants"`
+
 	// Override the value of email_verified to true in the returned claims
 	InsecureSkipEmailVerified bool `json:"insecureSkipEmailVerified"`
 
+	// RequireEmailVerified fails the logins of users whose "email_verified"
+	// claim is missing or false, rather than returning their email as
+	// unverified. Can't be combined with insecureSkipEmailVerified.
+	RequireEmailVerified bool `json:"requireEmailVerified"`
+
+	// CanonicalizeEmail trims and lowercases the email claim, so that
+	// downstream clients comparing emails don't tell "User@Example.com" and
+	// "user@example.com" apart.
+	CanonicalizeEmail bool `json:"canonicalizeEmail"`
+
 	// InsecureEnableGroups enables groups claims. This is disabled by default until https://github.com/dexidp/dex/issues/1065 is resolved
 	InsecureEnableGroups bool `json:"insecureEnableGroups"`
 
+	// ResolveDistributedClaims fetches the groups claims the provider
+	// distributes to another endpoint ("_claim_names" and "_claim_sources")
+	// from it, with the access token of the claim source or the one of the
+	// token response. Requires insecureEnableGroups.
+	ResolveDistributedClaims bool `json:"resolveDistributedClaims"`
+
+	// InsecureSkipIssuerCheck accepts discovery documents and ID tokens of
+	// any issuer, rather than only of the configured one (tolerating a single
+	// trailing slash difference). Only meant for development setups whose
+	// provider is reached through another URL than the one it issues tokens
+	// for.
+	InsecureSkipIssuerCheck bool `json:"insecureSkipIssuerCheck"`
+
 	// AcrValues (Authentication Context Class Reference Values) that specifies the Authentication Context Class Values
 	// within the Authentication Request that the Authorization Server is being requested to use for
 	// processing requests from this Client, with the values appearing in order of preference.
+	// Values requested by the downstream client take precedence.
 	AcrValues []string `json:"acrValues"`
 
+	// EnforceRequestedACR rejects logins whose "acr" claim doesn't satisfy
+	// the acr_values requested by the downstream client. Defaults to true.
+	EnforceRequestedACR *bool `json:"enforceRequestedACR"`
+
+	// ACRRanking lists acr values from the weakest to the strongest. With a
+	// ranking, a requested acr value is also satisfied by a stronger one,
+	// for example "urn:example:mfa" for a requested "urn:example:pwd".
+	ACRRanking []string `json:"acrRanking"`
+
+	// RequireACR rejects logins whose "acr" claim isn't one of these values,
+	// or with acrRanking at least as strong as one of them, whatever the
+	// downstream client requested. Usually set together with acrValues so
+	// the upstream provider is asked for one of them.
+	RequireACR []string `json:"requireACR"`
+
 	// GetUserInfo uses the userinfo endpoint to get additional claims for
 	// the token. This is especially useful where upstreams return "thin"
-	// id tokens
+	// id tokens. The userinfo claims, plain JSON or a signed JWT, only fill
+	// in claims missing from the id token, unless overrideClaimMapping is
+	// set. If the userinfo request fails, the id token claims are used
+	// alone.
 	GetUserInfo bool `json:"getUserInfo"`
 
+	// UserIDKey is the claim used as the user ID instead of "sub", for
+	// example "preferred_username". The claim is read verbatim and must be a
+	// string.
 	UserIDKey string `json:"userIDKey"`
 
+	// UserIDTemplate is a Go template over the claims used to build the user
+	// ID, for example '{{ .preferred_username }}@{{ .iss }}'. Unlike the
+	// usernameTemplate, the login fails if a claim used by the template is
+	// missing or the template renders an empty user ID. Only one of userIDKey
+	// and userIDTemplate can be set.
+	UserIDTemplate string `json:"userIDTemplate"`
+
 	UserNameKey string `json:"userNameKey"`
 
+	// UsernameTemplate is a Go template over the claims used to build the
+	// username, for example '{{ lower .given_name }}.{{ lower .family_name }}'
+	// or '{{ .sub }}@{{ .iss }}'. If a claim used by the template is missing,
+	// the username falls back to the claim named by userNameKey.
+	UsernameTemplate string `json:"usernameTemplate"`
+
 	// PromptType will be used fot the prompt parameter (when offline_access, by default prompt=consent)
 	PromptType string `json:"promptType"`
 
+	// ForwardSelectAccountPrompt passes prompt=select_account to the upstream
+	// provider when the client requested it, so users can switch accounts.
+	ForwardSelectAccountPrompt bool `json:"forwardSelectAccountPrompt"`
+
+	// ForwardPromptNone passes prompt=none to the upstream provider when the
+	// client requested it, for silent logins. If the upstream provider needs
+	// to interact with the user, the client gets a "login_required" or
+	// "interaction_required" error and can fall back to an interactive login.
+	ForwardPromptNone bool `json:"forwardPromptNone"`
+
+	// UpstreamExpiryClaim adds the expiry of the upstream ID token, in
+	// seconds since the epoch, to the tokens issued by dex as a claim of this
+	// name, for example "upstream_exp". It can't replace the standard claims.
+	UpstreamExpiryClaim string `json:"upstreamExpiryClaim"`
+
+	// ForwardUpstreamTokens keeps the access token and ID token of the
+	// provider in the connector data of the user's session, next to the
+	// refresh token, for servers forwarding them to clients which call APIs
+	// of the provider. They're replaced on every refresh. Read them with
+	// UpstreamTokens.
+	//
+	// The tokens are stored like the rest of the connector data, and only as
+	// protected as the storage of dex is: anyone reading the storage can use
+	// them until they expire. Only enable this if clients need them.
+	ForwardUpstreamTokens bool `json:"forwardUpstreamTokens"`
+
+	// EnablePKCE sends a PKCE (RFC 7636) code challenge with the S256 method
+	// in the authorization request and the matching code verifier in the token
+	// request, for upstream providers requiring PKCE.
+	EnablePKCE bool `json:"enablePKCE"`
+
+	// EnableNonce sends a random nonce in the authorization request and
+	// checks the nonce claim of the ID token against it in the callback. The
+	// nonce is kept in the login data, which the server stores with the auth
+	// request, like the state, so any replica can handle the callback.
+	EnableNonce bool `json:"enableNonce"`
+
+	// StaticKeys is a JSON Web Key Set, as JSON, used to verify ID tokens
+	// instead of the keys published at the jwks_uri of the provider.
+	StaticKeys string `json:"staticKeys"`
+
+	// JWKSFile is the path of a file holding a JSON Web Key Set, used like
+	// staticKeys. Only one of staticKeys and jwksFile can be set.
+	JWKSFile string `json:"jwksFile"`
+
+	// JWKSFileReloadInterval reloads the jwksFile at this interval, for
+	// example "1h", to pick up rotated keys. If unset, the file is only read
+	// when the connector is opened.
+	JWKSFileReloadInterval string `json:"jwksFileReloadInterval"`
+
+	// CacheKeySet keeps the key set published at the jwks_uri of the provider
+	// in the storage. The cached keys verify ID tokens at startup, before the
+	// provider is reachable, and are refreshed in the background. The
+	// discovery document is still needed at startup unless the endpoints are
+	// configured.
+	CacheKeySet bool `json:"cacheKeySet"`
+
+	// KeysRefreshInterval refreshes the keys published at the jwks_uri of the
+	// provider in the background at this interval, for example "10m", less up
+	// to a tenth at random so replicas don't refresh them at once. Keys
+	// which can't be refreshed are still used for the keysGracePeriod, so a
+	// brief outage of the provider doesn't fail logins. If unset, the keys
+	// are only fetched for tokens signed with unknown keys.
+	KeysRefreshInterval string `json:"keysRefreshInterval"`
+	// KeysGracePeriod is how long the keys are still used after they should
+	// have been refreshed, for example "30m". Defaults to one hour.
+	KeysGracePeriod string `json:"keysGracePeriod"`
+
+	// ClockSkew is the time the clocks of dex and the provider may drift
+	// apart by, tolerated when checking the expiry, issued at and not before
+	// times of ID tokens, for example "30s". Defaults to one minute, and can't
+	// be more than five minutes.
+	ClockSkew string `json:"clockSkew"`
+
+	// StateTTL is how long after the login started the callback is still
+	// accepted, for example "15m". Later callbacks fail with a "state
+	// expired" error, instead of the provider's tokens being used for a login
+	// the user has long given up on. Defaults to 24 hours, how long the server
+	// keeps the login by default.
+	StateTTL string `json:"stateTTL"`
+
+	// Endpoints are used instead of the endpoints in the discovery document
+	// of the provider, for providers publishing wrong ones. The discovery
+	// document isn't fetched at all if every endpoint needed is configured,
+	// for providers which aren't reachable.
+	Endpoints Endpoints `json:"endpoints"`
+
+	// HTTPClientConfig sets the timeout and retry policy of requests to the
+	// upstream provider.
+	HTTPClientConfig HTTPClientConfig `json:"httpClientConfig"`
+
+	// DiscoveryRetry retries fetching the discovery document when dex starts.
+	DiscoveryRetry DiscoveryRetry `json:"discoveryRetry"`
+
+	// ProxyURL is the URL of the proxy requests to the upstream provider are
+	// sent through, instead of the one set by the HTTP_PROXY and HTTPS_PROXY
+	// environment variables.
+	ProxyURL string `json:"proxyURL"`
+
+	// NoProxy lists hosts, domains, IP addresses and CIDR ranges which are
+	// contacted without the proxy, in the format of the NO_PROXY environment
+	// variable. Requests to localhost never use the proxy.
+	NoProxy []string `json:"noProxy"`
+
+	// RootCAs are PEM files of the certificate authorities trusted for the
+	// TLS connections to the upstream provider, instead of the ones of the
+	// system.
+	RootCAs []string `json:"rootCAs"`
+
+	// InsecureSkipVerify accepts any certificate of the upstream provider.
+	// Only meant for development setups.
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+
+	// ClientCertFile and ClientKeyFile are the PEM files of the certificate
+	// and key presented to the upstream provider, which requires mutual TLS,
+	// for example for the token exchange. Both or neither must be set.
+	ClientCertFile string `json:"clientCertFile"`
+	ClientKeyFile  string `json:"clientKeyFile"`
+
 	// OverrideClaimMapping will be used to override the options defined in claimMappings.
 	// i.e. if there are 'email' and `preferred_email` claims available, by default Dex will always use the `email` claim independent of the ClaimMapping.EmailKey.
 	// This setting allows you to override the default behavior of Dex and enforce the mappings defined in `claimMapping`.
+	// It also lets the claims of the userinfo endpoint replace the ones of the id token, see getUserInfo.
 	OverrideClaimMapping bool `json:"overrideClaimMapping"` // defaults to false
 
 	ClaimMapping struct {
@@ -73,13 +301,163 @@ type Config struct {
 		// Configurable key which contains the email claims
 		EmailKey string `json:"email"` // defaults to "email"
 
-		// Configurable key which contains the groups claims
-		GroupsKey string `json:"groups"` // defaults to "groups"
+		// Configurable key, or list of keys, which contain the groups claims.
+		// Groups of all listed claims are merged, missing claims are skipped.
+		// Nested claims can be given as a dotted path, for example
+		// "resource_access.my-client.roles".
+		GroupsKey GroupsKeys `json:"groups"` // defaults to "groups"
 	} `json:"claimMapping"`
 
+	// GroupsDelimiter separates the groups of groups claims holding a single
+	// string instead of a list, for example ",". Defaults to whitespace.
+	GroupsDelimiter string `json:"groupsDelimiter"`
+
+	// AccountStatus denies logins of accounts the upstream provider reports as
+	// disabled.
+	AccountStatus struct {
+		// Claim holding the status of the account, for example "account_enabled".
+		Claim string `json:"claim"`
+
+		// Values of the claim which allow a login, for example "true" or
+		// "active". Any other value, or a missing claim, denies the login.
+		AllowedValues []string `json:"allowedValues"`
+	} `json:"accountStatus"`
+
 	// Add additional authorization request parameters to acceess IdP specific features.
 	// Take care not to override standard OICD authorization requests parameters.
 	AdditionalAuthRequestParams map[string]string `json:"additionalAuthRequestParams"`
+
+	// ClaimTransforms are applied in order to the claims of the upstream
+	// provider before they are mapped to the identity.
+	ClaimTransforms []ClaimTransform `json:"claimTransforms"`
+
+	// GroupsFilter is a regular expression groups must match to be kept, for
+	// example "^dex-". GroupsDenyFilter drops the groups it matches. Unset
+	// filters keep all groups.
+	GroupsFilter     string `json:"groupsFilter"`
+	GroupsDenyFilter string `json:"groupsDenyFilter"`
+
+	// ClaimMutations rewrite the values mapped to the identity, in order.
+	ClaimMutations []ClaimMutation `json:"claimMutations"`
+
+	// keySetCache keeps the key set of the provider with cacheKeySet.
+	keySetCache connector.KeySetCache
+}
+
+// SetKeySetCache sets the cache of the key set used with cacheKeySet.
+func (c *Config) SetKeySetCache(cache connector.KeySetCache) {
+	c.keySetCache = cache
+}
+
+// ClaimMutation rewrites the value of a mapped claim with a regular
+// expression, for example to extract "jdoe" from "CN=jdoe,OU=users".
+type ClaimMutation struct {
+	// Key is the mapped claim to rewrite: "name", "preferred_username",
+	// "email" or "groups". Each group is rewritten on its own.
+	Key string `json:"key"`
+
+	// Regex is matched against the value, for example "^CN=([^,]+),.*$".
+	// Values it doesn't match are kept as they are.
+	Regex string `json:"regex"`
+
+	// Replacement replaces the matches of the regex, and can refer to its
+	// capture groups, for example "$1".
+	Replacement string `json:"replacement"`
+}
+
+// claimMutation is a ClaimMutation with a compiled regex.
+type claimMutation struct {
+	key         string
+	regex       *regexp.Regexp
+	replacement string
+}
+
+func (m ClaimMutation) compile() (claimMutation, error) {
+	switch m.Key {
+	case "name", "preferred_username", "email", "groups":
+	case "":
+		return claimMutation{}, errors.New("no key specified")
+	default:
+		return claimMutation{}, fmt.Errorf("unsupported key %q", m.Key)
+	}
+	if m.Regex == "" {
+		return claimMutation{}, errors.New("no regex specified")
+	}
+	regex, err := regexp.Compile(m.Regex)
+	if err != nil {
+		return claimMutation{}, err
+	}
+	return claimMutation{key: m.Key, regex: regex, replacement: m.Replacement}, nil
+}
+
+// Operations of claim transforms.
+const (
+	TransformLowercase  = "lowercase"
+	TransformTrimPrefix = "trimPrefix"
+	TransformTrimSuffix = "trimSuffix"
+	TransformReplace    = "replace"
+)
+
+// ClaimTransform modifies a string claim, or each string of a list claim like
+// "groups". Claims of other types are left alone.
+type ClaimTransform struct {
+	// Claim is the name of the claim to transform, for example "email".
+	Claim string `json:"claim"`
+
+	// Operation is one of "lowercase", "trimPrefix", "trimSuffix" or "replace".
+	Operation string `json:"operation"`
+
+	// Value is the prefix or suffix to trim, or the string to replace.
+	Value string `json:"value"`
+
+	// Replacement replaces all occurrences of Value for "replace".
+	Replacement string `json:"replacement"`
+}
+
+func (t ClaimTransform) validate() error {
+	if t.Claim == "" {
+		return errors.New("no claim specified")
+	}
+	switch t.Operation {
+	case TransformLowercase:
+	case TransformTrimPrefix, TransformTrimSuffix, TransformReplace:
+		if t.Value == "" {
+			return fmt.Errorf("operation %q requires a value", t.Operation)
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", t.Operation)
+	}
+	return nil
+}
+
+func (t ClaimTransform) apply(s string) string {
+	switch t.Operation {
+	case TransformLowercase:
+		return strings.ToLower(s)
+	case TransformTrimPrefix:
+		return strings.TrimPrefix(s, t.Value)
+	case TransformTrimSuffix:
+		return strings.TrimSuffix(s, t.Value)
+	case TransformReplace:
+		return strings.ReplaceAll(s, t.Value, t.Replacement)
+	}
+	return s
+}
+
+// transformClaims applies the claim transforms to the claims in place.
+func transformClaims(transforms []ClaimTransform, claims map[string]interface{}) {
+	for _, t := range transforms {
+		switch v := claims[t.Claim].(type) {
+		case string:
+			claims[t.Claim] = t.apply(v)
+		case []interface{}:
+			for i, e := range v {
+				if s, ok := e.(string); ok {
+					v[i] = t.apply(s)
+				}
+			}
+		}
+	}
 }
 
 // Domains that don't support basic auth. golang.org/x/oauth2 has an internal
@@ -90,9 +468,97 @@ var brokenAuthHeaderDomains = []string{
 	"oktapreview.com",
 }
 
+// promptSelectAccount asks the upstream provider to let the user pick an account.
+const promptSelectAccount = "select_account"
+
+// promptNone asks the upstream provider to log in the user without interaction.
+const promptNone = "none"
+
+// The clock skew tolerated by default, and at most, when checking the times of
+// ID tokens.
+const (
+	defaultClockSkew = time.Minute
+	maxClockSkew     = 5 * time.Minute
+)
+
+// defaultStateTTL is how long callbacks are accepted after the login started
+// unless configured otherwise.
+const defaultStateTTL = 24 * time.Hour
+
+// Errors of upstream providers failing to log in the user without interaction.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#AuthError
+var interactionRequiredErrors = map[string]bool{
+	"login_required":             true,
+	"interaction_required":       true,
+	"consent_required":           true,
+	"account_selection_required": true,
+}
+
 // connectorData stores information for sessions authenticated by this connector
 type connectorData struct {
 	RefreshToken []byte
+
+	// The upstream tokens, with forwardUpstreamTokens enabled.
+	AccessToken string `json:",omitempty"`
+	IDToken     string `json:",omitempty"`
+
+	// The sub and sid claims of the ID token, the user and the session
+	// back-channel logout tokens may log out. The user ID may be mapped from
+	// other claims, so the subject is kept too.
+	Subject   string `json:",omitempty"`
+	SessionID string `json:",omitempty"`
+}
+
+// String redacts the tokens, should the connector data ever be logged.
+func (cd connectorData) String() string {
+	redact := func(token string) string {
+		if token == "" {
+			return ""
+		}
+		return "[redacted]"
+	}
+	return fmt.Sprintf("{RefreshToken:%s AccessToken:%s IDToken:%s}",
+		redact(string(cd.RefreshToken)), redact(cd.AccessToken), redact(cd.IDToken))
+}
+
+// UpstreamTokens returns the access token and ID token of the provider kept in
+// the connector data of a session of an OIDC connector with
+// forwardUpstreamTokens enabled. Both are empty otherwise.
+func UpstreamTokens(connData []byte) (accessToken, idToken string, err error) {
+	var cd connectorData
+	if err := json.Unmarshal(connData, &cd); err != nil {
+		return "", "", fmt.Errorf("oidc: failed to unmarshal connector data: %v", err)
+	}
+	return cd.AccessToken, cd.IDToken, nil
+}
+
+// GroupsKeys is a list of claim keys, which can be configured as a single
+// string or as a list of strings.
+type GroupsKeys []string
+
+// UnmarshalJSON accepts either a string or a list of strings.
+func (k *GroupsKeys) UnmarshalJSON(b []byte) error {
+	var key string
+	if err := json.Unmarshal(b, &key); err == nil {
+		*k = nil
+		if key != "" {
+			*k = GroupsKeys{key}
+		}
+		return nil
+	}
+	var keys []string
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return fmt.Errorf("oidc: groups claim mapping must be a string or a list of strings: %v", err)
+	}
+	*k = keys
+	return nil
+}
+
+// loginData is kept by the server between the login redirect and the callback.
+type loginData struct {
+	CodeVerifier string `json:"codeVerifier,omitempty"`
+	Nonce        string `json:"nonce,omitempty"`
 }
 
 // Detect auth header provider issues for known providers. This lets users
@@ -113,23 +579,246 @@ func knownBrokenAuthHeaderProvider(issuerURL string) bool {
 // Open returns a connector which can be used to login users through an upstream
 // OpenID Connect provider.
 func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, err error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	switch c.ClientAuthMethod {
+	case "", clientAuthMethodBasic, clientAuthMethodPost:
+	case clientAuthMethodPrivateJWT:
+		if c.SigningKeyFile == "" {
+			return nil, errors.New("oidc: signingKeyFile is required for the private_key_jwt client auth method")
+		}
+	default:
+		return nil, fmt.Errorf("oidc: unsupported clientAuthMethod %q", c.ClientAuthMethod)
+	}
+
+	var decrypter *idTokenDecrypter
+	if c.DecryptionKeyFile != "" {
+		if decrypter, err = newIDTokenDecrypter(c.DecryptionKeyFile); err != nil {
+			return nil, fmt.Errorf("oidc: %v", err)
+		}
+	}
+
+	if c.AccountStatus.Claim != "" && len(c.AccountStatus.AllowedValues) == 0 {
+		return nil, errors.New("oidc: accountStatus.allowedValues is required when accountStatus.claim is set")
+	}
+
+	for i, t := range c.ClaimTransforms {
+		if err := t.validate(); err != nil {
+			return nil, fmt.Errorf("oidc: invalid claimTransforms[%d]: %v", i, err)
+		}
+	}
+
+	claimMutations := make([]claimMutation, len(c.ClaimMutations))
+	for i, m := range c.ClaimMutations {
+		if claimMutations[i], err = m.compile(); err != nil {
+			return nil, fmt.Errorf("oidc: invalid claimMutations[%d]: %v", i, err)
+		}
+	}
+
+	var groupsFilter, groupsDenyFilter *regexp.Regexp
+	if c.GroupsFilter != "" {
+		if groupsFilter, err = regexp.Compile(c.GroupsFilter); err != nil {
+			return nil, fmt.Errorf("oidc: invalid groupsFilter: %v", err)
+		}
+	}
+	if c.GroupsDenyFilter != "" {
+		if groupsDenyFilter, err = regexp.Compile(c.GroupsDenyFilter); err != nil {
+			return nil, fmt.Errorf("oidc: invalid groupsDenyFilter: %v", err)
+		}
+	}
 
-	provider, err := oidc.NewProvider(ctx, c.Issuer)
+	var usernameTemplate *template.Template
+	if c.UsernameTemplate != "" {
+		usernameTemplate, err = template.New("username").
+			Option("missingkey=error").
+			Funcs(usernameTemplateFuncs).
+			Parse(c.UsernameTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: invalid usernameTemplate: %v", err)
+		}
+	}
+
+	var userIDTemplate *template.Template
+	if c.UserIDTemplate != "" {
+		if c.UserIDKey != "" {
+			return nil, errors.New("oidc: only one of userIDKey and userIDTemplate can be set")
+		}
+		userIDTemplate, err = template.New("user ID").
+			Option("missingkey=error").
+			Funcs(usernameTemplateFuncs).
+			Parse(c.UserIDTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: invalid userIDTemplate: %v", err)
+		}
+	}
+
+	if err := c.Endpoints.validate(); err != nil {
+		return nil, fmt.Errorf("oidc: invalid endpoints: %v", err)
+	}
+	if c.RequireEmailVerified && c.InsecureSkipEmailVerified {
+		return nil, errors.New("oidc: requireEmailVerified can't be combined with insecureSkipEmailVerified")
+	}
+
+	var staticKeys *staticKeySet
+	var reloadInterval time.Duration
+	if c.StaticKeys != "" || c.JWKSFile != "" {
+		if c.StaticKeys != "" && c.JWKSFile != "" {
+			return nil, errors.New("oidc: only one of staticKeys and jwksFile can be set")
+		}
+		if staticKeys, err = c.newStaticKeySet(); err != nil {
+			return nil, fmt.Errorf("oidc: invalid static keys: %v", err)
+		}
+	}
+	if c.CacheKeySet && staticKeys != nil {
+		return nil, errors.New("oidc: cacheKeySet can't be combined with staticKeys or jwksFile")
+	}
+	if c.CacheKeySet && c.keySetCache == nil {
+		logger.Warnf("oidc: connector %q can't cache its key set, no cache is available", id)
+	}
+	if c.ResolveDistributedClaims && !c.InsecureEnableGroups {
+		logger.Warnf("oidc: connector %q doesn't resolve distributed claims, insecureEnableGroups is disabled", id)
+	}
+	if c.InsecureSkipVerify {
+		logger.Warnf("oidc: connector %q doesn't verify the certificates of the provider, never enable insecureSkipVerify in production", id)
+	}
+	if c.InsecureSkipIssuerCheck {
+		logger.Warnf("oidc: connector %q accepts ID tokens of any issuer, never enable insecureSkipIssuerCheck in production", id)
+	}
+	var keysRefreshInterval time.Duration
+	keysGracePeriod := defaultKeysGracePeriod
+	if c.KeysRefreshInterval != "" {
+		if staticKeys != nil {
+			return nil, errors.New("oidc: keysRefreshInterval can't be combined with staticKeys or jwksFile")
+		}
+		if keysRefreshInterval, err = time.ParseDuration(c.KeysRefreshInterval); err != nil || keysRefreshInterval <= 0 {
+			return nil, fmt.Errorf("oidc: invalid keysRefreshInterval %q", c.KeysRefreshInterval)
+		}
+	}
+	if c.KeysGracePeriod != "" {
+		if c.KeysRefreshInterval == "" {
+			return nil, errors.New("oidc: keysGracePeriod requires keysRefreshInterval")
+		}
+		if keysGracePeriod, err = time.ParseDuration(c.KeysGracePeriod); err != nil || keysGracePeriod < 0 {
+			return nil, fmt.Errorf("oidc: invalid keysGracePeriod %q", c.KeysGracePeriod)
+		}
+	}
+	clockSkew := defaultClockSkew
+	if c.ClockSkew != "" {
+		if clockSkew, err = time.ParseDuration(c.ClockSkew); err != nil || clockSkew < 0 || clockSkew > maxClockSkew {
+			return nil, fmt.Errorf("oidc: invalid clockSkew %q, must be between 0s and %v", c.ClockSkew, maxClockSkew)
+		}
+	}
+	stateTTL := defaultStateTTL
+	if c.StateTTL != "" {
+		if stateTTL, err = time.ParseDuration(c.StateTTL); err != nil || stateTTL <= 0 {
+			return nil, fmt.Errorf("oidc: invalid stateTTL %q", c.StateTTL)
+		}
+	}
+	if c.JWKSFileReloadInterval != "" {
+		if c.JWKSFile == "" {
+			return nil, errors.New("oidc: jwksFileReloadInterval requires a jwksFile")
+		}
+		if reloadInterval, err = time.ParseDuration(c.JWKSFileReloadInterval); err != nil || reloadInterval <= 0 {
+			return nil, fmt.Errorf("oidc: invalid jwksFileReloadInterval %q", c.JWKSFileReloadInterval)
+		}
+	}
+
+	transport, err := c.newTransport()
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %v", err)
+	}
+	httpClient, err := c.HTTPClientConfig.newHTTPClient(transport)
 	if err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to get provider: %v", err)
+		return nil, fmt.Errorf("oidc: invalid httpClientConfig: %v", err)
+	}
+	if httpClient.Timeout > 0 {
+		logger.Infof("oidc: connector %q uses an HTTP client timeout of %v", id, httpClient.Timeout)
+	} else {
+		logger.Infof("oidc: connector %q uses no HTTP client timeout", id)
 	}
+	discovery, err := c.DiscoveryRetry.newRetrier()
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid discoveryRetry: %v", err)
+	}
+	jwks := &jwksTransport{base: httpClient.Transport, connectorID: id}
+	httpClient.Transport = jwks
+	keySetClient := httpClient
+
+	ctx, cancel := context.WithCancel(oidc.ClientContext(context.Background(), httpClient))
+
+	// The discovery document is only fetched for the endpoints which aren't
+	// configured.
+	var provider *oidc.Provider
+	var scopesSupported []string
+	endpoint := oauth2.Endpoint{AuthURL: c.Endpoints.AuthURL, TokenURL: c.Endpoints.TokenURL}
+	jwksURL := c.Endpoints.JWKSURL
+	userInfoURL := c.Endpoints.UserInfoURL
+	if endpoint.AuthURL == "" || endpoint.TokenURL == "" || (staticKeys == nil && jwksURL == "") || (c.GetUserInfo && userInfoURL == "") {
+		// The issuer of the discovery document is checked below, as go-oidc
+		// doesn't tolerate trailing slash differences.
+		logger.Debugf("oidc: connector %q fetches the discovery document of %q", id, c.Issuer)
+		provider, err = discovery.newProvider(oidc.InsecureIssuerURLContext(ctx, c.Issuer), c.Issuer, logger)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to get provider: %v", err)
+		}
+		var providerClaims struct {
+			Issuer          string   `json:"issuer"`
+			JWKSURL         string   `json:"jwks_uri"`
+			ScopesSupported []string `json:"scopes_supported"`
+		}
+		if err := provider.Claims(&providerClaims); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to decode provider discovery object: %v", err)
+		}
+		if !c.InsecureSkipIssuerCheck && !issuersMatch(c.Issuer, providerClaims.Issuer) {
+			cancel()
+			return nil, fmt.Errorf("failed to get provider: issuer did not match the issuer returned by provider, expected %q got %q", c.Issuer, providerClaims.Issuer)
+		}
 
-	endpoint := provider.Endpoint()
+		discovered := provider.Endpoint()
+		if endpoint.AuthURL == "" {
+			endpoint.AuthURL = discovered.AuthURL
+		}
+		if endpoint.TokenURL == "" {
+			endpoint.TokenURL = discovered.TokenURL
+		}
+		if jwksURL == "" {
+			jwksURL = providerClaims.JWKSURL
+		}
+		scopesSupported = providerClaims.ScopesSupported
+	}
+	if staticKeys == nil {
+		jwks.url = jwksURL
+	}
 
-	if c.BasicAuthUnsupported != nil {
-		// Setting "basicAuthUnsupported" always overrides our detection.
-		if *c.BasicAuthUnsupported {
+	clientSecret := c.ClientSecret
+	switch c.ClientAuthMethod {
+	case "":
+		if c.BasicAuthUnsupported != nil {
+			// Setting "basicAuthUnsupported" always overrides our detection.
+			if *c.BasicAuthUnsupported {
+				endpoint.AuthStyle = oauth2.AuthStyleInParams
+			}
+		} else if knownBrokenAuthHeaderProvider(c.Issuer) {
 			endpoint.AuthStyle = oauth2.AuthStyleInParams
 		}
-	} else if knownBrokenAuthHeaderProvider(c.Issuer) {
+	case clientAuthMethodBasic:
+		endpoint.AuthStyle = oauth2.AuthStyleInHeader
+	case clientAuthMethodPost:
 		endpoint.AuthStyle = oauth2.AuthStyleInParams
+	case clientAuthMethodPrivateJWT:
+		signer, err := newClientAssertionSigner(c.ClientID, endpoint.TokenURL, c.SigningKeyFile)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("oidc: %v", err)
+		}
+		// Only the client_id is sent in the parameters, along with the
+		// client assertion added by the transport.
+		endpoint.AuthStyle = oauth2.AuthStyleInParams
+		clientSecret = ""
+		httpClient = &http.Client{
+			Transport: &clientAssertionTransport{base: httpClient.Transport, signer: signer},
+			Timeout:   httpClient.Timeout,
+		}
 	}
 
 	scopes := []string{oidc.ScopeOpenID}
@@ -138,6 +827,10 @@ func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, e
 	} else {
 		scopes = append(scopes, "profile", "email")
 	}
+	// Providers supporting the offline_access scope only issue refresh tokens
+	// if it's requested. Unless it's always requested, it's only requested for
+	// logins of clients which asked for offline access.
+	offlineAccessScope := hasScope(scopesSupported, oidc.ScopeOfflineAccess) && !hasScope(scopes, oidc.ScopeOfflineAccess)
 
 	// PromptType should be "consent" by default, if not set
 	if c.PromptType == "" {
@@ -145,62 +838,172 @@ func (c *Config) Open(id string, logger log.Logger) (conn connector.Connector, e
 	}
 
 	clientID := c.ClientID
+	// The audience is checked against the allowed audiences, the issuer
+	// against the configured one, and the expiry allowing for the clock skew,
+	// when verifying the token.
+	verifierConfig := &oidc.Config{
+		ClientID:          clientID,
+		SkipClientIDCheck: len(c.AllowedAudiences) > 0,
+		SkipIssuerCheck:   true,
+		SkipExpiryCheck:   true,
+	}
+	var keySet oidc.KeySet
+	switch {
+	case staticKeys != nil:
+		keySet = staticKeys
+		if reloadInterval > 0 {
+			go staticKeys.reload(ctx, c.JWKSFile, reloadInterval, logger)
+		}
+	case c.CacheKeySet && c.keySetCache != nil, keysRefreshInterval > 0:
+		config := remoteKeySetConfig{
+			connectorID:     id,
+			jwksURL:         jwksURL,
+			client:          keySetClient,
+			refreshInterval: keysRefreshInterval,
+			gracePeriod:     keysGracePeriod,
+			logger:          logger,
+		}
+		if c.CacheKeySet {
+			config.cache = c.keySetCache
+		}
+		keySet = newRemoteKeySet(ctx, config)
+	case c.Endpoints.JWKSURL != "" || userInfoURL != "":
+		// The key set is also needed to verify signed userinfo responses
+		// of the configured userinfo endpoint.
+		keySet = oidc.NewRemoteKeySet(ctx, jwksURL)
+	}
+	var verifier *oidc.IDTokenVerifier
+	if keySet != nil {
+		verifier = oidc.NewVerifier(c.Issuer, keySet, verifierConfig)
+	} else {
+		verifier = provider.Verifier(verifierConfig)
+	}
+
 	return &oidcConnector{
+		id:          id,
 		provider:    provider,
 		redirectURI: c.RedirectURI,
 		oauth2Config: &oauth2.Config{
 			ClientID:     clientID,
-			ClientSecret: c.ClientSecret,
+			ClientSecret: clientSecret,
 			Endpoint:     endpoint,
 			Scopes:       scopes,
 			RedirectURL:  c.RedirectURI,
 		},
-		verifier: provider.Verifier(
-			&oidc.Config{ClientID: clientID},
-		),
+		verifier:                    verifier,
+		issuer:                      c.Issuer,
+		insecureSkipIssuerCheck:     c.InsecureSkipIssuerCheck,
+		clockSkew:                   clockSkew,
+		stateTTL:                    stateTTL,
+		idTokenDecrypter:            decrypter,
+		keySet:                      keySet,
+		userInfoURL:                 userInfoURL,
+		allowedAudiences:            allowedAudiences(clientID, c.AllowedAudiences),
+		offlineAccessScope:          offlineAccessScope,
+		validateAZP:                 c.ValidateAZP,
+		httpClient:                  httpClient,
 		logger:                      logger,
 		cancel:                      cancel,
 		hostedDomains:               c.HostedDomains,
+		allowedTenants:              c.AllowedTenants,
 		insecureSkipEmailVerified:   c.InsecureSkipEmailVerified,
+		requireEmailVerified:        c.RequireEmailVerified,
+		canonicalizeEmail:           c.CanonicalizeEmail,
 		insecureEnableGroups:        c.InsecureEnableGroups,
+		distributedClaims:           c.ResolveDistributedClaims,
 		acrValues:                   c.AcrValues,
+		allowedScopes:               c.AllowedScopes,
+		enforceRequestedACR:         c.EnforceRequestedACR == nil || *c.EnforceRequestedACR,
+		acrRanking:                  c.ACRRanking,
+		requireACR:                  c.RequireACR,
 		getUserInfo:                 c.GetUserInfo,
 		promptType:                  c.PromptType,
+		forwardSelectAccountPrompt:  c.ForwardSelectAccountPrompt,
+		forwardPromptNone:           c.ForwardPromptNone,
+		upstreamExpiryClaim:         c.UpstreamExpiryClaim,
+		forwardUpstreamTokens:       c.ForwardUpstreamTokens,
+		enablePKCE:                  c.EnablePKCE,
+		enableNonce:                 c.EnableNonce,
 		userIDKey:                   c.UserIDKey,
 		userNameKey:                 c.UserNameKey,
+		usernameTemplate:            usernameTemplate,
+		userIDTemplate:              userIDTemplate,
 		overrideClaimMapping:        c.OverrideClaimMapping,
 		preferredUsernameKey:        c.ClaimMapping.PreferredUsernameKey,
 		emailKey:                    c.ClaimMapping.EmailKey,
-		groupsKey:                   c.ClaimMapping.GroupsKey,
+		groupsKeys:                  c.ClaimMapping.GroupsKey,
+		groupsDelimiter:             c.GroupsDelimiter,
 		additionalAuthRequestParams: c.AdditionalAuthRequestParams,
+		accountStatusClaim:          c.AccountStatus.Claim,
+		allowedAccountStatuses:      c.AccountStatus.AllowedValues,
+		claimTransforms:             c.ClaimTransforms,
+		groupsFilter:                groupsFilter,
+		groupsDenyFilter:            groupsDenyFilter,
+		claimMutations:              claimMutations,
 	}, nil
 }
 
 var (
-	_ connector.CallbackConnector = (*oidcConnector)(nil)
-	_ connector.RefreshConnector  = (*oidcConnector)(nil)
+	_ connector.CallbackConnector         = (*oidcConnector)(nil)
+	_ connector.StatefulCallbackConnector = (*oidcConnector)(nil)
+	_ connector.RefreshConnector          = (*oidcConnector)(nil)
 )
 
 type oidcConnector struct {
+	id                          string
 	provider                    *oidc.Provider
 	redirectURI                 string
 	oauth2Config                *oauth2.Config
 	verifier                    *oidc.IDTokenVerifier
+	issuer                      string
+	insecureSkipIssuerCheck     bool
+	clockSkew                   time.Duration
+	stateTTL                    time.Duration
+	idTokenDecrypter            *idTokenDecrypter
+	keySet                      oidc.KeySet
+	userInfoURL                 string
+	allowedAudiences            []string
+	offlineAccessScope          bool
+	validateAZP                 bool
+	httpClient                  *http.Client
 	cancel                      context.CancelFunc
 	logger                      log.Logger
 	hostedDomains               []string
+	allowedTenants              []string
 	insecureSkipEmailVerified   bool
+	requireEmailVerified        bool
+	canonicalizeEmail           bool
 	insecureEnableGroups        bool
+	distributedClaims           bool
 	acrValues                   []string
+	allowedScopes               []string
+	enforceRequestedACR         bool
+	acrRanking                  []string
+	requireACR                  []string
 	getUserInfo                 bool
 	promptType                  string
+	forwardSelectAccountPrompt  bool
+	forwardPromptNone           bool
+	upstreamExpiryClaim         string
+	forwardUpstreamTokens       bool
+	enablePKCE                  bool
+	enableNonce                 bool
 	userIDKey                   string
 	userNameKey                 string
+	usernameTemplate            *template.Template
+	userIDTemplate              *template.Template
 	overrideClaimMapping        bool
 	preferredUsernameKey        string
 	emailKey                    string
-	groupsKey                   string
+	groupsKeys                  []string
+	groupsDelimiter             string
 	additionalAuthRequestParams map[string]string
+	accountStatusClaim          string
+	allowedAccountStatuses      []string
+	claimTransforms             []ClaimTransform
+	groupsFilter                *regexp.Regexp
+	groupsDenyFilter            *regexp.Regexp
+	claimMutations              []claimMutation
 }
 
 func (c *oidcConnector) Close() error {
@@ -209,11 +1012,94 @@ func (c *oidcConnector) Close() error {
 }
 
 func (c *oidcConnector) LoginURL(s connector.Scopes, callbackURL, state string) (string, error) {
+	if c.enablePKCE {
+		return "", errors.New("oidc: PKCE requires the code verifier to be kept until the callback")
+	}
+	if c.enableNonce {
+		return "", errors.New("oidc: nonces require the nonce to be kept until the callback")
+	}
+	return c.loginURL(s, callbackURL, state, nil)
+}
+
+// LoginURLWithData returns the login URL and the login data to keep until the
+// callback: the nonce the ID token must contain if nonces are enabled, and the
+// code verifier to send in the token request if PKCE is enabled.
+func (c *oidcConnector) LoginURLWithData(s connector.Scopes, callbackURL, state string) (string, []byte, error) {
+	return c.loginURLWithData(s, callbackURL, state, nil)
+}
+
+// managedAuthParams are the parameters of the authorization request which
+// the connector sets itself, and login hints can't replace.
+var managedAuthParams = map[string]bool{
+	"client_id":             true,
+	"redirect_uri":          true,
+	"response_type":         true,
+	"scope":                 true,
+	"state":                 true,
+	"nonce":                 true,
+	"prompt":                true,
+	"code_challenge":        true,
+	"code_challenge_method": true,
+}
+
+// LoginURLWithHints is LoginURLWithData adding parameters varying per login,
+// like a login_hint, to the login URL. They take precedence over the
+// additionalAuthRequestParams.
+func (c *oidcConnector) LoginURLWithHints(s connector.Scopes, callbackURL, state string, hints map[string]string) (string, []byte, error) {
+	for name := range hints {
+		if managedAuthParams[name] {
+			return "", nil, fmt.Errorf("oidc: login hints can't set the %q parameter", name)
+		}
+	}
+	return c.loginURLWithData(s, callbackURL, state, hints)
+}
+
+func (c *oidcConnector) loginURLWithData(s connector.Scopes, callbackURL, state string, hints map[string]string) (string, []byte, error) {
+	var (
+		data loginData
+		opts []oauth2.AuthCodeOption
+		err  error
+	)
+	if c.enableNonce {
+		if data.Nonce, err = randomString(); err != nil {
+			return "", nil, fmt.Errorf("oidc: failed to generate nonce: %v", err)
+		}
+		opts = append(opts, oidc.Nonce(data.Nonce))
+	}
+
+	if c.enablePKCE {
+		if data.CodeVerifier, err = randomString(); err != nil {
+			return "", nil, fmt.Errorf("oidc: failed to generate code verifier: %v", err)
+		}
+		challenge := sha256.Sum256([]byte(data.CodeVerifier))
+		opts = append(opts,
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		)
+	}
+
+	connData, err := json.Marshal(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("oidc: failed to marshal login data: %v", err)
+	}
+	loginURL, err := c.loginURL(s, callbackURL, state, hints, opts...)
+	return loginURL, connData, err
+}
+
+// randomString returns a random value for PKCE code verifiers and nonces.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (c *oidcConnector) loginURL(s connector.Scopes, callbackURL, state string, hints map[string]string, opts ...oauth2.AuthCodeOption) (string, error) {
 	if c.redirectURI != callbackURL {
 		return "", fmt.Errorf("expected callback URL %q did not match the URL in the config %q", callbackURL, c.redirectURI)
 	}
 
-	var opts []oauth2.AuthCodeOption
 	if len(c.hostedDomains) > 0 {
 		preferredDomain := c.hostedDomains[0]
 		if len(c.hostedDomains) > 1 {
@@ -222,22 +1108,99 @@ func (c *oidcConnector) LoginURL(s connector.Scopes, callbackURL, state string)
 		opts = append(opts, oauth2.SetAuthURLParam("hd", preferredDomain))
 	}
 
-	if len(c.acrValues) > 0 {
-		acrValues := strings.Join(c.acrValues, " ")
-		opts = append(opts, oauth2.SetAuthURLParam("acr_values", acrValues))
+	// The values requested by the downstream client take precedence over the
+	// configured ones.
+	acrValues := c.acrValues
+	if len(s.ACRValues) > 0 {
+		acrValues = s.ACRValues
+	}
+	if len(acrValues) > 0 {
+		opts = append(opts, oauth2.SetAuthURLParam("acr_values", strings.Join(acrValues, " ")))
+	}
+	if s.MaxAge != nil {
+		opts = append(opts, oauth2.SetAuthURLParam("max_age", strconv.Itoa(*s.MaxAge)))
 	}
 
+	oauth2Config := c.oauth2Config
+	var prompts []string
 	if s.OfflineAccess {
-		opts = append(opts, oauth2.AccessTypeOffline, oauth2.SetAuthURLParam("prompt", c.promptType))
+		// Google style providers issue refresh tokens for access_type=offline
+		// instead of the offline_access scope.
+		opts = append(opts, oauth2.AccessTypeOffline)
+		prompts = append(prompts, c.promptType)
+		if c.offlineAccessScope {
+			config := *c.oauth2Config
+			config.Scopes = append(config.Scopes[:len(config.Scopes):len(config.Scopes)], oidc.ScopeOfflineAccess)
+			oauth2Config = &config
+		}
+	}
+	if upstreamScopes := c.upstreamScopes(s.UpstreamScopes); len(upstreamScopes) > 0 {
+		config := *oauth2Config
+		config.Scopes = mergeScopes(config.Scopes, upstreamScopes)
+		oauth2Config = &config
+	}
+	if s.SelectAccount && c.forwardSelectAccountPrompt && c.promptType != promptSelectAccount {
+		prompts = append(prompts, promptSelectAccount)
+	}
+	silent := s.PromptNone && c.forwardPromptNone
+	if silent {
+		// "none" must not be combined with other values.
+		prompts = []string{promptNone}
+	}
+	if len(prompts) > 0 {
+		opts = append(opts, oauth2.SetAuthURLParam("prompt", strings.Join(prompts, " ")))
 	}
 
 	if len(c.additionalAuthRequestParams) > 0 {
 		for k, v := range c.additionalAuthRequestParams {
+			// A configured prompt, for example "login", would turn a silent
+			// login into an interactive one.
+			if silent && k == "prompt" {
+				continue
+			}
 			opts = append(opts, oauth2.SetAuthURLParam(k, v))
 		}
 	}
+	for k, v := range hints {
+		opts = append(opts, oauth2.SetAuthURLParam(k, v))
+	}
 
-	return c.oauth2Config.AuthCodeURL(state, opts...), nil
+	return oauth2Config.AuthCodeURL(encodeState(state, time.Now()), opts...), nil
+}
+
+// upstreamScopes returns the scopes of the ones the downstream client asked
+// for which the connector may request.
+func (c *oidcConnector) upstreamScopes(requested []string) []string {
+	var allowed []string
+	for _, scope := range requested {
+		if hasScope(c.allowedScopes, scope) {
+			allowed = append(allowed, scope)
+		}
+	}
+	return allowed
+}
+
+// mergeScopes returns the scopes of all lists, each once and starting with
+// the openid scope, which is always requested.
+func mergeScopes(lists ...[]string) []string {
+	merged := []string{oidc.ScopeOpenID}
+	for _, scopes := range lists {
+		for _, scope := range scopes {
+			if !hasScope(merged, scope) {
+				merged = append(merged, scope)
+			}
+		}
+	}
+	return merged
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
 
 type oauth2Error struct {
@@ -253,23 +1216,92 @@ func (e *oauth2Error) Error() string {
 }
 
 func (c *oidcConnector) HandleCallback(s connector.Scopes, r *http.Request) (identity connector.Identity, err error) {
-	q := r.URL.Query()
+	return c.HandleCallbackWithData(s, nil, r)
+}
+
+// callbackParams returns the parameters of a callback, from the query or, for
+// providers returning them with response_mode=form_post, from the form the
+// user agent posted.
+//
+// See: https://openid.net/specs/oauth-v2-form-post-response-mode-1_0.html
+func callbackParams(r *http.Request) (url.Values, error) {
+	if r.Method != http.MethodPost {
+		return r.URL.Query(), nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return nil, fmt.Errorf("oidc: unsupported content type %q of posted callback", contentType)
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("oidc: failed to parse posted callback: %v", err)
+	}
+	return r.PostForm, nil
+}
+
+// HandleCallbackWithData exchanges the code, sending the code verifier from
+// the login data if PKCE is enabled, and checks the nonce of the ID token if
+// nonces are enabled.
+func (c *oidcConnector) HandleCallbackWithData(s connector.Scopes, connData []byte, r *http.Request) (identity connector.Identity, err error) {
+	defer func() { metrics.callbacks.WithLabelValues(c.id, result(err)).Inc() }()
+
+	q, err := callbackParams(r)
+	if err != nil {
+		return identity, err
+	}
 	if errType := q.Get("error"); errType != "" {
+		if interactionRequiredErrors[errType] {
+			return identity, &connector.InteractionRequiredError{Code: errType, Description: q.Get("error_description")}
+		}
 		return identity, &oauth2Error{errType, q.Get("error_description")}
 	}
-	token, err := c.oauth2Config.Exchange(r.Context(), q.Get("code"))
+
+	if err := c.checkState(q.Get("state"), time.Now()); err != nil {
+		return identity, err
+	}
+
+	var data loginData
+	if connData != nil {
+		if err := json.Unmarshal(connData, &data); err != nil {
+			return identity, fmt.Errorf("oidc: failed to unmarshal login data: %v", err)
+		}
+	}
+	if c.enableNonce && data.Nonce == "" {
+		return identity, errors.New("oidc: no nonce for the callback")
+	}
+
+	var opts []oauth2.AuthCodeOption
+	if c.enablePKCE {
+		if data.CodeVerifier == "" {
+			return identity, errors.New("oidc: no code verifier for the callback")
+		}
+		opts = append(opts, oauth2.SetAuthURLParam("code_verifier", data.CodeVerifier))
+	}
+
+	ctx := c.withCorrelationID(r.Context(), requestCorrelationID(r))
+	ctx = oidc.ClientContext(ctx, c.httpClient)
+	logger := c.loggerFrom(ctx)
+	logger.Debugf("oidc: exchanging the code at %q", c.oauth2Config.Endpoint.TokenURL)
+	start := time.Now()
+	token, err := c.oauth2Config.Exchange(ctx, q.Get("code"), opts...)
+	metrics.tokenExchange.WithLabelValues(c.id, "authorization_code").Observe(time.Since(start).Seconds())
 	if err != nil {
+		logger.Debugf("oidc: failed to exchange the code: %v", err)
 		return identity, fmt.Errorf("oidc: failed to get token: %v", err)
 	}
 
-	return c.createIdentity(r.Context(), identity, token)
+	nonce := ""
+	if c.enableNonce {
+		nonce = data.Nonce
+	}
+	return c.createIdentity(ctx, s, identity, token, nonce)
 }
 
 // Refresh is used to refresh a session with the refresh token provided by the IdP
-func (c *oidcConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+func (c *oidcConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (_ connector.Identity, err error) {
+	defer func() { metrics.refreshes.WithLabelValues(c.id, result(err)).Inc() }()
+
 	cd := connectorData{}
-	err := json.Unmarshal(identity.ConnectorData, &cd)
-	if err != nil {
+	if err := json.Unmarshal(identity.ConnectorData, &cd); err != nil {
 		return identity, fmt.Errorf("oidc: failed to unmarshal connector data: %v", err)
 	}
 
@@ -277,45 +1309,354 @@ func (c *oidcConnector) Refresh(ctx context.Context, s connector.Scopes, identit
 		RefreshToken: string(cd.RefreshToken),
 		Expiry:       time.Now().Add(-time.Hour),
 	}
+	ctx = c.withCorrelationID(ctx, "")
+	ctx = oidc.ClientContext(ctx, c.httpClient)
+	logger := c.loggerFrom(ctx)
+	logger.Debugf("oidc: refreshing the upstream tokens of %q", identity.UserID)
+	// If the provider doesn't rotate refresh tokens, the old one is kept in
+	// the token and so in the new connector data.
+	start := time.Now()
 	token, err := c.oauth2Config.TokenSource(ctx, t).Token()
+	metrics.tokenExchange.WithLabelValues(c.id, "refresh_token").Observe(time.Since(start).Seconds())
 	if err != nil {
+		logger.Debugf("oidc: failed to refresh the upstream tokens of %q: %v", identity.UserID, err)
+		if isInvalidGrant(err) {
+			return identity, fmt.Errorf("oidc: refresh token rejected: %w", connector.ErrReauthenticate)
+		}
 		return identity, fmt.Errorf("oidc: failed to get refresh token: %v", err)
 	}
+	logger.Debugf("oidc: refreshed upstream tokens of %q, which expire at %v", identity.UserID, token.Expiry)
+
+	return c.createIdentity(ctx, s, identity, token, "")
+}
+
+// isInvalidGrant reports whether the provider rejected a token request with
+// an "invalid_grant" error.
+func isInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(retrieveErr.Body, &resp) != nil {
+		return false
+	}
+	return resp.Error == "invalid_grant"
+}
+
+// allowedAudiences returns the audiences accepted in ID tokens, which are
+// checked by the verifier if no further audiences are configured.
+func allowedAudiences(clientID string, audiences []string) []string {
+	if len(audiences) == 0 {
+		return nil
+	}
+	return append([]string{clientID}, audiences...)
+}
+
+// audienceAllowed reports whether any of the audiences of an ID token is
+// allowed.
+func (c *oidcConnector) audienceAllowed(audiences []string) bool {
+	for _, aud := range audiences {
+		for _, allowed := range c.allowedAudiences {
+			if aud == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkAccountStatus returns an error unless the account status claim holds
+// one of the allowed values.
+func (c *oidcConnector) checkAccountStatus(claims map[string]interface{}) error {
+	v, found := claims[c.accountStatusClaim]
+	if !found {
+		return fmt.Errorf("oidc: missing \"%s\" claim", c.accountStatusClaim)
+	}
+	status := fmt.Sprint(v)
+	for _, allowed := range c.allowedAccountStatuses {
+		if status == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("oidc: account is disabled, \"%s\" claim is %q", c.accountStatusClaim, status)
+}
 
-	return c.createIdentity(ctx, identity, token)
+// usernameTemplateFuncs are the functions available to username and user ID
+// templates.
+var usernameTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// renderUsername builds the username from the claims with the username
+// template.
+func (c *oidcConnector) renderUsername(claims map[string]interface{}) (string, error) {
+	return renderClaims(c.usernameTemplate, claims)
+}
+
+// renderClaims executes a username or user ID template over the claims, and
+// fails if it renders an empty string.
+func renderClaims(t *template.Template, claims map[string]interface{}) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, claims); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", t.Name(), err)
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("%s template rendered an empty %s", t.Name(), t.Name())
+	}
+	return b.String(), nil
+}
+
+// keepGroup reports whether a group passes the groups filters.
+func (c *oidcConnector) keepGroup(group string) bool {
+	if c.groupsFilter != nil && !c.groupsFilter.MatchString(group) {
+		return false
+	}
+	return c.groupsDenyFilter == nil || !c.groupsDenyFilter.MatchString(group)
+}
+
+// mutateClaim returns the value rewritten by the claim mutation, or the value
+// itself if the regex doesn't match it.
+func (c *oidcConnector) mutateClaim(logger log.Logger, m claimMutation, value string) string {
+	if !m.regex.MatchString(value) {
+		logger.Debugf("oidc: claim mutation regex %q does not match the %q claim %q, keeping it", m.regex, m.key, value)
+		return value
+	}
+	return m.regex.ReplaceAllString(value, m.replacement)
 }
 
-func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.Identity, token *oauth2.Token) (connector.Identity, error) {
+// groupsClaim returns the groups of a groups claim, a list of strings or a
+// single string of groups separated by the groups delimiter. found is false
+// for missing claims and claims of other types.
+func (c *oidcConnector) groupsClaim(v interface{}) (groups []string, found bool, err error) {
+	switch v := v.(type) {
+	case []string:
+		return v, true, nil
+	case []interface{}:
+		groups = make([]string, 0, len(v))
+		for _, g := range v {
+			s, ok := g.(string)
+			if !ok {
+				return nil, true, fmt.Errorf("unexpected group of type %T", g)
+			}
+			groups = append(groups, s)
+		}
+		return groups, true, nil
+	case string:
+		if c.groupsDelimiter == "" {
+			return strings.Fields(v), true, nil
+		}
+		groups = []string{}
+		for _, s := range strings.Split(v, c.groupsDelimiter) {
+			if s = strings.TrimSpace(s); s != "" {
+				groups = append(groups, s)
+			}
+		}
+		return groups, true, nil
+	}
+	return nil, false, nil
+}
+
+// lookupClaim returns the claim with the given key. If there is none, a dotted
+// key is looked up as a path of nested claims.
+func lookupClaim(claims map[string]interface{}, key string) interface{} {
+	if v, ok := claims[key]; ok || !strings.Contains(key, ".") {
+		return v
+	}
+	var v interface{} = claims
+	for _, k := range strings.Split(key, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
+
+// checkAuthentication returns an error unless the ID token claims satisfy the
+// required acr values, and the acr_values and max_age requested by the
+// downstream client.
+func (c *oidcConnector) checkAuthentication(s connector.Scopes, claims map[string]interface{}, now time.Time) error {
+	acr, _ := claims["acr"].(string)
+	if len(c.requireACR) > 0 && !c.acrSatisfies(acr, c.requireACR) {
+		if acr == "" {
+			return fmt.Errorf("oidc: missing \"acr\" claim, one of %q is required", c.requireACR)
+		}
+		return fmt.Errorf("oidc: acr claim %q is not one of the required acr values %q", acr, c.requireACR)
+	}
+	if len(s.ACRValues) > 0 && c.enforceRequestedACR && !c.acrSatisfies(acr, s.ACRValues) {
+		return fmt.Errorf("oidc: acr claim %q does not satisfy the requested acr values %q", acr, s.ACRValues)
+	}
+
+	if s.MaxAge != nil {
+		authTime, ok := claims["auth_time"].(float64)
+		if !ok {
+			return errors.New("oidc: missing \"auth_time\" claim required by max_age")
+		}
+		if now.Sub(time.Unix(int64(authTime), 0)) > time.Duration(*s.MaxAge)*time.Second {
+			return fmt.Errorf("oidc: user authenticated more than the requested max_age of %d seconds ago", *s.MaxAge)
+		}
+	}
+	return nil
+}
+
+// mergeUserInfo adds the userinfo claims missing from the ID token claims. With
+// overrideClaimMapping set, the userinfo claims replace the ID token ones
+// instead. A failed userinfo request is logged and leaves the claims as they
+// are.
+func (c *oidcConnector) mergeUserInfo(ctx context.Context, subject string, token *oauth2.Token, claims map[string]interface{}) error {
+	var payload []byte
+	var err error
+	if c.userInfoURL != "" {
+		payload, err = c.fetchUserInfo(ctx, token)
+	} else {
+		var userInfo *oidc.UserInfo
+		if userInfo, err = c.provider.UserInfo(ctx, oauth2.StaticTokenSource(token)); err == nil {
+			var raw json.RawMessage
+			err = userInfo.Claims(&raw)
+			payload = raw
+		}
+	}
+	metrics.userInfo.WithLabelValues(c.id, result(err)).Inc()
+	if err != nil {
+		c.loggerFrom(ctx).Warnf("oidc: failed to load userinfo, using the id token claims only: %v", err)
+		return nil
+	}
+
+	var userInfoClaims map[string]interface{}
+	if err := json.Unmarshal(payload, &userInfoClaims); err != nil {
+		return fmt.Errorf("oidc: failed to decode userinfo claims: %v", err)
+	}
+	// Claims about another user must not be merged, see
+	// https://openid.net/specs/openid-connect-core-1_0.html#UserInfoResponse
+	if userInfoSubject, _ := userInfoClaims["sub"].(string); userInfoSubject != subject {
+		return fmt.Errorf("oidc: userinfo subject %q does not match id token subject %q", userInfoSubject, subject)
+	}
+	for k, v := range userInfoClaims {
+		if _, found := claims[k]; !found || c.overrideClaimMapping {
+			claims[k] = v
+		}
+	}
+	return nil
+}
+
+// checkTokenTimes checks that the ID token is neither expired, issued in the
+// future nor not valid yet, allowing for the clock skew between dex and the
+// provider.
+func (c *oidcConnector) checkTokenTimes(idToken *oidc.IDToken, claims map[string]interface{}, now time.Time) error {
+	if idToken.Expiry.Before(now.Add(-c.clockSkew)) {
+		return fmt.Errorf("oidc: failed to verify ID Token: token is expired (Token Expiry: %v)", idToken.Expiry)
+	}
+	if !idToken.IssuedAt.IsZero() && now.Add(c.clockSkew).Before(idToken.IssuedAt) {
+		return fmt.Errorf("oidc: failed to verify ID Token: token is issued in the future (Issued At: %v)", idToken.IssuedAt)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if notBefore := time.Unix(int64(nbf), 0); now.Add(c.clockSkew).Before(notBefore) {
+			return fmt.Errorf("oidc: failed to verify ID Token: token is not valid yet (Not Before: %v)", notBefore)
+		}
+	}
+	return nil
+}
+
+// acrSatisfies reports whether the acr is one of the requested values or, with
+// a ranking, at least as strong as one of them.
+func (c *oidcConnector) acrSatisfies(acr string, requested []string) bool {
+	rank := func(v string) int {
+		for i, ranked := range c.acrRanking {
+			if v == ranked {
+				return i
+			}
+		}
+		return -1
+	}
+
+	acrRank := rank(acr)
+	for _, v := range requested {
+		if acr == v {
+			return true
+		}
+		if r := rank(v); r >= 0 && acrRank >= r {
+			return true
+		}
+	}
+	return false
+}
+
+// createIdentity verifies the ID token of the token response and maps its
+// claims to an identity. A non-empty nonce must match the one of the ID token.
+func (c *oidcConnector) createIdentity(ctx context.Context, s connector.Scopes, identity connector.Identity, token *oauth2.Token, nonce string) (connector.Identity, error) {
 	rawIDToken, ok := token.Extra("id_token").(string)
 	if !ok {
 		return identity, errors.New("oidc: no id_token in token response")
 	}
+	if c.idTokenDecrypter != nil {
+		var err error
+		if rawIDToken, err = c.idTokenDecrypter.decrypt(rawIDToken); err != nil {
+			return identity, fmt.Errorf("oidc: failed to decrypt ID Token: %v", err)
+		}
+	}
+	logger := c.loggerFrom(ctx)
+	logger.Debugf("oidc: verifying the ID token")
 	idToken, err := c.verifier.Verify(ctx, rawIDToken)
 	if err != nil {
 		return identity, fmt.Errorf("oidc: failed to verify ID Token: %v", err)
 	}
+	if !c.insecureSkipIssuerCheck && !issuersMatch(c.issuer, idToken.Issuer) {
+		return identity, fmt.Errorf("oidc: failed to verify ID Token: id token issued by a different provider, expected %q got %q", c.issuer, idToken.Issuer)
+	}
+	if len(c.allowedAudiences) > 0 && !c.audienceAllowed(idToken.Audience) {
+		return identity, fmt.Errorf("oidc: failed to verify ID Token: none of the audiences %q is allowed", idToken.Audience)
+	}
+	if nonce != "" && idToken.Nonce != nonce {
+		return identity, errors.New("oidc: failed to verify ID Token: nonce does not match the login request")
+	}
 
 	var claims map[string]interface{}
 	if err := idToken.Claims(&claims); err != nil {
 		return identity, fmt.Errorf("oidc: failed to decode claims: %v", err)
 	}
+	if azp, found := claims["azp"]; found && c.validateAZP && azp != c.oauth2Config.ClientID {
+		return identity, fmt.Errorf("oidc: failed to verify ID Token: authorized party %q is not the client", azp)
+	}
+	now := time.Now()
+	if err := c.checkTokenTimes(idToken, claims, now); err != nil {
+		return identity, err
+	}
+	if err := c.checkAuthentication(s, claims, now); err != nil {
+		return identity, err
+	}
 
 	// We immediately want to run getUserInfo if configured before we validate the claims
 	if c.getUserInfo {
-		userInfo, err := c.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
-		if err != nil {
-			return identity, fmt.Errorf("oidc: error loading userinfo: %v", err)
+		if err := c.mergeUserInfo(ctx, idToken.Subject, token, claims); err != nil {
+			return identity, err
 		}
-		if err := userInfo.Claims(&claims); err != nil {
-			return identity, fmt.Errorf("oidc: failed to decode userinfo claims: %v", err)
+	}
+
+	if c.insecureEnableGroups && c.distributedClaims {
+		if err := c.resolveDistributedClaims(ctx, claims, token.AccessToken); err != nil {
+			return identity, err
 		}
 	}
 
+	transformClaims(c.claimTransforms, claims)
+
 	userNameKey := "name"
 	if c.userNameKey != "" {
 		userNameKey = c.userNameKey
 	}
 	name, found := claims[userNameKey].(string)
+	if c.usernameTemplate != nil {
+		if username, err := c.renderUsername(claims); err != nil {
+			logger.Warnf("oidc: falling back to the %q claim for the username: %v", userNameKey, err)
+		} else {
+			name, found = username, true
+		}
+	}
 	if !found {
 		return identity, fmt.Errorf("missing \"%s\" claim", userNameKey)
 	}
@@ -349,26 +1690,34 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 	if !found {
 		if c.insecureSkipEmailVerified {
 			emailVerified = true
-		} else if hasEmailScope {
+		} else if hasEmailScope || c.requireEmailVerified {
 			return identity, errors.New("missing \"email_verified\" claim")
 		}
 	}
+	if !emailVerified && c.requireEmailVerified {
+		return identity, fmt.Errorf("oidc: email %q is not verified", email)
+	}
 
 	var groups []string
 	if c.insecureEnableGroups {
-		groupsKey := "groups"
-		vs, found := claims[groupsKey].([]interface{})
-		if (!found || c.overrideClaimMapping) && c.groupsKey != "" {
-			groupsKey = c.groupsKey
-			vs, found = claims[groupsKey].([]interface{})
+		groupsKeys := []string{"groups"}
+		if _, found, _ := c.groupsClaim(claims["groups"]); (!found || c.overrideClaimMapping) && len(c.groupsKeys) > 0 {
+			groupsKeys = c.groupsKeys
 		}
 
-		if found {
-			for _, v := range vs {
-				if s, ok := v.(string); ok {
+		seen := make(map[string]bool)
+		for _, groupsKey := range groupsKeys {
+			vs, found, err := c.groupsClaim(lookupClaim(claims, groupsKey))
+			if !found {
+				continue
+			}
+			if err != nil {
+				return identity, fmt.Errorf("malformed \"%v\" claim", groupsKey)
+			}
+			for _, s := range vs {
+				if !seen[s] && c.keepGroup(s) {
+					seen[s] = true
 					groups = append(groups, s)
-				} else {
-					return identity, fmt.Errorf("malformed \"%v\" claim", groupsKey)
 				}
 			}
 		}
@@ -389,8 +1738,44 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 		}
 	}
 
+	tenant, _ := claims["tid"].(string)
+	if len(c.allowedTenants) > 0 {
+		found := false
+		for _, allowed := range c.allowedTenants {
+			if tenant == allowed {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return identity, fmt.Errorf("oidc: unexpected tid claim %v", tenant)
+		}
+	}
+
+	if c.accountStatusClaim != "" {
+		if err := c.checkAccountStatus(claims); err != nil {
+			return identity, err
+		}
+	}
+
 	cd := connectorData{
 		RefreshToken: []byte(token.RefreshToken),
+		Subject:      idToken.Subject,
+	}
+	// ID tokens of refreshes may leave out the sid claim, the session stays
+	// the same.
+	if sid, _ := claims["sid"].(string); sid != "" {
+		cd.SessionID = sid
+	} else if len(identity.ConnectorData) > 0 {
+		var old connectorData
+		if err := json.Unmarshal(identity.ConnectorData, &old); err == nil {
+			cd.SessionID = old.SessionID
+		}
+	}
+	if c.forwardUpstreamTokens {
+		cd.AccessToken = token.AccessToken
+		cd.IDToken = rawIDToken
 	}
 
 	connData, err := json.Marshal(&cd)
@@ -398,6 +1783,25 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 		return identity, fmt.Errorf("oidc: failed to encode connector data: %v", err)
 	}
 
+	for _, m := range c.claimMutations {
+		switch m.key {
+		case "name":
+			name = c.mutateClaim(logger, m, name)
+		case "preferred_username":
+			preferredUsername = c.mutateClaim(logger, m, preferredUsername)
+		case "email":
+			email = c.mutateClaim(logger, m, email)
+		case "groups":
+			for i, group := range groups {
+				groups[i] = c.mutateClaim(logger, m, group)
+			}
+		}
+	}
+
+	if c.canonicalizeEmail {
+		email = strings.ToLower(strings.TrimSpace(email))
+	}
+
 	identity = connector.Identity{
 		UserID:            idToken.Subject,
 		Username:          name,
@@ -408,6 +1812,14 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 		ConnectorData:     connData,
 	}
 
+	if authTime, ok := claims["auth_time"].(float64); ok {
+		identity.AuthTime = time.Unix(int64(authTime), 0)
+	}
+
+	if c.upstreamExpiryClaim != "" {
+		identity.CustomClaims = map[string]interface{}{c.upstreamExpiryClaim: idToken.Expiry.Unix()}
+	}
+
 	if c.userIDKey != "" {
 		userID, found := claims[c.userIDKey].(string)
 		if !found {
@@ -415,6 +1827,14 @@ func (c *oidcConnector) createIdentity(ctx context.Context, identity connector.I
 		}
 		identity.UserID = userID
 	}
+	if c.userIDTemplate != nil {
+		userID, err := renderClaims(c.userIDTemplate, claims)
+		if err != nil {
+			return identity, fmt.Errorf("oidc: %v", err)
+		}
+		identity.UserID = userID
+	}
 
+	logger.Debugf("oidc: mapped the claims of %q to user %q with %d groups", idToken.Subject, identity.UserID, len(identity.Groups))
 	return identity, nil
 }
@@ -2,8 +2,10 @@ package oidc
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
@@ -17,8 +19,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/square/go-jose.v2"
 
 	"github.com/dexidp/dex/connector"
@@ -138,6 +142,21 @@ func TestHandleCallback(t *testing.T) {
 				"email_verified": true,
 			},
 		},
+		{
+			name:                    "withPreferredUsernameUserIDKey",
+			userIDKey:               "preferred_username",
+			expectUserID:            "jdoe",
+			expectUserName:          "namevalue",
+			expectPreferredUsername: "jdoe",
+			expectedEmailField:      "emailvalue",
+			token: map[string]interface{}{
+				"sub":                "subvalue",
+				"name":               "namevalue",
+				"preferred_username": "jdoe",
+				"email":              "emailvalue",
+				"email_verified":     true,
+			},
+		},
 		{
 			name:               "withUserNameKey",
 			userNameKey:        "user_name",
@@ -307,7 +326,9 @@ func TestHandleCallback(t *testing.T) {
 			}
 			config.ClaimMapping.PreferredUsernameKey = tc.preferredUsernameKey
 			config.ClaimMapping.EmailKey = tc.emailKey
-			config.ClaimMapping.GroupsKey = tc.groupsKey
+			if tc.groupsKey != "" {
+				config.ClaimMapping.GroupsKey = GroupsKeys{tc.groupsKey}
+			}
 
 			conn, err := newConnector(config)
 			if err != nil {
@@ -334,185 +355,2379 @@ func TestHandleCallback(t *testing.T) {
 	}
 }
 
-func TestCustomLoginURL(t *testing.T) {
-	token := map[string]interface{}{}
-
-	testServer, err := setupServer(token)
-	if err != nil {
-		t.Fatal("failed to setup test server", err)
+func TestFormPostCallback(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
 	}
+	testServer, err := setupServer(token)
+	require.NoError(t, err)
 	defer testServer.Close()
 
-	serverUrl := testServer.URL
-
-	minConfig := Config{
-		Issuer:      serverUrl,
-		ClientID:    "my_client_id",
-		RedirectURI: fmt.Sprintf("%s/callback", serverUrl),
+	conn, err := newConnector(Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
 		AdditionalAuthRequestParams: map[string]string{
-			"organization": "myorg",
-			"connection":   "github",
+			"response_mode": "form_post",
 		},
-	}
+	})
+	require.NoError(t, err)
 
-	conn, err := newConnector(minConfig)
-	if err != nil {
-		t.Fatal("failed to create new connector", err)
+	post := func(contentType string, form url.Values) (connector.Identity, error) {
+		r := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", contentType)
+		return conn.HandleCallback(connector.Scopes{}, r)
 	}
 
-	// what we are testing, generation of LoginURL
-	loginUrl, err := conn.LoginURL(connector.Scopes{OfflineAccess: false, Groups: false}, minConfig.RedirectURI, "1234")
-	if err != nil {
-		t.Fatal("failed to get login url", err)
-	}
+	identity, err := post("application/x-www-form-urlencoded; charset=utf-8", url.Values{
+		"code":  {"someCode"},
+		"state": {encodeState("state", time.Now())},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "subvalue", identity.UserID)
 
-	u, err := url.Parse(loginUrl)
-	if err != nil {
-		t.Fatal("failed to parse login url", err)
-	}
+	_, err = post("application/x-www-form-urlencoded", url.Values{
+		"error":             {"access_denied"},
+		"error_description": {"the user declined"},
+		"state":             {encodeState("state", time.Now())},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "access_denied: the user declined", err.Error())
 
-	values, err := url.ParseQuery(u.RawQuery)
-	if err != nil {
-		t.Fatal("failed to parse login url query params", err)
-	}
+	_, err = post("application/x-www-form-urlencoded", url.Values{
+		"code":  {"someCode"},
+		"state": {encodeState("state", time.Now().Add(-2*defaultStateTTL))},
+	})
+	require.Error(t, err, "the state of posted callbacks is checked")
 
-	assertParamValue(t, values, "organization", "myorg")
-	assertParamValue(t, values, "connection", "github")
-	assertParamValue(t, values, "client_id", "my_client_id")
-	assertParamValue(t, values, "state", "1234")
+	_, err = post("application/json", url.Values{"code": {"someCode"}})
+	require.Error(t, err)
 }
 
-func TestCustomLoginURLEmptyParams(t *testing.T) {
-	token := map[string]interface{}{}
-
-	testServer, err := setupServer(token)
-	if err != nil {
-		t.Fatal("failed to setup test server", err)
+func TestAccountStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		claim         string
+		allowedValues []string
+		status        interface{}
+		expectErr     bool
+	}{
+		{
+			name:          "enabled",
+			claim:         "account_enabled",
+			allowedValues: []string{"true"},
+			status:        true,
+		},
+		{
+			name:          "disabled",
+			claim:         "account_enabled",
+			allowedValues: []string{"true"},
+			status:        false,
+			expectErr:     true,
+		},
+		{
+			name:          "active status",
+			claim:         "status",
+			allowedValues: []string{"active", "provisioned"},
+			status:        "provisioned",
+		},
+		{
+			name:          "suspended status",
+			claim:         "status",
+			allowedValues: []string{"active", "provisioned"},
+			status:        "suspended",
+			expectErr:     true,
+		},
+		{
+			name:          "missing status",
+			claim:         "status",
+			allowedValues: []string{"active"},
+			expectErr:     true,
+		},
+		{
+			name:   "not configured",
+			status: "suspended",
+		},
 	}
-	defer testServer.Close()
 
-	serverUrl := testServer.URL
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+			}
+			if tc.status != nil {
+				token["account_enabled"] = tc.status
+				token["status"] = tc.status
+			}
 
-	minConfig := Config{
-		Issuer:                      serverUrl,
-		ClientID:                    "my_client_id",
-		RedirectURI:                 fmt.Sprintf("%s/callback", serverUrl),
-		AdditionalAuthRequestParams: map[string]string{},
-	}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
 
-	conn, err := newConnector(minConfig)
-	if err != nil {
-		t.Fatal("failed to create new connector", err)
-	}
+			config := Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				Scopes:       []string{"email"},
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+			}
+			config.AccountStatus.Claim = tc.claim
+			config.AccountStatus.AllowedValues = tc.allowedValues
 
-	// what we are testing, generation of LoginURL
-	loginUrl, err := conn.LoginURL(connector.Scopes{OfflineAccess: false, Groups: false}, minConfig.RedirectURI, "1234")
-	if err != nil {
-		t.Fatal("failed to get login url", err)
-	}
+			conn, err := newConnector(config)
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
 
-	u, err := url.Parse(loginUrl)
-	if err != nil {
-		t.Fatal("failed to parse login url", err)
-	}
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
 
-	values, err := url.ParseQuery(u.RawQuery)
-	if err != nil {
-		t.Fatal("failed to parse login url query params", err)
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected login to be denied")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.UserID, "subvalue")
+		})
 	}
-
-	assertParamValue(t, values, "client_id", "my_client_id")
-	assertParamValue(t, values, "state", "1234")
-}
-
-func assertParamValue(t *testing.T, values url.Values, queryParam string, expectedValue string) {
-	assert.NotNil(t, values[queryParam])
-	assert.Equal(t, expectedValue, values[queryParam][0])
 }
 
-func setupServer(tok map[string]interface{}) (*httptest.Server, error) {
-	key, err := rsa.GenerateKey(rand.Reader, 1024)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate rsa key: %v", err)
+func TestAllowedTenants(t *testing.T) {
+	tests := []struct {
+		name           string
+		tenant         string
+		allowedTenants []string
+		expectErr      bool
+	}{
+		{
+			name:           "allowed tenant",
+			tenant:         "9188040d-6c67-4c5b-b112-36a304b66dad",
+			allowedTenants: []string{"72f988bf-86f1-41af-91ab-2d7cd011db47", "9188040d-6c67-4c5b-b112-36a304b66dad"},
+		},
+		{
+			name:           "disallowed tenant",
+			tenant:         "f8cdef31-a31e-4b4a-93e4-5f571e91255a",
+			allowedTenants: []string{"72f988bf-86f1-41af-91ab-2d7cd011db47"},
+			expectErr:      true,
+		},
+		{
+			name:           "missing tenant",
+			allowedTenants: []string{"72f988bf-86f1-41af-91ab-2d7cd011db47"},
+			expectErr:      true,
+		},
+		{
+			name:   "not configured",
+			tenant: "f8cdef31-a31e-4b4a-93e4-5f571e91255a",
+		},
 	}
 
-	jwk := jose.JSONWebKey{
-		Key:       key,
-		KeyID:     "keyId",
-		Algorithm: "RSA",
-	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+			}
+			if tc.tenant != "" {
+				token["tid"] = tc.tenant
+			}
 
-	mux := http.NewServeMux()
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
 
-	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
-		json.NewEncoder(w).Encode(&map[string]interface{}{
-			"keys": []map[string]interface{}{{
-				"alg": jwk.Algorithm,
-				"kty": jwk.Algorithm,
-				"kid": jwk.KeyID,
-				"n":   n(&key.PublicKey),
-				"e":   e(&key.PublicKey),
-			}},
-		})
-	})
+			conn, err := newConnector(Config{
+				Issuer:         testServer.URL,
+				ClientID:       "clientID",
+				ClientSecret:   "clientSecret",
+				Scopes:         []string{"email"},
+				RedirectURI:    fmt.Sprintf("%s/callback", testServer.URL),
+				AllowedTenants: tc.allowedTenants,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
 
-	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
-		url := fmt.Sprintf("http://%s", r.Host)
-		tok["iss"] = url
-		tok["exp"] = time.Now().Add(time.Hour).Unix()
-		tok["aud"] = "clientID"
-		token, err := newToken(&jwk, tok)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
 
-		w.Header().Add("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(&map[string]string{
-			"access_token": token,
-			"id_token":     token,
-			"token_type":   "Bearer",
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected login to be denied")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.UserID, "subvalue")
 		})
-	})
+	}
+}
 
-	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
-		url := fmt.Sprintf("http://%s", r.Host)
+func TestAllowedAudiences(t *testing.T) {
+	tests := []struct {
+		name             string
+		aud              interface{}
+		allowedAudiences []string
+		expectErr        bool
+	}{
+		{name: "client id", aud: "clientID"},
+		{name: "other audience", aud: "api://example", expectErr: true},
+		{name: "allowed string", aud: "api://example", allowedAudiences: []string{"api://example"}},
+		{name: "allowed array", aud: []string{"api://example", "api://other"}, allowedAudiences: []string{"api://example"}},
+		{name: "client id in array", aud: []string{"clientID", "api://other"}, allowedAudiences: []string{"api://example"}},
+		{name: "disallowed string", aud: "api://other", allowedAudiences: []string{"api://example"}, expectErr: true},
+		{name: "disallowed array", aud: []string{"api://other", "api://another"}, allowedAudiences: []string{"api://example"}, expectErr: true},
+	}
 
-		json.NewEncoder(w).Encode(&map[string]string{
-			"issuer":                 url,
-			"token_endpoint":         fmt.Sprintf("%s/token", url),
-			"authorization_endpoint": fmt.Sprintf("%s/authorize", url),
-			"userinfo_endpoint":      fmt.Sprintf("%s/userinfo", url),
-			"jwks_uri":               fmt.Sprintf("%s/keys", url),
-		})
-	})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+				"aud":            tc.aud,
+			}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:           testServer.URL,
+				ClientID:         "clientID",
+				ClientSecret:     "clientSecret",
+				RedirectURI:      fmt.Sprintf("%s/callback", testServer.URL),
+				AllowedAudiences: tc.allowedAudiences,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
 
-	return httptest.NewServer(mux), nil
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an ID token for %v to be rejected", tc.aud)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.UserID, "subvalue")
+		})
+	}
 }
 
-func newToken(key *jose.JSONWebKey, claims map[string]interface{}) (string, error) {
-	signingKey := jose.SigningKey{
-		Key:       key,
-		Algorithm: jose.RS256,
+func TestMultipleGroupsKeys(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"groups":         []string{"admins", "ops"},
+		"roles":          []string{"ops", "editor"},
+		"resource_access": map[string]interface{}{
+			"my-client": map[string]interface{}{"roles": []string{"viewer"}},
+		},
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
 	}
+	defer testServer.Close()
 
-	signer, err := jose.NewSigner(signingKey, &jose.SignerOptions{})
+	var config Config
+	err = json.Unmarshal([]byte(`{"claimMapping": {"groups": ["groups", "missing", "roles", "resource_access.my-client.roles"]}}`), &config)
 	if err != nil {
-		return "", fmt.Errorf("failed to create new signer: %v", err)
+		t.Fatal("failed to unmarshal config", err)
 	}
+	config.Issuer = testServer.URL
+	config.ClientID = "clientID"
+	config.ClientSecret = "clientSecret"
+	config.RedirectURI = fmt.Sprintf("%s/callback", testServer.URL)
+	config.InsecureEnableGroups = true
+	config.OverrideClaimMapping = true
 
-	payload, err := json.Marshal(claims)
+	conn, err := newConnector(config)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal claims: %v", err)
+		t.Fatal("failed to create new connector", err)
 	}
 
-	signature, err := signer.Sign(payload)
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
 	if err != nil {
-		return "", fmt.Errorf("failed to sign: %v", err)
+		t.Fatal("failed to create request", err)
 	}
-	return signature.CompactSerialize()
-}
 
-func newConnector(config Config) (*oidcConnector, error) {
+	identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	expectEquals(t, identity.Groups, []string{"admins", "ops", "editor", "viewer"})
+}
+
+func TestGroupsClaim(t *testing.T) {
+	tests := map[string]struct {
+		claim       interface{}
+		delimiter   string
+		expect      []string
+		expectFound bool
+		expectErr   bool
+	}{
+		"string list":          {claim: []string{"admins", "ops"}, expect: []string{"admins", "ops"}, expectFound: true},
+		"interface list":       {claim: []interface{}{"admins", "ops"}, expect: []string{"admins", "ops"}, expectFound: true},
+		"malformed list":       {claim: []interface{}{"admins", 1}, expectFound: true, expectErr: true},
+		"space delimited":      {claim: " admins  ops\tdevs ", expect: []string{"admins", "ops", "devs"}, expectFound: true},
+		"custom delimiter":     {claim: "admins, ops team,", delimiter: ",", expect: []string{"admins", "ops team"}, expectFound: true},
+		"single group":         {claim: "admins", expect: []string{"admins"}, expectFound: true},
+		"empty string":         {claim: "", expect: []string{}, expectFound: true},
+		"empty with delimiter": {claim: "", delimiter: ",", expect: []string{}, expectFound: true},
+		"missing":              {claim: nil},
+		"number":               {claim: 1.0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conn := &oidcConnector{groupsDelimiter: tc.delimiter}
+			groups, found, err := conn.groupsClaim(tc.claim)
+			assert.Equal(t, tc.expectFound, found)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, groups)
+		})
+	}
+}
+
+func TestDelimitedGroupsClaim(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"groups":         "admins ops",
+		"roles":          "ops;editor",
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	for _, tc := range []struct {
+		groupsKey    string
+		delimiter    string
+		expectGroups []string
+	}{
+		{groupsKey: "groups", expectGroups: []string{"admins", "ops"}},
+		{groupsKey: "roles", delimiter: ";", expectGroups: []string{"ops", "editor"}},
+	} {
+		t.Run(tc.groupsKey, func(t *testing.T) {
+			config := Config{
+				Issuer:               testServer.URL,
+				ClientID:             "clientID",
+				ClientSecret:         "clientSecret",
+				RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+				InsecureEnableGroups: true,
+				OverrideClaimMapping: true,
+				GroupsDelimiter:      tc.delimiter,
+			}
+			config.ClaimMapping.GroupsKey = GroupsKeys{tc.groupsKey}
+
+			conn, err := newConnector(config)
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.Groups, tc.expectGroups)
+		})
+	}
+}
+
+func TestGroupsKeysUnmarshal(t *testing.T) {
+	tests := map[string]struct {
+		json      string
+		expect    GroupsKeys
+		expectErr bool
+	}{
+		"string":       {json: `"cognito:groups"`, expect: GroupsKeys{"cognito:groups"}},
+		"empty string": {json: `""`},
+		"list":         {json: `["groups", "roles"]`, expect: GroupsKeys{"groups", "roles"}},
+		"number":       {json: `1`, expectErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var keys GroupsKeys
+			err := json.Unmarshal([]byte(tc.json), &keys)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, keys)
+		})
+	}
+}
+
+func TestUserInfo(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	}
+
+	tests := []struct {
+		name         string
+		userInfo     map[string]interface{}
+		signed       bool
+		override     bool
+		expectErr    bool
+		expectEmail  string
+		expectGroups []string
+	}{
+		{
+			name:         "json",
+			userInfo:     map[string]interface{}{"sub": "subvalue", "email": "userinfo@example.com", "email_verified": true, "groups": []string{"admins"}},
+			expectEmail:  "emailvalue",
+			expectGroups: []string{"admins"},
+		},
+		{
+			name:         "json with override",
+			userInfo:     map[string]interface{}{"sub": "subvalue", "email": "userinfo@example.com", "email_verified": true, "groups": []string{"admins"}},
+			override:     true,
+			expectEmail:  "userinfo@example.com",
+			expectGroups: []string{"admins"},
+		},
+		{
+			name:         "signed jwt",
+			userInfo:     map[string]interface{}{"sub": "subvalue", "groups": []string{"admins"}},
+			signed:       true,
+			expectEmail:  "emailvalue",
+			expectGroups: []string{"admins"},
+		},
+		{
+			name:      "subject mismatch",
+			userInfo:  map[string]interface{}{"sub": "othervalue", "groups": []string{"admins"}},
+			expectErr: true,
+		},
+		{
+			// The test server answers 404 without userinfo claims.
+			name:        "error response",
+			expectEmail: "emailvalue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testServer, err := setupServerWithUserInfo(token, tc.userInfo, tc.signed)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:               testServer.URL,
+				ClientID:             "clientID",
+				ClientSecret:         "clientSecret",
+				Scopes:               []string{"email", "groups"},
+				RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+				GetUserInfo:          true,
+				InsecureEnableGroups: true,
+				OverrideClaimMapping: tc.override,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected userinfo of another subject to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.UserID, "subvalue")
+			expectEquals(t, identity.Email, tc.expectEmail)
+			expectEquals(t, identity.Groups, tc.expectGroups)
+		})
+	}
+}
+
+func TestUsernameTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		token          map[string]interface{}
+		expectUsername string
+	}{
+		{
+			name:     "given and family name",
+			template: "{{ lower .given_name }}.{{ lower .family_name }}",
+			token: map[string]interface{}{
+				"given_name":  "Bob",
+				"family_name": "Smith",
+			},
+			expectUsername: "bob.smith",
+		},
+		{
+			name:           "subject at issuer",
+			template:       "{{ .sub }}@{{ .iss }}",
+			expectUsername: "subvalue@$ISSUER",
+		},
+		{
+			name:     "missing claim",
+			template: "{{ lower .given_name }}.{{ lower .family_name }}",
+			token: map[string]interface{}{
+				"given_name": "Bob",
+			},
+			expectUsername: "namevalue",
+		},
+		{
+			name:           "empty username",
+			template:       "{{ .nickname }}",
+			token:          map[string]interface{}{"nickname": ""},
+			expectUsername: "namevalue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+			}
+			for k, v := range tc.token {
+				token[k] = v
+			}
+
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:           testServer.URL,
+				ClientID:         "clientID",
+				ClientSecret:     "clientSecret",
+				Scopes:           []string{"email"},
+				RedirectURI:      fmt.Sprintf("%s/callback", testServer.URL),
+				UsernameTemplate: tc.template,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+
+			expectEquals(t, identity.Username, strings.ReplaceAll(tc.expectUsername, "$ISSUER", testServer.URL))
+		})
+	}
+}
+
+func TestInvalidUsernameTemplate(t *testing.T) {
+	config := Config{UsernameTemplate: "{{ .given_name "}
+	if _, err := newConnector(config); err == nil {
+		t.Fatal("expected an error for an invalid username template")
+	}
+}
+
+func TestUserIDTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     string
+		token        map[string]interface{}
+		expectUserID string
+		expectErr    bool
+	}{
+		{
+			name:         "simple claim",
+			template:     "{{ .preferred_username }}",
+			token:        map[string]interface{}{"preferred_username": "jdoe"},
+			expectUserID: "jdoe",
+		},
+		{
+			name:         "preferred username at issuer",
+			template:     "{{ .preferred_username }}@{{ .iss }}",
+			token:        map[string]interface{}{"preferred_username": "jdoe"},
+			expectUserID: "jdoe@$ISSUER",
+		},
+		{
+			name:      "missing claim",
+			template:  "{{ .preferred_username }}@{{ .iss }}",
+			expectErr: true,
+		},
+		{
+			name:      "empty user ID",
+			template:  "{{ .preferred_username }}",
+			token:     map[string]interface{}{"preferred_username": ""},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+			}
+			for k, v := range tc.token {
+				token[k] = v
+			}
+
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:         testServer.URL,
+				ClientID:       "clientID",
+				ClientSecret:   "clientSecret",
+				Scopes:         []string{"email"},
+				RedirectURI:    fmt.Sprintf("%s/callback", testServer.URL),
+				UserIDTemplate: tc.template,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got user ID %q", identity.UserID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+
+			expectEquals(t, identity.UserID, strings.ReplaceAll(tc.expectUserID, "$ISSUER", testServer.URL))
+		})
+	}
+}
+
+func TestInvalidUserIDTemplate(t *testing.T) {
+	for _, config := range []Config{
+		{UserIDTemplate: "{{ .preferred_username "},
+		{UserIDTemplate: "{{ .preferred_username }}", UserIDKey: "preferred_username"},
+	} {
+		if _, err := newConnector(config); err == nil {
+			t.Fatalf("expected an error for userIDTemplate %q with userIDKey %q", config.UserIDTemplate, config.UserIDKey)
+		}
+	}
+}
+
+func TestClaimTransforms(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "Jane.Doe@Example.COM",
+		"email_verified": true,
+		"groups":         []string{"admins@corp.example.com", "ops@corp.example.com", "team:dev"},
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		Scopes:               []string{"email", "groups"},
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		InsecureEnableGroups: true,
+		ClaimTransforms: []ClaimTransform{
+			{Claim: "email", Operation: TransformLowercase},
+			{Claim: "groups", Operation: TransformTrimSuffix, Value: "@corp.example.com"},
+			{Claim: "groups", Operation: TransformTrimPrefix, Value: "team:"},
+			{Claim: "groups", Operation: TransformReplace, Value: "ops", Replacement: "operations"},
+			{Claim: "missing", Operation: TransformLowercase},
+		},
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+
+	identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	expectEquals(t, identity.Email, "jane.doe@example.com")
+	expectEquals(t, identity.Groups, []string{"admins", "operations", "dev"})
+}
+
+func TestClaimMutations(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":                "subvalue",
+		"name":               "CN=jdoe,OU=users,DC=example,DC=com",
+		"preferred_username": "jdoe",
+		"email":              "SMTP:jdoe@example.com",
+		"email_verified":     true,
+		"groups":             []string{"CN=admins,OU=groups", "ops"},
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:               testServer.URL,
+		ClientID:             "clientID",
+		ClientSecret:         "clientSecret",
+		Scopes:               []string{"email", "groups"},
+		RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+		InsecureEnableGroups: true,
+		ClaimMutations: []ClaimMutation{
+			{Key: "name", Regex: "^CN=([^,]+),.*$", Replacement: "$1"},
+			{Key: "preferred_username", Regex: "^CN=([^,]+),.*$", Replacement: "$1"},
+			{Key: "email", Regex: "^(?i)smtp:(.+)$", Replacement: "$1"},
+			{Key: "groups", Regex: "^CN=([^,]+),.*$", Replacement: "$1"},
+		},
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+
+	identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+	if err != nil {
+		t.Fatal("handle callback failed", err)
+	}
+	expectEquals(t, identity.Username, "jdoe")
+	expectEquals(t, identity.PreferredUsername, "jdoe")
+	expectEquals(t, identity.Email, "jdoe@example.com")
+	expectEquals(t, identity.Groups, []string{"admins", "ops"})
+}
+
+func TestInvalidClaimMutations(t *testing.T) {
+	tests := map[string]ClaimMutation{
+		"unknown key":   {Key: "sub", Regex: ".*"},
+		"no key":        {Regex: ".*"},
+		"no regex":      {Key: "email"},
+		"invalid regex": {Key: "email", Regex: "(unclosed"},
+	}
+	for name, mutation := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := Config{ClaimMutations: []ClaimMutation{mutation}}
+			_, err := newConnector(config)
+			if err == nil || !strings.Contains(err.Error(), "claimMutations") {
+				t.Fatalf("expected an error for an invalid claim mutation, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupsFilter(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"groups":         []string{"dex-admins", "dex-ops", "dex-legacy-ops", "staff"},
+	}
+
+	tests := []struct {
+		name         string
+		filter       string
+		denyFilter   string
+		expectGroups []string
+	}{
+		{
+			name:         "no filters",
+			expectGroups: []string{"dex-admins", "dex-ops", "dex-legacy-ops", "staff"},
+		},
+		{
+			name:         "allow filter",
+			filter:       "^dex-",
+			expectGroups: []string{"dex-admins", "dex-ops", "dex-legacy-ops"},
+		},
+		{
+			name:         "allow and deny filters",
+			filter:       "^dex-",
+			denyFilter:   "legacy",
+			expectGroups: []string{"dex-admins", "dex-ops"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:               testServer.URL,
+				ClientID:             "clientID",
+				ClientSecret:         "clientSecret",
+				Scopes:               []string{"email", "groups"},
+				RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+				InsecureEnableGroups: true,
+				GroupsFilter:         tc.filter,
+				GroupsDenyFilter:     tc.denyFilter,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{Groups: true}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			expectEquals(t, identity.Groups, tc.expectGroups)
+
+			identity.ConnectorData, err = json.Marshal(connectorData{RefreshToken: []byte("refresh-token")})
+			if err != nil {
+				t.Fatal("failed to marshal connector data", err)
+			}
+			identity, err = conn.Refresh(req.Context(), connector.Scopes{Groups: true}, identity)
+			if err != nil {
+				t.Fatal("refresh failed", err)
+			}
+			expectEquals(t, identity.Groups, tc.expectGroups)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	}
+	upstream, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer upstream.Close()
+
+	// While rotating, only the current refresh token is accepted and every
+	// refresh returns a new one, like a provider rotating refresh tokens. A
+	// rejected refresh token fails with invalid_grant.
+	var (
+		rotating  bool
+		current   string
+		rotations int
+	)
+	refreshTokens := make(chan string, 1)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" || r.FormValue("grant_type") != "refresh_token" {
+			upstream.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		refreshToken := r.FormValue("refresh_token")
+		refreshTokens <- refreshToken
+		if refreshToken == "rejected" || (rotating && refreshToken != current) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		rr := httptest.NewRecorder()
+		upstream.Config.Handler.ServeHTTP(rr, r)
+		var resp map[string]interface{}
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if rotating {
+			rotations++
+			current = fmt.Sprintf("rotated-%d", rotations)
+			resp["refresh_token"] = current
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		Scopes:       []string{"email"},
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	refresh := func(refreshToken string) (connector.Identity, error) {
+		connData, err := json.Marshal(connectorData{RefreshToken: []byte(refreshToken)})
+		if err != nil {
+			t.Fatal("failed to marshal connector data", err)
+		}
+		identity := connector.Identity{UserID: "subvalue", ConnectorData: connData}
+		identity, err = conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true}, identity)
+		expectEquals(t, <-refreshTokens, refreshToken)
+		return identity, err
+	}
+	storedRefreshToken := func(identity connector.Identity) string {
+		var cd connectorData
+		if err := json.Unmarshal(identity.ConnectorData, &cd); err != nil {
+			t.Fatal("failed to unmarshal connector data", err)
+		}
+		return string(cd.RefreshToken)
+	}
+
+	t.Run("not rotated", func(t *testing.T) {
+		identity, err := refresh("first")
+		if err != nil {
+			t.Fatal("refresh failed", err)
+		}
+		expectEquals(t, identity.Email, "emailvalue")
+		expectEquals(t, storedRefreshToken(identity), "first")
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		rotating, current, rotations = true, "first", 0
+		defer func() { rotating = false }()
+
+		identity, err := refresh("first")
+		if err != nil {
+			t.Fatal("refresh failed", err)
+		}
+		expectEquals(t, storedRefreshToken(identity), "rotated-1")
+
+		// Each refresh must send the token returned by the previous one.
+		for i := 2; i <= 3; i++ {
+			if identity, err = refresh(storedRefreshToken(identity)); err != nil {
+				t.Fatalf("refresh %d failed: %v", i, err)
+			}
+			expectEquals(t, storedRefreshToken(identity), fmt.Sprintf("rotated-%d", i))
+		}
+
+		if _, err := refresh("rotated-2"); !errors.Is(err, connector.ErrReauthenticate) {
+			t.Fatalf("expected a replaced refresh token to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("invalid grant", func(t *testing.T) {
+		_, err := refresh("rejected")
+		if !errors.Is(err, connector.ErrReauthenticate) {
+			t.Fatalf("expected %v, got %v", connector.ErrReauthenticate, err)
+		}
+	})
+}
+
+func TestInvalidGroupsFilter(t *testing.T) {
+	for _, config := range []Config{{GroupsFilter: "(dex-"}, {GroupsDenyFilter: "[legacy"}} {
+		_, err := newConnector(config)
+		if err == nil || !strings.Contains(err.Error(), "invalid groups") {
+			t.Fatalf("expected an error for an invalid groups filter, got %v", err)
+		}
+	}
+}
+
+func TestInvalidClaimTransforms(t *testing.T) {
+	tests := map[string]ClaimTransform{
+		"unknown operation": {Claim: "email", Operation: "uppercase"},
+		"no claim":          {Operation: TransformLowercase},
+		"no value":          {Claim: "groups", Operation: TransformTrimSuffix},
+	}
+	for name, transform := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := Config{ClaimTransforms: []ClaimTransform{transform}}
+			_, err := newConnector(config)
+			if err == nil || !strings.Contains(err.Error(), "claimTransforms") {
+				t.Fatalf("expected an error for an invalid claim transform, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAccountStatusRequiresAllowedValues(t *testing.T) {
+	var config Config
+	config.AccountStatus.Claim = "status"
+	if _, err := newConnector(config); err == nil {
+		t.Fatal("expected an error without allowed values")
+	}
+}
+
+func TestUpstreamAuthTime(t *testing.T) {
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	for name, token := range map[string]map[string]interface{}{
+		"with auth_time": {"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true, "auth_time": authTime.Unix()},
+		"no auth_time":   {"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			if _, ok := token["auth_time"]; ok {
+				expectEquals(t, identity.AuthTime.Unix(), authTime.Unix())
+			} else {
+				expectEquals(t, identity.AuthTime.IsZero(), true)
+			}
+		})
+	}
+}
+
+func TestCustomLoginURL(t *testing.T) {
+	token := map[string]interface{}{}
+
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	serverUrl := testServer.URL
+
+	minConfig := Config{
+		Issuer:      serverUrl,
+		ClientID:    "my_client_id",
+		RedirectURI: fmt.Sprintf("%s/callback", serverUrl),
+		AdditionalAuthRequestParams: map[string]string{
+			"organization": "myorg",
+			"connection":   "github",
+		},
+	}
+
+	conn, err := newConnector(minConfig)
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	// what we are testing, generation of LoginURL
+	loginUrl, err := conn.LoginURL(connector.Scopes{OfflineAccess: false, Groups: false}, minConfig.RedirectURI, "1234")
+	if err != nil {
+		t.Fatal("failed to get login url", err)
+	}
+
+	u, err := url.Parse(loginUrl)
+	if err != nil {
+		t.Fatal("failed to parse login url", err)
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		t.Fatal("failed to parse login url query params", err)
+	}
+
+	assertParamValue(t, values, "organization", "myorg")
+	assertParamValue(t, values, "connection", "github")
+	assertParamValue(t, values, "client_id", "my_client_id")
+	assert.True(t, strings.HasPrefix(values.Get("state"), "1234"+connector.StateSeparator))
+}
+
+func TestLoginURLWithHints(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{})
+	require.NoError(t, err)
+	defer testServer.Close()
+
+	config := Config{
+		Issuer:      testServer.URL,
+		ClientID:    "my_client_id",
+		RedirectURI: fmt.Sprintf("%s/callback", testServer.URL),
+		AdditionalAuthRequestParams: map[string]string{
+			"organization": "myorg",
+			"login_hint":   "configured@example.com",
+		},
+		EnableNonce: true,
+	}
+	conn, err := newConnector(config)
+	require.NoError(t, err)
+
+	loginURL, connData, err := conn.LoginURLWithHints(connector.Scopes{}, config.RedirectURI, "1234", map[string]string{
+		"login_hint": "jane@example.com",
+	})
+	require.NoError(t, err)
+	u, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	values := u.Query()
+	assertParamValue(t, values, "login_hint", "jane@example.com")
+	assertParamValue(t, values, "organization", "myorg")
+	assertParamValue(t, values, "client_id", "my_client_id")
+
+	// The hints are added to the URL of a login with login data.
+	var data loginData
+	require.NoError(t, json.Unmarshal(connData, &data))
+	assertParamValue(t, values, "nonce", data.Nonce)
+
+	for _, name := range []string{"client_id", "redirect_uri", "state", "nonce"} {
+		_, _, err := conn.LoginURLWithHints(connector.Scopes{}, config.RedirectURI, "1234", map[string]string{name: "evil"})
+		assert.ErrorContains(t, err, fmt.Sprintf("can't set the %q parameter", name))
+	}
+}
+
+func TestCustomLoginURLEmptyParams(t *testing.T) {
+	token := map[string]interface{}{}
+
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	serverUrl := testServer.URL
+
+	minConfig := Config{
+		Issuer:                      serverUrl,
+		ClientID:                    "my_client_id",
+		RedirectURI:                 fmt.Sprintf("%s/callback", serverUrl),
+		AdditionalAuthRequestParams: map[string]string{},
+	}
+
+	conn, err := newConnector(minConfig)
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	// what we are testing, generation of LoginURL
+	loginUrl, err := conn.LoginURL(connector.Scopes{OfflineAccess: false, Groups: false}, minConfig.RedirectURI, "1234")
+	if err != nil {
+		t.Fatal("failed to get login url", err)
+	}
+
+	u, err := url.Parse(loginUrl)
+	if err != nil {
+		t.Fatal("failed to parse login url", err)
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		t.Fatal("failed to parse login url query params", err)
+	}
+
+	assertParamValue(t, values, "client_id", "my_client_id")
+	assert.True(t, strings.HasPrefix(values.Get("state"), "1234"+connector.StateSeparator))
+}
+
+func TestLoginURLSelectAccount(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	tests := []struct {
+		name          string
+		forward       bool
+		scopes        connector.Scopes
+		expectPrompt  string
+		expectMissing bool
+	}{
+		{
+			name:         "forwarded",
+			forward:      true,
+			scopes:       connector.Scopes{SelectAccount: true},
+			expectPrompt: "select_account",
+		},
+		{
+			name:         "forwarded with offline access",
+			forward:      true,
+			scopes:       connector.Scopes{SelectAccount: true, OfflineAccess: true},
+			expectPrompt: "consent select_account",
+		},
+		{
+			name:          "not requested",
+			forward:       true,
+			scopes:        connector.Scopes{},
+			expectMissing: true,
+		},
+		{
+			name:          "not enabled",
+			forward:       false,
+			scopes:        connector.Scopes{SelectAccount: true},
+			expectMissing: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				Issuer:                     testServer.URL,
+				ClientID:                   "my_client_id",
+				RedirectURI:                fmt.Sprintf("%s/callback", testServer.URL),
+				ForwardSelectAccountPrompt: tc.forward,
+			}
+
+			conn, err := newConnector(config)
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			loginURL, err := conn.LoginURL(tc.scopes, config.RedirectURI, "1234")
+			if err != nil {
+				t.Fatal("failed to get login url", err)
+			}
+
+			u, err := url.Parse(loginURL)
+			if err != nil {
+				t.Fatal("failed to parse login url", err)
+			}
+
+			values := u.Query()
+			if tc.expectMissing {
+				assert.NotContains(t, values, "prompt")
+				return
+			}
+			assertParamValue(t, values, "prompt", tc.expectPrompt)
+		})
+	}
+}
+
+func TestOfflineAccessScope(t *testing.T) {
+	upstream, err := setupServer(map[string]interface{}{})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer upstream.Close()
+
+	var scopesSupported []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			upstream.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		url := fmt.Sprintf("http://%s", r.Host)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 url,
+			"token_endpoint":         url + "/token",
+			"authorization_endpoint": url + "/authorize",
+			"jwks_uri":               url + "/keys",
+			"scopes_supported":       scopesSupported,
+		})
+	}))
+	defer testServer.Close()
+
+	loginValues := func(t *testing.T, scopes []string, offlineAccess bool) url.Values {
+		config := Config{
+			Issuer:      testServer.URL,
+			ClientID:    "my_client_id",
+			RedirectURI: fmt.Sprintf("%s/callback", testServer.URL),
+			Scopes:      scopes,
+		}
+		conn, err := newConnector(config)
+		if err != nil {
+			t.Fatal("failed to create new connector", err)
+		}
+		loginURL, err := conn.LoginURL(connector.Scopes{OfflineAccess: offlineAccess}, config.RedirectURI, "1234")
+		if err != nil {
+			t.Fatal("failed to get login url", err)
+		}
+		u, err := url.Parse(loginURL)
+		if err != nil {
+			t.Fatal("failed to parse login url", err)
+		}
+		return u.Query()
+	}
+
+	scopesSupported = []string{"openid", "profile", "email", "offline_access"}
+
+	values := loginValues(t, nil, false)
+	assertParamValue(t, values, "scope", "openid profile email")
+	assert.NotContains(t, values, "access_type")
+
+	values = loginValues(t, nil, true)
+	assertParamValue(t, values, "scope", "openid profile email offline_access")
+	assertParamValue(t, values, "access_type", "offline")
+	assertParamValue(t, values, "prompt", "consent")
+
+	// The scope isn't requested twice when it's always requested.
+	values = loginValues(t, []string{"profile", "offline_access"}, true)
+	assertParamValue(t, values, "scope", "openid profile offline_access")
+
+	// Providers without support for the scope only get access_type=offline.
+	scopesSupported = []string{"openid", "profile", "email"}
+	values = loginValues(t, nil, true)
+	assertParamValue(t, values, "scope", "openid profile email")
+	assertParamValue(t, values, "access_type", "offline")
+}
+
+func TestUpstreamScopes(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{})
+	require.NoError(t, err)
+	defer testServer.Close()
+
+	config := Config{
+		Issuer:        testServer.URL,
+		ClientID:      "clientID",
+		RedirectURI:   fmt.Sprintf("%s/callback", testServer.URL),
+		Scopes:        []string{"profile", "email"},
+		AllowedScopes: []string{"groups", "email", "offline_access"},
+	}
+	conn, err := newConnector(config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		upstreamScopes []string
+		wantScope      string
+	}{
+		{
+			name:      "no upstream scopes",
+			wantScope: "openid profile email",
+		},
+		{
+			name:           "allowed scope",
+			upstreamScopes: []string{"groups"},
+			wantScope:      "openid profile email groups",
+		},
+		{
+			name:           "duplicates and scopes which aren't allowed",
+			upstreamScopes: []string{"groups", "email", "groups", "admin", "openid"},
+			wantScope:      "openid profile email groups",
+		},
+		{
+			name:           "only scopes which aren't allowed",
+			upstreamScopes: []string{"admin"},
+			wantScope:      "openid profile email",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loginURL, err := conn.LoginURL(connector.Scopes{UpstreamScopes: tc.upstreamScopes}, config.RedirectURI, "1234")
+			require.NoError(t, err)
+			u, err := url.Parse(loginURL)
+			require.NoError(t, err)
+			assertParamValue(t, u.Query(), "scope", tc.wantScope)
+		})
+	}
+
+	// Without allowed scopes, clients can't add any.
+	config.AllowedScopes = nil
+	conn, err = newConnector(config)
+	require.NoError(t, err)
+	loginURL, err := conn.LoginURL(connector.Scopes{UpstreamScopes: []string{"groups"}}, config.RedirectURI, "1234")
+	require.NoError(t, err)
+	u, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	assertParamValue(t, u.Query(), "scope", "openid profile email")
+}
+
+func TestPromptNone(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{})
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	loginPrompt := func(t *testing.T, forward bool, scopes connector.Scopes) url.Values {
+		config := Config{
+			Issuer:            testServer.URL,
+			ClientID:          "my_client_id",
+			RedirectURI:       fmt.Sprintf("%s/callback", testServer.URL),
+			ForwardPromptNone: forward,
+		}
+		conn, err := newConnector(config)
+		if err != nil {
+			t.Fatal("failed to create new connector", err)
+		}
+		loginURL, err := conn.LoginURL(scopes, config.RedirectURI, "1234")
+		if err != nil {
+			t.Fatal("failed to get login url", err)
+		}
+		u, err := url.Parse(loginURL)
+		if err != nil {
+			t.Fatal("failed to parse login url", err)
+		}
+		return u.Query()
+	}
+
+	t.Run("forwarded", func(t *testing.T) {
+		assertParamValue(t, loginPrompt(t, true, connector.Scopes{PromptNone: true}), "prompt", "none")
+	})
+	t.Run("forwarded with offline access", func(t *testing.T) {
+		values := loginPrompt(t, true, connector.Scopes{PromptNone: true, OfflineAccess: true, SelectAccount: true})
+		assertParamValue(t, values, "prompt", "none")
+	})
+	t.Run("not enabled", func(t *testing.T) {
+		assert.NotContains(t, loginPrompt(t, false, connector.Scopes{PromptNone: true}), "prompt")
+	})
+	t.Run("additional prompt param", func(t *testing.T) {
+		config := Config{
+			Issuer:                      testServer.URL,
+			ClientID:                    "my_client_id",
+			RedirectURI:                 fmt.Sprintf("%s/callback", testServer.URL),
+			ForwardPromptNone:           true,
+			AdditionalAuthRequestParams: map[string]string{"prompt": "login", "hd": "example.com"},
+		}
+		conn, err := newConnector(config)
+		if err != nil {
+			t.Fatal("failed to create new connector", err)
+		}
+		for _, tc := range []struct {
+			scopes       connector.Scopes
+			expectPrompt string
+		}{
+			{scopes: connector.Scopes{PromptNone: true}, expectPrompt: "none"},
+			{scopes: connector.Scopes{}, expectPrompt: "login"},
+		} {
+			loginURL, err := conn.LoginURL(tc.scopes, config.RedirectURI, "1234")
+			if err != nil {
+				t.Fatal("failed to get login url", err)
+			}
+			u, err := url.Parse(loginURL)
+			if err != nil {
+				t.Fatal("failed to parse login url", err)
+			}
+			assertParamValue(t, u.Query(), "prompt", tc.expectPrompt)
+			assertParamValue(t, u.Query(), "hd", "example.com")
+		}
+	})
+
+	conn, err := newConnector(Config{
+		Issuer:            testServer.URL,
+		ClientID:          "clientID",
+		ClientSecret:      "clientSecret",
+		RedirectURI:       fmt.Sprintf("%s/callback", testServer.URL),
+		ForwardPromptNone: true,
+	})
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+	for errType, interactionRequired := range map[string]bool{
+		"login_required":       true,
+		"interaction_required": true,
+		"access_denied":        false,
+	} {
+		t.Run(errType, func(t *testing.T) {
+			req, err := http.NewRequest("GET", testServer.URL+"/callback?error="+errType+"&error_description=silent+login+failed", nil)
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(connector.Scopes{PromptNone: true}, req)
+			var interactionErr *connector.InteractionRequiredError
+			if !interactionRequired {
+				assert.Error(t, err)
+				assert.False(t, errors.As(err, &interactionErr), "unexpected interaction required error %v", err)
+				return
+			}
+			if !errors.As(err, &interactionErr) {
+				t.Fatalf("expected an interaction required error, got %v", err)
+			}
+			expectEquals(t, interactionErr.Code, errType)
+			expectEquals(t, interactionErr.Description, "silent login failed")
+		})
+	}
+}
+
+func TestPKCE(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	upstream, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer upstream.Close()
+
+	verifiers := make(chan string, 1)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			r.ParseForm()
+			verifiers <- r.PostForm.Get("code_verifier")
+		}
+		upstream.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer testServer.Close()
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			config := Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+				EnablePKCE:   enabled,
+			}
+			conn, err := newConnector(config)
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			loginURL, connData, err := conn.LoginURLWithData(connector.Scopes{}, config.RedirectURI, "state")
+			if err != nil {
+				t.Fatal("failed to get login url", err)
+			}
+			u, err := url.Parse(loginURL)
+			if err != nil {
+				t.Fatal("failed to parse login url", err)
+			}
+			token["nonce"] = u.Query().Get("nonce")
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			if _, err := conn.HandleCallbackWithData(connector.Scopes{}, connData, req); err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			verifier := <-verifiers
+
+			values := u.Query()
+			if !enabled {
+				assert.NotContains(t, values, "code_challenge")
+				assert.NotContains(t, values, "code_challenge_method")
+				assert.Empty(t, verifier)
+				return
+			}
+
+			challenge := sha256.Sum256([]byte(verifier))
+			assertParamValue(t, values, "code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+			assertParamValue(t, values, "code_challenge_method", "S256")
+
+			_, err = conn.LoginURL(connector.Scopes{}, config.RedirectURI, "state")
+			assert.Error(t, err, "LoginURL can't keep the code verifier")
+			_, err = conn.HandleCallbackWithData(connector.Scopes{}, nil, req)
+			assert.Error(t, err, "callback without the code verifier must fail")
+		})
+	}
+}
+
+func TestRequestedAuthentication(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"acr":            "urn:example:mfa",
+		"auth_time":      time.Now().Add(-time.Minute).Unix(),
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	config := Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+		AcrValues:    []string{"urn:example:pwd"},
+	}
+	conn, err := newConnector(config)
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	maxAge := func(seconds int) *int { return &seconds }
+	tests := []struct {
+		name          string
+		scopes        connector.Scopes
+		wantACRValues string
+		wantMaxAge    string
+		wantErr       bool
+	}{
+		{
+			name:          "configured acr values",
+			wantACRValues: "urn:example:pwd",
+		},
+		{
+			name:          "requested acr values and max age",
+			scopes:        connector.Scopes{ACRValues: []string{"urn:example:hw", "urn:example:mfa"}, MaxAge: maxAge(300)},
+			wantACRValues: "urn:example:hw urn:example:mfa",
+			wantMaxAge:    "300",
+		},
+		{
+			name:          "unsatisfied acr values",
+			scopes:        connector.Scopes{ACRValues: []string{"urn:example:hw"}},
+			wantACRValues: "urn:example:hw",
+			wantErr:       true,
+		},
+		{
+			name:          "expired max age",
+			scopes:        connector.Scopes{MaxAge: maxAge(30)},
+			wantACRValues: "urn:example:pwd",
+			wantMaxAge:    "30",
+			wantErr:       true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loginURL, err := conn.LoginURL(tc.scopes, config.RedirectURI, "state")
+			if err != nil {
+				t.Fatal("failed to get login url", err)
+			}
+			u, err := url.Parse(loginURL)
+			if err != nil {
+				t.Fatal("failed to parse login url", err)
+			}
+			assert.Equal(t, tc.wantACRValues, u.Query().Get("acr_values"))
+			assert.Equal(t, tc.wantMaxAge, u.Query().Get("max_age"))
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(tc.scopes, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStepUpACR(t *testing.T) {
+	token := map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+		"acr":            "urn:example:mfa",
+	}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	disabled := false
+	ranking := []string{"urn:example:pwd", "urn:example:mfa", "urn:example:hw"}
+	tests := []struct {
+		name      string
+		enforce   *bool
+		ranking   []string
+		requested string
+		wantErr   bool
+	}{
+		{name: "matching acr", requested: "urn:example:mfa"},
+		{name: "weaker acr", requested: "urn:example:hw", wantErr: true},
+		{name: "other acr without ranking", requested: "urn:example:pwd", wantErr: true},
+		{name: "stronger acr", ranking: ranking, requested: "urn:example:pwd"},
+		{name: "weaker acr with ranking", ranking: ranking, requested: "urn:example:hw", wantErr: true},
+		{name: "not enforced", enforce: &disabled, requested: "urn:example:hw"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := newConnector(Config{
+				Issuer:              testServer.URL,
+				ClientID:            "clientID",
+				ClientSecret:        "clientSecret",
+				RedirectURI:         fmt.Sprintf("%s/callback", testServer.URL),
+				EnforceRequestedACR: tc.enforce,
+				ACRRanking:          tc.ranking,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(connector.Scopes{ACRValues: []string{tc.requested}}, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequireACR(t *testing.T) {
+	tests := []struct {
+		name      string
+		acr       string
+		requested []string
+		wantErr   string
+	}{
+		{name: "required acr", acr: "urn:example:mfa"},
+		{name: "missing acr", wantErr: `missing "acr" claim`},
+		{name: "other acr", acr: "urn:example:pwd", wantErr: "is not one of the required acr values"},
+		{name: "required but not requested acr", acr: "urn:example:mfa", requested: []string{"urn:example:hw"}, wantErr: "does not satisfy the requested acr values"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{
+				"sub":            "subvalue",
+				"name":           "namevalue",
+				"email":          "emailvalue",
+				"email_verified": true,
+			}
+			if tc.acr != "" {
+				token["acr"] = tc.acr
+			}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+				AcrValues:    []string{"urn:example:mfa"},
+				RequireACR:   []string{"urn:example:mfa", "urn:example:hw"},
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(connector.Scopes{ACRValues: tc.requested}, req)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNonce(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	config := Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+		EnableNonce:  true,
+	}
+	conn, err := newConnector(config)
+	if err != nil {
+		t.Fatal("failed to create new connector", err)
+	}
+
+	loginURL, connData, err := conn.LoginURLWithData(connector.Scopes{}, config.RedirectURI, "state")
+	if err != nil {
+		t.Fatal("failed to get login url", err)
+	}
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal("failed to parse login url", err)
+	}
+	nonce := u.Query().Get("nonce")
+	assert.NotEmpty(t, nonce)
+
+	tests := []struct {
+		name    string
+		nonce   interface{}
+		wantErr bool
+	}{
+		{name: "matching nonce", nonce: nonce},
+		{name: "mismatched nonce", nonce: "othernonce", wantErr: true},
+		{name: "missing nonce", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.nonce == nil {
+				delete(token, "nonce")
+			} else {
+				token["nonce"] = tc.nonce
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallbackWithData(connector.Scopes{}, connData, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnableNonce(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	tests := []struct {
+		name        string
+		enableNonce bool
+		// nonce returns the nonce claim of the ID token, from the nonce of
+		// the login URL.
+		nonce    func(nonce string) string
+		dropData bool
+		wantErr  bool
+	}{
+		{name: "matching nonce", enableNonce: true, nonce: func(nonce string) string { return nonce }},
+		{name: "mismatched nonce", enableNonce: true, nonce: func(string) string { return "othernonce" }, wantErr: true},
+		{name: "missing nonce", enableNonce: true, nonce: func(string) string { return "" }, wantErr: true},
+		{name: "no login data", enableNonce: true, nonce: func(nonce string) string { return nonce }, dropData: true, wantErr: true},
+		{name: "disabled", nonce: func(string) string { return "othernonce" }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+				EnableNonce:  tc.enableNonce,
+			}
+			conn, err := newConnector(config)
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			// The server keeps the login data with the auth request until the
+			// callback.
+			loginURL, connData, err := conn.LoginURLWithData(connector.Scopes{}, config.RedirectURI, "state")
+			if err != nil {
+				t.Fatal("failed to get login url", err)
+			}
+			u, err := url.Parse(loginURL)
+			if err != nil {
+				t.Fatal("failed to parse login url", err)
+			}
+			nonce := u.Query().Get("nonce")
+			if tc.enableNonce {
+				var data loginData
+				require.NoError(t, json.Unmarshal(connData, &data))
+				assert.NotEmpty(t, nonce)
+				assert.Equal(t, data.Nonce, nonce)
+			} else {
+				assert.NotContains(t, u.Query(), "nonce")
+			}
+			if tc.dropData {
+				connData = nil
+			}
+
+			if claim := tc.nonce(nonce); claim == "" {
+				delete(token, "nonce")
+			} else {
+				token["nonce"] = claim
+			}
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallbackWithData(connector.Scopes{}, connData, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// Without login data to keep the nonce in, logins with nonces fail.
+	conn, err := newConnector(Config{
+		Issuer:      testServer.URL,
+		ClientID:    "clientID",
+		RedirectURI: fmt.Sprintf("%s/callback", testServer.URL),
+		EnableNonce: true,
+	})
+	require.NoError(t, err)
+	_, err = conn.LoginURL(connector.Scopes{}, conn.redirectURI, "state")
+	assert.Error(t, err)
+}
+
+func TestValidateAZP(t *testing.T) {
+	tests := []struct {
+		name        string
+		azp         interface{}
+		validateAZP bool
+		wantErr     bool
+	}{
+		{name: "no azp", validateAZP: true},
+		{name: "matching azp", azp: "clientID", validateAZP: true},
+		{name: "mismatched azp", azp: "otherClient", validateAZP: true, wantErr: true},
+		{name: "mismatched azp not validated", azp: "otherClient"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+			if tc.azp != nil {
+				token["azp"] = tc.azp
+			}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+				ValidateAZP:  tc.validateAZP,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(connector.Scopes{}, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRequireEmailVerified(t *testing.T) {
+	tests := []struct {
+		name                 string
+		emailVerified        interface{}
+		requireEmailVerified bool
+		wantErr              bool
+		wantVerified         bool
+	}{
+		{name: "verified", emailVerified: true, requireEmailVerified: true, wantVerified: true},
+		{name: "not verified", emailVerified: false, requireEmailVerified: true, wantErr: true},
+		{name: "missing", requireEmailVerified: true, wantErr: true},
+		{name: "not verified and not required", emailVerified: false},
+		{name: "missing and not required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue"}
+			if tc.emailVerified != nil {
+				token["email_verified"] = tc.emailVerified
+			}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			// Without the email scope, the claim isn't required otherwise.
+			conn, err := newConnector(Config{
+				Issuer:               testServer.URL,
+				ClientID:             "clientID",
+				ClientSecret:         "clientSecret",
+				Scopes:               []string{"profile"},
+				RedirectURI:          fmt.Sprintf("%s/callback", testServer.URL),
+				RequireEmailVerified: tc.requireEmailVerified,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantVerified, identity.EmailVerified)
+		})
+	}
+
+	_, err := newConnector(Config{Issuer: "https://idp.example.com", RequireEmailVerified: true, InsecureSkipEmailVerified: true})
+	assert.ErrorContains(t, err, "requireEmailVerified")
+}
+
+func TestClockSkew(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    map[string]interface{}
+		clockSkew string
+		wantErr   bool
+	}{
+		{name: "issued in the future", claims: map[string]interface{}{"iat": time.Now().Add(10 * time.Second).Unix()}},
+		{name: "issued in the future without skew", claims: map[string]interface{}{"iat": time.Now().Add(10 * time.Second).Unix()}, clockSkew: "0s", wantErr: true},
+		{name: "issued beyond the skew", claims: map[string]interface{}{"iat": time.Now().Add(2 * time.Minute).Unix()}, wantErr: true},
+		{name: "not valid yet", claims: map[string]interface{}{"nbf": time.Now().Add(10 * time.Second).Unix()}, clockSkew: "30s"},
+		{name: "not valid yet without skew", claims: map[string]interface{}{"nbf": time.Now().Add(10 * time.Second).Unix()}, clockSkew: "0s", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+			for k, v := range tc.claims {
+				token[k] = v
+			}
+			testServer, err := setupServer(token)
+			if err != nil {
+				t.Fatal("failed to setup test server", err)
+			}
+			defer testServer.Close()
+
+			conn, err := newConnector(Config{
+				Issuer:       testServer.URL,
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+				ClockSkew:    tc.clockSkew,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			_, err = conn.HandleCallback(connector.Scopes{}, req)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// The test server always issues tokens expiring in an hour.
+	now := time.Now()
+	expired := &oidc.IDToken{Expiry: now.Add(-10 * time.Second)}
+	assert.NoError(t, (&oidcConnector{clockSkew: time.Minute}).checkTokenTimes(expired, nil, now))
+	assert.Error(t, (&oidcConnector{}).checkTokenTimes(expired, nil, now))
+
+	for _, clockSkew := range []string{"-1s", "10m", "soon"} {
+		_, err := newConnector(Config{Issuer: "https://idp.example.com", ClockSkew: clockSkew})
+		assert.ErrorContains(t, err, "invalid clockSkew")
+	}
+}
+
+func TestUpstreamExpiryClaim(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	for _, claim := range []string{"", "upstream_exp"} {
+		t.Run(fmt.Sprintf("claim=%q", claim), func(t *testing.T) {
+			conn, err := newConnector(Config{
+				Issuer:              testServer.URL,
+				ClientID:            "clientID",
+				ClientSecret:        "clientSecret",
+				RedirectURI:         fmt.Sprintf("%s/callback", testServer.URL),
+				UpstreamExpiryClaim: claim,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+
+			if claim == "" {
+				assert.Nil(t, identity.CustomClaims)
+				return
+			}
+			// The test server sets the exp of the tokens it issues.
+			assert.Equal(t, map[string]interface{}{claim: token["exp"]}, identity.CustomClaims)
+		})
+	}
+}
+
+func TestCanonicalizeEmail(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "User@Example.com", "email_verified": true}
+	testServer, err := setupServer(token)
+	if err != nil {
+		t.Fatal("failed to setup test server", err)
+	}
+	defer testServer.Close()
+
+	tests := []struct {
+		canonicalize bool
+		expectEmail  string
+	}{
+		{canonicalize: false, expectEmail: "User@Example.com"},
+		{canonicalize: true, expectEmail: "user@example.com"},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("canonicalize=%t", tc.canonicalize), func(t *testing.T) {
+			conn, err := newConnector(Config{
+				Issuer:            testServer.URL,
+				ClientID:          "clientID",
+				ClientSecret:      "clientSecret",
+				RedirectURI:       fmt.Sprintf("%s/callback", testServer.URL),
+				CanonicalizeEmail: tc.canonicalize,
+			})
+			if err != nil {
+				t.Fatal("failed to create new connector", err)
+			}
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			if err != nil {
+				t.Fatal("failed to create request", err)
+			}
+			identity, err := conn.HandleCallback(connector.Scopes{}, req)
+			if err != nil {
+				t.Fatal("handle callback failed", err)
+			}
+			assert.Equal(t, tc.expectEmail, identity.Email)
+		})
+	}
+}
+
+func assertParamValue(t *testing.T, values url.Values, queryParam string, expectedValue string) {
+	assert.NotNil(t, values[queryParam])
+	assert.Equal(t, expectedValue, values[queryParam][0])
+}
+
+func setupServer(tok map[string]interface{}) (*httptest.Server, error) {
+	return setupServerWithUserInfo(tok, nil, false)
+}
+
+// setupServerWithUserInfo also serves the userinfo claims, as a signed JWT if
+// signUserInfo is set.
+func setupServerWithUserInfo(tok, userInfo map[string]interface{}, signUserInfo bool) (*httptest.Server, error) {
+	return setupTestServer(tok, userInfo, signUserInfo, nil)
+}
+
+// setupTestServer passes the signed ID tokens through wrapIDToken, if set,
+// before returning them.
+func setupTestServer(tok, userInfo map[string]interface{}, signUserInfo bool, wrapIDToken func(string) (string, error)) (*httptest.Server, error) {
+	handler, err := newTestProviderHandler(tok, userInfo, signUserInfo, wrapIDToken)
+	if err != nil {
+		return nil, err
+	}
+	return httptest.NewServer(handler), nil
+}
+
+// newTestProviderHandler returns the handler of a test provider issuing the
+// claims of tok in its tokens, served over HTTP or HTTPS.
+func newTestProviderHandler(tok, userInfo map[string]interface{}, signUserInfo bool, wrapIDToken func(string) (string, error)) (http.Handler, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate rsa key: %v", err)
+	}
+
+	jwk := jose.JSONWebKey{
+		Key:       key,
+		KeyID:     "keyId",
+		Algorithm: "RSA",
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&map[string]interface{}{
+			"keys": []map[string]interface{}{{
+				"alg": jwk.Algorithm,
+				"kty": jwk.Algorithm,
+				"kid": jwk.KeyID,
+				"n":   n(&key.PublicKey),
+				"e":   e(&key.PublicKey),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		url := testServerURL(r)
+		tok["iss"] = url
+		tok["exp"] = time.Now().Add(time.Hour).Unix()
+		if _, ok := tok["aud"]; !ok {
+			tok["aud"] = "clientID"
+		}
+		token, err := newToken(&jwk, tok)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		idToken := token
+		if wrapIDToken != nil {
+			if idToken, err = wrapIDToken(token); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&map[string]string{
+			"access_token": token,
+			"id_token":     idToken,
+			"token_type":   "Bearer",
+		})
+	})
+
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if userInfo == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if !signUserInfo {
+			w.Header().Add("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(userInfo)
+			return
+		}
+		token, err := newToken(&jwk, userInfo)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("Content-Type", "application/jwt")
+		w.Write([]byte(token))
+	})
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		url := testServerURL(r)
+
+		json.NewEncoder(w).Encode(&map[string]string{
+			"issuer":                 url,
+			"token_endpoint":         fmt.Sprintf("%s/token", url),
+			"authorization_endpoint": fmt.Sprintf("%s/authorize", url),
+			"userinfo_endpoint":      fmt.Sprintf("%s/userinfo", url),
+			"jwks_uri":               fmt.Sprintf("%s/keys", url),
+		})
+	})
+
+	return mux, nil
+}
+
+func testServerURL(r *http.Request) string {
+	if r.TLS != nil {
+		return "https://" + r.Host
+	}
+	return "http://" + r.Host
+}
+
+func newToken(key *jose.JSONWebKey, claims map[string]interface{}) (string, error) {
+	signingKey := jose.SigningKey{
+		Key:       key,
+		Algorithm: jose.RS256,
+	}
+
+	signer, err := jose.NewSigner(signingKey, &jose.SignerOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create new signer: %v", err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal claims: %v", err)
+	}
+
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign: %v", err)
+	}
+	return signature.CompactSerialize()
+}
+
+func newConnector(config Config) (*oidcConnector, error) {
 	logger := logrus.New()
 	conn, err := config.Open("id", logger)
 	if err != nil {
@@ -560,3 +2775,81 @@ func expectEquals(t *testing.T, a interface{}, b interface{}) {
 		t.Errorf("Expected %+v to equal %+v", a, b)
 	}
 }
+
+func TestForwardUpstreamTokens(t *testing.T) {
+	token := map[string]interface{}{"sub": "subvalue", "name": "namevalue", "email": "emailvalue", "email_verified": true}
+	upstream, err := setupServer(token)
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	// Adds a refresh token to the token responses, and records the access
+	// token and ID token returned last.
+	var accessToken, idToken string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" {
+			upstream.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		rr := httptest.NewRecorder()
+		upstream.Config.Handler.ServeHTTP(rr, r)
+		var resp map[string]interface{}
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		resp["refresh_token"] = "upstream-refresh-token"
+		accessToken, idToken = resp["access_token"].(string), resp["id_token"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer testServer.Close()
+
+	for _, forward := range []bool{false, true} {
+		t.Run(fmt.Sprintf("forward=%t", forward), func(t *testing.T) {
+			token["name"] = "namevalue"
+			conn, err := newConnector(Config{
+				Issuer:                testServer.URL,
+				ClientID:              "clientID",
+				ClientSecret:          "clientSecret",
+				RedirectURI:           fmt.Sprintf("%s/callback", testServer.URL),
+				ForwardUpstreamTokens: forward,
+			})
+			require.NoError(t, err)
+
+			req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+			require.NoError(t, err)
+			identity, err := conn.HandleCallback(connector.Scopes{OfflineAccess: true}, req)
+			require.NoError(t, err)
+
+			gotAccessToken, gotIDToken, err := UpstreamTokens(identity.ConnectorData)
+			require.NoError(t, err)
+			if !forward {
+				assert.Empty(t, gotAccessToken)
+				assert.Empty(t, gotIDToken)
+				return
+			}
+			assert.Equal(t, accessToken, gotAccessToken)
+			assert.Equal(t, idToken, gotIDToken)
+
+			// Refreshing replaces the tokens with the new ones, and keeps the
+			// refresh token.
+			token["name"] = "refreshed"
+			loginAccessToken := accessToken
+			identity, err = conn.Refresh(context.Background(), connector.Scopes{OfflineAccess: true}, identity)
+			require.NoError(t, err)
+			assert.Equal(t, "refreshed", identity.Username)
+
+			gotAccessToken, gotIDToken, err = UpstreamTokens(identity.ConnectorData)
+			require.NoError(t, err)
+			assert.NotEqual(t, loginAccessToken, gotAccessToken)
+			assert.Equal(t, accessToken, gotAccessToken)
+			assert.Equal(t, idToken, gotIDToken)
+
+			var cd connectorData
+			require.NoError(t, json.Unmarshal(identity.ConnectorData, &cd))
+			assert.Equal(t, "upstream-refresh-token", string(cd.RefreshToken))
+			assert.NotContains(t, fmt.Sprint(cd), gotAccessToken, "tokens must be redacted")
+			assert.NotContains(t, fmt.Sprintf("%+v", cd), "upstream-refresh-token", "tokens must be redacted")
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/log"
+)
+
+const (
+	// maxKeySetSize limits the size of the key sets fetched from the provider.
+	maxKeySetSize = 1 << 20
+
+	// defaultKeysGracePeriod is how long the keys are still used once they
+	// can't be refreshed, unless keysGracePeriod is set.
+	defaultKeysGracePeriod = time.Hour
+
+	// minKeysRefreshInterval limits how often tokens signed with unknown keys
+	// cause the keys to be fetched.
+	minKeysRefreshInterval = 10 * time.Second
+
+	// keysRefreshJitter is the fraction of the refresh interval the keys are
+	// refreshed earlier by, at random, so the replicas of dex don't all fetch
+	// the keys of the provider at once.
+	keysRefreshJitter = 0.1
+)
+
+// remoteKeySet verifies ID tokens with the keys published at the jwks_uri of
+// the provider. The keys are fetched again when a token isn't signed by one of
+// them, at most once every minRefreshInterval, so tokens signed with unknown
+// keys don't hammer the provider.
+//
+// The keys can be cached in the storage, in which case the cached keys are
+// used at startup, so tokens can be verified before the provider is
+// reachable. They can also be refreshed in the background, in which case they
+// expire once they couldn't be refreshed for the grace period.
+type remoteKeySet struct {
+	keys        *staticKeySet
+	jwksURL     string
+	client      *http.Client
+	connectorID string
+	logger      log.Logger
+
+	// cache is nil unless the key set is cached.
+	cache connector.KeySetCache
+
+	// refreshInterval is zero unless the keys are refreshed in the
+	// background.
+	refreshInterval    time.Duration
+	gracePeriod        time.Duration
+	minRefreshInterval time.Duration
+
+	now func() time.Time
+
+	// fetchMu serializes the requests for the key set, and guards lastFetch.
+	fetchMu   sync.Mutex
+	lastFetch time.Time
+
+	mu sync.RWMutex
+	// updatedAt is the time the keys were last fetched or loaded from the
+	// cache.
+	updatedAt time.Time
+}
+
+type remoteKeySetConfig struct {
+	connectorID     string
+	jwksURL         string
+	client          *http.Client
+	cache           connector.KeySetCache
+	refreshInterval time.Duration
+	gracePeriod     time.Duration
+	logger          log.Logger
+}
+
+// newRemoteKeySet loads the cached key set of the connector, if it's cached,
+// and fetches the current one in the background. The keys are refreshed
+// until the context is canceled.
+func newRemoteKeySet(ctx context.Context, c remoteKeySetConfig) *remoteKeySet {
+	s := &remoteKeySet{
+		keys:               &staticKeySet{},
+		jwksURL:            c.jwksURL,
+		client:             c.client,
+		connectorID:        c.connectorID,
+		logger:             c.logger,
+		cache:              c.cache,
+		refreshInterval:    c.refreshInterval,
+		gracePeriod:        c.gracePeriod,
+		minRefreshInterval: minKeysRefreshInterval,
+		now:                time.Now,
+	}
+	s.load()
+	go s.refreshLoop(ctx)
+	return s
+}
+
+// load sets the keys to the cached key set, if there's one.
+func (s *remoteKeySet) load() {
+	if s.cache == nil {
+		return
+	}
+	data, err := s.cache.GetKeySet(s.connectorID)
+	if err == nil && data != nil {
+		var keys []jose.JSONWebKey
+		if keys, err = parseKeySet(data); err == nil {
+			s.setKeys(keys)
+		}
+	}
+	if err != nil {
+		s.logger.Errorf("oidc: failed to load the cached key set of connector %q: %v", s.connectorID, err)
+	}
+}
+
+// refreshLoop fetches the keys, and then fetches them about every refresh
+// interval, until the context is canceled. Keys which can't be fetched are
+// logged and the previous keys are kept.
+func (s *remoteKeySet) refreshLoop(ctx context.Context) {
+	for {
+		s.fetchMu.Lock()
+		err := s.fetch(ctx)
+		s.fetchMu.Unlock()
+		if err != nil && ctx.Err() == nil {
+			s.logger.Errorf("oidc: failed to fetch the key set of connector %q, keeping the previous keys: %v", s.connectorID, err)
+		}
+		if s.refreshInterval == 0 {
+			return
+		}
+
+		timer := time.NewTimer(s.refreshDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// refreshDelay returns the time until the next background refresh: the
+// refresh interval, shortened by up to keysRefreshJitter of it at random.
+func (s *remoteKeySet) refreshDelay() time.Duration {
+	return s.refreshInterval - time.Duration(rand.Float64()*keysRefreshJitter*float64(s.refreshInterval))
+}
+
+func (s *remoteKeySet) VerifySignature(ctx context.Context, jwt string) ([]byte, error) {
+	if !s.expired() {
+		if payload, err := s.keys.VerifySignature(ctx, jwt); err == nil {
+			return payload, nil
+		}
+	}
+
+	// The provider may have rotated its keys.
+	if err := s.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("oidc: failed to fetch keys: %v", err)
+	}
+	if s.expired() {
+		return nil, errors.New("oidc: the keys of the provider couldn't be refreshed for longer than the grace period")
+	}
+	return s.keys.VerifySignature(ctx, jwt)
+}
+
+// refresh fetches the keys, unless they were fetched less than the minimum
+// refresh interval ago, for example by a concurrent verification.
+func (s *remoteKeySet) refresh(ctx context.Context) error {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+	if !s.lastFetch.IsZero() && s.now().Sub(s.lastFetch) < s.minRefreshInterval {
+		return nil
+	}
+	return s.fetch(ctx)
+}
+
+// expired reports whether the keys couldn't be refreshed for longer than the
+// grace period. Keys which aren't refreshed in the background never expire.
+func (s *remoteKeySet) expired() bool {
+	if s.refreshInterval == 0 {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.now().After(s.updatedAt.Add(s.refreshInterval + s.gracePeriod))
+}
+
+func (s *remoteKeySet) setKeys(keys []jose.JSONWebKey) {
+	s.keys.setKeys(keys)
+	s.mu.Lock()
+	s.updatedAt = s.now()
+	s.mu.Unlock()
+}
+
+// fetch requests the key set from the provider, and caches it. It must be
+// called with fetchMu held.
+func (s *remoteKeySet) fetch(ctx context.Context) error {
+	s.lastFetch = s.now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxKeySetSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+	keys, err := parseKeySet(body)
+	if err != nil {
+		return err
+	}
+	s.setKeys(keys)
+
+	if s.cache != nil {
+		if err := s.cache.SetKeySet(s.connectorID, body); err != nil {
+			s.logger.Errorf("oidc: failed to cache the key set of connector %q: %v", s.connectorID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// keysServer serves a key set which can be swapped, and counts the requests.
+type keysServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	keySet   []byte
+	fail     bool
+	requests int32
+}
+
+func newKeysServer() *keysServer {
+	s := &keysServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.keySet)
+	}))
+	return s
+}
+
+func (s *keysServer) serve(t *testing.T, keys ...*jose.JSONWebKey) {
+	var keySet jose.JSONWebKeySet
+	for _, key := range keys {
+		keySet.Keys = append(keySet.Keys, key.Public())
+	}
+	data, err := json.Marshal(keySet)
+	require.NoError(t, err)
+	s.mu.Lock()
+	s.keySet, s.fail = data, false
+	s.mu.Unlock()
+}
+
+func (s *keysServer) setFail(fail bool) {
+	s.mu.Lock()
+	s.fail = fail
+	s.mu.Unlock()
+}
+
+func newSigningKey(t *testing.T, keyID string) *jose.JSONWebKey {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	return &jose.JSONWebKey{Key: key, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func signedToken(t *testing.T, key *jose.JSONWebKey) string {
+	token, err := newToken(key, map[string]interface{}{
+		"iss": "https://issuer.example.com",
+		"sub": "subvalue",
+	})
+	require.NoError(t, err)
+	return token
+}
+
+// newTestRemoteKeySet returns a key set which isn't fetched until it's used.
+func newTestRemoteKeySet(server *keysServer, refreshInterval, gracePeriod time.Duration) *remoteKeySet {
+	return &remoteKeySet{
+		keys:               &staticKeySet{},
+		jwksURL:            server.URL,
+		client:             http.DefaultClient,
+		connectorID:        "remote",
+		logger:             logrus.New(),
+		refreshInterval:    refreshInterval,
+		gracePeriod:        gracePeriod,
+		minRefreshInterval: minKeysRefreshInterval,
+		now:                time.Now,
+	}
+}
+
+func TestRemoteKeySetRotation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second, third := newSigningKey(t, "first"), newSigningKey(t, "second"), newSigningKey(t, "third")
+	server := newKeysServer()
+	defer server.Close()
+	server.serve(t, first)
+
+	keySet := newTestRemoteKeySet(server, 50*time.Millisecond, time.Hour)
+	keySet.minRefreshInterval = 0
+
+	_, err := keySet.VerifySignature(ctx, signedToken(t, first))
+	require.NoError(t, err)
+
+	// The provider rotates its keys, tokens signed with the new key are
+	// verified after fetching the keys again.
+	server.serve(t, second)
+	_, err = keySet.VerifySignature(ctx, signedToken(t, second))
+	require.NoError(t, err)
+	_, err = keySet.VerifySignature(ctx, signedToken(t, first))
+	require.Error(t, err)
+
+	// Keys rotated while the keys are refreshed in the background are picked
+	// up without a verification failing first.
+	go keySet.refreshLoop(ctx)
+	server.serve(t, third)
+	token := signedToken(t, third)
+	require.Eventually(t, func() bool {
+		_, err := keySet.keys.VerifySignature(ctx, token)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRemoteKeySetRefreshRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	known, unknown := newSigningKey(t, "known"), newSigningKey(t, "unknown")
+	server := newKeysServer()
+	defer server.Close()
+	server.serve(t, known)
+
+	keySet := newTestRemoteKeySet(server, 0, 0)
+	_, err := keySet.VerifySignature(ctx, signedToken(t, known))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&server.requests))
+
+	// Tokens signed with unknown keys don't fetch the keys again within the
+	// minimum refresh interval, even when they're verified concurrently.
+	token := signedToken(t, unknown)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keySet.VerifySignature(ctx, token)
+			require.Error(t, err)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, atomic.LoadInt32(&server.requests))
+
+	// Once the interval passed, the keys are fetched once more.
+	keySet.lastFetch = time.Now().Add(-minKeysRefreshInterval)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			keySet.VerifySignature(ctx, token)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 2, atomic.LoadInt32(&server.requests))
+}
+
+func TestRemoteKeySetBackgroundRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key := newSigningKey(t, "key")
+	server := newKeysServer()
+	defer server.Close()
+	server.serve(t, key)
+
+	keySet := newTestRemoteKeySet(server, time.Hour, time.Hour)
+	for i := 0; i < 100; i++ {
+		delay := keySet.refreshDelay()
+		require.True(t, delay > 54*time.Minute && delay <= time.Hour, "refresh delay %v", delay)
+	}
+
+	go keySet.refreshLoop(ctx)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&server.requests) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A burst of logins within the refresh interval doesn't fetch the keys
+	// again.
+	token := signedToken(t, key)
+	var wg sync.WaitGroup
+	errs := make([]error, 50)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = keySet.VerifySignature(ctx, token)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&server.requests))
+}
+
+func TestRemoteKeySetStaleOnError(t *testing.T) {
+	ctx := context.Background()
+
+	key := newSigningKey(t, "key")
+	server := newKeysServer()
+	defer server.Close()
+	server.serve(t, key)
+
+	now := time.Now()
+	keySet := newTestRemoteKeySet(server, time.Minute, 10*time.Minute)
+	keySet.now = func() time.Time { return now }
+
+	token := signedToken(t, key)
+	_, err := keySet.VerifySignature(ctx, token)
+	require.NoError(t, err)
+
+	// The provider is down, the keys are still used within the grace period.
+	server.setFail(true)
+	now = now.Add(5 * time.Minute)
+	require.Error(t, keySet.fetch(ctx))
+	_, err = keySet.VerifySignature(ctx, token)
+	require.NoError(t, err)
+
+	// Once the grace period passed, the keys aren't used anymore.
+	now = now.Add(10 * time.Minute)
+	_, err = keySet.VerifySignature(ctx, token)
+	require.Error(t, err)
+
+	// The provider is back.
+	server.setFail(false)
+	now = now.Add(minKeysRefreshInterval)
+	_, err = keySet.VerifySignature(ctx, token)
+	require.NoError(t, err)
+}
+
+func TestKeysRefreshIntervalConfig(t *testing.T) {
+	server := newKeysServer()
+	defer server.Close()
+	server.serve(t, newSigningKey(t, "key"))
+
+	tests := []struct {
+		name            string
+		refreshInterval string
+		gracePeriod     string
+		staticKeys      bool
+		wantErr         string
+	}{
+		{name: "refresh interval", refreshInterval: "10m"},
+		{name: "refresh interval and grace period", refreshInterval: "10m", gracePeriod: "0s"},
+		{name: "invalid refresh interval", refreshInterval: "often", wantErr: `oidc: invalid keysRefreshInterval "often"`},
+		{name: "zero refresh interval", refreshInterval: "0s", wantErr: `oidc: invalid keysRefreshInterval "0s"`},
+		{name: "invalid grace period", refreshInterval: "10m", gracePeriod: "-1m", wantErr: `oidc: invalid keysGracePeriod "-1m"`},
+		{name: "grace period only", gracePeriod: "10m", wantErr: "oidc: keysGracePeriod requires keysRefreshInterval"},
+		{name: "static keys", refreshInterval: "10m", staticKeys: true, wantErr: "oidc: keysRefreshInterval can't be combined with staticKeys or jwksFile"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := Config{
+				Issuer:              "https://issuer.example.com",
+				ClientID:            "clientID",
+				KeysRefreshInterval: tc.refreshInterval,
+				KeysGracePeriod:     tc.gracePeriod,
+				Endpoints: Endpoints{
+					AuthURL:           server.URL + "/authorize",
+					TokenURL:          server.URL + "/token",
+					JWKSURL:           server.URL,
+					InsecureAllowHTTP: true,
+				},
+			}
+			if tc.staticKeys {
+				server.mu.Lock()
+				config.StaticKeys = string(server.keySet)
+				server.mu.Unlock()
+			}
+			conn, err := config.Open("remote", logrus.New())
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer conn.(*oidcConnector).Close()
+			require.IsType(t, &remoteKeySet{}, conn.(*oidcConnector).keySet)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// errStateExpired is returned for callbacks of logins which started longer
+// than the stateTTL ago.
+var errStateExpired = errors.New("oidc: state expired, please log in again")
+
+// encodeState appends the time the login started at to the state of the
+// server, so the callback can be rejected once the login is too old.
+func encodeState(state string, issuedAt time.Time) string {
+	return state + connector.StateSeparator + strconv.FormatInt(issuedAt.Unix(), 10)
+}
+
+// decodeState returns the state of the server and the time the login started
+// at. States without a time, of logins started before it was encoded, have a
+// zero time.
+func decodeState(state string) (string, time.Time, error) {
+	i := strings.Index(state, connector.StateSeparator)
+	if i < 0 {
+		return state, time.Time{}, nil
+	}
+	issuedAt, err := strconv.ParseInt(state[i+len(connector.StateSeparator):], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("oidc: malformed state %q", state)
+	}
+	return state[:i], time.Unix(issuedAt, 0), nil
+}
+
+// checkState fails if the login of the state of a callback started longer
+// than the stateTTL ago.
+//
+// The time isn't signed: it only spares users a confusing error later on,
+// the server still checks the login hasn't expired.
+func (c *oidcConnector) checkState(state string, now time.Time) error {
+	_, issuedAt, err := decodeState(state)
+	if err != nil {
+		return err
+	}
+	if !issuedAt.IsZero() && now.Sub(issuedAt) > c.stateTTL {
+		return errStateExpired
+	}
+	return nil
+}
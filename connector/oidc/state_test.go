@@ -0,0 +1,73 @@
+package oidc
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestStateTTL(t *testing.T) {
+	testServer, err := setupServer(map[string]interface{}{
+		"sub":            "subvalue",
+		"name":           "namevalue",
+		"email":          "emailvalue",
+		"email_verified": true,
+	})
+	require.NoError(t, err)
+	defer testServer.Close()
+
+	conn, err := newConnector(Config{
+		Issuer:       testServer.URL,
+		ClientID:     "clientID",
+		ClientSecret: "clientSecret",
+		RedirectURI:  fmt.Sprintf("%s/callback", testServer.URL),
+		StateTTL:     "15m",
+	})
+	require.NoError(t, err)
+
+	callback := func(state string) error {
+		req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+		require.NoError(t, err)
+		q := req.URL.Query()
+		q.Set("state", state)
+		req.URL.RawQuery = q.Encode()
+		_, err = conn.HandleCallback(connector.Scopes{}, req)
+		return err
+	}
+
+	// The state sent to the provider carries the time the login started at.
+	loginURL, err := conn.LoginURL(connector.Scopes{}, conn.redirectURI, "1234")
+	require.NoError(t, err)
+	u, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	state := u.Query().Get("state")
+	assert.NoError(t, callback(state))
+
+	serverState, issuedAt, err := decodeState(state)
+	require.NoError(t, err)
+	assert.Equal(t, "1234", serverState)
+	assert.WithinDuration(t, time.Now(), issuedAt, time.Minute)
+
+	assert.NoError(t, callback(encodeState("1234", time.Now().Add(-14*time.Minute))))
+	assert.Equal(t, errStateExpired, callback(encodeState("1234", time.Now().Add(-16*time.Minute))))
+	assert.ErrorContains(t, callback("1234"+connector.StateSeparator+"yesterday"), "malformed state")
+
+	// Logins started before the time was encoded don't expire.
+	assert.NoError(t, callback("1234"))
+
+	// Callbacks are accepted for a day by default.
+	conn.stateTTL = defaultStateTTL
+	assert.NoError(t, callback(encodeState("1234", time.Now().Add(-16*time.Minute))))
+	assert.Equal(t, errStateExpired, callback(encodeState("1234", time.Now().Add(-25*time.Hour))))
+
+	for _, stateTTL := range []string{"0s", "-1m", "soon"} {
+		_, err := newConnector(Config{Issuer: testServer.URL, StateTTL: stateTTL})
+		assert.ErrorContains(t, err, "invalid stateTTL")
+	}
+}
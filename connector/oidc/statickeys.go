@@ -0,0 +1,112 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// staticKeySet verifies ID tokens with configured keys instead of the keys
+// published by the provider.
+type staticKeySet struct {
+	mu   sync.RWMutex
+	keys []jose.JSONWebKey
+}
+
+// newStaticKeySet returns the key set of the staticKeys or jwksFile options.
+func (c *Config) newStaticKeySet() (*staticKeySet, error) {
+	data := []byte(c.StaticKeys)
+	if c.JWKSFile != "" {
+		var err error
+		if data, err = os.ReadFile(c.JWKSFile); err != nil {
+			return nil, fmt.Errorf("failed to read jwksFile: %v", err)
+		}
+	}
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return nil, err
+	}
+	return &staticKeySet{keys: keys}, nil
+}
+
+// parseKeySet returns the public keys of a JSON Web Key Set.
+func parseKeySet(data []byte) ([]jose.JSONWebKey, error) {
+	var keySet jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("malformed key set: %v", err)
+	}
+	if len(keySet.Keys) == 0 {
+		return nil, errors.New("key set has no keys")
+	}
+	keys := make([]jose.JSONWebKey, len(keySet.Keys))
+	for i, key := range keySet.Keys {
+		keys[i] = key.Public()
+		if !keys[i].Valid() {
+			return nil, fmt.Errorf("key %q is not a valid signing key", key.KeyID)
+		}
+	}
+	return keys, nil
+}
+
+func (s *staticKeySet) VerifySignature(ctx context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: malformed jwt: %v", err)
+	}
+	var keyID string
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	s.mu.RLock()
+	keys := s.keys
+	s.mu.RUnlock()
+	for _, key := range keys {
+		if keyID != "" && key.KeyID != keyID {
+			continue
+		}
+		if payload, err := jws.Verify(&key); err == nil {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("oidc: failed to verify id token signature with the static keys")
+}
+
+func (s *staticKeySet) setKeys(keys []jose.JSONWebKey) {
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+}
+
+// reload reads the key set from the file at every interval until the context
+// is canceled. A file which can't be loaded is logged and the previous keys
+// are kept.
+func (s *staticKeySet) reload(ctx context.Context, path string, interval time.Duration, logger log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var keys []jose.JSONWebKey
+			if keys, err = parseKeySet(data); err == nil {
+				s.setKeys(keys)
+				continue
+			}
+		}
+		logger.Errorf("oidc: failed to reload jwksFile, keeping the previous keys: %v", err)
+	}
+}
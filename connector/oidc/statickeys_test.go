@@ -0,0 +1,156 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestStaticKeys(t *testing.T) {
+	const issuer = "https://issuer.example.com"
+
+	newKey := func(keyID string) (*jose.JSONWebKey, string) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		jwk := &jose.JSONWebKey{Key: key, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+		keySet, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk.Public()}})
+		require.NoError(t, err)
+		return jwk, string(keySet)
+	}
+	signingKey, keySet := newKey("current")
+	_, otherKeySet := newKey("other")
+
+	// The provider only serves the token endpoint, its discovery document and
+	// keys aren't reachable.
+	var discoveryRequests int32
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" {
+			atomic.AddInt32(&discoveryRequests, 1)
+			http.NotFound(w, r)
+			return
+		}
+		token, err := newToken(signingKey, map[string]interface{}{
+			"iss":            issuer,
+			"aud":            "clientID",
+			"sub":            "subvalue",
+			"name":           "namevalue",
+			"email":          "emailvalue",
+			"email_verified": true,
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"id_token":     token,
+			"token_type":   "Bearer",
+		})
+	}))
+	defer testServer.Close()
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeKeySet := func(keySet string) {
+		require.NoError(t, os.WriteFile(jwksFile, []byte(keySet), 0o600))
+	}
+	open := func(config Config) (*oidcConnector, error) {
+		config.Issuer = issuer
+		config.ClientID = "clientID"
+		config.ClientSecret = "clientSecret"
+		config.RedirectURI = testServer.URL + "/callback"
+		config.Endpoints = Endpoints{
+			AuthURL:           testServer.URL + "/authorize",
+			TokenURL:          testServer.URL + "/token",
+			InsecureAllowHTTP: true,
+		}
+		conn, err := config.Open("static", logrus.New())
+		if err != nil {
+			return nil, err
+		}
+		return conn.(*oidcConnector), nil
+	}
+	callback := func(conn *oidcConnector) (connector.Identity, error) {
+		req, err := newRequestWithAuthCode(testServer.URL, "someCode")
+		require.NoError(t, err)
+		return conn.HandleCallback(connector.Scopes{}, req)
+	}
+
+	t.Run("inline keys", func(t *testing.T) {
+		conn, err := open(Config{StaticKeys: keySet})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		identity, err := callback(conn)
+		require.NoError(t, err)
+		assert.Equal(t, "subvalue", identity.UserID)
+	})
+
+	t.Run("keys file", func(t *testing.T) {
+		writeKeySet(keySet)
+		conn, err := open(Config{JWKSFile: jwksFile})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = callback(conn)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown signing key", func(t *testing.T) {
+		conn, err := open(Config{StaticKeys: otherKeySet})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = callback(conn)
+		require.Error(t, err)
+	})
+
+	t.Run("reloaded keys file", func(t *testing.T) {
+		writeKeySet(otherKeySet)
+		conn, err := open(Config{JWKSFile: jwksFile, JWKSFileReloadInterval: "10ms"})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = callback(conn)
+		require.Error(t, err)
+
+		// The provider rotated its keys.
+		writeKeySet(keySet)
+		require.Eventually(t, func() bool {
+			_, err := callback(conn)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		writeKeySet(keySet)
+		for _, config := range []Config{
+			{StaticKeys: "not json"},
+			{StaticKeys: `{"keys": []}`},
+			{StaticKeys: keySet, JWKSFile: jwksFile},
+			{JWKSFile: filepath.Join(t.TempDir(), "missing.json")},
+			{StaticKeys: keySet, JWKSFileReloadInterval: "1h"},
+			{JWKSFile: jwksFile, JWKSFileReloadInterval: "often"},
+		} {
+			_, err := open(config)
+			assert.Error(t, err, "config %+v", config)
+		}
+	})
+
+	require.Zero(t, atomic.LoadInt32(&discoveryRequests), "expected no requests for the discovery document or keys")
+}
@@ -0,0 +1,290 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha1" // Register the digests of RSA-OAEP.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// XML Encryption algorithms.
+//
+// See: https://www.w3.org/TR/xmlenc-core1/
+const (
+	encAES128CBC = "http://www.w3.org/2001/04/xmlenc#aes128-cbc"
+	encAES192CBC = "http://www.w3.org/2001/04/xmlenc#aes192-cbc"
+	encAES256CBC = "http://www.w3.org/2001/04/xmlenc#aes256-cbc"
+	encAES128GCM = "http://www.w3.org/2009/xmlenc11#aes128-gcm"
+	encAES192GCM = "http://www.w3.org/2009/xmlenc11#aes192-gcm"
+	encAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+
+	encRSAOAEPMGF1P = "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"
+	encRSAOAEP      = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+
+	digestSHA1   = "http://www.w3.org/2000/09/xmldsig#sha1"
+	digestSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	digestSHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+
+	mgf1SHA1   = "http://www.w3.org/2009/xmldsig11#mgf1sha1"
+	mgf1SHA256 = "http://www.w3.org/2009/xmldsig11#mgf1sha256"
+	mgf1SHA512 = "http://www.w3.org/2009/xmldsig11#mgf1sha512"
+)
+
+// blockCiphers maps the supported block encryption algorithms to their key
+// size, and whether they use GCM rather than CBC.
+var blockCiphers = map[string]struct {
+	keySize int
+	gcm     bool
+}{
+	encAES128CBC: {16, false},
+	encAES192CBC: {24, false},
+	encAES256CBC: {32, false},
+	encAES128GCM: {16, true},
+	encAES192GCM: {24, true},
+	encAES256GCM: {32, true},
+}
+
+var (
+	digestHashes = map[string]crypto.Hash{
+		digestSHA1:   crypto.SHA1,
+		digestSHA256: crypto.SHA256,
+		digestSHA512: crypto.SHA512,
+	}
+	mgf1Hashes = map[string]crypto.Hash{
+		mgf1SHA1:   crypto.SHA1,
+		mgf1SHA256: crypto.SHA256,
+		mgf1SHA512: crypto.SHA512,
+	}
+)
+
+// loadPrivateKey reads the RSA private key used to decrypt assertions from a
+// PEM file. Keys encrypted with the legacy PEM encryption are decrypted with
+// the password.
+func loadPrivateKey(path, password string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read private key file: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key file")
+	}
+
+	der := block.Bytes
+	//nolint:staticcheck // Legacy PEM encryption is still common for SAML keys.
+	if x509.IsEncryptedPEMBlock(block) {
+		if password == "" {
+			return nil, fmt.Errorf("private key is encrypted, but no privateKeyPassword was provided")
+		}
+		//nolint:staticcheck // Legacy PEM encryption is still common for SAML keys.
+		if der, err = x509.DecryptPEMBlock(block, []byte(password)); err != nil {
+			return nil, fmt.Errorf("decrypt private key: %v", err)
+		}
+	} else if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return nil, fmt.Errorf("encrypted PKCS #8 private keys aren't supported, use an unencrypted or PEM encrypted key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA private key, got %T", parsed)
+	}
+	return key, nil
+}
+
+// decryptResponse decrypts the encrypted assertion of a response which isn't
+// verified.
+func decryptResponse(key *rsa.PrivateKey, data []byte) ([]byte, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("parse document: %v", err)
+	}
+	if err := decryptAssertion(key, doc.Root()); err != nil {
+		return nil, err
+	}
+	return doc.WriteToBytes()
+}
+
+// decryptAssertion replaces the <EncryptedAssertion> element of the response,
+// if there's one, with the decrypted <Assertion> element.
+//
+// Only the first <EncryptedAssertion> element is decrypted, like only the
+// first <Assertion> element is processed.
+func decryptAssertion(key *rsa.PrivateKey, response *etree.Element) error {
+	el, err := etreeutils.NSFindOneChild(response, "urn:oasis:names:tc:SAML:2.0:assertion", "EncryptedAssertion")
+	if err != nil {
+		return fmt.Errorf("find EncryptedAssertion element: %v", err)
+	}
+	if el == nil {
+		return nil
+	}
+
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return fmt.Errorf("parse EncryptedAssertion element: %v", err)
+	}
+	var encrypted encryptedAssertion
+	if err := etreeutils.NSUnmarshalElement(ctx, el, &encrypted); err != nil {
+		return fmt.Errorf("parse EncryptedAssertion element: %v", err)
+	}
+	plaintext, err := encrypted.decrypt(key)
+	if err != nil {
+		return fmt.Errorf("decrypt assertion: %v", err)
+	}
+
+	if err := xrv.Validate(bytes.NewReader(plaintext)); err != nil {
+		return fmt.Errorf("validating decrypted assertion: %v", err)
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(plaintext); err != nil {
+		return fmt.Errorf("parse decrypted assertion: %v", err)
+	}
+	assertion := doc.Root()
+	if assertion == nil || assertion.Tag != "Assertion" {
+		return fmt.Errorf("decrypted data isn't an Assertion element")
+	}
+
+	response.InsertChild(el, assertion)
+	response.RemoveChild(el)
+	return nil
+}
+
+func (a *encryptedAssertion) decrypt(key *rsa.PrivateKey) ([]byte, error) {
+	var encryptedKeys []encryptedKey
+	if a.EncryptedData.KeyInfo != nil {
+		encryptedKeys = append(encryptedKeys, a.EncryptedData.KeyInfo.EncryptedKeys...)
+	}
+	encryptedKeys = append(encryptedKeys, a.EncryptedKeys...)
+	if len(encryptedKeys) == 0 {
+		return nil, fmt.Errorf("no EncryptedKey element found")
+	}
+
+	// The assertion may be encrypted for several recipients, use the first
+	// key which can be decrypted.
+	var (
+		sessionKey []byte
+		err        error
+	)
+	for _, k := range encryptedKeys {
+		if sessionKey, err = k.decrypt(key); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key: %v", err)
+	}
+	return a.EncryptedData.decrypt(sessionKey)
+}
+
+// decrypt returns the session key transported with RSA-OAEP.
+func (k *encryptedKey) decrypt(key *rsa.PrivateKey) ([]byte, error) {
+	method := k.EncryptionMethod
+	digest, mgf := crypto.SHA1, crypto.SHA1
+	if method.DigestMethod != nil {
+		var ok bool
+		if digest, ok = digestHashes[method.DigestMethod.Algorithm]; !ok {
+			return nil, fmt.Errorf("unsupported digest method %q", method.DigestMethod.Algorithm)
+		}
+	}
+	switch method.Algorithm {
+	case encRSAOAEPMGF1P:
+	case encRSAOAEP:
+		if method.MGF != nil {
+			var ok bool
+			if mgf, ok = mgf1Hashes[method.MGF.Algorithm]; !ok {
+				return nil, fmt.Errorf("unsupported mask generation function %q", method.MGF.Algorithm)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key transport algorithm %q", method.Algorithm)
+	}
+	if digest != mgf {
+		return nil, fmt.Errorf("RSA-OAEP with different digest and mask generation hashes isn't supported")
+	}
+
+	ciphertext, err := decodeCipherValue(k.CipherData)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.DecryptOAEP(digest.New(), rand.Reader, key, ciphertext, nil)
+}
+
+// decrypt returns the plaintext encrypted with the session key.
+func (d *encryptedData) decrypt(sessionKey []byte) ([]byte, error) {
+	alg, ok := blockCiphers[d.EncryptionMethod.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", d.EncryptionMethod.Algorithm)
+	}
+	if len(sessionKey) != alg.keySize {
+		return nil, fmt.Errorf("expected a %d bytes key, got %d bytes", alg.keySize, len(sessionKey))
+	}
+	ciphertext, err := decodeCipherValue(d.CipherData)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if alg.gcm {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		// The nonce is prepended to the ciphertext, followed by the tag.
+		if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+			return nil, fmt.Errorf("ciphertext too short")
+		}
+		nonce := ciphertext[:aead.NonceSize()]
+		return aead.Open(nil, nonce, ciphertext[aead.NonceSize():], nil)
+	}
+
+	// The IV is prepended to the ciphertext. The last byte of the plaintext is
+	// the length of the padding.
+	if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("invalid ciphertext length %d", len(ciphertext))
+	}
+	iv := ciphertext[:aes.BlockSize]
+	plaintext := make([]byte, len(ciphertext)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext[aes.BlockSize:])
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+func decodeCipherValue(data cipherData) ([]byte, error) {
+	// Base64 values are often wrapped.
+	value := strings.Join(strings.Fields(data.CipherValue), "")
+	if value == "" {
+		return nil, fmt.Errorf("no CipherValue found")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode CipherValue: %v", err)
+	}
+	return ciphertext, nil
+}
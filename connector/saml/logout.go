@@ -0,0 +1,284 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// Signature algorithms of the HTTP-Redirect binding.
+const (
+	sigAlgRSASHA1   = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	sigAlgRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	sigAlgRSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+)
+
+var sigAlgHashes = map[string]crypto.Hash{
+	sigAlgRSASHA1:   crypto.SHA1,
+	sigAlgRSASHA256: crypto.SHA256,
+	sigAlgRSASHA512: crypto.SHA512,
+}
+
+// maxLogoutResponseSize limits the size of inflated logout responses.
+const maxLogoutResponseSize = 1 << 20
+
+var _ connector.LogoutConnector = (*provider)(nil)
+
+// LogoutURL returns the URL of the single logout endpoint of the provider
+// with a signed LogoutRequest for the user, sent with the HTTP-Redirect
+// binding. The returnURL is sent as the RelayState.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+// "3.4 HTTP Redirect Binding"
+func (p *provider) LogoutURL(identity connector.Identity, returnURL string) (string, error) {
+	if p.sloURL == "" {
+		return "", nil
+	}
+
+	id, err := newRequestID()
+	if err != nil {
+		return "", err
+	}
+	r := &logoutRequest{
+		ID:           id,
+		IssueInstant: xmlTime(p.now()),
+		Destination:  p.sloURL,
+		NameID: nameID{
+			Format: p.nameIDPolicyFormat,
+			Value:  identity.UserID,
+		},
+	}
+	if p.entityIssuer != "" {
+		r.Issuer = &issuer{Issuer: p.entityIssuer}
+	}
+	data, err := xml.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("marshal logout request: %v", err)
+	}
+	samlRequest, err := deflateEncode(data)
+	if err != nil {
+		return "", fmt.Errorf("encode logout request: %v", err)
+	}
+
+	// The signature covers the URL encoded parameters, in this order.
+	//
+	// See: https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+	// "3.4.4.1 DEFLATE Encoding"
+	query := "SAMLRequest=" + url.QueryEscape(samlRequest)
+	if returnURL != "" {
+		query += "&RelayState=" + url.QueryEscape(returnURL)
+	}
+	query += "&SigAlg=" + url.QueryEscape(sigAlgRSASHA256)
+	h := crypto.SHA256.New()
+	h.Write([]byte(query))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.privateKey, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		return "", fmt.Errorf("sign logout request: %v", err)
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+
+	u, err := url.Parse(p.sloURL)
+	if err != nil {
+		return "", fmt.Errorf("parse sloURL: %v", err)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery += "&" + query
+	} else {
+		u.RawQuery = query
+	}
+	return u.String(), nil
+}
+
+// HandleLogoutResponse verifies the LogoutResponse of the provider, sent with
+// the HTTP-Redirect or the HTTP-POST binding, and returns its RelayState.
+func (p *provider) HandleLogoutResponse(r *http.Request) (string, error) {
+	var (
+		data       []byte
+		relayState string
+		err        error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		data, relayState, err = p.parseRedirectResponse(r.URL.RawQuery)
+	case http.MethodPost:
+		data, err = p.parsePOSTResponse(r.PostFormValue("SAMLResponse"))
+		relayState = r.PostFormValue("RelayState")
+	default:
+		return "", fmt.Errorf("unsupported method %s", r.Method)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var resp logoutResponse
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("unmarshal logout response: %v", err)
+	}
+	if p.ssoIssuer != "" && resp.Issuer != nil && resp.Issuer.Issuer != p.ssoIssuer {
+		return "", fmt.Errorf("expected Issuer value %s, got %s", p.ssoIssuer, resp.Issuer.Issuer)
+	}
+	if resp.Status == nil {
+		return "", fmt.Errorf("logout response did not contain a Status element")
+	}
+	if err := p.validateStatus(resp.Status); err != nil {
+		return "", err
+	}
+	return relayState, nil
+}
+
+// parseRedirectResponse verifies the signature of a LogoutResponse sent with
+// the HTTP-Redirect binding, and returns the inflated response and the
+// RelayState.
+func (p *provider) parseRedirectResponse(rawQuery string) (data []byte, relayState string, err error) {
+	// The signature covers the parameters as they were URL encoded by the
+	// provider, so they're taken from the raw query.
+	params := make(map[string]string)
+	for _, param := range strings.Split(rawQuery, "&") {
+		name, value := param, ""
+		if i := strings.IndexByte(param, '='); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+		if _, ok := params[name]; ok {
+			return nil, "", fmt.Errorf("duplicate parameter %q", name)
+		}
+		params[name] = value
+	}
+	samlResponse, err := url.QueryUnescape(params["SAMLResponse"])
+	if err != nil || samlResponse == "" {
+		return nil, "", fmt.Errorf("no SAMLResponse found")
+	}
+	if relayState, err = url.QueryUnescape(params["RelayState"]); err != nil {
+		return nil, "", fmt.Errorf("decode RelayState: %v", err)
+	}
+
+	if p.validator != nil {
+		if err := p.verifyRedirectSignature(params); err != nil {
+			return nil, "", fmt.Errorf("verify signature: %v", err)
+		}
+	}
+
+	if data, err = inflateDecode(samlResponse); err != nil {
+		return nil, "", fmt.Errorf("decode logout response: %v", err)
+	}
+	if err := xrv.Validate(bytes.NewReader(data)); err != nil {
+		return nil, "", fmt.Errorf("validating XML logout response: %v", err)
+	}
+	return data, relayState, nil
+}
+
+// verifyRedirectSignature verifies the signature of the URL encoded
+// parameters of the HTTP-Redirect binding with the certificates of the
+// provider.
+func (p *provider) verifyRedirectSignature(params map[string]string) error {
+	sigAlg, err := url.QueryUnescape(params["SigAlg"])
+	if err != nil {
+		return fmt.Errorf("decode SigAlg: %v", err)
+	}
+	hash, ok := sigAlgHashes[sigAlg]
+	if !ok {
+		return fmt.Errorf("unsupported signature algorithm %q", sigAlg)
+	}
+	signature, err := url.QueryUnescape(params["Signature"])
+	if err != nil {
+		return fmt.Errorf("decode Signature: %v", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) == 0 {
+		return fmt.Errorf("no valid Signature found")
+	}
+
+	signed := "SAMLResponse=" + params["SAMLResponse"]
+	if relayState, ok := params["RelayState"]; ok {
+		signed += "&RelayState=" + relayState
+	}
+	signed += "&SigAlg=" + params["SigAlg"]
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	now := p.now()
+	for _, cert := range p.validator.certs {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok || now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(key, hash, digest, sig) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature doesn't match any valid certificate")
+}
+
+// parsePOSTResponse verifies the signature of a LogoutResponse sent with the
+// HTTP-POST binding, and returns the verified response.
+func (p *provider) parsePOSTResponse(samlResponse string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("no valid SAMLResponse found")
+	}
+	if err := xrv.Validate(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("validating XML logout response: %v", err)
+	}
+	if p.validator == nil {
+		return data, nil
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("parse document: %v", err)
+	}
+	verified, err := p.validator.Validate(doc.Root())
+	if err != nil {
+		return nil, fmt.Errorf("verify signature: %v", err)
+	}
+	doc.SetRoot(verified)
+	return doc.WriteToBytes()
+}
+
+// newRequestID returns a random ID for a request, which must be a valid
+// xsd:ID, so it can't start with a digit.
+func newRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "_" + hex.EncodeToString(b), nil
+}
+
+func deflateEncode(data []byte) (string, error) {
+	var b bytes.Buffer
+	w, err := flate.NewWriter(&b, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+func inflateDecode(s string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), maxLogoutResponseSize))
+}
@@ -0,0 +1,276 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+const logoutReturnURL = "https://dex.example.com/logout?client_id=app&state=xyz"
+
+func newLogoutProvider(t *testing.T) *provider {
+	c := Config{
+		CA:           "testdata/ca.crt",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+		SSOURL:       "https://idp.example.com/sso",
+		SLOURL:       "https://idp.example.com/slo?tenant=dex",
+		EntityIssuer: "https://dex.example.com/callback",
+		PrivateKey:   "testdata/enc.key",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLogoutURL(t *testing.T) {
+	p := newLogoutProvider(t)
+
+	logoutURL, err := p.LogoutURL(connector.Identity{UserID: "jane"}, logoutReturnURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(logoutURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if got := u.Scheme + "://" + u.Host + u.Path; got != "https://idp.example.com/slo" {
+		t.Errorf("expected the logout request to be sent to the SLO URL, got %q", got)
+	}
+	if got := q.Get("tenant"); got != "dex" {
+		t.Errorf("expected the query of the SLO URL to be kept, got tenant %q", got)
+	}
+	if got := q.Get("RelayState"); got != logoutReturnURL {
+		t.Errorf("expected RelayState %q, got %q", logoutReturnURL, got)
+	}
+	if got := q.Get("SigAlg"); got != sigAlgRSASHA256 {
+		t.Errorf("expected SigAlg %q, got %q", sigAlgRSASHA256, got)
+	}
+
+	// The signature covers the SAML parameters of the raw query.
+	signed := u.RawQuery[strings.Index(u.RawQuery, "SAMLRequest="):strings.Index(u.RawQuery, "&Signature=")]
+	sig, err := base64.StdEncoding.DecodeString(q.Get("Signature"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := crypto.SHA256.New()
+	h.Write([]byte(signed))
+	if err := rsa.VerifyPKCS1v15(&p.privateKey.PublicKey, crypto.SHA256, h.Sum(nil), sig); err != nil {
+		t.Errorf("invalid signature: %v", err)
+	}
+
+	data, err := inflateDecode(q.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req logoutRequest
+	if err := xml.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	if req.NameID.Value != "jane" || req.NameID.Format != nameIDFormatPersistent {
+		t.Errorf("expected persistent NameID jane, got %+v", req.NameID)
+	}
+	if req.Destination != p.sloURL {
+		t.Errorf("expected Destination %q, got %q", p.sloURL, req.Destination)
+	}
+	if req.Issuer == nil || req.Issuer.Issuer != p.entityIssuer {
+		t.Errorf("expected Issuer %q, got %+v", p.entityIssuer, req.Issuer)
+	}
+	if req.ID == "" {
+		t.Error("expected a request ID")
+	}
+
+	// Without an SLO URL, users aren't logged out of the provider.
+	p.sloURL = ""
+	if logoutURL, err := p.LogoutURL(connector.Identity{UserID: "jane"}, logoutReturnURL); err != nil || logoutURL != "" {
+		t.Errorf("expected no logout URL, got %q, %v", logoutURL, err)
+	}
+}
+
+func TestSLOURLRequiresPrivateKey(t *testing.T) {
+	c := Config{
+		CA:           "testdata/ca.crt",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+		SSOURL:       "https://idp.example.com/sso",
+		SLOURL:       "https://idp.example.com/slo",
+	}
+	if _, err := c.openConnector(logrus.New()); err == nil {
+		t.Fatal("expected an error for an sloURL without a privateKey")
+	}
+}
+
+func logoutResponseXML(statusCode string) string {
+	return fmt.Sprintf(`<samlp:LogoutResponse xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" InResponseTo="_request" Version="2.0" IssueInstant="2017-04-04T04:34:59.330Z" Destination="https://dex.example.com/logout/callback/saml"><saml:Issuer>https://idp.example.com</saml:Issuer><samlp:Status><samlp:StatusCode Value="%s"/></samlp:Status></samlp:LogoutResponse>`, statusCode)
+}
+
+func loadKeyPair(t *testing.T, name string) tls.Certificate {
+	cert, err := tls.LoadX509KeyPair("testdata/"+name+".crt", "testdata/"+name+".key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// redirectLogoutResponse returns the query of a LogoutResponse sent with the
+// HTTP-Redirect binding, signed with the key.
+func redirectLogoutResponse(t *testing.T, key crypto.Signer, response, relayState string) string {
+	samlResponse, err := deflateEncode([]byte(response))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := "SAMLResponse=" + url.QueryEscape(samlResponse) +
+		"&RelayState=" + url.QueryEscape(relayState) +
+		"&SigAlg=" + url.QueryEscape(sigAlgRSASHA256)
+	h := crypto.SHA256.New()
+	h.Write([]byte(query))
+	sig, err := key.Sign(nil, h.Sum(nil), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestHandleLogoutResponseRedirect(t *testing.T) {
+	p := newLogoutProvider(t)
+	key := loadKeyPair(t, "ca").PrivateKey.(crypto.Signer)
+	badKey := loadKeyPair(t, "bad-ca").PrivateKey.(crypto.Signer)
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{
+			name:  "signed response",
+			query: redirectLogoutResponse(t, key, logoutResponseXML(statusCodeSuccess), logoutReturnURL),
+		},
+		{
+			name:    "signed with another key",
+			query:   redirectLogoutResponse(t, badKey, logoutResponseXML(statusCodeSuccess), logoutReturnURL),
+			wantErr: true,
+		},
+		{
+			name:    "tampered RelayState",
+			query:   strings.Replace(redirectLogoutResponse(t, key, logoutResponseXML(statusCodeSuccess), logoutReturnURL), "xyz", "abc", 1),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned response",
+			query:   strings.Split(redirectLogoutResponse(t, key, logoutResponseXML(statusCodeSuccess), logoutReturnURL), "&SigAlg=")[0],
+			wantErr: true,
+		},
+		{
+			name:    "failed logout",
+			query:   redirectLogoutResponse(t, key, logoutResponseXML("urn:oasis:names:tc:SAML:2.0:status:Responder"), logoutReturnURL),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/logout/callback/saml?"+tc.query, nil)
+			returnURL, err := p.HandleLogoutResponse(r)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if returnURL != logoutReturnURL {
+				t.Errorf("expected return URL %q, got %q", logoutReturnURL, returnURL)
+			}
+		})
+	}
+}
+
+func TestHandleLogoutResponsePOST(t *testing.T) {
+	p := newLogoutProvider(t)
+
+	sign := func(keyPair string, response string) string {
+		doc := etree.NewDocument()
+		if err := doc.ReadFromString(response); err != nil {
+			t.Fatal(err)
+		}
+		signed, err := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(loadKeyPair(t, keyPair))).SignEnveloped(doc.Root())
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc.SetRoot(signed)
+		data, err := doc.WriteToString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "signed response",
+			response: sign("ca", logoutResponseXML(statusCodeSuccess)),
+		},
+		{
+			name:     "signed with another key",
+			response: sign("bad-ca", logoutResponseXML(statusCodeSuccess)),
+			wantErr:  true,
+		},
+		{
+			name:     "unsigned response",
+			response: logoutResponseXML(statusCodeSuccess),
+			wantErr:  true,
+		},
+		{
+			name:     "failed logout",
+			response: sign("ca", logoutResponseXML("urn:oasis:names:tc:SAML:2.0:status:Responder")),
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			form := url.Values{
+				"SAMLResponse": {base64.StdEncoding.EncodeToString([]byte(tc.response))},
+				"RelayState":   {logoutReturnURL},
+			}
+			r := httptest.NewRequest(http.MethodPost, "/logout/callback/saml", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			returnURL, err := p.HandleLogoutResponse(r)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if returnURL != logoutReturnURL {
+				t.Errorf("expected return URL %q, got %q", logoutReturnURL, returnURL)
+			}
+		})
+	}
+}
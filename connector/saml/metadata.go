@@ -0,0 +1,74 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/dexidp/dex/connector"
+)
+
+const protocolSAML2 = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+var _ connector.SAMLMetadataConnector = (*provider)(nil)
+
+// Metadata returns the metadata of dex as a service provider of the identity
+// provider, which admins of the provider can import instead of configuring
+// dex by hand.
+//
+// See: https://docs.oasis-open.org/security/saml/v2.0/saml-metadata-2.0-os.pdf
+// "2.4.4 Element <SPSSODescriptor>"
+func (p *provider) Metadata(logoutCallbackURL string) ([]byte, error) {
+	d := entityDescriptor{
+		EntityID: p.entityIssuer,
+		SPSSODescriptor: spSSODescriptor{
+			WantAssertionsSigned:       p.validator != nil,
+			ProtocolSupportEnumeration: protocolSAML2,
+			NameIDFormats:              []string{p.nameIDPolicyFormat},
+			AssertionConsumerServices: []metadataEndpoint{
+				{Binding: bindingPOST, Location: p.redirectURI, Index: 1, IsDefault: true},
+			},
+		},
+	}
+	if d.EntityID == "" {
+		// Without an entityIssuer, dex expects the ACS URL as the audience
+		// of assertions.
+		d.EntityID = p.redirectURI
+	}
+	if p.certificate != nil {
+		// The private key of the certificate signs logout requests and
+		// decrypts encrypted assertions.
+		cert := base64.StdEncoding.EncodeToString(p.certificate.Raw)
+		d.SPSSODescriptor.KeyDescriptors = []keyDescriptor{
+			{Use: "signing", KeyInfo: keyInfo{X509Certificate: cert}},
+			{Use: "encryption", KeyInfo: keyInfo{X509Certificate: cert}},
+		}
+	}
+	if p.sloURL != "" && logoutCallbackURL != "" {
+		d.SPSSODescriptor.SingleLogoutServices = []metadataEndpoint{
+			{Binding: bindingRedirect, Location: logoutCallbackURL},
+			{Binding: bindingPOST, Location: logoutCallbackURL},
+		}
+	}
+
+	data, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %v", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read certificate file: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found in certificate file")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
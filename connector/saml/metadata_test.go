@@ -0,0 +1,149 @@
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestMetadata(t *testing.T) {
+	c := Config{
+		CA:           "testdata/ca.crt",
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "https://dex.example.com/callback",
+		SSOURL:       "https://idp.example.com/sso",
+		SLOURL:       "https://idp.example.com/slo",
+		EntityIssuer: "https://dex.example.com/saml",
+		PrivateKey:   "testdata/ca.key",
+		Certificate:  "testdata/ca.crt",
+
+		NameIDPolicyFormat: "emailAddress",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const logoutCallbackURL = "https://dex.example.com/logout/callback/saml"
+	data, err := p.Metadata(logoutCallbackURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d entityDescriptor
+	if err := xml.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	sp := d.SPSSODescriptor
+
+	if d.EntityID != c.EntityIssuer {
+		t.Errorf("expected entityID %q, got %q", c.EntityIssuer, d.EntityID)
+	}
+	if !sp.WantAssertionsSigned {
+		t.Error("expected WantAssertionsSigned")
+	}
+	if sp.ProtocolSupportEnumeration != protocolSAML2 {
+		t.Errorf("expected protocolSupportEnumeration %q, got %q", protocolSAML2, sp.ProtocolSupportEnumeration)
+	}
+	if len(sp.NameIDFormats) != 1 || sp.NameIDFormats[0] != nameIDFormatEmailAddress {
+		t.Errorf("expected NameIDFormat %q, got %q", nameIDFormatEmailAddress, sp.NameIDFormats)
+	}
+	if len(sp.AssertionConsumerServices) != 1 {
+		t.Fatalf("expected one AssertionConsumerService, got %d", len(sp.AssertionConsumerServices))
+	}
+	if acs := sp.AssertionConsumerServices[0]; acs.Location != c.RedirectURI || acs.Binding != bindingPOST {
+		t.Errorf("expected an HTTP-POST AssertionConsumerService at %q, got %+v", c.RedirectURI, acs)
+	}
+	if len(sp.SingleLogoutServices) != 2 {
+		t.Fatalf("expected two SingleLogoutServices, got %d", len(sp.SingleLogoutServices))
+	}
+	for _, slo := range sp.SingleLogoutServices {
+		if slo.Location != logoutCallbackURL {
+			t.Errorf("expected SingleLogoutService at %q, got %q", logoutCallbackURL, slo.Location)
+		}
+	}
+
+	var uses []string
+	for _, kd := range sp.KeyDescriptors {
+		uses = append(uses, kd.Use)
+		cert, err := base64.StdEncoding.DecodeString(kd.KeyInfo.X509Certificate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(cert) != string(p.certificate.Raw) {
+			t.Errorf("expected the %s key descriptor to hold the certificate", kd.Use)
+		}
+	}
+	if len(uses) != 2 || uses[0] != "signing" || uses[1] != "encryption" {
+		t.Errorf("expected signing and encryption key descriptors, got %q", uses)
+	}
+}
+
+func TestMetadataDefaults(t *testing.T) {
+	c := Config{
+		InsecureSkipSignatureValidation: true,
+		UsernameAttr:                    "Name",
+		EmailAttr:                       "email",
+		RedirectURI:                     "https://dex.example.com/callback",
+		SSOURL:                          "https://idp.example.com/sso",
+	}
+	p, err := c.openConnector(logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := p.Metadata("https://dex.example.com/logout/callback/saml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d entityDescriptor
+	if err := xml.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without an entityIssuer, the ACS URL identifies dex.
+	if d.EntityID != c.RedirectURI {
+		t.Errorf("expected entityID %q, got %q", c.RedirectURI, d.EntityID)
+	}
+	if d.SPSSODescriptor.WantAssertionsSigned {
+		t.Error("expected WantAssertionsSigned to be false without signature validation")
+	}
+	if n := len(d.SPSSODescriptor.KeyDescriptors); n != 0 {
+		t.Errorf("expected no key descriptors without a certificate, got %d", n)
+	}
+	if n := len(d.SPSSODescriptor.SingleLogoutServices); n != 0 {
+		t.Errorf("expected no SingleLogoutService without an sloURL, got %d", n)
+	}
+	if got := d.SPSSODescriptor.NameIDFormats; len(got) != 1 || got[0] != nameIDFormatPersistent {
+		t.Errorf("expected NameIDFormat %q, got %q", nameIDFormatPersistent, got)
+	}
+}
+
+func TestMetadataCertificateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		privateKey  string
+		certificate string
+	}{
+		{name: "no private key", certificate: "testdata/ca.crt"},
+		{name: "mismatched private key", privateKey: "testdata/enc.key", certificate: "testdata/ca.crt"},
+		{name: "not a certificate", privateKey: "testdata/ca.key", certificate: "testdata/ca.key"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Config{
+				CA:           "testdata/ca.crt",
+				UsernameAttr: "Name",
+				EmailAttr:    "email",
+				RedirectURI:  "https://dex.example.com/callback",
+				SSOURL:       "https://idp.example.com/sso",
+				PrivateKey:   tc.privateKey,
+				Certificate:  tc.certificate,
+			}
+			if _, err := c.openConnector(logrus.New()); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
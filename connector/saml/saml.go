@@ -3,6 +3,7 @@ package saml
 
 import (
 	"bytes"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -16,7 +17,6 @@ import (
 	"github.com/beevik/etree"
 	xrv "github.com/mattermost/xml-roundtrip-validator"
 	"github.com/pkg/errors"
-	dsig "github.com/russellhaering/goxmldsig"
 	"github.com/russellhaering/goxmldsig/etreeutils"
 
 	"github.com/dexidp/dex/connector"
@@ -77,12 +77,37 @@ type Config struct {
 	SSOIssuer    string `json:"ssoIssuer"`
 	SSOURL       string `json:"ssoURL"`
 
-	// X509 CA file or raw data to verify XML signatures.
+	// SLOURL is the single logout endpoint of the provider. If set, users
+	// logging out of dex are logged out of the provider with a LogoutRequest
+	// signed with privateKey and sent with the HTTP-Redirect binding. The
+	// provider must send its LogoutResponse, with the HTTP-Redirect or the
+	// HTTP-POST binding, to the "/logout/callback/<connector id>" endpoint of
+	// dex.
+	SLOURL string `json:"sloURL"`
+
+	// X509 CA file or raw data to verify XML signatures. Both may hold
+	// several PEM encoded certificates.
 	CA     string `json:"ca"`
 	CAData []byte `json:"caData"`
+	// CAs are more X509 CA files to verify XML signatures with. Signatures of
+	// any of the certificates of ca, caData and cas are accepted, so that
+	// providers can roll their signing certificates without downtime.
+	CAs []string `json:"cas"`
 
 	InsecureSkipSignatureValidation bool `json:"insecureSkipSignatureValidation"`
 
+	// Path to the PEM encoded RSA private key used to decrypt encrypted
+	// assertions, for providers such as ADFS encrypting them with the
+	// certificate of dex. The signature of decrypted assertions is still
+	// verified. The key also signs logout requests.
+	PrivateKey string `json:"privateKey"`
+	// Password of the private key, if it's encrypted.
+	PrivateKeyPassword string `json:"privateKeyPassword"`
+	// Path to the PEM encoded certificate of the private key. It's published
+	// in the metadata of dex, served at "/saml/metadata/<connector id>", so
+	// providers can encrypt assertions for dex and verify its logout requests.
+	Certificate string `json:"certificate"`
+
 	// Assertion attribute names to lookup various claims with.
 	UsernameAttr string `json:"usernameAttr"`
 	EmailAttr    string `json:"emailAttr"`
@@ -151,6 +176,7 @@ func (c *Config) openConnector(logger log.Logger) (*provider, error) {
 		entityIssuer:  c.EntityIssuer,
 		ssoIssuer:     c.SSOIssuer,
 		ssoURL:        c.SSOURL,
+		sloURL:        c.SLOURL,
 		now:           time.Now,
 		usernameAttr:  c.UsernameAttr,
 		emailAttr:     c.EmailAttr,
@@ -187,58 +213,111 @@ func (c *Config) openConnector(logger log.Logger) (*provider, error) {
 		}
 	}
 
+	if c.PrivateKey != "" {
+		key, err := loadPrivateKey(c.PrivateKey, c.PrivateKeyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("load private key: %v", err)
+		}
+		p.privateKey = key
+	} else if c.PrivateKeyPassword != "" {
+		return nil, errors.New("privateKeyPassword requires privateKey")
+	}
+	if c.SLOURL != "" && p.privateKey == nil {
+		return nil, errors.New("sloURL requires privateKey to sign logout requests")
+	}
+	if c.Certificate != "" {
+		if p.privateKey == nil {
+			return nil, errors.New("certificate requires privateKey")
+		}
+		cert, err := loadCertificate(c.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("load certificate: %v", err)
+		}
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); !ok || !pub.Equal(&p.privateKey.PublicKey) {
+			return nil, errors.New("certificate doesn't match privateKey")
+		}
+		p.certificate = cert
+	}
+
 	if !c.InsecureSkipSignatureValidation {
-		if (c.CA == "") == (c.CAData == nil) {
+		if c.CA != "" && c.CAData != nil {
 			return nil, errors.New("must provide either 'ca' or 'caData'")
 		}
+		if c.CA == "" && c.CAData == nil && len(c.CAs) == 0 {
+			return nil, errors.New("must provide 'ca', 'caData' or 'cas'")
+		}
 
-		var caData []byte
+		caFiles := c.CAs
 		if c.CA != "" {
-			data, err := os.ReadFile(c.CA)
+			caFiles = append([]string{c.CA}, caFiles...)
+		}
+		var certs []*x509.Certificate
+		for _, caFile := range caFiles {
+			data, err := os.ReadFile(caFile)
 			if err != nil {
 				return nil, fmt.Errorf("read ca file: %v", err)
 			}
-			caData = data
-		} else {
-			caData = c.CAData
-		}
-
-		var (
-			certs []*x509.Certificate
-			block *pem.Block
-		)
-		for {
-			block, caData = pem.Decode(caData)
-			if block == nil {
-				caData = bytes.TrimSpace(caData)
-				if len(caData) > 0 { // if there's some left, we've been given bad caData
-					return nil, fmt.Errorf("parse cert: trailing data: %q", string(caData))
-				}
-				break
-			}
-			cert, err := x509.ParseCertificate(block.Bytes)
+			fileCerts, err := parseCertificates(data)
 			if err != nil {
-				return nil, fmt.Errorf("parse cert: %v", err)
+				return nil, fmt.Errorf("ca file %s: %v", caFile, err)
 			}
-			certs = append(certs, cert)
+			certs = append(certs, fileCerts...)
 		}
-		if len(certs) == 0 {
-			return nil, errors.New("no certificates found in ca data")
+		if c.CAData != nil {
+			dataCerts, err := parseCertificates(c.CAData)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, dataCerts...)
 		}
-		p.validator = dsig.NewDefaultValidationContext(certStore{certs})
+		p.validator = newCertValidator(certs)
 	}
 	return p, nil
 }
 
+// parseCertificates parses one or more PEM encoded certificates.
+func parseCertificates(caData []byte) ([]*x509.Certificate, error) {
+	var (
+		certs []*x509.Certificate
+		block *pem.Block
+	)
+	for {
+		block, caData = pem.Decode(caData)
+		if block == nil {
+			caData = bytes.TrimSpace(caData)
+			if len(caData) > 0 { // if there's some left, we've been given bad caData
+				return nil, fmt.Errorf("parse cert: trailing data: %q", string(caData))
+			}
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse cert: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found in ca data")
+	}
+	return certs, nil
+}
+
 type provider struct {
 	entityIssuer string
 	ssoIssuer    string
 	ssoURL       string
+	sloURL       string
 
 	now func() time.Time
 
 	// If nil, don't do signature validation.
-	validator *dsig.ValidationContext
+	validator *certValidator
+
+	// If nil, encrypted assertions and logout aren't supported.
+	privateKey *rsa.PrivateKey
+
+	// The certificate of privateKey published in the metadata, if any.
+	certificate *x509.Certificate
 
 	// Attribute mappings
 	usernameAttr  string
@@ -290,6 +369,8 @@ func (p *provider) POSTData(s connector.Scopes, id string) (action, value string
 //
 // * Validate XML document does not contain malicious inputs.
 // * Verify signature on XML document (or verify sig on assertion elements).
+//   Encrypted assertions are decrypted after verifying the signature of the
+//   document, or before verifying the signature of the assertion.
 // * Verify various parts of the Assertion element. Conditions, audience, etc.
 // * Map the Assertion's attribute elements to user info.
 //
@@ -307,10 +388,14 @@ func (p *provider) HandlePOST(s connector.Scopes, samlResponse, inResponseTo str
 	// Root element is allowed to not be signed if the Assertion element is.
 	rootElementSigned := true
 	if p.validator != nil {
-		rawResp, rootElementSigned, err = verifyResponseSig(p.validator, rawResp)
+		rawResp, rootElementSigned, err = verifyResponseSig(p.validator, p.privateKey, rawResp)
 		if err != nil {
 			return ident, fmt.Errorf("verify signature: %v", err)
 		}
+	} else if p.privateKey != nil {
+		if rawResp, err = decryptResponse(p.privateKey, rawResp); err != nil {
+			return ident, err
+		}
 	}
 
 	var resp response
@@ -589,9 +674,13 @@ func (p *provider) validateConditions(conditions *conditions) error {
 // this method returns rootVerified=false to indicate that the <Assertion>
 // elements should be trusted, but all other elements MUST be ignored.
 //
+// If a decryption key is given, an <EncryptedAssertion> element is replaced
+// with the decrypted <Assertion> element, which must be signed unless the root
+// element is.
+//
 // Note: we still don't support multiple <Assertion> tags. If there are
 // multiple present this code will only process the first.
-func verifyResponseSig(validator *dsig.ValidationContext, data []byte) (signed []byte, rootVerified bool, err error) {
+func verifyResponseSig(validator signatureValidator, decryptionKey *rsa.PrivateKey, data []byte) (signed []byte, rootVerified bool, err error) {
 	doc := etree.NewDocument()
 	if err = doc.ReadFromBytes(data); err != nil {
 		return nil, false, fmt.Errorf("parse document: %v", err)
@@ -601,16 +690,27 @@ func verifyResponseSig(validator *dsig.ValidationContext, data []byte) (signed [
 	transformedResponse, err := validator.Validate(response)
 	if err == nil {
 		// Root element is verified, return it.
+		if decryptionKey != nil {
+			if err := decryptAssertion(decryptionKey, transformedResponse); err != nil {
+				return nil, false, err
+			}
+		}
 		doc.SetRoot(transformedResponse)
 		signed, err = doc.WriteToBytes()
 		return signed, true, err
 	}
 
+	if decryptionKey != nil {
+		if err := decryptAssertion(decryptionKey, response); err != nil {
+			return nil, false, err
+		}
+	}
+
 	// Ensures xmlns are copied down to the assertion element when they are defined in the root
 	//
 	// TODO: Only select from child elements of the root.
 	assertion, err := etreeutils.NSSelectOne(response, "urn:oasis:names:tc:SAML:2.0:assertion", "Assertion")
-	if err != nil {
+	if err != nil || assertion == nil {
 		return nil, false, fmt.Errorf("response does not contain an Assertion element")
 	}
 	transformedAssertion, err := validator.Validate(assertion)
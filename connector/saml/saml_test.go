@@ -42,6 +42,10 @@ type responseTest struct {
 	caFile   string
 	respFile string
 
+	// Private key file decrypting encrypted assertions.
+	privateKeyFile     string
+	privateKeyPassword string
+
 	// Values that should be used to validate the signature.
 	now          string
 	inResponseTo string
@@ -311,6 +315,101 @@ func TestAssertionSignedNotResponse(t *testing.T) {
 	test.run(t)
 }
 
+// TestEncryptedAssertion ensures the connector decrypts encrypted assertions,
+// and still verifies their signature.
+func TestEncryptedAssertion(t *testing.T) {
+	tests := map[string]struct {
+		respFile           string
+		privateKeyFile     string
+		privateKeyPassword string
+		wantErr            bool
+	}{
+		"aes256-cbc": {
+			respFile:       "testdata/assertion-encrypted-cbc.xml",
+			privateKeyFile: "testdata/enc.key",
+		},
+		"aes128-gcm": {
+			respFile:       "testdata/assertion-encrypted-gcm.xml",
+			privateKeyFile: "testdata/enc.key",
+		},
+		"encrypted private key": {
+			respFile:           "testdata/assertion-encrypted-cbc.xml",
+			privateKeyFile:     "testdata/enc-protected.key",
+			privateKeyPassword: "password",
+		},
+		"unsigned assertion": {
+			respFile:       "testdata/assertion-encrypted-unsigned.xml",
+			privateKeyFile: "testdata/enc.key",
+			wantErr:        true,
+		},
+		"no private key": {
+			respFile: "testdata/assertion-encrypted-cbc.xml",
+			wantErr:  true,
+		},
+		"other private key": {
+			respFile:       "testdata/assertion-encrypted-cbc.xml",
+			privateKeyFile: "testdata/ca.key",
+			wantErr:        true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test := responseTest{
+				caFile:             "testdata/ca.crt",
+				respFile:           tc.respFile,
+				privateKeyFile:     tc.privateKeyFile,
+				privateKeyPassword: tc.privateKeyPassword,
+				now:                "2017-04-04T04:34:59.330Z",
+				usernameAttr:       "Name",
+				emailAttr:          "email",
+				inResponseTo:       "6zmm5mguyebwvajyf2sdwwcw6m",
+				redirectURI:        "http://127.0.0.1:5556/dex/callback",
+				wantErr:            tc.wantErr,
+				wantIdent: connector.Identity{
+					UserID:        "eric.chiang+okta@coreos.com",
+					Username:      "Eric",
+					Email:         "eric.chiang+okta@coreos.com",
+					EmailVerified: true,
+				},
+			}
+			test.run(t)
+		})
+	}
+}
+
+func TestPrivateKeyPassword(t *testing.T) {
+	tests := map[string]struct {
+		privateKeyFile     string
+		privateKeyPassword string
+		wantErr            bool
+	}{
+		"valid password":       {"testdata/enc-protected.key", "password", false},
+		"invalid password":     {"testdata/enc-protected.key", "wrong", true},
+		"missing password":     {"testdata/enc-protected.key", "", true},
+		"password without key": {"", "password", true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := Config{
+				CA:                 "testdata/ca.crt",
+				PrivateKey:         tc.privateKeyFile,
+				PrivateKeyPassword: tc.privateKeyPassword,
+				UsernameAttr:       "user",
+				EmailAttr:          "email",
+				RedirectURI:        "http://127.0.0.1:5556/dex/callback",
+				SSOURL:             "http://foo.bar/",
+			}
+			_, err := c.openConnector(logrus.New())
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func TestInvalidSubjectInResponseTo(t *testing.T) {
 	test := responseTest{
 		caFile:       "testdata/ca.crt",
@@ -405,14 +504,16 @@ func loadCert(ca string) (*x509.Certificate, error) {
 
 func (r responseTest) run(t *testing.T) {
 	c := Config{
-		CA:            r.caFile,
-		UsernameAttr:  r.usernameAttr,
-		EmailAttr:     r.emailAttr,
-		GroupsAttr:    r.groupsAttr,
-		RedirectURI:   r.redirectURI,
-		EntityIssuer:  r.entityIssuer,
-		AllowedGroups: r.allowedGroups,
-		FilterGroups:  r.filterGroups,
+		CA:                 r.caFile,
+		PrivateKey:         r.privateKeyFile,
+		PrivateKeyPassword: r.privateKeyPassword,
+		UsernameAttr:       r.usernameAttr,
+		EmailAttr:          r.emailAttr,
+		GroupsAttr:         r.groupsAttr,
+		RedirectURI:        r.redirectURI,
+		EntityIssuer:       r.entityIssuer,
+		AllowedGroups:      r.allowedGroups,
+		FilterGroups:       r.filterGroups,
 		// Never logging in, don't need this.
 		SSOURL: "http://foo.bar/",
 	}
@@ -556,7 +657,7 @@ func runVerify(t *testing.T, ca string, resp string, shouldSucceed bool) {
 		t.Fatal(err)
 	}
 
-	if _, _, err := verifyResponseSig(validator, data); err != nil {
+	if _, _, err := verifyResponseSig(validator, nil, data); err != nil {
 		if shouldSucceed {
 			t.Fatal(err)
 		}
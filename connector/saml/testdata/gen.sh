@@ -45,3 +45,8 @@ xmlsec1 --sign --privkey-pem ca.key,ca.crt \
     --output two-assertions-first-signed.xml \
     two-assertions-first-signed.tmpl
 
+# Encrypt the signed assertion with enc.key, which was generated with:
+#
+# openssl genrsa -traditional -out enc.key 2048
+# openssl rsa -in enc.key -aes256 -traditional -passout pass:password -out enc-protected.key
+go run gen_encrypted.go
@@ -0,0 +1,177 @@
+//go:build ignore
+// +build ignore
+
+// This program encrypts the assertions of responses with enc.key, replacing
+// the <Assertion> element with an <EncryptedAssertion> element.
+//
+// Run it with "go run gen_encrypted.go" from the testdata directory.
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"log"
+	"os"
+	"regexp"
+)
+
+type encryption struct {
+	// Block encryption and key transport algorithms.
+	cipher  string
+	keySize int
+	gcm     bool
+	oaep    string
+	digest  string
+	mgf     string
+	newHash func() hash.Hash
+
+	// Embed the <EncryptedKey> element in the <KeyInfo> element of the
+	// <EncryptedData> element rather than next to it.
+	embedKey bool
+}
+
+var (
+	cbc = encryption{
+		cipher:   "http://www.w3.org/2001/04/xmlenc#aes256-cbc",
+		keySize:  32,
+		oaep:     "http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p",
+		digest:   "http://www.w3.org/2000/09/xmldsig#sha1",
+		newHash:  sha1.New,
+		embedKey: true,
+	}
+	gcm = encryption{
+		cipher:  "http://www.w3.org/2009/xmlenc11#aes128-gcm",
+		keySize: 16,
+		gcm:     true,
+		oaep:    "http://www.w3.org/2009/xmlenc11#rsa-oaep",
+		digest:  "http://www.w3.org/2001/04/xmlenc#sha256",
+		mgf:     "http://www.w3.org/2009/xmldsig11#mgf1sha256",
+		newHash: sha256.New,
+	}
+)
+
+var (
+	assertionRE = regexp.MustCompile(`(?s)<saml2:Assertion .*</saml2:Assertion>`)
+	signatureRE = regexp.MustCompile(`(?s)\s*<Signature .*</Signature>`)
+)
+
+func main() {
+	data, err := os.ReadFile("enc.key")
+	if err != nil {
+		log.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, r := range []struct {
+		in, out    string
+		enc        encryption
+		stripSigns bool
+	}{
+		{"assertion-signed.xml", "assertion-encrypted-cbc.xml", cbc, false},
+		{"assertion-signed.xml", "assertion-encrypted-gcm.xml", gcm, false},
+		{"assertion-signed.xml", "assertion-encrypted-unsigned.xml", gcm, true},
+	} {
+		resp, err := os.ReadFile(r.in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		assertion := assertionRE.Find(resp)
+		if assertion == nil {
+			log.Fatalf("%s: no assertion found", r.in)
+		}
+		if r.stripSigns {
+			assertion = signatureRE.ReplaceAll(assertion, nil)
+		}
+		encrypted, err := r.enc.encrypt(&key.PublicKey, assertion)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp = bytes.Replace(resp, assertionRE.Find(resp), encrypted, 1)
+		if err := os.WriteFile(r.out, resp, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func (e encryption) encrypt(pub *rsa.PublicKey, plaintext []byte) ([]byte, error) {
+	sessionKey := make([]byte, e.keySize)
+	if _, err := rand.Read(sessionKey); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var ciphertext []byte
+	if e.gcm {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		ciphertext = aead.Seal(nonce, nonce, plaintext, nil)
+	} else {
+		padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+		plaintext = append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+		ciphertext = make([]byte, aes.BlockSize+len(plaintext))
+		if _, err := rand.Read(ciphertext[:aes.BlockSize]); err != nil {
+			return nil, err
+		}
+		cipher.NewCBCEncrypter(block, ciphertext[:aes.BlockSize]).CryptBlocks(ciphertext[aes.BlockSize:], plaintext)
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(e.newHash(), rand.Reader, pub, sessionKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mgf string
+	if e.mgf != "" {
+		mgf = fmt.Sprintf(`
+          <xenc11:MGF xmlns:xenc11="http://www.w3.org/2009/xmlenc11#" Algorithm="%s"/>`, e.mgf)
+	}
+	keyElement := fmt.Sprintf(`<xenc:EncryptedKey Id="_key">
+        <xenc:EncryptionMethod Algorithm="%s">
+          <ds:DigestMethod Algorithm="%s"/>%s
+        </xenc:EncryptionMethod>
+        <xenc:CipherData>
+          <xenc:CipherValue>%s</xenc:CipherValue>
+        </xenc:CipherData>
+      </xenc:EncryptedKey>`, e.oaep, e.digest, mgf, base64.StdEncoding.EncodeToString(encryptedKey))
+
+	keyInfo := `<ds:RetrievalMethod URI="#_key" Type="http://www.w3.org/2001/04/xmlenc#EncryptedKey"/>`
+	sibling := "\n    " + keyElement
+	if e.embedKey {
+		keyInfo, sibling = keyElement, ""
+	}
+
+	return []byte(fmt.Sprintf(`<saml2:EncryptedAssertion xmlns:saml2="urn:oasis:names:tc:SAML:2.0:assertion" xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+    <xenc:EncryptedData Type="http://www.w3.org/2001/04/xmlenc#Element">
+      <xenc:EncryptionMethod Algorithm="%s"/>
+      <ds:KeyInfo>
+        %s
+      </ds:KeyInfo>
+      <xenc:CipherData>
+        <xenc:CipherValue>%s</xenc:CipherValue>
+      </xenc:CipherData>
+    </xenc:EncryptedData>%s
+  </saml2:EncryptedAssertion>`, e.cipher, keyInfo, base64.StdEncoding.EncodeToString(ciphertext), sibling)), nil
+}
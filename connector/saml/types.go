@@ -80,7 +80,7 @@ type subject struct {
 type nameID struct {
 	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
 
-	Format string `xml:"Format,omitempty"`
+	Format string `xml:"Format,attr,omitempty"`
 	Value  string `xml:",chardata"`
 }
 
@@ -275,3 +275,116 @@ func (a attribute) String() string {
 	// "groups" = ["engineering", "docs"]
 	return fmt.Sprintf("%q = %q", a.Name, values)
 }
+
+type encryptedAssertion struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion EncryptedAssertion"`
+
+	EncryptedData encryptedData `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedData"`
+
+	// Keys may also be referenced from the KeyInfo of the EncryptedData
+	// element instead of being embedded in it.
+	EncryptedKeys []encryptedKey `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+}
+
+type encryptedData struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedData"`
+
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	KeyInfo          *keyInfo         `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo,omitempty"`
+	CipherData       cipherData       `xml:"http://www.w3.org/2001/04/xmlenc# CipherData"`
+}
+
+type encryptedKey struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+
+	EncryptionMethod encryptionMethod `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+	CipherData       cipherData       `xml:"http://www.w3.org/2001/04/xmlenc# CipherData"`
+}
+
+type keyInfo struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+
+	EncryptedKeys []encryptedKey `xml:"http://www.w3.org/2001/04/xmlenc# EncryptedKey"`
+
+	// The certificate of a key descriptor in metadata.
+	X509Certificate string `xml:"http://www.w3.org/2000/09/xmldsig# X509Data>X509Certificate,omitempty"`
+}
+
+type encryptionMethod struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2001/04/xmlenc# EncryptionMethod"`
+
+	Algorithm string `xml:"Algorithm,attr"`
+
+	// Parameters of the RSA-OAEP key transport.
+	DigestMethod *algorithmElement `xml:"http://www.w3.org/2000/09/xmldsig# DigestMethod,omitempty"`
+	MGF          *algorithmElement `xml:"http://www.w3.org/2009/xmlenc11# MGF,omitempty"`
+}
+
+type algorithmElement struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+type cipherData struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2001/04/xmlenc# CipherData"`
+
+	CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherValue"`
+}
+
+type logoutRequest struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+
+	ID           string      `xml:"ID,attr"`
+	Version      samlVersion `xml:"Version,attr"`
+	IssueInstant xmlTime     `xml:"IssueInstant,attr"`
+	Destination  string      `xml:"Destination,attr,omitempty"`
+
+	Issuer *issuer `xml:"Issuer,omitempty"`
+	NameID nameID  `xml:"NameID"`
+}
+
+type logoutResponse struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutResponse"`
+
+	ID           string      `xml:"ID,attr"`
+	InResponseTo string      `xml:"InResponseTo,attr"`
+	Version      samlVersion `xml:"Version,attr"`
+
+	Destination string `xml:"Destination,attr,omitempty"`
+
+	Issuer *issuer `xml:"Issuer,omitempty"`
+
+	Status *status `xml:"Status"`
+}
+
+type entityDescriptor struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+
+	EntityID string `xml:"entityID,attr"`
+
+	SPSSODescriptor spSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool   `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool   `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string `xml:"protocolSupportEnumeration,attr"`
+
+	KeyDescriptors            []keyDescriptor    `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleLogoutServices      []metadataEndpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleLogoutService"`
+	NameIDFormats             []string           `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+	AssertionConsumerServices []metadataEndpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use string `xml:"use,attr,omitempty"`
+
+	KeyInfo keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+}
+
+type metadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+
+	Index     int  `xml:"index,attr,omitempty"`
+	IsDefault bool `xml:"isDefault,attr,omitempty"`
+}
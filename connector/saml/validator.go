@@ -0,0 +1,55 @@
+package saml
+
+import (
+	"crypto/x509"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// signatureValidator verifies the XML signature of an element, and returns the
+// signed element.
+type signatureValidator interface {
+	Validate(el *etree.Element) (*etree.Element, error)
+}
+
+// certValidator accepts XML signatures of any of the trusted certificates,
+// for example both the old and the new signing certificate of a provider
+// during a certificate rotation.
+type certValidator struct {
+	certs []*x509.Certificate
+
+	// all validates signatures against all certificates, but only works
+	// for signatures telling their certificate in a KeyInfo element, or
+	// with a single certificate.
+	all *dsig.ValidationContext
+	// each validates signatures against one of the certificates, for
+	// signatures without KeyInfo element.
+	each []*dsig.ValidationContext
+}
+
+func newCertValidator(certs []*x509.Certificate) *certValidator {
+	v := &certValidator{
+		certs: certs,
+		all:   dsig.NewDefaultValidationContext(certStore{certs}),
+	}
+	if len(certs) > 1 {
+		for _, cert := range certs {
+			v.each = append(v.each, dsig.NewDefaultValidationContext(certStore{[]*x509.Certificate{cert}}))
+		}
+	}
+	return v
+}
+
+func (v *certValidator) Validate(el *etree.Element) (*etree.Element, error) {
+	validated, err := v.all.Validate(el)
+	if err == nil {
+		return validated, nil
+	}
+	for _, ctx := range v.each {
+		if validated, eachErr := ctx.Validate(el); eachErr == nil {
+			return validated, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,117 @@
+package saml
+
+import (
+	"encoding/base64"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestMultipleCAs(t *testing.T) {
+	resp, err := os.ReadFile("testdata/good-resp.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signature stays valid without the KeyInfo element, which tells the
+	// signing certificate but isn't signed.
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(resp); err != nil {
+		t.Fatal(err)
+	}
+	sig := doc.Root().SelectElement("Signature")
+	sig.RemoveChild(sig.SelectElement("KeyInfo"))
+	respWithoutKeyInfo, err := doc.WriteToBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now, err := time.Parse(timeFormat, "2017-04-04T04:34:59.330Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ca      string
+		cas     []string
+		resp    []byte
+		wantErr bool
+	}{
+		{
+			name: "signed with the second certificate",
+			ca:   "testdata/bad-ca.crt",
+			cas:  []string{"testdata/ca.crt"},
+			resp: resp,
+		},
+		{
+			name: "signed with the second certificate without KeyInfo",
+			ca:   "testdata/bad-ca.crt",
+			cas:  []string{"testdata/ca.crt"},
+			resp: respWithoutKeyInfo,
+		},
+		{
+			name: "cas only",
+			cas:  []string{"testdata/okta-ca.pem", "testdata/ca.crt"},
+			resp: respWithoutKeyInfo,
+		},
+		{
+			name:    "signed with none of the certificates",
+			ca:      "testdata/bad-ca.crt",
+			cas:     []string{"testdata/okta-ca.pem"},
+			resp:    respWithoutKeyInfo,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Config{
+				CA:           tc.ca,
+				CAs:          tc.cas,
+				UsernameAttr: "Name",
+				EmailAttr:    "email",
+				RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+				SSOURL:       "http://foo.bar/",
+			}
+			p, err := c.openConnector(logrus.New())
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.now = func() time.Time { return now }
+
+			ident, err := p.HandlePOST(connector.Scopes{}, base64.StdEncoding.EncodeToString(tc.resp), "6zmm5mguyebwvajyf2sdwwcw6m")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ident.Email != "eric.chiang+okta@coreos.com" {
+				t.Errorf("unexpected identity %+v", ident)
+			}
+		})
+	}
+}
+
+func TestCAsRequired(t *testing.T) {
+	c := Config{
+		UsernameAttr: "Name",
+		EmailAttr:    "email",
+		RedirectURI:  "http://127.0.0.1:5556/dex/callback",
+		SSOURL:       "http://foo.bar/",
+	}
+	if _, err := c.openConnector(logrus.New()); err == nil {
+		t.Error("expected an error without ca, caData or cas")
+	}
+	c.CAs = []string{"testdata/ca.crt", "testdata/enc.key"}
+	if _, err := c.openConnector(logrus.New()); err == nil {
+		t.Error("expected an error for a ca file without certificates")
+	}
+}
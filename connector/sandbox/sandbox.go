@@ -0,0 +1,90 @@
+// Package sandbox implements a connector which logs everyone in as the same,
+// configured identity, for integration tests of applications using dex.
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/pkg/log"
+)
+
+// Config holds the configuration parameters for a connector which returns a
+// fixed identity without authenticating the user.
+//
+// Anyone who can reach dex can log in as the identity, so the connector must
+// never be configured in production. Opening it fails unless unsafe is set.
+type Config struct {
+	// Unsafe must be set to acknowledge that the connector authenticates no
+	// one.
+	Unsafe bool `json:"unsafe"`
+
+	UserID            string   `json:"userID"`
+	Username          string   `json:"username"`
+	PreferredUsername string   `json:"preferredUsername"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"emailVerified"`
+	Groups            []string `json:"groups"`
+}
+
+// Open returns a strategy which logs everyone in as the configured identity.
+func (c *Config) Open(id string, logger log.Logger) (connector.Connector, error) {
+	if !c.Unsafe {
+		return nil, errors.New("sandbox: the sandbox connector doesn't authenticate users, set unsafe to true to enable it")
+	}
+	if c.UserID == "" {
+		return nil, errors.New("sandbox: no userID supplied")
+	}
+	logger.Warnf("sandbox: connector %q logs everyone in as user %q without authentication, never enable it in production", id, c.UserID)
+	return &sandboxConnector{
+		id: id,
+		identity: connector.Identity{
+			UserID:            c.UserID,
+			Username:          c.Username,
+			PreferredUsername: c.PreferredUsername,
+			Email:             c.Email,
+			EmailVerified:     c.EmailVerified,
+			Groups:            c.Groups,
+		},
+		logger: logger,
+	}, nil
+}
+
+var (
+	_ connector.CallbackConnector = (*sandboxConnector)(nil)
+	_ connector.RefreshConnector  = (*sandboxConnector)(nil)
+)
+
+type sandboxConnector struct {
+	id       string
+	identity connector.Identity
+	logger   log.Logger
+}
+
+// LoginURL returns the callback URL, as there's nothing to log in with.
+func (c *sandboxConnector) LoginURL(s connector.Scopes, callbackURL, state string) (string, error) {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return "", fmt.Errorf("sandbox: failed to parse callbackURL %q: %v", callbackURL, err)
+	}
+	v := u.Query()
+	v.Set("state", state)
+	u.RawQuery = v.Encode()
+	return u.String(), nil
+}
+
+// HandleCallback returns the configured identity.
+func (c *sandboxConnector) HandleCallback(s connector.Scopes, r *http.Request) (connector.Identity, error) {
+	c.logger.Warnf("sandbox: connector %q logging in user %q without authentication", c.id, c.identity.UserID)
+	return c.identity, nil
+}
+
+// Refresh returns the configured identity, which may have changed since the
+// login if dex was restarted with another configuration.
+func (c *sandboxConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	return c.identity, nil
+}
@@ -0,0 +1,81 @@
+package sandbox
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dexidp/dex/connector"
+)
+
+func TestOpenRequiresUnsafe(t *testing.T) {
+	c := Config{UserID: "sandbox-user"}
+	if _, err := c.Open("sandbox", logrus.New()); err == nil {
+		t.Fatal("expected an error opening the connector without unsafe")
+	}
+
+	c = Config{Unsafe: true}
+	if _, err := c.Open("sandbox", logrus.New()); err == nil {
+		t.Fatal("expected an error opening the connector without a userID")
+	}
+}
+
+func TestHandleCallback(t *testing.T) {
+	c := Config{
+		Unsafe:            true,
+		UserID:            "sandbox-user",
+		Username:          "Sandbox User",
+		PreferredUsername: "sandbox",
+		Email:             "sandbox@example.com",
+		EmailVerified:     true,
+		Groups:            []string{"testers"},
+	}
+	conn, err := c.Open("sandbox", logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sandbox := conn.(*sandboxConnector)
+
+	loginURL, err := sandbox.LoginURL(connector.Scopes{}, "https://dex.example.com/callback?connector=sandbox", "state-value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "dex.example.com" || u.Path != "/callback" {
+		t.Errorf("expected the login URL to be the callback URL, got %q", loginURL)
+	}
+	if got := u.Query().Get("state"); got != "state-value" {
+		t.Errorf("expected state %q, got %q", "state-value", got)
+	}
+
+	want := connector.Identity{
+		UserID:            "sandbox-user",
+		Username:          "Sandbox User",
+		PreferredUsername: "sandbox",
+		Email:             "sandbox@example.com",
+		EmailVerified:     true,
+		Groups:            []string{"testers"},
+	}
+	identity, err := sandbox.HandleCallback(connector.Scopes{}, httptest.NewRequest("GET", loginURL, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(identity, want) {
+		t.Errorf("expected identity %+v, got %+v", want, identity)
+	}
+
+	identity, err = sandbox.Refresh(context.Background(), connector.Scopes{}, connector.Identity{UserID: "other-user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(identity, want) {
+		t.Errorf("expected refreshed identity %+v, got %+v", want, identity)
+	}
+}
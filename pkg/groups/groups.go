@@ -16,3 +16,20 @@ func Filter(given, required []string) []string {
 	}
 	return groups
 }
+
+// Merge returns given followed by the groups of additional that are not part
+// of given yet.
+func Merge(given, additional []string) []string {
+	groups := append([]string{}, given...)
+	seen := make(map[string]struct{}, len(given))
+	for _, group := range given {
+		seen[group] = struct{}{}
+	}
+	for _, group := range additional {
+		if _, ok := seen[group]; !ok {
+			seen[group] = struct{}{}
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
@@ -24,3 +24,19 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestMerge(t *testing.T) {
+	cases := map[string]struct {
+		given, additional, expected []string
+	}{
+		"nothing additional":   {given: []string{"foo"}, additional: nil, expected: []string{"foo"}},
+		"nothing given":        {given: nil, additional: []string{"foo"}, expected: []string{"foo"}},
+		"duplicates collapsed": {given: []string{"foo", "bar"}, additional: []string{"bar", "baz", "baz"}, expected: []string{"foo", "bar", "baz"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual := groups.Merge(tc.given, tc.additional)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
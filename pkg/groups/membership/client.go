@@ -0,0 +1,123 @@
+// Package membership looks up group memberships of users in an external gRPC
+// service.
+package membership
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// defaultTimeout bounds a single group lookup unless configured otherwise.
+const defaultTimeout = 5 * time.Second
+
+// Config holds the address and mutual TLS settings of a membership service.
+type Config struct {
+	// Addr is the host:port of the membership service.
+	Addr string `json:"addr"`
+
+	// TLSCert and TLSKey are the client certificate and key dex presents to the
+	// membership service.
+	TLSCert string `json:"tlsCert"`
+	TLSKey  string `json:"tlsKey"`
+
+	// RootCA is the CA bundle used to verify the membership service. Defaults
+	// to the system roots.
+	RootCA string `json:"rootCA"`
+
+	// Timeout of a single lookup, for example "3s". Defaults to 5s.
+	Timeout string `json:"timeout"`
+}
+
+// Client queries a membership service.
+type Client struct {
+	conn    *grpc.ClientConn
+	client  MembershipClient
+	timeout time.Duration
+}
+
+// Open dials the membership service described by the config.
+func (c *Config) Open() (*Client, error) {
+	if c.Addr == "" {
+		return nil, errors.New("membership: no address specified")
+	}
+	if c.TLSCert == "" || c.TLSKey == "" {
+		return nil, errors.New("membership: a client certificate and key are required")
+	}
+
+	timeout := defaultTimeout
+	if c.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return nil, fmt.Errorf("membership: invalid timeout %q: %v", c.Timeout, err)
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("membership: invalid client certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.RootCA != "" {
+		rootCA, err := os.ReadFile(c.RootCA)
+		if err != nil {
+			return nil, fmt.Errorf("membership: failed to read root CA: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(rootCA) {
+			return nil, fmt.Errorf("membership: no certs found in root CA file %q", c.RootCA)
+		}
+	}
+
+	conn, err := grpc.Dial(c.Addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("membership: dial %s: %v", c.Addr, err)
+	}
+	return NewClient(conn, timeout), nil
+}
+
+// NewClient returns a client using an established connection to a membership
+// service. The client takes ownership of the connection.
+func NewClient(conn *grpc.ClientConn, timeout time.Duration) *Client {
+	return &Client{
+		conn:    conn,
+		client:  NewMembershipClient(conn),
+		timeout: timeout,
+	}
+}
+
+// Groups returns the groups the membership service reports for an identity
+// authenticated by the given connector.
+func (c *Client) Groups(ctx context.Context, connectorID string, identity connector.Identity) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.ListGroups(ctx, &ListGroupsReq{
+		ConnectorId: connectorID,
+		UserId:      identity.UserID,
+		Username:    identity.Username,
+		Email:       identity.Email,
+		Groups:      identity.Groups,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("membership: list groups: %v", err)
+	}
+	return resp.Groups, nil
+}
+
+// Close closes the connection to the membership service.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
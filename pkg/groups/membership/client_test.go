@@ -0,0 +1,189 @@
+package membership
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/dexidp/dex/connector"
+)
+
+type mockMembership struct {
+	UnimplementedMembershipServer
+
+	groups map[string][]string
+	reqs   chan *ListGroupsReq
+}
+
+func (m *mockMembership) ListGroups(ctx context.Context, req *ListGroupsReq) (*ListGroupsResp, error) {
+	m.reqs <- req
+	return &ListGroupsResp{Groups: m.groups[req.UserId]}, nil
+}
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+// write stores the certificate and key as PEM files and returns their paths.
+func (c *testCert) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	key, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key}), 0o600))
+	return certFile, keyFile
+}
+
+func TestClientGroups(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "membership"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	clientCert := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "dex"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	caFile, _ := ca.write(t, dir, "ca")
+	clientCertFile, clientKeyFile := clientCert.write(t, dir, "client")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert.tlsCertificate()},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})))
+	defer grpcServer.Stop()
+
+	mock := &mockMembership{
+		groups: map[string][]string{"0-385-28089-0": {"admins", "ops"}},
+		reqs:   make(chan *ListGroupsReq, 1),
+	}
+	RegisterMembershipServer(grpcServer, mock)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go grpcServer.Serve(l)
+
+	identity := connector.Identity{
+		UserID:   "0-385-28089-0",
+		Username: "Kilgore Trout",
+		Email:    "kilgore@kilgore.trout",
+		Groups:   []string{"authors"},
+	}
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		c := &Config{
+			Addr:    l.Addr().String(),
+			TLSCert: clientCertFile,
+			TLSKey:  clientKeyFile,
+			RootCA:  caFile,
+		}
+		client, err := c.Open()
+		require.NoError(t, err)
+		defer client.Close()
+
+		groups, err := client.Groups(context.Background(), "ldap", identity)
+		require.NoError(t, err)
+		require.Equal(t, []string{"admins", "ops"}, groups)
+
+		req := <-mock.reqs
+		require.Equal(t, "ldap", req.ConnectorId)
+		require.Equal(t, identity.UserID, req.UserId)
+		require.Equal(t, identity.Username, req.Username)
+		require.Equal(t, identity.Email, req.Email)
+		require.Equal(t, identity.Groups, req.Groups)
+	})
+
+	t.Run("untrusted client certificate", func(t *testing.T) {
+		untrusted := newTestCert(t, &x509.Certificate{
+			SerialNumber: big.NewInt(4),
+			Subject:      pkix.Name{CommonName: "dex"},
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}, nil)
+		certFile, keyFile := untrusted.write(t, dir, "untrusted")
+
+		c := &Config{
+			Addr:    l.Addr().String(),
+			TLSCert: certFile,
+			TLSKey:  keyFile,
+			RootCA:  caFile,
+			Timeout: "1s",
+		}
+		client, err := c.Open()
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.Groups(context.Background(), "ldap", identity)
+		require.Error(t, err)
+	})
+}
+
+func TestConfigOpen(t *testing.T) {
+	tests := map[string]Config{
+		"no address":     {TLSCert: "tls.crt", TLSKey: "tls.key"},
+		"no certificate": {Addr: "127.0.0.1:5557"},
+		"bad timeout":    {Addr: "127.0.0.1:5557", TLSCert: "tls.crt", TLSKey: "tls.key", Timeout: "soon"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Open()
+			require.Error(t, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -15,7 +16,7 @@ import (
 
 // apiVersion increases every time a new call is added to the API. Clients should use this info
 // to determine if the server supports specific features.
-const apiVersion = 2
+const apiVersion = 3
 
 const (
 	// recCost is the recommended bcrypt cost, which balances hash strength and
@@ -56,15 +57,33 @@ func (d dexAPI) CreateClient(ctx context.Context, req *api.CreateClientReq) (*ap
 	if req.Client.Secret == "" && !req.Client.Public {
 		req.Client.Secret = storage.NewID() + storage.NewID()
 	}
+	if _, err := regexp.Compile(req.Client.RedirectUriPathPattern); err != nil {
+		return nil, fmt.Errorf("invalid redirect URI path pattern: %v", err)
+	}
+	if _, err := regexp.Compile(req.Client.AllowedGroupsPattern); err != nil {
+		return nil, fmt.Errorf("invalid allowed groups pattern: %v", err)
+	}
 
 	c := storage.Client{
 		ID:           req.Client.Id,
 		Secret:       req.Client.Secret,
 		RedirectURIs: req.Client.RedirectUris,
 		TrustedPeers: req.Client.TrustedPeers,
+		Resources:    req.Client.Resources,
 		Public:       req.Client.Public,
 		Name:         req.Client.Name,
 		LogoURL:      req.Client.LogoUrl,
+		HashGroups:   req.Client.HashGroups,
+		GroupsSalt:   req.Client.GroupsSalt,
+
+		RequireVerifiedEmail:   req.Client.RequireVerifiedEmail,
+		RedirectURIPathPattern: req.Client.RedirectUriPathPattern,
+
+		ConsentText:      req.Client.ConsentText,
+		PrivacyPolicyURL: req.Client.PrivacyPolicyUrl,
+
+		AllowedGroupPrefixes: req.Client.AllowedGroupPrefixes,
+		AllowedGroupsPattern: req.Client.AllowedGroupsPattern,
 	}
 	if err := d.s.CreateClient(c); err != nil {
 		if err == storage.ErrAlreadyExists {
@@ -282,6 +301,28 @@ func (d dexAPI) VerifyPassword(ctx context.Context, req *api.VerifyPasswordReq)
 	}, nil
 }
 
+func (d dexAPI) GetLastLogin(ctx context.Context, req *api.GetLastLoginReq) (*api.GetLastLoginResp, error) {
+	id := new(internal.IDTokenSubject)
+	if err := internal.Unmarshal(req.UserId, id); err != nil {
+		d.logger.Errorf("api: failed to unmarshal ID Token subject: %v", err)
+		return nil, err
+	}
+
+	session, err := d.s.GetOfflineSessions(id.UserId, id.ConnId)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return &api.GetLastLoginResp{NotFound: true}, nil
+		}
+		d.logger.Errorf("api: failed to get offline session: %v", err)
+		return nil, err
+	}
+	if session.LastLogin.IsZero() {
+		return &api.GetLastLoginResp{NotFound: true}, nil
+	}
+
+	return &api.GetLastLoginResp{LastLogin: session.LastLogin.Unix()}, nil
+}
+
 func (d dexAPI) ListRefresh(ctx context.Context, req *api.ListRefreshReq) (*api.ListRefreshResp, error) {
 	id := new(internal.IDTokenSubject)
 	if err := internal.Unmarshal(req.UserId, id); err != nil {
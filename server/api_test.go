@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -341,6 +342,107 @@ func TestRefreshToken(t *testing.T) {
 	}
 }
 
+func TestGetLastLogin(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	s := memory.New(logger)
+	client := newAPI(s, logger, t)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	lastLogin := time.Now().UTC().Round(time.Second)
+	sessions := []storage.OfflineSessions{
+		{UserID: "1", ConnID: "ldap", Refresh: map[string]*storage.RefreshTokenRef{}, LastLogin: lastLogin},
+		{UserID: "1", ConnID: "github", Refresh: map[string]*storage.RefreshTokenRef{}},
+	}
+	for _, session := range sessions {
+		if err := s.CreateOfflineSessions(session); err != nil {
+			t.Fatalf("create offline session: %v", err)
+		}
+	}
+
+	subject := func(userID, connID string) string {
+		sub, err := internal.Marshal(&internal.IDTokenSubject{UserId: userID, ConnId: connID})
+		if err != nil {
+			t.Fatalf("failed to marshal subject: %v", err)
+		}
+		return sub
+	}
+
+	resp, err := client.GetLastLogin(ctx, &api.GetLastLoginReq{UserId: subject("1", "ldap")})
+	if err != nil {
+		t.Fatalf("Unable to get last login: %v", err)
+	}
+	if resp.NotFound || resp.LastLogin != lastLogin.Unix() {
+		t.Errorf("Expected last login %d, got %d (not found: %t)", lastLogin.Unix(), resp.LastLogin, resp.NotFound)
+	}
+
+	for _, connID := range []string{"github", "saml"} {
+		resp, err := client.GetLastLogin(ctx, &api.GetLastLoginReq{UserId: subject("1", connID)})
+		if err != nil {
+			t.Fatalf("Unable to get last login: %v", err)
+		}
+		if !resp.NotFound {
+			t.Errorf("Expected no last login through %q, got %d", connID, resp.LastLogin)
+		}
+	}
+}
+
+func TestCreateClient(t *testing.T) {
+	logger := &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: &logrus.TextFormatter{DisableColors: true},
+		Level:     logrus.DebugLevel,
+	}
+
+	s := memory.New(logger)
+	client := newAPI(s, logger, t)
+	defer client.Close()
+	ctx := context.Background()
+
+	_, err := client.CreateClient(ctx, &api.CreateClientReq{
+		Client: &api.Client{
+			Id:         "hashed",
+			Public:     true,
+			HashGroups: true,
+			GroupsSalt: "salt",
+			Resources:  []string{"https://api.example.com"},
+
+			AllowedGroupPrefixes: []string{"tenant-a:"},
+			AllowedGroupsPattern: "admins",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to create the client: %v", err)
+	}
+
+	stored, err := s.GetClient("hashed")
+	if err != nil {
+		t.Fatalf("unable to get the client: %v", err)
+	}
+	if !stored.HashGroups || stored.GroupsSalt != "salt" {
+		t.Errorf("expected group hashing settings to be stored, got %+v", stored)
+	}
+	if !reflect.DeepEqual(stored.Resources, []string{"https://api.example.com"}) {
+		t.Errorf("expected resources to be stored, got %v", stored.Resources)
+	}
+	if !reflect.DeepEqual(stored.AllowedGroupPrefixes, []string{"tenant-a:"}) || stored.AllowedGroupsPattern != "admins" {
+		t.Errorf("expected allowed groups to be stored, got %+v", stored)
+	}
+
+	_, err = client.CreateClient(ctx, &api.CreateClientReq{
+		Client: &api.Client{Id: "invalid", Public: true, AllowedGroupsPattern: "("},
+	})
+	if err == nil {
+		t.Errorf("expected an invalid allowed groups pattern to be rejected")
+	}
+}
+
 func TestUpdateClient(t *testing.T) {
 	logger := &logrus.Logger{
 		Out:       os.Stderr,
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/server/internal"
+)
+
+// DenyList blocks the logins and refreshes of users by their subject or email,
+// for example to lock out a compromised account without changing the upstream
+// identity provider.
+//
+// It's safe for concurrent use. Load replaces the entries of a running server.
+type DenyList struct {
+	mu       sync.RWMutex
+	subjects map[string]bool
+	emails   map[string]bool
+}
+
+// NewDenyList returns a deny list for the given subjects, as found in the "sub"
+// claim of the ID tokens, and emails.
+func NewDenyList(subjects, emails []string) *DenyList {
+	d := new(DenyList)
+	d.Load(subjects, emails)
+	return d
+}
+
+// Load replaces the denied subjects and emails.
+func (d *DenyList) Load(subjects, emails []string) {
+	subjectSet := make(map[string]bool, len(subjects))
+	for _, subject := range subjects {
+		subjectSet[subject] = true
+	}
+	emailSet := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		emailSet[strings.ToLower(email)] = true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subjects = subjectSet
+	d.emails = emailSet
+}
+
+// denies reports whether the identity logged in through the connector is
+// denied. Emails are compared case-insensitively.
+func (d *DenyList) denies(connID string, identity connector.Identity) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if identity.Email != "" && d.emails[strings.ToLower(identity.Email)] {
+		return true
+	}
+	if len(d.subjects) == 0 {
+		return false
+	}
+	subject, err := internal.Marshal(&internal.IDTokenSubject{
+		UserId: identity.UserID,
+		ConnId: connID,
+	})
+	return err == nil && d.subjects[subject]
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/server/internal"
+	"github.com/dexidp/dex/storage"
+)
+
+func TestDenyList(t *testing.T) {
+	subject, err := internal.Marshal(&internal.IDTokenSubject{UserId: "jane", ConnId: "ldap"})
+	require.NoError(t, err)
+
+	jane := connector.Identity{UserID: "jane", Email: "jane@example.com"}
+	john := connector.Identity{UserID: "john", Email: "John@Example.com"}
+
+	d := NewDenyList([]string{subject}, nil)
+	require.True(t, d.denies("ldap", jane))
+	require.False(t, d.denies("github", jane), "subjects are specific to the connector")
+	require.False(t, d.denies("ldap", john))
+
+	d.Load(nil, []string{"john@example.com"})
+	require.False(t, d.denies("ldap", jane), "reloading replaces the previous entries")
+	require.True(t, d.denies("ldap", john))
+	require.True(t, d.denies("github", john))
+
+	var none *DenyList
+	require.False(t, none.denies("ldap", jane))
+}
+
+func TestDenyListLogin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	denyList := NewDenyList(nil, nil)
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.DenyList = denyList
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	login := func() int {
+		authReq := storage.AuthRequest{
+			ID:          storage.NewID(),
+			ClientID:    "test",
+			ConnectorID: "mock",
+			RedirectURI: "https://client.example.com/callback",
+			State:       "state",
+			Scopes:      []string{"openid"},
+			Expiry:      time.Now().Add(time.Minute),
+		}
+		require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, httptest.NewRequest("GET", "/callback/mock?state="+authReq.ID, nil))
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusSeeOther, login())
+
+	denyList.Load(nil, []string{"kilgore@kilgore.trout"})
+	require.Equal(t, http.StatusForbidden, login())
+
+	denyList.Load(nil, []string{"someone@example.com"})
+	require.Equal(t, http.StatusSeeOther, login())
+}
@@ -1,15 +1,18 @@
 package server
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/storage"
@@ -30,6 +33,93 @@ type deviceCodeResponse struct {
 	PollInterval int `json:"interval"`
 }
 
+// Defaults of the user code format, matching storage.NewUserCode.
+const (
+	defaultUserCodeLength    = 8
+	defaultUserCodeCharset   = "BCDFGHJKLMNPQRSTVWXZ"
+	defaultUserCodeGroupSize = 4
+)
+
+// UserCodeConfig describes the format of the user codes of the device flow.
+type UserCodeConfig struct {
+	// Length is the number of random characters. Defaults to 8.
+	Length int
+
+	// Charset holds the characters the code is made of. Users may enter codes
+	// in lower case, so it must not contain lower case letters. Defaults to
+	// upper case consonants.
+	Charset string
+
+	// ExcludedCharacters are removed from the charset, for example "0O1I" to
+	// avoid characters which are easily confused.
+	ExcludedCharacters string
+
+	// GroupSize is the number of characters between dashes, for example 4 for
+	// "XXXX-XXXX". Defaults to 4, a size of Length or more disables grouping.
+	GroupSize int
+}
+
+type userCodeFormat struct {
+	length    int
+	charset   string
+	groupSize int
+}
+
+func (c UserCodeConfig) format() (userCodeFormat, error) {
+	f := userCodeFormat{
+		length:    c.Length,
+		charset:   c.Charset,
+		groupSize: c.GroupSize,
+	}
+	if f.length < 0 || f.groupSize < 0 {
+		return f, errors.New("length and group size must not be negative")
+	}
+	if f.length == 0 {
+		f.length = defaultUserCodeLength
+	}
+	if f.charset == "" {
+		f.charset = defaultUserCodeCharset
+	}
+	if f.groupSize == 0 {
+		f.groupSize = defaultUserCodeGroupSize
+	}
+
+	var charset []rune
+	for _, r := range f.charset {
+		if strings.ContainsRune(c.ExcludedCharacters, r) || strings.ContainsRune(string(charset), r) {
+			continue
+		}
+		if r == '-' || unicode.IsSpace(r) || r != unicode.ToUpper(r) {
+			return f, fmt.Errorf("charset must not contain %q", r)
+		}
+		charset = append(charset, r)
+	}
+	if len(charset) < 2 {
+		return f, errors.New("charset must contain at least two characters which are not excluded")
+	}
+	f.charset = string(charset)
+	return f, nil
+}
+
+// newUserCode returns a random user code in the format.
+func (f userCodeFormat) newUserCode() (string, error) {
+	charset := []rune(f.charset)
+	max := big.NewInt(int64(len(charset)))
+
+	var b strings.Builder
+	for i := 0; i < f.length; i++ {
+		if i > 0 && i%f.groupSize == 0 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteRune(charset[n.Int64()])
+	}
+	return b.String(), nil
+}
+
 func (s *Server) getDeviceVerificationURI() string {
 	return path.Join(s.issuerURL.Path, "/device/auth/verify_code")
 }
@@ -78,7 +168,12 @@ func (s *Server) handleDeviceCode(w http.ResponseWriter, r *http.Request) {
 		deviceCode := storage.NewDeviceCode()
 
 		// make user code
-		userCode := storage.NewUserCode()
+		userCode, err := s.userCodeFormat.newUserCode()
+		if err != nil {
+			s.logger.Errorf("Failed to generate user code: %v", err)
+			s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+			return
+		}
 
 		// Generate the expire time
 		expireTime := time.Now().Add(s.deviceRequestsValidFor)
@@ -184,6 +279,13 @@ func (s *Server) handleDeviceToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Device flow tokens are minted when the user approves the request, before
+	// the device asks for them, so resource indicators (RFC 8707) can't be honored.
+	if len(r.PostForm["resource"]) != 0 {
+		s.tokenErrHelper(w, errInvalidTarget, "Resource indicators are not supported for the device code grant.", http.StatusBadRequest)
+		return
+	}
+
 	now := s.now()
 
 	// Grab the device token, check validity
@@ -289,7 +391,7 @@ func (s *Server) handleDeviceCallback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		resp, err := s.exchangeAuthCode(w, authCode, client)
+		resp, err := s.exchangeAuthCode(w, authCode, client, nil)
 		if err != nil {
 			s.logger.Errorf("Could not exchange auth code for client %q: %v", deviceReq.ClientID, err)
 			s.renderError(r, w, http.StatusInternalServerError, "Failed to exchange auth code.")
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -676,3 +677,65 @@ func TestVerifyCodeResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestUserCodeFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   UserCodeConfig
+		pattern  string
+		excluded string
+	}{
+		{
+			name:    "default",
+			pattern: `^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`,
+		},
+		{
+			name:     "custom charset with exclusions",
+			config:   UserCodeConfig{Length: 9, Charset: "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", ExcludedCharacters: "0O1I5S", GroupSize: 3},
+			pattern:  `^[A-Z0-9]{3}-[A-Z0-9]{3}-[A-Z0-9]{3}$`,
+			excluded: "0O1I5S",
+		},
+		{
+			name:    "no grouping",
+			config:  UserCodeConfig{Length: 6, Charset: "23456789", GroupSize: 6},
+			pattern: `^[2-9]{6}$`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := tc.config.format()
+			if err != nil {
+				t.Fatalf("invalid format: %v", err)
+			}
+			re := regexp.MustCompile(tc.pattern)
+			for i := 0; i < 100; i++ {
+				code, err := f.newUserCode()
+				if err != nil {
+					t.Fatalf("failed to generate user code: %v", err)
+				}
+				if !re.MatchString(code) {
+					t.Fatalf("user code %q does not match %s", code, tc.pattern)
+				}
+				if strings.ContainsAny(code, tc.excluded) {
+					t.Fatalf("user code %q contains one of the excluded characters %q", code, tc.excluded)
+				}
+			}
+		})
+	}
+}
+
+func TestUserCodeFormatInvalid(t *testing.T) {
+	tests := map[string]UserCodeConfig{
+		"negative length":     {Length: -1},
+		"lower case charset":  {Charset: "abcdef"},
+		"dash in charset":     {Charset: "ABC-"},
+		"everything excluded": {Charset: "AB", ExcludedCharacters: "B"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := c.format(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package server
 
 import (
+	"context"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -75,6 +77,8 @@ type discovery struct {
 	Keys              string   `json:"jwks_uri"`
 	UserInfo          string   `json:"userinfo_endpoint"`
 	DeviceEndpoint    string   `json:"device_authorization_endpoint"`
+	EndSession        string   `json:"end_session_endpoint"`
+	PushedAuth        string   `json:"pushed_authorization_request_endpoint"`
 	GrantTypes        []string `json:"grant_types_supported"`
 	ResponseTypes     []string `json:"response_types_supported"`
 	Subjects          []string `json:"subject_types_supported"`
@@ -83,6 +87,9 @@ type discovery struct {
 	Scopes            []string `json:"scopes_supported"`
 	AuthMethods       []string `json:"token_endpoint_auth_methods_supported"`
 	Claims            []string `json:"claims_supported"`
+
+	RequestParameter    bool `json:"request_parameter_supported"`
+	RequestURIParameter bool `json:"request_uri_parameter_supported"`
 }
 
 func (s *Server) discoveryHandler() (http.HandlerFunc, error) {
@@ -93,15 +100,21 @@ func (s *Server) discoveryHandler() (http.HandlerFunc, error) {
 		Keys:              s.absURL("/keys"),
 		UserInfo:          s.absURL("/userinfo"),
 		DeviceEndpoint:    s.absURL("/device/code"),
+		EndSession:        s.absURL("/logout"),
+		PushedAuth:        s.absURL("/par"),
 		Subjects:          []string{"public"},
 		IDTokenAlgs:       []string{string(jose.RS256)},
 		CodeChallengeAlgs: []string{codeChallengeMethodS256, codeChallengeMethodPlain},
-		Scopes:            []string{"openid", "email", "groups", "profile", "offline_access"},
+		Scopes:            []string{"openid", "email", "groups", "organizations", "address", "profile", "offline_access"},
 		AuthMethods:       []string{"client_secret_basic", "client_secret_post"},
 		Claims: []string{
 			"iss", "sub", "aud", "iat", "exp", "email", "email_verified",
 			"locale", "name", "preferred_username", "at_hash",
 		},
+		RequestURIParameter: len(s.requestURIPrefixes) > 0,
+	}
+	if len(s.languageMatchers) > 0 {
+		d.Claims = append(d.Claims, preferredLanguageClaim)
 	}
 
 	for responseType := range s.supportedResponseTypes {
@@ -185,7 +198,7 @@ func (s *Server) handleAuthorization(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
-	authReq, err := s.parseAuthorizationRequest(r)
+	authReq, params, err := s.resolveAuthorizationRequest(r)
 	if err != nil {
 		s.logger.Errorf("Failed to parse authorization request: %v", err)
 
@@ -227,14 +240,16 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	scopes := parseScopes(authReq.Scopes)
+	scopes := connectorScopes(*authReq)
 
-	// Work out where the "Select another login method" link should go.
+	// Work out where the "Select another login method" link should go. A
+	// pushed authorization request is used up by now, so the link carries its
+	// parameters rather than its request_uri.
 	backLink := ""
 	if len(s.connectors) > 1 {
 		backLinkURL := url.URL{
 			Path:     s.absPath("/auth"),
-			RawQuery: r.Form.Encode(),
+			RawQuery: params.Encode(),
 		}
 		backLink = backLinkURL.String()
 	}
@@ -243,15 +258,28 @@ func (s *Server) handleConnectorLogin(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		switch conn := conn.Connector.(type) {
 		case connector.CallbackConnector:
-			// Use the auth request ID as the "state" token.
-			//
-			// TODO(ericchiang): Is this appropriate or should we also be using a nonce?
-			callbackURL, err := conn.LoginURL(scopes, s.absURL("/callback"), authReq.ID)
+			// Use the auth request ID as the "state" token. The auth request
+			// is kept in storage until the callback, so connectors may derive
+			// a nonce from the state and check it on any instance.
+			callbackURL, connData, err := connectorLoginURL(conn, scopes, s.absURL("/callback"), authReq.ID, loginHints(params))
 			if err != nil {
 				s.logger.Errorf("Connector %q returned error when creating callback: %v", connID, err)
 				s.renderError(r, w, http.StatusInternalServerError, "Login error.")
 				return
 			}
+			// The callback may be handled by another instance, so keep the
+			// connector's data with the auth request rather than in memory.
+			if len(connData) > 0 {
+				updater := func(a storage.AuthRequest) (storage.AuthRequest, error) {
+					a.ConnectorData = connData
+					return a, nil
+				}
+				if err := s.storage.UpdateAuthRequest(authReq.ID, updater); err != nil {
+					s.logger.Errorf("Failed to update auth request: %v", err)
+					s.renderError(r, w, http.StatusInternalServerError, "Failed to connect to the database.")
+					return
+				}
+			}
 			http.Redirect(w, r, callbackURL, http.StatusFound)
 		case connector.PasswordConnector:
 			loginURL := url.URL{
@@ -350,7 +378,7 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 		identity, ok, err := pwConn.Login(r.Context(), scopes, username, password)
 		if err != nil {
 			s.logger.Errorf("Failed to login user: %v", err)
-			s.renderError(r, w, http.StatusInternalServerError, fmt.Sprintf("Login error: %v", err))
+			s.renderAuthError(r, w, authReq, http.StatusInternalServerError, errServerError, fmt.Sprintf("Login error: %v", err))
 			return
 		}
 		if !ok {
@@ -359,10 +387,19 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 			}
 			return
 		}
-		redirectURL, err := s.finalizeLogin(identity, authReq, conn.Connector)
+		identity = s.withPreferredLanguage(authReq.ConnectorID, identity, s.negotiateLanguage(authReq.ConnectorID, r))
+		redirectURL, err := s.finalizeLogin(r.Context(), identity, authReq, conn.Connector)
 		if err != nil {
 			s.logger.Errorf("Failed to finalize login: %v", err)
-			s.renderError(r, w, http.StatusInternalServerError, "Login error.")
+			switch authErr := err.(type) {
+			case *displayedAuthErr:
+				s.renderAuthError(r, w, authReq, authErr.Status, errAccessDenied, authErr.Description)
+				return
+			case *redirectedAuthErr:
+				authErr.Handler().ServeHTTP(w, r)
+				return
+			}
+			s.renderAuthError(r, w, authReq, http.StatusInternalServerError, errServerError, "Login error.")
 			return
 		}
 
@@ -372,23 +409,55 @@ func (s *Server) handlePasswordLogin(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// loginHints returns the parameters of the authorization request forwarded to
+// connectors adding them to their login URL.
+func loginHints(q url.Values) map[string]string {
+	hints := make(map[string]string)
+	if loginHint := q.Get("login_hint"); loginHint != "" {
+		hints["login_hint"] = loginHint
+	}
+	return hints
+}
+
+// connectorLoginURL returns the login URL of the callback connector, and the
+// data it needs to handle the callback, if any.
+func connectorLoginURL(conn connector.CallbackConnector, scopes connector.Scopes, callbackURL, state string, hints map[string]string) (string, []byte, error) {
+	if conn, ok := conn.(connector.HintedCallbackConnector); ok && len(hints) > 0 {
+		return conn.LoginURLWithHints(scopes, callbackURL, state, hints)
+	}
+	if conn, ok := conn.(connector.StatefulCallbackConnector); ok {
+		return conn.LoginURLWithData(scopes, callbackURL, state)
+	}
+	loginURL, err := conn.LoginURL(scopes, callbackURL, state)
+	return loginURL, nil, err
+}
+
 func (s *Server) handleConnectorCallback(w http.ResponseWriter, r *http.Request) {
-	var authID string
+	var (
+		authID string
+		// OAuth2 callbacks are usually redirects, but providers may post
+		// them with response_mode=form_post.
+		oauth2Callback bool
+	)
 	switch r.Method {
 	case http.MethodGet: // OAuth2 callback
-		if authID = r.URL.Query().Get("state"); authID == "" {
-			s.renderError(r, w, http.StatusBadRequest, "User session error.")
-			return
-		}
-	case http.MethodPost: // SAML POST binding
+		authID, oauth2Callback = r.URL.Query().Get("state"), true
+	case http.MethodPost: // SAML POST binding, or OAuth2 form post
 		if authID = r.PostFormValue("RelayState"); authID == "" {
-			s.renderError(r, w, http.StatusBadRequest, "User session error.")
-			return
+			authID, oauth2Callback = r.PostFormValue("state"), true
 		}
 	default:
 		s.renderError(r, w, http.StatusBadRequest, "Method not supported")
 		return
 	}
+	if authID == "" {
+		s.renderError(r, w, http.StatusBadRequest, "User session error.")
+		return
+	}
+	// Drop the data the connector appended to the state.
+	if i := strings.Index(authID, connector.StateSeparator); oauth2Callback && i >= 0 {
+		authID = authID[:i]
+	}
 
 	authReq, err := s.storage.GetAuthRequest(authID)
 	if err != nil {
@@ -417,15 +486,22 @@ func (s *Server) handleConnectorCallback(w http.ResponseWriter, r *http.Request)
 
 	var identity connector.Identity
 	switch conn := conn.Connector.(type) {
+	case connector.StatefulCallbackConnector:
+		if !oauth2Callback {
+			s.logger.Errorf("SAML request mapped to OAuth2 connector")
+			s.renderError(r, w, http.StatusBadRequest, "Invalid request")
+			return
+		}
+		identity, err = conn.HandleCallbackWithData(connectorScopes(authReq), authReq.ConnectorData, r)
 	case connector.CallbackConnector:
-		if r.Method != http.MethodGet {
+		if !oauth2Callback {
 			s.logger.Errorf("SAML request mapped to OAuth2 connector")
 			s.renderError(r, w, http.StatusBadRequest, "Invalid request")
 			return
 		}
-		identity, err = conn.HandleCallback(parseScopes(authReq.Scopes), r)
+		identity, err = conn.HandleCallback(connectorScopes(authReq), r)
 	case connector.SAMLConnector:
-		if r.Method != http.MethodPost {
+		if oauth2Callback {
 			s.logger.Errorf("OAuth2 request mapped to SAML connector")
 			s.renderError(r, w, http.StatusBadRequest, "Invalid request")
 			return
@@ -437,24 +513,94 @@ func (s *Server) handleConnectorCallback(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err != nil {
+		// A client asking for a silent login handles the error itself,
+		// usually by retrying with an interactive login.
+		var interactionErr *connector.InteractionRequiredError
+		if errors.As(err, &interactionErr) && hasPrompt(authReq.Prompt, promptNone) {
+			s.logger.Infof("Silent login through connector %q failed: %v", authReq.ConnectorID, err)
+			authErr := &redirectedAuthErr{
+				State:       authReq.State,
+				RedirectURI: authReq.RedirectURI,
+				Type:        interactionErr.Code,
+				Description: interactionErr.Description,
+			}
+			authErr.Handler().ServeHTTP(w, r)
+			return
+		}
 		s.logger.Errorf("Failed to authenticate: %v", err)
-		s.renderError(r, w, http.StatusInternalServerError, fmt.Sprintf("Failed to authenticate: %v", err))
+		s.renderAuthError(r, w, authReq, http.StatusInternalServerError, errAccessDenied, fmt.Sprintf("Failed to authenticate: %v", err))
 		return
 	}
 
-	redirectURL, err := s.finalizeLogin(identity, authReq, conn.Connector)
+	identity = s.withPreferredLanguage(authReq.ConnectorID, identity, s.negotiateLanguage(authReq.ConnectorID, r))
+	redirectURL, err := s.finalizeLogin(r.Context(), identity, authReq, conn.Connector)
 	if err != nil {
 		s.logger.Errorf("Failed to finalize login: %v", err)
-		s.renderError(r, w, http.StatusInternalServerError, "Login error.")
+		switch authErr := err.(type) {
+		case *displayedAuthErr:
+			s.renderAuthError(r, w, authReq, authErr.Status, errAccessDenied, authErr.Description)
+			return
+		case *redirectedAuthErr:
+			authErr.Handler().ServeHTTP(w, r)
+			return
+		}
+		s.renderAuthError(r, w, authReq, http.StatusInternalServerError, errServerError, "Login error.")
 		return
 	}
 
 	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
 }
 
+// handleSAMLMetadata serves the metadata describing dex as a service provider
+// of a SAML connector, for admins of the identity provider to import.
+func (s *Server) handleSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	connID := mux.Vars(r)["connector"]
+	conn, err := s.getConnector(connID)
+	if err != nil {
+		s.logger.Errorf("Failed to get connector with id %q : %v", connID, err)
+		s.renderError(r, w, http.StatusNotFound, "Requested resource does not exist.")
+		return
+	}
+	metadataConn, ok := conn.Connector.(connector.SAMLMetadataConnector)
+	if !ok {
+		s.renderError(r, w, http.StatusNotFound, "Requested resource does not exist.")
+		return
+	}
+
+	data, err := metadataConn.Metadata(s.absURL("/logout/callback", connID))
+	if err != nil {
+		s.logger.Errorf("Failed to create metadata of connector %q: %v", connID, err)
+		s.renderError(r, w, http.StatusInternalServerError, "Internal server error.")
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(data)
+}
+
 // finalizeLogin associates the user's identity with the current AuthRequest, then returns
-// the approval page's path.
-func (s *Server) finalizeLogin(identity connector.Identity, authReq storage.AuthRequest, conn connector.Connector) (string, error) {
+// the approval page's path. Logins denied by the client's policy return a
+// *displayedAuthErr.
+func (s *Server) finalizeLogin(ctx context.Context, identity connector.Identity, authReq storage.AuthRequest, conn connector.Connector) (string, error) {
+	client, err := s.storage.GetClient(authReq.ClientID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get client %q: %v", authReq.ClientID, err)
+	}
+	if client.RequireVerifiedEmail && !identity.EmailVerified {
+		return "", newDisplayedErr(http.StatusForbidden, "This application requires a verified email address.")
+	}
+	if s.denyList.denies(authReq.ConnectorID, identity) {
+		s.logger.Infof("denied login of user %q through connector %q", identity.UserID, authReq.ConnectorID)
+		return "", newDisplayedErr(http.StatusForbidden, "Your account has been blocked. Contact your administrator.")
+	}
+
+	if parseScopes(authReq.Scopes).Groups {
+		if identity, err = s.withMembershipGroups(ctx, authReq.ConnectorID, identity); err != nil {
+			return "", fmt.Errorf("failed to get groups from membership service: %v", err)
+		}
+	}
+	identity = s.filterGroups(identity)
+	s.warnOnGroupCount(authReq.ConnectorID, identity)
+
 	claims := storage.Claims{
 		UserID:            identity.UserID,
 		Username:          identity.Username,
@@ -462,6 +608,22 @@ func (s *Server) finalizeLogin(identity connector.Identity, authReq storage.Auth
 		Email:             identity.Email,
 		EmailVerified:     identity.EmailVerified,
 		Groups:            identity.Groups,
+		Organizations:     identity.Organizations,
+		Address:           storageAddress(identity.Address),
+		CustomClaims:      identity.CustomClaims,
+		AuthTime:          identity.AuthTime,
+	}
+	if claims.AuthTime.IsZero() {
+		claims.AuthTime = s.now()
+	}
+
+	if missing := missingEssentialClaims(authReq.EssentialClaims, authReq.Scopes, claims); len(missing) > 0 {
+		return "", &redirectedAuthErr{
+			State:       authReq.State,
+			RedirectURI: authReq.RedirectURI,
+			Type:        errInteractionRequired,
+			Description: "Essential claims are not available: " + strings.Join(missing, ", "),
+		}
 	}
 
 	updater := func(a storage.AuthRequest) (storage.AuthRequest, error) {
@@ -482,6 +644,12 @@ func (s *Server) finalizeLogin(identity connector.Identity, authReq storage.Auth
 	s.logger.Infof("login successful: connector %q, username=%q, preferred_username=%q, email=%q, groups=%q",
 		authReq.ConnectorID, claims.Username, claims.PreferredUsername, email, claims.Groups)
 
+	if s.trackLastLogin {
+		if err := s.recordLastLogin(identity.UserID, authReq.ConnectorID); err != nil {
+			s.logger.Errorf("failed to record last login: %v", err)
+		}
+	}
+
 	returnURL := path.Join(s.issuerURL.Path, "/approval") + "?req=" + authReq.ID
 	_, ok := conn.(connector.RefreshConnector)
 	if !ok {
@@ -526,6 +694,32 @@ func (s *Server) finalizeLogin(identity connector.Identity, authReq storage.Auth
 	return returnURL, nil
 }
 
+// recordLastLogin stores the current time as last login in the offline
+// session of the user and connector, creating the session if necessary.
+func (s *Server) recordLastLogin(userID, connID string) error {
+	now := s.now()
+	updater := func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+		old.LastLogin = now
+		return old, nil
+	}
+
+	err := s.storage.UpdateOfflineSessions(userID, connID, updater)
+	if err != storage.ErrNotFound {
+		return err
+	}
+	err = s.storage.CreateOfflineSessions(storage.OfflineSessions{
+		UserID:    userID,
+		ConnID:    connID,
+		Refresh:   make(map[string]*storage.RefreshTokenRef),
+		LastLogin: now,
+	})
+	if err == storage.ErrAlreadyExists {
+		// Another login of the user created the session meanwhile.
+		return s.storage.UpdateOfflineSessions(userID, connID, updater)
+	}
+	return err
+}
+
 func (s *Server) handleApproval(w http.ResponseWriter, r *http.Request) {
 	authReq, err := s.storage.GetAuthRequest(r.FormValue("req"))
 	if err != nil {
@@ -551,12 +745,12 @@ func (s *Server) handleApproval(w http.ResponseWriter, r *http.Request) {
 			s.renderError(r, w, http.StatusInternalServerError, "Failed to retrieve client.")
 			return
 		}
-		if err := s.templates.approval(r, w, authReq.ID, authReq.Claims.Username, client.Name, authReq.Scopes); err != nil {
+		if err := s.templates.approval(r, w, authReq.ID, authReq.Claims.Username, client.Name, client.ConsentText, client.PrivacyPolicyURL, authReq.Scopes); err != nil {
 			s.logger.Errorf("Server template error: %v", err)
 		}
 	case http.MethodPost:
 		if r.FormValue("approval") != "approve" {
-			s.renderError(r, w, http.StatusInternalServerError, "Approval rejected.")
+			s.renderAuthError(r, w, authReq, http.StatusInternalServerError, errAccessDenied, "Approval rejected.")
 			return
 		}
 		s.sendCodeResponse(w, r, authReq)
@@ -610,6 +804,7 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 				ConnectorID:   authReq.ConnectorID,
 				Nonce:         authReq.Nonce,
 				Scopes:        authReq.Scopes,
+				Resources:     authReq.Resources,
 				Claims:        authReq.Claims,
 				Expiry:        s.now().Add(time.Minute * 30),
 				RedirectURI:   authReq.RedirectURI,
@@ -634,16 +829,22 @@ func (s *Server) sendCodeResponse(w http.ResponseWriter, r *http.Request, authRe
 			implicitOrHybrid = true
 		case responseTypeIDToken:
 			implicitOrHybrid = true
-			var err error
 
-			accessToken, err = s.newAccessToken(authReq.ClientID, authReq.Claims, authReq.Scopes, authReq.Nonce, authReq.ConnectorID)
+			client, err := s.storage.GetClient(authReq.ClientID)
+			if err != nil {
+				s.logger.Errorf("Failed to get client %q: %v", authReq.ClientID, err)
+				s.renderError(r, w, http.StatusInternalServerError, "Internal server error.")
+				return
+			}
+
+			accessToken, err = s.newAccessToken(client, authReq.Claims, authReq.Scopes, authReq.Resources, authReq.Nonce, authReq.ConnectorID)
 			if err != nil {
 				s.logger.Errorf("failed to create new access token: %v", err)
 				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 				return
 			}
 
-			idToken, idTokenExpiry, err = s.newIDToken(authReq.ClientID, authReq.Claims, authReq.Scopes, authReq.Nonce, accessToken, code.ID, authReq.ConnectorID)
+			idToken, idTokenExpiry, err = s.newIDToken(client, authReq.Claims, authReq.Scopes, authReq.Nonce, accessToken, code.ID, authReq.ConnectorID)
 			if err != nil {
 				s.logger.Errorf("failed to create ID token: %v", err)
 				s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -834,7 +1035,13 @@ func (s *Server) handleAuthCode(w http.ResponseWriter, r *http.Request, client s
 		return
 	}
 
-	tokenResponse, err := s.exchangeAuthCode(w, authCode, client)
+	resources, err := authCodeResources(r, client, &authCode)
+	if err != nil {
+		s.tokenErrHelper(w, errInvalidTarget, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tokenResponse, err := s.exchangeAuthCode(w, authCode, client, resources)
 	if err != nil {
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 		return
@@ -842,15 +1049,30 @@ func (s *Server) handleAuthCode(w http.ResponseWriter, r *http.Request, client s
 	s.writeAccessToken(w, tokenResponse)
 }
 
-func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCode, client storage.Client) (*accessTokenResponse, error) {
-	accessToken, err := s.newAccessToken(client.ID, authCode.Claims, authCode.Scopes, authCode.Nonce, authCode.ConnectorID)
+// authCodeResources returns the resources an authorization code is exchanged
+// for. If the authorization request didn't name any resource, the resources of
+// the token request become the grant passed on to refresh tokens.
+func authCodeResources(r *http.Request, client storage.Client, authCode *storage.AuthCode) ([]string, error) {
+	resources, err := parseResources(r, client)
+	if err != nil {
+		return nil, err
+	}
+	if len(authCode.Resources) == 0 {
+		authCode.Resources = resources
+		return resources, nil
+	}
+	return narrowResources(resources, authCode.Resources)
+}
+
+func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCode, client storage.Client, resources []string) (*accessTokenResponse, error) {
+	accessToken, err := s.newAccessToken(client, authCode.Claims, authCode.Scopes, resources, authCode.Nonce, authCode.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create new access token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 		return nil, err
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, authCode.Claims, authCode.Scopes, authCode.Nonce, accessToken, authCode.ID, authCode.ConnectorID)
+	idToken, expiry, err := s.newIDToken(client, authCode.Claims, authCode.Scopes, authCode.Nonce, accessToken, authCode.ID, authCode.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create ID token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -894,6 +1116,7 @@ func (s *Server) exchangeAuthCode(w http.ResponseWriter, authCode storage.AuthCo
 			ClientID:      authCode.ClientID,
 			ConnectorID:   authCode.ConnectorID,
 			Scopes:        authCode.Scopes,
+			Resources:     authCode.Resources,
 			Claims:        authCode.Claims,
 			Nonce:         authCode.Nonce,
 			ConnectorData: authCode.ConnectorData,
@@ -1037,7 +1260,7 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		switch scope {
 		case scopeOpenID:
 			hasOpenIDScope = true
-		case scopeOfflineAccess, scopeEmail, scopeProfile, scopeGroups, scopeFederatedID:
+		case scopeOfflineAccess, scopeEmail, scopeProfile, scopeGroups, scopeOrganizations, scopeAddress, scopeFederatedID:
 		default:
 			peerID, ok := parseCrossClientScope(scope)
 			if !ok {
@@ -1068,6 +1291,12 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		return
 	}
 
+	resources, err := parseResources(r, client)
+	if err != nil {
+		s.tokenErrHelper(w, errInvalidTarget, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Which connector
 	connID := s.passwordConnector
 	conn, err := s.getConnector(connID)
@@ -1095,6 +1324,32 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		s.tokenErrHelper(w, errAccessDenied, "Invalid username or password", http.StatusUnauthorized)
 		return
 	}
+	if client.RequireVerifiedEmail && !identity.EmailVerified {
+		s.tokenErrHelper(w, errAccessDenied, "Client requires a verified email address", http.StatusForbidden)
+		return
+	}
+	if s.denyList.denies(connID, identity) {
+		s.logger.Infof("denied login of user %q through connector %q", identity.UserID, connID)
+		s.tokenErrHelper(w, errAccessDenied, "User is blocked", http.StatusForbidden)
+		return
+	}
+	if parseScopes(scopes).Groups {
+		identity, err = s.withMembershipGroups(r.Context(), connID, identity)
+		if err != nil {
+			s.logger.Errorf("Failed to get groups from membership service: %v", err)
+			s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+			return
+		}
+	}
+	identity = s.filterGroups(identity)
+	s.warnOnGroupCount(connID, identity)
+	identity = s.withPreferredLanguage(connID, identity, s.negotiateLanguage(connID, r))
+
+	if s.trackLastLogin {
+		if err := s.recordLastLogin(identity.UserID, connID); err != nil {
+			s.logger.Errorf("failed to record last login: %v", err)
+		}
+	}
 
 	// Build the claims to send the id token
 	claims := storage.Claims{
@@ -1104,16 +1359,23 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 		Email:             identity.Email,
 		EmailVerified:     identity.EmailVerified,
 		Groups:            identity.Groups,
+		Organizations:     identity.Organizations,
+		Address:           storageAddress(identity.Address),
+		CustomClaims:      identity.CustomClaims,
+		AuthTime:          identity.AuthTime,
+	}
+	if claims.AuthTime.IsZero() {
+		claims.AuthTime = s.now()
 	}
 
-	accessToken, err := s.newAccessToken(client.ID, claims, scopes, nonce, connID)
+	accessToken, err := s.newAccessToken(client, claims, scopes, resources, nonce, connID)
 	if err != nil {
 		s.logger.Errorf("password grant failed to create new access token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
 		return
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, claims, scopes, nonce, accessToken, "", connID)
+	idToken, expiry, err := s.newIDToken(client, claims, scopes, nonce, accessToken, "", connID)
 	if err != nil {
 		s.logger.Errorf("password grant failed to create new ID token: %v", err)
 		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
@@ -1144,6 +1406,7 @@ func (s *Server) handlePasswordGrant(w http.ResponseWriter, r *http.Request, cli
 			ClientID:    client.ID,
 			ConnectorID: connID,
 			Scopes:      scopes,
+			Resources:   resources,
 			Claims:      claims,
 			Nonce:       nonce,
 			// ConnectorData: authCode.ConnectorData,
@@ -1285,6 +1548,24 @@ func (s *Server) renderError(r *http.Request, w http.ResponseWriter, status int,
 	}
 }
 
+// renderAuthError reports an error of the login of the auth request. Native
+// clients can't show the HTML error page, so clients with a redirect URI of a
+// scheme listed in nativeErrorRedirectSchemes get the error redirected to
+// them instead.
+func (s *Server) renderAuthError(r *http.Request, w http.ResponseWriter, authReq storage.AuthRequest, status int, typ, description string) {
+	if u, err := url.Parse(authReq.RedirectURI); err == nil && s.nativeErrorRedirectSchemes[strings.ToLower(u.Scheme)] {
+		authErr := &redirectedAuthErr{
+			State:       authReq.State,
+			RedirectURI: authReq.RedirectURI,
+			Type:        typ,
+			Description: description,
+		}
+		authErr.Handler().ServeHTTP(w, r)
+		return
+	}
+	s.renderError(r, w, status, description)
+}
+
 func (s *Server) tokenErrHelper(w http.ResponseWriter, typ string, description string, statusCode int) {
 	if err := tokenErr(w, typ, description, statusCode); err != nil {
 		s.logger.Errorf("token error response: %v", err)
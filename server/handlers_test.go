@@ -3,22 +3,39 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	gosundheit "github.com/AppsFlyer/go-sundheit"
 	"github.com/AppsFlyer/go-sundheit/checks"
 	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	jose "gopkg.in/square/go-jose.v2"
 
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/connector/mock"
+	"github.com/dexidp/dex/pkg/groups/membership"
 	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/memory"
 )
 
 func TestHandleHealth(t *testing.T) {
@@ -301,3 +318,1281 @@ func TestPasswordConnectorDataNotEmpty(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, `{"test": "true"}`, string(newSess.ConnectorData))
 }
+
+func TestTrackLastLogin(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			now := time.Now().UTC().Round(time.Second)
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.PasswordConnector = "test"
+				c.TrackLastLogin = enabled
+				c.Now = func() time.Time { return now }
+			})
+			defer httpServer.Close()
+
+			mockConnectorDataTestStorage(t, s.storage)
+
+			login := func(scope string) {
+				v := url.Values{}
+				v.Add("scope", scope)
+				v.Add("grant_type", "password")
+				v.Add("username", "test")
+				v.Add("password", "test")
+
+				req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("test", "barfoo")
+
+				rr := httptest.NewRecorder()
+				s.ServeHTTP(rr, req)
+				require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			}
+
+			login("openid email")
+			session, err := s.storage.GetOfflineSessions("0-385-28089-0", "test")
+			if !enabled {
+				require.Equal(t, storage.ErrNotFound, err, "logins without offline access must not create sessions")
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, now.Equal(session.LastLogin), "expected last login %v, got %v", now, session.LastLogin)
+
+			// Issuing a refresh token must keep the last login.
+			now = now.Add(time.Hour)
+			login("openid email offline_access")
+			session, err = s.storage.GetOfflineSessions("0-385-28089-0", "test")
+			require.NoError(t, err)
+			require.True(t, now.Equal(session.LastLogin), "expected last login %v, got %v", now, session.LastLogin)
+			require.Len(t, session.Refresh, 1)
+			require.Equal(t, `{"test": "true"}`, string(session.ConnectorData))
+		})
+	}
+}
+
+type mockMembership struct {
+	membership.UnimplementedMembershipServer
+}
+
+func (mockMembership) ListGroups(ctx context.Context, req *membership.ListGroupsReq) (*membership.ListGroupsResp, error) {
+	return &membership.ListGroupsResp{Groups: []string{"authors", req.Username}}, nil
+}
+
+func TestMembershipGroups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	membership.RegisterMembershipServer(grpcServer, mockMembership{})
+	go grpcServer.Serve(l)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	client := membership.NewClient(conn, time.Second)
+	defer client.Close()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.PasswordConnector = "test"
+		c.MembershipClients = map[string]*membership.Client{"test": client}
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	for _, tc := range []struct {
+		scope      string
+		wantGroups interface{}
+	}{
+		{scope: "openid groups", wantGroups: []interface{}{"authors", "Kilgore Trout"}},
+		{scope: "openid", wantGroups: nil},
+	} {
+		t.Run(tc.scope, func(t *testing.T) {
+			v := url.Values{}
+			v.Add("scope", tc.scope)
+			v.Add("grant_type", "password")
+			v.Add("username", "test")
+			v.Add("password", "test")
+
+			req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth("test", "barfoo")
+
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+			var resp struct {
+				IDToken string `json:"id_token"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.wantGroups, idTokenPayload(t, resp.IDToken)["groups"])
+		})
+	}
+}
+
+func TestIssuedTokenIDs(t *testing.T) {
+	for _, track := range []bool{false, true} {
+		t.Run(fmt.Sprintf("track=%t", track), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.PasswordConnector = "test"
+				c.TrackIssuedTokens = track
+			})
+			defer httpServer.Close()
+
+			mockConnectorDataTestStorage(t, s.storage)
+
+			seen := make(map[string]bool)
+			for i := 0; i < 2; i++ {
+				v := url.Values{}
+				v.Add("scope", "openid email")
+				v.Add("grant_type", "password")
+				v.Add("username", "test")
+				v.Add("password", "test")
+
+				req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				req.SetBasicAuth("test", "barfoo")
+
+				rr := httptest.NewRecorder()
+				s.ServeHTTP(rr, req)
+				require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+				var resp struct {
+					AccessToken string `json:"access_token"`
+					IDToken     string `json:"id_token"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+				for _, token := range []string{resp.AccessToken, resp.IDToken} {
+					jti, ok := idTokenPayload(t, token)["jti"].(string)
+					require.True(t, ok, "token has no jti")
+					require.NotEmpty(t, jti)
+					require.False(t, seen[jti], "jti %q issued twice", jti)
+					seen[jti] = true
+
+					issued, err := s.storage.GetIssuedToken(jti)
+					if !track {
+						require.Equal(t, storage.ErrNotFound, err)
+						continue
+					}
+					require.NoError(t, err)
+					require.Equal(t, "test", issued.ClientID)
+					require.Equal(t, "0-385-28089-0", issued.UserID)
+					require.Equal(t, "test", issued.ConnectorID)
+				}
+			}
+			require.Len(t, seen, 4)
+		})
+	}
+}
+
+func TestAuthTime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now().Truncate(time.Second)
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.PasswordConnector = "test"
+		c.Now = func() time.Time { return now }
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	type tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	tokenRequest := func(v url.Values) tokenResponse {
+		req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("test", "barfoo")
+
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp tokenResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp
+	}
+	authTime := func(token string) int64 {
+		payload := idTokenPayload(t, token)
+		authTime, ok := payload["auth_time"].(float64)
+		require.True(t, ok, "token has no auth_time")
+		require.LessOrEqual(t, authTime, payload["iat"].(float64), "auth_time is after iat")
+		return int64(authTime)
+	}
+
+	v := url.Values{}
+	v.Add("scope", "openid email offline_access")
+	v.Add("grant_type", "password")
+	v.Add("username", "test")
+	v.Add("password", "test")
+	resp := tokenRequest(v)
+
+	loggedIn := now.Unix()
+	require.Equal(t, loggedIn, authTime(resp.IDToken))
+	require.Equal(t, loggedIn, authTime(resp.AccessToken))
+	require.NotEmpty(t, resp.RefreshToken)
+
+	// Refreshing doesn't authenticate the user again.
+	now = now.Add(time.Minute)
+	v = url.Values{}
+	v.Add("grant_type", "refresh_token")
+	v.Add("refresh_token", resp.RefreshToken)
+	resp = tokenRequest(v)
+	require.Equal(t, loggedIn, authTime(resp.IDToken))
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	for _, client := range []storage.Client{
+		{ID: "lenient", RedirectURIs: []string{"https://lenient.example.com/callback"}},
+		{ID: "strict", RedirectURIs: []string{"https://strict.example.com/callback"}, RequireVerifiedEmail: true},
+	} {
+		require.NoError(t, s.storage.CreateClient(client))
+	}
+
+	identity := connector.Identity{
+		UserID:        "0-385-28089-0",
+		Username:      "Kilgore Trout",
+		Email:         "kilgore@kilgore.trout",
+		EmailVerified: false,
+	}
+
+	for _, tc := range []struct {
+		clientID   string
+		wantDenied bool
+	}{
+		{clientID: "lenient"},
+		{clientID: "strict", wantDenied: true},
+	} {
+		t.Run(tc.clientID, func(t *testing.T) {
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    tc.clientID,
+				ConnectorID: "mock",
+				Scopes:      []string{"openid", "email"},
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			_, err := s.finalizeLogin(ctx, identity, authReq, mock.NewCallbackConnector(s.logger))
+			stored, getErr := s.storage.GetAuthRequest(authReq.ID)
+			require.NoError(t, getErr)
+
+			if !tc.wantDenied {
+				require.NoError(t, err)
+				require.True(t, stored.LoggedIn)
+				return
+			}
+			authErr, ok := err.(*displayedAuthErr)
+			require.True(t, ok, "expected a displayed error, got %v", err)
+			require.Equal(t, http.StatusForbidden, authErr.Status)
+			require.False(t, stored.LoggedIn)
+		})
+	}
+}
+
+func TestSilentLoginInteractionRequired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	conn := s.connectors["mock"].Connector.(*mock.Callback)
+	conn.Err = &connector.InteractionRequiredError{Code: "login_required", Description: "no upstream session"}
+
+	for _, tc := range []struct {
+		prompt       string
+		wantRedirect bool
+	}{
+		{prompt: "none", wantRedirect: true},
+		{prompt: "", wantRedirect: false},
+	} {
+		t.Run(fmt.Sprintf("prompt=%q", tc.prompt), func(t *testing.T) {
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    "test",
+				ConnectorID: "mock",
+				RedirectURI: "https://client.example.com/callback",
+				State:       "state",
+				Prompt:      tc.prompt,
+				Scopes:      []string{"openid"},
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, httptest.NewRequest("GET", "/callback/mock?state="+authReq.ID, nil))
+
+			if !tc.wantRedirect {
+				require.Equal(t, http.StatusInternalServerError, rr.Code)
+				return
+			}
+			require.Equal(t, http.StatusSeeOther, rr.Code)
+			u, err := url.Parse(rr.Header().Get("Location"))
+			require.NoError(t, err)
+			require.Equal(t, "client.example.com", u.Host)
+			require.Equal(t, "login_required", u.Query().Get("error"))
+			require.Equal(t, "no upstream session", u.Query().Get("error_description"))
+			require.Equal(t, "state", u.Query().Get("state"))
+		})
+	}
+}
+
+func TestConnectorCallbackStateData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           "test",
+		RedirectURIs: []string{"https://client.example.com/callback"},
+	}))
+	authReq := storage.AuthRequest{
+		ID:          storage.NewID(),
+		ClientID:    "test",
+		ConnectorID: "mock",
+		RedirectURI: "https://client.example.com/callback",
+		Scopes:      []string{"openid"},
+		Expiry:      time.Now().Add(time.Minute),
+	}
+	require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+	// The data the connector appended to the state is ignored.
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest("GET", "/callback/mock?state="+authReq.ID+connector.StateSeparator+"1700000000", nil))
+	require.Equal(t, http.StatusSeeOther, rr.Code, rr.Body.String())
+
+	stored, err := s.storage.GetAuthRequest(authReq.ID)
+	require.NoError(t, err)
+	require.True(t, stored.LoggedIn)
+
+	// So is the one of callbacks posted with response_mode=form_post.
+	authReq.ID = storage.NewID()
+	require.NoError(t, s.storage.CreateAuthRequest(authReq))
+	form := url.Values{"state": {authReq.ID + connector.StateSeparator + "1700000000"}}
+	r := httptest.NewRequest("POST", "/callback/mock", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, r)
+	require.Equal(t, http.StatusSeeOther, rr.Code, rr.Body.String())
+
+	stored, err = s.storage.GetAuthRequest(authReq.ID)
+	require.NoError(t, err)
+	require.True(t, stored.LoggedIn)
+}
+
+// hintedConnector adds the hints to the login URL of the mock connector.
+type hintedConnector struct {
+	*mock.Callback
+}
+
+func (c hintedConnector) LoginURLWithHints(s connector.Scopes, callbackURL, state string, hints map[string]string) (string, []byte, error) {
+	loginURL, err := c.LoginURL(s, callbackURL, state)
+	if err != nil {
+		return "", nil, err
+	}
+	for name, value := range hints {
+		loginURL += "&" + name + "=" + url.QueryEscape(value)
+	}
+	return loginURL, []byte("hinted"), nil
+}
+
+func TestConnectorLoginURLHints(t *testing.T) {
+	conn := mock.NewCallbackConnector(logger).(*mock.Callback)
+	const callbackURL = "https://dex.example.com/callback"
+
+	hints := loginHints(url.Values{"login_hint": {"jane@example.com"}, "prompt": {"login"}})
+	require.Equal(t, map[string]string{"login_hint": "jane@example.com"}, hints)
+
+	loginURL, connData, err := connectorLoginURL(hintedConnector{conn}, connector.Scopes{}, callbackURL, "state", hints)
+	require.NoError(t, err)
+	require.Equal(t, callbackURL+"?state=state&login_hint=jane%40example.com", loginURL)
+	require.Equal(t, []byte("hinted"), connData)
+
+	// Without hints, or with a connector not supporting them, the login URL
+	// is the usual one.
+	for _, tc := range []struct {
+		conn  connector.CallbackConnector
+		hints map[string]string
+	}{
+		{conn: hintedConnector{conn}, hints: loginHints(url.Values{})},
+		{conn: conn, hints: hints},
+	} {
+		loginURL, connData, err := connectorLoginURL(tc.conn, connector.Scopes{}, callbackURL, "state", tc.hints)
+		require.NoError(t, err)
+		require.Equal(t, callbackURL+"?state=state", loginURL)
+		require.Nil(t, connData)
+	}
+}
+
+func TestGroupsWarningThreshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.GroupsWarningThreshold = 2
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	for _, tc := range []struct {
+		name      string
+		groups    []string
+		wantCount float64
+	}{
+		{name: "under threshold", groups: []string{"a", "b"}, wantCount: 0},
+		{name: "over threshold", groups: []string{"a", "b", "c"}, wantCount: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    "test",
+				ConnectorID: "mock",
+				Scopes:      []string{"openid", "groups"},
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			identity := connector.Identity{UserID: "0-385-28089-0", Groups: tc.groups}
+			_, err := s.finalizeLogin(ctx, identity, authReq, mock.NewCallbackConnector(s.logger))
+			require.NoError(t, err)
+
+			count := testutil.ToFloat64(s.groupsWarningCounter.With(prometheus.Labels{"connector": "mock"}))
+			require.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
+func TestGroupsFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.GroupsFilter = "^dex-"
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	for _, tc := range []struct {
+		name       string
+		groups     []string
+		wantGroups []string
+	}{
+		{name: "some matching", groups: []string{"dex-admins", "engineering", "dex-users", "all-dex-"}, wantGroups: []string{"dex-admins", "dex-users"}},
+		{name: "none matching", groups: []string{"engineering"}, wantGroups: []string{}},
+		{name: "no groups", groups: nil, wantGroups: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    "test",
+				ConnectorID: "mock",
+				Scopes:      []string{"openid", "groups"},
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			identity := connector.Identity{UserID: "0-385-28089-0", Groups: tc.groups}
+			_, err := s.finalizeLogin(ctx, identity, authReq, mock.NewCallbackConnector(s.logger))
+			require.NoError(t, err)
+
+			authReq, err = s.storage.GetAuthRequest(authReq.ID)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantGroups, authReq.Claims.Groups)
+		})
+	}
+}
+
+func TestResourceIndicators(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.PasswordConnector = "test"
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+	err := s.storage.UpdateClient("test", func(old storage.Client) (storage.Client, error) {
+		old.Resources = []string{"https://api.example.com", "https://files.example.com"}
+		return old, nil
+	})
+	require.NoError(t, err)
+
+	tokenRequest := func(v url.Values, resources ...string) *httptest.ResponseRecorder {
+		for _, resource := range resources {
+			v.Add("resource", resource)
+		}
+
+		req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("test", "barfoo")
+
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, req)
+		return rr
+	}
+	passwordGrant := func(resources ...string) *httptest.ResponseRecorder {
+		v := url.Values{}
+		v.Add("scope", "openid email offline_access")
+		v.Add("grant_type", "password")
+		v.Add("username", "test")
+		v.Add("password", "test")
+		return tokenRequest(v, resources...)
+	}
+
+	type tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	requireTokens := func(t *testing.T, rr *httptest.ResponseRecorder, wantAudience ...interface{}) tokenResponse {
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp tokenResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+		accessClaims := idTokenPayload(t, resp.AccessToken)
+		require.ElementsMatch(t, wantAudience, accessClaims["aud"])
+		require.Equal(t, "test", accessClaims["azp"])
+
+		// Resource indicators only apply to access tokens.
+		idClaims := idTokenPayload(t, resp.IDToken)
+		require.Equal(t, "test", idClaims["aud"])
+		require.NotContains(t, idClaims, "azp")
+		return resp
+	}
+	requireInvalidTarget := func(t *testing.T, rr *httptest.ResponseRecorder) {
+		require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		var errResponse struct{ Error string }
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &errResponse))
+		require.Equal(t, errInvalidTarget, errResponse.Error)
+	}
+
+	t.Run("password grant", func(t *testing.T) {
+		rr := passwordGrant("https://api.example.com", "https://files.example.com")
+		requireTokens(t, rr, "https://api.example.com", "https://files.example.com", "test")
+	})
+
+	t.Run("unregistered resource", func(t *testing.T) {
+		requireInvalidTarget(t, passwordGrant("https://api.example.com", "https://evil.example.com"))
+	})
+
+	t.Run("refresh token grant", func(t *testing.T) {
+		resp := requireTokens(t, passwordGrant("https://api.example.com"), "https://api.example.com", "test")
+		require.NotEmpty(t, resp.RefreshToken)
+
+		refresh := func(resources ...string) *httptest.ResponseRecorder {
+			v := url.Values{}
+			v.Add("grant_type", "refresh_token")
+			v.Add("refresh_token", resp.RefreshToken)
+			return tokenRequest(v, resources...)
+		}
+
+		// Registered for the client, but outside of the original grant.
+		requireInvalidTarget(t, refresh("https://files.example.com"))
+
+		resp = requireTokens(t, refresh("https://api.example.com"), "https://api.example.com", "test")
+		requireTokens(t, refresh(), "https://api.example.com", "test")
+	})
+
+	t.Run("authorization code grant", func(t *testing.T) {
+		newCode := func() string {
+			code := storage.AuthCode{
+				ID:          storage.NewID(),
+				ClientID:    "test",
+				RedirectURI: "https://auth.example.com",
+				ConnectorID: "test",
+				Nonce:       "nonce",
+				Scopes:      []string{"openid", "email"},
+				Resources:   []string{"https://api.example.com"},
+				Claims:      storage.Claims{UserID: "0-385-28089-0", Username: "test"},
+				Expiry:      s.now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthCode(code))
+			return code.ID
+		}
+		exchange := func(code string, resources ...string) *httptest.ResponseRecorder {
+			v := url.Values{}
+			v.Add("grant_type", "authorization_code")
+			v.Add("code", code)
+			v.Add("redirect_uri", "https://auth.example.com")
+			return tokenRequest(v, resources...)
+		}
+
+		requireInvalidTarget(t, exchange(newCode(), "https://files.example.com"))
+		requireTokens(t, exchange(newCode()), "https://api.example.com", "test")
+	})
+
+	t.Run("device code grant", func(t *testing.T) {
+		v := url.Values{}
+		v.Add("grant_type", grantTypeDeviceCode)
+		v.Add("device_code", "device-code")
+		requireInvalidTarget(t, tokenRequest(v, "https://api.example.com"))
+	})
+}
+
+// statefulConnector mimics PKCE: the login URL carries the hash of the data
+// returned with it, and the callback's code must match the hash of the data.
+type statefulConnector struct {
+	connector.CallbackConnector
+}
+
+func (statefulConnector) LoginURLWithData(s connector.Scopes, callbackURL, state string) (string, []byte, error) {
+	verifier := []byte(storage.NewID())
+	challenge := sha256.Sum256(verifier)
+	u := fmt.Sprintf("https://upstream.example.com/authorize?state=%s&code_challenge=%s",
+		url.QueryEscape(state), base64.RawURLEncoding.EncodeToString(challenge[:]))
+	return u, verifier, nil
+}
+
+func (statefulConnector) HandleCallbackWithData(s connector.Scopes, connData []byte, r *http.Request) (connector.Identity, error) {
+	challenge := sha256.Sum256(connData)
+	if r.URL.Query().Get("code") != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+		return connector.Identity{}, errors.New("code verifier mismatch")
+	}
+	return connector.Identity{UserID: "0-385-28089-0", Username: "Kilgore Trout", Email: "kilgore@kilgore.trout", EmailVerified: true}, nil
+}
+
+func TestStatefulCallbackConnector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           "test",
+		RedirectURIs: []string{"https://client.example.com/callback"},
+	}))
+	useConnector := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.connectors["mock"] = Connector{
+			ResourceVersion: "1",
+			Connector:       statefulConnector{mock.NewCallbackConnector(logger).(connector.CallbackConnector)},
+		}
+	}
+
+	useConnector()
+	q := url.Values{
+		"client_id":     {"test"},
+		"redirect_uri":  {"https://client.example.com/callback"},
+		"response_type": {"code"},
+		"scope":         {"openid email"},
+		"state":         {"client-state"},
+	}
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest("GET", "/auth/mock?"+q.Encode(), nil))
+	require.Equal(t, http.StatusFound, rr.Code, rr.Body.String())
+
+	loginURL, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	state, challenge := loginURL.Query().Get("state"), loginURL.Query().Get("code_challenge")
+	require.NotEmpty(t, challenge)
+
+	authReq, err := s.storage.GetAuthRequest(state)
+	require.NoError(t, err)
+	require.NotEmpty(t, authReq.ConnectorData, "connector data must be kept in storage")
+
+	// A new connector instance, as on another replica, handles the callback.
+	useConnector()
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest("GET", "/callback?"+url.Values{"state": {state}, "code": {challenge}}.Encode(), nil))
+	require.Equal(t, http.StatusSeeOther, rr.Code, rr.Body.String())
+}
+
+func TestEssentialClaims(t *testing.T) {
+	tests := []struct {
+		name      string
+		enforce   bool
+		scope     string
+		claims    string
+		wantError string
+	}{
+		{
+			name:    "essential claim present",
+			enforce: true,
+			scope:   "openid email",
+			claims:  `{"id_token": {"email": {"essential": true}, "name": null}}`,
+		},
+		{
+			name:      "essential claim absent",
+			enforce:   true,
+			scope:     "openid email",
+			claims:    `{"userinfo": {"phone_number": {"essential": true}}}`,
+			wantError: errInteractionRequired,
+		},
+		{
+			name:      "essential claim not in scope",
+			enforce:   true,
+			scope:     "openid",
+			claims:    `{"id_token": {"email": {"essential": true}}}`,
+			wantError: errInteractionRequired,
+		},
+		{
+			name:      "malformed claims",
+			enforce:   true,
+			scope:     "openid",
+			claims:    `{"id_token": ["email"]}`,
+			wantError: errInvalidRequest,
+		},
+		{
+			name:   "not enforced",
+			scope:  "openid",
+			claims: `{"userinfo": {"phone_number": {"essential": true}}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.EnforceEssentialClaims = tc.enforce
+			})
+			defer httpServer.Close()
+
+			require.NoError(t, s.storage.CreateClient(storage.Client{
+				ID:           "test",
+				RedirectURIs: []string{"https://client.example.com/callback"},
+			}))
+
+			q := url.Values{
+				"client_id":     {"test"},
+				"redirect_uri":  {"https://client.example.com/callback"},
+				"response_type": {"code"},
+				"scope":         {tc.scope},
+				"state":         {"client-state"},
+				"claims":        {tc.claims},
+			}
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, httptest.NewRequest("GET", "/auth/mock?"+q.Encode(), nil))
+			require.Contains(t, []int{http.StatusFound, http.StatusSeeOther}, rr.Code, rr.Body.String())
+
+			location, err := url.Parse(rr.Header().Get("Location"))
+			require.NoError(t, err)
+			if location.Host != "client.example.com" {
+				rr = httptest.NewRecorder()
+				s.ServeHTTP(rr, httptest.NewRequest("GET", location.String(), nil))
+				require.Equal(t, http.StatusSeeOther, rr.Code, rr.Body.String())
+				location, err = url.Parse(rr.Header().Get("Location"))
+				require.NoError(t, err)
+			}
+
+			if tc.wantError == "" {
+				require.Equal(t, "/approval", location.Path)
+				return
+			}
+			require.Equal(t, "client.example.com", location.Host)
+			require.Equal(t, tc.wantError, location.Query().Get("error"))
+			require.Equal(t, "client-state", location.Query().Get("state"))
+		})
+	}
+}
+
+func TestHandleApprovalConsentText(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+	s.skipApproval = false
+
+	tests := []struct {
+		name        string
+		client      storage.Client
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name: "consent text and privacy policy",
+			client: storage.Client{
+				ID:               "consent",
+				Name:             "Consent App",
+				RedirectURIs:     []string{"https://client.example.com/callback"},
+				ConsentText:      "By signing in you agree to the Consent App terms.",
+				PrivacyPolicyURL: "https://client.example.com/privacy",
+			},
+			wantContain: []string{
+				"By signing in you agree to the Consent App terms.",
+				`href="https://client.example.com/privacy"`,
+			},
+		},
+		{
+			name: "no consent text",
+			client: storage.Client{
+				ID:           "plain",
+				Name:         "Plain App",
+				RedirectURIs: []string{"https://client.example.com/callback"},
+			},
+			wantMissing: []string{"Privacy policy"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, s.storage.CreateClient(tc.client))
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    tc.client.ID,
+				ConnectorID: "mock",
+				RedirectURI: "https://client.example.com/callback",
+				Scopes:      []string{"openid", "email"},
+				LoggedIn:    true,
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, httptest.NewRequest("GET", "/approval?req="+authReq.ID, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			body := rr.Body.String()
+			for _, want := range tc.wantContain {
+				require.Contains(t, body, want)
+			}
+			for _, missing := range tc.wantMissing {
+				require.NotContains(t, body, missing)
+			}
+		})
+	}
+}
+
+func TestNativeErrorRedirect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.NativeErrorRedirectSchemes = []string{"com.example.app"}
+	})
+	defer httpServer.Close()
+
+	tests := []struct {
+		name         string
+		redirectURI  string
+		wantRedirect bool
+	}{
+		{
+			name:         "allowlisted custom scheme",
+			redirectURI:  "com.example.app:/callback",
+			wantRedirect: true,
+		},
+		{
+			name:        "other custom scheme",
+			redirectURI: "com.example.other:/callback",
+		},
+		{
+			name:        "web client",
+			redirectURI: "https://client.example.com/callback",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			authReq := storage.AuthRequest{
+				ID:          storage.NewID(),
+				ClientID:    "test",
+				ConnectorID: "mock",
+				RedirectURI: tc.redirectURI,
+				State:       "some-state",
+				Scopes:      []string{"openid"},
+				LoggedIn:    true,
+				Expiry:      time.Now().Add(time.Minute),
+			}
+			require.NoError(t, s.storage.CreateAuthRequest(authReq))
+
+			form := url.Values{"req": {authReq.ID}, "approval": {"reject"}}
+			req := httptest.NewRequest("POST", "/approval", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			s.ServeHTTP(rr, req)
+
+			if !tc.wantRedirect {
+				require.Equal(t, http.StatusInternalServerError, rr.Code)
+				require.Contains(t, rr.Body.String(), "Approval rejected.")
+				return
+			}
+			require.Equal(t, http.StatusSeeOther, rr.Code)
+			u, err := url.Parse(rr.Header().Get("Location"))
+			require.NoError(t, err)
+			require.Equal(t, "com.example.app", u.Scheme)
+			require.Equal(t, "/callback", u.Path)
+			q := u.Query()
+			require.Equal(t, errAccessDenied, q.Get("error"))
+			require.Equal(t, "Approval rejected.", q.Get("error_description"))
+			require.Equal(t, "some-state", q.Get("state"))
+		})
+	}
+}
+
+func TestNativeErrorRedirectSchemesValidation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := memory.New(logger)
+	require.NoError(t, s.CreateConnector(storage.Connector{ID: "mock", Type: "mockCallback", Name: "Mock"}))
+
+	_, err := newServer(ctx, Config{
+		Issuer:                     "http://localhost",
+		Storage:                    s,
+		Web:                        WebConfig{Dir: "../web"},
+		Logger:                     logger,
+		NativeErrorRedirectSchemes: []string{"HTTPS"},
+	}, staticRotationStrategy(testKey))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "nativeErrorRedirectSchemes")
+}
+
+func TestLogout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	client := storage.Client{
+		ID:           "app",
+		RedirectURIs: []string{"https://app.example.com/callback", "https://app.example.com/logged-out"},
+	}
+	require.NoError(t, s.storage.CreateClient(client))
+
+	idToken, _, err := s.newIDToken(client, storage.Claims{UserID: "0-385-28089-0"}, []string{"openid"}, "", "", "", "mock")
+	require.NoError(t, err)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+		return rr
+	}
+
+	// The user is logged out of the mock connector, which sends them back to
+	// the logout callback.
+	q := url.Values{
+		"id_token_hint":            {idToken},
+		"post_logout_redirect_uri": {"https://app.example.com/logged-out"},
+		"state":                    {"xyz"},
+	}
+	rr := serve(http.MethodGet, "/logout?"+q.Encode())
+	require.Equal(t, http.StatusFound, rr.Code)
+	upstream, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "upstream.example.com", upstream.Host)
+	returnURL := upstream.Query().Get("RelayState")
+	require.True(t, strings.HasPrefix(returnURL, s.absURL("/logout")+"?"), returnURL)
+
+	rr = serve(http.MethodGet, "/logout/callback/mock?"+url.Values{"RelayState": {returnURL}}.Encode())
+	require.Equal(t, http.StatusFound, rr.Code)
+	require.Equal(t, returnURL, rr.Header().Get("Location"))
+
+	rr = serve(http.MethodGet, returnURL)
+	require.Equal(t, http.StatusFound, rr.Code)
+	require.Equal(t, "https://app.example.com/logged-out?state=xyz", rr.Header().Get("Location"))
+
+	// Without a post logout redirect URI, the user stays on dex.
+	rr = serve(http.MethodGet, "/logout")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	for name, target := range map[string]string{
+		"unregistered redirect URI": "/logout?" + url.Values{
+			"id_token_hint":            {idToken},
+			"post_logout_redirect_uri": {"https://evil.example.com"},
+		}.Encode(),
+		"redirect URI without client": "/logout?" + url.Values{
+			"post_logout_redirect_uri": {"https://app.example.com/logged-out"},
+		}.Encode(),
+		"other client": "/logout?" + url.Values{
+			"id_token_hint": {idToken},
+			"client_id":     {"other"},
+		}.Encode(),
+		"invalid hint": "/logout?" + url.Values{
+			"id_token_hint": {"invalid"},
+		}.Encode(),
+		"foreign return URL": "/logout/callback/mock?" + url.Values{
+			"RelayState": {"https://evil.example.com/logout"},
+		}.Encode(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			rr := serve(http.MethodGet, target)
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestSAMLMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	config, err := json.Marshal(map[string]interface{}{
+		"ssoURL":       "https://idp.example.com/sso",
+		"sloURL":       "https://idp.example.com/slo",
+		"ca":           "../connector/saml/testdata/ca.crt",
+		"privateKey":   "../connector/saml/testdata/ca.key",
+		"certificate":  "../connector/saml/testdata/ca.crt",
+		"redirectURI":  s.absURL("/callback"),
+		"entityIssuer": s.absURL("/saml"),
+		"usernameAttr": "name",
+		"emailAttr":    "email",
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.storage.CreateConnector(storage.Connector{
+		ID:              "saml",
+		Type:            "saml",
+		Name:            "SAML",
+		ResourceVersion: "1",
+		Config:          config,
+	}))
+
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/saml/metadata/saml", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/samlmetadata+xml", rr.Header().Get("Content-Type"))
+	require.Contains(t, rr.Body.String(), `entityID="`+s.absURL("/saml")+`"`)
+	require.Contains(t, rr.Body.String(), `Location="`+s.absURL("/logout/callback/saml")+`"`)
+
+	// Other connectors have no SAML metadata.
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/saml/metadata/mock", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.PasswordConnector = "test"
+		c.PreferredLanguages = map[string][]string{"test": {"en", "de", "fr-CA"}}
+	})
+	defer httpServer.Close()
+
+	mockConnectorDataTestStorage(t, s.storage)
+
+	type tokenResponse struct {
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	tokenRequest := func(v url.Values, acceptLanguage string) tokenResponse {
+		req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept-Language", acceptLanguage)
+		req.SetBasicAuth("test", "barfoo")
+
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp tokenResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp
+	}
+
+	for _, tc := range []struct {
+		acceptLanguage string
+		wantLanguage   interface{}
+	}{
+		{acceptLanguage: "de-CH, de;q=0.9, en;q=0.8", wantLanguage: "de"},
+		{acceptLanguage: "fr-CA", wantLanguage: "fr-CA"},
+		{acceptLanguage: "ja", wantLanguage: nil},
+		{acceptLanguage: "", wantLanguage: nil},
+	} {
+		t.Run(tc.acceptLanguage, func(t *testing.T) {
+			v := url.Values{}
+			v.Add("scope", "openid email offline_access")
+			v.Add("grant_type", "password")
+			v.Add("username", "test")
+			v.Add("password", "test")
+			resp := tokenRequest(v, tc.acceptLanguage)
+			require.Equal(t, tc.wantLanguage, idTokenPayload(t, resp.IDToken)[preferredLanguageClaim])
+
+			// Refreshing keeps the language negotiated at login.
+			v = url.Values{}
+			v.Add("grant_type", "refresh_token")
+			v.Add("refresh_token", resp.RefreshToken)
+			resp = tokenRequest(v, "en")
+			require.Equal(t, tc.wantLanguage, idTokenPayload(t, resp.IDToken)[preferredLanguageClaim])
+		})
+	}
+}
+
+func TestPreferredLanguageUpstreamLocale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.PreferredLanguages = map[string][]string{"mock": {"en", "de"}}
+	})
+	defer httpServer.Close()
+
+	identity := connector.Identity{UserID: "user", CustomClaims: map[string]interface{}{"locale": "fr"}}
+	require.Equal(t, identity, s.withPreferredLanguage("mock", identity, "de"), "the upstream locale takes precedence")
+
+	identity = connector.Identity{UserID: "user"}
+	require.Equal(t, identity, s.withPreferredLanguage("other", identity, "de"), "connector without languages")
+	require.Equal(t, map[string]interface{}{preferredLanguageClaim: "de"}, s.withPreferredLanguage("mock", identity, "de").CustomClaims)
+	require.Nil(t, identity.CustomClaims)
+}
+
+// nonceProvider is an upstream OpenID Connect provider issuing ID tokens with
+// a settable nonce claim.
+type nonceProvider struct {
+	*httptest.Server
+
+	key   *rsa.PrivateKey
+	nonce string
+}
+
+func newNonceProvider(t *testing.T) *nonceProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &nonceProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "key"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		claims := map[string]interface{}{
+			"iss":            p.URL,
+			"aud":            "upstream-client",
+			"sub":            "upstream-user",
+			"name":           "Jane",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+		if p.nonce != "" {
+			claims["nonce"] = p.nonce
+		}
+		payload, _ := json.Marshal(claims)
+		jws, err := signer.Sign(payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		idToken, _ := jws.CompactSerialize()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func TestOIDCConnectorNonce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	provider := newNonceProvider(t)
+	defer provider.Close()
+
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           "test",
+		RedirectURIs: []string{"https://client.example.com/callback"},
+	}))
+	for id, enableNonce := range map[string]bool{"upstream": true, "upstream-nonceless": false} {
+		config, err := json.Marshal(map[string]interface{}{
+			"issuer":       provider.URL,
+			"clientID":     "upstream-client",
+			"clientSecret": "upstream-secret",
+			"redirectURI":  s.absURL("/callback"),
+			"enableNonce":  enableNonce,
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.storage.CreateConnector(storage.Connector{
+			ID:     id,
+			Type:   "oidc",
+			Name:   id,
+			Config: config,
+		}))
+	}
+
+	// login starts a login with the connector, and returns the state and the
+	// nonce it sent to the provider.
+	login := func(connID string) (state, nonce string) {
+		q := url.Values{
+			"client_id":     {"test"},
+			"redirect_uri":  {"https://client.example.com/callback"},
+			"response_type": {"code"},
+			"scope":         {"openid"},
+		}
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/"+connID+"?"+q.Encode(), nil))
+		require.Equal(t, http.StatusFound, rr.Code, rr.Body.String())
+		loginURL, err := url.Parse(rr.Header().Get("Location"))
+		require.NoError(t, err)
+		return loginURL.Query().Get("state"), loginURL.Query().Get("nonce")
+	}
+	callback := func(state string) int {
+		q := url.Values{"code": {"code"}, "state": {state}}
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/callback?"+q.Encode(), nil))
+		return rr.Code
+	}
+
+	// The random nonce of the login is kept with the auth request until the
+	// callback.
+	state, nonce := login("upstream")
+	require.NotEmpty(t, nonce)
+	provider.nonce = nonce
+	require.Equal(t, http.StatusSeeOther, callback(state))
+
+	// ID tokens with the nonce of another login are rejected.
+	state, _ = login("upstream")
+	require.Equal(t, http.StatusInternalServerError, callback(state))
+
+	// Without enableNonce, no nonce is sent or checked.
+	state, nonce = login("upstream-nonceless")
+	require.Empty(t, nonce)
+	require.Equal(t, http.StatusSeeOther, callback(state))
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// storageKeySetCache caches the key sets of the upstream providers of
+// connectors in the storage.
+type storageKeySetCache struct {
+	storage storage.Storage
+	now     func() time.Time
+}
+
+func (c storageKeySetCache) GetKeySet(connectorID string) ([]byte, error) {
+	keySet, err := c.storage.GetUpstreamKeySet(connectorID)
+	if err == storage.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return keySet.KeySet, nil
+}
+
+func (c storageKeySetCache) SetKeySet(connectorID string, keySet []byte) error {
+	now := c.now()
+	err := c.storage.UpdateUpstreamKeySet(connectorID, func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error) {
+		old.KeySet = keySet
+		old.UpdatedAt = now
+		return old, nil
+	})
+	if err != storage.ErrNotFound {
+		return err
+	}
+	return c.storage.CreateUpstreamKeySet(storage.UpstreamKeySet{
+		ConnectorID: connectorID,
+		KeySet:      keySet,
+		UpdatedAt:   now,
+	})
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage/memory"
+)
+
+func TestStorageKeySetCache(t *testing.T) {
+	now := time.Now().UTC().Round(time.Second)
+	s := memory.New(logger)
+	cache := storageKeySetCache{storage: s, now: func() time.Time { return now }}
+
+	keySet, err := cache.GetKeySet("oidc")
+	require.NoError(t, err)
+	require.Nil(t, keySet, "expected no key set before one is cached")
+
+	require.NoError(t, cache.SetKeySet("oidc", []byte(`{"keys":[]}`)))
+	keySet, err = cache.GetKeySet("oidc")
+	require.NoError(t, err)
+	require.Equal(t, `{"keys":[]}`, string(keySet))
+
+	now = now.Add(time.Hour)
+	require.NoError(t, cache.SetKeySet("oidc", []byte(`{"keys":[{}]}`)))
+	stored, err := s.GetUpstreamKeySet("oidc")
+	require.NoError(t, err)
+	require.Equal(t, `{"keys":[{}]}`, string(stored.KeySet))
+	require.Equal(t, now, stored.UpdatedAt)
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/text/language"
+
+	"github.com/dexidp/dex/connector"
+)
+
+// preferredLanguageClaim holds the language negotiated for users whose
+// connector reports no locale.
+const preferredLanguageClaim = "preferred_language"
+
+// languageMatcher negotiates the preferred language of a user among the
+// languages configured for a connector.
+type languageMatcher struct {
+	languages []string
+	matcher   language.Matcher
+}
+
+func newLanguageMatcher(languages []string) (*languageMatcher, error) {
+	tags := make([]language.Tag, len(languages))
+	for i, lang := range languages {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %q: %v", lang, err)
+		}
+		tags[i] = tag
+	}
+	return &languageMatcher{languages: languages, matcher: language.NewMatcher(tags)}, nil
+}
+
+// match returns the configured language best matching the Accept-Language
+// header, or "" if none matches.
+func (m *languageMatcher) match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	if _, index, confidence := m.matcher.Match(tags...); confidence != language.No {
+		return m.languages[index]
+	}
+	return ""
+}
+
+// negotiateLanguage returns the language of the languages configured for the
+// connector the login request prefers, if any.
+func (s *Server) negotiateLanguage(connID string, r *http.Request) string {
+	m, ok := s.languageMatchers[connID]
+	if !ok {
+		return ""
+	}
+	return m.match(r.Header.Get("Accept-Language"))
+}
+
+// withPreferredLanguage adds the preferred_language claim to identities of
+// connectors configured with languages, unless the connector already reports
+// the locale of the user.
+func (s *Server) withPreferredLanguage(connID string, identity connector.Identity, lang string) connector.Identity {
+	if _, ok := s.languageMatchers[connID]; !ok || lang == "" {
+		return identity
+	}
+	for _, claim := range []string{"locale", preferredLanguageClaim} {
+		if _, ok := identity.CustomClaims[claim]; ok {
+			return identity
+		}
+	}
+	claims := make(map[string]interface{}, len(identity.CustomClaims)+1)
+	for k, v := range identity.CustomClaims {
+		claims[k] = v
+	}
+	claims[preferredLanguageClaim] = lang
+	identity.CustomClaims = claims
+	return identity
+}
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gorilla/mux"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/server/internal"
+	"github.com/dexidp/dex/storage"
+)
+
+// logoutRequest is a validated request of a client to log a user out.
+type logoutRequest struct {
+	clientID    string
+	redirectURI string
+	state       string
+
+	// subject is the user of the id_token_hint, if one was sent.
+	subject *internal.IDTokenSubject
+}
+
+// handleLogout logs a user out. Dex keeps no session of its own, so users who
+// logged in through a connector ending upstream sessions are logged out of
+// the upstream provider. They're then redirected to the post logout redirect
+// URI of the client.
+//
+// The provider sends the user back to the logout callback of the connector,
+// which redirects them to this endpoint again, without an id_token_hint.
+//
+// See: https://openid.net/specs/openid-connect-rpinitiated-1_0.html
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		s.renderError(r, w, http.StatusBadRequest, "Unsupported request method.")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.logger.Errorf("Failed to parse logout request: %v", err)
+		s.renderError(r, w, http.StatusBadRequest, "Invalid logout request.")
+		return
+	}
+
+	req, err := s.parseLogoutRequest(r)
+	if err != nil {
+		s.logger.Errorf("Invalid logout request: %v", err)
+		s.renderError(r, w, http.StatusBadRequest, "Invalid logout request.")
+		return
+	}
+
+	if req.subject != nil {
+		logoutURL, err := s.upstreamLogoutURL(req)
+		if err != nil {
+			s.logger.Errorf("Failed to log out of connector %q: %v", req.subject.ConnId, err)
+			s.renderError(r, w, http.StatusInternalServerError, "Logout error.")
+			return
+		}
+		if logoutURL != "" {
+			http.Redirect(w, r, logoutURL, http.StatusFound)
+			return
+		}
+	}
+
+	if req.redirectURI == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintf(w, "You have been logged out.")
+		return
+	}
+	u, err := url.Parse(req.redirectURI)
+	if err != nil {
+		s.renderError(r, w, http.StatusBadRequest, "Invalid logout request.")
+		return
+	}
+	if req.state != "" {
+		q := u.Query()
+		q.Set("state", req.state)
+		u.RawQuery = q.Encode()
+	}
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+// parseLogoutRequest verifies the id_token_hint, if there's one, and the post
+// logout redirect URI of a logout request.
+func (s *Server) parseLogoutRequest(r *http.Request) (*logoutRequest, error) {
+	req := &logoutRequest{
+		clientID:    r.Form.Get("client_id"),
+		redirectURI: r.Form.Get("post_logout_redirect_uri"),
+		state:       r.Form.Get("state"),
+	}
+
+	if hint := r.Form.Get("id_token_hint"); hint != "" {
+		// Users may log out after their ID token expired.
+		verifier := oidc.NewVerifier(s.issuerURL.String(), &storageKeySet{s.storage}, &oidc.Config{
+			SkipClientIDCheck: true,
+			SkipExpiryCheck:   true,
+		})
+		idToken, err := verifier.Verify(r.Context(), hint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id_token_hint: %v", err)
+		}
+		switch {
+		case req.clientID == "" && len(idToken.Audience) == 1:
+			req.clientID = idToken.Audience[0]
+		case req.clientID == "":
+			return nil, errors.New("client_id is required for an id_token_hint with several audiences")
+		case !contains(idToken.Audience, req.clientID):
+			return nil, fmt.Errorf("client %q isn't an audience of the id_token_hint", req.clientID)
+		}
+
+		req.subject = new(internal.IDTokenSubject)
+		if err := internal.Unmarshal(idToken.Subject, req.subject); err != nil {
+			return nil, fmt.Errorf("invalid subject of the id_token_hint: %v", err)
+		}
+	}
+
+	if req.redirectURI == "" {
+		return req, nil
+	}
+	if req.clientID == "" {
+		return nil, errors.New("post_logout_redirect_uri requires client_id or id_token_hint")
+	}
+	client, err := s.storage.GetClient(req.clientID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, fmt.Errorf("unknown client %q", req.clientID)
+		}
+		return nil, fmt.Errorf("failed to get client %q: %v", req.clientID, err)
+	}
+	if !validateRedirectURI(client, req.redirectURI) && !s.matchRedirectURIPattern(client, req.redirectURI) {
+		return nil, fmt.Errorf("unregistered post_logout_redirect_uri %q for client %q", req.redirectURI, req.clientID)
+	}
+	return req, nil
+}
+
+// upstreamLogoutURL returns the URL logging the user out of the upstream
+// provider, or an empty string if the connector doesn't end upstream sessions.
+func (s *Server) upstreamLogoutURL(req *logoutRequest) (string, error) {
+	conn, err := s.getConnector(req.subject.ConnId)
+	if err != nil {
+		// The connector may have been removed since the login.
+		s.logger.Infof("Not logging out of connector %q: %v", req.subject.ConnId, err)
+		return "", nil
+	}
+	logoutConn, ok := conn.Connector.(connector.LogoutConnector)
+	if !ok {
+		return "", nil
+	}
+
+	identity := connector.Identity{UserID: req.subject.UserId}
+	session, err := s.storage.GetOfflineSessions(req.subject.UserId, req.subject.ConnId)
+	switch {
+	case err == nil:
+		identity.ConnectorData = session.ConnectorData
+	case err != storage.ErrNotFound:
+		return "", fmt.Errorf("failed to get offline session: %v", err)
+	}
+
+	// The provider sends the user back to this endpoint, which finishes the
+	// logout without the id_token_hint.
+	q := url.Values{}
+	for name, value := range map[string]string{
+		"client_id":                req.clientID,
+		"post_logout_redirect_uri": req.redirectURI,
+		"state":                    req.state,
+	} {
+		if value != "" {
+			q.Set(name, value)
+		}
+	}
+	returnURL := s.absURL("/logout")
+	if len(q) > 0 {
+		returnURL += "?" + q.Encode()
+	}
+	return logoutConn.LogoutURL(identity, returnURL)
+}
+
+// handleLogoutCallback handles the response of the upstream provider to the
+// logout, and sends the user back to the logout endpoint.
+func (s *Server) handleLogoutCallback(w http.ResponseWriter, r *http.Request) {
+	connID := mux.Vars(r)["connector"]
+	conn, err := s.getConnector(connID)
+	if err != nil {
+		s.logger.Errorf("Failed to get connector with id %q : %v", connID, err)
+		s.renderError(r, w, http.StatusBadRequest, "Requested resource does not exist.")
+		return
+	}
+	logoutConn, ok := conn.Connector.(connector.LogoutConnector)
+	if !ok {
+		s.renderError(r, w, http.StatusBadRequest, "Requested resource does not exist.")
+		return
+	}
+
+	returnURL, err := logoutConn.HandleLogoutResponse(r)
+	if err != nil {
+		s.logger.Errorf("Failed to log out of connector %q: %v", connID, err)
+		s.renderError(r, w, http.StatusInternalServerError, "Logout error.")
+		return
+	}
+
+	// The return URL comes back from the provider, only redirect to the
+	// logout endpoint, which validates the post logout redirect URI again.
+	u, err := url.Parse(returnURL)
+	if err != nil || u.Scheme+"://"+u.Host+u.Path != s.absURL("/logout") || u.User != nil || u.Fragment != "" {
+		s.logger.Errorf("Connector %q returned an invalid logout return URL %q", connID, returnURL)
+		s.renderError(r, w, http.StatusBadRequest, "Invalid logout request.")
+		return
+	}
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+// handleBackChannelLogout revokes the refresh tokens of the user or session
+// the upstream provider of a connector notifies the server of logging out.
+//
+// See: https://openid.net/specs/openid-connect-backchannel-1_0.html
+func (s *Server) handleBackChannelLogout(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	connID := mux.Vars(r)["connector"]
+	conn, err := s.getConnector(connID)
+	if err != nil {
+		s.logger.Errorf("Failed to get connector with id %q : %v", connID, err)
+		s.tokenErrHelper(w, errInvalidRequest, "Requested resource does not exist.", http.StatusNotFound)
+		return
+	}
+	logoutConn, ok := conn.Connector.(connector.BackChannelLogoutConnector)
+	if !ok {
+		s.tokenErrHelper(w, errInvalidRequest, "Requested resource does not exist.", http.StatusNotFound)
+		return
+	}
+
+	loggedOut, err := logoutConn.HandleBackChannelLogout(r)
+	if err != nil {
+		s.logger.Errorf("Invalid back-channel logout of connector %q: %v", connID, err)
+		s.tokenErrHelper(w, errInvalidRequest, "Invalid logout token.", http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := s.revokeLoggedOutRefreshTokens(connID, logoutConn, loggedOut)
+	if err != nil {
+		s.logger.Errorf("Failed to revoke the refresh tokens of a back-channel logout of connector %q: %v", connID, err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+	s.logger.Infof("Back-channel logout of connector %q revoked %d refresh tokens", connID, revoked)
+	w.WriteHeader(http.StatusOK)
+}
+
+// revokeLoggedOutRefreshTokens deletes the refresh tokens of the logins of the
+// connector which the connector reports as belonging to the logged out session,
+// and returns how many it deleted.
+func (s *Server) revokeLoggedOutRefreshTokens(connID string, conn connector.BackChannelLogoutConnector, loggedOut connector.Identity) (int, error) {
+	tokens, err := s.storage.ListRefreshTokens()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list refresh tokens: %v", err)
+	}
+	byUser := make(map[string][]storage.RefreshToken)
+	for _, token := range tokens {
+		if token.ConnectorID == connID {
+			byUser[token.Claims.UserID] = append(byUser[token.Claims.UserID], token)
+		}
+	}
+
+	revoked := 0
+	for userID, userTokens := range byUser {
+		// The connector data of refresh tokens moved to the offline session.
+		var sessionData []byte
+		session, err := s.storage.GetOfflineSessions(userID, connID)
+		switch {
+		case err == nil:
+			sessionData = session.ConnectorData
+		case err != storage.ErrNotFound:
+			return revoked, fmt.Errorf("failed to get offline session: %v", err)
+		}
+
+		loggedOutIDs := make(map[string]bool)
+		for _, token := range userTokens {
+			connData := token.ConnectorData
+			if len(connData) == 0 {
+				connData = sessionData
+			}
+			if conn.InSession(loggedOut, connector.Identity{UserID: userID, ConnectorData: connData}) {
+				loggedOutIDs[token.ID] = true
+			}
+		}
+		if len(loggedOutIDs) == 0 {
+			continue
+		}
+
+		updater := func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
+			for clientID, ref := range old.Refresh {
+				if ref != nil && loggedOutIDs[ref.ID] {
+					delete(old.Refresh, clientID)
+				}
+			}
+			return old, nil
+		}
+		if err := s.storage.UpdateOfflineSessions(userID, connID, updater); err != nil && err != storage.ErrNotFound {
+			return revoked, fmt.Errorf("failed to update offline session: %v", err)
+		}
+		for id := range loggedOutIDs {
+			if err := s.storage.DeleteRefresh(id); err != nil && err != storage.ErrNotFound {
+				return revoked, fmt.Errorf("failed to delete refresh token: %v", err)
+			}
+			revoked++
+		}
+	}
+	return revoked, nil
+}
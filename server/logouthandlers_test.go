@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/storage"
+)
+
+// sessionConnector logs out the logins with the user ID and the connector data
+// of the logged out identity, where they're set.
+type sessionConnector struct{}
+
+func (sessionConnector) HandleBackChannelLogout(r *http.Request) (connector.Identity, error) {
+	return connector.Identity{}, nil
+}
+
+func (sessionConnector) InSession(loggedOut, identity connector.Identity) bool {
+	return (loggedOut.UserID == "" || loggedOut.UserID == identity.UserID) &&
+		(loggedOut.ConnectorData == nil || bytes.Equal(loggedOut.ConnectorData, identity.ConnectorData))
+}
+
+func TestRevokeLoggedOutRefreshTokens(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	// Jane logged in to two clients in the first session, and to a third one
+	// in the second session, whose connector data is kept in the offline
+	// session. John logged in with the same connector, and Jane with another
+	// connector.
+	tokens := []storage.RefreshToken{
+		{ID: "jane-a", ClientID: "a", ConnectorID: "mock", Claims: storage.Claims{UserID: "jane"}, ConnectorData: []byte("session-1")},
+		{ID: "jane-b", ClientID: "b", ConnectorID: "mock", Claims: storage.Claims{UserID: "jane"}, ConnectorData: []byte("session-1")},
+		{ID: "jane-c", ClientID: "c", ConnectorID: "mock", Claims: storage.Claims{UserID: "jane"}},
+		{ID: "john-a", ClientID: "a", ConnectorID: "mock", Claims: storage.Claims{UserID: "john"}, ConnectorData: []byte("session-3")},
+		{ID: "jane-other", ClientID: "a", ConnectorID: "other", Claims: storage.Claims{UserID: "jane"}, ConnectorData: []byte("session-1")},
+	}
+	sessions := make(map[[2]string]*storage.OfflineSessions)
+	for _, token := range tokens {
+		token.CreatedAt = time.Now()
+		token.LastUsed = token.CreatedAt
+		require.NoError(t, s.storage.CreateRefresh(token))
+
+		key := [2]string{token.Claims.UserID, token.ConnectorID}
+		if sessions[key] == nil {
+			sessions[key] = &storage.OfflineSessions{
+				UserID:  token.Claims.UserID,
+				ConnID:  token.ConnectorID,
+				Refresh: make(map[string]*storage.RefreshTokenRef),
+			}
+		}
+		sessions[key].Refresh[token.ClientID] = &storage.RefreshTokenRef{ID: token.ID, ClientID: token.ClientID}
+	}
+	sessions[[2]string{"jane", "mock"}].ConnectorData = []byte("session-2")
+	for _, session := range sessions {
+		require.NoError(t, s.storage.CreateOfflineSessions(*session))
+	}
+
+	remaining := func() []string {
+		tokens, err := s.storage.ListRefreshTokens()
+		require.NoError(t, err)
+		var ids []string
+		for _, token := range tokens {
+			ids = append(ids, token.ID)
+		}
+		return ids
+	}
+
+	// Logging out of the first session keeps the login of the second one.
+	revoked, err := s.revokeLoggedOutRefreshTokens("mock", sessionConnector{}, connector.Identity{UserID: "jane", ConnectorData: []byte("session-1")})
+	require.NoError(t, err)
+	require.Equal(t, 2, revoked)
+	require.ElementsMatch(t, []string{"jane-c", "john-a", "jane-other"}, remaining())
+
+	session, err := s.storage.GetOfflineSessions("jane", "mock")
+	require.NoError(t, err)
+	require.Len(t, session.Refresh, 1)
+	require.Equal(t, "jane-c", session.Refresh["c"].ID)
+
+	// Logging out of the user logs out of all sessions of the user.
+	revoked, err = s.revokeLoggedOutRefreshTokens("mock", sessionConnector{}, connector.Identity{UserID: "jane"})
+	require.NoError(t, err)
+	require.Equal(t, 1, revoked)
+	require.ElementsMatch(t, []string{"john-a", "jane-other"}, remaining())
+
+	// A session without any login is nothing to revoke.
+	revoked, err = s.revokeLoggedOutRefreshTokens("mock", sessionConnector{}, connector.Identity{ConnectorData: []byte("session-4")})
+	require.NoError(t, err)
+	require.Equal(t, 0, revoked)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/hmac"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -16,6 +17,9 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -99,22 +103,30 @@ const (
 	errAccessDenied            = "access_denied"
 	errUnsupportedResponseType = "unsupported_response_type"
 	errRequestNotSupported     = "request_not_supported"
+	errRequestURINotSupported  = "request_uri_not_supported"
 	errInvalidScope            = "invalid_scope"
 	errServerError             = "server_error"
 	errTemporarilyUnavailable  = "temporarily_unavailable"
 	errUnsupportedGrantType    = "unsupported_grant_type"
 	errInvalidGrant            = "invalid_grant"
 	errInvalidClient           = "invalid_client"
+	errInvalidTarget           = "invalid_target"
+	errInteractionRequired     = "interaction_required"
 )
 
 const (
 	scopeOfflineAccess     = "offline_access" // Request a refresh token.
 	scopeOpenID            = "openid"
 	scopeGroups            = "groups"
+	scopeOrganizations     = "organizations"
+	scopeAddress           = "address"
 	scopeEmail             = "email"
 	scopeProfile           = "profile"
 	scopeFederatedID       = "federated:id"
 	scopeCrossClientPrefix = "audience:server:client_id:"
+	// Request a scope from the upstream provider the user logs in through,
+	// for connectors allowing it, for example "upstream:groups".
+	scopeUpstreamPrefix = "upstream:"
 )
 
 const (
@@ -125,6 +137,11 @@ const (
 	redirectURIOOB = "urn:ietf:wg:oauth:2.0:oob"
 )
 
+const (
+	promptSelectAccount = "select_account" // Let the user switch accounts.
+	promptNone          = "none"           // Don't interact with the user.
+)
+
 const (
 	grantTypeAuthorizationCode = "authorization_code"
 	grantTypeRefreshToken      = "refresh_token"
@@ -154,11 +171,168 @@ func parseScopes(scopes []string) connector.Scopes {
 			s.OfflineAccess = true
 		case scopeGroups:
 			s.Groups = true
+		case scopeOrganizations:
+			s.Organizations = true
+		default:
+			if upstreamScope, ok := parseUpstreamScope(scope); ok {
+				s.UpstreamScopes = append(s.UpstreamScopes, upstreamScope)
+			}
 		}
 	}
 	return s
 }
 
+// parseUpstreamScope returns the scope of the upstream provider an upstream
+// scope requests.
+func parseUpstreamScope(scope string) (upstreamScope string, ok bool) {
+	if !strings.HasPrefix(scope, scopeUpstreamPrefix) {
+		return "", false
+	}
+	upstreamScope = scope[len(scopeUpstreamPrefix):]
+	return upstreamScope, upstreamScope != ""
+}
+
+// connectorScopes returns the scopes passed to the connector logging in the
+// end user of the auth request.
+func connectorScopes(authReq storage.AuthRequest) connector.Scopes {
+	scopes := parseScopes(authReq.Scopes)
+	scopes.SelectAccount = hasPrompt(authReq.Prompt, promptSelectAccount)
+	scopes.PromptNone = hasPrompt(authReq.Prompt, promptNone)
+	scopes.ACRValues = authReq.ACRValues
+	scopes.MaxAge = authReq.MaxAge
+	return scopes
+}
+
+// hasPrompt reports whether the space delimited prompt parameter of a request
+// contains the given value.
+func hasPrompt(prompt, value string) bool {
+	for _, p := range strings.Fields(prompt) {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedClaims are the claims dex sets. Custom claims can't set them, even
+// if dex leaves them out of a token, for example groups without the "groups"
+// scope.
+var reservedClaims = func() map[string]bool {
+	reserved := make(map[string]bool)
+	t := reflect.TypeOf(idTokenClaims{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		reserved[name] = true
+	}
+	return reserved
+}()
+
+// marshalClaims serializes the claims of a token along with the custom claims
+// of the user.
+func marshalClaims(tok idTokenClaims, custom map[string]interface{}) ([]byte, error) {
+	payload, err := json.Marshal(tok)
+	if err != nil || len(custom) == 0 {
+		return payload, err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(payload, &merged); err != nil {
+		return nil, err
+	}
+	for k, v := range custom {
+		if !reservedClaims[k] {
+			merged[k] = v
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// storageAddress converts the address of a connector identity into the one
+// stored with the claims of the user.
+func storageAddress(a *connector.Address) *storage.Address {
+	if a == nil {
+		return nil
+	}
+	return &storage.Address{
+		Formatted:     a.Formatted,
+		StreetAddress: a.StreetAddress,
+		Locality:      a.Locality,
+		Region:        a.Region,
+		PostalCode:    a.PostalCode,
+		Country:       a.Country,
+	}
+}
+
+// connectorAddress is the inverse of storageAddress.
+func connectorAddress(a *storage.Address) *connector.Address {
+	if a == nil {
+		return nil
+	}
+	return &connector.Address{
+		Formatted:     a.Formatted,
+		StreetAddress: a.StreetAddress,
+		Locality:      a.Locality,
+		Region:        a.Region,
+		PostalCode:    a.PostalCode,
+		Country:       a.Country,
+	}
+}
+
+// parseEssentialClaims returns the names of the claims marked as essential in
+// the "claims" parameter of a request.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#ClaimsParameter
+func parseEssentialClaims(param string) ([]string, error) {
+	if param == "" {
+		return nil, nil
+	}
+	var req map[string]map[string]*struct {
+		Essential bool `json:"essential"`
+	}
+	if err := json.Unmarshal([]byte(param), &req); err != nil {
+		return nil, err
+	}
+
+	var essential []string
+	for _, member := range []string{"userinfo", "id_token"} {
+		for name, claim := range req[member] {
+			if claim != nil && claim.Essential && !contains(essential, name) {
+				essential = append(essential, name)
+			}
+		}
+	}
+	sort.Strings(essential)
+	return essential, nil
+}
+
+// missingEssentialClaims returns the essential claims which tokens issued for
+// the scopes and claims would lack.
+func missingEssentialClaims(essential, scopes []string, claims storage.Claims) []string {
+	var missing []string
+	for _, name := range essential {
+		var ok bool
+		switch name {
+		case "sub", "auth_time":
+			ok = true
+		case "email", "email_verified":
+			ok = contains(scopes, scopeEmail) && claims.Email != ""
+		case "groups":
+			ok = contains(scopes, scopeGroups) && len(claims.Groups) > 0
+		case "organizations":
+			ok = contains(scopes, scopeOrganizations) && len(claims.Organizations) > 0
+		case "address":
+			ok = contains(scopes, scopeAddress) && claims.Address != nil
+		case "name":
+			ok = contains(scopes, scopeProfile) && claims.Username != ""
+		case "preferred_username":
+			ok = contains(scopes, scopeProfile) && claims.PreferredUsername != ""
+		}
+		if !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
 // Determine the signature algorithm for a JWT.
 func signatureAlgorithm(jwk *jose.JSONWebKey) (alg jose.SignatureAlgorithm, err error) {
 	if jwk.Key == nil {
@@ -265,7 +439,10 @@ type idTokenClaims struct {
 	Audience         audience `json:"aud"`
 	Expiry           int64    `json:"exp"`
 	IssuedAt         int64    `json:"iat"`
+	AuthTime         int64    `json:"auth_time,omitempty"`
+	JWTID            string   `json:"jti"`
 	AuthorizingParty string   `json:"azp,omitempty"`
+	ClientID         string   `json:"client_id,omitempty"`
 	Nonce            string   `json:"nonce,omitempty"`
 
 	AccessTokenHash string `json:"at_hash,omitempty"`
@@ -276,6 +453,10 @@ type idTokenClaims struct {
 
 	Groups []string `json:"groups,omitempty"`
 
+	Organizations []string `json:"organizations,omitempty"`
+
+	Address *storage.Address `json:"address,omitempty"`
+
 	Name              string `json:"name,omitempty"`
 	PreferredUsername string `json:"preferred_username,omitempty"`
 
@@ -287,12 +468,18 @@ type federatedIDClaims struct {
 	UserID      string `json:"user_id,omitempty"`
 }
 
-func (s *Server) newAccessToken(clientID string, claims storage.Claims, scopes []string, nonce, connID string) (accessToken string, err error) {
-	idToken, _, err := s.newIDToken(clientID, claims, scopes, nonce, storage.NewID(), "", connID)
+// newAccessToken returns an access token for the client. Any requested resource
+// indicators (RFC 8707) are added to the token's audience.
+func (s *Server) newAccessToken(client storage.Client, claims storage.Claims, scopes, resources []string, nonce, connID string) (accessToken string, err error) {
+	idToken, _, err := s.newToken(client, claims, scopes, resources, nonce, storage.NewID(), "", connID)
 	return idToken, err
 }
 
-func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
+func (s *Server) newIDToken(client storage.Client, claims storage.Claims, scopes []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
+	return s.newToken(client, claims, scopes, nil, nonce, accessToken, code, connID)
+}
+
+func (s *Server) newToken(client storage.Client, claims storage.Claims, scopes, resources []string, nonce, accessToken, code, connID string) (idToken string, expiry time.Time, err error) {
 	keys, err := s.storage.GetKeys()
 	if err != nil {
 		s.logger.Errorf("Failed to get keys: %v", err)
@@ -328,6 +515,15 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 		Nonce:    nonce,
 		Expiry:   expiry.Unix(),
 		IssuedAt: issuedAt.Unix(),
+		JWTID:    storage.NewID(),
+	}
+
+	if !claims.AuthTime.IsZero() {
+		tok.AuthTime = claims.AuthTime.Unix()
+	}
+
+	if s.emitClientIDClaim {
+		tok.ClientID = client.ID
 	}
 
 	if accessToken != "" {
@@ -354,7 +550,15 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 			tok.Email = claims.Email
 			tok.EmailVerified = &claims.EmailVerified
 		case scope == scopeGroups:
-			tok.Groups = claims.Groups
+			groups, err := s.clientGroups(client, claims.Groups)
+			if err != nil {
+				return "", expiry, err
+			}
+			tok.Groups = groups
+		case scope == scopeOrganizations:
+			tok.Organizations = claims.Organizations
+		case scope == scopeAddress:
+			tok.Address = claims.Address
 		case scope == scopeProfile:
 			tok.Name = claims.Username
 			tok.PreferredUsername = claims.PreferredUsername
@@ -370,7 +574,7 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 				// initial auth request.
 				continue
 			}
-			isTrusted, err := s.validateCrossClientTrust(clientID, peerID)
+			isTrusted, err := s.validateCrossClientTrust(client.ID, peerID)
 			if err != nil {
 				return "", expiry, err
 			}
@@ -382,22 +586,28 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 		}
 	}
 
+	for _, resource := range resources {
+		if !tok.Audience.contains(resource) {
+			tok.Audience = append(tok.Audience, resource)
+		}
+	}
+
 	if len(tok.Audience) == 0 {
 		// Client didn't ask for cross client audience. Set the current
 		// client as the audience.
-		tok.Audience = audience{clientID}
+		tok.Audience = audience{client.ID}
 	} else {
 		// Client asked for cross client audience:
 		// if the current client was not requested explicitly
-		if !tok.Audience.contains(clientID) {
+		if !tok.Audience.contains(client.ID) {
 			// by default it becomes one of entries in Audience
-			tok.Audience = append(tok.Audience, clientID)
+			tok.Audience = append(tok.Audience, client.ID)
 		}
 		// The current client becomes the authorizing party.
-		tok.AuthorizingParty = clientID
+		tok.AuthorizingParty = client.ID
 	}
 
-	payload, err := json.Marshal(tok)
+	payload, err := marshalClaims(tok, claims.CustomClaims)
 	if err != nil {
 		return "", expiry, fmt.Errorf("could not serialize claims: %v", err)
 	}
@@ -405,21 +615,173 @@ func (s *Server) newIDToken(clientID string, claims storage.Claims, scopes []str
 	if idToken, err = signPayload(signingKey, signingAlg, payload); err != nil {
 		return "", expiry, fmt.Errorf("failed to sign payload: %v", err)
 	}
+
+	if s.trackIssuedTokens {
+		issued := storage.IssuedToken{
+			ID:          tok.JWTID,
+			ClientID:    client.ID,
+			UserID:      claims.UserID,
+			ConnectorID: connID,
+			IssuedAt:    issuedAt,
+			Expiry:      expiry,
+		}
+		if err := s.storage.CreateIssuedToken(issued); err != nil {
+			s.logger.Errorf("failed to record issued token: %v", err)
+			return "", expiry, fmt.Errorf("failed to record issued token: %v", err)
+		}
+	}
 	return idToken, expiry, nil
 }
 
+// parseResources returns the resource indicators (RFC 8707) of a token request.
+// Every requested resource must be registered for the client.
+func parseResources(r *http.Request, client storage.Client) ([]string, error) {
+	resources := r.PostForm["resource"]
+	if err := validateResources(client, resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func validateResources(client storage.Client, resources []string) error {
+	for _, resource := range resources {
+		if !contains(client.Resources, resource) {
+			return fmt.Errorf("resource %q is not registered for client %q", resource, client.ID)
+		}
+	}
+	return nil
+}
+
+// narrowResources returns the resources a token request is issued for. A
+// request that names no resource receives the whole grant; otherwise every
+// requested resource must be part of it (RFC 8707, Section 2.2).
+func narrowResources(requested, granted []string) ([]string, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	for _, resource := range requested {
+		if !contains(granted, resource) {
+			return nil, fmt.Errorf("resource %q was not part of the original grant", resource)
+		}
+	}
+	return requested, nil
+}
+
+// clientGroups returns the groups to emit in tokens issued to the given client.
+func (s *Server) clientGroups(client storage.Client, groups []string) ([]string, error) {
+	groups, err := allowedGroups(client, groups)
+	if err != nil {
+		return nil, err
+	}
+	if !client.HashGroups || len(groups) == 0 {
+		return groups, nil
+	}
+	if s.groupsHashSalt == "" {
+		return nil, fmt.Errorf("client %q hashes groups but no groups hash salt is configured", client.ID)
+	}
+
+	hashed := make([]string, len(groups))
+	for i, group := range groups {
+		hashed[i] = hashGroup(s.groupsHashSalt, client.GroupsSalt, group)
+	}
+	return hashed, nil
+}
+
+// allowedGroups returns the groups the client may receive: the groups with
+// one of its allowed prefixes or matching its allowed groups pattern.
+func allowedGroups(client storage.Client, groups []string) ([]string, error) {
+	if len(client.AllowedGroupPrefixes) == 0 && client.AllowedGroupsPattern == "" {
+		return groups, nil
+	}
+	var pattern *regexp.Regexp
+	if client.AllowedGroupsPattern != "" {
+		var err error
+		if pattern, err = regexp.Compile("^(?:" + client.AllowedGroupsPattern + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid allowed groups pattern of client %q: %v", client.ID, err)
+		}
+	}
+
+	var allowed []string
+	for _, group := range groups {
+		if hasAnyPrefix(group, client.AllowedGroupPrefixes) || (pattern != nil && pattern.MatchString(group)) {
+			allowed = append(allowed, group)
+		}
+	}
+	return allowed, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashGroup returns a stable, pseudonymous identifier for a group name.
+func hashGroup(serverSalt, clientSalt, group string) string {
+	h := hmac.New(sha256.New, []byte(serverSalt))
+	h.Write([]byte(clientSalt))
+	h.Write([]byte{0})
+	h.Write([]byte(group))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
 // parse the initial request from the OAuth2 client.
 func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthRequest, error) {
+	authReq, _, err := s.resolveAuthorizationRequest(r)
+	return authReq, err
+}
+
+// resolveAuthorizationRequest parses the initial request from the OAuth2
+// client, and also returns its parameters, which are those of the pushed
+// authorization request or of the request object it references, if any.
+func (s *Server) resolveAuthorizationRequest(r *http.Request) (*storage.AuthRequest, url.Values, error) {
 	if err := r.ParseForm(); err != nil {
-		return nil, newDisplayedErr(http.StatusBadRequest, "Failed to parse request.")
+		return nil, nil, newDisplayedErr(http.StatusBadRequest, "Failed to parse request.")
 	}
 	q := r.Form
+	clientID := q.Get("client_id")
+	client, err := s.storage.GetClient(clientID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, nil, newDisplayedErr(http.StatusNotFound, "Invalid client_id (%q).", clientID)
+		}
+		s.logger.Errorf("Failed to get client: %v", err)
+		return nil, nil, newDisplayedErr(http.StatusInternalServerError, "Database error.")
+	}
+
+	// The parameters of a pushed authorization request or of a request object
+	// passed by reference replace the query parameters.
+	switch requestURI := q.Get("request_uri"); {
+	case strings.HasPrefix(requestURI, pushedRequestURIPrefix):
+		if q, err = s.resolvePushedRequestURI(client, requestURI); err != nil {
+			s.logger.Errorf("Failed to resolve request_uri %q: %v", requestURI, err)
+			return nil, nil, newDisplayedErr(http.StatusBadRequest, "Invalid request_uri.")
+		}
+	case requestURI != "" && len(s.requestURIPrefixes) > 0:
+		if q.Get("request") != "" {
+			return nil, nil, newDisplayedErr(http.StatusBadRequest, "Only one of request and request_uri can be provided.")
+		}
+		if q, err = s.resolveRequestURI(r.Context(), client, requestURI); err != nil {
+			s.logger.Errorf("Failed to resolve request_uri %q: %v", requestURI, err)
+			return nil, nil, newDisplayedErr(http.StatusBadRequest, "Invalid request_uri.")
+		}
+	}
+	authReq, err := s.parseAuthorizationParams(client, q)
+	return authReq, q, err
+}
+
+// parseAuthorizationParams validates the parameters of an authorization
+// request of the client.
+func (s *Server) parseAuthorizationParams(client storage.Client, q url.Values) (*storage.AuthRequest, error) {
+	clientID := client.ID
 	redirectURI, err := url.QueryUnescape(q.Get("redirect_uri"))
 	if err != nil {
 		return nil, newDisplayedErr(http.StatusBadRequest, "No redirect_uri provided.")
 	}
 
-	clientID := q.Get("client_id")
 	state := q.Get("state")
 	nonce := q.Get("nonce")
 	connectorID := q.Get("connector_id")
@@ -434,16 +796,7 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		codeChallengeMethod = codeChallengeMethodPlain
 	}
 
-	client, err := s.storage.GetClient(clientID)
-	if err != nil {
-		if err == storage.ErrNotFound {
-			return nil, newDisplayedErr(http.StatusNotFound, "Invalid client_id (%q).", clientID)
-		}
-		s.logger.Errorf("Failed to get client: %v", err)
-		return nil, newDisplayedErr(http.StatusInternalServerError, "Database error.")
-	}
-
-	if !validateRedirectURI(client, redirectURI) {
+	if !validateRedirectURI(client, redirectURI) && !s.matchRedirectURIPattern(client, redirectURI) {
 		return nil, newDisplayedErr(http.StatusBadRequest, "Unregistered redirect_uri (%q).", redirectURI)
 	}
 	if redirectURI == deviceCallbackURI && client.Public {
@@ -471,12 +824,20 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 	if q.Get("request") != "" {
 		return nil, newRedirectedErr(errRequestNotSupported, "Server does not support request parameter.")
 	}
+	if q.Get("request_uri") != "" {
+		return nil, newRedirectedErr(errRequestURINotSupported, "Server does not support request_uri parameter.")
+	}
 
 	if codeChallengeMethod != codeChallengeMethodS256 && codeChallengeMethod != codeChallengeMethodPlain {
 		description := fmt.Sprintf("Unsupported PKCE challenge method (%q).", codeChallengeMethod)
 		return nil, newRedirectedErr(errInvalidRequest, description)
 	}
 
+	resources := q["resource"]
+	if err := validateResources(client, resources); err != nil {
+		return nil, newRedirectedErr(errInvalidTarget, "%v", err)
+	}
+
 	var (
 		unrecognized  []string
 		invalidScopes []string
@@ -486,8 +847,12 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		switch scope {
 		case scopeOpenID:
 			hasOpenIDScope = true
-		case scopeOfflineAccess, scopeEmail, scopeProfile, scopeGroups, scopeFederatedID:
+		case scopeOfflineAccess, scopeEmail, scopeProfile, scopeGroups, scopeOrganizations, scopeAddress, scopeFederatedID:
 		default:
+			// Connectors drop the upstream scopes they don't allow.
+			if _, ok := parseUpstreamScope(scope); ok {
+				continue
+			}
 			peerID, ok := parseCrossClientScope(scope)
 			if !ok {
 				unrecognized = append(unrecognized, scope)
@@ -562,6 +927,22 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		}
 	}
 
+	var maxAge *int
+	if v := q.Get("max_age"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			return nil, newRedirectedErr(errInvalidRequest, "Invalid max_age value %q", v)
+		}
+		maxAge = &seconds
+	}
+
+	var essentialClaims []string
+	if s.enforceEssentialClaims {
+		if essentialClaims, err = parseEssentialClaims(q.Get("claims")); err != nil {
+			return nil, newRedirectedErr(errInvalidRequest, "Invalid claims parameter: %v", err)
+		}
+	}
+
 	return &storage.AuthRequest{
 		ID:                  storage.NewID(),
 		ClientID:            client.ID,
@@ -571,6 +952,11 @@ func (s *Server) parseAuthorizationRequest(r *http.Request) (*storage.AuthReques
 		Scopes:              scopes,
 		RedirectURI:         redirectURI,
 		ResponseTypes:       responseTypes,
+		Resources:           resources,
+		Prompt:              q.Get("prompt"),
+		EssentialClaims:     essentialClaims,
+		ACRValues:           strings.Fields(q.Get("acr_values")),
+		MaxAge:              maxAge,
 		ConnectorID:         connectorID,
 		PKCE: storage.PKCE{
 			CodeChallenge:       codeChallenge,
@@ -639,6 +1025,35 @@ func validateRedirectURI(client storage.Client, redirectURI string) bool {
 	return err == nil && host == "localhost"
 }
 
+// matchRedirectURIPattern reports whether the redirect URI has the scheme and
+// host of a registered redirect URI and a path matching the client's redirect
+// URI path pattern.
+func (s *Server) matchRedirectURIPattern(client storage.Client, redirectURI string) bool {
+	if !s.allowRedirectURIPatterns || client.RedirectURIPathPattern == "" {
+		return false
+	}
+	pattern, err := regexp.Compile("^(?:" + client.RedirectURIPathPattern + ")$")
+	if err != nil {
+		s.logger.Errorf("Invalid redirect URI path pattern of client %q: %v", client.ID, err)
+		return false
+	}
+
+	u, err := url.Parse(redirectURI)
+	if err != nil || u.User != nil || u.Fragment != "" || u.Opaque != "" {
+		return false
+	}
+	if !pattern.MatchString(u.Path) {
+		return false
+	}
+	for _, uri := range client.RedirectURIs {
+		registered, err := url.Parse(uri)
+		if err == nil && registered.Scheme == u.Scheme && registered.Host == u.Host {
+			return true
+		}
+	}
+	return false
+}
+
 func validateConnectorID(connectors []storage.Connector, connectorID string) bool {
 	for _, c := range connectors {
 		if c.ID == connectorID {
@@ -4,14 +4,18 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
 	"gopkg.in/square/go-jose.v2"
 
+	"github.com/dexidp/dex/connector"
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/memory"
 )
@@ -128,6 +132,43 @@ func TestParseAuthorizationRequest(t *testing.T) {
 			},
 			expectedError: &redirectedAuthErr{Type: errUnsupportedResponseType},
 		},
+		{
+			name: "registered resource",
+			clients: []storage.Client{
+				{
+					ID:           "bar",
+					RedirectURIs: []string{"https://example.com/bar"},
+					Resources:    []string{"https://api.example.com"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email profile",
+				"resource":      "https://api.example.com",
+			},
+		},
+		{
+			name: "unregistered resource",
+			clients: []storage.Client{
+				{
+					ID:           "bar",
+					RedirectURIs: []string{"https://example.com/bar"},
+					Resources:    []string{"https://api.example.com"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email profile",
+				"resource":      "https://evil.example.com",
+			},
+			expectedError: &redirectedAuthErr{Type: errInvalidTarget},
+		},
 		{
 			name: "only token response type",
 			clients: []storage.Client{
@@ -287,6 +328,58 @@ func TestParseAuthorizationRequest(t *testing.T) {
 			},
 			expectedError: &redirectedAuthErr{Type: errInvalidRequest},
 		},
+		{
+			name: "Negative max_age",
+			clients: []storage.Client{
+				{
+					ID:           "bar",
+					RedirectURIs: []string{"https://example.com/bar"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email profile",
+				"acr_values":    "urn:example:mfa",
+				"max_age":       "-1",
+			},
+			expectedError: &redirectedAuthErr{Type: errInvalidRequest},
+		},
+		{
+			name: "upstream scope",
+			clients: []storage.Client{
+				{
+					ID:           "bar",
+					RedirectURIs: []string{"https://example.com/bar"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email upstream:groups",
+			},
+		},
+		{
+			name: "empty upstream scope",
+			clients: []storage.Client{
+				{
+					ID:           "bar",
+					RedirectURIs: []string{"https://example.com/bar"},
+				},
+			},
+			supportedResponseTypes: []string{"code"},
+			queryParams: map[string]string{
+				"client_id":     "bar",
+				"redirect_uri":  "https://example.com/bar",
+				"response_type": "code",
+				"scope":         "openid email upstream:",
+			},
+			expectedError: &redirectedAuthErr{Type: errInvalidScope},
+		},
 	}
 
 	for _, tc := range tests {
@@ -364,6 +457,24 @@ func TestAccessTokenHash(t *testing.T) {
 	}
 }
 
+func TestHasPrompt(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   bool
+	}{
+		{prompt: "", want: false},
+		{prompt: "select_account", want: true},
+		{prompt: "login select_account", want: true},
+		{prompt: "login consent", want: false},
+		{prompt: "select_accounts", want: false},
+	}
+	for _, tc := range tests {
+		if got := hasPrompt(tc.prompt, promptSelectAccount); got != tc.want {
+			t.Errorf("hasPrompt(%q): expected %t, got %t", tc.prompt, tc.want, got)
+		}
+	}
+}
+
 func TestValidRedirectURI(t *testing.T) {
 	tests := []struct {
 		client      storage.Client
@@ -544,6 +655,58 @@ func TestValidRedirectURI(t *testing.T) {
 	}
 }
 
+func TestRedirectURIPathPattern(t *testing.T) {
+	client := storage.Client{
+		ID:                     "dynamic",
+		RedirectURIs:           []string{"https://apps.example.com/callback"},
+		RedirectURIPathPattern: "/apps/[a-z0-9-]+/callback",
+	}
+
+	tests := []struct {
+		redirectURI string
+		allow       bool
+		wantValid   bool
+	}{
+		{redirectURI: "https://apps.example.com/callback", allow: true, wantValid: true},
+		{redirectURI: "https://apps.example.com/apps/team-a/callback", allow: true, wantValid: true},
+		{redirectURI: "https://apps.example.com/apps/team-a/callback", allow: false, wantValid: false},
+		{redirectURI: "https://evil.example.com/apps/team-a/callback", allow: true, wantValid: false},
+		{redirectURI: "http://apps.example.com/apps/team-a/callback", allow: true, wantValid: false},
+		{redirectURI: "https://apps.example.com/apps/team-a/callback/extra", allow: true, wantValid: false},
+		{redirectURI: "https://user@apps.example.com/apps/team-a/callback", allow: true, wantValid: false},
+	}
+	for _, tc := range tests {
+		func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			httpServer, server := newTestServer(ctx, t, func(c *Config) {
+				c.Storage = storage.WithStaticClients(c.Storage, []storage.Client{client})
+				c.AllowRedirectURIPatterns = tc.allow
+			})
+			defer httpServer.Close()
+
+			params := url.Values{
+				"client_id":     {client.ID},
+				"redirect_uri":  {tc.redirectURI},
+				"response_type": {"code"},
+				"scope":         {"openid"},
+			}
+			req := httptest.NewRequest("GET", httpServer.URL+"/auth?"+params.Encode(), nil)
+
+			_, err := server.parseAuthorizationRequest(req)
+			if tc.wantValid && err != nil {
+				t.Errorf("redirectURI=%q, allow=%t: expected no error, got %v", tc.redirectURI, tc.allow, err)
+			}
+			if !tc.wantValid {
+				if e, ok := err.(*displayedAuthErr); !ok || e.Status != http.StatusBadRequest {
+					t.Errorf("redirectURI=%q, allow=%t: expected unregistered redirect_uri error, got %v", tc.redirectURI, tc.allow, err)
+				}
+			}
+		}()
+	}
+}
+
 func TestStorageKeySet(t *testing.T) {
 	s := memory.New(logger)
 	if err := s.UpdateKeys(func(keys storage.Keys) (storage.Keys, error) {
@@ -627,3 +790,288 @@ func TestStorageKeySet(t *testing.T) {
 		})
 	}
 }
+
+// idTokenPayload returns the claims of a token issued by the test server.
+func idTokenPayload(t *testing.T, token string) map[string]interface{} {
+	t.Helper()
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	payload, err := jws.Verify(testKey.Public())
+	if err != nil {
+		t.Fatalf("failed to verify token: %v", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("failed to decode token claims: %v", err)
+	}
+	return claims
+}
+
+func TestHashedGroups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.GroupsHashSalt = "server-salt"
+	})
+	defer httpServer.Close()
+
+	clients := map[string]storage.Client{
+		"plain":    {ID: "plain"},
+		"hashed-a": {ID: "hashed-a", HashGroups: true, GroupsSalt: "salt-a"},
+		"hashed-b": {ID: "hashed-b", HashGroups: true, GroupsSalt: "salt-b"},
+	}
+
+	claims := storage.Claims{UserID: "user", Groups: []string{"admins", "devs"}}
+	groupsFor := func(clientID string) []interface{} {
+		token, _, err := s.newIDToken(clients[clientID], claims, []string{"openid", "groups"}, "", "", "", "mock")
+		if err != nil {
+			t.Fatalf("failed to create id token: %v", err)
+		}
+		groups, _ := idTokenPayload(t, token)["groups"].([]interface{})
+		return groups
+	}
+
+	if got := groupsFor("plain"); !reflect.DeepEqual(got, []interface{}{"admins", "devs"}) {
+		t.Errorf("expected plain groups, got %v", got)
+	}
+
+	hashedA := groupsFor("hashed-a")
+	if len(hashedA) != 2 || hashedA[0] == "admins" || hashedA[0] == hashedA[1] {
+		t.Fatalf("expected distinct hashed groups, got %v", hashedA)
+	}
+	if again := groupsFor("hashed-a"); !reflect.DeepEqual(hashedA, again) {
+		t.Errorf("expected stable hashes for the same client, got %v and %v", hashedA, again)
+	}
+	if hashedB := groupsFor("hashed-b"); reflect.DeepEqual(hashedA, hashedB) {
+		t.Errorf("expected clients with different salts to receive different hashes, got %v", hashedB)
+	}
+
+	s.groupsHashSalt = ""
+	if _, _, err := s.newIDToken(clients["hashed-a"], claims, []string{"openid", "groups"}, "", "", "", "mock"); err == nil {
+		t.Errorf("expected hashing groups without a server salt to fail")
+	}
+}
+
+func TestAllowedGroups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.GroupsHashSalt = "server-salt"
+	})
+	defer httpServer.Close()
+
+	clients := map[string]storage.Client{
+		"all":      {ID: "all"},
+		"tenant-a": {ID: "tenant-a", AllowedGroupPrefixes: []string{"tenant-a:"}},
+		"tenant-b": {ID: "tenant-b", AllowedGroupPrefixes: []string{"tenant-b:"}, AllowedGroupsPattern: "admins|ops-.*"},
+		"pattern":  {ID: "pattern", AllowedGroupsPattern: "tenant-.:admins"},
+		"none":     {ID: "none", AllowedGroupPrefixes: []string{"tenant-c:"}},
+		"hashed":   {ID: "hashed", AllowedGroupPrefixes: []string{"tenant-a:"}, HashGroups: true},
+		"invalid":  {ID: "invalid", AllowedGroupsPattern: "("},
+	}
+
+	claims := storage.Claims{
+		UserID: "user",
+		Groups: []string{"tenant-a:admins", "tenant-a:devs", "tenant-b:devs", "admins", "ops-oncall", "sysadmins"},
+	}
+	groupsFor := func(clientID string) []interface{} {
+		token, _, err := s.newIDToken(clients[clientID], claims, []string{"openid", "groups"}, "", "", "", "mock")
+		if err != nil {
+			t.Fatalf("failed to create id token: %v", err)
+		}
+		groups, _ := idTokenPayload(t, token)["groups"].([]interface{})
+		return groups
+	}
+
+	for clientID, want := range map[string][]interface{}{
+		"all":      {"tenant-a:admins", "tenant-a:devs", "tenant-b:devs", "admins", "ops-oncall", "sysadmins"},
+		"tenant-a": {"tenant-a:admins", "tenant-a:devs"},
+		"tenant-b": {"tenant-b:devs", "admins", "ops-oncall"},
+		"pattern":  {"tenant-a:admins"},
+		"none":     nil,
+		// Groups are filtered before being hashed.
+		"hashed": {
+			hashGroup("server-salt", "", "tenant-a:admins"),
+			hashGroup("server-salt", "", "tenant-a:devs"),
+		},
+	} {
+		if got := groupsFor(clientID); !reflect.DeepEqual(got, want) {
+			t.Errorf("client %q: expected groups %v, got %v", clientID, want, got)
+		}
+	}
+
+	if _, _, err := s.newIDToken(clients["invalid"], claims, []string{"openid", "groups"}, "", "", "", "mock"); err == nil {
+		t.Errorf("expected an invalid allowed groups pattern to fail")
+	}
+}
+
+func TestClientIDClaim(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			httpServer, s := newTestServer(ctx, t, func(c *Config) {
+				c.EmitClientIDClaim = enabled
+			})
+			defer httpServer.Close()
+
+			client := storage.Client{ID: "test"}
+			claims := storage.Claims{UserID: "user"}
+
+			accessToken, err := s.newAccessToken(client, claims, []string{"openid"}, nil, "", "mock")
+			if err != nil {
+				t.Fatalf("failed to create access token: %v", err)
+			}
+			idToken, _, err := s.newIDToken(client, claims, []string{"openid"}, "", accessToken, "", "mock")
+			if err != nil {
+				t.Fatalf("failed to create id token: %v", err)
+			}
+
+			for name, token := range map[string]string{"access token": accessToken, "id token": idToken} {
+				clientID, ok := idTokenPayload(t, token)["client_id"]
+				switch {
+				case enabled && clientID != "test":
+					t.Errorf("expected %s to carry client_id %q, got %v", name, "test", clientID)
+				case !enabled && ok:
+					t.Errorf("expected %s to carry no client_id, got %v", name, clientID)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizationsClaim(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	client := storage.Client{ID: "test"}
+	claims := storage.Claims{
+		UserID:        "user",
+		Groups:        []string{"acme:admins"},
+		Organizations: []string{"acme", "umbrella"},
+	}
+
+	tests := []struct {
+		scopes            []string
+		wantGroups        interface{}
+		wantOrganizations interface{}
+	}{
+		{[]string{"openid"}, nil, nil},
+		{[]string{"openid", "groups"}, []interface{}{"acme:admins"}, nil},
+		{[]string{"openid", "organizations"}, nil, []interface{}{"acme", "umbrella"}},
+		{[]string{"openid", "groups", "organizations"}, []interface{}{"acme:admins"}, []interface{}{"acme", "umbrella"}},
+	}
+	for _, tc := range tests {
+		idToken, _, err := s.newIDToken(client, claims, tc.scopes, "", "", "", "mock")
+		if err != nil {
+			t.Fatalf("failed to create id token: %v", err)
+		}
+		payload := idTokenPayload(t, idToken)
+		if !reflect.DeepEqual(payload["groups"], tc.wantGroups) {
+			t.Errorf("scopes %v: expected groups %v, got %v", tc.scopes, tc.wantGroups, payload["groups"])
+		}
+		if !reflect.DeepEqual(payload["organizations"], tc.wantOrganizations) {
+			t.Errorf("scopes %v: expected organizations %v, got %v", tc.scopes, tc.wantOrganizations, payload["organizations"])
+		}
+	}
+}
+
+func TestAddressClaim(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	client := storage.Client{ID: "test"}
+	claims := storage.Claims{
+		UserID: "user",
+		Address: storageAddress(&connector.Address{
+			StreetAddress: "1 Main St",
+			Locality:      "Springfield",
+			Country:       "US",
+		}),
+	}
+
+	wantAddress := map[string]interface{}{
+		"street_address": "1 Main St",
+		"locality":       "Springfield",
+		"country":        "US",
+	}
+
+	tests := []struct {
+		scopes      []string
+		wantAddress interface{}
+	}{
+		{[]string{"openid"}, nil},
+		{[]string{"openid", "profile"}, nil},
+		{[]string{"openid", "address"}, wantAddress},
+	}
+	for _, tc := range tests {
+		idToken, _, err := s.newIDToken(client, claims, tc.scopes, "", "", "", "mock")
+		if err != nil {
+			t.Fatalf("failed to create id token: %v", err)
+		}
+		payload := idTokenPayload(t, idToken)
+		if !reflect.DeepEqual(payload["address"], tc.wantAddress) {
+			t.Errorf("scopes %v: expected address %v, got %v", tc.scopes, tc.wantAddress, payload["address"])
+		}
+	}
+
+	missing := missingEssentialClaims([]string{"address"}, []string{"openid", "address"}, storage.Claims{UserID: "user"})
+	if !reflect.DeepEqual(missing, []string{"address"}) {
+		t.Errorf("expected address to be reported missing, got %v", missing)
+	}
+}
+
+func TestCustomClaims(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	client := storage.Client{ID: "test"}
+	claims := storage.Claims{
+		UserID: "user",
+		Email:  "user@example.com",
+		CustomClaims: map[string]interface{}{
+			"upstream_exp": int64(1700000000),
+			"groups":       []string{"admins"},
+			"sub":          "someone-else",
+		},
+	}
+
+	idToken, _, err := s.newIDToken(client, claims, []string{"openid"}, "", "", "", "mock")
+	if err != nil {
+		t.Fatalf("failed to create id token: %v", err)
+	}
+	payload := idTokenPayload(t, idToken)
+	if got := payload["upstream_exp"]; got != float64(1700000000) {
+		t.Errorf("expected upstream_exp claim 1700000000, got %v", got)
+	}
+	if _, ok := payload["groups"]; ok {
+		t.Errorf("custom claims must not set the groups claim")
+	}
+	if payload["sub"] == "someone-else" {
+		t.Errorf("custom claims must not replace the sub claim")
+	}
+}
+
+func TestParseScopesUpstream(t *testing.T) {
+	scopes := parseScopes([]string{"openid", "groups", "upstream:groups", "upstream:", "upstream:api:read"})
+	if !scopes.Groups {
+		t.Error("expected the groups scope")
+	}
+	if want := []string{"groups", "api:read"}; !reflect.DeepEqual(scopes.UpstreamScopes, want) {
+		t.Errorf("expected upstream scopes %q, got %q", want, scopes.UpstreamScopes)
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// pushedRequestURIPrefix is the prefix of the request URIs referencing pushed
+// authorization requests.
+const pushedRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+type pushedAuthResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// handlePushedAuthRequest stores the parameters of an authorization request
+// pushed by an authenticated client, and returns the request URI the client
+// then sends the user to the authorization endpoint with, so the parameters
+// never go through the browser.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9126.html
+func (s *Server) handlePushedAuthRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.tokenErrHelper(w, errInvalidRequest, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.logger.Errorf("Could not parse pushed authorization request body: %v", err)
+		s.tokenErrHelper(w, errInvalidRequest, "", http.StatusBadRequest)
+		return
+	}
+	s.withClientFromStorage(w, r, s.pushAuthRequest)
+}
+
+func (s *Server) pushAuthRequest(w http.ResponseWriter, r *http.Request, client storage.Client) {
+	if r.PostForm.Get("request_uri") != "" {
+		s.tokenErrHelper(w, errInvalidRequest, "request_uri can't be pushed.", http.StatusBadRequest)
+		return
+	}
+
+	params := url.Values{}
+	for name, values := range r.PostForm {
+		if name != "client_secret" {
+			params[name] = values
+		}
+	}
+	// Clients authenticating with HTTP basic auth may leave it out.
+	params.Set("client_id", client.ID)
+
+	// Validate the request now, so the client gets the error rather than the
+	// user.
+	if _, err := s.parseAuthorizationParams(client, params); err != nil {
+		switch authErr := err.(type) {
+		case *redirectedAuthErr:
+			status := http.StatusBadRequest
+			if authErr.Type == errServerError {
+				status = http.StatusInternalServerError
+			}
+			s.tokenErrHelper(w, authErr.Type, authErr.Description, status)
+		case *displayedAuthErr:
+			typ := errInvalidRequest
+			if authErr.Status >= http.StatusInternalServerError {
+				typ = errServerError
+			}
+			s.tokenErrHelper(w, typ, authErr.Description, authErr.Status)
+		default:
+			panic("unsupported error type")
+		}
+		return
+	}
+
+	req := storage.PushedAuthRequest{
+		ID:       storage.NewID(),
+		ClientID: client.ID,
+		Params:   params,
+		Expiry:   s.now().Add(s.pushedAuthRequestsValidFor),
+	}
+	if err := s.storage.CreatePushedAuthRequest(req); err != nil {
+		s.logger.Errorf("Failed to store pushed authorization request: %v", err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(pushedAuthResponse{
+		RequestURI: pushedRequestURIPrefix + req.ID,
+		ExpiresIn:  int(s.pushedAuthRequestsValidFor.Seconds()),
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to marshal pushed authorization response: %v", err)
+		s.tokenErrHelper(w, errServerError, "", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+}
+
+// resolvePushedRequestURI returns the parameters of the pushed authorization
+// request the request URI references. Pushed requests can only be used once,
+// by the client which pushed them.
+func (s *Server) resolvePushedRequestURI(client storage.Client, requestURI string) (url.Values, error) {
+	id := strings.TrimPrefix(requestURI, pushedRequestURIPrefix)
+	req, err := s.storage.GetPushedAuthRequest(id)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, errors.New("unknown or already used pushed authorization request")
+		}
+		return nil, fmt.Errorf("failed to get pushed authorization request: %v", err)
+	}
+	if req.ClientID != client.ID {
+		return nil, fmt.Errorf("pushed authorization request is for client %q, not %q", req.ClientID, client.ID)
+	}
+
+	// Of concurrent uses, only the one which deletes the request succeeds.
+	if err := s.storage.DeletePushedAuthRequest(id); err != nil {
+		if err == storage.ErrNotFound {
+			return nil, errors.New("pushed authorization request already used")
+		}
+		return nil, fmt.Errorf("failed to delete pushed authorization request: %v", err)
+	}
+	if !s.now().Before(req.Expiry) {
+		return nil, errors.New("pushed authorization request expired")
+	}
+	return url.Values(req.Params), nil
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage"
+)
+
+func TestPushedAuthRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Now()
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.Now = func() time.Time { return now }
+	})
+	defer httpServer.Close()
+
+	const redirectURI = "https://client.example.com/callback"
+	for _, client := range []storage.Client{
+		{ID: "par-client", Secret: "par-secret", RedirectURIs: []string{redirectURI}},
+		{ID: "other-client", Secret: "other-secret", RedirectURIs: []string{redirectURI}},
+	} {
+		require.NoError(t, s.storage.CreateClient(client))
+	}
+
+	push := func(secret string, params url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/par", strings.NewReader(params.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.SetBasicAuth("par-client", secret)
+		rr := httptest.NewRecorder()
+		s.ServeHTTP(rr, r)
+		return rr
+	}
+	pushed := func() string {
+		rr := push("par-secret", url.Values{
+			"redirect_uri":  {redirectURI},
+			"response_type": {"code"},
+			"scope":         {"openid email"},
+			"state":         {"pushed-state"},
+			"nonce":         {"pushed-nonce"},
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp pushedAuthResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.True(t, strings.HasPrefix(resp.RequestURI, pushedRequestURIPrefix), resp.RequestURI)
+		require.Equal(t, 60, resp.ExpiresIn)
+		return resp.RequestURI
+	}
+	authorize := func(clientID, requestURI string) (*storage.AuthRequest, error) {
+		q := url.Values{
+			"client_id":   {clientID},
+			"request_uri": {requestURI},
+		}
+		return s.parseAuthorizationRequest(httptest.NewRequest(http.MethodGet, "/auth/mock?"+q.Encode(), nil))
+	}
+
+	// The pushed parameters are used for the authorization request, once.
+	requestURI := pushed()
+	authReq, err := authorize("par-client", requestURI)
+	require.NoError(t, err)
+	require.Equal(t, "par-client", authReq.ClientID)
+	require.Equal(t, redirectURI, authReq.RedirectURI)
+	require.Equal(t, "pushed-state", authReq.State)
+	require.Equal(t, "pushed-nonce", authReq.Nonce)
+	require.Equal(t, []string{"openid", "email"}, authReq.Scopes)
+
+	_, err = authorize("par-client", requestURI)
+	require.Error(t, err, "pushed requests can't be reused")
+
+	// Expired requests are rejected.
+	requestURI = pushed()
+	now = now.Add(time.Minute)
+	_, err = authorize("par-client", requestURI)
+	require.Error(t, err)
+
+	// Other clients can't use the request, and don't use it up.
+	requestURI = pushed()
+	_, err = authorize("other-client", requestURI)
+	require.Error(t, err)
+	_, err = authorize("par-client", requestURI)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		secret     string
+		params     url.Values
+		wantStatus int
+		wantErr    string
+	}{
+		{
+			name:       "wrong secret",
+			secret:     "wrong-secret",
+			params:     url.Values{"redirect_uri": {redirectURI}, "response_type": {"code"}, "scope": {"openid"}},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    errInvalidClient,
+		},
+		{
+			name:       "invalid scope",
+			secret:     "par-secret",
+			params:     url.Values{"redirect_uri": {redirectURI}, "response_type": {"code"}, "scope": {"email"}},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    errInvalidScope,
+		},
+		{
+			name:       "unregistered redirect URI",
+			secret:     "par-secret",
+			params:     url.Values{"redirect_uri": {"https://evil.example.com"}, "response_type": {"code"}, "scope": {"openid"}},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    errInvalidRequest,
+		},
+		{
+			name:       "request URI",
+			secret:     "par-secret",
+			params:     url.Values{"redirect_uri": {redirectURI}, "response_type": {"code"}, "scope": {"openid"}, "request_uri": {requestURI}},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    errInvalidRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := push(tc.secret, tc.params)
+			require.Equal(t, tc.wantStatus, rr.Code)
+			var resp struct {
+				Error string `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.wantErr, resp.Error)
+		})
+	}
+}
+
+func TestPushedAuthRequestBackLink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServerMultipleConnectors(ctx, t, nil)
+	defer httpServer.Close()
+
+	const redirectURI = "https://client.example.com/callback"
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           "par-client",
+		Secret:       "par-secret",
+		RedirectURIs: []string{redirectURI},
+	}))
+	require.NoError(t, s.storage.CreateConnector(storage.Connector{
+		ID:     "password",
+		Type:   "mockPassword",
+		Name:   "mockPassword",
+		Config: []byte(`{"username": "test", "password": "test"}`),
+	}))
+
+	params := url.Values{
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"scope":         {"openid email"},
+		"state":         {"pushed-state"},
+	}
+	r := httptest.NewRequest(http.MethodPost, "/par", strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBasicAuth("par-client", "par-secret")
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, r)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var resp pushedAuthResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	// Picking the password connector uses up the pushed request.
+	q := url.Values{
+		"client_id":   {"par-client"},
+		"request_uri": {resp.RequestURI},
+	}
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/password?"+q.Encode(), nil))
+	require.Equal(t, http.StatusFound, rr.Code, rr.Body.String())
+	loginURL, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(t, err)
+	backLink, err := url.Parse(loginURL.Query().Get("back"))
+	require.NoError(t, err)
+	require.Empty(t, backLink.Query().Get("request_uri"))
+
+	// Going back, the user can pick another connector for the same request.
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, backLink.String(), nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	backLink.Path = s.absPath("/auth", "mock")
+	authReq, err := s.parseAuthorizationRequest(httptest.NewRequest(http.MethodGet, backLink.String(), nil))
+	require.NoError(t, err)
+	require.Equal(t, "par-client", authReq.ClientID)
+	require.Equal(t, "pushed-state", authReq.State)
+	require.Equal(t, []string{"openid", "email"}, authReq.Scopes)
+
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, backLink.String(), nil))
+	require.Equal(t, http.StatusFound, rr.Code, rr.Body.String())
+}
@@ -168,6 +168,10 @@ func (s *Server) refreshWithConnector(ctx context.Context, token *internal.Refre
 		Email:             refresh.Claims.Email,
 		EmailVerified:     refresh.Claims.EmailVerified,
 		Groups:            refresh.Claims.Groups,
+		Organizations:     refresh.Claims.Organizations,
+		Address:           connectorAddress(refresh.Claims.Address),
+		CustomClaims:      refresh.Claims.CustomClaims,
+		AuthTime:          refresh.Claims.AuthTime,
 		ConnectorData:     connectorData,
 	}
 
@@ -186,9 +190,29 @@ func (s *Server) refreshWithConnector(ctx context.Context, token *internal.Refre
 		newIdent, err := refreshConn.Refresh(ctx, parseScopes(scopes), ident)
 		if err != nil {
 			s.logger.Errorf("failed to refresh identity: %v", err)
+			if errors.Is(err, connector.ErrReauthenticate) {
+				return connector.Identity{}, &refreshError{msg: errInvalidGrant, desc: "Upstream session is no longer valid, log in again.", code: http.StatusBadRequest}
+			}
 			return connector.Identity{}, newInternalServerError()
 		}
 		ident = newIdent
+		if ident.AuthTime.IsZero() {
+			// Refreshing doesn't authenticate the user again.
+			ident.AuthTime = refresh.Claims.AuthTime
+		}
+		// Refresh requests don't come from the user's browser, keep the
+		// language negotiated at login.
+		lang, _ := refresh.Claims.CustomClaims[preferredLanguageClaim].(string)
+		ident = s.withPreferredLanguage(refresh.ConnectorID, ident, lang)
+
+		if parseScopes(scopes).Groups {
+			if ident, err = s.withMembershipGroups(ctx, refresh.ConnectorID, ident); err != nil {
+				s.logger.Errorf("failed to get groups from membership service: %v", err)
+				return connector.Identity{}, newInternalServerError()
+			}
+		}
+		ident = s.filterGroups(ident)
+		s.warnOnGroupCount(refresh.ConnectorID, ident)
 	}
 
 	return ident, nil
@@ -252,6 +276,10 @@ func (s *Server) updateRefreshToken(token *internal.RefreshToken, refresh *stora
 		old.Claims.Email = ident.Email
 		old.Claims.EmailVerified = ident.EmailVerified
 		old.Claims.Groups = ident.Groups
+		old.Claims.Organizations = ident.Organizations
+		old.Claims.Address = storageAddress(ident.Address)
+		old.Claims.CustomClaims = ident.CustomClaims
+		old.Claims.AuthTime = ident.AuthTime
 		old.LastUsed = lastUsed
 
 		// ConnectorData has been moved to OfflineSession
@@ -295,11 +323,25 @@ func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, clie
 		return
 	}
 
+	resources, err := parseResources(r, client)
+	if err == nil {
+		resources, err = narrowResources(resources, refresh.Resources)
+	}
+	if err != nil {
+		s.tokenErrHelper(w, errInvalidTarget, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ident, rerr := s.refreshWithConnector(r.Context(), token, refresh, scopes)
 	if rerr != nil {
 		s.refreshTokenErrHelper(w, rerr)
 		return
 	}
+	if s.denyList.denies(refresh.ConnectorID, ident) {
+		s.logger.Infof("denied refresh of user %q through connector %q", ident.UserID, refresh.ConnectorID)
+		s.refreshTokenErrHelper(w, &refreshError{msg: errInvalidGrant, desc: "User is blocked.", code: http.StatusBadRequest})
+		return
+	}
 
 	claims := storage.Claims{
 		UserID:            ident.UserID,
@@ -308,16 +350,20 @@ func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request, clie
 		Email:             ident.Email,
 		EmailVerified:     ident.EmailVerified,
 		Groups:            ident.Groups,
+		Organizations:     ident.Organizations,
+		Address:           storageAddress(ident.Address),
+		CustomClaims:      ident.CustomClaims,
+		AuthTime:          ident.AuthTime,
 	}
 
-	accessToken, err := s.newAccessToken(client.ID, claims, scopes, refresh.Nonce, refresh.ConnectorID)
+	accessToken, err := s.newAccessToken(client, claims, scopes, resources, refresh.Nonce, refresh.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create new access token: %v", err)
 		s.refreshTokenErrHelper(w, newInternalServerError())
 		return
 	}
 
-	idToken, expiry, err := s.newIDToken(client.ID, claims, scopes, refresh.Nonce, accessToken, "", refresh.ConnectorID)
+	idToken, expiry, err := s.newIDToken(client, claims, scopes, refresh.Nonce, accessToken, "", refresh.ConnectorID)
 	if err != nil {
 		s.logger.Errorf("failed to create ID token: %v", err)
 		s.refreshTokenErrHelper(w, newInternalServerError())
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dexidp/dex/connector"
+	"github.com/dexidp/dex/connector/mock"
 	"github.com/dexidp/dex/server/internal"
 	"github.com/dexidp/dex/storage"
 )
@@ -210,3 +213,45 @@ func TestRefreshTokenExpirationScenarios(t *testing.T) {
 		})
 	}
 }
+
+// reauthenticateConnector fails refreshes as if the upstream session was revoked.
+type reauthenticateConnector struct {
+	connector.CallbackConnector
+}
+
+func (reauthenticateConnector) Refresh(ctx context.Context, s connector.Scopes, identity connector.Identity) (connector.Identity, error) {
+	return identity, fmt.Errorf("upstream: %w", connector.ErrReauthenticate)
+}
+
+func TestRefreshUpstreamSessionRevoked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	mockRefreshTokenTestStorage(t, s.storage, false)
+	s.connectors["test"] = Connector{
+		Connector: reauthenticateConnector{mock.NewCallbackConnector(logger).(connector.CallbackConnector)},
+	}
+
+	tokenData, err := internal.Marshal(&internal.RefreshToken{RefreshId: "test", Token: "bar"})
+	require.NoError(t, err)
+
+	v := url.Values{}
+	v.Add("grant_type", "refresh_token")
+	v.Add("refresh_token", tokenData)
+	req, _ := http.NewRequest("POST", s.absURL("/token"), bytes.NewBufferString(v.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("test", "barfoo")
+
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp struct {
+		Error string `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, errInvalidGrant, resp.Error)
+}
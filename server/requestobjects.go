@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// maxRequestObjectSize limits the size of the request objects fetched from
+// request URIs.
+const maxRequestObjectSize = 64 << 10
+
+// requestObjectCache caches the request objects fetched from request URIs, so
+// the authorization endpoint doesn't fetch them again when the user picks a
+// connector or goes back to the login page.
+type requestObjectCache struct {
+	cacheFor time.Duration
+	client   *http.Client
+
+	mu      sync.Mutex
+	objects map[string]cachedRequestObject
+}
+
+type cachedRequestObject struct {
+	jwt    string
+	expiry time.Time
+}
+
+func newRequestObjectCache(cacheFor time.Duration) *requestObjectCache {
+	return &requestObjectCache{
+		cacheFor: cacheFor,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			// Redirects could lead anywhere, not only to the allowed prefixes.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		objects: make(map[string]cachedRequestObject),
+	}
+}
+
+// get returns the cached request object of the request URI, or fetches it.
+func (c *requestObjectCache) get(ctx context.Context, requestURI string, now time.Time) (string, error) {
+	c.mu.Lock()
+	object, ok := c.objects[requestURI]
+	c.mu.Unlock()
+	if ok && now.Before(object.expiry) {
+		return object.jwt, nil
+	}
+
+	jwt, err := c.fetch(ctx, requestURI)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for uri, object := range c.objects {
+		if !now.Before(object.expiry) {
+			delete(c.objects, uri)
+		}
+	}
+	c.objects[requestURI] = cachedRequestObject{jwt: jwt, expiry: now.Add(c.cacheFor)}
+	return jwt, nil
+}
+
+func (c *requestObjectCache) fetch(ctx context.Context, requestURI string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURI, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestObjectSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxRequestObjectSize {
+		return "", errors.New("request object is too large")
+	}
+	return string(bytes.TrimSpace(body)), nil
+}
+
+// allowedRequestURI reports whether request objects may be fetched from the
+// request URI. Paths with dot segments are rejected, as they could lead out of
+// the allowed prefixes.
+func (s *Server) allowedRequestURI(requestURI string) bool {
+	u, err := url.Parse(requestURI)
+	if err != nil || u.User != nil || u.Fragment != "" || path.Clean(u.Path) != u.Path {
+		return false
+	}
+	for _, prefix := range s.requestURIPrefixes {
+		if u.Scheme == prefix.Scheme && u.Host == prefix.Host && strings.HasPrefix(u.Path, prefix.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRequestURI returns the authorization request parameters of the
+// request object the request URI references.
+//
+// See: https://openid.net/specs/openid-connect-core-1_0.html#RequestUriParameter
+func (s *Server) resolveRequestURI(ctx context.Context, client storage.Client, requestURI string) (url.Values, error) {
+	if !s.allowedRequestURI(requestURI) {
+		return nil, errors.New("request URI doesn't match any allowed prefix")
+	}
+	jwt, err := s.requestObjects.get(ctx, requestURI, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch request object: %v", err)
+	}
+	return s.parseRequestObject(client, jwt)
+}
+
+// parseRequestObject verifies a request object signed by the client with its
+// secret, and returns its authorization request parameters.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9101.html
+func (s *Server) parseRequestObject(client storage.Client, jwt string) (url.Values, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed request object: %v", err)
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, errors.New("request object must have exactly one signature")
+	}
+	switch alg := jose.SignatureAlgorithm(jws.Signatures[0].Header.Algorithm); alg {
+	case jose.HS256, jose.HS384, jose.HS512:
+	default:
+		return nil, fmt.Errorf("unsupported request object signing algorithm %q", alg)
+	}
+	if client.Secret == "" {
+		return nil, errors.New("client has no secret to verify request objects with")
+	}
+	payload, err := jws.Verify([]byte(client.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request object signature: %v", err)
+	}
+
+	var claims map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&claims); err != nil {
+		return nil, fmt.Errorf("malformed request object claims: %v", err)
+	}
+
+	if iss, ok := claims["iss"]; ok && iss != client.ID {
+		return nil, fmt.Errorf("request object issued by %v, not the client", iss)
+	}
+	if aud, ok := claims["aud"]; ok && !audienceContains(aud, s.issuerURL.String()) {
+		return nil, fmt.Errorf("request object isn't intended for this server, audience is %v", aud)
+	}
+	if exp, ok := claims["exp"]; ok {
+		n, _ := exp.(json.Number)
+		expiry, err := n.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("malformed request object expiry %v", exp)
+		}
+		if !s.now().Before(time.Unix(expiry, 0)) {
+			return nil, errors.New("request object expired")
+		}
+	}
+
+	params := url.Values{}
+	for name, value := range claims {
+		switch name {
+		case "iss", "aud", "exp", "iat", "nbf", "jti":
+			continue
+		case "request", "request_uri":
+			return nil, fmt.Errorf("request object can't contain the %q parameter", name)
+		}
+		switch value := value.(type) {
+		case string:
+			params.Set(name, value)
+		case json.Number:
+			params.Set(name, value.String())
+		case bool:
+			params.Set(name, strconv.FormatBool(value))
+		case []interface{}:
+			// For example several resource indicators.
+			for _, v := range value {
+				str, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("malformed request object parameter %q", name)
+				}
+				params.Add(name, str)
+			}
+		default:
+			// For example the "claims" parameter.
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("malformed request object parameter %q: %v", name, err)
+			}
+			params.Set(name, string(data))
+		}
+	}
+	if clientID := params.Get("client_id"); clientID != client.ID {
+		return nil, fmt.Errorf("request object is for client %q, not %q", clientID, client.ID)
+	}
+	return params, nil
+}
+
+// audienceContains reports whether the "aud" claim, a string or a list of
+// strings, contains the audience.
+func audienceContains(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// requestObjectServer serves request objects and redirects by cleaned path, and
+// counts the requests.
+type requestObjectServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	objects   map[string]string
+	redirects map[string]string
+	requests  int32
+}
+
+func newRequestObjectServer() *requestObjectServer {
+	s := &requestObjectServer{objects: make(map[string]string), redirects: make(map[string]string)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		p := path.Clean(r.URL.Path)
+		s.mu.Lock()
+		object, ok := s.objects[p]
+		location, redirect := s.redirects[p]
+		s.mu.Unlock()
+		if redirect {
+			http.Redirect(w, r, location, http.StatusFound)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/oauth-authz-req+jwt")
+		w.Write([]byte(object))
+	}))
+	return s
+}
+
+// serve signs the claims with the secret and serves them at the path.
+func (s *requestObjectServer) serve(t *testing.T, path, secret string, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(secret)}, nil)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	object, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	s.objects[path] = object
+	s.mu.Unlock()
+	return s.URL + path
+}
+
+// redirect redirects the requests of the path to the location.
+func (s *requestObjectServer) redirect(path, location string) string {
+	s.mu.Lock()
+	s.redirects[path] = location
+	s.mu.Unlock()
+	return s.URL + path
+}
+
+func TestRequestURI(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reqServer := newRequestObjectServer()
+	defer reqServer.Close()
+
+	now := time.Now()
+	httpServer, s := newTestServer(ctx, t, func(c *Config) {
+		c.RequestURIPrefixes = []string{reqServer.URL + "/requests/"}
+		c.Now = func() time.Time { return now }
+	})
+	defer httpServer.Close()
+
+	const (
+		clientID    = "request-client"
+		secret      = "request-client-secret"
+		redirectURI = "https://client.example.com/callback"
+	)
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           clientID,
+		Secret:       secret,
+		RedirectURIs: []string{redirectURI},
+	}))
+
+	claims := func(update func(claims map[string]interface{})) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":           clientID,
+			"aud":           s.issuerURL.String(),
+			"exp":           now.Add(time.Minute).Unix(),
+			"client_id":     clientID,
+			"redirect_uri":  redirectURI,
+			"response_type": "code",
+			"scope":         "openid email",
+			"state":         "request-state",
+		}
+		if update != nil {
+			update(claims)
+		}
+		return claims
+	}
+	parse := func(requestURI string) (*storage.AuthRequest, error) {
+		q := url.Values{
+			"client_id":   {clientID},
+			"request_uri": {requestURI},
+			"state":       {"query-state"},
+		}
+		return s.parseAuthorizationRequest(httptest.NewRequest(http.MethodGet, "/auth/mock?"+q.Encode(), nil))
+	}
+
+	// The parameters of the request object replace the query parameters.
+	requestURI := reqServer.serve(t, "/requests/valid", secret, claims(nil))
+	authReq, err := parse(requestURI)
+	require.NoError(t, err)
+	require.Equal(t, redirectURI, authReq.RedirectURI)
+	require.Equal(t, "request-state", authReq.State)
+	require.Equal(t, []string{"openid", "email"}, authReq.Scopes)
+	require.EqualValues(t, 1, atomic.LoadInt32(&reqServer.requests))
+
+	// The request object is cached, for example when the user picks a
+	// connector, until it expires from the cache.
+	_, err = parse(requestURI)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&reqServer.requests))
+
+	now = now.Add(s.requestObjects.cacheFor)
+	reqServer.serve(t, "/requests/valid", secret, claims(func(claims map[string]interface{}) {
+		claims["exp"] = now.Add(time.Minute).Unix()
+	}))
+	_, err = parse(requestURI)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&reqServer.requests))
+
+	tests := []struct {
+		name       string
+		requestURI string
+	}{
+		{
+			name: "expired",
+			requestURI: reqServer.serve(t, "/requests/expired", secret, claims(func(claims map[string]interface{}) {
+				claims["exp"] = now.Add(-time.Minute).Unix()
+			})),
+		},
+		{
+			name:       "wrong secret",
+			requestURI: reqServer.serve(t, "/requests/wrong-secret", "other-secret", claims(nil)),
+		},
+		{
+			name:       "disallowed prefix",
+			requestURI: reqServer.serve(t, "/other/valid", secret, claims(nil)),
+		},
+		{
+			name: "other client",
+			requestURI: reqServer.serve(t, "/requests/other-client", secret, claims(func(claims map[string]interface{}) {
+				claims["client_id"] = "other-client"
+			})),
+		},
+		{
+			name: "other audience",
+			requestURI: reqServer.serve(t, "/requests/other-audience", secret, claims(func(claims map[string]interface{}) {
+				claims["aud"] = "https://other.example.com"
+			})),
+		},
+		{
+			name: "nested request_uri",
+			requestURI: reqServer.serve(t, "/requests/nested", secret, claims(func(claims map[string]interface{}) {
+				claims["request_uri"] = requestURI
+			})),
+		},
+		{
+			name:       "dot segments",
+			requestURI: reqServer.URL + "/requests/../other/valid",
+		},
+		{
+			name:       "redirect",
+			requestURI: reqServer.redirect("/requests/redirect", reqServer.URL+"/other/valid"),
+		},
+		{
+			name:       "not found",
+			requestURI: reqServer.URL + "/requests/missing",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse(tc.requestURI)
+			require.Error(t, err)
+			require.IsType(t, &displayedAuthErr{}, err)
+		})
+	}
+}
+
+func TestRequestURINotSupported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	httpServer, s := newTestServer(ctx, t, nil)
+	defer httpServer.Close()
+
+	require.NoError(t, s.storage.CreateClient(storage.Client{
+		ID:           "client",
+		RedirectURIs: []string{"https://client.example.com/callback"},
+	}))
+
+	q := url.Values{
+		"client_id":     {"client"},
+		"redirect_uri":  {"https://client.example.com/callback"},
+		"response_type": {"code"},
+		"scope":         {"openid"},
+		"request_uri":   {"https://client.example.com/requests/1"},
+	}
+	_, err := s.parseAuthorizationRequest(httptest.NewRequest(http.MethodGet, "/auth/mock?"+q.Encode(), nil))
+	require.Error(t, err)
+	redirectErr, ok := err.(*redirectedAuthErr)
+	require.True(t, ok, "expected a redirected error, got %T", err)
+	require.Equal(t, errRequestURINotSupported, redirectErr.Type)
+}
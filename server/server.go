@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"github.com/dexidp/dex/connector/atlassiancrowd"
 	"github.com/dexidp/dex/connector/authproxy"
 	"github.com/dexidp/dex/connector/bitbucketcloud"
+	"github.com/dexidp/dex/connector/composite"
 	"github.com/dexidp/dex/connector/gitea"
 	"github.com/dexidp/dex/connector/github"
 	"github.com/dexidp/dex/connector/gitlab"
@@ -42,6 +44,9 @@ import (
 	"github.com/dexidp/dex/connector/oidc"
 	"github.com/dexidp/dex/connector/openshift"
 	"github.com/dexidp/dex/connector/saml"
+	"github.com/dexidp/dex/connector/sandbox"
+	"github.com/dexidp/dex/pkg/groups"
+	"github.com/dexidp/dex/pkg/groups/membership"
 	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/web"
@@ -87,12 +92,68 @@ type Config struct {
 	AuthRequestsValidFor   time.Duration // Defaults to 24 hours
 	DeviceRequestsValidFor time.Duration // Defaults to 5 minutes
 
+	// How long pushed authorization requests can be used. Defaults to 60
+	// seconds.
+	PushedAuthRequestsValidFor time.Duration
+
+	// Format of the user codes of the device flow.
+	DeviceUserCode UserCodeConfig
+
 	// Refresh token expiration settings
 	RefreshTokenPolicy *RefreshTokenPolicy
 
 	// If set, the server will use this connector to handle password grants
 	PasswordConnector string
 
+	// Salt used when hashing group names for clients with HashGroups enabled.
+	GroupsHashSalt string
+
+	// If enabled, the "jti" of every issued token is recorded in the storage.
+	TrackIssuedTokens bool
+
+	// If enabled, the time of every login is recorded in the offline session of
+	// the user and connector, and can be queried through the API.
+	TrackLastLogin bool
+
+	// If positive, a warning is logged and counted for identities with more
+	// groups than this, since their tokens may grow too large for clients.
+	GroupsWarningThreshold int
+
+	// If set, only the groups matching this regular expression, for example
+	// "^dex-", are passed to clients, whatever connector reports them. Groups
+	// of the membership service are filtered too.
+	GroupsFilter string
+
+	// If enabled, logins are denied unless the identity provides every claim
+	// the client marked as essential in the "claims" request parameter.
+	EnforceEssentialClaims bool
+
+	// If enabled, tokens carry the ID of the client they were issued to in
+	// the "client_id" claim.
+	EmitClientIDClaim bool
+
+	// If enabled, clients may accept redirect URIs matching their redirect URI
+	// path pattern. Disabled by default, as loose patterns can leak codes and
+	// tokens to unintended endpoints.
+	AllowRedirectURIPatterns bool
+
+	// Custom redirect URI schemes of native clients, for example
+	// "com.example.app", which get the errors of failed logins redirected to
+	// them with the "error" and "error_description" parameters instead of
+	// the HTML error page. The "http" and "https" schemes aren't allowed.
+	NativeErrorRedirectSchemes []string
+
+	// URL prefixes, for example "https://app.example.com/requests/", request
+	// objects may be fetched from when an authorization request passes them
+	// by reference with the "request_uri" parameter. The scheme and host of a
+	// request URI must be the ones of a prefix, and its path must start with
+	// the path of the prefix. If empty, request_uri isn't supported.
+	RequestURIPrefixes []string
+
+	// How long request objects fetched from request URIs are cached. Defaults
+	// to 5 minutes.
+	RequestObjectsCacheFor time.Duration
+
 	GCFrequency time.Duration // Defaults to 5 minutes
 
 	// If specified, the server will use this function for determining time.
@@ -102,6 +163,28 @@ type Config struct {
 
 	Logger log.Logger
 
+	// ConnectorLoggers overrides the logger handed to the connectors with the
+	// given IDs. Connectors not listed here use Logger.
+	//
+	// Only static connectors from the config file are expected here: connectors
+	// created through the gRPC API and the local password DB connector always
+	// use Logger.
+	ConnectorLoggers map[string]log.Logger
+
+	// MembershipClients look up additional groups for users of the connectors
+	// with the given IDs. The groups are merged into the groups reported by the
+	// connector whenever the client requested the "groups" scope.
+	MembershipClients map[string]*membership.Client
+
+	// PreferredLanguages are the languages the preferred_language claim of the
+	// users of the connectors with the given IDs is negotiated from, with the
+	// Accept-Language header of the login request. Users whose connector
+	// reports their locale get no preferred_language claim.
+	PreferredLanguages map[string][]string
+
+	// DenyList blocks the logins and refreshes of the listed users. Optional.
+	DenyList *DenyList
+
 	PrometheusRegistry *prometheus.Registry
 
 	HealthChecker gosundheit.Health
@@ -136,6 +219,18 @@ type WebConfig struct {
 
 	// Map of extra values passed into the templates
 	Extra map[string]string
+
+	// Banner shown on the login and approval pages, for example during
+	// planned maintenance. Hidden if it has no message.
+	Banner Banner
+}
+
+// Banner is a message shown to users on the login and approval pages.
+type Banner struct {
+	Message string
+
+	// One of "info", "warning" or "error". Defaults to "info".
+	Severity string
 }
 
 func value(val, defaultValue time.Duration) time.Duration {
@@ -169,6 +264,31 @@ type Server struct {
 	// Used for password grant
 	passwordConnector string
 
+	groupsHashSalt string
+
+	trackIssuedTokens bool
+
+	trackLastLogin bool
+
+	groupsFilter *regexp.Regexp
+
+	groupsWarningThreshold int
+	// Counts identities exceeding groupsWarningThreshold. Nil without a
+	// Prometheus registry.
+	groupsWarningCounter *prometheus.CounterVec
+
+	enforceEssentialClaims bool
+
+	emitClientIDClaim bool
+
+	allowRedirectURIPatterns bool
+
+	// Lowercased schemes of NativeErrorRedirectSchemes.
+	nativeErrorRedirectSchemes map[string]bool
+
+	requestURIPrefixes []*url.URL
+	requestObjects     *requestObjectCache
+
 	supportedResponseTypes map[string]bool
 
 	supportedGrantTypes []string
@@ -179,9 +299,21 @@ type Server struct {
 	authRequestsValidFor   time.Duration
 	deviceRequestsValidFor time.Duration
 
+	pushedAuthRequestsValidFor time.Duration
+
+	userCodeFormat userCodeFormat
+
 	refreshTokenPolicy *RefreshTokenPolicy
 
 	logger log.Logger
+
+	connectorLoggers map[string]log.Logger
+
+	membershipClients map[string]*membership.Client
+
+	languageMatchers map[string]*languageMatcher
+
+	denyList *DenyList
 }
 
 // NewServer constructs a server from the provided config.
@@ -236,6 +368,43 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 
 	sort.Strings(supportedGrant)
 
+	nativeErrorRedirectSchemes := make(map[string]bool)
+	for _, scheme := range c.NativeErrorRedirectSchemes {
+		scheme = strings.ToLower(scheme)
+		if scheme == "http" || scheme == "https" {
+			return nil, fmt.Errorf("server: nativeErrorRedirectSchemes can't list the %q scheme", scheme)
+		}
+		nativeErrorRedirectSchemes[scheme] = true
+	}
+
+	var requestURIPrefixes []*url.URL
+	for _, prefix := range c.RequestURIPrefixes {
+		u, err := url.Parse(prefix)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("server: invalid request URI prefix %q", prefix)
+		}
+		requestURIPrefixes = append(requestURIPrefixes, u)
+	}
+
+	var groupsFilter *regexp.Regexp
+	if c.GroupsFilter != "" {
+		if groupsFilter, err = regexp.Compile(c.GroupsFilter); err != nil {
+			return nil, fmt.Errorf("server: invalid groups filter: %v", err)
+		}
+	}
+
+	languageMatchers := make(map[string]*languageMatcher)
+	for connID, languages := range c.PreferredLanguages {
+		if len(languages) == 0 {
+			continue
+		}
+		m, err := newLanguageMatcher(languages)
+		if err != nil {
+			return nil, fmt.Errorf("server: preferred languages of connector %q: %v", connID, err)
+		}
+		languageMatchers[connID] = m
+	}
+
 	webFS := web.FS()
 	if c.Web.Dir != "" {
 		webFS = os.DirFS(c.Web.Dir)
@@ -250,6 +419,7 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 		issuer:    c.Web.Issuer,
 		theme:     c.Web.Theme,
 		extra:     c.Web.Extra,
+		banner:    c.Web.Banner,
 	}
 
 	static, theme, tmpls, err := loadWebConfig(web)
@@ -257,6 +427,11 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 		return nil, fmt.Errorf("server: failed to load web static: %v", err)
 	}
 
+	userCodeFormat, err := c.DeviceUserCode.format()
+	if err != nil {
+		return nil, fmt.Errorf("server: invalid device user code format: %v", err)
+	}
+
 	now := c.Now
 	if now == nil {
 		now = time.Now
@@ -271,13 +446,34 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 		idTokensValidFor:       value(c.IDTokensValidFor, 24*time.Hour),
 		authRequestsValidFor:   value(c.AuthRequestsValidFor, 24*time.Hour),
 		deviceRequestsValidFor: value(c.DeviceRequestsValidFor, 5*time.Minute),
+		userCodeFormat:         userCodeFormat,
 		refreshTokenPolicy:     c.RefreshTokenPolicy,
 		skipApproval:           c.SkipApprovalScreen,
 		alwaysShowLogin:        c.AlwaysShowLoginScreen,
 		now:                    now,
 		templates:              tmpls,
 		passwordConnector:      c.PasswordConnector,
+		groupsHashSalt:         c.GroupsHashSalt,
+		trackIssuedTokens:      c.TrackIssuedTokens,
+		groupsWarningThreshold: c.GroupsWarningThreshold,
+		groupsFilter:           groupsFilter,
+		enforceEssentialClaims: c.EnforceEssentialClaims,
+		emitClientIDClaim:      c.EmitClientIDClaim,
 		logger:                 c.Logger,
+		connectorLoggers:       c.ConnectorLoggers,
+		membershipClients:      c.MembershipClients,
+		languageMatchers:       languageMatchers,
+		denyList:               c.DenyList,
+
+		allowRedirectURIPatterns: c.AllowRedirectURIPatterns,
+		trackLastLogin:           c.TrackLastLogin,
+
+		nativeErrorRedirectSchemes: nativeErrorRedirectSchemes,
+
+		requestURIPrefixes: requestURIPrefixes,
+		requestObjects:     newRequestObjectCache(value(c.RequestObjectsCacheFor, 5*time.Minute)),
+
+		pushedAuthRequestsValidFor: value(c.PushedAuthRequestsValidFor, 60*time.Second),
 	}
 
 	// Retrieves connector objects in backend storage. This list includes the static connectors
@@ -312,6 +508,16 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 			return nil, fmt.Errorf("server: Failed to register Prometheus HTTP metrics: %v", err)
 		}
 
+		if s.groupsWarningThreshold > 0 {
+			s.groupsWarningCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "identity_groups_over_threshold_total",
+				Help: "Count of identities with more groups than the configured warning threshold.",
+			}, []string{"connector"})
+			if err := c.PrometheusRegistry.Register(s.groupsWarningCounter); err != nil {
+				return nil, fmt.Errorf("server: Failed to register Prometheus groups metrics: %v", err)
+			}
+		}
+
 		instrumentHandlerCounter = func(handlerName string, handler http.Handler) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
 				m := httpsnoop.CaptureMetrics(handler, w, r)
@@ -355,6 +561,7 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 
 	// TODO(ericchiang): rate limit certain paths based on IP.
 	handleWithCORS("/token", s.handleToken)
+	handleWithCORS("/par", s.handlePushedAuthRequest)
 	handleWithCORS("/keys", s.handlePublicKeys)
 	handleWithCORS("/userinfo", s.handleUserInfo)
 	handleFunc("/auth", s.handleAuthorization)
@@ -380,6 +587,10 @@ func newServer(ctx context.Context, c Config, rotationStrategy rotationStrategy)
 	// "authproxy" connector.
 	handleFunc("/callback/{connector}", s.handleConnectorCallback)
 	handleFunc("/approval", s.handleApproval)
+	handleFunc("/logout", s.handleLogout)
+	handleFunc("/logout/callback/{connector}", s.handleLogoutCallback)
+	handleFunc("/logout/backchannel/{connector}", s.handleBackChannelLogout)
+	handleFunc("/saml/metadata/{connector}", s.handleSAMLMetadata)
 	handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !c.HealthChecker.IsHealthy() {
 			s.renderError(r, w, http.StatusInternalServerError, "Health check failed.")
@@ -521,8 +732,8 @@ func (s *Server) startGarbageCollection(ctx context.Context, frequency time.Dura
 				if r, err := s.storage.GarbageCollect(now()); err != nil {
 					s.logger.Errorf("garbage collection failed: %v", err)
 				} else if !r.IsEmpty() {
-					s.logger.Infof("garbage collection run, delete auth requests=%d, auth codes=%d, device requests=%d, device tokens=%d",
-						r.AuthRequests, r.AuthCodes, r.DeviceRequests, r.DeviceTokens)
+					s.logger.Infof("garbage collection run, delete auth requests=%d, auth codes=%d, device requests=%d, device tokens=%d, issued tokens=%d, pushed auth requests=%d",
+						r.AuthRequests, r.AuthCodes, r.DeviceRequests, r.DeviceTokens, r.IssuedTokens, r.PushedAuthRequests)
 				}
 			}
 		}
@@ -554,12 +765,28 @@ var ConnectorsConfig = map[string]func() ConnectorConfig{
 	"bitbucket-cloud": func() ConnectorConfig { return new(bitbucketcloud.Config) },
 	"openshift":       func() ConnectorConfig { return new(openshift.Config) },
 	"atlassian-crowd": func() ConnectorConfig { return new(atlassiancrowd.Config) },
+	"sandbox":         func() ConnectorConfig { return new(sandbox.Config) },
 	// Keep around for backwards compatibility.
 	"samlExperimental": func() ConnectorConfig { return new(saml.Config) },
 }
 
-// openConnector will parse the connector config and open the connector.
-func openConnector(logger log.Logger, conn storage.Connector) (connector.Connector, error) {
+func init() {
+	// The composite connector opens other connectors through ConnectorsConfig,
+	// so it can't be part of its initializer.
+	ConnectorsConfig["composite"] = func() ConnectorConfig {
+		return &composite.Config{OpenConnector: openSubconnector}
+	}
+}
+
+// openSubconnector opens a connector composed by another connector.
+func openSubconnector(typ string, config []byte, id string, logger log.Logger) (connector.Connector, error) {
+	return openConnector(logger, storage.Connector{ID: id, Type: typ, Config: config}, nil)
+}
+
+// openConnector will parse the connector config and open the connector. The
+// key set cache, if any, is handed to connectors which can cache the key sets
+// of their upstream provider.
+func openConnector(logger log.Logger, conn storage.Connector, keySetCache connector.KeySetCache) (connector.Connector, error) {
 	var c connector.Connector
 
 	f, ok := ConnectorsConfig[conn.Type]
@@ -574,6 +801,9 @@ func openConnector(logger log.Logger, conn storage.Connector) (connector.Connect
 			return c, fmt.Errorf("parse connector config: %v", err)
 		}
 	}
+	if cacheConfig, ok := connConfig.(connector.KeySetCacheConfig); ok && keySetCache != nil {
+		cacheConfig.SetKeySetCache(keySetCache)
+	}
 
 	c, err := connConfig.Open(conn.ID, logger)
 	if err != nil {
@@ -591,7 +821,7 @@ func (s *Server) OpenConnector(conn storage.Connector) (Connector, error) {
 		c = newPasswordDB(s.storage)
 	} else {
 		var err error
-		c, err = openConnector(s.logger, conn)
+		c, err = openConnector(s.connectorLogger(conn.ID), conn, storageKeySetCache{s.storage, s.now})
 		if err != nil {
 			return Connector{}, fmt.Errorf("failed to open connector: %v", err)
 		}
@@ -608,6 +838,58 @@ func (s *Server) OpenConnector(conn storage.Connector) (Connector, error) {
 	return connector, nil
 }
 
+// connectorLogger returns the logger configured for the connector with the given id.
+func (s *Server) connectorLogger(id string) log.Logger {
+	if logger, ok := s.connectorLoggers[id]; ok {
+		return logger
+	}
+	return s.logger
+}
+
+// withMembershipGroups merges the groups of the membership service configured
+// for the connector, if any, into the identity.
+func (s *Server) withMembershipGroups(ctx context.Context, connID string, identity connector.Identity) (connector.Identity, error) {
+	client, ok := s.membershipClients[connID]
+	if !ok {
+		return identity, nil
+	}
+	memberOf, err := client.Groups(ctx, connID, identity)
+	if err != nil {
+		return identity, err
+	}
+	identity.Groups = groups.Merge(identity.Groups, memberOf)
+	return identity, nil
+}
+
+// filterGroups drops the groups not matching the groups filter, if any, from
+// the identity.
+func (s *Server) filterGroups(identity connector.Identity) connector.Identity {
+	if s.groupsFilter == nil || len(identity.Groups) == 0 {
+		return identity
+	}
+	filtered := make([]string, 0, len(identity.Groups))
+	for _, group := range identity.Groups {
+		if s.groupsFilter.MatchString(group) {
+			filtered = append(filtered, group)
+		}
+	}
+	identity.Groups = filtered
+	return identity
+}
+
+// warnOnGroupCount logs and counts identities with more groups than the
+// configured threshold before tokens are issued to them.
+func (s *Server) warnOnGroupCount(connID string, identity connector.Identity) {
+	if s.groupsWarningThreshold <= 0 || len(identity.Groups) <= s.groupsWarningThreshold {
+		return
+	}
+	s.logger.Warnf("user %q of connector %q has %d groups, more than the warning threshold of %d",
+		identity.UserID, connID, len(identity.Groups), s.groupsWarningThreshold)
+	if s.groupsWarningCounter != nil {
+		s.groupsWarningCounter.With(prometheus.Labels{"connector": connID}).Inc()
+	}
+}
+
 // getConnector retrieves the connector object with the given id from the storage
 // and updates the connector list for server if necessary.
 func (s *Server) getConnector(id string) (Connector, error) {
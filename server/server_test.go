@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/dexidp/dex/connector"
 	"github.com/dexidp/dex/connector/mock"
+	"github.com/dexidp/dex/pkg/log"
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/memory"
 )
@@ -1774,3 +1776,36 @@ func TestServerSupportedGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectorLoggers(t *testing.T) {
+	var debugBuf, infoBuf bytes.Buffer
+	newBufferLogger := func(w io.Writer, level logrus.Level) *logrus.Logger {
+		return &logrus.Logger{
+			Out:       w,
+			Formatter: &logrus.TextFormatter{DisableColors: true},
+			Level:     level,
+		}
+	}
+
+	_, srv := newTestServerMultipleConnectors(context.TODO(), t, func(c *Config) {
+		c.ConnectorLoggers = map[string]log.Logger{
+			"mock":  newBufferLogger(&debugBuf, logrus.DebugLevel),
+			"mock2": newBufferLogger(&infoBuf, logrus.InfoLevel),
+		}
+	})
+
+	for _, id := range []string{"mock", "mock2"} {
+		conn, err := srv.getConnector(id)
+		require.NoError(t, err)
+
+		callback, ok := conn.Connector.(connector.CallbackConnector)
+		require.True(t, ok, "expected a callback connector")
+
+		req := httptest.NewRequest(http.MethodGet, "/callback", nil)
+		_, err = callback.HandleCallback(connector.Scopes{}, req)
+		require.NoError(t, err)
+	}
+
+	require.Contains(t, debugBuf.String(), "mock: returning identity")
+	require.Empty(t, infoBuf.String())
+}
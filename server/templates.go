@@ -51,6 +51,7 @@ type webConfig struct {
 	theme     string
 	issuerURL string
 	extra     map[string]string
+	banner    Banner
 }
 
 func getFuncMap(c webConfig) (template.FuncMap, error) {
@@ -61,7 +62,13 @@ func getFuncMap(c webConfig) (template.FuncMap, error) {
 		return nil, fmt.Errorf("error parsing issuerURL: %v", err)
 	}
 
+	var banner *Banner
+	if c.banner.Message != "" {
+		banner = &c.banner
+	}
+
 	additionalFuncs := map[string]interface{}{
+		"banner": func() *Banner { return banner },
 		"extra":  func(k string) string { return c.extra[k] },
 		"issuer": func() string { return c.issuer },
 		"logo":   func() string { return c.logoURL },
@@ -103,6 +110,13 @@ func loadWebConfig(c webConfig) (http.Handler, http.Handler, *templates, error)
 	if c.logoURL == "" {
 		c.logoURL = "theme/logo.png"
 	}
+	switch c.banner.Severity {
+	case "":
+		c.banner.Severity = "info"
+	case "info", "warning", "error":
+	default:
+		return nil, nil, nil, fmt.Errorf("invalid banner severity %q", c.banner.Severity)
+	}
 
 	staticFiles, err := fs.Sub(c.webFS, "static")
 	if err != nil {
@@ -295,7 +309,7 @@ func (t *templates) password(r *http.Request, w http.ResponseWriter, postURL, la
 	return renderTemplate(w, t.passwordTmpl, data)
 }
 
-func (t *templates) approval(r *http.Request, w http.ResponseWriter, authReqID, username, clientName string, scopes []string) error {
+func (t *templates) approval(r *http.Request, w http.ResponseWriter, authReqID, username, clientName, consentText, privacyPolicyURL string, scopes []string) error {
 	accesses := []string{}
 	for _, scope := range scopes {
 		access, ok := scopeDescriptions[scope]
@@ -305,12 +319,14 @@ func (t *templates) approval(r *http.Request, w http.ResponseWriter, authReqID,
 	}
 	sort.Strings(accesses)
 	data := struct {
-		User      string
-		Client    string
-		AuthReqID string
-		Scopes    []string
-		ReqPath   string
-	}{username, clientName, authReqID, accesses, r.URL.Path}
+		User             string
+		Client           string
+		ConsentText      string
+		PrivacyPolicyURL string
+		AuthReqID        string
+		Scopes           []string
+		ReqPath          string
+	}{username, clientName, consentText, privacyPolicyURL, authReqID, accesses, r.URL.Path}
 	return renderTemplate(w, t.approvalTmpl, data)
 }
 
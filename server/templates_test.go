@@ -1,6 +1,11 @@
 package server
 
-import "testing"
+import (
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestRelativeURL(t *testing.T) {
 	tests := []struct {
@@ -49,3 +54,77 @@ func TestRelativeURL(t *testing.T) {
 		})
 	}
 }
+
+func TestBanner(t *testing.T) {
+	const message = "Logins may fail during planned maintenance."
+
+	tests := []struct {
+		name       string
+		banner     Banner
+		wantBanner string
+	}{
+		{
+			name:       "no banner",
+			wantBanner: "",
+		},
+		{
+			name:       "default severity",
+			banner:     Banner{Message: message},
+			wantBanner: `<div class="dex-banner dex-banner--info">` + message + `</div>`,
+		},
+		{
+			name:       "warning",
+			banner:     Banner{Message: message, Severity: "warning"},
+			wantBanner: `<div class="dex-banner dex-banner--warning">` + message + `</div>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, tmpls, err := loadWebConfig(webConfig{
+				webFS:     os.DirFS("../web"),
+				issuerURL: "https://example.com/dex",
+				banner:    test.banner,
+			})
+			if err != nil {
+				t.Fatalf("failed to load web config: %v", err)
+			}
+
+			pages := map[string]func(*httptest.ResponseRecorder) error{
+				"login": func(w *httptest.ResponseRecorder) error {
+					return tmpls.login(httptest.NewRequest("GET", "/dex/auth", nil), w, nil)
+				},
+				"approval": func(w *httptest.ResponseRecorder) error {
+					return tmpls.approval(httptest.NewRequest("GET", "/dex/approval", nil), w, "req", "jane", "app", "", "", nil)
+				},
+			}
+			for page, render := range pages {
+				w := httptest.NewRecorder()
+				if err := render(w); err != nil {
+					t.Fatalf("failed to render %s page: %v", page, err)
+				}
+				body := w.Body.String()
+				if test.wantBanner == "" {
+					if strings.Contains(body, "dex-banner") {
+						t.Errorf("expected no banner on the %s page", page)
+					}
+					continue
+				}
+				if !strings.Contains(body, test.wantBanner) {
+					t.Errorf("expected %s page to contain %q", page, test.wantBanner)
+				}
+			}
+		})
+	}
+}
+
+func TestBannerInvalidSeverity(t *testing.T) {
+	_, _, _, err := loadWebConfig(webConfig{
+		webFS:     os.DirFS("../web"),
+		issuerURL: "https://example.com/dex",
+		banner:    Banner{Message: "maintenance", Severity: "critical"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid banner severity")
+	}
+}
@@ -49,6 +49,9 @@ func RunTests(t *testing.T, newStorage func() storage.Storage) {
 		{"TimezoneSupport", testTimezones},
 		{"DeviceRequestCRUD", testDeviceRequestCRUD},
 		{"DeviceTokenCRUD", testDeviceTokenCRUD},
+		{"IssuedTokenCRUD", testIssuedTokenCRUD},
+		{"UpstreamKeySetCRUD", testUpstreamKeySetCRUD},
+		{"PushedAuthRequestCRUD", testPushedAuthRequestCRUD},
 	})
 }
 
@@ -84,6 +87,7 @@ func testAuthRequestCRUD(t *testing.T, s storage.Storage) {
 		CodeChallengeMethod: "plain",
 	}
 
+	maxAge := 300
 	a1 := storage.AuthRequest{
 		ID:                  storage.NewID(),
 		ClientID:            "client1",
@@ -92,6 +96,11 @@ func testAuthRequestCRUD(t *testing.T, s storage.Storage) {
 		RedirectURI:         "https://localhost:80/callback",
 		Nonce:               "foo",
 		State:               "bar",
+		Resources:           []string{"https://api.example.com"},
+		Prompt:              "login select_account",
+		EssentialClaims:     []string{"email", "groups"},
+		ACRValues:           []string{"urn:example:mfa", "urn:example:pwd"},
+		MaxAge:              &maxAge,
 		ForceApprovalPrompt: true,
 		LoggedIn:            true,
 		Expiry:              neverExpire,
@@ -103,11 +112,20 @@ func testAuthRequestCRUD(t *testing.T, s storage.Storage) {
 			Email:         "jane.doe@example.com",
 			EmailVerified: true,
 			Groups:        []string{"a", "b"},
+			Organizations: []string{"acme"},
+			Address:       &storage.Address{Locality: "Berlin", Country: "DE"},
+			CustomClaims:  map[string]interface{}{"upstream_exp": float64(1700000000)},
 		},
 		PKCE: codeChallenge,
 	}
 
-	identity := storage.Claims{Email: "foobar"}
+	identity := storage.Claims{
+		Email:         "foobar",
+		Organizations: []string{"acme", "umbrella"},
+		Address:       &storage.Address{Formatted: "1 Main St\nSpringfield", Country: "US"},
+		CustomClaims:  map[string]interface{}{"department": "sales"},
+		AuthTime:      time.Now().UTC().Round(time.Second),
+	}
 
 	if err := s.CreateAuthRequest(a1); err != nil {
 		t.Fatalf("failed creating auth request: %v", err)
@@ -155,6 +173,10 @@ func testAuthRequestCRUD(t *testing.T, s storage.Storage) {
 	if err != nil {
 		t.Fatalf("failed to get auth req: %v", err)
 	}
+	if got.Claims.AuthTime.Unix() != identity.AuthTime.Unix() {
+		t.Fatalf("update failed, wanted auth time=%s got %s", identity.AuthTime, got.Claims.AuthTime)
+	}
+	got.Claims.AuthTime = identity.AuthTime // time fields do not compare well
 	if !reflect.DeepEqual(got.Claims, identity) {
 		t.Fatalf("update failed, wanted identity=%#v got %#v", identity, got.Claims)
 	}
@@ -163,6 +185,22 @@ func testAuthRequestCRUD(t *testing.T, s storage.Storage) {
 		t.Fatalf("storage does not support PKCE, wanted challenge=%#v got %#v", codeChallenge, got.PKCE)
 	}
 
+	if got.Prompt != a1.Prompt {
+		t.Fatalf("update failed, wanted prompt=%q got %q", a1.Prompt, got.Prompt)
+	}
+
+	if !reflect.DeepEqual(got.EssentialClaims, a1.EssentialClaims) {
+		t.Fatalf("update failed, wanted essential claims=%q got %q", a1.EssentialClaims, got.EssentialClaims)
+	}
+
+	if !reflect.DeepEqual(got.ACRValues, a1.ACRValues) {
+		t.Fatalf("update failed, wanted acr values=%q got %q", a1.ACRValues, got.ACRValues)
+	}
+
+	if got.MaxAge == nil || *got.MaxAge != maxAge {
+		t.Fatalf("update failed, wanted max age=%d got %v", maxAge, got.MaxAge)
+	}
+
 	if err := s.DeleteAuthRequest(a1.ID); err != nil {
 		t.Fatalf("failed to delete auth request: %v", err)
 	}
@@ -182,6 +220,7 @@ func testAuthCodeCRUD(t *testing.T, s storage.Storage) {
 		RedirectURI:   "https://localhost:80/callback",
 		Nonce:         "foobar",
 		Scopes:        []string{"openid", "email"},
+		Resources:     []string{"https://api.example.com"},
 		Expiry:        neverExpire,
 		ConnectorID:   "ldap",
 		ConnectorData: []byte(`{"some":"data"}`),
@@ -195,6 +234,10 @@ func testAuthCodeCRUD(t *testing.T, s storage.Storage) {
 			Email:         "jane.doe@example.com",
 			EmailVerified: true,
 			Groups:        []string{"a", "b"},
+			Organizations: []string{"acme"},
+			Address:       &storage.Address{Locality: "Berlin", Country: "DE"},
+			CustomClaims:  map[string]interface{}{"upstream_exp": float64(1700000000)},
+			AuthTime:      time.Now().UTC().Round(time.Second),
 		},
 	}
 
@@ -235,7 +278,11 @@ func testAuthCodeCRUD(t *testing.T, s storage.Storage) {
 	if a1.Expiry.Unix() != got.Expiry.Unix() {
 		t.Errorf("auth code expiry did not match want=%s vs got=%s", a1.Expiry, got.Expiry)
 	}
+	if a1.Claims.AuthTime.Unix() != got.Claims.AuthTime.Unix() {
+		t.Errorf("auth code auth time did not match want=%s vs got=%s", a1.Claims.AuthTime, got.Claims.AuthTime)
+	}
 	got.Expiry = a1.Expiry // time fields do not compare well
+	got.Claims.AuthTime = a1.Claims.AuthTime
 	if diff := pretty.Compare(a1, got); diff != "" {
 		t.Errorf("auth code retrieved from storage did not match: %s", diff)
 	}
@@ -258,8 +305,20 @@ func testClientCRUD(t *testing.T, s storage.Storage) {
 		ID:           id1,
 		Secret:       "foobar",
 		RedirectURIs: []string{"foo://bar.com/", "https://auth.example.com"},
+		Resources:    []string{"https://api.example.com"},
 		Name:         "dex client",
 		LogoURL:      "https://goo.gl/JIyzIC",
+		HashGroups:   true,
+		GroupsSalt:   "salt",
+
+		RequireVerifiedEmail:   true,
+		RedirectURIPathPattern: "/apps/[a-z]+/callback",
+
+		ConsentText:      "By signing in you agree to the terms of use.",
+		PrivacyPolicyURL: "https://example.com/privacy",
+
+		AllowedGroupPrefixes: []string{"tenant-a:"},
+		AllowedGroupsPattern: "admins|tenant-a-.*",
 	}
 	err := s.DeleteClient(id1)
 	mustBeErrNotFound(t, "client", err)
@@ -309,6 +368,30 @@ func testClientCRUD(t *testing.T, s storage.Storage) {
 	c1.Secret = newSecret
 	getAndCompare(id1, c1)
 
+	err = s.UpdateClient(id1, func(old storage.Client) (storage.Client, error) {
+		old.HashGroups = false
+		old.GroupsSalt = ""
+		old.RequireVerifiedEmail = false
+		old.RedirectURIPathPattern = ""
+		old.ConsentText = ""
+		old.PrivacyPolicyURL = ""
+		old.AllowedGroupPrefixes = []string{"tenant-b:"}
+		old.AllowedGroupsPattern = ""
+		return old, nil
+	})
+	if err != nil {
+		t.Errorf("update client: %v", err)
+	}
+	c1.HashGroups = false
+	c1.GroupsSalt = ""
+	c1.RequireVerifiedEmail = false
+	c1.RedirectURIPathPattern = ""
+	c1.ConsentText = ""
+	c1.PrivacyPolicyURL = ""
+	c1.AllowedGroupPrefixes = []string{"tenant-b:"}
+	c1.AllowedGroupsPattern = ""
+	getAndCompare(id1, c1)
+
 	if err := s.DeleteClient(id1); err != nil {
 		t.Fatalf("delete client: %v", err)
 	}
@@ -331,6 +414,7 @@ func testRefreshTokenCRUD(t *testing.T, s storage.Storage) {
 		ClientID:      "client_id",
 		ConnectorID:   "client_secret",
 		Scopes:        []string{"openid", "email", "profile"},
+		Resources:     []string{"https://api.example.com"},
 		CreatedAt:     time.Now().UTC().Round(time.Millisecond),
 		LastUsed:      time.Now().UTC().Round(time.Millisecond),
 		Claims: storage.Claims{
@@ -339,6 +423,10 @@ func testRefreshTokenCRUD(t *testing.T, s storage.Storage) {
 			Email:         "jane.doe@example.com",
 			EmailVerified: true,
 			Groups:        []string{"a", "b"},
+			Organizations: []string{"acme"},
+			Address:       &storage.Address{Locality: "Berlin", Country: "DE"},
+			CustomClaims:  map[string]interface{}{"upstream_exp": float64(1700000000)},
+			AuthTime:      time.Now().UTC().Round(time.Second),
 		},
 		ConnectorData: []byte(`{"some":"data"}`),
 	}
@@ -365,10 +453,16 @@ func testRefreshTokenCRUD(t *testing.T, s storage.Storage) {
 			t.Errorf("refresh token last used timestamp retrieved from storage did not match: %s", diff)
 		}
 
+		if gr.Claims.AuthTime.Unix() != want.Claims.AuthTime.Unix() {
+			t.Errorf("refresh token auth time retrieved from storage did not match want=%s vs got=%s", want.Claims.AuthTime, gr.Claims.AuthTime)
+		}
+
 		gr.CreatedAt = time.Time{}
 		gr.LastUsed = time.Time{}
+		gr.Claims.AuthTime = time.Time{}
 		want.CreatedAt = time.Time{}
 		want.LastUsed = time.Time{}
+		want.Claims.AuthTime = time.Time{}
 
 		if diff := pretty.Compare(want, gr); diff != "" {
 			t.Errorf("refresh token retrieved from storage did not match: %s", diff)
@@ -581,9 +675,11 @@ func testOfflineSessionCRUD(t *testing.T, s storage.Storage) {
 		LastUsed:  time.Now().UTC().Round(time.Millisecond),
 	}
 	session1.Refresh[tokenRef.ClientID] = &tokenRef
+	session1.LastLogin = time.Now().UTC().Round(time.Millisecond)
 
 	if err := s.UpdateOfflineSessions(session1.UserID, session1.ConnID, func(old storage.OfflineSessions) (storage.OfflineSessions, error) {
 		old.Refresh[tokenRef.ClientID] = &tokenRef
+		old.LastLogin = session1.LastLogin
 		return old, nil
 	}); err != nil {
 		t.Fatalf("failed to update offline session: %v", err)
@@ -918,6 +1014,76 @@ func testGC(t *testing.T, s storage.Storage) {
 	} else if err != storage.ErrNotFound {
 		t.Errorf("expected storage.ErrNotFound, got %v", err)
 	}
+
+	it := storage.IssuedToken{
+		ID:          storage.NewID(),
+		ClientID:    "foobar",
+		UserID:      "1",
+		ConnectorID: "ldap",
+		IssuedAt:    expiry.Add(-time.Hour),
+		Expiry:      expiry,
+	}
+
+	if err := s.CreateIssuedToken(it); err != nil {
+		t.Fatalf("failed creating issued token: %v", err)
+	}
+
+	for _, tz := range []*time.Location{time.UTC, est, pst} {
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		if err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if result.IssuedTokens != 0 {
+			t.Errorf("expected no issued token garbage collection results, got %#v", result)
+		}
+		if _, err := s.GetIssuedToken(it.ID); err != nil {
+			t.Errorf("expected to be able to get issued token after GC: %v", err)
+		}
+	}
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+		t.Errorf("garbage collection failed: %v", err)
+	} else if r.IssuedTokens != 1 {
+		t.Errorf("expected to garbage collect 1 issued token, got %d", r.IssuedTokens)
+	}
+
+	if _, err := s.GetIssuedToken(it.ID); err == nil {
+		t.Errorf("expected issued token to be GC'd")
+	} else if err != storage.ErrNotFound {
+		t.Errorf("expected storage.ErrNotFound, got %v", err)
+	}
+
+	par := storage.PushedAuthRequest{
+		ID:       storage.NewID(),
+		ClientID: "foobar",
+		Params:   map[string][]string{"scope": {"openid"}},
+		Expiry:   expiry,
+	}
+
+	if err := s.CreatePushedAuthRequest(par); err != nil {
+		t.Fatalf("failed creating pushed auth request: %v", err)
+	}
+
+	for _, tz := range []*time.Location{time.UTC, est, pst} {
+		result, err := s.GarbageCollect(expiry.Add(-time.Hour).In(tz))
+		if err != nil {
+			t.Errorf("garbage collection failed: %v", err)
+		} else if result.PushedAuthRequests != 0 {
+			t.Errorf("expected no pushed auth request garbage collection results, got %#v", result)
+		}
+		if _, err := s.GetPushedAuthRequest(par.ID); err != nil {
+			t.Errorf("expected to be able to get pushed auth request after GC: %v", err)
+		}
+	}
+	if r, err := s.GarbageCollect(expiry.Add(time.Hour)); err != nil {
+		t.Errorf("garbage collection failed: %v", err)
+	} else if r.PushedAuthRequests != 1 {
+		t.Errorf("expected to garbage collect 1 pushed auth request, got %d", r.PushedAuthRequests)
+	}
+
+	if _, err := s.GetPushedAuthRequest(par.ID); err == nil {
+		t.Errorf("expected pushed auth request to be GC'd")
+	} else if err != storage.ErrNotFound {
+		t.Errorf("expected storage.ErrNotFound, got %v", err)
+	}
 }
 
 // testTimezones tests that backends either fully support timezones or
@@ -1030,3 +1196,130 @@ func testDeviceTokenCRUD(t *testing.T, s storage.Storage) {
 		t.Fatalf("update failed, wanted token %v got %v", "token data", got.Token)
 	}
 }
+
+func testIssuedTokenCRUD(t *testing.T, s storage.Storage) {
+	t1 := storage.IssuedToken{
+		ID:          storage.NewID(),
+		ClientID:    "client1",
+		UserID:      "1",
+		ConnectorID: "ldap",
+		IssuedAt:    time.Now().UTC().Round(time.Millisecond),
+		Expiry:      neverExpire,
+	}
+
+	if err := s.CreateIssuedToken(t1); err != nil {
+		t.Fatalf("failed creating issued token: %v", err)
+	}
+
+	// Attempt to create same issued token twice.
+	err := s.CreateIssuedToken(t1)
+	mustBeErrAlreadyExists(t, "issued token", err)
+
+	got, err := s.GetIssuedToken(t1.ID)
+	if err != nil {
+		t.Fatalf("failed to get issued token: %v", err)
+	}
+	if !got.IssuedAt.Equal(t1.IssuedAt) {
+		t.Errorf("issued token issued at did not match want=%s vs got=%s", t1.IssuedAt, got.IssuedAt)
+	}
+	if !got.Expiry.Equal(t1.Expiry) {
+		t.Errorf("issued token expiry did not match want=%s vs got=%s", t1.Expiry, got.Expiry)
+	}
+	got.IssuedAt, got.Expiry = t1.IssuedAt, t1.Expiry // Ignore timezones.
+	if diff := pretty.Compare(t1, got); diff != "" {
+		t.Errorf("issued token retrieved from storage did not match: %s", diff)
+	}
+
+	if err := s.DeleteIssuedToken(t1.ID); err != nil {
+		t.Fatalf("failed to delete issued token: %v", err)
+	}
+
+	_, err = s.GetIssuedToken(t1.ID)
+	mustBeErrNotFound(t, "issued token", err)
+}
+
+func testUpstreamKeySetCRUD(t *testing.T, s storage.Storage) {
+	_, err := s.GetUpstreamKeySet("oidc")
+	mustBeErrNotFound(t, "upstream key set", err)
+
+	k1 := storage.UpstreamKeySet{
+		ConnectorID: "oidc",
+		KeySet:      []byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`),
+		UpdatedAt:   time.Now().UTC().Round(time.Millisecond),
+	}
+	if err := s.CreateUpstreamKeySet(k1); err != nil {
+		t.Fatalf("failed creating upstream key set: %v", err)
+	}
+
+	// Attempt to create the key set of the same connector twice.
+	err = s.CreateUpstreamKeySet(k1)
+	mustBeErrAlreadyExists(t, "upstream key set", err)
+
+	getAndCompare := func(want storage.UpstreamKeySet) {
+		got, err := s.GetUpstreamKeySet(want.ConnectorID)
+		if err != nil {
+			t.Errorf("failed to get upstream key set: %v", err)
+			return
+		}
+		if !got.UpdatedAt.Equal(want.UpdatedAt) {
+			t.Errorf("upstream key set updated at did not match want=%s vs got=%s", want.UpdatedAt, got.UpdatedAt)
+		}
+		got.UpdatedAt = want.UpdatedAt // Ignore timezones.
+		if diff := pretty.Compare(want, got); diff != "" {
+			t.Errorf("upstream key set retrieved from storage did not match: %s", diff)
+		}
+	}
+	getAndCompare(k1)
+
+	k2 := k1
+	k2.KeySet = []byte(`{"keys":[]}`)
+	k2.UpdatedAt = k1.UpdatedAt.Add(time.Hour)
+	if err := s.UpdateUpstreamKeySet(k1.ConnectorID, func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error) {
+		old.KeySet = k2.KeySet
+		old.UpdatedAt = k2.UpdatedAt
+		return old, nil
+	}); err != nil {
+		t.Fatalf("failed to update upstream key set: %v", err)
+	}
+	getAndCompare(k2)
+}
+
+func testPushedAuthRequestCRUD(t *testing.T, s storage.Storage) {
+	p1 := storage.PushedAuthRequest{
+		ID:       storage.NewID(),
+		ClientID: "client1",
+		Params: map[string][]string{
+			"redirect_uri": {"https://localhost:80/callback"},
+			"scope":        {"openid email"},
+			"resource":     {"https://api1.example.com", "https://api2.example.com"},
+		},
+		Expiry: neverExpire,
+	}
+
+	if err := s.CreatePushedAuthRequest(p1); err != nil {
+		t.Fatalf("failed creating pushed auth request: %v", err)
+	}
+
+	// Attempt to create same pushed auth request twice.
+	err := s.CreatePushedAuthRequest(p1)
+	mustBeErrAlreadyExists(t, "pushed auth request", err)
+
+	got, err := s.GetPushedAuthRequest(p1.ID)
+	if err != nil {
+		t.Fatalf("failed to get pushed auth request: %v", err)
+	}
+	if !got.Expiry.Equal(p1.Expiry) {
+		t.Errorf("pushed auth request expiry did not match want=%s vs got=%s", p1.Expiry, got.Expiry)
+	}
+	got.Expiry = p1.Expiry // Ignore timezones.
+	if diff := pretty.Compare(p1, got); diff != "" {
+		t.Errorf("pushed auth request retrieved from storage did not match: %s", diff)
+	}
+
+	if err := s.DeletePushedAuthRequest(p1.ID); err != nil {
+		t.Fatalf("failed to delete pushed auth request: %v", err)
+	}
+
+	_, err = s.GetPushedAuthRequest(p1.ID)
+	mustBeErrNotFound(t, "pushed auth request", err)
+}
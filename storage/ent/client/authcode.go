@@ -12,6 +12,7 @@ func (d *Database) CreateAuthCode(code storage.AuthCode) error {
 		SetID(code.ID).
 		SetClientID(code.ClientID).
 		SetScopes(code.Scopes).
+		SetResources(code.Resources).
 		SetRedirectURI(code.RedirectURI).
 		SetNonce(code.Nonce).
 		SetClaimsUserID(code.Claims.UserID).
@@ -19,7 +20,11 @@ func (d *Database) CreateAuthCode(code storage.AuthCode) error {
 		SetClaimsEmailVerified(code.Claims.EmailVerified).
 		SetClaimsUsername(code.Claims.Username).
 		SetClaimsPreferredUsername(code.Claims.PreferredUsername).
+		SetClaimsAuthTime(code.Claims.AuthTime).
 		SetClaimsGroups(code.Claims.Groups).
+		SetClaimsOrganizations(code.Claims.Organizations).
+		SetClaimsAddress(code.Claims.Address).
+		SetClaimsCustom(code.Claims.CustomClaims).
 		SetCodeChallenge(code.PKCE.CodeChallenge).
 		SetCodeChallengeMethod(code.PKCE.CodeChallengeMethod).
 		// Save utc time into database because ent doesn't support comparing dates with different timezones
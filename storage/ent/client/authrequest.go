@@ -13,6 +13,11 @@ func (d *Database) CreateAuthRequest(authRequest storage.AuthRequest) error {
 		SetID(authRequest.ID).
 		SetClientID(authRequest.ClientID).
 		SetScopes(authRequest.Scopes).
+		SetResources(authRequest.Resources).
+		SetPrompt(authRequest.Prompt).
+		SetEssentialClaims(authRequest.EssentialClaims).
+		SetAcrValues(authRequest.ACRValues).
+		SetNillableMaxAge(authRequest.MaxAge).
 		SetResponseTypes(authRequest.ResponseTypes).
 		SetRedirectURI(authRequest.RedirectURI).
 		SetState(authRequest.State).
@@ -24,7 +29,11 @@ func (d *Database) CreateAuthRequest(authRequest storage.AuthRequest) error {
 		SetClaimsEmailVerified(authRequest.Claims.EmailVerified).
 		SetClaimsUsername(authRequest.Claims.Username).
 		SetClaimsPreferredUsername(authRequest.Claims.PreferredUsername).
+		SetClaimsAuthTime(authRequest.Claims.AuthTime).
 		SetClaimsGroups(authRequest.Claims.Groups).
+		SetClaimsOrganizations(authRequest.Claims.Organizations).
+		SetClaimsAddress(authRequest.Claims.Address).
+		SetClaimsCustom(authRequest.Claims.CustomClaims).
 		SetCodeChallenge(authRequest.PKCE.CodeChallenge).
 		SetCodeChallengeMethod(authRequest.PKCE.CodeChallengeMethod).
 		// Save utc time into database because ent doesn't support comparing dates with different timezones
@@ -73,9 +82,13 @@ func (d *Database) UpdateAuthRequest(id string, updater func(old storage.AuthReq
 		return rollback(tx, "update auth request updating: %w", err)
 	}
 
-	_, err = tx.AuthRequest.UpdateOneID(newAuthRequest.ID).
+	update := tx.AuthRequest.UpdateOneID(newAuthRequest.ID).
 		SetClientID(newAuthRequest.ClientID).
 		SetScopes(newAuthRequest.Scopes).
+		SetResources(newAuthRequest.Resources).
+		SetPrompt(newAuthRequest.Prompt).
+		SetEssentialClaims(newAuthRequest.EssentialClaims).
+		SetAcrValues(newAuthRequest.ACRValues).
 		SetResponseTypes(newAuthRequest.ResponseTypes).
 		SetRedirectURI(newAuthRequest.RedirectURI).
 		SetState(newAuthRequest.State).
@@ -87,14 +100,23 @@ func (d *Database) UpdateAuthRequest(id string, updater func(old storage.AuthReq
 		SetClaimsEmailVerified(newAuthRequest.Claims.EmailVerified).
 		SetClaimsUsername(newAuthRequest.Claims.Username).
 		SetClaimsPreferredUsername(newAuthRequest.Claims.PreferredUsername).
+		SetClaimsAuthTime(newAuthRequest.Claims.AuthTime).
 		SetClaimsGroups(newAuthRequest.Claims.Groups).
+		SetClaimsOrganizations(newAuthRequest.Claims.Organizations).
+		SetClaimsAddress(newAuthRequest.Claims.Address).
+		SetClaimsCustom(newAuthRequest.Claims.CustomClaims).
 		SetCodeChallenge(newAuthRequest.PKCE.CodeChallenge).
 		SetCodeChallengeMethod(newAuthRequest.PKCE.CodeChallengeMethod).
 		// Save utc time into database because ent doesn't support comparing dates with different timezones
 		SetExpiry(newAuthRequest.Expiry.UTC()).
 		SetConnectorID(newAuthRequest.ConnectorID).
-		SetConnectorData(newAuthRequest.ConnectorData).
-		Save(context.TODO())
+		SetConnectorData(newAuthRequest.ConnectorData)
+	if newAuthRequest.MaxAge != nil {
+		update.SetMaxAge(*newAuthRequest.MaxAge)
+	} else {
+		update.ClearMaxAge()
+	}
+	_, err = update.Save(context.TODO())
 	if err != nil {
 		return rollback(tx, "update auth request uploading: %w", err)
 	}
@@ -16,6 +16,15 @@ func (d *Database) CreateClient(client storage.Client) error {
 		SetLogoURL(client.LogoURL).
 		SetRedirectUris(client.RedirectURIs).
 		SetTrustedPeers(client.TrustedPeers).
+		SetResources(client.Resources).
+		SetHashGroups(client.HashGroups).
+		SetGroupsSalt(client.GroupsSalt).
+		SetRequireVerifiedEmail(client.RequireVerifiedEmail).
+		SetRedirectURIPathPattern(client.RedirectURIPathPattern).
+		SetConsentText(client.ConsentText).
+		SetPrivacyPolicyURL(client.PrivacyPolicyURL).
+		SetAllowedGroupPrefixes(client.AllowedGroupPrefixes).
+		SetAllowedGroupsPattern(client.AllowedGroupsPattern).
 		Save(context.TODO())
 	if err != nil {
 		return convertDBError("create oauth2 client: %w", err)
@@ -79,6 +88,15 @@ func (d *Database) UpdateClient(id string, updater func(old storage.Client) (sto
 		SetLogoURL(newClient.LogoURL).
 		SetRedirectUris(newClient.RedirectURIs).
 		SetTrustedPeers(newClient.TrustedPeers).
+		SetResources(newClient.Resources).
+		SetHashGroups(newClient.HashGroups).
+		SetGroupsSalt(newClient.GroupsSalt).
+		SetRequireVerifiedEmail(newClient.RequireVerifiedEmail).
+		SetRedirectURIPathPattern(newClient.RedirectURIPathPattern).
+		SetConsentText(newClient.ConsentText).
+		SetPrivacyPolicyURL(newClient.PrivacyPolicyURL).
+		SetAllowedGroupPrefixes(newClient.AllowedGroupPrefixes).
+		SetAllowedGroupsPattern(newClient.AllowedGroupsPattern).
 		Save(context.TODO())
 	if err != nil {
 		return rollback(tx, "update client uploading: %w", err)
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// CreateIssuedToken saves provided issued token into the database.
+func (d *Database) CreateIssuedToken(token storage.IssuedToken) error {
+	_, err := d.client.IssuedToken.Create().
+		SetID(token.ID).
+		SetClientID(token.ClientID).
+		SetUserID(token.UserID).
+		SetConnectorID(token.ConnectorID).
+		// Save utc time into database because ent doesn't support comparing dates with different timezones
+		SetIssuedAt(token.IssuedAt.UTC()).
+		SetExpiry(token.Expiry.UTC()).
+		Save(context.TODO())
+	if err != nil {
+		return convertDBError("create issued token: %w", err)
+	}
+	return nil
+}
+
+// GetIssuedToken extracts an issued token from the database by id.
+func (d *Database) GetIssuedToken(id string) (storage.IssuedToken, error) {
+	token, err := d.client.IssuedToken.Get(context.TODO(), id)
+	if err != nil {
+		return storage.IssuedToken{}, convertDBError("get issued token: %w", err)
+	}
+	return toStorageIssuedToken(token), nil
+}
+
+// DeleteIssuedToken deletes an issued token from the database by id.
+func (d *Database) DeleteIssuedToken(id string) error {
+	err := d.client.IssuedToken.DeleteOneID(id).Exec(context.TODO())
+	if err != nil {
+		return convertDBError("delete issued token: %w", err)
+	}
+	return nil
+}
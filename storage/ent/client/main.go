@@ -12,7 +12,9 @@ import (
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
 	"github.com/dexidp/dex/storage/ent/db/migrate"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
 )
 
 var _ storage.Storage = (*Database)(nil)
@@ -106,5 +108,21 @@ func (d *Database) GarbageCollect(now time.Time) (storage.GCResult, error) {
 	}
 	result.DeviceTokens = int64(q)
 
+	q, err = d.client.IssuedToken.Delete().
+		Where(issuedtoken.ExpiryLT(utcNow)).
+		Exec(context.TODO())
+	if err != nil {
+		return result, convertDBError("gc issued token: %w", err)
+	}
+	result.IssuedTokens = int64(q)
+
+	q, err = d.client.PushedAuthRequest.Delete().
+		Where(pushedauthrequest.ExpiryLT(utcNow)).
+		Exec(context.TODO())
+	if err != nil {
+		return result, convertDBError("gc pushed auth request: %w", err)
+	}
+	result.PushedAuthRequests = int64(q)
+
 	return result, err
 }
@@ -21,6 +21,7 @@ func (d *Database) CreateOfflineSessions(session storage.OfflineSessions) error
 		SetUserID(session.UserID).
 		SetConnID(session.ConnID).
 		SetConnectorData(session.ConnectorData).
+		SetLastLogin(session.LastLogin).
 		SetRefresh(encodedRefresh).
 		Save(context.TODO())
 	if err != nil {
@@ -79,6 +80,7 @@ func (d *Database) UpdateOfflineSessions(userID string, connID string, updater f
 		SetUserID(newOfflineSession.UserID).
 		SetConnID(newOfflineSession.ConnID).
 		SetConnectorData(newOfflineSession.ConnectorData).
+		SetLastLogin(newOfflineSession.LastLogin).
 		SetRefresh(encodedRefresh).
 		Save(context.TODO())
 	if err != nil {
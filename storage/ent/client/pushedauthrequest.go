@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// CreatePushedAuthRequest saves provided pushed auth request into the database.
+func (d *Database) CreatePushedAuthRequest(req storage.PushedAuthRequest) error {
+	_, err := d.client.PushedAuthRequest.Create().
+		SetID(req.ID).
+		SetClientID(req.ClientID).
+		SetParams(req.Params).
+		// Save utc time into database because ent doesn't support comparing dates with different timezones
+		SetExpiry(req.Expiry.UTC()).
+		Save(context.TODO())
+	if err != nil {
+		return convertDBError("create pushed auth request: %w", err)
+	}
+	return nil
+}
+
+// GetPushedAuthRequest extracts a pushed auth request from the database by id.
+func (d *Database) GetPushedAuthRequest(id string) (storage.PushedAuthRequest, error) {
+	req, err := d.client.PushedAuthRequest.Get(context.TODO(), id)
+	if err != nil {
+		return storage.PushedAuthRequest{}, convertDBError("get pushed auth request: %w", err)
+	}
+	return toStoragePushedAuthRequest(req), nil
+}
+
+// DeletePushedAuthRequest deletes a pushed auth request from the database by id.
+func (d *Database) DeletePushedAuthRequest(id string) error {
+	err := d.client.PushedAuthRequest.DeleteOneID(id).Exec(context.TODO())
+	if err != nil {
+		return convertDBError("delete pushed auth request: %w", err)
+	}
+	return nil
+}
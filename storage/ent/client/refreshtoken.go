@@ -12,13 +12,18 @@ func (d *Database) CreateRefresh(refresh storage.RefreshToken) error {
 		SetID(refresh.ID).
 		SetClientID(refresh.ClientID).
 		SetScopes(refresh.Scopes).
+		SetResources(refresh.Resources).
 		SetNonce(refresh.Nonce).
 		SetClaimsUserID(refresh.Claims.UserID).
 		SetClaimsEmail(refresh.Claims.Email).
 		SetClaimsEmailVerified(refresh.Claims.EmailVerified).
 		SetClaimsUsername(refresh.Claims.Username).
 		SetClaimsPreferredUsername(refresh.Claims.PreferredUsername).
+		SetClaimsAuthTime(refresh.Claims.AuthTime).
 		SetClaimsGroups(refresh.Claims.Groups).
+		SetClaimsOrganizations(refresh.Claims.Organizations).
+		SetClaimsAddress(refresh.Claims.Address).
+		SetClaimsCustom(refresh.Claims.CustomClaims).
 		SetConnectorID(refresh.ConnectorID).
 		SetConnectorData(refresh.ConnectorData).
 		SetToken(refresh.Token).
@@ -85,13 +90,18 @@ func (d *Database) UpdateRefreshToken(id string, updater func(old storage.Refres
 	_, err = tx.RefreshToken.UpdateOneID(newtToken.ID).
 		SetClientID(newtToken.ClientID).
 		SetScopes(newtToken.Scopes).
+		SetResources(newtToken.Resources).
 		SetNonce(newtToken.Nonce).
 		SetClaimsUserID(newtToken.Claims.UserID).
 		SetClaimsEmail(newtToken.Claims.Email).
 		SetClaimsEmailVerified(newtToken.Claims.EmailVerified).
 		SetClaimsUsername(newtToken.Claims.Username).
 		SetClaimsPreferredUsername(newtToken.Claims.PreferredUsername).
+		SetClaimsAuthTime(newtToken.Claims.AuthTime).
 		SetClaimsGroups(newtToken.Claims.Groups).
+		SetClaimsOrganizations(newtToken.Claims.Organizations).
+		SetClaimsAddress(newtToken.Claims.Address).
+		SetClaimsCustom(newtToken.Claims.CustomClaims).
 		SetConnectorID(newtToken.ConnectorID).
 		SetConnectorData(newtToken.ConnectorData).
 		SetToken(newtToken.Token).
@@ -25,9 +25,14 @@ func toStorageAuthRequest(a *db.AuthRequest) storage.AuthRequest {
 		ClientID:            a.ClientID,
 		ResponseTypes:       a.ResponseTypes,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		RedirectURI:         a.RedirectURI,
 		Nonce:               a.Nonce,
 		State:               a.State,
+		Prompt:              a.Prompt,
+		EssentialClaims:     a.EssentialClaims,
+		ACRValues:           a.AcrValues,
+		MaxAge:              a.MaxAge,
 		ForceApprovalPrompt: a.ForceApprovalPrompt,
 		LoggedIn:            a.LoggedIn,
 		ConnectorID:         a.ConnectorID,
@@ -40,6 +45,10 @@ func toStorageAuthRequest(a *db.AuthRequest) storage.AuthRequest {
 			Email:             a.ClaimsEmail,
 			EmailVerified:     a.ClaimsEmailVerified,
 			Groups:            a.ClaimsGroups,
+			Organizations:     a.ClaimsOrganizations,
+			Address:           a.ClaimsAddress,
+			CustomClaims:      a.ClaimsCustom,
+			AuthTime:          a.ClaimsAuthTime,
 		},
 		PKCE: storage.PKCE{
 			CodeChallenge:       a.CodeChallenge,
@@ -53,6 +62,7 @@ func toStorageAuthCode(a *db.AuthCode) storage.AuthCode {
 		ID:            a.ID,
 		ClientID:      a.ClientID,
 		Scopes:        a.Scopes,
+		Resources:     a.Resources,
 		RedirectURI:   a.RedirectURI,
 		Nonce:         a.Nonce,
 		ConnectorID:   a.ConnectorID,
@@ -65,6 +75,10 @@ func toStorageAuthCode(a *db.AuthCode) storage.AuthCode {
 			Email:             a.ClaimsEmail,
 			EmailVerified:     a.ClaimsEmailVerified,
 			Groups:            a.ClaimsGroups,
+			Organizations:     a.ClaimsOrganizations,
+			Address:           a.ClaimsAddress,
+			CustomClaims:      a.ClaimsCustom,
+			AuthTime:          a.ClaimsAuthTime,
 		},
 		PKCE: storage.PKCE{
 			CodeChallenge:       a.CodeChallenge,
@@ -79,9 +93,19 @@ func toStorageClient(c *db.OAuth2Client) storage.Client {
 		Secret:       c.Secret,
 		RedirectURIs: c.RedirectUris,
 		TrustedPeers: c.TrustedPeers,
+		Resources:    c.Resources,
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+		HashGroups:   c.HashGroups,
+		GroupsSalt:   c.GroupsSalt,
+
+		RequireVerifiedEmail:   c.RequireVerifiedEmail,
+		RedirectURIPathPattern: c.RedirectURIPathPattern,
+		ConsentText:            c.ConsentText,
+		PrivacyPolicyURL:       c.PrivacyPolicyURL,
+		AllowedGroupPrefixes:   c.AllowedGroupPrefixes,
+		AllowedGroupsPattern:   c.AllowedGroupsPattern,
 	}
 }
 
@@ -99,6 +123,7 @@ func toStorageOfflineSession(o *db.OfflineSession) storage.OfflineSessions {
 		UserID:        o.UserID,
 		ConnID:        o.ConnID,
 		ConnectorData: *o.ConnectorData,
+		LastLogin:     o.LastLogin,
 	}
 
 	if o.Refresh != nil {
@@ -124,6 +149,7 @@ func toStorageRefreshToken(r *db.RefreshToken) storage.RefreshToken {
 		ConnectorID:   r.ConnectorID,
 		ConnectorData: *r.ConnectorData,
 		Scopes:        r.Scopes,
+		Resources:     r.Resources,
 		Nonce:         r.Nonce,
 		Claims: storage.Claims{
 			UserID:            r.ClaimsUserID,
@@ -132,6 +158,10 @@ func toStorageRefreshToken(r *db.RefreshToken) storage.RefreshToken {
 			Email:             r.ClaimsEmail,
 			EmailVerified:     r.ClaimsEmailVerified,
 			Groups:            r.ClaimsGroups,
+			Organizations:     r.ClaimsOrganizations,
+			Address:           r.ClaimsAddress,
+			CustomClaims:      r.ClaimsCustom,
+			AuthTime:          r.ClaimsAuthTime,
 		},
 	}
 }
@@ -166,3 +196,31 @@ func toStorageDeviceToken(t *db.DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollInterval,
 	}
 }
+
+func toStorageIssuedToken(t *db.IssuedToken) storage.IssuedToken {
+	return storage.IssuedToken{
+		ID:          t.ID,
+		ClientID:    t.ClientID,
+		UserID:      t.UserID,
+		ConnectorID: t.ConnectorID,
+		IssuedAt:    t.IssuedAt,
+		Expiry:      t.Expiry,
+	}
+}
+
+func toStorageUpstreamKeySet(k *db.UpstreamKeySet) storage.UpstreamKeySet {
+	return storage.UpstreamKeySet{
+		ConnectorID: k.ID,
+		KeySet:      k.KeySet,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+func toStoragePushedAuthRequest(p *db.PushedAuthRequest) storage.PushedAuthRequest {
+	return storage.PushedAuthRequest{
+		ID:       p.ID,
+		ClientID: p.ClientID,
+		Params:   p.Params,
+		Expiry:   p.Expiry,
+	}
+}
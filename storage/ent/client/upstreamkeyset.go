@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// CreateUpstreamKeySet saves the key set of the upstream provider of a connector into the database.
+func (d *Database) CreateUpstreamKeySet(keySet storage.UpstreamKeySet) error {
+	_, err := d.client.UpstreamKeySet.Create().
+		SetID(keySet.ConnectorID).
+		SetKeySet(keySet.KeySet).
+		SetUpdatedAt(keySet.UpdatedAt.UTC()).
+		Save(context.TODO())
+	if err != nil {
+		return convertDBError("create upstream key set: %w", err)
+	}
+	return nil
+}
+
+// GetUpstreamKeySet extracts the key set of the upstream provider of a connector from the database.
+func (d *Database) GetUpstreamKeySet(connectorID string) (storage.UpstreamKeySet, error) {
+	keySet, err := d.client.UpstreamKeySet.Get(context.TODO(), connectorID)
+	if err != nil {
+		return storage.UpstreamKeySet{}, convertDBError("get upstream key set: %w", err)
+	}
+	return toStorageUpstreamKeySet(keySet), nil
+}
+
+// UpdateUpstreamKeySet changes the key set of the upstream provider of a connector using an updater function and saves it to the database.
+func (d *Database) UpdateUpstreamKeySet(connectorID string, updater func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error)) error {
+	tx, err := d.BeginTx(context.TODO())
+	if err != nil {
+		return convertDBError("update upstream key set tx: %w", err)
+	}
+
+	keySet, err := tx.UpstreamKeySet.Get(context.TODO(), connectorID)
+	if err != nil {
+		return rollback(tx, "update upstream key set database: %w", err)
+	}
+
+	newKeySet, err := updater(toStorageUpstreamKeySet(keySet))
+	if err != nil {
+		return rollback(tx, "update upstream key set updating: %w", err)
+	}
+
+	_, err = tx.UpstreamKeySet.UpdateOneID(connectorID).
+		SetKeySet(newKeySet.KeySet).
+		SetUpdatedAt(newKeySet.UpdatedAt.UTC()).
+		Save(context.TODO())
+	if err != nil {
+		return rollback(tx, "update upstream key set uploading: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return rollback(tx, "update upstream key set commit: %w", err)
+	}
+
+	return nil
+}
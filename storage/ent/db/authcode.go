@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 )
 
@@ -21,6 +22,8 @@ type AuthCode struct {
 	ClientID string `json:"client_id,omitempty"`
 	// Scopes holds the value of the "scopes" field.
 	Scopes []string `json:"scopes,omitempty"`
+	// Resources holds the value of the "resources" field.
+	Resources []string `json:"resources,omitempty"`
 	// Nonce holds the value of the "nonce" field.
 	Nonce string `json:"nonce,omitempty"`
 	// RedirectURI holds the value of the "redirect_uri" field.
@@ -35,8 +38,16 @@ type AuthCode struct {
 	ClaimsEmailVerified bool `json:"claims_email_verified,omitempty"`
 	// ClaimsGroups holds the value of the "claims_groups" field.
 	ClaimsGroups []string `json:"claims_groups,omitempty"`
+	// ClaimsOrganizations holds the value of the "claims_organizations" field.
+	ClaimsOrganizations []string `json:"claims_organizations,omitempty"`
+	// ClaimsAddress holds the value of the "claims_address" field.
+	ClaimsAddress *storage.Address `json:"claims_address,omitempty"`
+	// ClaimsCustom holds the value of the "claims_custom" field.
+	ClaimsCustom map[string]interface{} `json:"claims_custom,omitempty"`
 	// ClaimsPreferredUsername holds the value of the "claims_preferred_username" field.
 	ClaimsPreferredUsername string `json:"claims_preferred_username,omitempty"`
+	// ClaimsAuthTime holds the value of the "claims_auth_time" field.
+	ClaimsAuthTime time.Time `json:"claims_auth_time,omitempty"`
 	// ConnectorID holds the value of the "connector_id" field.
 	ConnectorID string `json:"connector_id,omitempty"`
 	// ConnectorData holds the value of the "connector_data" field.
@@ -54,13 +65,13 @@ func (*AuthCode) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case authcode.FieldScopes, authcode.FieldClaimsGroups, authcode.FieldConnectorData:
+		case authcode.FieldScopes, authcode.FieldResources, authcode.FieldClaimsGroups, authcode.FieldClaimsOrganizations, authcode.FieldClaimsAddress, authcode.FieldClaimsCustom, authcode.FieldConnectorData:
 			values[i] = new([]byte)
 		case authcode.FieldClaimsEmailVerified:
 			values[i] = new(sql.NullBool)
 		case authcode.FieldID, authcode.FieldClientID, authcode.FieldNonce, authcode.FieldRedirectURI, authcode.FieldClaimsUserID, authcode.FieldClaimsUsername, authcode.FieldClaimsEmail, authcode.FieldClaimsPreferredUsername, authcode.FieldConnectorID, authcode.FieldCodeChallenge, authcode.FieldCodeChallengeMethod:
 			values[i] = new(sql.NullString)
-		case authcode.FieldExpiry:
+		case authcode.FieldClaimsAuthTime, authcode.FieldExpiry:
 			values[i] = new(sql.NullTime)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type AuthCode", columns[i])
@@ -97,6 +108,14 @@ func (ac *AuthCode) assignValues(columns []string, values []interface{}) error {
 					return fmt.Errorf("unmarshal field scopes: %w", err)
 				}
 			}
+		case authcode.FieldResources:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field resources", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ac.Resources); err != nil {
+					return fmt.Errorf("unmarshal field resources: %w", err)
+				}
+			}
 		case authcode.FieldNonce:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field nonce", values[i])
@@ -141,12 +160,42 @@ func (ac *AuthCode) assignValues(columns []string, values []interface{}) error {
 					return fmt.Errorf("unmarshal field claims_groups: %w", err)
 				}
 			}
+		case authcode.FieldClaimsOrganizations:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_organizations", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ac.ClaimsOrganizations); err != nil {
+					return fmt.Errorf("unmarshal field claims_organizations: %w", err)
+				}
+			}
+		case authcode.FieldClaimsAddress:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_address", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ac.ClaimsAddress); err != nil {
+					return fmt.Errorf("unmarshal field claims_address: %w", err)
+				}
+			}
+		case authcode.FieldClaimsCustom:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_custom", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ac.ClaimsCustom); err != nil {
+					return fmt.Errorf("unmarshal field claims_custom: %w", err)
+				}
+			}
 		case authcode.FieldClaimsPreferredUsername:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field claims_preferred_username", values[i])
 			} else if value.Valid {
 				ac.ClaimsPreferredUsername = value.String
 			}
+		case authcode.FieldClaimsAuthTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_auth_time", values[i])
+			} else if value.Valid {
+				ac.ClaimsAuthTime = value.Time
+			}
 		case authcode.FieldConnectorID:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field connector_id", values[i])
@@ -209,6 +258,8 @@ func (ac *AuthCode) String() string {
 	builder.WriteString(ac.ClientID)
 	builder.WriteString(", scopes=")
 	builder.WriteString(fmt.Sprintf("%v", ac.Scopes))
+	builder.WriteString(", resources=")
+	builder.WriteString(fmt.Sprintf("%v", ac.Resources))
 	builder.WriteString(", nonce=")
 	builder.WriteString(ac.Nonce)
 	builder.WriteString(", redirect_uri=")
@@ -223,8 +274,16 @@ func (ac *AuthCode) String() string {
 	builder.WriteString(fmt.Sprintf("%v", ac.ClaimsEmailVerified))
 	builder.WriteString(", claims_groups=")
 	builder.WriteString(fmt.Sprintf("%v", ac.ClaimsGroups))
+	builder.WriteString(", claims_organizations=")
+	builder.WriteString(fmt.Sprintf("%v", ac.ClaimsOrganizations))
+	builder.WriteString(", claims_address=")
+	builder.WriteString(fmt.Sprintf("%v", ac.ClaimsAddress))
+	builder.WriteString(", claims_custom=")
+	builder.WriteString(fmt.Sprintf("%v", ac.ClaimsCustom))
 	builder.WriteString(", claims_preferred_username=")
 	builder.WriteString(ac.ClaimsPreferredUsername)
+	builder.WriteString(", claims_auth_time=")
+	builder.WriteString(ac.ClaimsAuthTime.Format(time.ANSIC))
 	builder.WriteString(", connector_id=")
 	builder.WriteString(ac.ConnectorID)
 	if v := ac.ConnectorData; v != nil {
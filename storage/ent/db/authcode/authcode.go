@@ -11,6 +11,8 @@ const (
 	FieldClientID = "client_id"
 	// FieldScopes holds the string denoting the scopes field in the database.
 	FieldScopes = "scopes"
+	// FieldResources holds the string denoting the resources field in the database.
+	FieldResources = "resources"
 	// FieldNonce holds the string denoting the nonce field in the database.
 	FieldNonce = "nonce"
 	// FieldRedirectURI holds the string denoting the redirect_uri field in the database.
@@ -25,8 +27,16 @@ const (
 	FieldClaimsEmailVerified = "claims_email_verified"
 	// FieldClaimsGroups holds the string denoting the claims_groups field in the database.
 	FieldClaimsGroups = "claims_groups"
+	// FieldClaimsOrganizations holds the string denoting the claims_organizations field in the database.
+	FieldClaimsOrganizations = "claims_organizations"
+	// FieldClaimsAddress holds the string denoting the claims_address field in the database.
+	FieldClaimsAddress = "claims_address"
+	// FieldClaimsCustom holds the string denoting the claims_custom field in the database.
+	FieldClaimsCustom = "claims_custom"
 	// FieldClaimsPreferredUsername holds the string denoting the claims_preferred_username field in the database.
 	FieldClaimsPreferredUsername = "claims_preferred_username"
+	// FieldClaimsAuthTime holds the string denoting the claims_auth_time field in the database.
+	FieldClaimsAuthTime = "claims_auth_time"
 	// FieldConnectorID holds the string denoting the connector_id field in the database.
 	FieldConnectorID = "connector_id"
 	// FieldConnectorData holds the string denoting the connector_data field in the database.
@@ -46,6 +56,7 @@ var Columns = []string{
 	FieldID,
 	FieldClientID,
 	FieldScopes,
+	FieldResources,
 	FieldNonce,
 	FieldRedirectURI,
 	FieldClaimsUserID,
@@ -53,7 +64,11 @@ var Columns = []string{
 	FieldClaimsEmail,
 	FieldClaimsEmailVerified,
 	FieldClaimsGroups,
+	FieldClaimsOrganizations,
+	FieldClaimsAddress,
+	FieldClaimsCustom,
 	FieldClaimsPreferredUsername,
+	FieldClaimsAuthTime,
 	FieldConnectorID,
 	FieldConnectorData,
 	FieldExpiry,
@@ -148,6 +148,13 @@ func ClaimsPreferredUsername(v string) predicate.AuthCode {
 	})
 }
 
+// ClaimsAuthTime applies equality check predicate on the "claims_auth_time" field. It's identical to ClaimsAuthTimeEQ.
+func ClaimsAuthTime(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
 // ConnectorID applies equality check predicate on the "connector_id" field. It's identical to ConnectorIDEQ.
 func ConnectorID(v string) predicate.AuthCode {
 	return predicate.AuthCode(func(s *sql.Selector) {
@@ -308,6 +315,20 @@ func ScopesNotNil() predicate.AuthCode {
 	})
 }
 
+// ResourcesIsNil applies the IsNil predicate on the "resources" field.
+func ResourcesIsNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldResources)))
+	})
+}
+
+// ResourcesNotNil applies the NotNil predicate on the "resources" field.
+func ResourcesNotNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldResources)))
+	})
+}
+
 // NonceEQ applies the EQ predicate on the "nonce" field.
 func NonceEQ(v string) predicate.AuthCode {
 	return predicate.AuthCode(func(s *sql.Selector) {
@@ -891,6 +912,48 @@ func ClaimsGroupsNotNil() predicate.AuthCode {
 	})
 }
 
+// ClaimsOrganizationsIsNil applies the IsNil predicate on the "claims_organizations" field.
+func ClaimsOrganizationsIsNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsOrganizations)))
+	})
+}
+
+// ClaimsOrganizationsNotNil applies the NotNil predicate on the "claims_organizations" field.
+func ClaimsOrganizationsNotNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsOrganizations)))
+	})
+}
+
+// ClaimsAddressIsNil applies the IsNil predicate on the "claims_address" field.
+func ClaimsAddressIsNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsAddress)))
+	})
+}
+
+// ClaimsAddressNotNil applies the NotNil predicate on the "claims_address" field.
+func ClaimsAddressNotNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsAddress)))
+	})
+}
+
+// ClaimsCustomIsNil applies the IsNil predicate on the "claims_custom" field.
+func ClaimsCustomIsNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsCustom)))
+	})
+}
+
+// ClaimsCustomNotNil applies the NotNil predicate on the "claims_custom" field.
+func ClaimsCustomNotNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsCustom)))
+	})
+}
+
 // ClaimsPreferredUsernameEQ applies the EQ predicate on the "claims_preferred_username" field.
 func ClaimsPreferredUsernameEQ(v string) predicate.AuthCode {
 	return predicate.AuthCode(func(s *sql.Selector) {
@@ -1002,6 +1065,96 @@ func ClaimsPreferredUsernameContainsFold(v string) predicate.AuthCode {
 	})
 }
 
+// ClaimsAuthTimeEQ applies the EQ predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeEQ(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeNEQ applies the NEQ predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNEQ(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeIn applies the In predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeIn(vs ...time.Time) predicate.AuthCode {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthCode(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClaimsAuthTime), v...))
+	})
+}
+
+// ClaimsAuthTimeNotIn applies the NotIn predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNotIn(vs ...time.Time) predicate.AuthCode {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthCode(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClaimsAuthTime), v...))
+	})
+}
+
+// ClaimsAuthTimeGT applies the GT predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeGT(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeGTE applies the GTE predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeGTE(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeLT applies the LT predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeLT(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeLTE applies the LTE predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeLTE(v time.Time) predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeIsNil applies the IsNil predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeIsNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsAuthTime)))
+	})
+}
+
+// ClaimsAuthTimeNotNil applies the NotNil predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNotNil() predicate.AuthCode {
+	return predicate.AuthCode(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsAuthTime)))
+	})
+}
+
 // ConnectorIDEQ applies the EQ predicate on the "connector_id" field.
 func ConnectorIDEQ(v string) predicate.AuthCode {
 	return predicate.AuthCode(func(s *sql.Selector) {
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 )
 
@@ -32,6 +33,12 @@ func (acc *AuthCodeCreate) SetScopes(s []string) *AuthCodeCreate {
 	return acc
 }
 
+// SetResources sets the "resources" field.
+func (acc *AuthCodeCreate) SetResources(s []string) *AuthCodeCreate {
+	acc.mutation.SetResources(s)
+	return acc
+}
+
 // SetNonce sets the "nonce" field.
 func (acc *AuthCodeCreate) SetNonce(s string) *AuthCodeCreate {
 	acc.mutation.SetNonce(s)
@@ -74,6 +81,24 @@ func (acc *AuthCodeCreate) SetClaimsGroups(s []string) *AuthCodeCreate {
 	return acc
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (acc *AuthCodeCreate) SetClaimsOrganizations(s []string) *AuthCodeCreate {
+	acc.mutation.SetClaimsOrganizations(s)
+	return acc
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (acc *AuthCodeCreate) SetClaimsAddress(s *storage.Address) *AuthCodeCreate {
+	acc.mutation.SetClaimsAddress(s)
+	return acc
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (acc *AuthCodeCreate) SetClaimsCustom(m map[string]interface{}) *AuthCodeCreate {
+	acc.mutation.SetClaimsCustom(m)
+	return acc
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (acc *AuthCodeCreate) SetClaimsPreferredUsername(s string) *AuthCodeCreate {
 	acc.mutation.SetClaimsPreferredUsername(s)
@@ -88,6 +113,20 @@ func (acc *AuthCodeCreate) SetNillableClaimsPreferredUsername(s *string) *AuthCo
 	return acc
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (acc *AuthCodeCreate) SetClaimsAuthTime(t time.Time) *AuthCodeCreate {
+	acc.mutation.SetClaimsAuthTime(t)
+	return acc
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (acc *AuthCodeCreate) SetNillableClaimsAuthTime(t *time.Time) *AuthCodeCreate {
+	if t != nil {
+		acc.SetClaimsAuthTime(*t)
+	}
+	return acc
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (acc *AuthCodeCreate) SetConnectorID(s string) *AuthCodeCreate {
 	acc.mutation.SetConnectorID(s)
@@ -355,6 +394,14 @@ func (acc *AuthCodeCreate) createSpec() (*AuthCode, *sqlgraph.CreateSpec) {
 		})
 		_node.Scopes = value
 	}
+	if value, ok := acc.mutation.Resources(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldResources,
+		})
+		_node.Resources = value
+	}
 	if value, ok := acc.mutation.Nonce(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -411,6 +458,30 @@ func (acc *AuthCodeCreate) createSpec() (*AuthCode, *sqlgraph.CreateSpec) {
 		})
 		_node.ClaimsGroups = value
 	}
+	if value, ok := acc.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsOrganizations,
+		})
+		_node.ClaimsOrganizations = value
+	}
+	if value, ok := acc.mutation.ClaimsAddress(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsAddress,
+		})
+		_node.ClaimsAddress = value
+	}
+	if value, ok := acc.mutation.ClaimsCustom(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsCustom,
+		})
+		_node.ClaimsCustom = value
+	}
 	if value, ok := acc.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -419,6 +490,14 @@ func (acc *AuthCodeCreate) createSpec() (*AuthCode, *sqlgraph.CreateSpec) {
 		})
 		_node.ClaimsPreferredUsername = value
 	}
+	if value, ok := acc.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authcode.FieldClaimsAuthTime,
+		})
+		_node.ClaimsAuthTime = value
+	}
 	if value, ok := acc.mutation.ConnectorID(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authcode"
 	"github.com/dexidp/dex/storage/ent/db/predicate"
 )
@@ -46,6 +47,18 @@ func (acu *AuthCodeUpdate) ClearScopes() *AuthCodeUpdate {
 	return acu
 }
 
+// SetResources sets the "resources" field.
+func (acu *AuthCodeUpdate) SetResources(s []string) *AuthCodeUpdate {
+	acu.mutation.SetResources(s)
+	return acu
+}
+
+// ClearResources clears the value of the "resources" field.
+func (acu *AuthCodeUpdate) ClearResources() *AuthCodeUpdate {
+	acu.mutation.ClearResources()
+	return acu
+}
+
 // SetNonce sets the "nonce" field.
 func (acu *AuthCodeUpdate) SetNonce(s string) *AuthCodeUpdate {
 	acu.mutation.SetNonce(s)
@@ -94,6 +107,42 @@ func (acu *AuthCodeUpdate) ClearClaimsGroups() *AuthCodeUpdate {
 	return acu
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (acu *AuthCodeUpdate) SetClaimsOrganizations(s []string) *AuthCodeUpdate {
+	acu.mutation.SetClaimsOrganizations(s)
+	return acu
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (acu *AuthCodeUpdate) ClearClaimsOrganizations() *AuthCodeUpdate {
+	acu.mutation.ClearClaimsOrganizations()
+	return acu
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (acu *AuthCodeUpdate) SetClaimsAddress(s *storage.Address) *AuthCodeUpdate {
+	acu.mutation.SetClaimsAddress(s)
+	return acu
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (acu *AuthCodeUpdate) ClearClaimsAddress() *AuthCodeUpdate {
+	acu.mutation.ClearClaimsAddress()
+	return acu
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (acu *AuthCodeUpdate) SetClaimsCustom(m map[string]interface{}) *AuthCodeUpdate {
+	acu.mutation.SetClaimsCustom(m)
+	return acu
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (acu *AuthCodeUpdate) ClearClaimsCustom() *AuthCodeUpdate {
+	acu.mutation.ClearClaimsCustom()
+	return acu
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (acu *AuthCodeUpdate) SetClaimsPreferredUsername(s string) *AuthCodeUpdate {
 	acu.mutation.SetClaimsPreferredUsername(s)
@@ -108,6 +157,26 @@ func (acu *AuthCodeUpdate) SetNillableClaimsPreferredUsername(s *string) *AuthCo
 	return acu
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (acu *AuthCodeUpdate) SetClaimsAuthTime(t time.Time) *AuthCodeUpdate {
+	acu.mutation.SetClaimsAuthTime(t)
+	return acu
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (acu *AuthCodeUpdate) SetNillableClaimsAuthTime(t *time.Time) *AuthCodeUpdate {
+	if t != nil {
+		acu.SetClaimsAuthTime(*t)
+	}
+	return acu
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (acu *AuthCodeUpdate) ClearClaimsAuthTime() *AuthCodeUpdate {
+	acu.mutation.ClearClaimsAuthTime()
+	return acu
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (acu *AuthCodeUpdate) SetConnectorID(s string) *AuthCodeUpdate {
 	acu.mutation.SetConnectorID(s)
@@ -303,6 +372,19 @@ func (acu *AuthCodeUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authcode.FieldScopes,
 		})
 	}
+	if value, ok := acu.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldResources,
+		})
+	}
+	if acu.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldResources,
+		})
+	}
 	if value, ok := acu.mutation.Nonce(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -358,6 +440,45 @@ func (acu *AuthCodeUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authcode.FieldClaimsGroups,
 		})
 	}
+	if value, ok := acu.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsOrganizations,
+		})
+	}
+	if acu.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := acu.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsAddress,
+		})
+	}
+	if acu.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsAddress,
+		})
+	}
+	if value, ok := acu.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsCustom,
+		})
+	}
+	if acu.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsCustom,
+		})
+	}
 	if value, ok := acu.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -365,6 +486,19 @@ func (acu *AuthCodeUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authcode.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := acu.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authcode.FieldClaimsAuthTime,
+		})
+	}
+	if acu.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: authcode.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := acu.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -443,6 +577,18 @@ func (acuo *AuthCodeUpdateOne) ClearScopes() *AuthCodeUpdateOne {
 	return acuo
 }
 
+// SetResources sets the "resources" field.
+func (acuo *AuthCodeUpdateOne) SetResources(s []string) *AuthCodeUpdateOne {
+	acuo.mutation.SetResources(s)
+	return acuo
+}
+
+// ClearResources clears the value of the "resources" field.
+func (acuo *AuthCodeUpdateOne) ClearResources() *AuthCodeUpdateOne {
+	acuo.mutation.ClearResources()
+	return acuo
+}
+
 // SetNonce sets the "nonce" field.
 func (acuo *AuthCodeUpdateOne) SetNonce(s string) *AuthCodeUpdateOne {
 	acuo.mutation.SetNonce(s)
@@ -491,6 +637,42 @@ func (acuo *AuthCodeUpdateOne) ClearClaimsGroups() *AuthCodeUpdateOne {
 	return acuo
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (acuo *AuthCodeUpdateOne) SetClaimsOrganizations(s []string) *AuthCodeUpdateOne {
+	acuo.mutation.SetClaimsOrganizations(s)
+	return acuo
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (acuo *AuthCodeUpdateOne) ClearClaimsOrganizations() *AuthCodeUpdateOne {
+	acuo.mutation.ClearClaimsOrganizations()
+	return acuo
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (acuo *AuthCodeUpdateOne) SetClaimsAddress(s *storage.Address) *AuthCodeUpdateOne {
+	acuo.mutation.SetClaimsAddress(s)
+	return acuo
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (acuo *AuthCodeUpdateOne) ClearClaimsAddress() *AuthCodeUpdateOne {
+	acuo.mutation.ClearClaimsAddress()
+	return acuo
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (acuo *AuthCodeUpdateOne) SetClaimsCustom(m map[string]interface{}) *AuthCodeUpdateOne {
+	acuo.mutation.SetClaimsCustom(m)
+	return acuo
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (acuo *AuthCodeUpdateOne) ClearClaimsCustom() *AuthCodeUpdateOne {
+	acuo.mutation.ClearClaimsCustom()
+	return acuo
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (acuo *AuthCodeUpdateOne) SetClaimsPreferredUsername(s string) *AuthCodeUpdateOne {
 	acuo.mutation.SetClaimsPreferredUsername(s)
@@ -505,6 +687,26 @@ func (acuo *AuthCodeUpdateOne) SetNillableClaimsPreferredUsername(s *string) *Au
 	return acuo
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (acuo *AuthCodeUpdateOne) SetClaimsAuthTime(t time.Time) *AuthCodeUpdateOne {
+	acuo.mutation.SetClaimsAuthTime(t)
+	return acuo
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (acuo *AuthCodeUpdateOne) SetNillableClaimsAuthTime(t *time.Time) *AuthCodeUpdateOne {
+	if t != nil {
+		acuo.SetClaimsAuthTime(*t)
+	}
+	return acuo
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (acuo *AuthCodeUpdateOne) ClearClaimsAuthTime() *AuthCodeUpdateOne {
+	acuo.mutation.ClearClaimsAuthTime()
+	return acuo
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (acuo *AuthCodeUpdateOne) SetConnectorID(s string) *AuthCodeUpdateOne {
 	acuo.mutation.SetConnectorID(s)
@@ -724,6 +926,19 @@ func (acuo *AuthCodeUpdateOne) sqlSave(ctx context.Context) (_node *AuthCode, er
 			Column: authcode.FieldScopes,
 		})
 	}
+	if value, ok := acuo.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldResources,
+		})
+	}
+	if acuo.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldResources,
+		})
+	}
 	if value, ok := acuo.mutation.Nonce(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -779,6 +994,45 @@ func (acuo *AuthCodeUpdateOne) sqlSave(ctx context.Context) (_node *AuthCode, er
 			Column: authcode.FieldClaimsGroups,
 		})
 	}
+	if value, ok := acuo.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsOrganizations,
+		})
+	}
+	if acuo.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := acuo.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsAddress,
+		})
+	}
+	if acuo.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsAddress,
+		})
+	}
+	if value, ok := acuo.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authcode.FieldClaimsCustom,
+		})
+	}
+	if acuo.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authcode.FieldClaimsCustom,
+		})
+	}
 	if value, ok := acuo.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -786,6 +1040,19 @@ func (acuo *AuthCodeUpdateOne) sqlSave(ctx context.Context) (_node *AuthCode, er
 			Column: authcode.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := acuo.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authcode.FieldClaimsAuthTime,
+		})
+	}
+	if acuo.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: authcode.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := acuo.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
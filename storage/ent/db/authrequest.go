@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
 )
 
@@ -21,6 +22,8 @@ type AuthRequest struct {
 	ClientID string `json:"client_id,omitempty"`
 	// Scopes holds the value of the "scopes" field.
 	Scopes []string `json:"scopes,omitempty"`
+	// Resources holds the value of the "resources" field.
+	Resources []string `json:"resources,omitempty"`
 	// ResponseTypes holds the value of the "response_types" field.
 	ResponseTypes []string `json:"response_types,omitempty"`
 	// RedirectURI holds the value of the "redirect_uri" field.
@@ -29,6 +32,14 @@ type AuthRequest struct {
 	Nonce string `json:"nonce,omitempty"`
 	// State holds the value of the "state" field.
 	State string `json:"state,omitempty"`
+	// Prompt holds the value of the "prompt" field.
+	Prompt string `json:"prompt,omitempty"`
+	// EssentialClaims holds the value of the "essential_claims" field.
+	EssentialClaims []string `json:"essential_claims,omitempty"`
+	// AcrValues holds the value of the "acr_values" field.
+	AcrValues []string `json:"acr_values,omitempty"`
+	// MaxAge holds the value of the "max_age" field.
+	MaxAge *int `json:"max_age,omitempty"`
 	// ForceApprovalPrompt holds the value of the "force_approval_prompt" field.
 	ForceApprovalPrompt bool `json:"force_approval_prompt,omitempty"`
 	// LoggedIn holds the value of the "logged_in" field.
@@ -43,8 +54,16 @@ type AuthRequest struct {
 	ClaimsEmailVerified bool `json:"claims_email_verified,omitempty"`
 	// ClaimsGroups holds the value of the "claims_groups" field.
 	ClaimsGroups []string `json:"claims_groups,omitempty"`
+	// ClaimsOrganizations holds the value of the "claims_organizations" field.
+	ClaimsOrganizations []string `json:"claims_organizations,omitempty"`
+	// ClaimsAddress holds the value of the "claims_address" field.
+	ClaimsAddress *storage.Address `json:"claims_address,omitempty"`
+	// ClaimsCustom holds the value of the "claims_custom" field.
+	ClaimsCustom map[string]interface{} `json:"claims_custom,omitempty"`
 	// ClaimsPreferredUsername holds the value of the "claims_preferred_username" field.
 	ClaimsPreferredUsername string `json:"claims_preferred_username,omitempty"`
+	// ClaimsAuthTime holds the value of the "claims_auth_time" field.
+	ClaimsAuthTime time.Time `json:"claims_auth_time,omitempty"`
 	// ConnectorID holds the value of the "connector_id" field.
 	ConnectorID string `json:"connector_id,omitempty"`
 	// ConnectorData holds the value of the "connector_data" field.
@@ -62,13 +81,15 @@ func (*AuthRequest) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case authrequest.FieldScopes, authrequest.FieldResponseTypes, authrequest.FieldClaimsGroups, authrequest.FieldConnectorData:
+		case authrequest.FieldScopes, authrequest.FieldResources, authrequest.FieldResponseTypes, authrequest.FieldEssentialClaims, authrequest.FieldAcrValues, authrequest.FieldClaimsGroups, authrequest.FieldClaimsOrganizations, authrequest.FieldClaimsAddress, authrequest.FieldClaimsCustom, authrequest.FieldConnectorData:
 			values[i] = new([]byte)
 		case authrequest.FieldForceApprovalPrompt, authrequest.FieldLoggedIn, authrequest.FieldClaimsEmailVerified:
 			values[i] = new(sql.NullBool)
-		case authrequest.FieldID, authrequest.FieldClientID, authrequest.FieldRedirectURI, authrequest.FieldNonce, authrequest.FieldState, authrequest.FieldClaimsUserID, authrequest.FieldClaimsUsername, authrequest.FieldClaimsEmail, authrequest.FieldClaimsPreferredUsername, authrequest.FieldConnectorID, authrequest.FieldCodeChallenge, authrequest.FieldCodeChallengeMethod:
+		case authrequest.FieldMaxAge:
+			values[i] = new(sql.NullInt64)
+		case authrequest.FieldID, authrequest.FieldClientID, authrequest.FieldRedirectURI, authrequest.FieldNonce, authrequest.FieldState, authrequest.FieldPrompt, authrequest.FieldClaimsUserID, authrequest.FieldClaimsUsername, authrequest.FieldClaimsEmail, authrequest.FieldClaimsPreferredUsername, authrequest.FieldConnectorID, authrequest.FieldCodeChallenge, authrequest.FieldCodeChallengeMethod:
 			values[i] = new(sql.NullString)
-		case authrequest.FieldExpiry:
+		case authrequest.FieldClaimsAuthTime, authrequest.FieldExpiry:
 			values[i] = new(sql.NullTime)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type AuthRequest", columns[i])
@@ -105,6 +126,14 @@ func (ar *AuthRequest) assignValues(columns []string, values []interface{}) erro
 					return fmt.Errorf("unmarshal field scopes: %w", err)
 				}
 			}
+		case authrequest.FieldResources:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field resources", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.Resources); err != nil {
+					return fmt.Errorf("unmarshal field resources: %w", err)
+				}
+			}
 		case authrequest.FieldResponseTypes:
 			if value, ok := values[i].(*[]byte); !ok {
 				return fmt.Errorf("unexpected type %T for field response_types", values[i])
@@ -131,6 +160,35 @@ func (ar *AuthRequest) assignValues(columns []string, values []interface{}) erro
 			} else if value.Valid {
 				ar.State = value.String
 			}
+		case authrequest.FieldPrompt:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prompt", values[i])
+			} else if value.Valid {
+				ar.Prompt = value.String
+			}
+		case authrequest.FieldEssentialClaims:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field essential_claims", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.EssentialClaims); err != nil {
+					return fmt.Errorf("unmarshal field essential_claims: %w", err)
+				}
+			}
+		case authrequest.FieldAcrValues:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field acr_values", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.AcrValues); err != nil {
+					return fmt.Errorf("unmarshal field acr_values: %w", err)
+				}
+			}
+		case authrequest.FieldMaxAge:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_age", values[i])
+			} else if value.Valid {
+				ar.MaxAge = new(int)
+				*ar.MaxAge = int(value.Int64)
+			}
 		case authrequest.FieldForceApprovalPrompt:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field force_approval_prompt", values[i])
@@ -175,12 +233,42 @@ func (ar *AuthRequest) assignValues(columns []string, values []interface{}) erro
 					return fmt.Errorf("unmarshal field claims_groups: %w", err)
 				}
 			}
+		case authrequest.FieldClaimsOrganizations:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_organizations", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.ClaimsOrganizations); err != nil {
+					return fmt.Errorf("unmarshal field claims_organizations: %w", err)
+				}
+			}
+		case authrequest.FieldClaimsAddress:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_address", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.ClaimsAddress); err != nil {
+					return fmt.Errorf("unmarshal field claims_address: %w", err)
+				}
+			}
+		case authrequest.FieldClaimsCustom:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_custom", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ar.ClaimsCustom); err != nil {
+					return fmt.Errorf("unmarshal field claims_custom: %w", err)
+				}
+			}
 		case authrequest.FieldClaimsPreferredUsername:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field claims_preferred_username", values[i])
 			} else if value.Valid {
 				ar.ClaimsPreferredUsername = value.String
 			}
+		case authrequest.FieldClaimsAuthTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_auth_time", values[i])
+			} else if value.Valid {
+				ar.ClaimsAuthTime = value.Time
+			}
 		case authrequest.FieldConnectorID:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field connector_id", values[i])
@@ -243,6 +331,8 @@ func (ar *AuthRequest) String() string {
 	builder.WriteString(ar.ClientID)
 	builder.WriteString(", scopes=")
 	builder.WriteString(fmt.Sprintf("%v", ar.Scopes))
+	builder.WriteString(", resources=")
+	builder.WriteString(fmt.Sprintf("%v", ar.Resources))
 	builder.WriteString(", response_types=")
 	builder.WriteString(fmt.Sprintf("%v", ar.ResponseTypes))
 	builder.WriteString(", redirect_uri=")
@@ -251,6 +341,16 @@ func (ar *AuthRequest) String() string {
 	builder.WriteString(ar.Nonce)
 	builder.WriteString(", state=")
 	builder.WriteString(ar.State)
+	builder.WriteString(", prompt=")
+	builder.WriteString(ar.Prompt)
+	builder.WriteString(", essential_claims=")
+	builder.WriteString(fmt.Sprintf("%v", ar.EssentialClaims))
+	builder.WriteString(", acr_values=")
+	builder.WriteString(fmt.Sprintf("%v", ar.AcrValues))
+	if v := ar.MaxAge; v != nil {
+		builder.WriteString(", max_age=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteString(", force_approval_prompt=")
 	builder.WriteString(fmt.Sprintf("%v", ar.ForceApprovalPrompt))
 	builder.WriteString(", logged_in=")
@@ -265,8 +365,16 @@ func (ar *AuthRequest) String() string {
 	builder.WriteString(fmt.Sprintf("%v", ar.ClaimsEmailVerified))
 	builder.WriteString(", claims_groups=")
 	builder.WriteString(fmt.Sprintf("%v", ar.ClaimsGroups))
+	builder.WriteString(", claims_organizations=")
+	builder.WriteString(fmt.Sprintf("%v", ar.ClaimsOrganizations))
+	builder.WriteString(", claims_address=")
+	builder.WriteString(fmt.Sprintf("%v", ar.ClaimsAddress))
+	builder.WriteString(", claims_custom=")
+	builder.WriteString(fmt.Sprintf("%v", ar.ClaimsCustom))
 	builder.WriteString(", claims_preferred_username=")
 	builder.WriteString(ar.ClaimsPreferredUsername)
+	builder.WriteString(", claims_auth_time=")
+	builder.WriteString(ar.ClaimsAuthTime.Format(time.ANSIC))
 	builder.WriteString(", connector_id=")
 	builder.WriteString(ar.ConnectorID)
 	if v := ar.ConnectorData; v != nil {
@@ -11,6 +11,8 @@ const (
 	FieldClientID = "client_id"
 	// FieldScopes holds the string denoting the scopes field in the database.
 	FieldScopes = "scopes"
+	// FieldResources holds the string denoting the resources field in the database.
+	FieldResources = "resources"
 	// FieldResponseTypes holds the string denoting the response_types field in the database.
 	FieldResponseTypes = "response_types"
 	// FieldRedirectURI holds the string denoting the redirect_uri field in the database.
@@ -19,6 +21,14 @@ const (
 	FieldNonce = "nonce"
 	// FieldState holds the string denoting the state field in the database.
 	FieldState = "state"
+	// FieldPrompt holds the string denoting the prompt field in the database.
+	FieldPrompt = "prompt"
+	// FieldEssentialClaims holds the string denoting the essential_claims field in the database.
+	FieldEssentialClaims = "essential_claims"
+	// FieldAcrValues holds the string denoting the acr_values field in the database.
+	FieldAcrValues = "acr_values"
+	// FieldMaxAge holds the string denoting the max_age field in the database.
+	FieldMaxAge = "max_age"
 	// FieldForceApprovalPrompt holds the string denoting the force_approval_prompt field in the database.
 	FieldForceApprovalPrompt = "force_approval_prompt"
 	// FieldLoggedIn holds the string denoting the logged_in field in the database.
@@ -33,8 +43,16 @@ const (
 	FieldClaimsEmailVerified = "claims_email_verified"
 	// FieldClaimsGroups holds the string denoting the claims_groups field in the database.
 	FieldClaimsGroups = "claims_groups"
+	// FieldClaimsOrganizations holds the string denoting the claims_organizations field in the database.
+	FieldClaimsOrganizations = "claims_organizations"
+	// FieldClaimsAddress holds the string denoting the claims_address field in the database.
+	FieldClaimsAddress = "claims_address"
+	// FieldClaimsCustom holds the string denoting the claims_custom field in the database.
+	FieldClaimsCustom = "claims_custom"
 	// FieldClaimsPreferredUsername holds the string denoting the claims_preferred_username field in the database.
 	FieldClaimsPreferredUsername = "claims_preferred_username"
+	// FieldClaimsAuthTime holds the string denoting the claims_auth_time field in the database.
+	FieldClaimsAuthTime = "claims_auth_time"
 	// FieldConnectorID holds the string denoting the connector_id field in the database.
 	FieldConnectorID = "connector_id"
 	// FieldConnectorData holds the string denoting the connector_data field in the database.
@@ -54,10 +72,15 @@ var Columns = []string{
 	FieldID,
 	FieldClientID,
 	FieldScopes,
+	FieldResources,
 	FieldResponseTypes,
 	FieldRedirectURI,
 	FieldNonce,
 	FieldState,
+	FieldPrompt,
+	FieldEssentialClaims,
+	FieldAcrValues,
+	FieldMaxAge,
 	FieldForceApprovalPrompt,
 	FieldLoggedIn,
 	FieldClaimsUserID,
@@ -65,7 +88,11 @@ var Columns = []string{
 	FieldClaimsEmail,
 	FieldClaimsEmailVerified,
 	FieldClaimsGroups,
+	FieldClaimsOrganizations,
+	FieldClaimsAddress,
+	FieldClaimsCustom,
 	FieldClaimsPreferredUsername,
+	FieldClaimsAuthTime,
 	FieldConnectorID,
 	FieldConnectorData,
 	FieldExpiry,
@@ -84,6 +111,8 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultPrompt holds the default value on creation for the "prompt" field.
+	DefaultPrompt string
 	// DefaultClaimsPreferredUsername holds the default value on creation for the "claims_preferred_username" field.
 	DefaultClaimsPreferredUsername string
 	// DefaultCodeChallenge holds the default value on creation for the "code_challenge" field.
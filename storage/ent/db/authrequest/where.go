@@ -120,6 +120,20 @@ func State(v string) predicate.AuthRequest {
 	})
 }
 
+// Prompt applies equality check predicate on the "prompt" field. It's identical to PromptEQ.
+func Prompt(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrompt), v))
+	})
+}
+
+// MaxAge applies equality check predicate on the "max_age" field. It's identical to MaxAgeEQ.
+func MaxAge(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldMaxAge), v))
+	})
+}
+
 // ForceApprovalPrompt applies equality check predicate on the "force_approval_prompt" field. It's identical to ForceApprovalPromptEQ.
 func ForceApprovalPrompt(v bool) predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -169,6 +183,13 @@ func ClaimsPreferredUsername(v string) predicate.AuthRequest {
 	})
 }
 
+// ClaimsAuthTime applies equality check predicate on the "claims_auth_time" field. It's identical to ClaimsAuthTimeEQ.
+func ClaimsAuthTime(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
 // ConnectorID applies equality check predicate on the "connector_id" field. It's identical to ConnectorIDEQ.
 func ConnectorID(v string) predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -329,6 +350,20 @@ func ScopesNotNil() predicate.AuthRequest {
 	})
 }
 
+// ResourcesIsNil applies the IsNil predicate on the "resources" field.
+func ResourcesIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldResources)))
+	})
+}
+
+// ResourcesNotNil applies the NotNil predicate on the "resources" field.
+func ResourcesNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldResources)))
+	})
+}
+
 // ResponseTypesIsNil applies the IsNil predicate on the "response_types" field.
 func ResponseTypesIsNil() predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -676,6 +711,235 @@ func StateContainsFold(v string) predicate.AuthRequest {
 	})
 }
 
+// PromptEQ applies the EQ predicate on the "prompt" field.
+func PromptEQ(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptNEQ applies the NEQ predicate on the "prompt" field.
+func PromptNEQ(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptIn applies the In predicate on the "prompt" field.
+func PromptIn(vs ...string) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPrompt), v...))
+	})
+}
+
+// PromptNotIn applies the NotIn predicate on the "prompt" field.
+func PromptNotIn(vs ...string) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPrompt), v...))
+	})
+}
+
+// PromptGT applies the GT predicate on the "prompt" field.
+func PromptGT(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptGTE applies the GTE predicate on the "prompt" field.
+func PromptGTE(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptLT applies the LT predicate on the "prompt" field.
+func PromptLT(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptLTE applies the LTE predicate on the "prompt" field.
+func PromptLTE(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptContains applies the Contains predicate on the "prompt" field.
+func PromptContains(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptHasPrefix applies the HasPrefix predicate on the "prompt" field.
+func PromptHasPrefix(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptHasSuffix applies the HasSuffix predicate on the "prompt" field.
+func PromptHasSuffix(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptEqualFold applies the EqualFold predicate on the "prompt" field.
+func PromptEqualFold(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldPrompt), v))
+	})
+}
+
+// PromptContainsFold applies the ContainsFold predicate on the "prompt" field.
+func PromptContainsFold(v string) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldPrompt), v))
+	})
+}
+
+// EssentialClaimsIsNil applies the IsNil predicate on the "essential_claims" field.
+func EssentialClaimsIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldEssentialClaims)))
+	})
+}
+
+// EssentialClaimsNotNil applies the NotNil predicate on the "essential_claims" field.
+func EssentialClaimsNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldEssentialClaims)))
+	})
+}
+
+// AcrValuesIsNil applies the IsNil predicate on the "acr_values" field.
+func AcrValuesIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAcrValues)))
+	})
+}
+
+// AcrValuesNotNil applies the NotNil predicate on the "acr_values" field.
+func AcrValuesNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAcrValues)))
+	})
+}
+
+// MaxAgeEQ applies the EQ predicate on the "max_age" field.
+func MaxAgeEQ(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeNEQ applies the NEQ predicate on the "max_age" field.
+func MaxAgeNEQ(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeIn applies the In predicate on the "max_age" field.
+func MaxAgeIn(vs ...int) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldMaxAge), v...))
+	})
+}
+
+// MaxAgeNotIn applies the NotIn predicate on the "max_age" field.
+func MaxAgeNotIn(vs ...int) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldMaxAge), v...))
+	})
+}
+
+// MaxAgeGT applies the GT predicate on the "max_age" field.
+func MaxAgeGT(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeGTE applies the GTE predicate on the "max_age" field.
+func MaxAgeGTE(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeLT applies the LT predicate on the "max_age" field.
+func MaxAgeLT(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeLTE applies the LTE predicate on the "max_age" field.
+func MaxAgeLTE(v int) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldMaxAge), v))
+	})
+}
+
+// MaxAgeIsNil applies the IsNil predicate on the "max_age" field.
+func MaxAgeIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldMaxAge)))
+	})
+}
+
+// MaxAgeNotNil applies the NotNil predicate on the "max_age" field.
+func MaxAgeNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldMaxAge)))
+	})
+}
+
 // ForceApprovalPromptEQ applies the EQ predicate on the "force_approval_prompt" field.
 func ForceApprovalPromptEQ(v bool) predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -1065,6 +1329,48 @@ func ClaimsGroupsNotNil() predicate.AuthRequest {
 	})
 }
 
+// ClaimsOrganizationsIsNil applies the IsNil predicate on the "claims_organizations" field.
+func ClaimsOrganizationsIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsOrganizations)))
+	})
+}
+
+// ClaimsOrganizationsNotNil applies the NotNil predicate on the "claims_organizations" field.
+func ClaimsOrganizationsNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsOrganizations)))
+	})
+}
+
+// ClaimsAddressIsNil applies the IsNil predicate on the "claims_address" field.
+func ClaimsAddressIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsAddress)))
+	})
+}
+
+// ClaimsAddressNotNil applies the NotNil predicate on the "claims_address" field.
+func ClaimsAddressNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsAddress)))
+	})
+}
+
+// ClaimsCustomIsNil applies the IsNil predicate on the "claims_custom" field.
+func ClaimsCustomIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsCustom)))
+	})
+}
+
+// ClaimsCustomNotNil applies the NotNil predicate on the "claims_custom" field.
+func ClaimsCustomNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsCustom)))
+	})
+}
+
 // ClaimsPreferredUsernameEQ applies the EQ predicate on the "claims_preferred_username" field.
 func ClaimsPreferredUsernameEQ(v string) predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -1176,6 +1482,96 @@ func ClaimsPreferredUsernameContainsFold(v string) predicate.AuthRequest {
 	})
 }
 
+// ClaimsAuthTimeEQ applies the EQ predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeEQ(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeNEQ applies the NEQ predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNEQ(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeIn applies the In predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeIn(vs ...time.Time) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClaimsAuthTime), v...))
+	})
+}
+
+// ClaimsAuthTimeNotIn applies the NotIn predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNotIn(vs ...time.Time) predicate.AuthRequest {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClaimsAuthTime), v...))
+	})
+}
+
+// ClaimsAuthTimeGT applies the GT predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeGT(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeGTE applies the GTE predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeGTE(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeLT applies the LT predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeLT(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeLTE applies the LTE predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeLTE(v time.Time) predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClaimsAuthTime), v))
+	})
+}
+
+// ClaimsAuthTimeIsNil applies the IsNil predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeIsNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldClaimsAuthTime)))
+	})
+}
+
+// ClaimsAuthTimeNotNil applies the NotNil predicate on the "claims_auth_time" field.
+func ClaimsAuthTimeNotNil() predicate.AuthRequest {
+	return predicate.AuthRequest(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldClaimsAuthTime)))
+	})
+}
+
 // ConnectorIDEQ applies the EQ predicate on the "connector_id" field.
 func ConnectorIDEQ(v string) predicate.AuthRequest {
 	return predicate.AuthRequest(func(s *sql.Selector) {
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
 )
 
@@ -32,6 +33,12 @@ func (arc *AuthRequestCreate) SetScopes(s []string) *AuthRequestCreate {
 	return arc
 }
 
+// SetResources sets the "resources" field.
+func (arc *AuthRequestCreate) SetResources(s []string) *AuthRequestCreate {
+	arc.mutation.SetResources(s)
+	return arc
+}
+
 // SetResponseTypes sets the "response_types" field.
 func (arc *AuthRequestCreate) SetResponseTypes(s []string) *AuthRequestCreate {
 	arc.mutation.SetResponseTypes(s)
@@ -56,6 +63,46 @@ func (arc *AuthRequestCreate) SetState(s string) *AuthRequestCreate {
 	return arc
 }
 
+// SetPrompt sets the "prompt" field.
+func (arc *AuthRequestCreate) SetPrompt(s string) *AuthRequestCreate {
+	arc.mutation.SetPrompt(s)
+	return arc
+}
+
+// SetNillablePrompt sets the "prompt" field if the given value is not nil.
+func (arc *AuthRequestCreate) SetNillablePrompt(s *string) *AuthRequestCreate {
+	if s != nil {
+		arc.SetPrompt(*s)
+	}
+	return arc
+}
+
+// SetEssentialClaims sets the "essential_claims" field.
+func (arc *AuthRequestCreate) SetEssentialClaims(s []string) *AuthRequestCreate {
+	arc.mutation.SetEssentialClaims(s)
+	return arc
+}
+
+// SetAcrValues sets the "acr_values" field.
+func (arc *AuthRequestCreate) SetAcrValues(s []string) *AuthRequestCreate {
+	arc.mutation.SetAcrValues(s)
+	return arc
+}
+
+// SetMaxAge sets the "max_age" field.
+func (arc *AuthRequestCreate) SetMaxAge(i int) *AuthRequestCreate {
+	arc.mutation.SetMaxAge(i)
+	return arc
+}
+
+// SetNillableMaxAge sets the "max_age" field if the given value is not nil.
+func (arc *AuthRequestCreate) SetNillableMaxAge(i *int) *AuthRequestCreate {
+	if i != nil {
+		arc.SetMaxAge(*i)
+	}
+	return arc
+}
+
 // SetForceApprovalPrompt sets the "force_approval_prompt" field.
 func (arc *AuthRequestCreate) SetForceApprovalPrompt(b bool) *AuthRequestCreate {
 	arc.mutation.SetForceApprovalPrompt(b)
@@ -98,6 +145,24 @@ func (arc *AuthRequestCreate) SetClaimsGroups(s []string) *AuthRequestCreate {
 	return arc
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (arc *AuthRequestCreate) SetClaimsOrganizations(s []string) *AuthRequestCreate {
+	arc.mutation.SetClaimsOrganizations(s)
+	return arc
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (arc *AuthRequestCreate) SetClaimsAddress(s *storage.Address) *AuthRequestCreate {
+	arc.mutation.SetClaimsAddress(s)
+	return arc
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (arc *AuthRequestCreate) SetClaimsCustom(m map[string]interface{}) *AuthRequestCreate {
+	arc.mutation.SetClaimsCustom(m)
+	return arc
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (arc *AuthRequestCreate) SetClaimsPreferredUsername(s string) *AuthRequestCreate {
 	arc.mutation.SetClaimsPreferredUsername(s)
@@ -112,6 +177,20 @@ func (arc *AuthRequestCreate) SetNillableClaimsPreferredUsername(s *string) *Aut
 	return arc
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (arc *AuthRequestCreate) SetClaimsAuthTime(t time.Time) *AuthRequestCreate {
+	arc.mutation.SetClaimsAuthTime(t)
+	return arc
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (arc *AuthRequestCreate) SetNillableClaimsAuthTime(t *time.Time) *AuthRequestCreate {
+	if t != nil {
+		arc.SetClaimsAuthTime(*t)
+	}
+	return arc
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (arc *AuthRequestCreate) SetConnectorID(s string) *AuthRequestCreate {
 	arc.mutation.SetConnectorID(s)
@@ -235,6 +314,10 @@ func (arc *AuthRequestCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (arc *AuthRequestCreate) defaults() {
+	if _, ok := arc.mutation.Prompt(); !ok {
+		v := authrequest.DefaultPrompt
+		arc.mutation.SetPrompt(v)
+	}
 	if _, ok := arc.mutation.ClaimsPreferredUsername(); !ok {
 		v := authrequest.DefaultClaimsPreferredUsername
 		arc.mutation.SetClaimsPreferredUsername(v)
@@ -263,6 +346,9 @@ func (arc *AuthRequestCreate) check() error {
 	if _, ok := arc.mutation.State(); !ok {
 		return &ValidationError{Name: "state", err: errors.New(`db: missing required field "AuthRequest.state"`)}
 	}
+	if _, ok := arc.mutation.Prompt(); !ok {
+		return &ValidationError{Name: "prompt", err: errors.New(`db: missing required field "AuthRequest.prompt"`)}
+	}
 	if _, ok := arc.mutation.ForceApprovalPrompt(); !ok {
 		return &ValidationError{Name: "force_approval_prompt", err: errors.New(`db: missing required field "AuthRequest.force_approval_prompt"`)}
 	}
@@ -353,6 +439,14 @@ func (arc *AuthRequestCreate) createSpec() (*AuthRequest, *sqlgraph.CreateSpec)
 		})
 		_node.Scopes = value
 	}
+	if value, ok := arc.mutation.Resources(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldResources,
+		})
+		_node.Resources = value
+	}
 	if value, ok := arc.mutation.ResponseTypes(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeJSON,
@@ -385,6 +479,38 @@ func (arc *AuthRequestCreate) createSpec() (*AuthRequest, *sqlgraph.CreateSpec)
 		})
 		_node.State = value
 	}
+	if value, ok := arc.mutation.Prompt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: authrequest.FieldPrompt,
+		})
+		_node.Prompt = value
+	}
+	if value, ok := arc.mutation.EssentialClaims(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldEssentialClaims,
+		})
+		_node.EssentialClaims = value
+	}
+	if value, ok := arc.mutation.AcrValues(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldAcrValues,
+		})
+		_node.AcrValues = value
+	}
+	if value, ok := arc.mutation.MaxAge(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: authrequest.FieldMaxAge,
+		})
+		_node.MaxAge = &value
+	}
 	if value, ok := arc.mutation.ForceApprovalPrompt(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -441,6 +567,30 @@ func (arc *AuthRequestCreate) createSpec() (*AuthRequest, *sqlgraph.CreateSpec)
 		})
 		_node.ClaimsGroups = value
 	}
+	if value, ok := arc.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsOrganizations,
+		})
+		_node.ClaimsOrganizations = value
+	}
+	if value, ok := arc.mutation.ClaimsAddress(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsAddress,
+		})
+		_node.ClaimsAddress = value
+	}
+	if value, ok := arc.mutation.ClaimsCustom(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsCustom,
+		})
+		_node.ClaimsCustom = value
+	}
 	if value, ok := arc.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -449,6 +599,14 @@ func (arc *AuthRequestCreate) createSpec() (*AuthRequest, *sqlgraph.CreateSpec)
 		})
 		_node.ClaimsPreferredUsername = value
 	}
+	if value, ok := arc.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authrequest.FieldClaimsAuthTime,
+		})
+		_node.ClaimsAuthTime = value
+	}
 	if value, ok := arc.mutation.ConnectorID(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
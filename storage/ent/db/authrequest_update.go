@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/authrequest"
 	"github.com/dexidp/dex/storage/ent/db/predicate"
 )
@@ -46,6 +47,18 @@ func (aru *AuthRequestUpdate) ClearScopes() *AuthRequestUpdate {
 	return aru
 }
 
+// SetResources sets the "resources" field.
+func (aru *AuthRequestUpdate) SetResources(s []string) *AuthRequestUpdate {
+	aru.mutation.SetResources(s)
+	return aru
+}
+
+// ClearResources clears the value of the "resources" field.
+func (aru *AuthRequestUpdate) ClearResources() *AuthRequestUpdate {
+	aru.mutation.ClearResources()
+	return aru
+}
+
 // SetResponseTypes sets the "response_types" field.
 func (aru *AuthRequestUpdate) SetResponseTypes(s []string) *AuthRequestUpdate {
 	aru.mutation.SetResponseTypes(s)
@@ -76,6 +89,71 @@ func (aru *AuthRequestUpdate) SetState(s string) *AuthRequestUpdate {
 	return aru
 }
 
+// SetPrompt sets the "prompt" field.
+func (aru *AuthRequestUpdate) SetPrompt(s string) *AuthRequestUpdate {
+	aru.mutation.SetPrompt(s)
+	return aru
+}
+
+// SetNillablePrompt sets the "prompt" field if the given value is not nil.
+func (aru *AuthRequestUpdate) SetNillablePrompt(s *string) *AuthRequestUpdate {
+	if s != nil {
+		aru.SetPrompt(*s)
+	}
+	return aru
+}
+
+// SetEssentialClaims sets the "essential_claims" field.
+func (aru *AuthRequestUpdate) SetEssentialClaims(s []string) *AuthRequestUpdate {
+	aru.mutation.SetEssentialClaims(s)
+	return aru
+}
+
+// ClearEssentialClaims clears the value of the "essential_claims" field.
+func (aru *AuthRequestUpdate) ClearEssentialClaims() *AuthRequestUpdate {
+	aru.mutation.ClearEssentialClaims()
+	return aru
+}
+
+// SetAcrValues sets the "acr_values" field.
+func (aru *AuthRequestUpdate) SetAcrValues(s []string) *AuthRequestUpdate {
+	aru.mutation.SetAcrValues(s)
+	return aru
+}
+
+// ClearAcrValues clears the value of the "acr_values" field.
+func (aru *AuthRequestUpdate) ClearAcrValues() *AuthRequestUpdate {
+	aru.mutation.ClearAcrValues()
+	return aru
+}
+
+// SetMaxAge sets the "max_age" field.
+func (aru *AuthRequestUpdate) SetMaxAge(i int) *AuthRequestUpdate {
+	aru.mutation.ResetMaxAge()
+	aru.mutation.SetMaxAge(i)
+	return aru
+}
+
+// SetNillableMaxAge sets the "max_age" field if the given value is not nil.
+func (aru *AuthRequestUpdate) SetNillableMaxAge(i *int) *AuthRequestUpdate {
+	if i != nil {
+		aru.SetMaxAge(*i)
+	}
+	return aru
+}
+
+// AddMaxAge adds i to the "max_age" field.
+func (aru *AuthRequestUpdate) AddMaxAge(i int) *AuthRequestUpdate {
+	aru.mutation.AddMaxAge(i)
+	return aru
+}
+
+// ClearMaxAge clears the value of the "max_age" field.
+func (aru *AuthRequestUpdate) ClearMaxAge() *AuthRequestUpdate {
+	aru.mutation.ClearMaxAge()
+	return aru
+}
+
 // SetForceApprovalPrompt sets the "force_approval_prompt" field.
 func (aru *AuthRequestUpdate) SetForceApprovalPrompt(b bool) *AuthRequestUpdate {
 	aru.mutation.SetForceApprovalPrompt(b)
@@ -124,6 +202,42 @@ func (aru *AuthRequestUpdate) ClearClaimsGroups() *AuthRequestUpdate {
 	return aru
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (aru *AuthRequestUpdate) SetClaimsOrganizations(s []string) *AuthRequestUpdate {
+	aru.mutation.SetClaimsOrganizations(s)
+	return aru
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (aru *AuthRequestUpdate) ClearClaimsOrganizations() *AuthRequestUpdate {
+	aru.mutation.ClearClaimsOrganizations()
+	return aru
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (aru *AuthRequestUpdate) SetClaimsAddress(s *storage.Address) *AuthRequestUpdate {
+	aru.mutation.SetClaimsAddress(s)
+	return aru
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (aru *AuthRequestUpdate) ClearClaimsAddress() *AuthRequestUpdate {
+	aru.mutation.ClearClaimsAddress()
+	return aru
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (aru *AuthRequestUpdate) SetClaimsCustom(m map[string]interface{}) *AuthRequestUpdate {
+	aru.mutation.SetClaimsCustom(m)
+	return aru
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (aru *AuthRequestUpdate) ClearClaimsCustom() *AuthRequestUpdate {
+	aru.mutation.ClearClaimsCustom()
+	return aru
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (aru *AuthRequestUpdate) SetClaimsPreferredUsername(s string) *AuthRequestUpdate {
 	aru.mutation.SetClaimsPreferredUsername(s)
@@ -138,6 +252,26 @@ func (aru *AuthRequestUpdate) SetNillableClaimsPreferredUsername(s *string) *Aut
 	return aru
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (aru *AuthRequestUpdate) SetClaimsAuthTime(t time.Time) *AuthRequestUpdate {
+	aru.mutation.SetClaimsAuthTime(t)
+	return aru
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (aru *AuthRequestUpdate) SetNillableClaimsAuthTime(t *time.Time) *AuthRequestUpdate {
+	if t != nil {
+		aru.SetClaimsAuthTime(*t)
+	}
+	return aru
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (aru *AuthRequestUpdate) ClearClaimsAuthTime() *AuthRequestUpdate {
+	aru.mutation.ClearClaimsAuthTime()
+	return aru
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (aru *AuthRequestUpdate) SetConnectorID(s string) *AuthRequestUpdate {
 	aru.mutation.SetConnectorID(s)
@@ -287,6 +421,19 @@ func (aru *AuthRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authrequest.FieldScopes,
 		})
 	}
+	if value, ok := aru.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldResources,
+		})
+	}
+	if aru.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldResources,
+		})
+	}
 	if value, ok := aru.mutation.ResponseTypes(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeJSON,
@@ -321,6 +468,59 @@ func (aru *AuthRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authrequest.FieldState,
 		})
 	}
+	if value, ok := aru.mutation.Prompt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: authrequest.FieldPrompt,
+		})
+	}
+	if value, ok := aru.mutation.EssentialClaims(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldEssentialClaims,
+		})
+	}
+	if aru.mutation.EssentialClaimsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldEssentialClaims,
+		})
+	}
+	if value, ok := aru.mutation.AcrValues(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldAcrValues,
+		})
+	}
+	if aru.mutation.AcrValuesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldAcrValues,
+		})
+	}
+	if value, ok := aru.mutation.MaxAge(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
+	if value, ok := aru.mutation.AddedMaxAge(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
+	if aru.mutation.MaxAgeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
 	if value, ok := aru.mutation.ForceApprovalPrompt(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -376,6 +576,45 @@ func (aru *AuthRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authrequest.FieldClaimsGroups,
 		})
 	}
+	if value, ok := aru.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsOrganizations,
+		})
+	}
+	if aru.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := aru.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsAddress,
+		})
+	}
+	if aru.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsAddress,
+		})
+	}
+	if value, ok := aru.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsCustom,
+		})
+	}
+	if aru.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsCustom,
+		})
+	}
 	if value, ok := aru.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -383,6 +622,19 @@ func (aru *AuthRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: authrequest.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := aru.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authrequest.FieldClaimsAuthTime,
+		})
+	}
+	if aru.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: authrequest.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := aru.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -461,6 +713,18 @@ func (aruo *AuthRequestUpdateOne) ClearScopes() *AuthRequestUpdateOne {
 	return aruo
 }
 
+// SetResources sets the "resources" field.
+func (aruo *AuthRequestUpdateOne) SetResources(s []string) *AuthRequestUpdateOne {
+	aruo.mutation.SetResources(s)
+	return aruo
+}
+
+// ClearResources clears the value of the "resources" field.
+func (aruo *AuthRequestUpdateOne) ClearResources() *AuthRequestUpdateOne {
+	aruo.mutation.ClearResources()
+	return aruo
+}
+
 // SetResponseTypes sets the "response_types" field.
 func (aruo *AuthRequestUpdateOne) SetResponseTypes(s []string) *AuthRequestUpdateOne {
 	aruo.mutation.SetResponseTypes(s)
@@ -491,6 +755,71 @@ func (aruo *AuthRequestUpdateOne) SetState(s string) *AuthRequestUpdateOne {
 	return aruo
 }
 
+// SetPrompt sets the "prompt" field.
+func (aruo *AuthRequestUpdateOne) SetPrompt(s string) *AuthRequestUpdateOne {
+	aruo.mutation.SetPrompt(s)
+	return aruo
+}
+
+// SetNillablePrompt sets the "prompt" field if the given value is not nil.
+func (aruo *AuthRequestUpdateOne) SetNillablePrompt(s *string) *AuthRequestUpdateOne {
+	if s != nil {
+		aruo.SetPrompt(*s)
+	}
+	return aruo
+}
+
+// SetEssentialClaims sets the "essential_claims" field.
+func (aruo *AuthRequestUpdateOne) SetEssentialClaims(s []string) *AuthRequestUpdateOne {
+	aruo.mutation.SetEssentialClaims(s)
+	return aruo
+}
+
+// ClearEssentialClaims clears the value of the "essential_claims" field.
+func (aruo *AuthRequestUpdateOne) ClearEssentialClaims() *AuthRequestUpdateOne {
+	aruo.mutation.ClearEssentialClaims()
+	return aruo
+}
+
+// SetAcrValues sets the "acr_values" field.
+func (aruo *AuthRequestUpdateOne) SetAcrValues(s []string) *AuthRequestUpdateOne {
+	aruo.mutation.SetAcrValues(s)
+	return aruo
+}
+
+// ClearAcrValues clears the value of the "acr_values" field.
+func (aruo *AuthRequestUpdateOne) ClearAcrValues() *AuthRequestUpdateOne {
+	aruo.mutation.ClearAcrValues()
+	return aruo
+}
+
+// SetMaxAge sets the "max_age" field.
+func (aruo *AuthRequestUpdateOne) SetMaxAge(i int) *AuthRequestUpdateOne {
+	aruo.mutation.ResetMaxAge()
+	aruo.mutation.SetMaxAge(i)
+	return aruo
+}
+
+// SetNillableMaxAge sets the "max_age" field if the given value is not nil.
+func (aruo *AuthRequestUpdateOne) SetNillableMaxAge(i *int) *AuthRequestUpdateOne {
+	if i != nil {
+		aruo.SetMaxAge(*i)
+	}
+	return aruo
+}
+
+// AddMaxAge adds i to the "max_age" field.
+func (aruo *AuthRequestUpdateOne) AddMaxAge(i int) *AuthRequestUpdateOne {
+	aruo.mutation.AddMaxAge(i)
+	return aruo
+}
+
+// ClearMaxAge clears the value of the "max_age" field.
+func (aruo *AuthRequestUpdateOne) ClearMaxAge() *AuthRequestUpdateOne {
+	aruo.mutation.ClearMaxAge()
+	return aruo
+}
+
 // SetForceApprovalPrompt sets the "force_approval_prompt" field.
 func (aruo *AuthRequestUpdateOne) SetForceApprovalPrompt(b bool) *AuthRequestUpdateOne {
 	aruo.mutation.SetForceApprovalPrompt(b)
@@ -539,6 +868,42 @@ func (aruo *AuthRequestUpdateOne) ClearClaimsGroups() *AuthRequestUpdateOne {
 	return aruo
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (aruo *AuthRequestUpdateOne) SetClaimsOrganizations(s []string) *AuthRequestUpdateOne {
+	aruo.mutation.SetClaimsOrganizations(s)
+	return aruo
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (aruo *AuthRequestUpdateOne) ClearClaimsOrganizations() *AuthRequestUpdateOne {
+	aruo.mutation.ClearClaimsOrganizations()
+	return aruo
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (aruo *AuthRequestUpdateOne) SetClaimsAddress(s *storage.Address) *AuthRequestUpdateOne {
+	aruo.mutation.SetClaimsAddress(s)
+	return aruo
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (aruo *AuthRequestUpdateOne) ClearClaimsAddress() *AuthRequestUpdateOne {
+	aruo.mutation.ClearClaimsAddress()
+	return aruo
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (aruo *AuthRequestUpdateOne) SetClaimsCustom(m map[string]interface{}) *AuthRequestUpdateOne {
+	aruo.mutation.SetClaimsCustom(m)
+	return aruo
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (aruo *AuthRequestUpdateOne) ClearClaimsCustom() *AuthRequestUpdateOne {
+	aruo.mutation.ClearClaimsCustom()
+	return aruo
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (aruo *AuthRequestUpdateOne) SetClaimsPreferredUsername(s string) *AuthRequestUpdateOne {
 	aruo.mutation.SetClaimsPreferredUsername(s)
@@ -553,6 +918,26 @@ func (aruo *AuthRequestUpdateOne) SetNillableClaimsPreferredUsername(s *string)
 	return aruo
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (aruo *AuthRequestUpdateOne) SetClaimsAuthTime(t time.Time) *AuthRequestUpdateOne {
+	aruo.mutation.SetClaimsAuthTime(t)
+	return aruo
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (aruo *AuthRequestUpdateOne) SetNillableClaimsAuthTime(t *time.Time) *AuthRequestUpdateOne {
+	if t != nil {
+		aruo.SetClaimsAuthTime(*t)
+	}
+	return aruo
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (aruo *AuthRequestUpdateOne) ClearClaimsAuthTime() *AuthRequestUpdateOne {
+	aruo.mutation.ClearClaimsAuthTime()
+	return aruo
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (aruo *AuthRequestUpdateOne) SetConnectorID(s string) *AuthRequestUpdateOne {
 	aruo.mutation.SetConnectorID(s)
@@ -726,6 +1111,19 @@ func (aruo *AuthRequestUpdateOne) sqlSave(ctx context.Context) (_node *AuthReque
 			Column: authrequest.FieldScopes,
 		})
 	}
+	if value, ok := aruo.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldResources,
+		})
+	}
+	if aruo.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldResources,
+		})
+	}
 	if value, ok := aruo.mutation.ResponseTypes(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeJSON,
@@ -760,6 +1158,59 @@ func (aruo *AuthRequestUpdateOne) sqlSave(ctx context.Context) (_node *AuthReque
 			Column: authrequest.FieldState,
 		})
 	}
+	if value, ok := aruo.mutation.Prompt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: authrequest.FieldPrompt,
+		})
+	}
+	if value, ok := aruo.mutation.EssentialClaims(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldEssentialClaims,
+		})
+	}
+	if aruo.mutation.EssentialClaimsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldEssentialClaims,
+		})
+	}
+	if value, ok := aruo.mutation.AcrValues(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldAcrValues,
+		})
+	}
+	if aruo.mutation.AcrValuesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldAcrValues,
+		})
+	}
+	if value, ok := aruo.mutation.MaxAge(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
+	if value, ok := aruo.mutation.AddedMaxAge(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
+	if aruo.mutation.MaxAgeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Column: authrequest.FieldMaxAge,
+		})
+	}
 	if value, ok := aruo.mutation.ForceApprovalPrompt(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -815,6 +1266,45 @@ func (aruo *AuthRequestUpdateOne) sqlSave(ctx context.Context) (_node *AuthReque
 			Column: authrequest.FieldClaimsGroups,
 		})
 	}
+	if value, ok := aruo.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsOrganizations,
+		})
+	}
+	if aruo.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := aruo.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsAddress,
+		})
+	}
+	if aruo.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsAddress,
+		})
+	}
+	if value, ok := aruo.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: authrequest.FieldClaimsCustom,
+		})
+	}
+	if aruo.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: authrequest.FieldClaimsCustom,
+		})
+	}
 	if value, ok := aruo.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -822,6 +1312,19 @@ func (aruo *AuthRequestUpdateOne) sqlSave(ctx context.Context) (_node *AuthReque
 			Column: authrequest.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := aruo.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: authrequest.FieldClaimsAuthTime,
+		})
+	}
+	if aruo.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: authrequest.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := aruo.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
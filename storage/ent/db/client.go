@@ -14,11 +14,14 @@ import (
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
@@ -39,6 +42,8 @@ type Client struct {
 	DeviceRequest *DeviceRequestClient
 	// DeviceToken is the client for interacting with the DeviceToken builders.
 	DeviceToken *DeviceTokenClient
+	// IssuedToken is the client for interacting with the IssuedToken builders.
+	IssuedToken *IssuedTokenClient
 	// Keys is the client for interacting with the Keys builders.
 	Keys *KeysClient
 	// OAuth2Client is the client for interacting with the OAuth2Client builders.
@@ -47,8 +52,12 @@ type Client struct {
 	OfflineSession *OfflineSessionClient
 	// Password is the client for interacting with the Password builders.
 	Password *PasswordClient
+	// PushedAuthRequest is the client for interacting with the PushedAuthRequest builders.
+	PushedAuthRequest *PushedAuthRequestClient
 	// RefreshToken is the client for interacting with the RefreshToken builders.
 	RefreshToken *RefreshTokenClient
+	// UpstreamKeySet is the client for interacting with the UpstreamKeySet builders.
+	UpstreamKeySet *UpstreamKeySetClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -67,11 +76,14 @@ func (c *Client) init() {
 	c.Connector = NewConnectorClient(c.config)
 	c.DeviceRequest = NewDeviceRequestClient(c.config)
 	c.DeviceToken = NewDeviceTokenClient(c.config)
+	c.IssuedToken = NewIssuedTokenClient(c.config)
 	c.Keys = NewKeysClient(c.config)
 	c.OAuth2Client = NewOAuth2ClientClient(c.config)
 	c.OfflineSession = NewOfflineSessionClient(c.config)
 	c.Password = NewPasswordClient(c.config)
+	c.PushedAuthRequest = NewPushedAuthRequestClient(c.config)
 	c.RefreshToken = NewRefreshTokenClient(c.config)
+	c.UpstreamKeySet = NewUpstreamKeySetClient(c.config)
 }
 
 // Open opens a database/sql.DB specified by the driver name and
@@ -103,18 +115,21 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:            ctx,
-		config:         cfg,
-		AuthCode:       NewAuthCodeClient(cfg),
-		AuthRequest:    NewAuthRequestClient(cfg),
-		Connector:      NewConnectorClient(cfg),
-		DeviceRequest:  NewDeviceRequestClient(cfg),
-		DeviceToken:    NewDeviceTokenClient(cfg),
-		Keys:           NewKeysClient(cfg),
-		OAuth2Client:   NewOAuth2ClientClient(cfg),
-		OfflineSession: NewOfflineSessionClient(cfg),
-		Password:       NewPasswordClient(cfg),
-		RefreshToken:   NewRefreshTokenClient(cfg),
+		ctx:               ctx,
+		config:            cfg,
+		AuthCode:          NewAuthCodeClient(cfg),
+		AuthRequest:       NewAuthRequestClient(cfg),
+		Connector:         NewConnectorClient(cfg),
+		DeviceRequest:     NewDeviceRequestClient(cfg),
+		DeviceToken:       NewDeviceTokenClient(cfg),
+		IssuedToken:       NewIssuedTokenClient(cfg),
+		Keys:              NewKeysClient(cfg),
+		OAuth2Client:      NewOAuth2ClientClient(cfg),
+		OfflineSession:    NewOfflineSessionClient(cfg),
+		Password:          NewPasswordClient(cfg),
+		PushedAuthRequest: NewPushedAuthRequestClient(cfg),
+		RefreshToken:      NewRefreshTokenClient(cfg),
+		UpstreamKeySet:    NewUpstreamKeySetClient(cfg),
 	}, nil
 }
 
@@ -132,18 +147,21 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:            ctx,
-		config:         cfg,
-		AuthCode:       NewAuthCodeClient(cfg),
-		AuthRequest:    NewAuthRequestClient(cfg),
-		Connector:      NewConnectorClient(cfg),
-		DeviceRequest:  NewDeviceRequestClient(cfg),
-		DeviceToken:    NewDeviceTokenClient(cfg),
-		Keys:           NewKeysClient(cfg),
-		OAuth2Client:   NewOAuth2ClientClient(cfg),
-		OfflineSession: NewOfflineSessionClient(cfg),
-		Password:       NewPasswordClient(cfg),
-		RefreshToken:   NewRefreshTokenClient(cfg),
+		ctx:               ctx,
+		config:            cfg,
+		AuthCode:          NewAuthCodeClient(cfg),
+		AuthRequest:       NewAuthRequestClient(cfg),
+		Connector:         NewConnectorClient(cfg),
+		DeviceRequest:     NewDeviceRequestClient(cfg),
+		DeviceToken:       NewDeviceTokenClient(cfg),
+		IssuedToken:       NewIssuedTokenClient(cfg),
+		Keys:              NewKeysClient(cfg),
+		OAuth2Client:      NewOAuth2ClientClient(cfg),
+		OfflineSession:    NewOfflineSessionClient(cfg),
+		Password:          NewPasswordClient(cfg),
+		PushedAuthRequest: NewPushedAuthRequestClient(cfg),
+		RefreshToken:      NewRefreshTokenClient(cfg),
+		UpstreamKeySet:    NewUpstreamKeySetClient(cfg),
 	}, nil
 }
 
@@ -178,11 +196,14 @@ func (c *Client) Use(hooks ...Hook) {
 	c.Connector.Use(hooks...)
 	c.DeviceRequest.Use(hooks...)
 	c.DeviceToken.Use(hooks...)
+	c.IssuedToken.Use(hooks...)
 	c.Keys.Use(hooks...)
 	c.OAuth2Client.Use(hooks...)
 	c.OfflineSession.Use(hooks...)
 	c.Password.Use(hooks...)
+	c.PushedAuthRequest.Use(hooks...)
 	c.RefreshToken.Use(hooks...)
+	c.UpstreamKeySet.Use(hooks...)
 }
 
 // AuthCodeClient is a client for the AuthCode schema.
@@ -635,6 +656,96 @@ func (c *DeviceTokenClient) Hooks() []Hook {
 	return c.hooks.DeviceToken
 }
 
+// IssuedTokenClient is a client for the IssuedToken schema.
+type IssuedTokenClient struct {
+	config
+}
+
+// NewIssuedTokenClient returns a client for the IssuedToken from the given config.
+func NewIssuedTokenClient(c config) *IssuedTokenClient {
+	return &IssuedTokenClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `issuedtoken.Hooks(f(g(h())))`.
+func (c *IssuedTokenClient) Use(hooks ...Hook) {
+	c.hooks.IssuedToken = append(c.hooks.IssuedToken, hooks...)
+}
+
+// Create returns a create builder for IssuedToken.
+func (c *IssuedTokenClient) Create() *IssuedTokenCreate {
+	mutation := newIssuedTokenMutation(c.config, OpCreate)
+	return &IssuedTokenCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of IssuedToken entities.
+func (c *IssuedTokenClient) CreateBulk(builders ...*IssuedTokenCreate) *IssuedTokenCreateBulk {
+	return &IssuedTokenCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for IssuedToken.
+func (c *IssuedTokenClient) Update() *IssuedTokenUpdate {
+	mutation := newIssuedTokenMutation(c.config, OpUpdate)
+	return &IssuedTokenUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *IssuedTokenClient) UpdateOne(it *IssuedToken) *IssuedTokenUpdateOne {
+	mutation := newIssuedTokenMutation(c.config, OpUpdateOne, withIssuedToken(it))
+	return &IssuedTokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *IssuedTokenClient) UpdateOneID(id string) *IssuedTokenUpdateOne {
+	mutation := newIssuedTokenMutation(c.config, OpUpdateOne, withIssuedTokenID(id))
+	return &IssuedTokenUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for IssuedToken.
+func (c *IssuedTokenClient) Delete() *IssuedTokenDelete {
+	mutation := newIssuedTokenMutation(c.config, OpDelete)
+	return &IssuedTokenDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *IssuedTokenClient) DeleteOne(it *IssuedToken) *IssuedTokenDeleteOne {
+	return c.DeleteOneID(it.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *IssuedTokenClient) DeleteOneID(id string) *IssuedTokenDeleteOne {
+	builder := c.Delete().Where(issuedtoken.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &IssuedTokenDeleteOne{builder}
+}
+
+// Query returns a query builder for IssuedToken.
+func (c *IssuedTokenClient) Query() *IssuedTokenQuery {
+	return &IssuedTokenQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a IssuedToken entity by its id.
+func (c *IssuedTokenClient) Get(ctx context.Context, id string) (*IssuedToken, error) {
+	return c.Query().Where(issuedtoken.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *IssuedTokenClient) GetX(ctx context.Context, id string) *IssuedToken {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *IssuedTokenClient) Hooks() []Hook {
+	return c.hooks.IssuedToken
+}
+
 // KeysClient is a client for the Keys schema.
 type KeysClient struct {
 	config
@@ -995,6 +1106,96 @@ func (c *PasswordClient) Hooks() []Hook {
 	return c.hooks.Password
 }
 
+// PushedAuthRequestClient is a client for the PushedAuthRequest schema.
+type PushedAuthRequestClient struct {
+	config
+}
+
+// NewPushedAuthRequestClient returns a client for the PushedAuthRequest from the given config.
+func NewPushedAuthRequestClient(c config) *PushedAuthRequestClient {
+	return &PushedAuthRequestClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `pushedauthrequest.Hooks(f(g(h())))`.
+func (c *PushedAuthRequestClient) Use(hooks ...Hook) {
+	c.hooks.PushedAuthRequest = append(c.hooks.PushedAuthRequest, hooks...)
+}
+
+// Create returns a create builder for PushedAuthRequest.
+func (c *PushedAuthRequestClient) Create() *PushedAuthRequestCreate {
+	mutation := newPushedAuthRequestMutation(c.config, OpCreate)
+	return &PushedAuthRequestCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of PushedAuthRequest entities.
+func (c *PushedAuthRequestClient) CreateBulk(builders ...*PushedAuthRequestCreate) *PushedAuthRequestCreateBulk {
+	return &PushedAuthRequestCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for PushedAuthRequest.
+func (c *PushedAuthRequestClient) Update() *PushedAuthRequestUpdate {
+	mutation := newPushedAuthRequestMutation(c.config, OpUpdate)
+	return &PushedAuthRequestUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *PushedAuthRequestClient) UpdateOne(par *PushedAuthRequest) *PushedAuthRequestUpdateOne {
+	mutation := newPushedAuthRequestMutation(c.config, OpUpdateOne, withPushedAuthRequest(par))
+	return &PushedAuthRequestUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *PushedAuthRequestClient) UpdateOneID(id string) *PushedAuthRequestUpdateOne {
+	mutation := newPushedAuthRequestMutation(c.config, OpUpdateOne, withPushedAuthRequestID(id))
+	return &PushedAuthRequestUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for PushedAuthRequest.
+func (c *PushedAuthRequestClient) Delete() *PushedAuthRequestDelete {
+	mutation := newPushedAuthRequestMutation(c.config, OpDelete)
+	return &PushedAuthRequestDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *PushedAuthRequestClient) DeleteOne(par *PushedAuthRequest) *PushedAuthRequestDeleteOne {
+	return c.DeleteOneID(par.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *PushedAuthRequestClient) DeleteOneID(id string) *PushedAuthRequestDeleteOne {
+	builder := c.Delete().Where(pushedauthrequest.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &PushedAuthRequestDeleteOne{builder}
+}
+
+// Query returns a query builder for PushedAuthRequest.
+func (c *PushedAuthRequestClient) Query() *PushedAuthRequestQuery {
+	return &PushedAuthRequestQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a PushedAuthRequest entity by its id.
+func (c *PushedAuthRequestClient) Get(ctx context.Context, id string) (*PushedAuthRequest, error) {
+	return c.Query().Where(pushedauthrequest.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *PushedAuthRequestClient) GetX(ctx context.Context, id string) *PushedAuthRequest {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *PushedAuthRequestClient) Hooks() []Hook {
+	return c.hooks.PushedAuthRequest
+}
+
 // RefreshTokenClient is a client for the RefreshToken schema.
 type RefreshTokenClient struct {
 	config
@@ -1084,3 +1285,93 @@ func (c *RefreshTokenClient) GetX(ctx context.Context, id string) *RefreshToken
 func (c *RefreshTokenClient) Hooks() []Hook {
 	return c.hooks.RefreshToken
 }
+
+// UpstreamKeySetClient is a client for the UpstreamKeySet schema.
+type UpstreamKeySetClient struct {
+	config
+}
+
+// NewUpstreamKeySetClient returns a client for the UpstreamKeySet from the given config.
+func NewUpstreamKeySetClient(c config) *UpstreamKeySetClient {
+	return &UpstreamKeySetClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `upstreamkeyset.Hooks(f(g(h())))`.
+func (c *UpstreamKeySetClient) Use(hooks ...Hook) {
+	c.hooks.UpstreamKeySet = append(c.hooks.UpstreamKeySet, hooks...)
+}
+
+// Create returns a create builder for UpstreamKeySet.
+func (c *UpstreamKeySetClient) Create() *UpstreamKeySetCreate {
+	mutation := newUpstreamKeySetMutation(c.config, OpCreate)
+	return &UpstreamKeySetCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of UpstreamKeySet entities.
+func (c *UpstreamKeySetClient) CreateBulk(builders ...*UpstreamKeySetCreate) *UpstreamKeySetCreateBulk {
+	return &UpstreamKeySetCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for UpstreamKeySet.
+func (c *UpstreamKeySetClient) Update() *UpstreamKeySetUpdate {
+	mutation := newUpstreamKeySetMutation(c.config, OpUpdate)
+	return &UpstreamKeySetUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *UpstreamKeySetClient) UpdateOne(uks *UpstreamKeySet) *UpstreamKeySetUpdateOne {
+	mutation := newUpstreamKeySetMutation(c.config, OpUpdateOne, withUpstreamKeySet(uks))
+	return &UpstreamKeySetUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *UpstreamKeySetClient) UpdateOneID(id string) *UpstreamKeySetUpdateOne {
+	mutation := newUpstreamKeySetMutation(c.config, OpUpdateOne, withUpstreamKeySetID(id))
+	return &UpstreamKeySetUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for UpstreamKeySet.
+func (c *UpstreamKeySetClient) Delete() *UpstreamKeySetDelete {
+	mutation := newUpstreamKeySetMutation(c.config, OpDelete)
+	return &UpstreamKeySetDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a delete builder for the given entity.
+func (c *UpstreamKeySetClient) DeleteOne(uks *UpstreamKeySet) *UpstreamKeySetDeleteOne {
+	return c.DeleteOneID(uks.ID)
+}
+
+// DeleteOneID returns a delete builder for the given id.
+func (c *UpstreamKeySetClient) DeleteOneID(id string) *UpstreamKeySetDeleteOne {
+	builder := c.Delete().Where(upstreamkeyset.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &UpstreamKeySetDeleteOne{builder}
+}
+
+// Query returns a query builder for UpstreamKeySet.
+func (c *UpstreamKeySetClient) Query() *UpstreamKeySetQuery {
+	return &UpstreamKeySetQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a UpstreamKeySet entity by its id.
+func (c *UpstreamKeySetClient) Get(ctx context.Context, id string) (*UpstreamKeySet, error) {
+	return c.Query().Where(upstreamkeyset.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *UpstreamKeySetClient) GetX(ctx context.Context, id string) *UpstreamKeySet {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *UpstreamKeySetClient) Hooks() []Hook {
+	return c.hooks.UpstreamKeySet
+}
@@ -24,16 +24,19 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	AuthCode       []ent.Hook
-	AuthRequest    []ent.Hook
-	Connector      []ent.Hook
-	DeviceRequest  []ent.Hook
-	DeviceToken    []ent.Hook
-	Keys           []ent.Hook
-	OAuth2Client   []ent.Hook
-	OfflineSession []ent.Hook
-	Password       []ent.Hook
-	RefreshToken   []ent.Hook
+	AuthCode          []ent.Hook
+	AuthRequest       []ent.Hook
+	Connector         []ent.Hook
+	DeviceRequest     []ent.Hook
+	DeviceToken       []ent.Hook
+	IssuedToken       []ent.Hook
+	Keys              []ent.Hook
+	OAuth2Client      []ent.Hook
+	OfflineSession    []ent.Hook
+	Password          []ent.Hook
+	PushedAuthRequest []ent.Hook
+	RefreshToken      []ent.Hook
+	UpstreamKeySet    []ent.Hook
 }
 
 // Options applies the options on the config object.
@@ -13,11 +13,14 @@ import (
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -38,16 +41,19 @@ type OrderFunc func(*sql.Selector)
 // columnChecker returns a function indicates if the column exists in the given column.
 func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
-		authcode.Table:       authcode.ValidColumn,
-		authrequest.Table:    authrequest.ValidColumn,
-		connector.Table:      connector.ValidColumn,
-		devicerequest.Table:  devicerequest.ValidColumn,
-		devicetoken.Table:    devicetoken.ValidColumn,
-		keys.Table:           keys.ValidColumn,
-		oauth2client.Table:   oauth2client.ValidColumn,
-		offlinesession.Table: offlinesession.ValidColumn,
-		password.Table:       password.ValidColumn,
-		refreshtoken.Table:   refreshtoken.ValidColumn,
+		authcode.Table:          authcode.ValidColumn,
+		authrequest.Table:       authrequest.ValidColumn,
+		connector.Table:         connector.ValidColumn,
+		devicerequest.Table:     devicerequest.ValidColumn,
+		devicetoken.Table:       devicetoken.ValidColumn,
+		issuedtoken.Table:       issuedtoken.ValidColumn,
+		keys.Table:              keys.ValidColumn,
+		oauth2client.Table:      oauth2client.ValidColumn,
+		offlinesession.Table:    offlinesession.ValidColumn,
+		password.Table:          password.ValidColumn,
+		pushedauthrequest.Table: pushedauthrequest.ValidColumn,
+		refreshtoken.Table:      refreshtoken.ValidColumn,
+		upstreamkeyset.Table:    upstreamkeyset.ValidColumn,
 	}
 	check, ok := checks[table]
 	if !ok {
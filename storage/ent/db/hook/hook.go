@@ -74,6 +74,19 @@ func (f DeviceTokenFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, e
 	return f(ctx, mv)
 }
 
+// The IssuedTokenFunc type is an adapter to allow the use of ordinary
+// function as IssuedToken mutator.
+type IssuedTokenFunc func(context.Context, *db.IssuedTokenMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f IssuedTokenFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.IssuedTokenMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.IssuedTokenMutation", m)
+	}
+	return f(ctx, mv)
+}
+
 // The KeysFunc type is an adapter to allow the use of ordinary
 // function as Keys mutator.
 type KeysFunc func(context.Context, *db.KeysMutation) (db.Value, error)
@@ -126,6 +139,19 @@ func (f PasswordFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, erro
 	return f(ctx, mv)
 }
 
+// The PushedAuthRequestFunc type is an adapter to allow the use of ordinary
+// function as PushedAuthRequest mutator.
+type PushedAuthRequestFunc func(context.Context, *db.PushedAuthRequestMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f PushedAuthRequestFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.PushedAuthRequestMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.PushedAuthRequestMutation", m)
+	}
+	return f(ctx, mv)
+}
+
 // The RefreshTokenFunc type is an adapter to allow the use of ordinary
 // function as RefreshToken mutator.
 type RefreshTokenFunc func(context.Context, *db.RefreshTokenMutation) (db.Value, error)
@@ -139,6 +165,19 @@ func (f RefreshTokenFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value,
 	return f(ctx, mv)
 }
 
+// The UpstreamKeySetFunc type is an adapter to allow the use of ordinary
+// function as UpstreamKeySet mutator.
+type UpstreamKeySetFunc func(context.Context, *db.UpstreamKeySetMutation) (db.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f UpstreamKeySetFunc) Mutate(ctx context.Context, m db.Mutation) (db.Value, error) {
+	mv, ok := m.(*db.UpstreamKeySetMutation)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T. expect *db.UpstreamKeySetMutation", m)
+	}
+	return f(ctx, mv)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, db.Mutation) bool
 
@@ -0,0 +1,310 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
+)
+
+// IssuedTokenCreate is the builder for creating a IssuedToken entity.
+type IssuedTokenCreate struct {
+	config
+	mutation *IssuedTokenMutation
+	hooks    []Hook
+}
+
+// SetClientID sets the "client_id" field.
+func (itc *IssuedTokenCreate) SetClientID(s string) *IssuedTokenCreate {
+	itc.mutation.SetClientID(s)
+	return itc
+}
+
+// SetUserID sets the "user_id" field.
+func (itc *IssuedTokenCreate) SetUserID(s string) *IssuedTokenCreate {
+	itc.mutation.SetUserID(s)
+	return itc
+}
+
+// SetConnectorID sets the "connector_id" field.
+func (itc *IssuedTokenCreate) SetConnectorID(s string) *IssuedTokenCreate {
+	itc.mutation.SetConnectorID(s)
+	return itc
+}
+
+// SetIssuedAt sets the "issued_at" field.
+func (itc *IssuedTokenCreate) SetIssuedAt(t time.Time) *IssuedTokenCreate {
+	itc.mutation.SetIssuedAt(t)
+	return itc
+}
+
+// SetExpiry sets the "expiry" field.
+func (itc *IssuedTokenCreate) SetExpiry(t time.Time) *IssuedTokenCreate {
+	itc.mutation.SetExpiry(t)
+	return itc
+}
+
+// SetID sets the "id" field.
+func (itc *IssuedTokenCreate) SetID(s string) *IssuedTokenCreate {
+	itc.mutation.SetID(s)
+	return itc
+}
+
+// Mutation returns the IssuedTokenMutation object of the builder.
+func (itc *IssuedTokenCreate) Mutation() *IssuedTokenMutation {
+	return itc.mutation
+}
+
+// Save creates the IssuedToken in the database.
+func (itc *IssuedTokenCreate) Save(ctx context.Context) (*IssuedToken, error) {
+	var (
+		err  error
+		node *IssuedToken
+	)
+	if len(itc.hooks) == 0 {
+		if err = itc.check(); err != nil {
+			return nil, err
+		}
+		node, err = itc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IssuedTokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = itc.check(); err != nil {
+				return nil, err
+			}
+			itc.mutation = mutation
+			if node, err = itc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(itc.hooks) - 1; i >= 0; i-- {
+			if itc.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = itc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, itc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (itc *IssuedTokenCreate) SaveX(ctx context.Context) *IssuedToken {
+	v, err := itc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (itc *IssuedTokenCreate) Exec(ctx context.Context) error {
+	_, err := itc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (itc *IssuedTokenCreate) ExecX(ctx context.Context) {
+	if err := itc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (itc *IssuedTokenCreate) check() error {
+	if _, ok := itc.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`db: missing required field "IssuedToken.client_id"`)}
+	}
+	if v, ok := itc.mutation.ClientID(); ok {
+		if err := issuedtoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "IssuedToken.client_id": %w`, err)}
+		}
+	}
+	if _, ok := itc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`db: missing required field "IssuedToken.user_id"`)}
+	}
+	if _, ok := itc.mutation.ConnectorID(); !ok {
+		return &ValidationError{Name: "connector_id", err: errors.New(`db: missing required field "IssuedToken.connector_id"`)}
+	}
+	if _, ok := itc.mutation.IssuedAt(); !ok {
+		return &ValidationError{Name: "issued_at", err: errors.New(`db: missing required field "IssuedToken.issued_at"`)}
+	}
+	if _, ok := itc.mutation.Expiry(); !ok {
+		return &ValidationError{Name: "expiry", err: errors.New(`db: missing required field "IssuedToken.expiry"`)}
+	}
+	if v, ok := itc.mutation.ID(); ok {
+		if err := issuedtoken.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`db: validator failed for field "IssuedToken.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (itc *IssuedTokenCreate) sqlSave(ctx context.Context) (*IssuedToken, error) {
+	_node, _spec := itc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, itc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected IssuedToken.ID type: %T", _spec.ID.Value)
+		}
+	}
+	return _node, nil
+}
+
+func (itc *IssuedTokenCreate) createSpec() (*IssuedToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &IssuedToken{config: itc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: issuedtoken.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: issuedtoken.FieldID,
+			},
+		}
+	)
+	if id, ok := itc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := itc.mutation.ClientID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldClientID,
+		})
+		_node.ClientID = value
+	}
+	if value, ok := itc.mutation.UserID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldUserID,
+		})
+		_node.UserID = value
+	}
+	if value, ok := itc.mutation.ConnectorID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldConnectorID,
+		})
+		_node.ConnectorID = value
+	}
+	if value, ok := itc.mutation.IssuedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldIssuedAt,
+		})
+		_node.IssuedAt = value
+	}
+	if value, ok := itc.mutation.Expiry(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldExpiry,
+		})
+		_node.Expiry = value
+	}
+	return _node, _spec
+}
+
+// IssuedTokenCreateBulk is the builder for creating many IssuedToken entities in bulk.
+type IssuedTokenCreateBulk struct {
+	config
+	builders []*IssuedTokenCreate
+}
+
+// Save creates the IssuedToken entities in the database.
+func (itcb *IssuedTokenCreateBulk) Save(ctx context.Context) ([]*IssuedToken, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(itcb.builders))
+	nodes := make([]*IssuedToken, len(itcb.builders))
+	mutators := make([]Mutator, len(itcb.builders))
+	for i := range itcb.builders {
+		func(i int, root context.Context) {
+			builder := itcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*IssuedTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, itcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, itcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{err.Error(), err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, itcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (itcb *IssuedTokenCreateBulk) SaveX(ctx context.Context) []*IssuedToken {
+	v, err := itcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (itcb *IssuedTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := itcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (itcb *IssuedTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := itcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,111 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// IssuedTokenDelete is the builder for deleting a IssuedToken entity.
+type IssuedTokenDelete struct {
+	config
+	hooks    []Hook
+	mutation *IssuedTokenMutation
+}
+
+// Where appends a list predicates to the IssuedTokenDelete builder.
+func (itd *IssuedTokenDelete) Where(ps ...predicate.IssuedToken) *IssuedTokenDelete {
+	itd.mutation.Where(ps...)
+	return itd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (itd *IssuedTokenDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(itd.hooks) == 0 {
+		affected, err = itd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IssuedTokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			itd.mutation = mutation
+			affected, err = itd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(itd.hooks) - 1; i >= 0; i-- {
+			if itd.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = itd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, itd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (itd *IssuedTokenDelete) ExecX(ctx context.Context) int {
+	n, err := itd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (itd *IssuedTokenDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: issuedtoken.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: issuedtoken.FieldID,
+			},
+		},
+	}
+	if ps := itd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, itd.driver, _spec)
+}
+
+// IssuedTokenDeleteOne is the builder for deleting a single IssuedToken entity.
+type IssuedTokenDeleteOne struct {
+	itd *IssuedTokenDelete
+}
+
+// Exec executes the deletion query.
+func (itdo *IssuedTokenDeleteOne) Exec(ctx context.Context) error {
+	n, err := itdo.itd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{issuedtoken.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (itdo *IssuedTokenDeleteOne) ExecX(ctx context.Context) {
+	itdo.itd.ExecX(ctx)
+}
@@ -0,0 +1,919 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// IssuedTokenQuery is the builder for querying IssuedToken entities.
+type IssuedTokenQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.IssuedToken
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the IssuedTokenQuery builder.
+func (itq *IssuedTokenQuery) Where(ps ...predicate.IssuedToken) *IssuedTokenQuery {
+	itq.predicates = append(itq.predicates, ps...)
+	return itq
+}
+
+// Limit adds a limit step to the query.
+func (itq *IssuedTokenQuery) Limit(limit int) *IssuedTokenQuery {
+	itq.limit = &limit
+	return itq
+}
+
+// Offset adds an offset step to the query.
+func (itq *IssuedTokenQuery) Offset(offset int) *IssuedTokenQuery {
+	itq.offset = &offset
+	return itq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (itq *IssuedTokenQuery) Unique(unique bool) *IssuedTokenQuery {
+	itq.unique = &unique
+	return itq
+}
+
+// Order adds an order step to the query.
+func (itq *IssuedTokenQuery) Order(o ...OrderFunc) *IssuedTokenQuery {
+	itq.order = append(itq.order, o...)
+	return itq
+}
+
+// First returns the first IssuedToken entity from the query.
+// Returns a *NotFoundError when no IssuedToken was found.
+func (itq *IssuedTokenQuery) First(ctx context.Context) (*IssuedToken, error) {
+	nodes, err := itq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{issuedtoken.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (itq *IssuedTokenQuery) FirstX(ctx context.Context) *IssuedToken {
+	node, err := itq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first IssuedToken ID from the query.
+// Returns a *NotFoundError when no IssuedToken ID was found.
+func (itq *IssuedTokenQuery) FirstID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = itq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{issuedtoken.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (itq *IssuedTokenQuery) FirstIDX(ctx context.Context) string {
+	id, err := itq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single IssuedToken entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one IssuedToken entity is found.
+// Returns a *NotFoundError when no IssuedToken entities are found.
+func (itq *IssuedTokenQuery) Only(ctx context.Context) (*IssuedToken, error) {
+	nodes, err := itq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{issuedtoken.Label}
+	default:
+		return nil, &NotSingularError{issuedtoken.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (itq *IssuedTokenQuery) OnlyX(ctx context.Context) *IssuedToken {
+	node, err := itq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only IssuedToken ID in the query.
+// Returns a *NotSingularError when more than one IssuedToken ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (itq *IssuedTokenQuery) OnlyID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = itq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = &NotSingularError{issuedtoken.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (itq *IssuedTokenQuery) OnlyIDX(ctx context.Context) string {
+	id, err := itq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of IssuedTokens.
+func (itq *IssuedTokenQuery) All(ctx context.Context) ([]*IssuedToken, error) {
+	if err := itq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return itq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (itq *IssuedTokenQuery) AllX(ctx context.Context) []*IssuedToken {
+	nodes, err := itq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of IssuedToken IDs.
+func (itq *IssuedTokenQuery) IDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := itq.Select(issuedtoken.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (itq *IssuedTokenQuery) IDsX(ctx context.Context) []string {
+	ids, err := itq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (itq *IssuedTokenQuery) Count(ctx context.Context) (int, error) {
+	if err := itq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return itq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (itq *IssuedTokenQuery) CountX(ctx context.Context) int {
+	count, err := itq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (itq *IssuedTokenQuery) Exist(ctx context.Context) (bool, error) {
+	if err := itq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return itq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (itq *IssuedTokenQuery) ExistX(ctx context.Context) bool {
+	exist, err := itq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the IssuedTokenQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (itq *IssuedTokenQuery) Clone() *IssuedTokenQuery {
+	if itq == nil {
+		return nil
+	}
+	return &IssuedTokenQuery{
+		config:     itq.config,
+		limit:      itq.limit,
+		offset:     itq.offset,
+		order:      append([]OrderFunc{}, itq.order...),
+		predicates: append([]predicate.IssuedToken{}, itq.predicates...),
+		// clone intermediate query.
+		sql:    itq.sql.Clone(),
+		path:   itq.path,
+		unique: itq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		ClientID string `json:"client_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.IssuedToken.Query().
+//		GroupBy(issuedtoken.FieldClientID).
+//		Aggregate(db.Count()).
+//		Scan(ctx, &v)
+func (itq *IssuedTokenQuery) GroupBy(field string, fields ...string) *IssuedTokenGroupBy {
+	group := &IssuedTokenGroupBy{config: itq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := itq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return itq.sqlQuery(ctx), nil
+	}
+	return group
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		ClientID string `json:"client_id,omitempty"`
+//	}
+//
+//	client.IssuedToken.Query().
+//		Select(issuedtoken.FieldClientID).
+//		Scan(ctx, &v)
+func (itq *IssuedTokenQuery) Select(fields ...string) *IssuedTokenSelect {
+	itq.fields = append(itq.fields, fields...)
+	return &IssuedTokenSelect{IssuedTokenQuery: itq}
+}
+
+func (itq *IssuedTokenQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range itq.fields {
+		if !issuedtoken.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+		}
+	}
+	if itq.path != nil {
+		prev, err := itq.path(ctx)
+		if err != nil {
+			return err
+		}
+		itq.sql = prev
+	}
+	return nil
+}
+
+func (itq *IssuedTokenQuery) sqlAll(ctx context.Context) ([]*IssuedToken, error) {
+	var (
+		nodes = []*IssuedToken{}
+		_spec = itq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		node := &IssuedToken{config: itq.config}
+		nodes = append(nodes, node)
+		return node.scanValues(columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("db: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(columns, values)
+	}
+	if err := sqlgraph.QueryNodes(ctx, itq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (itq *IssuedTokenQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := itq.querySpec()
+	_spec.Node.Columns = itq.fields
+	if len(itq.fields) > 0 {
+		_spec.Unique = itq.unique != nil && *itq.unique
+	}
+	return sqlgraph.CountNodes(ctx, itq.driver, _spec)
+}
+
+func (itq *IssuedTokenQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := itq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("db: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (itq *IssuedTokenQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   issuedtoken.Table,
+			Columns: issuedtoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: issuedtoken.FieldID,
+			},
+		},
+		From:   itq.sql,
+		Unique: true,
+	}
+	if unique := itq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := itq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, issuedtoken.FieldID)
+		for i := range fields {
+			if fields[i] != issuedtoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := itq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := itq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := itq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := itq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (itq *IssuedTokenQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(itq.driver.Dialect())
+	t1 := builder.Table(issuedtoken.Table)
+	columns := itq.fields
+	if len(columns) == 0 {
+		columns = issuedtoken.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if itq.sql != nil {
+		selector = itq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if itq.unique != nil && *itq.unique {
+		selector.Distinct()
+	}
+	for _, p := range itq.predicates {
+		p(selector)
+	}
+	for _, p := range itq.order {
+		p(selector)
+	}
+	if offset := itq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := itq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// IssuedTokenGroupBy is the group-by builder for IssuedToken entities.
+type IssuedTokenGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (itgb *IssuedTokenGroupBy) Aggregate(fns ...AggregateFunc) *IssuedTokenGroupBy {
+	itgb.fns = append(itgb.fns, fns...)
+	return itgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (itgb *IssuedTokenGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := itgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	itgb.sql = query
+	return itgb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := itgb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(itgb.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := itgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) StringsX(ctx context.Context) []string {
+	v, err := itgb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = itgb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) StringX(ctx context.Context) string {
+	v, err := itgb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(itgb.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := itgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) IntsX(ctx context.Context) []int {
+	v, err := itgb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = itgb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) IntX(ctx context.Context) int {
+	v, err := itgb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(itgb.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := itgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := itgb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = itgb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := itgb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(itgb.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := itgb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := itgb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (itgb *IssuedTokenGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = itgb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (itgb *IssuedTokenGroupBy) BoolX(ctx context.Context) bool {
+	v, err := itgb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (itgb *IssuedTokenGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range itgb.fields {
+		if !issuedtoken.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := itgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := itgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (itgb *IssuedTokenGroupBy) sqlQuery() *sql.Selector {
+	selector := itgb.sql.Select()
+	aggregation := make([]string, 0, len(itgb.fns))
+	for _, fn := range itgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(itgb.fields)+len(itgb.fns))
+		for _, f := range itgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(itgb.fields...)...)
+}
+
+// IssuedTokenSelect is the builder for selecting fields of IssuedToken entities.
+type IssuedTokenSelect struct {
+	*IssuedTokenQuery
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (its *IssuedTokenSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := its.prepareQuery(ctx); err != nil {
+		return err
+	}
+	its.sql = its.IssuedTokenQuery.sqlQuery(ctx)
+	return its.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (its *IssuedTokenSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := its.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(its.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := its.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (its *IssuedTokenSelect) StringsX(ctx context.Context) []string {
+	v, err := its.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = its.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (its *IssuedTokenSelect) StringX(ctx context.Context) string {
+	v, err := its.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(its.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := its.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (its *IssuedTokenSelect) IntsX(ctx context.Context) []int {
+	v, err := its.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = its.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (its *IssuedTokenSelect) IntX(ctx context.Context) int {
+	v, err := its.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(its.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := its.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (its *IssuedTokenSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := its.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = its.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (its *IssuedTokenSelect) Float64X(ctx context.Context) float64 {
+	v, err := its.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(its.fields) > 1 {
+		return nil, errors.New("db: IssuedTokenSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := its.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (its *IssuedTokenSelect) BoolsX(ctx context.Context) []bool {
+	v, err := its.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a selector. It is only allowed when selecting one field.
+func (its *IssuedTokenSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = its.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{issuedtoken.Label}
+	default:
+		err = fmt.Errorf("db: IssuedTokenSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (its *IssuedTokenSelect) BoolX(ctx context.Context) bool {
+	v, err := its.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (its *IssuedTokenSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := its.sql.Query()
+	if err := its.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
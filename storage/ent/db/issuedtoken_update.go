@@ -0,0 +1,402 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// IssuedTokenUpdate is the builder for updating IssuedToken entities.
+type IssuedTokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *IssuedTokenMutation
+}
+
+// Where appends a list predicates to the IssuedTokenUpdate builder.
+func (itu *IssuedTokenUpdate) Where(ps ...predicate.IssuedToken) *IssuedTokenUpdate {
+	itu.mutation.Where(ps...)
+	return itu
+}
+
+// SetClientID sets the "client_id" field.
+func (itu *IssuedTokenUpdate) SetClientID(s string) *IssuedTokenUpdate {
+	itu.mutation.SetClientID(s)
+	return itu
+}
+
+// SetUserID sets the "user_id" field.
+func (itu *IssuedTokenUpdate) SetUserID(s string) *IssuedTokenUpdate {
+	itu.mutation.SetUserID(s)
+	return itu
+}
+
+// SetConnectorID sets the "connector_id" field.
+func (itu *IssuedTokenUpdate) SetConnectorID(s string) *IssuedTokenUpdate {
+	itu.mutation.SetConnectorID(s)
+	return itu
+}
+
+// SetIssuedAt sets the "issued_at" field.
+func (itu *IssuedTokenUpdate) SetIssuedAt(t time.Time) *IssuedTokenUpdate {
+	itu.mutation.SetIssuedAt(t)
+	return itu
+}
+
+// SetExpiry sets the "expiry" field.
+func (itu *IssuedTokenUpdate) SetExpiry(t time.Time) *IssuedTokenUpdate {
+	itu.mutation.SetExpiry(t)
+	return itu
+}
+
+// Mutation returns the IssuedTokenMutation object of the builder.
+func (itu *IssuedTokenUpdate) Mutation() *IssuedTokenMutation {
+	return itu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (itu *IssuedTokenUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(itu.hooks) == 0 {
+		if err = itu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = itu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IssuedTokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = itu.check(); err != nil {
+				return 0, err
+			}
+			itu.mutation = mutation
+			affected, err = itu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(itu.hooks) - 1; i >= 0; i-- {
+			if itu.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = itu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, itu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (itu *IssuedTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := itu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (itu *IssuedTokenUpdate) Exec(ctx context.Context) error {
+	_, err := itu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (itu *IssuedTokenUpdate) ExecX(ctx context.Context) {
+	if err := itu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (itu *IssuedTokenUpdate) check() error {
+	if v, ok := itu.mutation.ClientID(); ok {
+		if err := issuedtoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "IssuedToken.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (itu *IssuedTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   issuedtoken.Table,
+			Columns: issuedtoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: issuedtoken.FieldID,
+			},
+		},
+	}
+	if ps := itu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := itu.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldClientID,
+		})
+	}
+	if value, ok := itu.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldUserID,
+		})
+	}
+	if value, ok := itu.mutation.ConnectorID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldConnectorID,
+		})
+	}
+	if value, ok := itu.mutation.IssuedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldIssuedAt,
+		})
+	}
+	if value, ok := itu.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldExpiry,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, itu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{issuedtoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// IssuedTokenUpdateOne is the builder for updating a single IssuedToken entity.
+type IssuedTokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *IssuedTokenMutation
+}
+
+// SetClientID sets the "client_id" field.
+func (ituo *IssuedTokenUpdateOne) SetClientID(s string) *IssuedTokenUpdateOne {
+	ituo.mutation.SetClientID(s)
+	return ituo
+}
+
+// SetUserID sets the "user_id" field.
+func (ituo *IssuedTokenUpdateOne) SetUserID(s string) *IssuedTokenUpdateOne {
+	ituo.mutation.SetUserID(s)
+	return ituo
+}
+
+// SetConnectorID sets the "connector_id" field.
+func (ituo *IssuedTokenUpdateOne) SetConnectorID(s string) *IssuedTokenUpdateOne {
+	ituo.mutation.SetConnectorID(s)
+	return ituo
+}
+
+// SetIssuedAt sets the "issued_at" field.
+func (ituo *IssuedTokenUpdateOne) SetIssuedAt(t time.Time) *IssuedTokenUpdateOne {
+	ituo.mutation.SetIssuedAt(t)
+	return ituo
+}
+
+// SetExpiry sets the "expiry" field.
+func (ituo *IssuedTokenUpdateOne) SetExpiry(t time.Time) *IssuedTokenUpdateOne {
+	ituo.mutation.SetExpiry(t)
+	return ituo
+}
+
+// Mutation returns the IssuedTokenMutation object of the builder.
+func (ituo *IssuedTokenUpdateOne) Mutation() *IssuedTokenMutation {
+	return ituo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ituo *IssuedTokenUpdateOne) Select(field string, fields ...string) *IssuedTokenUpdateOne {
+	ituo.fields = append([]string{field}, fields...)
+	return ituo
+}
+
+// Save executes the query and returns the updated IssuedToken entity.
+func (ituo *IssuedTokenUpdateOne) Save(ctx context.Context) (*IssuedToken, error) {
+	var (
+		err  error
+		node *IssuedToken
+	)
+	if len(ituo.hooks) == 0 {
+		if err = ituo.check(); err != nil {
+			return nil, err
+		}
+		node, err = ituo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IssuedTokenMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ituo.check(); err != nil {
+				return nil, err
+			}
+			ituo.mutation = mutation
+			node, err = ituo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ituo.hooks) - 1; i >= 0; i-- {
+			if ituo.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = ituo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ituo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ituo *IssuedTokenUpdateOne) SaveX(ctx context.Context) *IssuedToken {
+	node, err := ituo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ituo *IssuedTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := ituo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ituo *IssuedTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := ituo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ituo *IssuedTokenUpdateOne) check() error {
+	if v, ok := ituo.mutation.ClientID(); ok {
+		if err := issuedtoken.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "IssuedToken.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ituo *IssuedTokenUpdateOne) sqlSave(ctx context.Context) (_node *IssuedToken, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   issuedtoken.Table,
+			Columns: issuedtoken.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: issuedtoken.FieldID,
+			},
+		},
+	}
+	id, ok := ituo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`db: missing "IssuedToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ituo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, issuedtoken.FieldID)
+		for _, f := range fields {
+			if !issuedtoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+			}
+			if f != issuedtoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ituo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ituo.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldClientID,
+		})
+	}
+	if value, ok := ituo.mutation.UserID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldUserID,
+		})
+	}
+	if value, ok := ituo.mutation.ConnectorID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: issuedtoken.FieldConnectorID,
+		})
+	}
+	if value, ok := ituo.mutation.IssuedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldIssuedAt,
+		})
+	}
+	if value, ok := ituo.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: issuedtoken.FieldExpiry,
+		})
+	}
+	_node = &IssuedToken{config: ituo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ituo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{issuedtoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
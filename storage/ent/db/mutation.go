@@ -15,12 +15,15 @@ import (
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
 	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
 	"gopkg.in/square/go-jose.v2"
 
 	"entgo.io/ent"
@@ -35,16 +38,19 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeAuthCode       = "AuthCode"
-	TypeAuthRequest    = "AuthRequest"
-	TypeConnector      = "Connector"
-	TypeDeviceRequest  = "DeviceRequest"
-	TypeDeviceToken    = "DeviceToken"
-	TypeKeys           = "Keys"
-	TypeOAuth2Client   = "OAuth2Client"
-	TypeOfflineSession = "OfflineSession"
-	TypePassword       = "Password"
-	TypeRefreshToken   = "RefreshToken"
+	TypeAuthCode          = "AuthCode"
+	TypeAuthRequest       = "AuthRequest"
+	TypeConnector         = "Connector"
+	TypeDeviceRequest     = "DeviceRequest"
+	TypeDeviceToken       = "DeviceToken"
+	TypeIssuedToken       = "IssuedToken"
+	TypeKeys              = "Keys"
+	TypeOAuth2Client      = "OAuth2Client"
+	TypeOfflineSession    = "OfflineSession"
+	TypePassword          = "Password"
+	TypePushedAuthRequest = "PushedAuthRequest"
+	TypeRefreshToken      = "RefreshToken"
+	TypeUpstreamKeySet    = "UpstreamKeySet"
 )
 
 // AuthCodeMutation represents an operation that mutates the AuthCode nodes in the graph.
@@ -55,6 +61,7 @@ type AuthCodeMutation struct {
 	id                        *string
 	client_id                 *string
 	scopes                    *[]string
+	resources                 *[]string
 	nonce                     *string
 	redirect_uri              *string
 	claims_user_id            *string
@@ -62,7 +69,11 @@ type AuthCodeMutation struct {
 	claims_email              *string
 	claims_email_verified     *bool
 	claims_groups             *[]string
+	claims_organizations      *[]string
+	claims_address            **storage.Address
+	claims_custom             *map[string]interface{}
 	claims_preferred_username *string
+	claims_auth_time          *time.Time
 	connector_id              *string
 	connector_data            *[]byte
 	expiry                    *time.Time
@@ -263,6 +274,55 @@ func (m *AuthCodeMutation) ResetScopes() {
 	delete(m.clearedFields, authcode.FieldScopes)
 }
 
+// SetResources sets the "resources" field.
+func (m *AuthCodeMutation) SetResources(s []string) {
+	m.resources = &s
+}
+
+// Resources returns the value of the "resources" field in the mutation.
+func (m *AuthCodeMutation) Resources() (r []string, exists bool) {
+	v := m.resources
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResources returns the old "resources" field's value of the AuthCode entity.
+// If the AuthCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthCodeMutation) OldResources(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResources is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResources requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResources: %w", err)
+	}
+	return oldValue.Resources, nil
+}
+
+// ClearResources clears the value of the "resources" field.
+func (m *AuthCodeMutation) ClearResources() {
+	m.resources = nil
+	m.clearedFields[authcode.FieldResources] = struct{}{}
+}
+
+// ResourcesCleared returns if the "resources" field was cleared in this mutation.
+func (m *AuthCodeMutation) ResourcesCleared() bool {
+	_, ok := m.clearedFields[authcode.FieldResources]
+	return ok
+}
+
+// ResetResources resets all changes to the "resources" field.
+func (m *AuthCodeMutation) ResetResources() {
+	m.resources = nil
+	delete(m.clearedFields, authcode.FieldResources)
+}
+
 // SetNonce sets the "nonce" field.
 func (m *AuthCodeMutation) SetNonce(s string) {
 	m.nonce = &s
@@ -528,6 +588,153 @@ func (m *AuthCodeMutation) ResetClaimsGroups() {
 	delete(m.clearedFields, authcode.FieldClaimsGroups)
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (m *AuthCodeMutation) SetClaimsOrganizations(s []string) {
+	m.claims_organizations = &s
+}
+
+// ClaimsOrganizations returns the value of the "claims_organizations" field in the mutation.
+func (m *AuthCodeMutation) ClaimsOrganizations() (r []string, exists bool) {
+	v := m.claims_organizations
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsOrganizations returns the old "claims_organizations" field's value of the AuthCode entity.
+// If the AuthCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthCodeMutation) OldClaimsOrganizations(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsOrganizations is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsOrganizations requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsOrganizations: %w", err)
+	}
+	return oldValue.ClaimsOrganizations, nil
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (m *AuthCodeMutation) ClearClaimsOrganizations() {
+	m.claims_organizations = nil
+	m.clearedFields[authcode.FieldClaimsOrganizations] = struct{}{}
+}
+
+// ClaimsOrganizationsCleared returns if the "claims_organizations" field was cleared in this mutation.
+func (m *AuthCodeMutation) ClaimsOrganizationsCleared() bool {
+	_, ok := m.clearedFields[authcode.FieldClaimsOrganizations]
+	return ok
+}
+
+// ResetClaimsOrganizations resets all changes to the "claims_organizations" field.
+func (m *AuthCodeMutation) ResetClaimsOrganizations() {
+	m.claims_organizations = nil
+	delete(m.clearedFields, authcode.FieldClaimsOrganizations)
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (m *AuthCodeMutation) SetClaimsAddress(s *storage.Address) {
+	m.claims_address = &s
+}
+
+// ClaimsAddress returns the value of the "claims_address" field in the mutation.
+func (m *AuthCodeMutation) ClaimsAddress() (r *storage.Address, exists bool) {
+	v := m.claims_address
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsAddress returns the old "claims_address" field's value of the AuthCode entity.
+// If the AuthCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthCodeMutation) OldClaimsAddress(ctx context.Context) (v *storage.Address, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsAddress is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsAddress requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsAddress: %w", err)
+	}
+	return oldValue.ClaimsAddress, nil
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (m *AuthCodeMutation) ClearClaimsAddress() {
+	m.claims_address = nil
+	m.clearedFields[authcode.FieldClaimsAddress] = struct{}{}
+}
+
+// ClaimsAddressCleared returns if the "claims_address" field was cleared in this mutation.
+func (m *AuthCodeMutation) ClaimsAddressCleared() bool {
+	_, ok := m.clearedFields[authcode.FieldClaimsAddress]
+	return ok
+}
+
+// ResetClaimsAddress resets all changes to the "claims_address" field.
+func (m *AuthCodeMutation) ResetClaimsAddress() {
+	m.claims_address = nil
+	delete(m.clearedFields, authcode.FieldClaimsAddress)
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (m *AuthCodeMutation) SetClaimsCustom(value map[string]interface{}) {
+	m.claims_custom = &value
+}
+
+// ClaimsCustom returns the value of the "claims_custom" field in the mutation.
+func (m *AuthCodeMutation) ClaimsCustom() (r map[string]interface{}, exists bool) {
+	v := m.claims_custom
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsCustom returns the old "claims_custom" field's value of the AuthCode entity.
+// If the AuthCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthCodeMutation) OldClaimsCustom(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsCustom is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsCustom requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsCustom: %w", err)
+	}
+	return oldValue.ClaimsCustom, nil
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (m *AuthCodeMutation) ClearClaimsCustom() {
+	m.claims_custom = nil
+	m.clearedFields[authcode.FieldClaimsCustom] = struct{}{}
+}
+
+// ClaimsCustomCleared returns if the "claims_custom" field was cleared in this mutation.
+func (m *AuthCodeMutation) ClaimsCustomCleared() bool {
+	_, ok := m.clearedFields[authcode.FieldClaimsCustom]
+	return ok
+}
+
+// ResetClaimsCustom resets all changes to the "claims_custom" field.
+func (m *AuthCodeMutation) ResetClaimsCustom() {
+	m.claims_custom = nil
+	delete(m.clearedFields, authcode.FieldClaimsCustom)
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (m *AuthCodeMutation) SetClaimsPreferredUsername(s string) {
 	m.claims_preferred_username = &s
@@ -564,6 +771,55 @@ func (m *AuthCodeMutation) ResetClaimsPreferredUsername() {
 	m.claims_preferred_username = nil
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (m *AuthCodeMutation) SetClaimsAuthTime(t time.Time) {
+	m.claims_auth_time = &t
+}
+
+// ClaimsAuthTime returns the value of the "claims_auth_time" field in the mutation.
+func (m *AuthCodeMutation) ClaimsAuthTime() (r time.Time, exists bool) {
+	v := m.claims_auth_time
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsAuthTime returns the old "claims_auth_time" field's value of the AuthCode entity.
+// If the AuthCode object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthCodeMutation) OldClaimsAuthTime(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsAuthTime is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsAuthTime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsAuthTime: %w", err)
+	}
+	return oldValue.ClaimsAuthTime, nil
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (m *AuthCodeMutation) ClearClaimsAuthTime() {
+	m.claims_auth_time = nil
+	m.clearedFields[authcode.FieldClaimsAuthTime] = struct{}{}
+}
+
+// ClaimsAuthTimeCleared returns if the "claims_auth_time" field was cleared in this mutation.
+func (m *AuthCodeMutation) ClaimsAuthTimeCleared() bool {
+	_, ok := m.clearedFields[authcode.FieldClaimsAuthTime]
+	return ok
+}
+
+// ResetClaimsAuthTime resets all changes to the "claims_auth_time" field.
+func (m *AuthCodeMutation) ResetClaimsAuthTime() {
+	m.claims_auth_time = nil
+	delete(m.clearedFields, authcode.FieldClaimsAuthTime)
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (m *AuthCodeMutation) SetConnectorID(s string) {
 	m.connector_id = &s
@@ -776,13 +1032,16 @@ func (m *AuthCodeMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *AuthCodeMutation) Fields() []string {
-	fields := make([]string, 0, 15)
+	fields := make([]string, 0, 20)
 	if m.client_id != nil {
 		fields = append(fields, authcode.FieldClientID)
 	}
 	if m.scopes != nil {
 		fields = append(fields, authcode.FieldScopes)
 	}
+	if m.resources != nil {
+		fields = append(fields, authcode.FieldResources)
+	}
 	if m.nonce != nil {
 		fields = append(fields, authcode.FieldNonce)
 	}
@@ -804,9 +1063,21 @@ func (m *AuthCodeMutation) Fields() []string {
 	if m.claims_groups != nil {
 		fields = append(fields, authcode.FieldClaimsGroups)
 	}
+	if m.claims_organizations != nil {
+		fields = append(fields, authcode.FieldClaimsOrganizations)
+	}
+	if m.claims_address != nil {
+		fields = append(fields, authcode.FieldClaimsAddress)
+	}
+	if m.claims_custom != nil {
+		fields = append(fields, authcode.FieldClaimsCustom)
+	}
 	if m.claims_preferred_username != nil {
 		fields = append(fields, authcode.FieldClaimsPreferredUsername)
 	}
+	if m.claims_auth_time != nil {
+		fields = append(fields, authcode.FieldClaimsAuthTime)
+	}
 	if m.connector_id != nil {
 		fields = append(fields, authcode.FieldConnectorID)
 	}
@@ -834,6 +1105,8 @@ func (m *AuthCodeMutation) Field(name string) (ent.Value, bool) {
 		return m.ClientID()
 	case authcode.FieldScopes:
 		return m.Scopes()
+	case authcode.FieldResources:
+		return m.Resources()
 	case authcode.FieldNonce:
 		return m.Nonce()
 	case authcode.FieldRedirectURI:
@@ -848,8 +1121,16 @@ func (m *AuthCodeMutation) Field(name string) (ent.Value, bool) {
 		return m.ClaimsEmailVerified()
 	case authcode.FieldClaimsGroups:
 		return m.ClaimsGroups()
+	case authcode.FieldClaimsOrganizations:
+		return m.ClaimsOrganizations()
+	case authcode.FieldClaimsAddress:
+		return m.ClaimsAddress()
+	case authcode.FieldClaimsCustom:
+		return m.ClaimsCustom()
 	case authcode.FieldClaimsPreferredUsername:
 		return m.ClaimsPreferredUsername()
+	case authcode.FieldClaimsAuthTime:
+		return m.ClaimsAuthTime()
 	case authcode.FieldConnectorID:
 		return m.ConnectorID()
 	case authcode.FieldConnectorData:
@@ -873,6 +1154,8 @@ func (m *AuthCodeMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldClientID(ctx)
 	case authcode.FieldScopes:
 		return m.OldScopes(ctx)
+	case authcode.FieldResources:
+		return m.OldResources(ctx)
 	case authcode.FieldNonce:
 		return m.OldNonce(ctx)
 	case authcode.FieldRedirectURI:
@@ -887,8 +1170,16 @@ func (m *AuthCodeMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldClaimsEmailVerified(ctx)
 	case authcode.FieldClaimsGroups:
 		return m.OldClaimsGroups(ctx)
+	case authcode.FieldClaimsOrganizations:
+		return m.OldClaimsOrganizations(ctx)
+	case authcode.FieldClaimsAddress:
+		return m.OldClaimsAddress(ctx)
+	case authcode.FieldClaimsCustom:
+		return m.OldClaimsCustom(ctx)
 	case authcode.FieldClaimsPreferredUsername:
 		return m.OldClaimsPreferredUsername(ctx)
+	case authcode.FieldClaimsAuthTime:
+		return m.OldClaimsAuthTime(ctx)
 	case authcode.FieldConnectorID:
 		return m.OldConnectorID(ctx)
 	case authcode.FieldConnectorData:
@@ -922,6 +1213,13 @@ func (m *AuthCodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetScopes(v)
 		return nil
+	case authcode.FieldResources:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResources(v)
+		return nil
 	case authcode.FieldNonce:
 		v, ok := value.(string)
 		if !ok {
@@ -971,6 +1269,27 @@ func (m *AuthCodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetClaimsGroups(v)
 		return nil
+	case authcode.FieldClaimsOrganizations:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsOrganizations(v)
+		return nil
+	case authcode.FieldClaimsAddress:
+		v, ok := value.(*storage.Address)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAddress(v)
+		return nil
+	case authcode.FieldClaimsCustom:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsCustom(v)
+		return nil
 	case authcode.FieldClaimsPreferredUsername:
 		v, ok := value.(string)
 		if !ok {
@@ -978,6 +1297,13 @@ func (m *AuthCodeMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetClaimsPreferredUsername(v)
 		return nil
+	case authcode.FieldClaimsAuthTime:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAuthTime(v)
+		return nil
 	case authcode.FieldConnectorID:
 		v, ok := value.(string)
 		if !ok {
@@ -1046,9 +1372,24 @@ func (m *AuthCodeMutation) ClearedFields() []string {
 	if m.FieldCleared(authcode.FieldScopes) {
 		fields = append(fields, authcode.FieldScopes)
 	}
+	if m.FieldCleared(authcode.FieldResources) {
+		fields = append(fields, authcode.FieldResources)
+	}
 	if m.FieldCleared(authcode.FieldClaimsGroups) {
 		fields = append(fields, authcode.FieldClaimsGroups)
 	}
+	if m.FieldCleared(authcode.FieldClaimsOrganizations) {
+		fields = append(fields, authcode.FieldClaimsOrganizations)
+	}
+	if m.FieldCleared(authcode.FieldClaimsAddress) {
+		fields = append(fields, authcode.FieldClaimsAddress)
+	}
+	if m.FieldCleared(authcode.FieldClaimsCustom) {
+		fields = append(fields, authcode.FieldClaimsCustom)
+	}
+	if m.FieldCleared(authcode.FieldClaimsAuthTime) {
+		fields = append(fields, authcode.FieldClaimsAuthTime)
+	}
 	if m.FieldCleared(authcode.FieldConnectorData) {
 		fields = append(fields, authcode.FieldConnectorData)
 	}
@@ -1069,9 +1410,24 @@ func (m *AuthCodeMutation) ClearField(name string) error {
 	case authcode.FieldScopes:
 		m.ClearScopes()
 		return nil
+	case authcode.FieldResources:
+		m.ClearResources()
+		return nil
 	case authcode.FieldClaimsGroups:
 		m.ClearClaimsGroups()
 		return nil
+	case authcode.FieldClaimsOrganizations:
+		m.ClearClaimsOrganizations()
+		return nil
+	case authcode.FieldClaimsAddress:
+		m.ClearClaimsAddress()
+		return nil
+	case authcode.FieldClaimsCustom:
+		m.ClearClaimsCustom()
+		return nil
+	case authcode.FieldClaimsAuthTime:
+		m.ClearClaimsAuthTime()
+		return nil
 	case authcode.FieldConnectorData:
 		m.ClearConnectorData()
 		return nil
@@ -1089,6 +1445,9 @@ func (m *AuthCodeMutation) ResetField(name string) error {
 	case authcode.FieldScopes:
 		m.ResetScopes()
 		return nil
+	case authcode.FieldResources:
+		m.ResetResources()
+		return nil
 	case authcode.FieldNonce:
 		m.ResetNonce()
 		return nil
@@ -1110,9 +1469,21 @@ func (m *AuthCodeMutation) ResetField(name string) error {
 	case authcode.FieldClaimsGroups:
 		m.ResetClaimsGroups()
 		return nil
+	case authcode.FieldClaimsOrganizations:
+		m.ResetClaimsOrganizations()
+		return nil
+	case authcode.FieldClaimsAddress:
+		m.ResetClaimsAddress()
+		return nil
+	case authcode.FieldClaimsCustom:
+		m.ResetClaimsCustom()
+		return nil
 	case authcode.FieldClaimsPreferredUsername:
 		m.ResetClaimsPreferredUsername()
 		return nil
+	case authcode.FieldClaimsAuthTime:
+		m.ResetClaimsAuthTime()
+		return nil
 	case authcode.FieldConnectorID:
 		m.ResetConnectorID()
 		return nil
@@ -1188,10 +1559,16 @@ type AuthRequestMutation struct {
 	id                        *string
 	client_id                 *string
 	scopes                    *[]string
+	resources                 *[]string
 	response_types            *[]string
 	redirect_uri              *string
 	nonce                     *string
 	state                     *string
+	prompt                    *string
+	essential_claims          *[]string
+	acr_values                *[]string
+	max_age                   *int
+	addmax_age                *int
 	force_approval_prompt     *bool
 	logged_in                 *bool
 	claims_user_id            *string
@@ -1199,7 +1576,11 @@ type AuthRequestMutation struct {
 	claims_email              *string
 	claims_email_verified     *bool
 	claims_groups             *[]string
+	claims_organizations      *[]string
+	claims_address            **storage.Address
+	claims_custom             *map[string]interface{}
 	claims_preferred_username *string
+	claims_auth_time          *time.Time
 	connector_id              *string
 	connector_data            *[]byte
 	expiry                    *time.Time
@@ -1400,6 +1781,55 @@ func (m *AuthRequestMutation) ResetScopes() {
 	delete(m.clearedFields, authrequest.FieldScopes)
 }
 
+// SetResources sets the "resources" field.
+func (m *AuthRequestMutation) SetResources(s []string) {
+	m.resources = &s
+}
+
+// Resources returns the value of the "resources" field in the mutation.
+func (m *AuthRequestMutation) Resources() (r []string, exists bool) {
+	v := m.resources
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResources returns the old "resources" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldResources(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResources is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResources requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResources: %w", err)
+	}
+	return oldValue.Resources, nil
+}
+
+// ClearResources clears the value of the "resources" field.
+func (m *AuthRequestMutation) ClearResources() {
+	m.resources = nil
+	m.clearedFields[authrequest.FieldResources] = struct{}{}
+}
+
+// ResourcesCleared returns if the "resources" field was cleared in this mutation.
+func (m *AuthRequestMutation) ResourcesCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldResources]
+	return ok
+}
+
+// ResetResources resets all changes to the "resources" field.
+func (m *AuthRequestMutation) ResetResources() {
+	m.resources = nil
+	delete(m.clearedFields, authrequest.FieldResources)
+}
+
 // SetResponseTypes sets the "response_types" field.
 func (m *AuthRequestMutation) SetResponseTypes(s []string) {
 	m.response_types = &s
@@ -1557,121 +1987,325 @@ func (m *AuthRequestMutation) ResetState() {
 	m.state = nil
 }
 
-// SetForceApprovalPrompt sets the "force_approval_prompt" field.
-func (m *AuthRequestMutation) SetForceApprovalPrompt(b bool) {
-	m.force_approval_prompt = &b
+// SetPrompt sets the "prompt" field.
+func (m *AuthRequestMutation) SetPrompt(s string) {
+	m.prompt = &s
 }
 
-// ForceApprovalPrompt returns the value of the "force_approval_prompt" field in the mutation.
-func (m *AuthRequestMutation) ForceApprovalPrompt() (r bool, exists bool) {
-	v := m.force_approval_prompt
+// Prompt returns the value of the "prompt" field in the mutation.
+func (m *AuthRequestMutation) Prompt() (r string, exists bool) {
+	v := m.prompt
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldForceApprovalPrompt returns the old "force_approval_prompt" field's value of the AuthRequest entity.
+// OldPrompt returns the old "prompt" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldForceApprovalPrompt(ctx context.Context) (v bool, err error) {
+func (m *AuthRequestMutation) OldPrompt(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldForceApprovalPrompt is only allowed on UpdateOne operations")
+		return v, errors.New("OldPrompt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldForceApprovalPrompt requires an ID field in the mutation")
+		return v, errors.New("OldPrompt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldForceApprovalPrompt: %w", err)
+		return v, fmt.Errorf("querying old value for OldPrompt: %w", err)
 	}
-	return oldValue.ForceApprovalPrompt, nil
+	return oldValue.Prompt, nil
 }
 
-// ResetForceApprovalPrompt resets all changes to the "force_approval_prompt" field.
-func (m *AuthRequestMutation) ResetForceApprovalPrompt() {
-	m.force_approval_prompt = nil
+// ResetPrompt resets all changes to the "prompt" field.
+func (m *AuthRequestMutation) ResetPrompt() {
+	m.prompt = nil
 }
 
-// SetLoggedIn sets the "logged_in" field.
-func (m *AuthRequestMutation) SetLoggedIn(b bool) {
-	m.logged_in = &b
+// SetEssentialClaims sets the "essential_claims" field.
+func (m *AuthRequestMutation) SetEssentialClaims(s []string) {
+	m.essential_claims = &s
 }
 
-// LoggedIn returns the value of the "logged_in" field in the mutation.
-func (m *AuthRequestMutation) LoggedIn() (r bool, exists bool) {
-	v := m.logged_in
+// EssentialClaims returns the value of the "essential_claims" field in the mutation.
+func (m *AuthRequestMutation) EssentialClaims() (r []string, exists bool) {
+	v := m.essential_claims
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLoggedIn returns the old "logged_in" field's value of the AuthRequest entity.
+// OldEssentialClaims returns the old "essential_claims" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldLoggedIn(ctx context.Context) (v bool, err error) {
+func (m *AuthRequestMutation) OldEssentialClaims(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLoggedIn is only allowed on UpdateOne operations")
+		return v, errors.New("OldEssentialClaims is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLoggedIn requires an ID field in the mutation")
+		return v, errors.New("OldEssentialClaims requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLoggedIn: %w", err)
+		return v, fmt.Errorf("querying old value for OldEssentialClaims: %w", err)
 	}
-	return oldValue.LoggedIn, nil
+	return oldValue.EssentialClaims, nil
 }
 
-// ResetLoggedIn resets all changes to the "logged_in" field.
-func (m *AuthRequestMutation) ResetLoggedIn() {
-	m.logged_in = nil
+// ClearEssentialClaims clears the value of the "essential_claims" field.
+func (m *AuthRequestMutation) ClearEssentialClaims() {
+	m.essential_claims = nil
+	m.clearedFields[authrequest.FieldEssentialClaims] = struct{}{}
 }
 
-// SetClaimsUserID sets the "claims_user_id" field.
-func (m *AuthRequestMutation) SetClaimsUserID(s string) {
-	m.claims_user_id = &s
+// EssentialClaimsCleared returns if the "essential_claims" field was cleared in this mutation.
+func (m *AuthRequestMutation) EssentialClaimsCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldEssentialClaims]
+	return ok
 }
 
-// ClaimsUserID returns the value of the "claims_user_id" field in the mutation.
-func (m *AuthRequestMutation) ClaimsUserID() (r string, exists bool) {
-	v := m.claims_user_id
+// ResetEssentialClaims resets all changes to the "essential_claims" field.
+func (m *AuthRequestMutation) ResetEssentialClaims() {
+	m.essential_claims = nil
+	delete(m.clearedFields, authrequest.FieldEssentialClaims)
+}
+
+// SetAcrValues sets the "acr_values" field.
+func (m *AuthRequestMutation) SetAcrValues(s []string) {
+	m.acr_values = &s
+}
+
+// AcrValues returns the value of the "acr_values" field in the mutation.
+func (m *AuthRequestMutation) AcrValues() (r []string, exists bool) {
+	v := m.acr_values
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClaimsUserID returns the old "claims_user_id" field's value of the AuthRequest entity.
+// OldAcrValues returns the old "acr_values" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldClaimsUserID(ctx context.Context) (v string, err error) {
+func (m *AuthRequestMutation) OldAcrValues(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldAcrValues is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsUserID requires an ID field in the mutation")
+		return v, errors.New("OldAcrValues requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldAcrValues: %w", err)
 	}
-	return oldValue.ClaimsUserID, nil
+	return oldValue.AcrValues, nil
 }
 
-// ResetClaimsUserID resets all changes to the "claims_user_id" field.
-func (m *AuthRequestMutation) ResetClaimsUserID() {
-	m.claims_user_id = nil
+// ClearAcrValues clears the value of the "acr_values" field.
+func (m *AuthRequestMutation) ClearAcrValues() {
+	m.acr_values = nil
+	m.clearedFields[authrequest.FieldAcrValues] = struct{}{}
 }
 
-// SetClaimsUsername sets the "claims_username" field.
-func (m *AuthRequestMutation) SetClaimsUsername(s string) {
-	m.claims_username = &s
+// AcrValuesCleared returns if the "acr_values" field was cleared in this mutation.
+func (m *AuthRequestMutation) AcrValuesCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldAcrValues]
+	return ok
 }
 
-// ClaimsUsername returns the value of the "claims_username" field in the mutation.
-func (m *AuthRequestMutation) ClaimsUsername() (r string, exists bool) {
+// ResetAcrValues resets all changes to the "acr_values" field.
+func (m *AuthRequestMutation) ResetAcrValues() {
+	m.acr_values = nil
+	delete(m.clearedFields, authrequest.FieldAcrValues)
+}
+
+// SetMaxAge sets the "max_age" field.
+func (m *AuthRequestMutation) SetMaxAge(i int) {
+	m.max_age = &i
+	m.addmax_age = nil
+}
+
+// MaxAge returns the value of the "max_age" field in the mutation.
+func (m *AuthRequestMutation) MaxAge() (r int, exists bool) {
+	v := m.max_age
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxAge returns the old "max_age" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldMaxAge(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxAge is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxAge requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxAge: %w", err)
+	}
+	return oldValue.MaxAge, nil
+}
+
+// AddMaxAge adds i to the "max_age" field.
+func (m *AuthRequestMutation) AddMaxAge(i int) {
+	if m.addmax_age != nil {
+		*m.addmax_age += i
+	} else {
+		m.addmax_age = &i
+	}
+}
+
+// AddedMaxAge returns the value that was added to the "max_age" field in this mutation.
+func (m *AuthRequestMutation) AddedMaxAge() (r int, exists bool) {
+	v := m.addmax_age
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearMaxAge clears the value of the "max_age" field.
+func (m *AuthRequestMutation) ClearMaxAge() {
+	m.max_age = nil
+	m.addmax_age = nil
+	m.clearedFields[authrequest.FieldMaxAge] = struct{}{}
+}
+
+// MaxAgeCleared returns if the "max_age" field was cleared in this mutation.
+func (m *AuthRequestMutation) MaxAgeCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldMaxAge]
+	return ok
+}
+
+// ResetMaxAge resets all changes to the "max_age" field.
+func (m *AuthRequestMutation) ResetMaxAge() {
+	m.max_age = nil
+	m.addmax_age = nil
+	delete(m.clearedFields, authrequest.FieldMaxAge)
+}
+
+// SetForceApprovalPrompt sets the "force_approval_prompt" field.
+func (m *AuthRequestMutation) SetForceApprovalPrompt(b bool) {
+	m.force_approval_prompt = &b
+}
+
+// ForceApprovalPrompt returns the value of the "force_approval_prompt" field in the mutation.
+func (m *AuthRequestMutation) ForceApprovalPrompt() (r bool, exists bool) {
+	v := m.force_approval_prompt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldForceApprovalPrompt returns the old "force_approval_prompt" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldForceApprovalPrompt(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldForceApprovalPrompt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldForceApprovalPrompt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldForceApprovalPrompt: %w", err)
+	}
+	return oldValue.ForceApprovalPrompt, nil
+}
+
+// ResetForceApprovalPrompt resets all changes to the "force_approval_prompt" field.
+func (m *AuthRequestMutation) ResetForceApprovalPrompt() {
+	m.force_approval_prompt = nil
+}
+
+// SetLoggedIn sets the "logged_in" field.
+func (m *AuthRequestMutation) SetLoggedIn(b bool) {
+	m.logged_in = &b
+}
+
+// LoggedIn returns the value of the "logged_in" field in the mutation.
+func (m *AuthRequestMutation) LoggedIn() (r bool, exists bool) {
+	v := m.logged_in
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLoggedIn returns the old "logged_in" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldLoggedIn(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLoggedIn is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLoggedIn requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLoggedIn: %w", err)
+	}
+	return oldValue.LoggedIn, nil
+}
+
+// ResetLoggedIn resets all changes to the "logged_in" field.
+func (m *AuthRequestMutation) ResetLoggedIn() {
+	m.logged_in = nil
+}
+
+// SetClaimsUserID sets the "claims_user_id" field.
+func (m *AuthRequestMutation) SetClaimsUserID(s string) {
+	m.claims_user_id = &s
+}
+
+// ClaimsUserID returns the value of the "claims_user_id" field in the mutation.
+func (m *AuthRequestMutation) ClaimsUserID() (r string, exists bool) {
+	v := m.claims_user_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsUserID returns the old "claims_user_id" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldClaimsUserID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsUserID: %w", err)
+	}
+	return oldValue.ClaimsUserID, nil
+}
+
+// ResetClaimsUserID resets all changes to the "claims_user_id" field.
+func (m *AuthRequestMutation) ResetClaimsUserID() {
+	m.claims_user_id = nil
+}
+
+// SetClaimsUsername sets the "claims_username" field.
+func (m *AuthRequestMutation) SetClaimsUsername(s string) {
+	m.claims_username = &s
+}
+
+// ClaimsUsername returns the value of the "claims_username" field in the mutation.
+func (m *AuthRequestMutation) ClaimsUsername() (r string, exists bool) {
 	v := m.claims_username
 	if v == nil {
 		return
@@ -1822,186 +2456,382 @@ func (m *AuthRequestMutation) ResetClaimsGroups() {
 	delete(m.clearedFields, authrequest.FieldClaimsGroups)
 }
 
-// SetClaimsPreferredUsername sets the "claims_preferred_username" field.
-func (m *AuthRequestMutation) SetClaimsPreferredUsername(s string) {
-	m.claims_preferred_username = &s
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (m *AuthRequestMutation) SetClaimsOrganizations(s []string) {
+	m.claims_organizations = &s
 }
 
-// ClaimsPreferredUsername returns the value of the "claims_preferred_username" field in the mutation.
-func (m *AuthRequestMutation) ClaimsPreferredUsername() (r string, exists bool) {
-	v := m.claims_preferred_username
+// ClaimsOrganizations returns the value of the "claims_organizations" field in the mutation.
+func (m *AuthRequestMutation) ClaimsOrganizations() (r []string, exists bool) {
+	v := m.claims_organizations
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClaimsPreferredUsername returns the old "claims_preferred_username" field's value of the AuthRequest entity.
+// OldClaimsOrganizations returns the old "claims_organizations" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldClaimsPreferredUsername(ctx context.Context) (v string, err error) {
+func (m *AuthRequestMutation) OldClaimsOrganizations(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsPreferredUsername is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaimsOrganizations is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsPreferredUsername requires an ID field in the mutation")
+		return v, errors.New("OldClaimsOrganizations requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsPreferredUsername: %w", err)
+		return v, fmt.Errorf("querying old value for OldClaimsOrganizations: %w", err)
 	}
-	return oldValue.ClaimsPreferredUsername, nil
+	return oldValue.ClaimsOrganizations, nil
 }
 
-// ResetClaimsPreferredUsername resets all changes to the "claims_preferred_username" field.
-func (m *AuthRequestMutation) ResetClaimsPreferredUsername() {
-	m.claims_preferred_username = nil
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (m *AuthRequestMutation) ClearClaimsOrganizations() {
+	m.claims_organizations = nil
+	m.clearedFields[authrequest.FieldClaimsOrganizations] = struct{}{}
 }
 
-// SetConnectorID sets the "connector_id" field.
-func (m *AuthRequestMutation) SetConnectorID(s string) {
-	m.connector_id = &s
+// ClaimsOrganizationsCleared returns if the "claims_organizations" field was cleared in this mutation.
+func (m *AuthRequestMutation) ClaimsOrganizationsCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldClaimsOrganizations]
+	return ok
 }
 
-// ConnectorID returns the value of the "connector_id" field in the mutation.
-func (m *AuthRequestMutation) ConnectorID() (r string, exists bool) {
-	v := m.connector_id
+// ResetClaimsOrganizations resets all changes to the "claims_organizations" field.
+func (m *AuthRequestMutation) ResetClaimsOrganizations() {
+	m.claims_organizations = nil
+	delete(m.clearedFields, authrequest.FieldClaimsOrganizations)
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (m *AuthRequestMutation) SetClaimsAddress(s *storage.Address) {
+	m.claims_address = &s
+}
+
+// ClaimsAddress returns the value of the "claims_address" field in the mutation.
+func (m *AuthRequestMutation) ClaimsAddress() (r *storage.Address, exists bool) {
+	v := m.claims_address
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConnectorID returns the old "connector_id" field's value of the AuthRequest entity.
+// OldClaimsAddress returns the old "claims_address" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldConnectorID(ctx context.Context) (v string, err error) {
+func (m *AuthRequestMutation) OldClaimsAddress(ctx context.Context) (v *storage.Address, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnectorID is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaimsAddress is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnectorID requires an ID field in the mutation")
+		return v, errors.New("OldClaimsAddress requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnectorID: %w", err)
+		return v, fmt.Errorf("querying old value for OldClaimsAddress: %w", err)
 	}
-	return oldValue.ConnectorID, nil
+	return oldValue.ClaimsAddress, nil
 }
 
-// ResetConnectorID resets all changes to the "connector_id" field.
-func (m *AuthRequestMutation) ResetConnectorID() {
-	m.connector_id = nil
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (m *AuthRequestMutation) ClearClaimsAddress() {
+	m.claims_address = nil
+	m.clearedFields[authrequest.FieldClaimsAddress] = struct{}{}
 }
 
-// SetConnectorData sets the "connector_data" field.
-func (m *AuthRequestMutation) SetConnectorData(b []byte) {
-	m.connector_data = &b
+// ClaimsAddressCleared returns if the "claims_address" field was cleared in this mutation.
+func (m *AuthRequestMutation) ClaimsAddressCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldClaimsAddress]
+	return ok
 }
 
-// ConnectorData returns the value of the "connector_data" field in the mutation.
-func (m *AuthRequestMutation) ConnectorData() (r []byte, exists bool) {
-	v := m.connector_data
+// ResetClaimsAddress resets all changes to the "claims_address" field.
+func (m *AuthRequestMutation) ResetClaimsAddress() {
+	m.claims_address = nil
+	delete(m.clearedFields, authrequest.FieldClaimsAddress)
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (m *AuthRequestMutation) SetClaimsCustom(value map[string]interface{}) {
+	m.claims_custom = &value
+}
+
+// ClaimsCustom returns the value of the "claims_custom" field in the mutation.
+func (m *AuthRequestMutation) ClaimsCustom() (r map[string]interface{}, exists bool) {
+	v := m.claims_custom
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConnectorData returns the old "connector_data" field's value of the AuthRequest entity.
+// OldClaimsCustom returns the old "claims_custom" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
+func (m *AuthRequestMutation) OldClaimsCustom(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaimsCustom is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnectorData requires an ID field in the mutation")
+		return v, errors.New("OldClaimsCustom requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
+		return v, fmt.Errorf("querying old value for OldClaimsCustom: %w", err)
 	}
-	return oldValue.ConnectorData, nil
+	return oldValue.ClaimsCustom, nil
 }
 
-// ClearConnectorData clears the value of the "connector_data" field.
-func (m *AuthRequestMutation) ClearConnectorData() {
-	m.connector_data = nil
-	m.clearedFields[authrequest.FieldConnectorData] = struct{}{}
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (m *AuthRequestMutation) ClearClaimsCustom() {
+	m.claims_custom = nil
+	m.clearedFields[authrequest.FieldClaimsCustom] = struct{}{}
 }
 
-// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
-func (m *AuthRequestMutation) ConnectorDataCleared() bool {
-	_, ok := m.clearedFields[authrequest.FieldConnectorData]
+// ClaimsCustomCleared returns if the "claims_custom" field was cleared in this mutation.
+func (m *AuthRequestMutation) ClaimsCustomCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldClaimsCustom]
 	return ok
 }
 
-// ResetConnectorData resets all changes to the "connector_data" field.
-func (m *AuthRequestMutation) ResetConnectorData() {
-	m.connector_data = nil
-	delete(m.clearedFields, authrequest.FieldConnectorData)
+// ResetClaimsCustom resets all changes to the "claims_custom" field.
+func (m *AuthRequestMutation) ResetClaimsCustom() {
+	m.claims_custom = nil
+	delete(m.clearedFields, authrequest.FieldClaimsCustom)
 }
 
-// SetExpiry sets the "expiry" field.
-func (m *AuthRequestMutation) SetExpiry(t time.Time) {
-	m.expiry = &t
+// SetClaimsPreferredUsername sets the "claims_preferred_username" field.
+func (m *AuthRequestMutation) SetClaimsPreferredUsername(s string) {
+	m.claims_preferred_username = &s
 }
 
-// Expiry returns the value of the "expiry" field in the mutation.
-func (m *AuthRequestMutation) Expiry() (r time.Time, exists bool) {
-	v := m.expiry
+// ClaimsPreferredUsername returns the value of the "claims_preferred_username" field in the mutation.
+func (m *AuthRequestMutation) ClaimsPreferredUsername() (r string, exists bool) {
+	v := m.claims_preferred_username
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiry returns the old "expiry" field's value of the AuthRequest entity.
+// OldClaimsPreferredUsername returns the old "claims_preferred_username" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+func (m *AuthRequestMutation) OldClaimsPreferredUsername(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaimsPreferredUsername is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiry requires an ID field in the mutation")
+		return v, errors.New("OldClaimsPreferredUsername requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+		return v, fmt.Errorf("querying old value for OldClaimsPreferredUsername: %w", err)
 	}
-	return oldValue.Expiry, nil
+	return oldValue.ClaimsPreferredUsername, nil
 }
 
-// ResetExpiry resets all changes to the "expiry" field.
-func (m *AuthRequestMutation) ResetExpiry() {
-	m.expiry = nil
+// ResetClaimsPreferredUsername resets all changes to the "claims_preferred_username" field.
+func (m *AuthRequestMutation) ResetClaimsPreferredUsername() {
+	m.claims_preferred_username = nil
 }
 
-// SetCodeChallenge sets the "code_challenge" field.
-func (m *AuthRequestMutation) SetCodeChallenge(s string) {
-	m.code_challenge = &s
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (m *AuthRequestMutation) SetClaimsAuthTime(t time.Time) {
+	m.claims_auth_time = &t
 }
 
-// CodeChallenge returns the value of the "code_challenge" field in the mutation.
-func (m *AuthRequestMutation) CodeChallenge() (r string, exists bool) {
-	v := m.code_challenge
+// ClaimsAuthTime returns the value of the "claims_auth_time" field in the mutation.
+func (m *AuthRequestMutation) ClaimsAuthTime() (r time.Time, exists bool) {
+	v := m.claims_auth_time
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCodeChallenge returns the old "code_challenge" field's value of the AuthRequest entity.
+// OldClaimsAuthTime returns the old "claims_auth_time" field's value of the AuthRequest entity.
 // If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthRequestMutation) OldCodeChallenge(ctx context.Context) (v string, err error) {
+func (m *AuthRequestMutation) OldClaimsAuthTime(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCodeChallenge is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaimsAuthTime is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCodeChallenge requires an ID field in the mutation")
+		return v, errors.New("OldClaimsAuthTime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsAuthTime: %w", err)
+	}
+	return oldValue.ClaimsAuthTime, nil
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (m *AuthRequestMutation) ClearClaimsAuthTime() {
+	m.claims_auth_time = nil
+	m.clearedFields[authrequest.FieldClaimsAuthTime] = struct{}{}
+}
+
+// ClaimsAuthTimeCleared returns if the "claims_auth_time" field was cleared in this mutation.
+func (m *AuthRequestMutation) ClaimsAuthTimeCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldClaimsAuthTime]
+	return ok
+}
+
+// ResetClaimsAuthTime resets all changes to the "claims_auth_time" field.
+func (m *AuthRequestMutation) ResetClaimsAuthTime() {
+	m.claims_auth_time = nil
+	delete(m.clearedFields, authrequest.FieldClaimsAuthTime)
+}
+
+// SetConnectorID sets the "connector_id" field.
+func (m *AuthRequestMutation) SetConnectorID(s string) {
+	m.connector_id = &s
+}
+
+// ConnectorID returns the value of the "connector_id" field in the mutation.
+func (m *AuthRequestMutation) ConnectorID() (r string, exists bool) {
+	v := m.connector_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldConnectorID returns the old "connector_id" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldConnectorID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldConnectorID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldConnectorID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldConnectorID: %w", err)
+	}
+	return oldValue.ConnectorID, nil
+}
+
+// ResetConnectorID resets all changes to the "connector_id" field.
+func (m *AuthRequestMutation) ResetConnectorID() {
+	m.connector_id = nil
+}
+
+// SetConnectorData sets the "connector_data" field.
+func (m *AuthRequestMutation) SetConnectorData(b []byte) {
+	m.connector_data = &b
+}
+
+// ConnectorData returns the value of the "connector_data" field in the mutation.
+func (m *AuthRequestMutation) ConnectorData() (r []byte, exists bool) {
+	v := m.connector_data
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldConnectorData returns the old "connector_data" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldConnectorData requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
+	}
+	return oldValue.ConnectorData, nil
+}
+
+// ClearConnectorData clears the value of the "connector_data" field.
+func (m *AuthRequestMutation) ClearConnectorData() {
+	m.connector_data = nil
+	m.clearedFields[authrequest.FieldConnectorData] = struct{}{}
+}
+
+// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
+func (m *AuthRequestMutation) ConnectorDataCleared() bool {
+	_, ok := m.clearedFields[authrequest.FieldConnectorData]
+	return ok
+}
+
+// ResetConnectorData resets all changes to the "connector_data" field.
+func (m *AuthRequestMutation) ResetConnectorData() {
+	m.connector_data = nil
+	delete(m.clearedFields, authrequest.FieldConnectorData)
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *AuthRequestMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *AuthRequestMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *AuthRequestMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// SetCodeChallenge sets the "code_challenge" field.
+func (m *AuthRequestMutation) SetCodeChallenge(s string) {
+	m.code_challenge = &s
+}
+
+// CodeChallenge returns the value of the "code_challenge" field in the mutation.
+func (m *AuthRequestMutation) CodeChallenge() (r string, exists bool) {
+	v := m.code_challenge
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCodeChallenge returns the old "code_challenge" field's value of the AuthRequest entity.
+// If the AuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthRequestMutation) OldCodeChallenge(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCodeChallenge is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCodeChallenge requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
@@ -2070,13 +2900,16 @@ func (m *AuthRequestMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *AuthRequestMutation) Fields() []string {
-	fields := make([]string, 0, 19)
+	fields := make([]string, 0, 28)
 	if m.client_id != nil {
 		fields = append(fields, authrequest.FieldClientID)
 	}
 	if m.scopes != nil {
 		fields = append(fields, authrequest.FieldScopes)
 	}
+	if m.resources != nil {
+		fields = append(fields, authrequest.FieldResources)
+	}
 	if m.response_types != nil {
 		fields = append(fields, authrequest.FieldResponseTypes)
 	}
@@ -2089,6 +2922,18 @@ func (m *AuthRequestMutation) Fields() []string {
 	if m.state != nil {
 		fields = append(fields, authrequest.FieldState)
 	}
+	if m.prompt != nil {
+		fields = append(fields, authrequest.FieldPrompt)
+	}
+	if m.essential_claims != nil {
+		fields = append(fields, authrequest.FieldEssentialClaims)
+	}
+	if m.acr_values != nil {
+		fields = append(fields, authrequest.FieldAcrValues)
+	}
+	if m.max_age != nil {
+		fields = append(fields, authrequest.FieldMaxAge)
+	}
 	if m.force_approval_prompt != nil {
 		fields = append(fields, authrequest.FieldForceApprovalPrompt)
 	}
@@ -2110,9 +2955,21 @@ func (m *AuthRequestMutation) Fields() []string {
 	if m.claims_groups != nil {
 		fields = append(fields, authrequest.FieldClaimsGroups)
 	}
+	if m.claims_organizations != nil {
+		fields = append(fields, authrequest.FieldClaimsOrganizations)
+	}
+	if m.claims_address != nil {
+		fields = append(fields, authrequest.FieldClaimsAddress)
+	}
+	if m.claims_custom != nil {
+		fields = append(fields, authrequest.FieldClaimsCustom)
+	}
 	if m.claims_preferred_username != nil {
 		fields = append(fields, authrequest.FieldClaimsPreferredUsername)
 	}
+	if m.claims_auth_time != nil {
+		fields = append(fields, authrequest.FieldClaimsAuthTime)
+	}
 	if m.connector_id != nil {
 		fields = append(fields, authrequest.FieldConnectorID)
 	}
@@ -2140,6 +2997,8 @@ func (m *AuthRequestMutation) Field(name string) (ent.Value, bool) {
 		return m.ClientID()
 	case authrequest.FieldScopes:
 		return m.Scopes()
+	case authrequest.FieldResources:
+		return m.Resources()
 	case authrequest.FieldResponseTypes:
 		return m.ResponseTypes()
 	case authrequest.FieldRedirectURI:
@@ -2148,6 +3007,14 @@ func (m *AuthRequestMutation) Field(name string) (ent.Value, bool) {
 		return m.Nonce()
 	case authrequest.FieldState:
 		return m.State()
+	case authrequest.FieldPrompt:
+		return m.Prompt()
+	case authrequest.FieldEssentialClaims:
+		return m.EssentialClaims()
+	case authrequest.FieldAcrValues:
+		return m.AcrValues()
+	case authrequest.FieldMaxAge:
+		return m.MaxAge()
 	case authrequest.FieldForceApprovalPrompt:
 		return m.ForceApprovalPrompt()
 	case authrequest.FieldLoggedIn:
@@ -2162,8 +3029,16 @@ func (m *AuthRequestMutation) Field(name string) (ent.Value, bool) {
 		return m.ClaimsEmailVerified()
 	case authrequest.FieldClaimsGroups:
 		return m.ClaimsGroups()
+	case authrequest.FieldClaimsOrganizations:
+		return m.ClaimsOrganizations()
+	case authrequest.FieldClaimsAddress:
+		return m.ClaimsAddress()
+	case authrequest.FieldClaimsCustom:
+		return m.ClaimsCustom()
 	case authrequest.FieldClaimsPreferredUsername:
 		return m.ClaimsPreferredUsername()
+	case authrequest.FieldClaimsAuthTime:
+		return m.ClaimsAuthTime()
 	case authrequest.FieldConnectorID:
 		return m.ConnectorID()
 	case authrequest.FieldConnectorData:
@@ -2187,6 +3062,8 @@ func (m *AuthRequestMutation) OldField(ctx context.Context, name string) (ent.Va
 		return m.OldClientID(ctx)
 	case authrequest.FieldScopes:
 		return m.OldScopes(ctx)
+	case authrequest.FieldResources:
+		return m.OldResources(ctx)
 	case authrequest.FieldResponseTypes:
 		return m.OldResponseTypes(ctx)
 	case authrequest.FieldRedirectURI:
@@ -2195,6 +3072,14 @@ func (m *AuthRequestMutation) OldField(ctx context.Context, name string) (ent.Va
 		return m.OldNonce(ctx)
 	case authrequest.FieldState:
 		return m.OldState(ctx)
+	case authrequest.FieldPrompt:
+		return m.OldPrompt(ctx)
+	case authrequest.FieldEssentialClaims:
+		return m.OldEssentialClaims(ctx)
+	case authrequest.FieldAcrValues:
+		return m.OldAcrValues(ctx)
+	case authrequest.FieldMaxAge:
+		return m.OldMaxAge(ctx)
 	case authrequest.FieldForceApprovalPrompt:
 		return m.OldForceApprovalPrompt(ctx)
 	case authrequest.FieldLoggedIn:
@@ -2209,8 +3094,16 @@ func (m *AuthRequestMutation) OldField(ctx context.Context, name string) (ent.Va
 		return m.OldClaimsEmailVerified(ctx)
 	case authrequest.FieldClaimsGroups:
 		return m.OldClaimsGroups(ctx)
+	case authrequest.FieldClaimsOrganizations:
+		return m.OldClaimsOrganizations(ctx)
+	case authrequest.FieldClaimsAddress:
+		return m.OldClaimsAddress(ctx)
+	case authrequest.FieldClaimsCustom:
+		return m.OldClaimsCustom(ctx)
 	case authrequest.FieldClaimsPreferredUsername:
 		return m.OldClaimsPreferredUsername(ctx)
+	case authrequest.FieldClaimsAuthTime:
+		return m.OldClaimsAuthTime(ctx)
 	case authrequest.FieldConnectorID:
 		return m.OldConnectorID(ctx)
 	case authrequest.FieldConnectorData:
@@ -2244,6 +3137,13 @@ func (m *AuthRequestMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetScopes(v)
 		return nil
+	case authrequest.FieldResources:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResources(v)
+		return nil
 	case authrequest.FieldResponseTypes:
 		v, ok := value.([]string)
 		if !ok {
@@ -2272,6 +3172,34 @@ func (m *AuthRequestMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetState(v)
 		return nil
+	case authrequest.FieldPrompt:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrompt(v)
+		return nil
+	case authrequest.FieldEssentialClaims:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEssentialClaims(v)
+		return nil
+	case authrequest.FieldAcrValues:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAcrValues(v)
+		return nil
+	case authrequest.FieldMaxAge:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxAge(v)
+		return nil
 	case authrequest.FieldForceApprovalPrompt:
 		v, ok := value.(bool)
 		if !ok {
@@ -2321,6 +3249,27 @@ func (m *AuthRequestMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetClaimsGroups(v)
 		return nil
+	case authrequest.FieldClaimsOrganizations:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsOrganizations(v)
+		return nil
+	case authrequest.FieldClaimsAddress:
+		v, ok := value.(*storage.Address)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAddress(v)
+		return nil
+	case authrequest.FieldClaimsCustom:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsCustom(v)
+		return nil
 	case authrequest.FieldClaimsPreferredUsername:
 		v, ok := value.(string)
 		if !ok {
@@ -2328,6 +3277,13 @@ func (m *AuthRequestMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetClaimsPreferredUsername(v)
 		return nil
+	case authrequest.FieldClaimsAuthTime:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAuthTime(v)
+		return nil
 	case authrequest.FieldConnectorID:
 		v, ok := value.(string)
 		if !ok {
@@ -2370,13 +3326,21 @@ func (m *AuthRequestMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *AuthRequestMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addmax_age != nil {
+		fields = append(fields, authrequest.FieldMaxAge)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *AuthRequestMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case authrequest.FieldMaxAge:
+		return m.AddedMaxAge()
+	}
 	return nil, false
 }
 
@@ -2385,6 +3349,13 @@ func (m *AuthRequestMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *AuthRequestMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case authrequest.FieldMaxAge:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxAge(v)
+		return nil
 	}
 	return fmt.Errorf("unknown AuthRequest numeric field %s", name)
 }
@@ -2396,12 +3367,36 @@ func (m *AuthRequestMutation) ClearedFields() []string {
 	if m.FieldCleared(authrequest.FieldScopes) {
 		fields = append(fields, authrequest.FieldScopes)
 	}
+	if m.FieldCleared(authrequest.FieldResources) {
+		fields = append(fields, authrequest.FieldResources)
+	}
 	if m.FieldCleared(authrequest.FieldResponseTypes) {
 		fields = append(fields, authrequest.FieldResponseTypes)
 	}
+	if m.FieldCleared(authrequest.FieldEssentialClaims) {
+		fields = append(fields, authrequest.FieldEssentialClaims)
+	}
+	if m.FieldCleared(authrequest.FieldAcrValues) {
+		fields = append(fields, authrequest.FieldAcrValues)
+	}
+	if m.FieldCleared(authrequest.FieldMaxAge) {
+		fields = append(fields, authrequest.FieldMaxAge)
+	}
 	if m.FieldCleared(authrequest.FieldClaimsGroups) {
 		fields = append(fields, authrequest.FieldClaimsGroups)
 	}
+	if m.FieldCleared(authrequest.FieldClaimsOrganizations) {
+		fields = append(fields, authrequest.FieldClaimsOrganizations)
+	}
+	if m.FieldCleared(authrequest.FieldClaimsAddress) {
+		fields = append(fields, authrequest.FieldClaimsAddress)
+	}
+	if m.FieldCleared(authrequest.FieldClaimsCustom) {
+		fields = append(fields, authrequest.FieldClaimsCustom)
+	}
+	if m.FieldCleared(authrequest.FieldClaimsAuthTime) {
+		fields = append(fields, authrequest.FieldClaimsAuthTime)
+	}
 	if m.FieldCleared(authrequest.FieldConnectorData) {
 		fields = append(fields, authrequest.FieldConnectorData)
 	}
@@ -2422,12 +3417,36 @@ func (m *AuthRequestMutation) ClearField(name string) error {
 	case authrequest.FieldScopes:
 		m.ClearScopes()
 		return nil
+	case authrequest.FieldResources:
+		m.ClearResources()
+		return nil
 	case authrequest.FieldResponseTypes:
 		m.ClearResponseTypes()
 		return nil
+	case authrequest.FieldEssentialClaims:
+		m.ClearEssentialClaims()
+		return nil
+	case authrequest.FieldAcrValues:
+		m.ClearAcrValues()
+		return nil
+	case authrequest.FieldMaxAge:
+		m.ClearMaxAge()
+		return nil
 	case authrequest.FieldClaimsGroups:
 		m.ClearClaimsGroups()
 		return nil
+	case authrequest.FieldClaimsOrganizations:
+		m.ClearClaimsOrganizations()
+		return nil
+	case authrequest.FieldClaimsAddress:
+		m.ClearClaimsAddress()
+		return nil
+	case authrequest.FieldClaimsCustom:
+		m.ClearClaimsCustom()
+		return nil
+	case authrequest.FieldClaimsAuthTime:
+		m.ClearClaimsAuthTime()
+		return nil
 	case authrequest.FieldConnectorData:
 		m.ClearConnectorData()
 		return nil
@@ -2445,6 +3464,9 @@ func (m *AuthRequestMutation) ResetField(name string) error {
 	case authrequest.FieldScopes:
 		m.ResetScopes()
 		return nil
+	case authrequest.FieldResources:
+		m.ResetResources()
+		return nil
 	case authrequest.FieldResponseTypes:
 		m.ResetResponseTypes()
 		return nil
@@ -2457,6 +3479,18 @@ func (m *AuthRequestMutation) ResetField(name string) error {
 	case authrequest.FieldState:
 		m.ResetState()
 		return nil
+	case authrequest.FieldPrompt:
+		m.ResetPrompt()
+		return nil
+	case authrequest.FieldEssentialClaims:
+		m.ResetEssentialClaims()
+		return nil
+	case authrequest.FieldAcrValues:
+		m.ResetAcrValues()
+		return nil
+	case authrequest.FieldMaxAge:
+		m.ResetMaxAge()
+		return nil
 	case authrequest.FieldForceApprovalPrompt:
 		m.ResetForceApprovalPrompt()
 		return nil
@@ -2478,9 +3512,21 @@ func (m *AuthRequestMutation) ResetField(name string) error {
 	case authrequest.FieldClaimsGroups:
 		m.ResetClaimsGroups()
 		return nil
+	case authrequest.FieldClaimsOrganizations:
+		m.ResetClaimsOrganizations()
+		return nil
+	case authrequest.FieldClaimsAddress:
+		m.ResetClaimsAddress()
+		return nil
+	case authrequest.FieldClaimsCustom:
+		m.ResetClaimsCustom()
+		return nil
 	case authrequest.FieldClaimsPreferredUsername:
 		m.ResetClaimsPreferredUsername()
 		return nil
+	case authrequest.FieldClaimsAuthTime:
+		m.ResetClaimsAuthTime()
+		return nil
 	case authrequest.FieldConnectorID:
 		m.ResetConnectorID()
 		return nil
@@ -4269,33 +5315,34 @@ func (m *DeviceTokenMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown DeviceToken edge %s", name)
 }
 
-// KeysMutation represents an operation that mutates the Keys nodes in the graph.
-type KeysMutation struct {
+// IssuedTokenMutation represents an operation that mutates the IssuedToken nodes in the graph.
+type IssuedTokenMutation struct {
 	config
-	op                Op
-	typ               string
-	id                *string
-	verification_keys *[]storage.VerificationKey
-	signing_key       *jose.JSONWebKey
-	signing_key_pub   *jose.JSONWebKey
-	next_rotation     *time.Time
-	clearedFields     map[string]struct{}
-	done              bool
-	oldValue          func(context.Context) (*Keys, error)
-	predicates        []predicate.Keys
+	op            Op
+	typ           string
+	id            *string
+	client_id     *string
+	user_id       *string
+	connector_id  *string
+	issued_at     *time.Time
+	expiry        *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*IssuedToken, error)
+	predicates    []predicate.IssuedToken
 }
 
-var _ ent.Mutation = (*KeysMutation)(nil)
+var _ ent.Mutation = (*IssuedTokenMutation)(nil)
 
-// keysOption allows management of the mutation configuration using functional options.
-type keysOption func(*KeysMutation)
+// issuedtokenOption allows management of the mutation configuration using functional options.
+type issuedtokenOption func(*IssuedTokenMutation)
 
-// newKeysMutation creates new mutation for the Keys entity.
-func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
-	m := &KeysMutation{
+// newIssuedTokenMutation creates new mutation for the IssuedToken entity.
+func newIssuedTokenMutation(c config, op Op, opts ...issuedtokenOption) *IssuedTokenMutation {
+	m := &IssuedTokenMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeKeys,
+		typ:           TypeIssuedToken,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4304,20 +5351,20 @@ func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
 	return m
 }
 
-// withKeysID sets the ID field of the mutation.
-func withKeysID(id string) keysOption {
-	return func(m *KeysMutation) {
+// withIssuedTokenID sets the ID field of the mutation.
+func withIssuedTokenID(id string) issuedtokenOption {
+	return func(m *IssuedTokenMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Keys
+			value *IssuedToken
 		)
-		m.oldValue = func(ctx context.Context) (*Keys, error) {
+		m.oldValue = func(ctx context.Context) (*IssuedToken, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Keys.Get(ctx, id)
+					value, err = m.Client().IssuedToken.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4326,10 +5373,10 @@ func withKeysID(id string) keysOption {
 	}
 }
 
-// withKeys sets the old Keys of the mutation.
-func withKeys(node *Keys) keysOption {
-	return func(m *KeysMutation) {
-		m.oldValue = func(context.Context) (*Keys, error) {
+// withIssuedToken sets the old IssuedToken of the mutation.
+func withIssuedToken(node *IssuedToken) issuedtokenOption {
+	return func(m *IssuedTokenMutation) {
+		m.oldValue = func(context.Context) (*IssuedToken, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4338,7 +5385,7 @@ func withKeys(node *Keys) keysOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m KeysMutation) Client() *Client {
+func (m IssuedTokenMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4346,7 +5393,7 @@ func (m KeysMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m KeysMutation) Tx() (*Tx, error) {
+func (m IssuedTokenMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -4356,14 +5403,14 @@ func (m KeysMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Keys entities.
-func (m *KeysMutation) SetID(id string) {
+// operation is only accepted on creation of IssuedToken entities.
+func (m *IssuedTokenMutation) SetID(id string) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *KeysMutation) ID() (id string, exists bool) {
+func (m *IssuedTokenMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -4374,7 +5421,7 @@ func (m *KeysMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *KeysMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *IssuedTokenMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -4383,187 +5430,226 @@ func (m *KeysMutation) IDs(ctx context.Context) ([]string, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Keys.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().IssuedToken.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetVerificationKeys sets the "verification_keys" field.
-func (m *KeysMutation) SetVerificationKeys(sk []storage.VerificationKey) {
-	m.verification_keys = &sk
+// SetClientID sets the "client_id" field.
+func (m *IssuedTokenMutation) SetClientID(s string) {
+	m.client_id = &s
 }
 
-// VerificationKeys returns the value of the "verification_keys" field in the mutation.
-func (m *KeysMutation) VerificationKeys() (r []storage.VerificationKey, exists bool) {
-	v := m.verification_keys
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *IssuedTokenMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVerificationKeys returns the old "verification_keys" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldClientID returns the old "client_id" field's value of the IssuedToken entity.
+// If the IssuedToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldVerificationKeys(ctx context.Context) (v []storage.VerificationKey, err error) {
+func (m *IssuedTokenMutation) OldClientID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVerificationKeys is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVerificationKeys requires an ID field in the mutation")
+		return v, errors.New("OldClientID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVerificationKeys: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
 	}
-	return oldValue.VerificationKeys, nil
+	return oldValue.ClientID, nil
 }
 
-// ResetVerificationKeys resets all changes to the "verification_keys" field.
-func (m *KeysMutation) ResetVerificationKeys() {
-	m.verification_keys = nil
+// ResetClientID resets all changes to the "client_id" field.
+func (m *IssuedTokenMutation) ResetClientID() {
+	m.client_id = nil
 }
 
-// SetSigningKey sets the "signing_key" field.
-func (m *KeysMutation) SetSigningKey(jwk jose.JSONWebKey) {
-	m.signing_key = &jwk
+// SetUserID sets the "user_id" field.
+func (m *IssuedTokenMutation) SetUserID(s string) {
+	m.user_id = &s
 }
 
-// SigningKey returns the value of the "signing_key" field in the mutation.
-func (m *KeysMutation) SigningKey() (r jose.JSONWebKey, exists bool) {
-	v := m.signing_key
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *IssuedTokenMutation) UserID() (r string, exists bool) {
+	v := m.user_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSigningKey returns the old "signing_key" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the IssuedToken entity.
+// If the IssuedToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldSigningKey(ctx context.Context) (v jose.JSONWebKey, err error) {
+func (m *IssuedTokenMutation) OldUserID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSigningKey is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSigningKey requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSigningKey: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.SigningKey, nil
+	return oldValue.UserID, nil
 }
 
-// ResetSigningKey resets all changes to the "signing_key" field.
-func (m *KeysMutation) ResetSigningKey() {
-	m.signing_key = nil
+// ResetUserID resets all changes to the "user_id" field.
+func (m *IssuedTokenMutation) ResetUserID() {
+	m.user_id = nil
 }
 
-// SetSigningKeyPub sets the "signing_key_pub" field.
-func (m *KeysMutation) SetSigningKeyPub(jwk jose.JSONWebKey) {
-	m.signing_key_pub = &jwk
+// SetConnectorID sets the "connector_id" field.
+func (m *IssuedTokenMutation) SetConnectorID(s string) {
+	m.connector_id = &s
 }
 
-// SigningKeyPub returns the value of the "signing_key_pub" field in the mutation.
-func (m *KeysMutation) SigningKeyPub() (r jose.JSONWebKey, exists bool) {
-	v := m.signing_key_pub
+// ConnectorID returns the value of the "connector_id" field in the mutation.
+func (m *IssuedTokenMutation) ConnectorID() (r string, exists bool) {
+	v := m.connector_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSigningKeyPub returns the old "signing_key_pub" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldConnectorID returns the old "connector_id" field's value of the IssuedToken entity.
+// If the IssuedToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldSigningKeyPub(ctx context.Context) (v jose.JSONWebKey, err error) {
+func (m *IssuedTokenMutation) OldConnectorID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSigningKeyPub is only allowed on UpdateOne operations")
+		return v, errors.New("OldConnectorID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSigningKeyPub requires an ID field in the mutation")
+		return v, errors.New("OldConnectorID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSigningKeyPub: %w", err)
+		return v, fmt.Errorf("querying old value for OldConnectorID: %w", err)
 	}
-	return oldValue.SigningKeyPub, nil
+	return oldValue.ConnectorID, nil
 }
 
-// ResetSigningKeyPub resets all changes to the "signing_key_pub" field.
-func (m *KeysMutation) ResetSigningKeyPub() {
-	m.signing_key_pub = nil
+// ResetConnectorID resets all changes to the "connector_id" field.
+func (m *IssuedTokenMutation) ResetConnectorID() {
+	m.connector_id = nil
 }
 
-// SetNextRotation sets the "next_rotation" field.
-func (m *KeysMutation) SetNextRotation(t time.Time) {
-	m.next_rotation = &t
+// SetIssuedAt sets the "issued_at" field.
+func (m *IssuedTokenMutation) SetIssuedAt(t time.Time) {
+	m.issued_at = &t
 }
 
-// NextRotation returns the value of the "next_rotation" field in the mutation.
-func (m *KeysMutation) NextRotation() (r time.Time, exists bool) {
-	v := m.next_rotation
+// IssuedAt returns the value of the "issued_at" field in the mutation.
+func (m *IssuedTokenMutation) IssuedAt() (r time.Time, exists bool) {
+	v := m.issued_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNextRotation returns the old "next_rotation" field's value of the Keys entity.
-// If the Keys object wasn't provided to the builder, the object is fetched from the database.
+// OldIssuedAt returns the old "issued_at" field's value of the IssuedToken entity.
+// If the IssuedToken object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *KeysMutation) OldNextRotation(ctx context.Context) (v time.Time, err error) {
+func (m *IssuedTokenMutation) OldIssuedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNextRotation is only allowed on UpdateOne operations")
+		return v, errors.New("OldIssuedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNextRotation requires an ID field in the mutation")
+		return v, errors.New("OldIssuedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNextRotation: %w", err)
+		return v, fmt.Errorf("querying old value for OldIssuedAt: %w", err)
 	}
-	return oldValue.NextRotation, nil
+	return oldValue.IssuedAt, nil
 }
 
-// ResetNextRotation resets all changes to the "next_rotation" field.
-func (m *KeysMutation) ResetNextRotation() {
-	m.next_rotation = nil
+// ResetIssuedAt resets all changes to the "issued_at" field.
+func (m *IssuedTokenMutation) ResetIssuedAt() {
+	m.issued_at = nil
 }
 
-// Where appends a list predicates to the KeysMutation builder.
-func (m *KeysMutation) Where(ps ...predicate.Keys) {
+// SetExpiry sets the "expiry" field.
+func (m *IssuedTokenMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *IssuedTokenMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the IssuedToken entity.
+// If the IssuedToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IssuedTokenMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *IssuedTokenMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// Where appends a list predicates to the IssuedTokenMutation builder.
+func (m *IssuedTokenMutation) Where(ps ...predicate.IssuedToken) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *KeysMutation) Op() Op {
+func (m *IssuedTokenMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Keys).
-func (m *KeysMutation) Type() string {
+// Type returns the node type of this mutation (IssuedToken).
+func (m *IssuedTokenMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *KeysMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.verification_keys != nil {
-		fields = append(fields, keys.FieldVerificationKeys)
+func (m *IssuedTokenMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.client_id != nil {
+		fields = append(fields, issuedtoken.FieldClientID)
 	}
-	if m.signing_key != nil {
-		fields = append(fields, keys.FieldSigningKey)
+	if m.user_id != nil {
+		fields = append(fields, issuedtoken.FieldUserID)
 	}
-	if m.signing_key_pub != nil {
-		fields = append(fields, keys.FieldSigningKeyPub)
+	if m.connector_id != nil {
+		fields = append(fields, issuedtoken.FieldConnectorID)
 	}
-	if m.next_rotation != nil {
-		fields = append(fields, keys.FieldNextRotation)
+	if m.issued_at != nil {
+		fields = append(fields, issuedtoken.FieldIssuedAt)
+	}
+	if m.expiry != nil {
+		fields = append(fields, issuedtoken.FieldExpiry)
 	}
 	return fields
 }
@@ -4571,16 +5657,18 @@ func (m *KeysMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *KeysMutation) Field(name string) (ent.Value, bool) {
+func (m *IssuedTokenMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case keys.FieldVerificationKeys:
-		return m.VerificationKeys()
-	case keys.FieldSigningKey:
-		return m.SigningKey()
-	case keys.FieldSigningKeyPub:
-		return m.SigningKeyPub()
-	case keys.FieldNextRotation:
-		return m.NextRotation()
+	case issuedtoken.FieldClientID:
+		return m.ClientID()
+	case issuedtoken.FieldUserID:
+		return m.UserID()
+	case issuedtoken.FieldConnectorID:
+		return m.ConnectorID()
+	case issuedtoken.FieldIssuedAt:
+		return m.IssuedAt()
+	case issuedtoken.FieldExpiry:
+		return m.Expiry()
 	}
 	return nil, false
 }
@@ -4588,195 +5676,205 @@ func (m *KeysMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *KeysMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *IssuedTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case keys.FieldVerificationKeys:
-		return m.OldVerificationKeys(ctx)
-	case keys.FieldSigningKey:
-		return m.OldSigningKey(ctx)
-	case keys.FieldSigningKeyPub:
-		return m.OldSigningKeyPub(ctx)
-	case keys.FieldNextRotation:
-		return m.OldNextRotation(ctx)
+	case issuedtoken.FieldClientID:
+		return m.OldClientID(ctx)
+	case issuedtoken.FieldUserID:
+		return m.OldUserID(ctx)
+	case issuedtoken.FieldConnectorID:
+		return m.OldConnectorID(ctx)
+	case issuedtoken.FieldIssuedAt:
+		return m.OldIssuedAt(ctx)
+	case issuedtoken.FieldExpiry:
+		return m.OldExpiry(ctx)
 	}
-	return nil, fmt.Errorf("unknown Keys field %s", name)
+	return nil, fmt.Errorf("unknown IssuedToken field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *KeysMutation) SetField(name string, value ent.Value) error {
+func (m *IssuedTokenMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case keys.FieldVerificationKeys:
-		v, ok := value.([]storage.VerificationKey)
+	case issuedtoken.FieldClientID:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetVerificationKeys(v)
+		m.SetClientID(v)
 		return nil
-	case keys.FieldSigningKey:
-		v, ok := value.(jose.JSONWebKey)
+	case issuedtoken.FieldUserID:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSigningKey(v)
+		m.SetUserID(v)
 		return nil
-	case keys.FieldSigningKeyPub:
-		v, ok := value.(jose.JSONWebKey)
+	case issuedtoken.FieldConnectorID:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSigningKeyPub(v)
+		m.SetConnectorID(v)
 		return nil
-	case keys.FieldNextRotation:
+	case issuedtoken.FieldIssuedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetNextRotation(v)
+		m.SetIssuedAt(v)
+		return nil
+	case issuedtoken.FieldExpiry:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiry(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Keys field %s", name)
+	return fmt.Errorf("unknown IssuedToken field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *KeysMutation) AddedFields() []string {
+func (m *IssuedTokenMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *KeysMutation) AddedField(name string) (ent.Value, bool) {
+func (m *IssuedTokenMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *KeysMutation) AddField(name string, value ent.Value) error {
+func (m *IssuedTokenMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Keys numeric field %s", name)
+	return fmt.Errorf("unknown IssuedToken numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *KeysMutation) ClearedFields() []string {
+func (m *IssuedTokenMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *KeysMutation) FieldCleared(name string) bool {
+func (m *IssuedTokenMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *KeysMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Keys nullable field %s", name)
+func (m *IssuedTokenMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown IssuedToken nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *KeysMutation) ResetField(name string) error {
+func (m *IssuedTokenMutation) ResetField(name string) error {
 	switch name {
-	case keys.FieldVerificationKeys:
-		m.ResetVerificationKeys()
+	case issuedtoken.FieldClientID:
+		m.ResetClientID()
 		return nil
-	case keys.FieldSigningKey:
-		m.ResetSigningKey()
+	case issuedtoken.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case keys.FieldSigningKeyPub:
-		m.ResetSigningKeyPub()
+	case issuedtoken.FieldConnectorID:
+		m.ResetConnectorID()
 		return nil
-	case keys.FieldNextRotation:
-		m.ResetNextRotation()
+	case issuedtoken.FieldIssuedAt:
+		m.ResetIssuedAt()
+		return nil
+	case issuedtoken.FieldExpiry:
+		m.ResetExpiry()
 		return nil
 	}
-	return fmt.Errorf("unknown Keys field %s", name)
+	return fmt.Errorf("unknown IssuedToken field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *KeysMutation) AddedEdges() []string {
+func (m *IssuedTokenMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *KeysMutation) AddedIDs(name string) []ent.Value {
+func (m *IssuedTokenMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *KeysMutation) RemovedEdges() []string {
+func (m *IssuedTokenMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *KeysMutation) RemovedIDs(name string) []ent.Value {
+func (m *IssuedTokenMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *KeysMutation) ClearedEdges() []string {
+func (m *IssuedTokenMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *KeysMutation) EdgeCleared(name string) bool {
+func (m *IssuedTokenMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *KeysMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Keys unique edge %s", name)
+func (m *IssuedTokenMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown IssuedToken unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *KeysMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Keys edge %s", name)
+func (m *IssuedTokenMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown IssuedToken edge %s", name)
 }
 
-// OAuth2ClientMutation represents an operation that mutates the OAuth2Client nodes in the graph.
-type OAuth2ClientMutation struct {
+// KeysMutation represents an operation that mutates the Keys nodes in the graph.
+type KeysMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *string
-	secret        *string
-	redirect_uris *[]string
-	trusted_peers *[]string
-	public        *bool
-	name          *string
-	logo_url      *string
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*OAuth2Client, error)
-	predicates    []predicate.OAuth2Client
+	op                Op
+	typ               string
+	id                *string
+	verification_keys *[]storage.VerificationKey
+	signing_key       *jose.JSONWebKey
+	signing_key_pub   *jose.JSONWebKey
+	next_rotation     *time.Time
+	clearedFields     map[string]struct{}
+	done              bool
+	oldValue          func(context.Context) (*Keys, error)
+	predicates        []predicate.Keys
 }
 
-var _ ent.Mutation = (*OAuth2ClientMutation)(nil)
+var _ ent.Mutation = (*KeysMutation)(nil)
 
-// oauth2clientOption allows management of the mutation configuration using functional options.
-type oauth2clientOption func(*OAuth2ClientMutation)
+// keysOption allows management of the mutation configuration using functional options.
+type keysOption func(*KeysMutation)
 
-// newOAuth2ClientMutation creates new mutation for the OAuth2Client entity.
-func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth2ClientMutation {
-	m := &OAuth2ClientMutation{
+// newKeysMutation creates new mutation for the Keys entity.
+func newKeysMutation(c config, op Op, opts ...keysOption) *KeysMutation {
+	m := &KeysMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeOAuth2Client,
+		typ:           TypeKeys,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4785,20 +5883,20 @@ func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth
 	return m
 }
 
-// withOAuth2ClientID sets the ID field of the mutation.
-func withOAuth2ClientID(id string) oauth2clientOption {
-	return func(m *OAuth2ClientMutation) {
+// withKeysID sets the ID field of the mutation.
+func withKeysID(id string) keysOption {
+	return func(m *KeysMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *OAuth2Client
+			value *Keys
 		)
-		m.oldValue = func(ctx context.Context) (*OAuth2Client, error) {
+		m.oldValue = func(ctx context.Context) (*Keys, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().OAuth2Client.Get(ctx, id)
+					value, err = m.Client().Keys.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4807,10 +5905,10 @@ func withOAuth2ClientID(id string) oauth2clientOption {
 	}
 }
 
-// withOAuth2Client sets the old OAuth2Client of the mutation.
-func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
-	return func(m *OAuth2ClientMutation) {
-		m.oldValue = func(context.Context) (*OAuth2Client, error) {
+// withKeys sets the old Keys of the mutation.
+func withKeys(node *Keys) keysOption {
+	return func(m *KeysMutation) {
+		m.oldValue = func(context.Context) (*Keys, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4819,7 +5917,7 @@ func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m OAuth2ClientMutation) Client() *Client {
+func (m KeysMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4827,7 +5925,7 @@ func (m OAuth2ClientMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m OAuth2ClientMutation) Tx() (*Tx, error) {
+func (m KeysMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -4837,14 +5935,14 @@ func (m OAuth2ClientMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of OAuth2Client entities.
-func (m *OAuth2ClientMutation) SetID(id string) {
+// operation is only accepted on creation of Keys entities.
+func (m *KeysMutation) SetID(id string) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *OAuth2ClientMutation) ID() (id string, exists bool) {
+func (m *KeysMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -4855,7 +5953,7 @@ func (m *OAuth2ClientMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *OAuth2ClientMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *KeysMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -4864,291 +5962,187 @@ func (m *OAuth2ClientMutation) IDs(ctx context.Context) ([]string, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().OAuth2Client.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Keys.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetSecret sets the "secret" field.
-func (m *OAuth2ClientMutation) SetSecret(s string) {
-	m.secret = &s
+// SetVerificationKeys sets the "verification_keys" field.
+func (m *KeysMutation) SetVerificationKeys(sk []storage.VerificationKey) {
+	m.verification_keys = &sk
 }
 
-// Secret returns the value of the "secret" field in the mutation.
-func (m *OAuth2ClientMutation) Secret() (r string, exists bool) {
-	v := m.secret
+// VerificationKeys returns the value of the "verification_keys" field in the mutation.
+func (m *KeysMutation) VerificationKeys() (r []storage.VerificationKey, exists bool) {
+	v := m.verification_keys
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSecret returns the old "secret" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// OldVerificationKeys returns the old "verification_keys" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldSecret(ctx context.Context) (v string, err error) {
+func (m *KeysMutation) OldVerificationKeys(ctx context.Context) (v []storage.VerificationKey, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSecret is only allowed on UpdateOne operations")
+		return v, errors.New("OldVerificationKeys is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSecret requires an ID field in the mutation")
+		return v, errors.New("OldVerificationKeys requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSecret: %w", err)
+		return v, fmt.Errorf("querying old value for OldVerificationKeys: %w", err)
 	}
-	return oldValue.Secret, nil
+	return oldValue.VerificationKeys, nil
 }
 
-// ResetSecret resets all changes to the "secret" field.
-func (m *OAuth2ClientMutation) ResetSecret() {
-	m.secret = nil
+// ResetVerificationKeys resets all changes to the "verification_keys" field.
+func (m *KeysMutation) ResetVerificationKeys() {
+	m.verification_keys = nil
 }
 
-// SetRedirectUris sets the "redirect_uris" field.
-func (m *OAuth2ClientMutation) SetRedirectUris(s []string) {
-	m.redirect_uris = &s
+// SetSigningKey sets the "signing_key" field.
+func (m *KeysMutation) SetSigningKey(jwk jose.JSONWebKey) {
+	m.signing_key = &jwk
 }
 
-// RedirectUris returns the value of the "redirect_uris" field in the mutation.
-func (m *OAuth2ClientMutation) RedirectUris() (r []string, exists bool) {
-	v := m.redirect_uris
+// SigningKey returns the value of the "signing_key" field in the mutation.
+func (m *KeysMutation) SigningKey() (r jose.JSONWebKey, exists bool) {
+	v := m.signing_key
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRedirectUris returns the old "redirect_uris" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// OldSigningKey returns the old "signing_key" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldRedirectUris(ctx context.Context) (v []string, err error) {
+func (m *KeysMutation) OldSigningKey(ctx context.Context) (v jose.JSONWebKey, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRedirectUris is only allowed on UpdateOne operations")
+		return v, errors.New("OldSigningKey is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRedirectUris requires an ID field in the mutation")
+		return v, errors.New("OldSigningKey requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRedirectUris: %w", err)
+		return v, fmt.Errorf("querying old value for OldSigningKey: %w", err)
 	}
-	return oldValue.RedirectUris, nil
-}
-
-// ClearRedirectUris clears the value of the "redirect_uris" field.
-func (m *OAuth2ClientMutation) ClearRedirectUris() {
-	m.redirect_uris = nil
-	m.clearedFields[oauth2client.FieldRedirectUris] = struct{}{}
-}
-
-// RedirectUrisCleared returns if the "redirect_uris" field was cleared in this mutation.
-func (m *OAuth2ClientMutation) RedirectUrisCleared() bool {
-	_, ok := m.clearedFields[oauth2client.FieldRedirectUris]
-	return ok
+	return oldValue.SigningKey, nil
 }
 
-// ResetRedirectUris resets all changes to the "redirect_uris" field.
-func (m *OAuth2ClientMutation) ResetRedirectUris() {
-	m.redirect_uris = nil
-	delete(m.clearedFields, oauth2client.FieldRedirectUris)
+// ResetSigningKey resets all changes to the "signing_key" field.
+func (m *KeysMutation) ResetSigningKey() {
+	m.signing_key = nil
 }
 
-// SetTrustedPeers sets the "trusted_peers" field.
-func (m *OAuth2ClientMutation) SetTrustedPeers(s []string) {
-	m.trusted_peers = &s
+// SetSigningKeyPub sets the "signing_key_pub" field.
+func (m *KeysMutation) SetSigningKeyPub(jwk jose.JSONWebKey) {
+	m.signing_key_pub = &jwk
 }
 
-// TrustedPeers returns the value of the "trusted_peers" field in the mutation.
-func (m *OAuth2ClientMutation) TrustedPeers() (r []string, exists bool) {
-	v := m.trusted_peers
+// SigningKeyPub returns the value of the "signing_key_pub" field in the mutation.
+func (m *KeysMutation) SigningKeyPub() (r jose.JSONWebKey, exists bool) {
+	v := m.signing_key_pub
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTrustedPeers returns the old "trusted_peers" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// OldSigningKeyPub returns the old "signing_key_pub" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldTrustedPeers(ctx context.Context) (v []string, err error) {
+func (m *KeysMutation) OldSigningKeyPub(ctx context.Context) (v jose.JSONWebKey, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTrustedPeers is only allowed on UpdateOne operations")
+		return v, errors.New("OldSigningKeyPub is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTrustedPeers requires an ID field in the mutation")
+		return v, errors.New("OldSigningKeyPub requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTrustedPeers: %w", err)
+		return v, fmt.Errorf("querying old value for OldSigningKeyPub: %w", err)
 	}
-	return oldValue.TrustedPeers, nil
-}
-
-// ClearTrustedPeers clears the value of the "trusted_peers" field.
-func (m *OAuth2ClientMutation) ClearTrustedPeers() {
-	m.trusted_peers = nil
-	m.clearedFields[oauth2client.FieldTrustedPeers] = struct{}{}
-}
-
-// TrustedPeersCleared returns if the "trusted_peers" field was cleared in this mutation.
-func (m *OAuth2ClientMutation) TrustedPeersCleared() bool {
-	_, ok := m.clearedFields[oauth2client.FieldTrustedPeers]
-	return ok
+	return oldValue.SigningKeyPub, nil
 }
 
-// ResetTrustedPeers resets all changes to the "trusted_peers" field.
-func (m *OAuth2ClientMutation) ResetTrustedPeers() {
-	m.trusted_peers = nil
-	delete(m.clearedFields, oauth2client.FieldTrustedPeers)
+// ResetSigningKeyPub resets all changes to the "signing_key_pub" field.
+func (m *KeysMutation) ResetSigningKeyPub() {
+	m.signing_key_pub = nil
 }
 
-// SetPublic sets the "public" field.
-func (m *OAuth2ClientMutation) SetPublic(b bool) {
-	m.public = &b
+// SetNextRotation sets the "next_rotation" field.
+func (m *KeysMutation) SetNextRotation(t time.Time) {
+	m.next_rotation = &t
 }
 
-// Public returns the value of the "public" field in the mutation.
-func (m *OAuth2ClientMutation) Public() (r bool, exists bool) {
-	v := m.public
+// NextRotation returns the value of the "next_rotation" field in the mutation.
+func (m *KeysMutation) NextRotation() (r time.Time, exists bool) {
+	v := m.next_rotation
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPublic returns the old "public" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// OldNextRotation returns the old "next_rotation" field's value of the Keys entity.
+// If the Keys object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldPublic(ctx context.Context) (v bool, err error) {
+func (m *KeysMutation) OldNextRotation(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPublic is only allowed on UpdateOne operations")
+		return v, errors.New("OldNextRotation is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPublic requires an ID field in the mutation")
+		return v, errors.New("OldNextRotation requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPublic: %w", err)
+		return v, fmt.Errorf("querying old value for OldNextRotation: %w", err)
 	}
-	return oldValue.Public, nil
+	return oldValue.NextRotation, nil
 }
 
-// ResetPublic resets all changes to the "public" field.
-func (m *OAuth2ClientMutation) ResetPublic() {
-	m.public = nil
+// ResetNextRotation resets all changes to the "next_rotation" field.
+func (m *KeysMutation) ResetNextRotation() {
+	m.next_rotation = nil
 }
 
-// SetName sets the "name" field.
-func (m *OAuth2ClientMutation) SetName(s string) {
-	m.name = &s
+// Where appends a list predicates to the KeysMutation builder.
+func (m *KeysMutation) Where(ps ...predicate.Keys) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *OAuth2ClientMutation) Name() (r string, exists bool) {
-	v := m.name
-	if v == nil {
-		return
-	}
-	return *v, true
+// Op returns the operation name.
+func (m *KeysMutation) Op() Op {
+	return m.op
 }
 
-// OldName returns the old "name" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
-	}
-	return oldValue.Name, nil
-}
-
-// ResetName resets all changes to the "name" field.
-func (m *OAuth2ClientMutation) ResetName() {
-	m.name = nil
-}
-
-// SetLogoURL sets the "logo_url" field.
-func (m *OAuth2ClientMutation) SetLogoURL(s string) {
-	m.logo_url = &s
-}
-
-// LogoURL returns the value of the "logo_url" field in the mutation.
-func (m *OAuth2ClientMutation) LogoURL() (r string, exists bool) {
-	v := m.logo_url
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldLogoURL returns the old "logo_url" field's value of the OAuth2Client entity.
-// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OAuth2ClientMutation) OldLogoURL(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLogoURL is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLogoURL requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLogoURL: %w", err)
-	}
-	return oldValue.LogoURL, nil
-}
-
-// ResetLogoURL resets all changes to the "logo_url" field.
-func (m *OAuth2ClientMutation) ResetLogoURL() {
-	m.logo_url = nil
-}
-
-// Where appends a list predicates to the OAuth2ClientMutation builder.
-func (m *OAuth2ClientMutation) Where(ps ...predicate.OAuth2Client) {
-	m.predicates = append(m.predicates, ps...)
-}
-
-// Op returns the operation name.
-func (m *OAuth2ClientMutation) Op() Op {
-	return m.op
-}
-
-// Type returns the node type of this mutation (OAuth2Client).
-func (m *OAuth2ClientMutation) Type() string {
+// Type returns the node type of this mutation (Keys).
+func (m *KeysMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *OAuth2ClientMutation) Fields() []string {
-	fields := make([]string, 0, 6)
-	if m.secret != nil {
-		fields = append(fields, oauth2client.FieldSecret)
-	}
-	if m.redirect_uris != nil {
-		fields = append(fields, oauth2client.FieldRedirectUris)
-	}
-	if m.trusted_peers != nil {
-		fields = append(fields, oauth2client.FieldTrustedPeers)
+func (m *KeysMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.verification_keys != nil {
+		fields = append(fields, keys.FieldVerificationKeys)
 	}
-	if m.public != nil {
-		fields = append(fields, oauth2client.FieldPublic)
+	if m.signing_key != nil {
+		fields = append(fields, keys.FieldSigningKey)
 	}
-	if m.name != nil {
-		fields = append(fields, oauth2client.FieldName)
+	if m.signing_key_pub != nil {
+		fields = append(fields, keys.FieldSigningKeyPub)
 	}
-	if m.logo_url != nil {
-		fields = append(fields, oauth2client.FieldLogoURL)
+	if m.next_rotation != nil {
+		fields = append(fields, keys.FieldNextRotation)
 	}
 	return fields
 }
@@ -5156,20 +6150,16 @@ func (m *OAuth2ClientMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *OAuth2ClientMutation) Field(name string) (ent.Value, bool) {
+func (m *KeysMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case oauth2client.FieldSecret:
-		return m.Secret()
-	case oauth2client.FieldRedirectUris:
-		return m.RedirectUris()
-	case oauth2client.FieldTrustedPeers:
-		return m.TrustedPeers()
-	case oauth2client.FieldPublic:
-		return m.Public()
-	case oauth2client.FieldName:
-		return m.Name()
-	case oauth2client.FieldLogoURL:
-		return m.LogoURL()
+	case keys.FieldVerificationKeys:
+		return m.VerificationKeys()
+	case keys.FieldSigningKey:
+		return m.SigningKey()
+	case keys.FieldSigningKeyPub:
+		return m.SigningKeyPub()
+	case keys.FieldNextRotation:
+		return m.NextRotation()
 	}
 	return nil, false
 }
@@ -5177,232 +6167,204 @@ func (m *OAuth2ClientMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *OAuth2ClientMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *KeysMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case oauth2client.FieldSecret:
-		return m.OldSecret(ctx)
-	case oauth2client.FieldRedirectUris:
-		return m.OldRedirectUris(ctx)
-	case oauth2client.FieldTrustedPeers:
-		return m.OldTrustedPeers(ctx)
-	case oauth2client.FieldPublic:
-		return m.OldPublic(ctx)
-	case oauth2client.FieldName:
-		return m.OldName(ctx)
-	case oauth2client.FieldLogoURL:
-		return m.OldLogoURL(ctx)
+	case keys.FieldVerificationKeys:
+		return m.OldVerificationKeys(ctx)
+	case keys.FieldSigningKey:
+		return m.OldSigningKey(ctx)
+	case keys.FieldSigningKeyPub:
+		return m.OldSigningKeyPub(ctx)
+	case keys.FieldNextRotation:
+		return m.OldNextRotation(ctx)
 	}
-	return nil, fmt.Errorf("unknown OAuth2Client field %s", name)
+	return nil, fmt.Errorf("unknown Keys field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *OAuth2ClientMutation) SetField(name string, value ent.Value) error {
+func (m *KeysMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case oauth2client.FieldSecret:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSecret(v)
-		return nil
-	case oauth2client.FieldRedirectUris:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRedirectUris(v)
-		return nil
-	case oauth2client.FieldTrustedPeers:
-		v, ok := value.([]string)
+	case keys.FieldVerificationKeys:
+		v, ok := value.([]storage.VerificationKey)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetTrustedPeers(v)
+		m.SetVerificationKeys(v)
 		return nil
-	case oauth2client.FieldPublic:
-		v, ok := value.(bool)
+	case keys.FieldSigningKey:
+		v, ok := value.(jose.JSONWebKey)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPublic(v)
+		m.SetSigningKey(v)
 		return nil
-	case oauth2client.FieldName:
-		v, ok := value.(string)
+	case keys.FieldSigningKeyPub:
+		v, ok := value.(jose.JSONWebKey)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetSigningKeyPub(v)
 		return nil
-	case oauth2client.FieldLogoURL:
-		v, ok := value.(string)
+	case keys.FieldNextRotation:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLogoURL(v)
+		m.SetNextRotation(v)
 		return nil
 	}
-	return fmt.Errorf("unknown OAuth2Client field %s", name)
+	return fmt.Errorf("unknown Keys field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *OAuth2ClientMutation) AddedFields() []string {
+func (m *KeysMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *OAuth2ClientMutation) AddedField(name string) (ent.Value, bool) {
+func (m *KeysMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *OAuth2ClientMutation) AddField(name string, value ent.Value) error {
+func (m *KeysMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown OAuth2Client numeric field %s", name)
+	return fmt.Errorf("unknown Keys numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *OAuth2ClientMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(oauth2client.FieldRedirectUris) {
-		fields = append(fields, oauth2client.FieldRedirectUris)
-	}
-	if m.FieldCleared(oauth2client.FieldTrustedPeers) {
-		fields = append(fields, oauth2client.FieldTrustedPeers)
-	}
-	return fields
+func (m *KeysMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *OAuth2ClientMutation) FieldCleared(name string) bool {
+func (m *KeysMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *OAuth2ClientMutation) ClearField(name string) error {
-	switch name {
-	case oauth2client.FieldRedirectUris:
-		m.ClearRedirectUris()
-		return nil
-	case oauth2client.FieldTrustedPeers:
-		m.ClearTrustedPeers()
-		return nil
-	}
-	return fmt.Errorf("unknown OAuth2Client nullable field %s", name)
+func (m *KeysMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Keys nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *OAuth2ClientMutation) ResetField(name string) error {
+func (m *KeysMutation) ResetField(name string) error {
 	switch name {
-	case oauth2client.FieldSecret:
-		m.ResetSecret()
-		return nil
-	case oauth2client.FieldRedirectUris:
-		m.ResetRedirectUris()
-		return nil
-	case oauth2client.FieldTrustedPeers:
-		m.ResetTrustedPeers()
+	case keys.FieldVerificationKeys:
+		m.ResetVerificationKeys()
 		return nil
-	case oauth2client.FieldPublic:
-		m.ResetPublic()
+	case keys.FieldSigningKey:
+		m.ResetSigningKey()
 		return nil
-	case oauth2client.FieldName:
-		m.ResetName()
+	case keys.FieldSigningKeyPub:
+		m.ResetSigningKeyPub()
 		return nil
-	case oauth2client.FieldLogoURL:
-		m.ResetLogoURL()
+	case keys.FieldNextRotation:
+		m.ResetNextRotation()
 		return nil
 	}
-	return fmt.Errorf("unknown OAuth2Client field %s", name)
+	return fmt.Errorf("unknown Keys field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *OAuth2ClientMutation) AddedEdges() []string {
+func (m *KeysMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *OAuth2ClientMutation) AddedIDs(name string) []ent.Value {
+func (m *KeysMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *OAuth2ClientMutation) RemovedEdges() []string {
+func (m *KeysMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *OAuth2ClientMutation) RemovedIDs(name string) []ent.Value {
+func (m *KeysMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *OAuth2ClientMutation) ClearedEdges() []string {
+func (m *KeysMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *OAuth2ClientMutation) EdgeCleared(name string) bool {
+func (m *KeysMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *OAuth2ClientMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown OAuth2Client unique edge %s", name)
+func (m *KeysMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Keys unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *OAuth2ClientMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown OAuth2Client edge %s", name)
+func (m *KeysMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Keys edge %s", name)
 }
 
-// OfflineSessionMutation represents an operation that mutates the OfflineSession nodes in the graph.
-type OfflineSessionMutation struct {
+// OAuth2ClientMutation represents an operation that mutates the OAuth2Client nodes in the graph.
+type OAuth2ClientMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *string
-	user_id        *string
-	conn_id        *string
-	refresh        *[]byte
-	connector_data *[]byte
-	clearedFields  map[string]struct{}
-	done           bool
-	oldValue       func(context.Context) (*OfflineSession, error)
-	predicates     []predicate.OfflineSession
+	op                        Op
+	typ                       string
+	id                        *string
+	secret                    *string
+	redirect_uris             *[]string
+	trusted_peers             *[]string
+	resources                 *[]string
+	public                    *bool
+	name                      *string
+	logo_url                  *string
+	hash_groups               *bool
+	groups_salt               *string
+	require_verified_email    *bool
+	redirect_uri_path_pattern *string
+	consent_text              *string
+	privacy_policy_url        *string
+	allowed_group_prefixes    *[]string
+	allowed_groups_pattern    *string
+	clearedFields             map[string]struct{}
+	done                      bool
+	oldValue                  func(context.Context) (*OAuth2Client, error)
+	predicates                []predicate.OAuth2Client
 }
 
-var _ ent.Mutation = (*OfflineSessionMutation)(nil)
+var _ ent.Mutation = (*OAuth2ClientMutation)(nil)
 
-// offlinesessionOption allows management of the mutation configuration using functional options.
-type offlinesessionOption func(*OfflineSessionMutation)
+// oauth2clientOption allows management of the mutation configuration using functional options.
+type oauth2clientOption func(*OAuth2ClientMutation)
 
-// newOfflineSessionMutation creates new mutation for the OfflineSession entity.
-func newOfflineSessionMutation(c config, op Op, opts ...offlinesessionOption) *OfflineSessionMutation {
-	m := &OfflineSessionMutation{
+// newOAuth2ClientMutation creates new mutation for the OAuth2Client entity.
+func newOAuth2ClientMutation(c config, op Op, opts ...oauth2clientOption) *OAuth2ClientMutation {
+	m := &OAuth2ClientMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeOfflineSession,
+		typ:           TypeOAuth2Client,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -5411,20 +6373,20 @@ func newOfflineSessionMutation(c config, op Op, opts ...offlinesessionOption) *O
 	return m
 }
 
-// withOfflineSessionID sets the ID field of the mutation.
-func withOfflineSessionID(id string) offlinesessionOption {
-	return func(m *OfflineSessionMutation) {
+// withOAuth2ClientID sets the ID field of the mutation.
+func withOAuth2ClientID(id string) oauth2clientOption {
+	return func(m *OAuth2ClientMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *OfflineSession
+			value *OAuth2Client
 		)
-		m.oldValue = func(ctx context.Context) (*OfflineSession, error) {
+		m.oldValue = func(ctx context.Context) (*OAuth2Client, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().OfflineSession.Get(ctx, id)
+					value, err = m.Client().OAuth2Client.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -5433,10 +6395,10 @@ func withOfflineSessionID(id string) offlinesessionOption {
 	}
 }
 
-// withOfflineSession sets the old OfflineSession of the mutation.
-func withOfflineSession(node *OfflineSession) offlinesessionOption {
-	return func(m *OfflineSessionMutation) {
-		m.oldValue = func(context.Context) (*OfflineSession, error) {
+// withOAuth2Client sets the old OAuth2Client of the mutation.
+func withOAuth2Client(node *OAuth2Client) oauth2clientOption {
+	return func(m *OAuth2ClientMutation) {
+		m.oldValue = func(context.Context) (*OAuth2Client, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -5445,7 +6407,7 @@ func withOfflineSession(node *OfflineSession) offlinesessionOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m OfflineSessionMutation) Client() *Client {
+func (m OAuth2ClientMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -5453,7 +6415,7 @@ func (m OfflineSessionMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m OfflineSessionMutation) Tx() (*Tx, error) {
+func (m OAuth2ClientMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -5463,14 +6425,14 @@ func (m OfflineSessionMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of OfflineSession entities.
-func (m *OfflineSessionMutation) SetID(id string) {
+// operation is only accepted on creation of OAuth2Client entities.
+func (m *OAuth2ClientMutation) SetID(id string) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *OfflineSessionMutation) ID() (id string, exists bool) {
+func (m *OAuth2ClientMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -5481,7 +6443,7 @@ func (m *OfflineSessionMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *OfflineSessionMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *OAuth2ClientMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -5490,682 +6452,668 @@ func (m *OfflineSessionMutation) IDs(ctx context.Context) ([]string, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().OfflineSession.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().OAuth2Client.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUserID sets the "user_id" field.
-func (m *OfflineSessionMutation) SetUserID(s string) {
-	m.user_id = &s
+// SetSecret sets the "secret" field.
+func (m *OAuth2ClientMutation) SetSecret(s string) {
+	m.secret = &s
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *OfflineSessionMutation) UserID() (r string, exists bool) {
-	v := m.user_id
+// Secret returns the value of the "secret" field in the mutation.
+func (m *OAuth2ClientMutation) Secret() (r string, exists bool) {
+	v := m.secret
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the OfflineSession entity.
-// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
+// OldSecret returns the old "secret" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OfflineSessionMutation) OldUserID(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldSecret(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldSecret is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldSecret requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldSecret: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.Secret, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *OfflineSessionMutation) ResetUserID() {
-	m.user_id = nil
+// ResetSecret resets all changes to the "secret" field.
+func (m *OAuth2ClientMutation) ResetSecret() {
+	m.secret = nil
 }
 
-// SetConnID sets the "conn_id" field.
-func (m *OfflineSessionMutation) SetConnID(s string) {
-	m.conn_id = &s
+// SetRedirectUris sets the "redirect_uris" field.
+func (m *OAuth2ClientMutation) SetRedirectUris(s []string) {
+	m.redirect_uris = &s
 }
 
-// ConnID returns the value of the "conn_id" field in the mutation.
-func (m *OfflineSessionMutation) ConnID() (r string, exists bool) {
-	v := m.conn_id
+// RedirectUris returns the value of the "redirect_uris" field in the mutation.
+func (m *OAuth2ClientMutation) RedirectUris() (r []string, exists bool) {
+	v := m.redirect_uris
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConnID returns the old "conn_id" field's value of the OfflineSession entity.
-// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
+// OldRedirectUris returns the old "redirect_uris" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OfflineSessionMutation) OldConnID(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldRedirectUris(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnID is only allowed on UpdateOne operations")
+		return v, errors.New("OldRedirectUris is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnID requires an ID field in the mutation")
+		return v, errors.New("OldRedirectUris requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnID: %w", err)
+		return v, fmt.Errorf("querying old value for OldRedirectUris: %w", err)
 	}
-	return oldValue.ConnID, nil
+	return oldValue.RedirectUris, nil
 }
 
-// ResetConnID resets all changes to the "conn_id" field.
-func (m *OfflineSessionMutation) ResetConnID() {
-	m.conn_id = nil
+// ClearRedirectUris clears the value of the "redirect_uris" field.
+func (m *OAuth2ClientMutation) ClearRedirectUris() {
+	m.redirect_uris = nil
+	m.clearedFields[oauth2client.FieldRedirectUris] = struct{}{}
 }
 
-// SetRefresh sets the "refresh" field.
-func (m *OfflineSessionMutation) SetRefresh(b []byte) {
-	m.refresh = &b
+// RedirectUrisCleared returns if the "redirect_uris" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) RedirectUrisCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldRedirectUris]
+	return ok
 }
 
-// Refresh returns the value of the "refresh" field in the mutation.
-func (m *OfflineSessionMutation) Refresh() (r []byte, exists bool) {
-	v := m.refresh
+// ResetRedirectUris resets all changes to the "redirect_uris" field.
+func (m *OAuth2ClientMutation) ResetRedirectUris() {
+	m.redirect_uris = nil
+	delete(m.clearedFields, oauth2client.FieldRedirectUris)
+}
+
+// SetTrustedPeers sets the "trusted_peers" field.
+func (m *OAuth2ClientMutation) SetTrustedPeers(s []string) {
+	m.trusted_peers = &s
+}
+
+// TrustedPeers returns the value of the "trusted_peers" field in the mutation.
+func (m *OAuth2ClientMutation) TrustedPeers() (r []string, exists bool) {
+	v := m.trusted_peers
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRefresh returns the old "refresh" field's value of the OfflineSession entity.
-// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
+// OldTrustedPeers returns the old "trusted_peers" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OfflineSessionMutation) OldRefresh(ctx context.Context) (v []byte, err error) {
+func (m *OAuth2ClientMutation) OldTrustedPeers(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRefresh is only allowed on UpdateOne operations")
+		return v, errors.New("OldTrustedPeers is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRefresh requires an ID field in the mutation")
+		return v, errors.New("OldTrustedPeers requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRefresh: %w", err)
+		return v, fmt.Errorf("querying old value for OldTrustedPeers: %w", err)
 	}
-	return oldValue.Refresh, nil
+	return oldValue.TrustedPeers, nil
 }
 
-// ResetRefresh resets all changes to the "refresh" field.
-func (m *OfflineSessionMutation) ResetRefresh() {
-	m.refresh = nil
+// ClearTrustedPeers clears the value of the "trusted_peers" field.
+func (m *OAuth2ClientMutation) ClearTrustedPeers() {
+	m.trusted_peers = nil
+	m.clearedFields[oauth2client.FieldTrustedPeers] = struct{}{}
 }
 
-// SetConnectorData sets the "connector_data" field.
-func (m *OfflineSessionMutation) SetConnectorData(b []byte) {
-	m.connector_data = &b
+// TrustedPeersCleared returns if the "trusted_peers" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) TrustedPeersCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldTrustedPeers]
+	return ok
 }
 
-// ConnectorData returns the value of the "connector_data" field in the mutation.
-func (m *OfflineSessionMutation) ConnectorData() (r []byte, exists bool) {
-	v := m.connector_data
+// ResetTrustedPeers resets all changes to the "trusted_peers" field.
+func (m *OAuth2ClientMutation) ResetTrustedPeers() {
+	m.trusted_peers = nil
+	delete(m.clearedFields, oauth2client.FieldTrustedPeers)
+}
+
+// SetResources sets the "resources" field.
+func (m *OAuth2ClientMutation) SetResources(s []string) {
+	m.resources = &s
+}
+
+// Resources returns the value of the "resources" field in the mutation.
+func (m *OAuth2ClientMutation) Resources() (r []string, exists bool) {
+	v := m.resources
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConnectorData returns the old "connector_data" field's value of the OfflineSession entity.
-// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
+// OldResources returns the old "resources" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *OfflineSessionMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
+func (m *OAuth2ClientMutation) OldResources(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
+		return v, errors.New("OldResources is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnectorData requires an ID field in the mutation")
+		return v, errors.New("OldResources requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
+		return v, fmt.Errorf("querying old value for OldResources: %w", err)
 	}
-	return oldValue.ConnectorData, nil
+	return oldValue.Resources, nil
 }
 
-// ClearConnectorData clears the value of the "connector_data" field.
-func (m *OfflineSessionMutation) ClearConnectorData() {
-	m.connector_data = nil
-	m.clearedFields[offlinesession.FieldConnectorData] = struct{}{}
+// ClearResources clears the value of the "resources" field.
+func (m *OAuth2ClientMutation) ClearResources() {
+	m.resources = nil
+	m.clearedFields[oauth2client.FieldResources] = struct{}{}
 }
 
-// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
-func (m *OfflineSessionMutation) ConnectorDataCleared() bool {
-	_, ok := m.clearedFields[offlinesession.FieldConnectorData]
+// ResourcesCleared returns if the "resources" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) ResourcesCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldResources]
 	return ok
 }
 
-// ResetConnectorData resets all changes to the "connector_data" field.
-func (m *OfflineSessionMutation) ResetConnectorData() {
-	m.connector_data = nil
-	delete(m.clearedFields, offlinesession.FieldConnectorData)
-}
-
-// Where appends a list predicates to the OfflineSessionMutation builder.
-func (m *OfflineSessionMutation) Where(ps ...predicate.OfflineSession) {
-	m.predicates = append(m.predicates, ps...)
+// ResetResources resets all changes to the "resources" field.
+func (m *OAuth2ClientMutation) ResetResources() {
+	m.resources = nil
+	delete(m.clearedFields, oauth2client.FieldResources)
 }
 
-// Op returns the operation name.
-func (m *OfflineSessionMutation) Op() Op {
-	return m.op
+// SetPublic sets the "public" field.
+func (m *OAuth2ClientMutation) SetPublic(b bool) {
+	m.public = &b
 }
 
-// Type returns the node type of this mutation (OfflineSession).
-func (m *OfflineSessionMutation) Type() string {
-	return m.typ
+// Public returns the value of the "public" field in the mutation.
+func (m *OAuth2ClientMutation) Public() (r bool, exists bool) {
+	v := m.public
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *OfflineSessionMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.user_id != nil {
-		fields = append(fields, offlinesession.FieldUserID)
+// OldPublic returns the old "public" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldPublic(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPublic is only allowed on UpdateOne operations")
 	}
-	if m.conn_id != nil {
-		fields = append(fields, offlinesession.FieldConnID)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPublic requires an ID field in the mutation")
 	}
-	if m.refresh != nil {
-		fields = append(fields, offlinesession.FieldRefresh)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPublic: %w", err)
 	}
-	if m.connector_data != nil {
-		fields = append(fields, offlinesession.FieldConnectorData)
+	return oldValue.Public, nil
+}
+
+// ResetPublic resets all changes to the "public" field.
+func (m *OAuth2ClientMutation) ResetPublic() {
+	m.public = nil
+}
+
+// SetName sets the "name" field.
+func (m *OAuth2ClientMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *OAuth2ClientMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
 	}
-	return fields
+	return *v, true
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *OfflineSessionMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case offlinesession.FieldUserID:
-		return m.UserID()
-	case offlinesession.FieldConnID:
-		return m.ConnID()
-	case offlinesession.FieldRefresh:
-		return m.Refresh()
-	case offlinesession.FieldConnectorData:
-		return m.ConnectorData()
+// OldName returns the old "name" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
-	return nil, false
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
 }
 
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *OfflineSessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case offlinesession.FieldUserID:
-		return m.OldUserID(ctx)
-	case offlinesession.FieldConnID:
-		return m.OldConnID(ctx)
-	case offlinesession.FieldRefresh:
-		return m.OldRefresh(ctx)
-	case offlinesession.FieldConnectorData:
-		return m.OldConnectorData(ctx)
+// ResetName resets all changes to the "name" field.
+func (m *OAuth2ClientMutation) ResetName() {
+	m.name = nil
+}
+
+// SetLogoURL sets the "logo_url" field.
+func (m *OAuth2ClientMutation) SetLogoURL(s string) {
+	m.logo_url = &s
+}
+
+// LogoURL returns the value of the "logo_url" field in the mutation.
+func (m *OAuth2ClientMutation) LogoURL() (r string, exists bool) {
+	v := m.logo_url
+	if v == nil {
+		return
 	}
-	return nil, fmt.Errorf("unknown OfflineSession field %s", name)
+	return *v, true
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *OfflineSessionMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case offlinesession.FieldUserID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUserID(v)
-		return nil
-	case offlinesession.FieldConnID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetConnID(v)
-		return nil
-	case offlinesession.FieldRefresh:
-		v, ok := value.([]byte)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRefresh(v)
-		return nil
-	case offlinesession.FieldConnectorData:
-		v, ok := value.([]byte)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetConnectorData(v)
-		return nil
+// OldLogoURL returns the old "logo_url" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldLogoURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLogoURL is only allowed on UpdateOne operations")
 	}
-	return fmt.Errorf("unknown OfflineSession field %s", name)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLogoURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLogoURL: %w", err)
+	}
+	return oldValue.LogoURL, nil
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *OfflineSessionMutation) AddedFields() []string {
-	return nil
+// ResetLogoURL resets all changes to the "logo_url" field.
+func (m *OAuth2ClientMutation) ResetLogoURL() {
+	m.logo_url = nil
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *OfflineSessionMutation) AddedField(name string) (ent.Value, bool) {
-	return nil, false
+// SetHashGroups sets the "hash_groups" field.
+func (m *OAuth2ClientMutation) SetHashGroups(b bool) {
+	m.hash_groups = &b
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *OfflineSessionMutation) AddField(name string, value ent.Value) error {
-	switch name {
+// HashGroups returns the value of the "hash_groups" field in the mutation.
+func (m *OAuth2ClientMutation) HashGroups() (r bool, exists bool) {
+	v := m.hash_groups
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown OfflineSession numeric field %s", name)
+	return *v, true
 }
 
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *OfflineSessionMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(offlinesession.FieldConnectorData) {
-		fields = append(fields, offlinesession.FieldConnectorData)
+// OldHashGroups returns the old "hash_groups" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldHashGroups(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHashGroups is only allowed on UpdateOne operations")
 	}
-	return fields
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHashGroups requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHashGroups: %w", err)
+	}
+	return oldValue.HashGroups, nil
 }
 
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *OfflineSessionMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
-	return ok
+// ResetHashGroups resets all changes to the "hash_groups" field.
+func (m *OAuth2ClientMutation) ResetHashGroups() {
+	m.hash_groups = nil
 }
 
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *OfflineSessionMutation) ClearField(name string) error {
-	switch name {
-	case offlinesession.FieldConnectorData:
-		m.ClearConnectorData()
-		return nil
-	}
-	return fmt.Errorf("unknown OfflineSession nullable field %s", name)
+// SetGroupsSalt sets the "groups_salt" field.
+func (m *OAuth2ClientMutation) SetGroupsSalt(s string) {
+	m.groups_salt = &s
 }
 
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *OfflineSessionMutation) ResetField(name string) error {
-	switch name {
-	case offlinesession.FieldUserID:
-		m.ResetUserID()
-		return nil
-	case offlinesession.FieldConnID:
-		m.ResetConnID()
-		return nil
-	case offlinesession.FieldRefresh:
-		m.ResetRefresh()
-		return nil
-	case offlinesession.FieldConnectorData:
-		m.ResetConnectorData()
-		return nil
+// GroupsSalt returns the value of the "groups_salt" field in the mutation.
+func (m *OAuth2ClientMutation) GroupsSalt() (r string, exists bool) {
+	v := m.groups_salt
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown OfflineSession field %s", name)
+	return *v, true
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *OfflineSessionMutation) AddedEdges() []string {
-	edges := make([]string, 0, 0)
-	return edges
+// OldGroupsSalt returns the old "groups_salt" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldGroupsSalt(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGroupsSalt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGroupsSalt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGroupsSalt: %w", err)
+	}
+	return oldValue.GroupsSalt, nil
 }
 
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *OfflineSessionMutation) AddedIDs(name string) []ent.Value {
-	return nil
+// ResetGroupsSalt resets all changes to the "groups_salt" field.
+func (m *OAuth2ClientMutation) ResetGroupsSalt() {
+	m.groups_salt = nil
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *OfflineSessionMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 0)
-	return edges
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (m *OAuth2ClientMutation) SetRequireVerifiedEmail(b bool) {
+	m.require_verified_email = &b
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *OfflineSessionMutation) RemovedIDs(name string) []ent.Value {
-	return nil
+// RequireVerifiedEmail returns the value of the "require_verified_email" field in the mutation.
+func (m *OAuth2ClientMutation) RequireVerifiedEmail() (r bool, exists bool) {
+	v := m.require_verified_email
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *OfflineSessionMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 0)
-	return edges
+// OldRequireVerifiedEmail returns the old "require_verified_email" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldRequireVerifiedEmail(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequireVerifiedEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequireVerifiedEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequireVerifiedEmail: %w", err)
+	}
+	return oldValue.RequireVerifiedEmail, nil
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *OfflineSessionMutation) EdgeCleared(name string) bool {
-	return false
+// ResetRequireVerifiedEmail resets all changes to the "require_verified_email" field.
+func (m *OAuth2ClientMutation) ResetRequireVerifiedEmail() {
+	m.require_verified_email = nil
 }
 
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *OfflineSessionMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown OfflineSession unique edge %s", name)
+// SetRedirectURIPathPattern sets the "redirect_uri_path_pattern" field.
+func (m *OAuth2ClientMutation) SetRedirectURIPathPattern(s string) {
+	m.redirect_uri_path_pattern = &s
 }
 
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *OfflineSessionMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown OfflineSession edge %s", name)
+// RedirectURIPathPattern returns the value of the "redirect_uri_path_pattern" field in the mutation.
+func (m *OAuth2ClientMutation) RedirectURIPathPattern() (r string, exists bool) {
+	v := m.redirect_uri_path_pattern
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// PasswordMutation represents an operation that mutates the Password nodes in the graph.
-type PasswordMutation struct {
-	config
-	op            Op
-	typ           string
-	id            *int
-	email         *string
-	hash          *[]byte
-	username      *string
-	user_id       *string
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*Password, error)
-	predicates    []predicate.Password
-}
-
-var _ ent.Mutation = (*PasswordMutation)(nil)
-
-// passwordOption allows management of the mutation configuration using functional options.
-type passwordOption func(*PasswordMutation)
-
-// newPasswordMutation creates new mutation for the Password entity.
-func newPasswordMutation(c config, op Op, opts ...passwordOption) *PasswordMutation {
-	m := &PasswordMutation{
-		config:        c,
-		op:            op,
-		typ:           TypePassword,
-		clearedFields: make(map[string]struct{}),
-	}
-	for _, opt := range opts {
-		opt(m)
-	}
-	return m
-}
-
-// withPasswordID sets the ID field of the mutation.
-func withPasswordID(id int) passwordOption {
-	return func(m *PasswordMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *Password
-		)
-		m.oldValue = func(ctx context.Context) (*Password, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().Password.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
-	}
-}
-
-// withPassword sets the old Password of the mutation.
-func withPassword(node *Password) passwordOption {
-	return func(m *PasswordMutation) {
-		m.oldValue = func(context.Context) (*Password, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+// OldRedirectURIPathPattern returns the old "redirect_uri_path_pattern" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *OAuth2ClientMutation) OldRedirectURIPathPattern(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRedirectURIPathPattern is only allowed on UpdateOne operations")
 	}
-}
-
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PasswordMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
-}
-
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m PasswordMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("db: mutation is not running in a transaction")
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRedirectURIPathPattern requires an ID field in the mutation")
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
-}
-
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *PasswordMutation) ID() (id int, exists bool) {
-	if m.id == nil {
-		return
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRedirectURIPathPattern: %w", err)
 	}
-	return *m.id, true
+	return oldValue.RedirectURIPathPattern, nil
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *PasswordMutation) IDs(ctx context.Context) ([]int, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Password.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
+// ResetRedirectURIPathPattern resets all changes to the "redirect_uri_path_pattern" field.
+func (m *OAuth2ClientMutation) ResetRedirectURIPathPattern() {
+	m.redirect_uri_path_pattern = nil
 }
 
-// SetEmail sets the "email" field.
-func (m *PasswordMutation) SetEmail(s string) {
-	m.email = &s
+// SetConsentText sets the "consent_text" field.
+func (m *OAuth2ClientMutation) SetConsentText(s string) {
+	m.consent_text = &s
 }
 
-// Email returns the value of the "email" field in the mutation.
-func (m *PasswordMutation) Email() (r string, exists bool) {
-	v := m.email
+// ConsentText returns the value of the "consent_text" field in the mutation.
+func (m *OAuth2ClientMutation) ConsentText() (r string, exists bool) {
+	v := m.consent_text
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldEmail returns the old "email" field's value of the Password entity.
-// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// OldConsentText returns the old "consent_text" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PasswordMutation) OldEmail(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldConsentText(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+		return v, errors.New("OldConsentText is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldEmail requires an ID field in the mutation")
+		return v, errors.New("OldConsentText requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+		return v, fmt.Errorf("querying old value for OldConsentText: %w", err)
 	}
-	return oldValue.Email, nil
+	return oldValue.ConsentText, nil
 }
 
-// ResetEmail resets all changes to the "email" field.
-func (m *PasswordMutation) ResetEmail() {
-	m.email = nil
+// ResetConsentText resets all changes to the "consent_text" field.
+func (m *OAuth2ClientMutation) ResetConsentText() {
+	m.consent_text = nil
 }
 
-// SetHash sets the "hash" field.
-func (m *PasswordMutation) SetHash(b []byte) {
-	m.hash = &b
+// SetPrivacyPolicyURL sets the "privacy_policy_url" field.
+func (m *OAuth2ClientMutation) SetPrivacyPolicyURL(s string) {
+	m.privacy_policy_url = &s
 }
 
-// Hash returns the value of the "hash" field in the mutation.
-func (m *PasswordMutation) Hash() (r []byte, exists bool) {
-	v := m.hash
+// PrivacyPolicyURL returns the value of the "privacy_policy_url" field in the mutation.
+func (m *OAuth2ClientMutation) PrivacyPolicyURL() (r string, exists bool) {
+	v := m.privacy_policy_url
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldHash returns the old "hash" field's value of the Password entity.
-// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// OldPrivacyPolicyURL returns the old "privacy_policy_url" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PasswordMutation) OldHash(ctx context.Context) (v []byte, err error) {
+func (m *OAuth2ClientMutation) OldPrivacyPolicyURL(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+		return v, errors.New("OldPrivacyPolicyURL is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldHash requires an ID field in the mutation")
+		return v, errors.New("OldPrivacyPolicyURL requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+		return v, fmt.Errorf("querying old value for OldPrivacyPolicyURL: %w", err)
 	}
-	return oldValue.Hash, nil
+	return oldValue.PrivacyPolicyURL, nil
 }
 
-// ResetHash resets all changes to the "hash" field.
-func (m *PasswordMutation) ResetHash() {
-	m.hash = nil
+// ResetPrivacyPolicyURL resets all changes to the "privacy_policy_url" field.
+func (m *OAuth2ClientMutation) ResetPrivacyPolicyURL() {
+	m.privacy_policy_url = nil
 }
 
-// SetUsername sets the "username" field.
-func (m *PasswordMutation) SetUsername(s string) {
-	m.username = &s
+// SetAllowedGroupPrefixes sets the "allowed_group_prefixes" field.
+func (m *OAuth2ClientMutation) SetAllowedGroupPrefixes(s []string) {
+	m.allowed_group_prefixes = &s
 }
 
-// Username returns the value of the "username" field in the mutation.
-func (m *PasswordMutation) Username() (r string, exists bool) {
-	v := m.username
+// AllowedGroupPrefixes returns the value of the "allowed_group_prefixes" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedGroupPrefixes() (r []string, exists bool) {
+	v := m.allowed_group_prefixes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUsername returns the old "username" field's value of the Password entity.
-// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// OldAllowedGroupPrefixes returns the old "allowed_group_prefixes" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PasswordMutation) OldUsername(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldAllowedGroupPrefixes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedGroupPrefixes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsername requires an ID field in the mutation")
+		return v, errors.New("OldAllowedGroupPrefixes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedGroupPrefixes: %w", err)
 	}
-	return oldValue.Username, nil
+	return oldValue.AllowedGroupPrefixes, nil
 }
 
-// ResetUsername resets all changes to the "username" field.
-func (m *PasswordMutation) ResetUsername() {
-	m.username = nil
+// ClearAllowedGroupPrefixes clears the value of the "allowed_group_prefixes" field.
+func (m *OAuth2ClientMutation) ClearAllowedGroupPrefixes() {
+	m.allowed_group_prefixes = nil
+	m.clearedFields[oauth2client.FieldAllowedGroupPrefixes] = struct{}{}
 }
 
-// SetUserID sets the "user_id" field.
-func (m *PasswordMutation) SetUserID(s string) {
-	m.user_id = &s
+// AllowedGroupPrefixesCleared returns if the "allowed_group_prefixes" field was cleared in this mutation.
+func (m *OAuth2ClientMutation) AllowedGroupPrefixesCleared() bool {
+	_, ok := m.clearedFields[oauth2client.FieldAllowedGroupPrefixes]
+	return ok
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *PasswordMutation) UserID() (r string, exists bool) {
-	v := m.user_id
+// ResetAllowedGroupPrefixes resets all changes to the "allowed_group_prefixes" field.
+func (m *OAuth2ClientMutation) ResetAllowedGroupPrefixes() {
+	m.allowed_group_prefixes = nil
+	delete(m.clearedFields, oauth2client.FieldAllowedGroupPrefixes)
+}
+
+// SetAllowedGroupsPattern sets the "allowed_groups_pattern" field.
+func (m *OAuth2ClientMutation) SetAllowedGroupsPattern(s string) {
+	m.allowed_groups_pattern = &s
+}
+
+// AllowedGroupsPattern returns the value of the "allowed_groups_pattern" field in the mutation.
+func (m *OAuth2ClientMutation) AllowedGroupsPattern() (r string, exists bool) {
+	v := m.allowed_groups_pattern
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the Password entity.
-// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// OldAllowedGroupsPattern returns the old "allowed_groups_pattern" field's value of the OAuth2Client entity.
+// If the OAuth2Client object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PasswordMutation) OldUserID(ctx context.Context) (v string, err error) {
+func (m *OAuth2ClientMutation) OldAllowedGroupsPattern(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldAllowedGroupsPattern is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldAllowedGroupsPattern requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldAllowedGroupsPattern: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.AllowedGroupsPattern, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *PasswordMutation) ResetUserID() {
-	m.user_id = nil
+// ResetAllowedGroupsPattern resets all changes to the "allowed_groups_pattern" field.
+func (m *OAuth2ClientMutation) ResetAllowedGroupsPattern() {
+	m.allowed_groups_pattern = nil
 }
 
-// Where appends a list predicates to the PasswordMutation builder.
-func (m *PasswordMutation) Where(ps ...predicate.Password) {
+// Where appends a list predicates to the OAuth2ClientMutation builder.
+func (m *OAuth2ClientMutation) Where(ps ...predicate.OAuth2Client) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *PasswordMutation) Op() Op {
+func (m *OAuth2ClientMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Password).
-func (m *PasswordMutation) Type() string {
+// Type returns the node type of this mutation (OAuth2Client).
+func (m *OAuth2ClientMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PasswordMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.email != nil {
-		fields = append(fields, password.FieldEmail)
+func (m *OAuth2ClientMutation) Fields() []string {
+	fields := make([]string, 0, 15)
+	if m.secret != nil {
+		fields = append(fields, oauth2client.FieldSecret)
 	}
-	if m.hash != nil {
-		fields = append(fields, password.FieldHash)
+	if m.redirect_uris != nil {
+		fields = append(fields, oauth2client.FieldRedirectUris)
 	}
-	if m.username != nil {
-		fields = append(fields, password.FieldUsername)
+	if m.trusted_peers != nil {
+		fields = append(fields, oauth2client.FieldTrustedPeers)
 	}
-	if m.user_id != nil {
-		fields = append(fields, password.FieldUserID)
+	if m.resources != nil {
+		fields = append(fields, oauth2client.FieldResources)
+	}
+	if m.public != nil {
+		fields = append(fields, oauth2client.FieldPublic)
+	}
+	if m.name != nil {
+		fields = append(fields, oauth2client.FieldName)
+	}
+	if m.logo_url != nil {
+		fields = append(fields, oauth2client.FieldLogoURL)
+	}
+	if m.hash_groups != nil {
+		fields = append(fields, oauth2client.FieldHashGroups)
+	}
+	if m.groups_salt != nil {
+		fields = append(fields, oauth2client.FieldGroupsSalt)
+	}
+	if m.require_verified_email != nil {
+		fields = append(fields, oauth2client.FieldRequireVerifiedEmail)
+	}
+	if m.redirect_uri_path_pattern != nil {
+		fields = append(fields, oauth2client.FieldRedirectURIPathPattern)
+	}
+	if m.consent_text != nil {
+		fields = append(fields, oauth2client.FieldConsentText)
+	}
+	if m.privacy_policy_url != nil {
+		fields = append(fields, oauth2client.FieldPrivacyPolicyURL)
+	}
+	if m.allowed_group_prefixes != nil {
+		fields = append(fields, oauth2client.FieldAllowedGroupPrefixes)
+	}
+	if m.allowed_groups_pattern != nil {
+		fields = append(fields, oauth2client.FieldAllowedGroupsPattern)
 	}
 	return fields
 }
@@ -6173,16 +7121,38 @@ func (m *PasswordMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PasswordMutation) Field(name string) (ent.Value, bool) {
+func (m *OAuth2ClientMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case password.FieldEmail:
-		return m.Email()
-	case password.FieldHash:
-		return m.Hash()
-	case password.FieldUsername:
-		return m.Username()
-	case password.FieldUserID:
-		return m.UserID()
+	case oauth2client.FieldSecret:
+		return m.Secret()
+	case oauth2client.FieldRedirectUris:
+		return m.RedirectUris()
+	case oauth2client.FieldTrustedPeers:
+		return m.TrustedPeers()
+	case oauth2client.FieldResources:
+		return m.Resources()
+	case oauth2client.FieldPublic:
+		return m.Public()
+	case oauth2client.FieldName:
+		return m.Name()
+	case oauth2client.FieldLogoURL:
+		return m.LogoURL()
+	case oauth2client.FieldHashGroups:
+		return m.HashGroups()
+	case oauth2client.FieldGroupsSalt:
+		return m.GroupsSalt()
+	case oauth2client.FieldRequireVerifiedEmail:
+		return m.RequireVerifiedEmail()
+	case oauth2client.FieldRedirectURIPathPattern:
+		return m.RedirectURIPathPattern()
+	case oauth2client.FieldConsentText:
+		return m.ConsentText()
+	case oauth2client.FieldPrivacyPolicyURL:
+		return m.PrivacyPolicyURL()
+	case oauth2client.FieldAllowedGroupPrefixes:
+		return m.AllowedGroupPrefixes()
+	case oauth2client.FieldAllowedGroupsPattern:
+		return m.AllowedGroupsPattern()
 	}
 	return nil, false
 }
@@ -6190,204 +7160,353 @@ func (m *PasswordMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PasswordMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *OAuth2ClientMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case password.FieldEmail:
-		return m.OldEmail(ctx)
-	case password.FieldHash:
-		return m.OldHash(ctx)
-	case password.FieldUsername:
-		return m.OldUsername(ctx)
-	case password.FieldUserID:
-		return m.OldUserID(ctx)
+	case oauth2client.FieldSecret:
+		return m.OldSecret(ctx)
+	case oauth2client.FieldRedirectUris:
+		return m.OldRedirectUris(ctx)
+	case oauth2client.FieldTrustedPeers:
+		return m.OldTrustedPeers(ctx)
+	case oauth2client.FieldResources:
+		return m.OldResources(ctx)
+	case oauth2client.FieldPublic:
+		return m.OldPublic(ctx)
+	case oauth2client.FieldName:
+		return m.OldName(ctx)
+	case oauth2client.FieldLogoURL:
+		return m.OldLogoURL(ctx)
+	case oauth2client.FieldHashGroups:
+		return m.OldHashGroups(ctx)
+	case oauth2client.FieldGroupsSalt:
+		return m.OldGroupsSalt(ctx)
+	case oauth2client.FieldRequireVerifiedEmail:
+		return m.OldRequireVerifiedEmail(ctx)
+	case oauth2client.FieldRedirectURIPathPattern:
+		return m.OldRedirectURIPathPattern(ctx)
+	case oauth2client.FieldConsentText:
+		return m.OldConsentText(ctx)
+	case oauth2client.FieldPrivacyPolicyURL:
+		return m.OldPrivacyPolicyURL(ctx)
+	case oauth2client.FieldAllowedGroupPrefixes:
+		return m.OldAllowedGroupPrefixes(ctx)
+	case oauth2client.FieldAllowedGroupsPattern:
+		return m.OldAllowedGroupsPattern(ctx)
 	}
-	return nil, fmt.Errorf("unknown Password field %s", name)
+	return nil, fmt.Errorf("unknown OAuth2Client field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PasswordMutation) SetField(name string, value ent.Value) error {
+func (m *OAuth2ClientMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case password.FieldEmail:
+	case oauth2client.FieldSecret:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetEmail(v)
+		m.SetSecret(v)
 		return nil
-	case password.FieldHash:
-		v, ok := value.([]byte)
+	case oauth2client.FieldRedirectUris:
+		v, ok := value.([]string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetHash(v)
+		m.SetRedirectUris(v)
 		return nil
-	case password.FieldUsername:
+	case oauth2client.FieldTrustedPeers:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTrustedPeers(v)
+		return nil
+	case oauth2client.FieldResources:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResources(v)
+		return nil
+	case oauth2client.FieldPublic:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPublic(v)
+		return nil
+	case oauth2client.FieldName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUsername(v)
+		m.SetName(v)
 		return nil
-	case password.FieldUserID:
+	case oauth2client.FieldLogoURL:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUserID(v)
+		m.SetLogoURL(v)
+		return nil
+	case oauth2client.FieldHashGroups:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHashGroups(v)
+		return nil
+	case oauth2client.FieldGroupsSalt:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGroupsSalt(v)
+		return nil
+	case oauth2client.FieldRequireVerifiedEmail:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequireVerifiedEmail(v)
+		return nil
+	case oauth2client.FieldRedirectURIPathPattern:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRedirectURIPathPattern(v)
+		return nil
+	case oauth2client.FieldConsentText:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConsentText(v)
+		return nil
+	case oauth2client.FieldPrivacyPolicyURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrivacyPolicyURL(v)
+		return nil
+	case oauth2client.FieldAllowedGroupPrefixes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedGroupPrefixes(v)
+		return nil
+	case oauth2client.FieldAllowedGroupsPattern:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedGroupsPattern(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Password field %s", name)
+	return fmt.Errorf("unknown OAuth2Client field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PasswordMutation) AddedFields() []string {
+func (m *OAuth2ClientMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PasswordMutation) AddedField(name string) (ent.Value, bool) {
+func (m *OAuth2ClientMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PasswordMutation) AddField(name string, value ent.Value) error {
+func (m *OAuth2ClientMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Password numeric field %s", name)
+	return fmt.Errorf("unknown OAuth2Client numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PasswordMutation) ClearedFields() []string {
-	return nil
+func (m *OAuth2ClientMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(oauth2client.FieldRedirectUris) {
+		fields = append(fields, oauth2client.FieldRedirectUris)
+	}
+	if m.FieldCleared(oauth2client.FieldTrustedPeers) {
+		fields = append(fields, oauth2client.FieldTrustedPeers)
+	}
+	if m.FieldCleared(oauth2client.FieldResources) {
+		fields = append(fields, oauth2client.FieldResources)
+	}
+	if m.FieldCleared(oauth2client.FieldAllowedGroupPrefixes) {
+		fields = append(fields, oauth2client.FieldAllowedGroupPrefixes)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PasswordMutation) FieldCleared(name string) bool {
+func (m *OAuth2ClientMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PasswordMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Password nullable field %s", name)
+func (m *OAuth2ClientMutation) ClearField(name string) error {
+	switch name {
+	case oauth2client.FieldRedirectUris:
+		m.ClearRedirectUris()
+		return nil
+	case oauth2client.FieldTrustedPeers:
+		m.ClearTrustedPeers()
+		return nil
+	case oauth2client.FieldResources:
+		m.ClearResources()
+		return nil
+	case oauth2client.FieldAllowedGroupPrefixes:
+		m.ClearAllowedGroupPrefixes()
+		return nil
+	}
+	return fmt.Errorf("unknown OAuth2Client nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PasswordMutation) ResetField(name string) error {
+func (m *OAuth2ClientMutation) ResetField(name string) error {
 	switch name {
-	case password.FieldEmail:
-		m.ResetEmail()
+	case oauth2client.FieldSecret:
+		m.ResetSecret()
 		return nil
-	case password.FieldHash:
-		m.ResetHash()
+	case oauth2client.FieldRedirectUris:
+		m.ResetRedirectUris()
 		return nil
-	case password.FieldUsername:
-		m.ResetUsername()
+	case oauth2client.FieldTrustedPeers:
+		m.ResetTrustedPeers()
 		return nil
-	case password.FieldUserID:
-		m.ResetUserID()
+	case oauth2client.FieldResources:
+		m.ResetResources()
+		return nil
+	case oauth2client.FieldPublic:
+		m.ResetPublic()
+		return nil
+	case oauth2client.FieldName:
+		m.ResetName()
+		return nil
+	case oauth2client.FieldLogoURL:
+		m.ResetLogoURL()
+		return nil
+	case oauth2client.FieldHashGroups:
+		m.ResetHashGroups()
+		return nil
+	case oauth2client.FieldGroupsSalt:
+		m.ResetGroupsSalt()
+		return nil
+	case oauth2client.FieldRequireVerifiedEmail:
+		m.ResetRequireVerifiedEmail()
+		return nil
+	case oauth2client.FieldRedirectURIPathPattern:
+		m.ResetRedirectURIPathPattern()
+		return nil
+	case oauth2client.FieldConsentText:
+		m.ResetConsentText()
+		return nil
+	case oauth2client.FieldPrivacyPolicyURL:
+		m.ResetPrivacyPolicyURL()
+		return nil
+	case oauth2client.FieldAllowedGroupPrefixes:
+		m.ResetAllowedGroupPrefixes()
+		return nil
+	case oauth2client.FieldAllowedGroupsPattern:
+		m.ResetAllowedGroupsPattern()
 		return nil
 	}
-	return fmt.Errorf("unknown Password field %s", name)
+	return fmt.Errorf("unknown OAuth2Client field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PasswordMutation) AddedEdges() []string {
+func (m *OAuth2ClientMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PasswordMutation) AddedIDs(name string) []ent.Value {
+func (m *OAuth2ClientMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PasswordMutation) RemovedEdges() []string {
+func (m *OAuth2ClientMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PasswordMutation) RemovedIDs(name string) []ent.Value {
+func (m *OAuth2ClientMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PasswordMutation) ClearedEdges() []string {
+func (m *OAuth2ClientMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PasswordMutation) EdgeCleared(name string) bool {
+func (m *OAuth2ClientMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PasswordMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Password unique edge %s", name)
+func (m *OAuth2ClientMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown OAuth2Client unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PasswordMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Password edge %s", name)
+func (m *OAuth2ClientMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown OAuth2Client edge %s", name)
 }
 
-// RefreshTokenMutation represents an operation that mutates the RefreshToken nodes in the graph.
-type RefreshTokenMutation struct {
+// OfflineSessionMutation represents an operation that mutates the OfflineSession nodes in the graph.
+type OfflineSessionMutation struct {
 	config
-	op                        Op
-	typ                       string
-	id                        *string
-	client_id                 *string
-	scopes                    *[]string
-	nonce                     *string
-	claims_user_id            *string
-	claims_username           *string
-	claims_email              *string
-	claims_email_verified     *bool
-	claims_groups             *[]string
-	claims_preferred_username *string
-	connector_id              *string
-	connector_data            *[]byte
-	token                     *string
-	obsolete_token            *string
-	created_at                *time.Time
-	last_used                 *time.Time
-	clearedFields             map[string]struct{}
-	done                      bool
-	oldValue                  func(context.Context) (*RefreshToken, error)
-	predicates                []predicate.RefreshToken
+	op             Op
+	typ            string
+	id             *string
+	user_id        *string
+	conn_id        *string
+	refresh        *[]byte
+	connector_data *[]byte
+	last_login     *time.Time
+	clearedFields  map[string]struct{}
+	done           bool
+	oldValue       func(context.Context) (*OfflineSession, error)
+	predicates     []predicate.OfflineSession
 }
 
-var _ ent.Mutation = (*RefreshTokenMutation)(nil)
+var _ ent.Mutation = (*OfflineSessionMutation)(nil)
 
-// refreshtokenOption allows management of the mutation configuration using functional options.
-type refreshtokenOption func(*RefreshTokenMutation)
+// offlinesessionOption allows management of the mutation configuration using functional options.
+type offlinesessionOption func(*OfflineSessionMutation)
 
-// newRefreshTokenMutation creates new mutation for the RefreshToken entity.
-func newRefreshTokenMutation(c config, op Op, opts ...refreshtokenOption) *RefreshTokenMutation {
-	m := &RefreshTokenMutation{
+// newOfflineSessionMutation creates new mutation for the OfflineSession entity.
+func newOfflineSessionMutation(c config, op Op, opts ...offlinesessionOption) *OfflineSessionMutation {
+	m := &OfflineSessionMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeRefreshToken,
+		typ:           TypeOfflineSession,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -6396,20 +7515,20 @@ func newRefreshTokenMutation(c config, op Op, opts ...refreshtokenOption) *Refre
 	return m
 }
 
-// withRefreshTokenID sets the ID field of the mutation.
-func withRefreshTokenID(id string) refreshtokenOption {
-	return func(m *RefreshTokenMutation) {
+// withOfflineSessionID sets the ID field of the mutation.
+func withOfflineSessionID(id string) offlinesessionOption {
+	return func(m *OfflineSessionMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *RefreshToken
+			value *OfflineSession
 		)
-		m.oldValue = func(ctx context.Context) (*RefreshToken, error) {
+		m.oldValue = func(ctx context.Context) (*OfflineSession, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().RefreshToken.Get(ctx, id)
+					value, err = m.Client().OfflineSession.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -6418,10 +7537,10 @@ func withRefreshTokenID(id string) refreshtokenOption {
 	}
 }
 
-// withRefreshToken sets the old RefreshToken of the mutation.
-func withRefreshToken(node *RefreshToken) refreshtokenOption {
-	return func(m *RefreshTokenMutation) {
-		m.oldValue = func(context.Context) (*RefreshToken, error) {
+// withOfflineSession sets the old OfflineSession of the mutation.
+func withOfflineSession(node *OfflineSession) offlinesessionOption {
+	return func(m *OfflineSessionMutation) {
+		m.oldValue = func(context.Context) (*OfflineSession, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -6430,7 +7549,7 @@ func withRefreshToken(node *RefreshToken) refreshtokenOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m RefreshTokenMutation) Client() *Client {
+func (m OfflineSessionMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -6438,7 +7557,7 @@ func (m RefreshTokenMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m RefreshTokenMutation) Tx() (*Tx, error) {
+func (m OfflineSessionMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("db: mutation is not running in a transaction")
 	}
@@ -6448,14 +7567,14 @@ func (m RefreshTokenMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of RefreshToken entities.
-func (m *RefreshTokenMutation) SetID(id string) {
+// operation is only accepted on creation of OfflineSession entities.
+func (m *OfflineSessionMutation) SetID(id string) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *RefreshTokenMutation) ID() (id string, exists bool) {
+func (m *OfflineSessionMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -6466,7 +7585,7 @@ func (m *RefreshTokenMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *RefreshTokenMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *OfflineSessionMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -6475,655 +7594,3076 @@ func (m *RefreshTokenMutation) IDs(ctx context.Context) ([]string, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().RefreshToken.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().OfflineSession.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetClientID sets the "client_id" field.
-func (m *RefreshTokenMutation) SetClientID(s string) {
-	m.client_id = &s
+// SetUserID sets the "user_id" field.
+func (m *OfflineSessionMutation) SetUserID(s string) {
+	m.user_id = &s
 }
 
-// ClientID returns the value of the "client_id" field in the mutation.
-func (m *RefreshTokenMutation) ClientID() (r string, exists bool) {
-	v := m.client_id
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *OfflineSessionMutation) UserID() (r string, exists bool) {
+	v := m.user_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClientID returns the old "client_id" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the OfflineSession entity.
+// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClientID(ctx context.Context) (v string, err error) {
+func (m *OfflineSessionMutation) OldUserID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClientID requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.ClientID, nil
+	return oldValue.UserID, nil
 }
 
-// ResetClientID resets all changes to the "client_id" field.
-func (m *RefreshTokenMutation) ResetClientID() {
-	m.client_id = nil
+// ResetUserID resets all changes to the "user_id" field.
+func (m *OfflineSessionMutation) ResetUserID() {
+	m.user_id = nil
 }
 
-// SetScopes sets the "scopes" field.
-func (m *RefreshTokenMutation) SetScopes(s []string) {
-	m.scopes = &s
+// SetConnID sets the "conn_id" field.
+func (m *OfflineSessionMutation) SetConnID(s string) {
+	m.conn_id = &s
 }
 
-// Scopes returns the value of the "scopes" field in the mutation.
-func (m *RefreshTokenMutation) Scopes() (r []string, exists bool) {
-	v := m.scopes
+// ConnID returns the value of the "conn_id" field in the mutation.
+func (m *OfflineSessionMutation) ConnID() (r string, exists bool) {
+	v := m.conn_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldScopes returns the old "scopes" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldConnID returns the old "conn_id" field's value of the OfflineSession entity.
+// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldScopes(ctx context.Context) (v []string, err error) {
+func (m *OfflineSessionMutation) OldConnID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
+		return v, errors.New("OldConnID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldScopes requires an ID field in the mutation")
+		return v, errors.New("OldConnID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
+		return v, fmt.Errorf("querying old value for OldConnID: %w", err)
 	}
-	return oldValue.Scopes, nil
-}
-
-// ClearScopes clears the value of the "scopes" field.
-func (m *RefreshTokenMutation) ClearScopes() {
-	m.scopes = nil
-	m.clearedFields[refreshtoken.FieldScopes] = struct{}{}
-}
-
-// ScopesCleared returns if the "scopes" field was cleared in this mutation.
-func (m *RefreshTokenMutation) ScopesCleared() bool {
-	_, ok := m.clearedFields[refreshtoken.FieldScopes]
-	return ok
+	return oldValue.ConnID, nil
 }
 
-// ResetScopes resets all changes to the "scopes" field.
-func (m *RefreshTokenMutation) ResetScopes() {
-	m.scopes = nil
-	delete(m.clearedFields, refreshtoken.FieldScopes)
+// ResetConnID resets all changes to the "conn_id" field.
+func (m *OfflineSessionMutation) ResetConnID() {
+	m.conn_id = nil
 }
 
-// SetNonce sets the "nonce" field.
-func (m *RefreshTokenMutation) SetNonce(s string) {
-	m.nonce = &s
+// SetRefresh sets the "refresh" field.
+func (m *OfflineSessionMutation) SetRefresh(b []byte) {
+	m.refresh = &b
 }
 
-// Nonce returns the value of the "nonce" field in the mutation.
-func (m *RefreshTokenMutation) Nonce() (r string, exists bool) {
-	v := m.nonce
+// Refresh returns the value of the "refresh" field in the mutation.
+func (m *OfflineSessionMutation) Refresh() (r []byte, exists bool) {
+	v := m.refresh
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldNonce returns the old "nonce" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldRefresh returns the old "refresh" field's value of the OfflineSession entity.
+// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldNonce(ctx context.Context) (v string, err error) {
+func (m *OfflineSessionMutation) OldRefresh(ctx context.Context) (v []byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNonce is only allowed on UpdateOne operations")
+		return v, errors.New("OldRefresh is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNonce requires an ID field in the mutation")
+		return v, errors.New("OldRefresh requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNonce: %w", err)
+		return v, fmt.Errorf("querying old value for OldRefresh: %w", err)
 	}
-	return oldValue.Nonce, nil
+	return oldValue.Refresh, nil
 }
 
-// ResetNonce resets all changes to the "nonce" field.
-func (m *RefreshTokenMutation) ResetNonce() {
-	m.nonce = nil
+// ResetRefresh resets all changes to the "refresh" field.
+func (m *OfflineSessionMutation) ResetRefresh() {
+	m.refresh = nil
 }
 
-// SetClaimsUserID sets the "claims_user_id" field.
-func (m *RefreshTokenMutation) SetClaimsUserID(s string) {
-	m.claims_user_id = &s
+// SetConnectorData sets the "connector_data" field.
+func (m *OfflineSessionMutation) SetConnectorData(b []byte) {
+	m.connector_data = &b
 }
 
-// ClaimsUserID returns the value of the "claims_user_id" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsUserID() (r string, exists bool) {
-	v := m.claims_user_id
+// ConnectorData returns the value of the "connector_data" field in the mutation.
+func (m *OfflineSessionMutation) ConnectorData() (r []byte, exists bool) {
+	v := m.connector_data
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClaimsUserID returns the old "claims_user_id" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldConnectorData returns the old "connector_data" field's value of the OfflineSession entity.
+// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsUserID(ctx context.Context) (v string, err error) {
+func (m *OfflineSessionMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsUserID requires an ID field in the mutation")
+		return v, errors.New("OldConnectorData requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
 	}
-	return oldValue.ClaimsUserID, nil
+	return oldValue.ConnectorData, nil
 }
 
-// ResetClaimsUserID resets all changes to the "claims_user_id" field.
-func (m *RefreshTokenMutation) ResetClaimsUserID() {
-	m.claims_user_id = nil
+// ClearConnectorData clears the value of the "connector_data" field.
+func (m *OfflineSessionMutation) ClearConnectorData() {
+	m.connector_data = nil
+	m.clearedFields[offlinesession.FieldConnectorData] = struct{}{}
 }
 
-// SetClaimsUsername sets the "claims_username" field.
-func (m *RefreshTokenMutation) SetClaimsUsername(s string) {
-	m.claims_username = &s
+// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
+func (m *OfflineSessionMutation) ConnectorDataCleared() bool {
+	_, ok := m.clearedFields[offlinesession.FieldConnectorData]
+	return ok
 }
 
-// ClaimsUsername returns the value of the "claims_username" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsUsername() (r string, exists bool) {
-	v := m.claims_username
+// ResetConnectorData resets all changes to the "connector_data" field.
+func (m *OfflineSessionMutation) ResetConnectorData() {
+	m.connector_data = nil
+	delete(m.clearedFields, offlinesession.FieldConnectorData)
+}
+
+// SetLastLogin sets the "last_login" field.
+func (m *OfflineSessionMutation) SetLastLogin(t time.Time) {
+	m.last_login = &t
+}
+
+// LastLogin returns the value of the "last_login" field in the mutation.
+func (m *OfflineSessionMutation) LastLogin() (r time.Time, exists bool) {
+	v := m.last_login
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClaimsUsername returns the old "claims_username" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldLastLogin returns the old "last_login" field's value of the OfflineSession entity.
+// If the OfflineSession object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsUsername(ctx context.Context) (v string, err error) {
+func (m *OfflineSessionMutation) OldLastLogin(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsUsername is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastLogin is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsUsername requires an ID field in the mutation")
+		return v, errors.New("OldLastLogin requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsUsername: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastLogin: %w", err)
 	}
-	return oldValue.ClaimsUsername, nil
-}
-
-// ResetClaimsUsername resets all changes to the "claims_username" field.
-func (m *RefreshTokenMutation) ResetClaimsUsername() {
-	m.claims_username = nil
+	return oldValue.LastLogin, nil
 }
 
-// SetClaimsEmail sets the "claims_email" field.
-func (m *RefreshTokenMutation) SetClaimsEmail(s string) {
-	m.claims_email = &s
+// ClearLastLogin clears the value of the "last_login" field.
+func (m *OfflineSessionMutation) ClearLastLogin() {
+	m.last_login = nil
+	m.clearedFields[offlinesession.FieldLastLogin] = struct{}{}
 }
 
-// ClaimsEmail returns the value of the "claims_email" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsEmail() (r string, exists bool) {
-	v := m.claims_email
-	if v == nil {
-		return
-	}
-	return *v, true
+// LastLoginCleared returns if the "last_login" field was cleared in this mutation.
+func (m *OfflineSessionMutation) LastLoginCleared() bool {
+	_, ok := m.clearedFields[offlinesession.FieldLastLogin]
+	return ok
 }
 
-// OldClaimsEmail returns the old "claims_email" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsEmail(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsEmail is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsEmail requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsEmail: %w", err)
-	}
-	return oldValue.ClaimsEmail, nil
+// ResetLastLogin resets all changes to the "last_login" field.
+func (m *OfflineSessionMutation) ResetLastLogin() {
+	m.last_login = nil
+	delete(m.clearedFields, offlinesession.FieldLastLogin)
 }
 
-// ResetClaimsEmail resets all changes to the "claims_email" field.
-func (m *RefreshTokenMutation) ResetClaimsEmail() {
-	m.claims_email = nil
+// Where appends a list predicates to the OfflineSessionMutation builder.
+func (m *OfflineSessionMutation) Where(ps ...predicate.OfflineSession) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// SetClaimsEmailVerified sets the "claims_email_verified" field.
-func (m *RefreshTokenMutation) SetClaimsEmailVerified(b bool) {
-	m.claims_email_verified = &b
+// Op returns the operation name.
+func (m *OfflineSessionMutation) Op() Op {
+	return m.op
 }
 
-// ClaimsEmailVerified returns the value of the "claims_email_verified" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsEmailVerified() (r bool, exists bool) {
-	v := m.claims_email_verified
-	if v == nil {
-		return
-	}
-	return *v, true
+// Type returns the node type of this mutation (OfflineSession).
+func (m *OfflineSessionMutation) Type() string {
+	return m.typ
 }
 
-// OldClaimsEmailVerified returns the old "claims_email_verified" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsEmailVerified(ctx context.Context) (v bool, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsEmailVerified is only allowed on UpdateOne operations")
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *OfflineSessionMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.user_id != nil {
+		fields = append(fields, offlinesession.FieldUserID)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsEmailVerified requires an ID field in the mutation")
+	if m.conn_id != nil {
+		fields = append(fields, offlinesession.FieldConnID)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsEmailVerified: %w", err)
+	if m.refresh != nil {
+		fields = append(fields, offlinesession.FieldRefresh)
 	}
-	return oldValue.ClaimsEmailVerified, nil
+	if m.connector_data != nil {
+		fields = append(fields, offlinesession.FieldConnectorData)
+	}
+	if m.last_login != nil {
+		fields = append(fields, offlinesession.FieldLastLogin)
+	}
+	return fields
 }
 
-// ResetClaimsEmailVerified resets all changes to the "claims_email_verified" field.
-func (m *RefreshTokenMutation) ResetClaimsEmailVerified() {
-	m.claims_email_verified = nil
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *OfflineSessionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case offlinesession.FieldUserID:
+		return m.UserID()
+	case offlinesession.FieldConnID:
+		return m.ConnID()
+	case offlinesession.FieldRefresh:
+		return m.Refresh()
+	case offlinesession.FieldConnectorData:
+		return m.ConnectorData()
+	case offlinesession.FieldLastLogin:
+		return m.LastLogin()
+	}
+	return nil, false
 }
 
-// SetClaimsGroups sets the "claims_groups" field.
-func (m *RefreshTokenMutation) SetClaimsGroups(s []string) {
-	m.claims_groups = &s
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *OfflineSessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case offlinesession.FieldUserID:
+		return m.OldUserID(ctx)
+	case offlinesession.FieldConnID:
+		return m.OldConnID(ctx)
+	case offlinesession.FieldRefresh:
+		return m.OldRefresh(ctx)
+	case offlinesession.FieldConnectorData:
+		return m.OldConnectorData(ctx)
+	case offlinesession.FieldLastLogin:
+		return m.OldLastLogin(ctx)
+	}
+	return nil, fmt.Errorf("unknown OfflineSession field %s", name)
 }
 
-// ClaimsGroups returns the value of the "claims_groups" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsGroups() (r []string, exists bool) {
-	v := m.claims_groups
-	if v == nil {
-		return
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *OfflineSessionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case offlinesession.FieldUserID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case offlinesession.FieldConnID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConnID(v)
+		return nil
+	case offlinesession.FieldRefresh:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRefresh(v)
+		return nil
+	case offlinesession.FieldConnectorData:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConnectorData(v)
+		return nil
+	case offlinesession.FieldLastLogin:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastLogin(v)
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown OfflineSession field %s", name)
 }
 
-// OldClaimsGroups returns the old "claims_groups" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsGroups(ctx context.Context) (v []string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsGroups is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsGroups requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsGroups: %w", err)
-	}
-	return oldValue.ClaimsGroups, nil
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *OfflineSessionMutation) AddedFields() []string {
+	return nil
 }
 
-// ClearClaimsGroups clears the value of the "claims_groups" field.
-func (m *RefreshTokenMutation) ClearClaimsGroups() {
-	m.claims_groups = nil
-	m.clearedFields[refreshtoken.FieldClaimsGroups] = struct{}{}
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *OfflineSessionMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
 }
 
-// ClaimsGroupsCleared returns if the "claims_groups" field was cleared in this mutation.
-func (m *RefreshTokenMutation) ClaimsGroupsCleared() bool {
-	_, ok := m.clearedFields[refreshtoken.FieldClaimsGroups]
-	return ok
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *OfflineSessionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown OfflineSession numeric field %s", name)
 }
 
-// ResetClaimsGroups resets all changes to the "claims_groups" field.
-func (m *RefreshTokenMutation) ResetClaimsGroups() {
-	m.claims_groups = nil
-	delete(m.clearedFields, refreshtoken.FieldClaimsGroups)
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *OfflineSessionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(offlinesession.FieldConnectorData) {
+		fields = append(fields, offlinesession.FieldConnectorData)
+	}
+	if m.FieldCleared(offlinesession.FieldLastLogin) {
+		fields = append(fields, offlinesession.FieldLastLogin)
+	}
+	return fields
 }
 
-// SetClaimsPreferredUsername sets the "claims_preferred_username" field.
-func (m *RefreshTokenMutation) SetClaimsPreferredUsername(s string) {
-	m.claims_preferred_username = &s
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *OfflineSessionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// ClaimsPreferredUsername returns the value of the "claims_preferred_username" field in the mutation.
-func (m *RefreshTokenMutation) ClaimsPreferredUsername() (r string, exists bool) {
-	v := m.claims_preferred_username
-	if v == nil {
-		return
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *OfflineSessionMutation) ClearField(name string) error {
+	switch name {
+	case offlinesession.FieldConnectorData:
+		m.ClearConnectorData()
+		return nil
+	case offlinesession.FieldLastLogin:
+		m.ClearLastLogin()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown OfflineSession nullable field %s", name)
 }
 
-// OldClaimsPreferredUsername returns the old "claims_preferred_username" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldClaimsPreferredUsername(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaimsPreferredUsername is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaimsPreferredUsername requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaimsPreferredUsername: %w", err)
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *OfflineSessionMutation) ResetField(name string) error {
+	switch name {
+	case offlinesession.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case offlinesession.FieldConnID:
+		m.ResetConnID()
+		return nil
+	case offlinesession.FieldRefresh:
+		m.ResetRefresh()
+		return nil
+	case offlinesession.FieldConnectorData:
+		m.ResetConnectorData()
+		return nil
+	case offlinesession.FieldLastLogin:
+		m.ResetLastLogin()
+		return nil
 	}
-	return oldValue.ClaimsPreferredUsername, nil
+	return fmt.Errorf("unknown OfflineSession field %s", name)
 }
 
-// ResetClaimsPreferredUsername resets all changes to the "claims_preferred_username" field.
-func (m *RefreshTokenMutation) ResetClaimsPreferredUsername() {
-	m.claims_preferred_username = nil
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *OfflineSessionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// SetConnectorID sets the "connector_id" field.
-func (m *RefreshTokenMutation) SetConnectorID(s string) {
-	m.connector_id = &s
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *OfflineSessionMutation) AddedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ConnectorID returns the value of the "connector_id" field in the mutation.
-func (m *RefreshTokenMutation) ConnectorID() (r string, exists bool) {
-	v := m.connector_id
-	if v == nil {
-		return
-	}
-	return *v, true
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *OfflineSessionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// OldConnectorID returns the old "connector_id" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldConnectorID(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnectorID is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnectorID requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnectorID: %w", err)
-	}
-	return oldValue.ConnectorID, nil
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *OfflineSessionMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ResetConnectorID resets all changes to the "connector_id" field.
-func (m *RefreshTokenMutation) ResetConnectorID() {
-	m.connector_id = nil
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *OfflineSessionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// SetConnectorData sets the "connector_data" field.
-func (m *RefreshTokenMutation) SetConnectorData(b []byte) {
-	m.connector_data = &b
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *OfflineSessionMutation) EdgeCleared(name string) bool {
+	return false
 }
 
-// ConnectorData returns the value of the "connector_data" field in the mutation.
-func (m *RefreshTokenMutation) ConnectorData() (r []byte, exists bool) {
-	v := m.connector_data
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *OfflineSessionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown OfflineSession unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *OfflineSessionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown OfflineSession edge %s", name)
+}
+
+// PasswordMutation represents an operation that mutates the Password nodes in the graph.
+type PasswordMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	email         *string
+	hash          *[]byte
+	username      *string
+	user_id       *string
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*Password, error)
+	predicates    []predicate.Password
+}
+
+var _ ent.Mutation = (*PasswordMutation)(nil)
+
+// passwordOption allows management of the mutation configuration using functional options.
+type passwordOption func(*PasswordMutation)
+
+// newPasswordMutation creates new mutation for the Password entity.
+func newPasswordMutation(c config, op Op, opts ...passwordOption) *PasswordMutation {
+	m := &PasswordMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePassword,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPasswordID sets the ID field of the mutation.
+func withPasswordID(id int) passwordOption {
+	return func(m *PasswordMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Password
+		)
+		m.oldValue = func(ctx context.Context) (*Password, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Password.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPassword sets the old Password of the mutation.
+func withPassword(node *Password) passwordOption {
+	return func(m *PasswordMutation) {
+		m.oldValue = func(context.Context) (*Password, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PasswordMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PasswordMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PasswordMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PasswordMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Password.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetEmail sets the "email" field.
+func (m *PasswordMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *PasswordMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the Password entity.
+// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PasswordMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *PasswordMutation) ResetEmail() {
+	m.email = nil
+}
+
+// SetHash sets the "hash" field.
+func (m *PasswordMutation) SetHash(b []byte) {
+	m.hash = &b
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *PasswordMutation) Hash() (r []byte, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the Password entity.
+// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PasswordMutation) OldHash(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *PasswordMutation) ResetHash() {
+	m.hash = nil
+}
+
+// SetUsername sets the "username" field.
+func (m *PasswordMutation) SetUsername(s string) {
+	m.username = &s
+}
+
+// Username returns the value of the "username" field in the mutation.
+func (m *PasswordMutation) Username() (r string, exists bool) {
+	v := m.username
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsername returns the old "username" field's value of the Password entity.
+// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PasswordMutation) OldUsername(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsername requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
+	}
+	return oldValue.Username, nil
+}
+
+// ResetUsername resets all changes to the "username" field.
+func (m *PasswordMutation) ResetUsername() {
+	m.username = nil
+}
+
+// SetUserID sets the "user_id" field.
+func (m *PasswordMutation) SetUserID(s string) {
+	m.user_id = &s
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *PasswordMutation) UserID() (r string, exists bool) {
+	v := m.user_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldConnectorData returns the old "connector_data" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConnectorData requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
-	}
-	return oldValue.ConnectorData, nil
+// OldUserID returns the old "user_id" field's value of the Password entity.
+// If the Password object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PasswordMutation) OldUserID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *PasswordMutation) ResetUserID() {
+	m.user_id = nil
+}
+
+// Where appends a list predicates to the PasswordMutation builder.
+func (m *PasswordMutation) Where(ps ...predicate.Password) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *PasswordMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Password).
+func (m *PasswordMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PasswordMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.email != nil {
+		fields = append(fields, password.FieldEmail)
+	}
+	if m.hash != nil {
+		fields = append(fields, password.FieldHash)
+	}
+	if m.username != nil {
+		fields = append(fields, password.FieldUsername)
+	}
+	if m.user_id != nil {
+		fields = append(fields, password.FieldUserID)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PasswordMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case password.FieldEmail:
+		return m.Email()
+	case password.FieldHash:
+		return m.Hash()
+	case password.FieldUsername:
+		return m.Username()
+	case password.FieldUserID:
+		return m.UserID()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PasswordMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case password.FieldEmail:
+		return m.OldEmail(ctx)
+	case password.FieldHash:
+		return m.OldHash(ctx)
+	case password.FieldUsername:
+		return m.OldUsername(ctx)
+	case password.FieldUserID:
+		return m.OldUserID(ctx)
+	}
+	return nil, fmt.Errorf("unknown Password field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PasswordMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case password.FieldEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmail(v)
+		return nil
+	case password.FieldHash:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
+		return nil
+	case password.FieldUsername:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsername(v)
+		return nil
+	case password.FieldUserID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Password field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PasswordMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PasswordMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PasswordMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Password numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PasswordMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PasswordMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PasswordMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Password nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PasswordMutation) ResetField(name string) error {
+	switch name {
+	case password.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case password.FieldHash:
+		m.ResetHash()
+		return nil
+	case password.FieldUsername:
+		m.ResetUsername()
+		return nil
+	case password.FieldUserID:
+		m.ResetUserID()
+		return nil
+	}
+	return fmt.Errorf("unknown Password field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PasswordMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PasswordMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PasswordMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PasswordMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PasswordMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PasswordMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PasswordMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Password unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PasswordMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Password edge %s", name)
+}
+
+// PushedAuthRequestMutation represents an operation that mutates the PushedAuthRequest nodes in the graph.
+type PushedAuthRequestMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	client_id     *string
+	params        *map[string][]string
+	expiry        *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*PushedAuthRequest, error)
+	predicates    []predicate.PushedAuthRequest
+}
+
+var _ ent.Mutation = (*PushedAuthRequestMutation)(nil)
+
+// pushedauthrequestOption allows management of the mutation configuration using functional options.
+type pushedauthrequestOption func(*PushedAuthRequestMutation)
+
+// newPushedAuthRequestMutation creates new mutation for the PushedAuthRequest entity.
+func newPushedAuthRequestMutation(c config, op Op, opts ...pushedauthrequestOption) *PushedAuthRequestMutation {
+	m := &PushedAuthRequestMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePushedAuthRequest,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPushedAuthRequestID sets the ID field of the mutation.
+func withPushedAuthRequestID(id string) pushedauthrequestOption {
+	return func(m *PushedAuthRequestMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PushedAuthRequest
+		)
+		m.oldValue = func(ctx context.Context) (*PushedAuthRequest, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PushedAuthRequest.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPushedAuthRequest sets the old PushedAuthRequest of the mutation.
+func withPushedAuthRequest(node *PushedAuthRequest) pushedauthrequestOption {
+	return func(m *PushedAuthRequestMutation) {
+		m.oldValue = func(context.Context) (*PushedAuthRequest, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PushedAuthRequestMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PushedAuthRequestMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of PushedAuthRequest entities.
+func (m *PushedAuthRequestMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PushedAuthRequestMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PushedAuthRequestMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PushedAuthRequest.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetClientID sets the "client_id" field.
+func (m *PushedAuthRequestMutation) SetClientID(s string) {
+	m.client_id = &s
+}
+
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *PushedAuthRequestMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClientID returns the old "client_id" field's value of the PushedAuthRequest entity.
+// If the PushedAuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PushedAuthRequestMutation) OldClientID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClientID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
+	}
+	return oldValue.ClientID, nil
+}
+
+// ResetClientID resets all changes to the "client_id" field.
+func (m *PushedAuthRequestMutation) ResetClientID() {
+	m.client_id = nil
+}
+
+// SetParams sets the "params" field.
+func (m *PushedAuthRequestMutation) SetParams(value map[string][]string) {
+	m.params = &value
+}
+
+// Params returns the value of the "params" field in the mutation.
+func (m *PushedAuthRequestMutation) Params() (r map[string][]string, exists bool) {
+	v := m.params
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldParams returns the old "params" field's value of the PushedAuthRequest entity.
+// If the PushedAuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PushedAuthRequestMutation) OldParams(ctx context.Context) (v map[string][]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldParams is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldParams requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldParams: %w", err)
+	}
+	return oldValue.Params, nil
+}
+
+// ResetParams resets all changes to the "params" field.
+func (m *PushedAuthRequestMutation) ResetParams() {
+	m.params = nil
+}
+
+// SetExpiry sets the "expiry" field.
+func (m *PushedAuthRequestMutation) SetExpiry(t time.Time) {
+	m.expiry = &t
+}
+
+// Expiry returns the value of the "expiry" field in the mutation.
+func (m *PushedAuthRequestMutation) Expiry() (r time.Time, exists bool) {
+	v := m.expiry
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiry returns the old "expiry" field's value of the PushedAuthRequest entity.
+// If the PushedAuthRequest object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PushedAuthRequestMutation) OldExpiry(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiry is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiry requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiry: %w", err)
+	}
+	return oldValue.Expiry, nil
+}
+
+// ResetExpiry resets all changes to the "expiry" field.
+func (m *PushedAuthRequestMutation) ResetExpiry() {
+	m.expiry = nil
+}
+
+// Where appends a list predicates to the PushedAuthRequestMutation builder.
+func (m *PushedAuthRequestMutation) Where(ps ...predicate.PushedAuthRequest) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *PushedAuthRequestMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (PushedAuthRequest).
+func (m *PushedAuthRequestMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PushedAuthRequestMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.client_id != nil {
+		fields = append(fields, pushedauthrequest.FieldClientID)
+	}
+	if m.params != nil {
+		fields = append(fields, pushedauthrequest.FieldParams)
+	}
+	if m.expiry != nil {
+		fields = append(fields, pushedauthrequest.FieldExpiry)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PushedAuthRequestMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case pushedauthrequest.FieldClientID:
+		return m.ClientID()
+	case pushedauthrequest.FieldParams:
+		return m.Params()
+	case pushedauthrequest.FieldExpiry:
+		return m.Expiry()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PushedAuthRequestMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case pushedauthrequest.FieldClientID:
+		return m.OldClientID(ctx)
+	case pushedauthrequest.FieldParams:
+		return m.OldParams(ctx)
+	case pushedauthrequest.FieldExpiry:
+		return m.OldExpiry(ctx)
+	}
+	return nil, fmt.Errorf("unknown PushedAuthRequest field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PushedAuthRequestMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case pushedauthrequest.FieldClientID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientID(v)
+		return nil
+	case pushedauthrequest.FieldParams:
+		v, ok := value.(map[string][]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetParams(v)
+		return nil
+	case pushedauthrequest.FieldExpiry:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiry(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PushedAuthRequest field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PushedAuthRequestMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PushedAuthRequestMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PushedAuthRequestMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown PushedAuthRequest numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PushedAuthRequestMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PushedAuthRequestMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PushedAuthRequestMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown PushedAuthRequest nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PushedAuthRequestMutation) ResetField(name string) error {
+	switch name {
+	case pushedauthrequest.FieldClientID:
+		m.ResetClientID()
+		return nil
+	case pushedauthrequest.FieldParams:
+		m.ResetParams()
+		return nil
+	case pushedauthrequest.FieldExpiry:
+		m.ResetExpiry()
+		return nil
+	}
+	return fmt.Errorf("unknown PushedAuthRequest field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PushedAuthRequestMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PushedAuthRequestMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PushedAuthRequestMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PushedAuthRequestMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PushedAuthRequestMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PushedAuthRequestMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PushedAuthRequestMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown PushedAuthRequest unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PushedAuthRequestMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown PushedAuthRequest edge %s", name)
+}
+
+// RefreshTokenMutation represents an operation that mutates the RefreshToken nodes in the graph.
+type RefreshTokenMutation struct {
+	config
+	op                        Op
+	typ                       string
+	id                        *string
+	client_id                 *string
+	scopes                    *[]string
+	resources                 *[]string
+	nonce                     *string
+	claims_user_id            *string
+	claims_username           *string
+	claims_email              *string
+	claims_email_verified     *bool
+	claims_groups             *[]string
+	claims_organizations      *[]string
+	claims_address            **storage.Address
+	claims_custom             *map[string]interface{}
+	claims_preferred_username *string
+	claims_auth_time          *time.Time
+	connector_id              *string
+	connector_data            *[]byte
+	token                     *string
+	obsolete_token            *string
+	created_at                *time.Time
+	last_used                 *time.Time
+	clearedFields             map[string]struct{}
+	done                      bool
+	oldValue                  func(context.Context) (*RefreshToken, error)
+	predicates                []predicate.RefreshToken
+}
+
+var _ ent.Mutation = (*RefreshTokenMutation)(nil)
+
+// refreshtokenOption allows management of the mutation configuration using functional options.
+type refreshtokenOption func(*RefreshTokenMutation)
+
+// newRefreshTokenMutation creates new mutation for the RefreshToken entity.
+func newRefreshTokenMutation(c config, op Op, opts ...refreshtokenOption) *RefreshTokenMutation {
+	m := &RefreshTokenMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeRefreshToken,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withRefreshTokenID sets the ID field of the mutation.
+func withRefreshTokenID(id string) refreshtokenOption {
+	return func(m *RefreshTokenMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *RefreshToken
+		)
+		m.oldValue = func(ctx context.Context) (*RefreshToken, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().RefreshToken.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withRefreshToken sets the old RefreshToken of the mutation.
+func withRefreshToken(node *RefreshToken) refreshtokenOption {
+	return func(m *RefreshTokenMutation) {
+		m.oldValue = func(context.Context) (*RefreshToken, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m RefreshTokenMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m RefreshTokenMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of RefreshToken entities.
+func (m *RefreshTokenMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *RefreshTokenMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *RefreshTokenMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().RefreshToken.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetClientID sets the "client_id" field.
+func (m *RefreshTokenMutation) SetClientID(s string) {
+	m.client_id = &s
+}
+
+// ClientID returns the value of the "client_id" field in the mutation.
+func (m *RefreshTokenMutation) ClientID() (r string, exists bool) {
+	v := m.client_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClientID returns the old "client_id" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClientID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClientID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClientID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClientID: %w", err)
+	}
+	return oldValue.ClientID, nil
+}
+
+// ResetClientID resets all changes to the "client_id" field.
+func (m *RefreshTokenMutation) ResetClientID() {
+	m.client_id = nil
+}
+
+// SetScopes sets the "scopes" field.
+func (m *RefreshTokenMutation) SetScopes(s []string) {
+	m.scopes = &s
+}
+
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *RefreshTokenMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldScopes returns the old "scopes" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldScopes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScopes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
+	}
+	return oldValue.Scopes, nil
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (m *RefreshTokenMutation) ClearScopes() {
+	m.scopes = nil
+	m.clearedFields[refreshtoken.FieldScopes] = struct{}{}
+}
+
+// ScopesCleared returns if the "scopes" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ScopesCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldScopes]
+	return ok
+}
+
+// ResetScopes resets all changes to the "scopes" field.
+func (m *RefreshTokenMutation) ResetScopes() {
+	m.scopes = nil
+	delete(m.clearedFields, refreshtoken.FieldScopes)
+}
+
+// SetResources sets the "resources" field.
+func (m *RefreshTokenMutation) SetResources(s []string) {
+	m.resources = &s
+}
+
+// Resources returns the value of the "resources" field in the mutation.
+func (m *RefreshTokenMutation) Resources() (r []string, exists bool) {
+	v := m.resources
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResources returns the old "resources" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldResources(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResources is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResources requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResources: %w", err)
+	}
+	return oldValue.Resources, nil
+}
+
+// ClearResources clears the value of the "resources" field.
+func (m *RefreshTokenMutation) ClearResources() {
+	m.resources = nil
+	m.clearedFields[refreshtoken.FieldResources] = struct{}{}
+}
+
+// ResourcesCleared returns if the "resources" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ResourcesCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldResources]
+	return ok
+}
+
+// ResetResources resets all changes to the "resources" field.
+func (m *RefreshTokenMutation) ResetResources() {
+	m.resources = nil
+	delete(m.clearedFields, refreshtoken.FieldResources)
+}
+
+// SetNonce sets the "nonce" field.
+func (m *RefreshTokenMutation) SetNonce(s string) {
+	m.nonce = &s
+}
+
+// Nonce returns the value of the "nonce" field in the mutation.
+func (m *RefreshTokenMutation) Nonce() (r string, exists bool) {
+	v := m.nonce
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNonce returns the old "nonce" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldNonce(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNonce is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNonce requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNonce: %w", err)
+	}
+	return oldValue.Nonce, nil
+}
+
+// ResetNonce resets all changes to the "nonce" field.
+func (m *RefreshTokenMutation) ResetNonce() {
+	m.nonce = nil
+}
+
+// SetClaimsUserID sets the "claims_user_id" field.
+func (m *RefreshTokenMutation) SetClaimsUserID(s string) {
+	m.claims_user_id = &s
+}
+
+// ClaimsUserID returns the value of the "claims_user_id" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsUserID() (r string, exists bool) {
+	v := m.claims_user_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsUserID returns the old "claims_user_id" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsUserID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsUserID: %w", err)
+	}
+	return oldValue.ClaimsUserID, nil
+}
+
+// ResetClaimsUserID resets all changes to the "claims_user_id" field.
+func (m *RefreshTokenMutation) ResetClaimsUserID() {
+	m.claims_user_id = nil
+}
+
+// SetClaimsUsername sets the "claims_username" field.
+func (m *RefreshTokenMutation) SetClaimsUsername(s string) {
+	m.claims_username = &s
+}
+
+// ClaimsUsername returns the value of the "claims_username" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsUsername() (r string, exists bool) {
+	v := m.claims_username
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsUsername returns the old "claims_username" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsUsername(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsUsername is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsUsername requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsUsername: %w", err)
+	}
+	return oldValue.ClaimsUsername, nil
+}
+
+// ResetClaimsUsername resets all changes to the "claims_username" field.
+func (m *RefreshTokenMutation) ResetClaimsUsername() {
+	m.claims_username = nil
+}
+
+// SetClaimsEmail sets the "claims_email" field.
+func (m *RefreshTokenMutation) SetClaimsEmail(s string) {
+	m.claims_email = &s
+}
+
+// ClaimsEmail returns the value of the "claims_email" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsEmail() (r string, exists bool) {
+	v := m.claims_email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsEmail returns the old "claims_email" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsEmail: %w", err)
+	}
+	return oldValue.ClaimsEmail, nil
+}
+
+// ResetClaimsEmail resets all changes to the "claims_email" field.
+func (m *RefreshTokenMutation) ResetClaimsEmail() {
+	m.claims_email = nil
+}
+
+// SetClaimsEmailVerified sets the "claims_email_verified" field.
+func (m *RefreshTokenMutation) SetClaimsEmailVerified(b bool) {
+	m.claims_email_verified = &b
+}
+
+// ClaimsEmailVerified returns the value of the "claims_email_verified" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsEmailVerified() (r bool, exists bool) {
+	v := m.claims_email_verified
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsEmailVerified returns the old "claims_email_verified" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsEmailVerified(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsEmailVerified is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsEmailVerified requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsEmailVerified: %w", err)
+	}
+	return oldValue.ClaimsEmailVerified, nil
+}
+
+// ResetClaimsEmailVerified resets all changes to the "claims_email_verified" field.
+func (m *RefreshTokenMutation) ResetClaimsEmailVerified() {
+	m.claims_email_verified = nil
+}
+
+// SetClaimsGroups sets the "claims_groups" field.
+func (m *RefreshTokenMutation) SetClaimsGroups(s []string) {
+	m.claims_groups = &s
+}
+
+// ClaimsGroups returns the value of the "claims_groups" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsGroups() (r []string, exists bool) {
+	v := m.claims_groups
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsGroups returns the old "claims_groups" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsGroups(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsGroups is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsGroups requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsGroups: %w", err)
+	}
+	return oldValue.ClaimsGroups, nil
+}
+
+// ClearClaimsGroups clears the value of the "claims_groups" field.
+func (m *RefreshTokenMutation) ClearClaimsGroups() {
+	m.claims_groups = nil
+	m.clearedFields[refreshtoken.FieldClaimsGroups] = struct{}{}
+}
+
+// ClaimsGroupsCleared returns if the "claims_groups" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ClaimsGroupsCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldClaimsGroups]
+	return ok
+}
+
+// ResetClaimsGroups resets all changes to the "claims_groups" field.
+func (m *RefreshTokenMutation) ResetClaimsGroups() {
+	m.claims_groups = nil
+	delete(m.clearedFields, refreshtoken.FieldClaimsGroups)
+}
+
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (m *RefreshTokenMutation) SetClaimsOrganizations(s []string) {
+	m.claims_organizations = &s
+}
+
+// ClaimsOrganizations returns the value of the "claims_organizations" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsOrganizations() (r []string, exists bool) {
+	v := m.claims_organizations
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsOrganizations returns the old "claims_organizations" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsOrganizations(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsOrganizations is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsOrganizations requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsOrganizations: %w", err)
+	}
+	return oldValue.ClaimsOrganizations, nil
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (m *RefreshTokenMutation) ClearClaimsOrganizations() {
+	m.claims_organizations = nil
+	m.clearedFields[refreshtoken.FieldClaimsOrganizations] = struct{}{}
+}
+
+// ClaimsOrganizationsCleared returns if the "claims_organizations" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ClaimsOrganizationsCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldClaimsOrganizations]
+	return ok
+}
+
+// ResetClaimsOrganizations resets all changes to the "claims_organizations" field.
+func (m *RefreshTokenMutation) ResetClaimsOrganizations() {
+	m.claims_organizations = nil
+	delete(m.clearedFields, refreshtoken.FieldClaimsOrganizations)
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (m *RefreshTokenMutation) SetClaimsAddress(s *storage.Address) {
+	m.claims_address = &s
+}
+
+// ClaimsAddress returns the value of the "claims_address" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsAddress() (r *storage.Address, exists bool) {
+	v := m.claims_address
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsAddress returns the old "claims_address" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsAddress(ctx context.Context) (v *storage.Address, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsAddress is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsAddress requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsAddress: %w", err)
+	}
+	return oldValue.ClaimsAddress, nil
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (m *RefreshTokenMutation) ClearClaimsAddress() {
+	m.claims_address = nil
+	m.clearedFields[refreshtoken.FieldClaimsAddress] = struct{}{}
+}
+
+// ClaimsAddressCleared returns if the "claims_address" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ClaimsAddressCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldClaimsAddress]
+	return ok
+}
+
+// ResetClaimsAddress resets all changes to the "claims_address" field.
+func (m *RefreshTokenMutation) ResetClaimsAddress() {
+	m.claims_address = nil
+	delete(m.clearedFields, refreshtoken.FieldClaimsAddress)
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (m *RefreshTokenMutation) SetClaimsCustom(value map[string]interface{}) {
+	m.claims_custom = &value
+}
+
+// ClaimsCustom returns the value of the "claims_custom" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsCustom() (r map[string]interface{}, exists bool) {
+	v := m.claims_custom
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsCustom returns the old "claims_custom" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsCustom(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsCustom is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsCustom requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsCustom: %w", err)
+	}
+	return oldValue.ClaimsCustom, nil
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (m *RefreshTokenMutation) ClearClaimsCustom() {
+	m.claims_custom = nil
+	m.clearedFields[refreshtoken.FieldClaimsCustom] = struct{}{}
+}
+
+// ClaimsCustomCleared returns if the "claims_custom" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ClaimsCustomCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldClaimsCustom]
+	return ok
+}
+
+// ResetClaimsCustom resets all changes to the "claims_custom" field.
+func (m *RefreshTokenMutation) ResetClaimsCustom() {
+	m.claims_custom = nil
+	delete(m.clearedFields, refreshtoken.FieldClaimsCustom)
+}
+
+// SetClaimsPreferredUsername sets the "claims_preferred_username" field.
+func (m *RefreshTokenMutation) SetClaimsPreferredUsername(s string) {
+	m.claims_preferred_username = &s
+}
+
+// ClaimsPreferredUsername returns the value of the "claims_preferred_username" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsPreferredUsername() (r string, exists bool) {
+	v := m.claims_preferred_username
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsPreferredUsername returns the old "claims_preferred_username" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsPreferredUsername(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsPreferredUsername is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsPreferredUsername requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsPreferredUsername: %w", err)
+	}
+	return oldValue.ClaimsPreferredUsername, nil
+}
+
+// ResetClaimsPreferredUsername resets all changes to the "claims_preferred_username" field.
+func (m *RefreshTokenMutation) ResetClaimsPreferredUsername() {
+	m.claims_preferred_username = nil
+}
+
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (m *RefreshTokenMutation) SetClaimsAuthTime(t time.Time) {
+	m.claims_auth_time = &t
+}
+
+// ClaimsAuthTime returns the value of the "claims_auth_time" field in the mutation.
+func (m *RefreshTokenMutation) ClaimsAuthTime() (r time.Time, exists bool) {
+	v := m.claims_auth_time
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClaimsAuthTime returns the old "claims_auth_time" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldClaimsAuthTime(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClaimsAuthTime is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClaimsAuthTime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClaimsAuthTime: %w", err)
+	}
+	return oldValue.ClaimsAuthTime, nil
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (m *RefreshTokenMutation) ClearClaimsAuthTime() {
+	m.claims_auth_time = nil
+	m.clearedFields[refreshtoken.FieldClaimsAuthTime] = struct{}{}
+}
+
+// ClaimsAuthTimeCleared returns if the "claims_auth_time" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ClaimsAuthTimeCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldClaimsAuthTime]
+	return ok
+}
+
+// ResetClaimsAuthTime resets all changes to the "claims_auth_time" field.
+func (m *RefreshTokenMutation) ResetClaimsAuthTime() {
+	m.claims_auth_time = nil
+	delete(m.clearedFields, refreshtoken.FieldClaimsAuthTime)
+}
+
+// SetConnectorID sets the "connector_id" field.
+func (m *RefreshTokenMutation) SetConnectorID(s string) {
+	m.connector_id = &s
+}
+
+// ConnectorID returns the value of the "connector_id" field in the mutation.
+func (m *RefreshTokenMutation) ConnectorID() (r string, exists bool) {
+	v := m.connector_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldConnectorID returns the old "connector_id" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldConnectorID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldConnectorID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldConnectorID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldConnectorID: %w", err)
+	}
+	return oldValue.ConnectorID, nil
+}
+
+// ResetConnectorID resets all changes to the "connector_id" field.
+func (m *RefreshTokenMutation) ResetConnectorID() {
+	m.connector_id = nil
+}
+
+// SetConnectorData sets the "connector_data" field.
+func (m *RefreshTokenMutation) SetConnectorData(b []byte) {
+	m.connector_data = &b
+}
+
+// ConnectorData returns the value of the "connector_data" field in the mutation.
+func (m *RefreshTokenMutation) ConnectorData() (r []byte, exists bool) {
+	v := m.connector_data
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldConnectorData returns the old "connector_data" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldConnectorData(ctx context.Context) (v *[]byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldConnectorData is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldConnectorData requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldConnectorData: %w", err)
+	}
+	return oldValue.ConnectorData, nil
+}
+
+// ClearConnectorData clears the value of the "connector_data" field.
+func (m *RefreshTokenMutation) ClearConnectorData() {
+	m.connector_data = nil
+	m.clearedFields[refreshtoken.FieldConnectorData] = struct{}{}
+}
+
+// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
+func (m *RefreshTokenMutation) ConnectorDataCleared() bool {
+	_, ok := m.clearedFields[refreshtoken.FieldConnectorData]
+	return ok
+}
+
+// ResetConnectorData resets all changes to the "connector_data" field.
+func (m *RefreshTokenMutation) ResetConnectorData() {
+	m.connector_data = nil
+	delete(m.clearedFields, refreshtoken.FieldConnectorData)
+}
+
+// SetToken sets the "token" field.
+func (m *RefreshTokenMutation) SetToken(s string) {
+	m.token = &s
+}
+
+// Token returns the value of the "token" field in the mutation.
+func (m *RefreshTokenMutation) Token() (r string, exists bool) {
+	v := m.token
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldToken returns the old "token" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldToken(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldToken is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldToken requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldToken: %w", err)
+	}
+	return oldValue.Token, nil
+}
+
+// ResetToken resets all changes to the "token" field.
+func (m *RefreshTokenMutation) ResetToken() {
+	m.token = nil
+}
+
+// SetObsoleteToken sets the "obsolete_token" field.
+func (m *RefreshTokenMutation) SetObsoleteToken(s string) {
+	m.obsolete_token = &s
+}
+
+// ObsoleteToken returns the value of the "obsolete_token" field in the mutation.
+func (m *RefreshTokenMutation) ObsoleteToken() (r string, exists bool) {
+	v := m.obsolete_token
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldObsoleteToken returns the old "obsolete_token" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldObsoleteToken(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldObsoleteToken is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldObsoleteToken requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldObsoleteToken: %w", err)
+	}
+	return oldValue.ObsoleteToken, nil
+}
+
+// ResetObsoleteToken resets all changes to the "obsolete_token" field.
+func (m *RefreshTokenMutation) ResetObsoleteToken() {
+	m.obsolete_token = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *RefreshTokenMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *RefreshTokenMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *RefreshTokenMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetLastUsed sets the "last_used" field.
+func (m *RefreshTokenMutation) SetLastUsed(t time.Time) {
+	m.last_used = &t
+}
+
+// LastUsed returns the value of the "last_used" field in the mutation.
+func (m *RefreshTokenMutation) LastUsed() (r time.Time, exists bool) {
+	v := m.last_used
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastUsed returns the old "last_used" field's value of the RefreshToken entity.
+// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RefreshTokenMutation) OldLastUsed(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastUsed is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastUsed requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastUsed: %w", err)
+	}
+	return oldValue.LastUsed, nil
+}
+
+// ResetLastUsed resets all changes to the "last_used" field.
+func (m *RefreshTokenMutation) ResetLastUsed() {
+	m.last_used = nil
+}
+
+// Where appends a list predicates to the RefreshTokenMutation builder.
+func (m *RefreshTokenMutation) Where(ps ...predicate.RefreshToken) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *RefreshTokenMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (RefreshToken).
+func (m *RefreshTokenMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *RefreshTokenMutation) Fields() []string {
+	fields := make([]string, 0, 20)
+	if m.client_id != nil {
+		fields = append(fields, refreshtoken.FieldClientID)
+	}
+	if m.scopes != nil {
+		fields = append(fields, refreshtoken.FieldScopes)
+	}
+	if m.resources != nil {
+		fields = append(fields, refreshtoken.FieldResources)
+	}
+	if m.nonce != nil {
+		fields = append(fields, refreshtoken.FieldNonce)
+	}
+	if m.claims_user_id != nil {
+		fields = append(fields, refreshtoken.FieldClaimsUserID)
+	}
+	if m.claims_username != nil {
+		fields = append(fields, refreshtoken.FieldClaimsUsername)
+	}
+	if m.claims_email != nil {
+		fields = append(fields, refreshtoken.FieldClaimsEmail)
+	}
+	if m.claims_email_verified != nil {
+		fields = append(fields, refreshtoken.FieldClaimsEmailVerified)
+	}
+	if m.claims_groups != nil {
+		fields = append(fields, refreshtoken.FieldClaimsGroups)
+	}
+	if m.claims_organizations != nil {
+		fields = append(fields, refreshtoken.FieldClaimsOrganizations)
+	}
+	if m.claims_address != nil {
+		fields = append(fields, refreshtoken.FieldClaimsAddress)
+	}
+	if m.claims_custom != nil {
+		fields = append(fields, refreshtoken.FieldClaimsCustom)
+	}
+	if m.claims_preferred_username != nil {
+		fields = append(fields, refreshtoken.FieldClaimsPreferredUsername)
+	}
+	if m.claims_auth_time != nil {
+		fields = append(fields, refreshtoken.FieldClaimsAuthTime)
+	}
+	if m.connector_id != nil {
+		fields = append(fields, refreshtoken.FieldConnectorID)
+	}
+	if m.connector_data != nil {
+		fields = append(fields, refreshtoken.FieldConnectorData)
+	}
+	if m.token != nil {
+		fields = append(fields, refreshtoken.FieldToken)
+	}
+	if m.obsolete_token != nil {
+		fields = append(fields, refreshtoken.FieldObsoleteToken)
+	}
+	if m.created_at != nil {
+		fields = append(fields, refreshtoken.FieldCreatedAt)
+	}
+	if m.last_used != nil {
+		fields = append(fields, refreshtoken.FieldLastUsed)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *RefreshTokenMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case refreshtoken.FieldClientID:
+		return m.ClientID()
+	case refreshtoken.FieldScopes:
+		return m.Scopes()
+	case refreshtoken.FieldResources:
+		return m.Resources()
+	case refreshtoken.FieldNonce:
+		return m.Nonce()
+	case refreshtoken.FieldClaimsUserID:
+		return m.ClaimsUserID()
+	case refreshtoken.FieldClaimsUsername:
+		return m.ClaimsUsername()
+	case refreshtoken.FieldClaimsEmail:
+		return m.ClaimsEmail()
+	case refreshtoken.FieldClaimsEmailVerified:
+		return m.ClaimsEmailVerified()
+	case refreshtoken.FieldClaimsGroups:
+		return m.ClaimsGroups()
+	case refreshtoken.FieldClaimsOrganizations:
+		return m.ClaimsOrganizations()
+	case refreshtoken.FieldClaimsAddress:
+		return m.ClaimsAddress()
+	case refreshtoken.FieldClaimsCustom:
+		return m.ClaimsCustom()
+	case refreshtoken.FieldClaimsPreferredUsername:
+		return m.ClaimsPreferredUsername()
+	case refreshtoken.FieldClaimsAuthTime:
+		return m.ClaimsAuthTime()
+	case refreshtoken.FieldConnectorID:
+		return m.ConnectorID()
+	case refreshtoken.FieldConnectorData:
+		return m.ConnectorData()
+	case refreshtoken.FieldToken:
+		return m.Token()
+	case refreshtoken.FieldObsoleteToken:
+		return m.ObsoleteToken()
+	case refreshtoken.FieldCreatedAt:
+		return m.CreatedAt()
+	case refreshtoken.FieldLastUsed:
+		return m.LastUsed()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *RefreshTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case refreshtoken.FieldClientID:
+		return m.OldClientID(ctx)
+	case refreshtoken.FieldScopes:
+		return m.OldScopes(ctx)
+	case refreshtoken.FieldResources:
+		return m.OldResources(ctx)
+	case refreshtoken.FieldNonce:
+		return m.OldNonce(ctx)
+	case refreshtoken.FieldClaimsUserID:
+		return m.OldClaimsUserID(ctx)
+	case refreshtoken.FieldClaimsUsername:
+		return m.OldClaimsUsername(ctx)
+	case refreshtoken.FieldClaimsEmail:
+		return m.OldClaimsEmail(ctx)
+	case refreshtoken.FieldClaimsEmailVerified:
+		return m.OldClaimsEmailVerified(ctx)
+	case refreshtoken.FieldClaimsGroups:
+		return m.OldClaimsGroups(ctx)
+	case refreshtoken.FieldClaimsOrganizations:
+		return m.OldClaimsOrganizations(ctx)
+	case refreshtoken.FieldClaimsAddress:
+		return m.OldClaimsAddress(ctx)
+	case refreshtoken.FieldClaimsCustom:
+		return m.OldClaimsCustom(ctx)
+	case refreshtoken.FieldClaimsPreferredUsername:
+		return m.OldClaimsPreferredUsername(ctx)
+	case refreshtoken.FieldClaimsAuthTime:
+		return m.OldClaimsAuthTime(ctx)
+	case refreshtoken.FieldConnectorID:
+		return m.OldConnectorID(ctx)
+	case refreshtoken.FieldConnectorData:
+		return m.OldConnectorData(ctx)
+	case refreshtoken.FieldToken:
+		return m.OldToken(ctx)
+	case refreshtoken.FieldObsoleteToken:
+		return m.OldObsoleteToken(ctx)
+	case refreshtoken.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case refreshtoken.FieldLastUsed:
+		return m.OldLastUsed(ctx)
+	}
+	return nil, fmt.Errorf("unknown RefreshToken field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RefreshTokenMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case refreshtoken.FieldClientID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientID(v)
+		return nil
+	case refreshtoken.FieldScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScopes(v)
+		return nil
+	case refreshtoken.FieldResources:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResources(v)
+		return nil
+	case refreshtoken.FieldNonce:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNonce(v)
+		return nil
+	case refreshtoken.FieldClaimsUserID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsUserID(v)
+		return nil
+	case refreshtoken.FieldClaimsUsername:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsUsername(v)
+		return nil
+	case refreshtoken.FieldClaimsEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsEmail(v)
+		return nil
+	case refreshtoken.FieldClaimsEmailVerified:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsEmailVerified(v)
+		return nil
+	case refreshtoken.FieldClaimsGroups:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsGroups(v)
+		return nil
+	case refreshtoken.FieldClaimsOrganizations:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsOrganizations(v)
+		return nil
+	case refreshtoken.FieldClaimsAddress:
+		v, ok := value.(*storage.Address)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAddress(v)
+		return nil
+	case refreshtoken.FieldClaimsCustom:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsCustom(v)
+		return nil
+	case refreshtoken.FieldClaimsPreferredUsername:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsPreferredUsername(v)
+		return nil
+	case refreshtoken.FieldClaimsAuthTime:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClaimsAuthTime(v)
+		return nil
+	case refreshtoken.FieldConnectorID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConnectorID(v)
+		return nil
+	case refreshtoken.FieldConnectorData:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConnectorData(v)
+		return nil
+	case refreshtoken.FieldToken:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetToken(v)
+		return nil
+	case refreshtoken.FieldObsoleteToken:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetObsoleteToken(v)
+		return nil
+	case refreshtoken.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case refreshtoken.FieldLastUsed:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastUsed(v)
+		return nil
+	}
+	return fmt.Errorf("unknown RefreshToken field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *RefreshTokenMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *RefreshTokenMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RefreshTokenMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown RefreshToken numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *RefreshTokenMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(refreshtoken.FieldScopes) {
+		fields = append(fields, refreshtoken.FieldScopes)
+	}
+	if m.FieldCleared(refreshtoken.FieldResources) {
+		fields = append(fields, refreshtoken.FieldResources)
+	}
+	if m.FieldCleared(refreshtoken.FieldClaimsGroups) {
+		fields = append(fields, refreshtoken.FieldClaimsGroups)
+	}
+	if m.FieldCleared(refreshtoken.FieldClaimsOrganizations) {
+		fields = append(fields, refreshtoken.FieldClaimsOrganizations)
+	}
+	if m.FieldCleared(refreshtoken.FieldClaimsAddress) {
+		fields = append(fields, refreshtoken.FieldClaimsAddress)
+	}
+	if m.FieldCleared(refreshtoken.FieldClaimsCustom) {
+		fields = append(fields, refreshtoken.FieldClaimsCustom)
+	}
+	if m.FieldCleared(refreshtoken.FieldClaimsAuthTime) {
+		fields = append(fields, refreshtoken.FieldClaimsAuthTime)
+	}
+	if m.FieldCleared(refreshtoken.FieldConnectorData) {
+		fields = append(fields, refreshtoken.FieldConnectorData)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *RefreshTokenMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *RefreshTokenMutation) ClearField(name string) error {
+	switch name {
+	case refreshtoken.FieldScopes:
+		m.ClearScopes()
+		return nil
+	case refreshtoken.FieldResources:
+		m.ClearResources()
+		return nil
+	case refreshtoken.FieldClaimsGroups:
+		m.ClearClaimsGroups()
+		return nil
+	case refreshtoken.FieldClaimsOrganizations:
+		m.ClearClaimsOrganizations()
+		return nil
+	case refreshtoken.FieldClaimsAddress:
+		m.ClearClaimsAddress()
+		return nil
+	case refreshtoken.FieldClaimsCustom:
+		m.ClearClaimsCustom()
+		return nil
+	case refreshtoken.FieldClaimsAuthTime:
+		m.ClearClaimsAuthTime()
+		return nil
+	case refreshtoken.FieldConnectorData:
+		m.ClearConnectorData()
+		return nil
+	}
+	return fmt.Errorf("unknown RefreshToken nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *RefreshTokenMutation) ResetField(name string) error {
+	switch name {
+	case refreshtoken.FieldClientID:
+		m.ResetClientID()
+		return nil
+	case refreshtoken.FieldScopes:
+		m.ResetScopes()
+		return nil
+	case refreshtoken.FieldResources:
+		m.ResetResources()
+		return nil
+	case refreshtoken.FieldNonce:
+		m.ResetNonce()
+		return nil
+	case refreshtoken.FieldClaimsUserID:
+		m.ResetClaimsUserID()
+		return nil
+	case refreshtoken.FieldClaimsUsername:
+		m.ResetClaimsUsername()
+		return nil
+	case refreshtoken.FieldClaimsEmail:
+		m.ResetClaimsEmail()
+		return nil
+	case refreshtoken.FieldClaimsEmailVerified:
+		m.ResetClaimsEmailVerified()
+		return nil
+	case refreshtoken.FieldClaimsGroups:
+		m.ResetClaimsGroups()
+		return nil
+	case refreshtoken.FieldClaimsOrganizations:
+		m.ResetClaimsOrganizations()
+		return nil
+	case refreshtoken.FieldClaimsAddress:
+		m.ResetClaimsAddress()
+		return nil
+	case refreshtoken.FieldClaimsCustom:
+		m.ResetClaimsCustom()
+		return nil
+	case refreshtoken.FieldClaimsPreferredUsername:
+		m.ResetClaimsPreferredUsername()
+		return nil
+	case refreshtoken.FieldClaimsAuthTime:
+		m.ResetClaimsAuthTime()
+		return nil
+	case refreshtoken.FieldConnectorID:
+		m.ResetConnectorID()
+		return nil
+	case refreshtoken.FieldConnectorData:
+		m.ResetConnectorData()
+		return nil
+	case refreshtoken.FieldToken:
+		m.ResetToken()
+		return nil
+	case refreshtoken.FieldObsoleteToken:
+		m.ResetObsoleteToken()
+		return nil
+	case refreshtoken.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case refreshtoken.FieldLastUsed:
+		m.ResetLastUsed()
+		return nil
+	}
+	return fmt.Errorf("unknown RefreshToken field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *RefreshTokenMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *RefreshTokenMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *RefreshTokenMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *RefreshTokenMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ClearConnectorData clears the value of the "connector_data" field.
-func (m *RefreshTokenMutation) ClearConnectorData() {
-	m.connector_data = nil
-	m.clearedFields[refreshtoken.FieldConnectorData] = struct{}{}
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *RefreshTokenMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ConnectorDataCleared returns if the "connector_data" field was cleared in this mutation.
-func (m *RefreshTokenMutation) ConnectorDataCleared() bool {
-	_, ok := m.clearedFields[refreshtoken.FieldConnectorData]
-	return ok
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *RefreshTokenMutation) EdgeCleared(name string) bool {
+	return false
 }
 
-// ResetConnectorData resets all changes to the "connector_data" field.
-func (m *RefreshTokenMutation) ResetConnectorData() {
-	m.connector_data = nil
-	delete(m.clearedFields, refreshtoken.FieldConnectorData)
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *RefreshTokenMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown RefreshToken unique edge %s", name)
 }
 
-// SetToken sets the "token" field.
-func (m *RefreshTokenMutation) SetToken(s string) {
-	m.token = &s
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *RefreshTokenMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown RefreshToken edge %s", name)
 }
 
-// Token returns the value of the "token" field in the mutation.
-func (m *RefreshTokenMutation) Token() (r string, exists bool) {
-	v := m.token
-	if v == nil {
-		return
-	}
-	return *v, true
+// UpstreamKeySetMutation represents an operation that mutates the UpstreamKeySet nodes in the graph.
+type UpstreamKeySetMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	key_set       *[]byte
+	updated_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*UpstreamKeySet, error)
+	predicates    []predicate.UpstreamKeySet
 }
 
-// OldToken returns the old "token" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldToken(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldToken is only allowed on UpdateOne operations")
+var _ ent.Mutation = (*UpstreamKeySetMutation)(nil)
+
+// upstreamkeysetOption allows management of the mutation configuration using functional options.
+type upstreamkeysetOption func(*UpstreamKeySetMutation)
+
+// newUpstreamKeySetMutation creates new mutation for the UpstreamKeySet entity.
+func newUpstreamKeySetMutation(c config, op Op, opts ...upstreamkeysetOption) *UpstreamKeySetMutation {
+	m := &UpstreamKeySetMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUpstreamKeySet,
+		clearedFields: make(map[string]struct{}),
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldToken requires an ID field in the mutation")
+	for _, opt := range opts {
+		opt(m)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldToken: %w", err)
+	return m
+}
+
+// withUpstreamKeySetID sets the ID field of the mutation.
+func withUpstreamKeySetID(id string) upstreamkeysetOption {
+	return func(m *UpstreamKeySetMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *UpstreamKeySet
+		)
+		m.oldValue = func(ctx context.Context) (*UpstreamKeySet, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().UpstreamKeySet.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	return oldValue.Token, nil
 }
 
-// ResetToken resets all changes to the "token" field.
-func (m *RefreshTokenMutation) ResetToken() {
-	m.token = nil
+// withUpstreamKeySet sets the old UpstreamKeySet of the mutation.
+func withUpstreamKeySet(node *UpstreamKeySet) upstreamkeysetOption {
+	return func(m *UpstreamKeySetMutation) {
+		m.oldValue = func(context.Context) (*UpstreamKeySet, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
 }
 
-// SetObsoleteToken sets the "obsolete_token" field.
-func (m *RefreshTokenMutation) SetObsoleteToken(s string) {
-	m.obsolete_token = &s
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UpstreamKeySetMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// ObsoleteToken returns the value of the "obsolete_token" field in the mutation.
-func (m *RefreshTokenMutation) ObsoleteToken() (r string, exists bool) {
-	v := m.obsolete_token
-	if v == nil {
-		return
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UpstreamKeySetMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("db: mutation is not running in a transaction")
 	}
-	return *v, true
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// OldObsoleteToken returns the old "obsolete_token" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldObsoleteToken(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldObsoleteToken is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldObsoleteToken requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldObsoleteToken: %w", err)
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of UpstreamKeySet entities.
+func (m *UpstreamKeySetMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UpstreamKeySetMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
 	}
-	return oldValue.ObsoleteToken, nil
+	return *m.id, true
 }
 
-// ResetObsoleteToken resets all changes to the "obsolete_token" field.
-func (m *RefreshTokenMutation) ResetObsoleteToken() {
-	m.obsolete_token = nil
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UpstreamKeySetMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().UpstreamKeySet.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *RefreshTokenMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetKeySet sets the "key_set" field.
+func (m *UpstreamKeySetMutation) SetKeySet(b []byte) {
+	m.key_set = &b
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *RefreshTokenMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// KeySet returns the value of the "key_set" field in the mutation.
+func (m *UpstreamKeySetMutation) KeySet() (r []byte, exists bool) {
+	v := m.key_set
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldKeySet returns the old "key_set" field's value of the UpstreamKeySet entity.
+// If the UpstreamKeySet object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *UpstreamKeySetMutation) OldKeySet(ctx context.Context) (v []byte, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldKeySet is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldKeySet requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldKeySet: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.KeySet, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *RefreshTokenMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetKeySet resets all changes to the "key_set" field.
+func (m *UpstreamKeySetMutation) ResetKeySet() {
+	m.key_set = nil
 }
 
-// SetLastUsed sets the "last_used" field.
-func (m *RefreshTokenMutation) SetLastUsed(t time.Time) {
-	m.last_used = &t
+// SetUpdatedAt sets the "updated_at" field.
+func (m *UpstreamKeySetMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// LastUsed returns the value of the "last_used" field in the mutation.
-func (m *RefreshTokenMutation) LastUsed() (r time.Time, exists bool) {
-	v := m.last_used
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *UpstreamKeySetMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLastUsed returns the old "last_used" field's value of the RefreshToken entity.
-// If the RefreshToken object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the UpstreamKeySet entity.
+// If the UpstreamKeySet object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *RefreshTokenMutation) OldLastUsed(ctx context.Context) (v time.Time, err error) {
+func (m *UpstreamKeySetMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastUsed is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastUsed requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastUsed: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.LastUsed, nil
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetLastUsed resets all changes to the "last_used" field.
-func (m *RefreshTokenMutation) ResetLastUsed() {
-	m.last_used = nil
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *UpstreamKeySetMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// Where appends a list predicates to the RefreshTokenMutation builder.
-func (m *RefreshTokenMutation) Where(ps ...predicate.RefreshToken) {
+// Where appends a list predicates to the UpstreamKeySetMutation builder.
+func (m *UpstreamKeySetMutation) Where(ps ...predicate.UpstreamKeySet) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *RefreshTokenMutation) Op() Op {
+func (m *UpstreamKeySetMutation) Op() Op {
 	return m.op
-}
-
-// Type returns the node type of this mutation (RefreshToken).
-func (m *RefreshTokenMutation) Type() string {
-	return m.typ
-}
-
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *RefreshTokenMutation) Fields() []string {
-	fields := make([]string, 0, 15)
-	if m.client_id != nil {
-		fields = append(fields, refreshtoken.FieldClientID)
-	}
-	if m.scopes != nil {
-		fields = append(fields, refreshtoken.FieldScopes)
-	}
-	if m.nonce != nil {
-		fields = append(fields, refreshtoken.FieldNonce)
-	}
-	if m.claims_user_id != nil {
-		fields = append(fields, refreshtoken.FieldClaimsUserID)
-	}
-	if m.claims_username != nil {
-		fields = append(fields, refreshtoken.FieldClaimsUsername)
-	}
-	if m.claims_email != nil {
-		fields = append(fields, refreshtoken.FieldClaimsEmail)
-	}
-	if m.claims_email_verified != nil {
-		fields = append(fields, refreshtoken.FieldClaimsEmailVerified)
-	}
-	if m.claims_groups != nil {
-		fields = append(fields, refreshtoken.FieldClaimsGroups)
-	}
-	if m.claims_preferred_username != nil {
-		fields = append(fields, refreshtoken.FieldClaimsPreferredUsername)
-	}
-	if m.connector_id != nil {
-		fields = append(fields, refreshtoken.FieldConnectorID)
-	}
-	if m.connector_data != nil {
-		fields = append(fields, refreshtoken.FieldConnectorData)
-	}
-	if m.token != nil {
-		fields = append(fields, refreshtoken.FieldToken)
-	}
-	if m.obsolete_token != nil {
-		fields = append(fields, refreshtoken.FieldObsoleteToken)
-	}
-	if m.created_at != nil {
-		fields = append(fields, refreshtoken.FieldCreatedAt)
+}
+
+// Type returns the node type of this mutation (UpstreamKeySet).
+func (m *UpstreamKeySetMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *UpstreamKeySetMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.key_set != nil {
+		fields = append(fields, upstreamkeyset.FieldKeySet)
 	}
-	if m.last_used != nil {
-		fields = append(fields, refreshtoken.FieldLastUsed)
+	if m.updated_at != nil {
+		fields = append(fields, upstreamkeyset.FieldUpdatedAt)
 	}
 	return fields
 }
@@ -7131,38 +10671,12 @@ func (m *RefreshTokenMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *RefreshTokenMutation) Field(name string) (ent.Value, bool) {
+func (m *UpstreamKeySetMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case refreshtoken.FieldClientID:
-		return m.ClientID()
-	case refreshtoken.FieldScopes:
-		return m.Scopes()
-	case refreshtoken.FieldNonce:
-		return m.Nonce()
-	case refreshtoken.FieldClaimsUserID:
-		return m.ClaimsUserID()
-	case refreshtoken.FieldClaimsUsername:
-		return m.ClaimsUsername()
-	case refreshtoken.FieldClaimsEmail:
-		return m.ClaimsEmail()
-	case refreshtoken.FieldClaimsEmailVerified:
-		return m.ClaimsEmailVerified()
-	case refreshtoken.FieldClaimsGroups:
-		return m.ClaimsGroups()
-	case refreshtoken.FieldClaimsPreferredUsername:
-		return m.ClaimsPreferredUsername()
-	case refreshtoken.FieldConnectorID:
-		return m.ConnectorID()
-	case refreshtoken.FieldConnectorData:
-		return m.ConnectorData()
-	case refreshtoken.FieldToken:
-		return m.Token()
-	case refreshtoken.FieldObsoleteToken:
-		return m.ObsoleteToken()
-	case refreshtoken.FieldCreatedAt:
-		return m.CreatedAt()
-	case refreshtoken.FieldLastUsed:
-		return m.LastUsed()
+	case upstreamkeyset.FieldKeySet:
+		return m.KeySet()
+	case upstreamkeyset.FieldUpdatedAt:
+		return m.UpdatedAt()
 	}
 	return nil, false
 }
@@ -7170,315 +10684,138 @@ func (m *RefreshTokenMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *RefreshTokenMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *UpstreamKeySetMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case refreshtoken.FieldClientID:
-		return m.OldClientID(ctx)
-	case refreshtoken.FieldScopes:
-		return m.OldScopes(ctx)
-	case refreshtoken.FieldNonce:
-		return m.OldNonce(ctx)
-	case refreshtoken.FieldClaimsUserID:
-		return m.OldClaimsUserID(ctx)
-	case refreshtoken.FieldClaimsUsername:
-		return m.OldClaimsUsername(ctx)
-	case refreshtoken.FieldClaimsEmail:
-		return m.OldClaimsEmail(ctx)
-	case refreshtoken.FieldClaimsEmailVerified:
-		return m.OldClaimsEmailVerified(ctx)
-	case refreshtoken.FieldClaimsGroups:
-		return m.OldClaimsGroups(ctx)
-	case refreshtoken.FieldClaimsPreferredUsername:
-		return m.OldClaimsPreferredUsername(ctx)
-	case refreshtoken.FieldConnectorID:
-		return m.OldConnectorID(ctx)
-	case refreshtoken.FieldConnectorData:
-		return m.OldConnectorData(ctx)
-	case refreshtoken.FieldToken:
-		return m.OldToken(ctx)
-	case refreshtoken.FieldObsoleteToken:
-		return m.OldObsoleteToken(ctx)
-	case refreshtoken.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case refreshtoken.FieldLastUsed:
-		return m.OldLastUsed(ctx)
+	case upstreamkeyset.FieldKeySet:
+		return m.OldKeySet(ctx)
+	case upstreamkeyset.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown RefreshToken field %s", name)
+	return nil, fmt.Errorf("unknown UpstreamKeySet field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *RefreshTokenMutation) SetField(name string, value ent.Value) error {
+func (m *UpstreamKeySetMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case refreshtoken.FieldClientID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClientID(v)
-		return nil
-	case refreshtoken.FieldScopes:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetScopes(v)
-		return nil
-	case refreshtoken.FieldNonce:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetNonce(v)
-		return nil
-	case refreshtoken.FieldClaimsUserID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsUserID(v)
-		return nil
-	case refreshtoken.FieldClaimsUsername:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsUsername(v)
-		return nil
-	case refreshtoken.FieldClaimsEmail:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsEmail(v)
-		return nil
-	case refreshtoken.FieldClaimsEmailVerified:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsEmailVerified(v)
-		return nil
-	case refreshtoken.FieldClaimsGroups:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsGroups(v)
-		return nil
-	case refreshtoken.FieldClaimsPreferredUsername:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetClaimsPreferredUsername(v)
-		return nil
-	case refreshtoken.FieldConnectorID:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetConnectorID(v)
-		return nil
-	case refreshtoken.FieldConnectorData:
+	case upstreamkeyset.FieldKeySet:
 		v, ok := value.([]byte)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetConnectorData(v)
-		return nil
-	case refreshtoken.FieldToken:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetToken(v)
-		return nil
-	case refreshtoken.FieldObsoleteToken:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetObsoleteToken(v)
-		return nil
-	case refreshtoken.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
+		m.SetKeySet(v)
 		return nil
-	case refreshtoken.FieldLastUsed:
+	case upstreamkeyset.FieldUpdatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetLastUsed(v)
+		m.SetUpdatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown RefreshToken field %s", name)
+	return fmt.Errorf("unknown UpstreamKeySet field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *RefreshTokenMutation) AddedFields() []string {
+func (m *UpstreamKeySetMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *RefreshTokenMutation) AddedField(name string) (ent.Value, bool) {
+func (m *UpstreamKeySetMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *RefreshTokenMutation) AddField(name string, value ent.Value) error {
+func (m *UpstreamKeySetMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown RefreshToken numeric field %s", name)
+	return fmt.Errorf("unknown UpstreamKeySet numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *RefreshTokenMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(refreshtoken.FieldScopes) {
-		fields = append(fields, refreshtoken.FieldScopes)
-	}
-	if m.FieldCleared(refreshtoken.FieldClaimsGroups) {
-		fields = append(fields, refreshtoken.FieldClaimsGroups)
-	}
-	if m.FieldCleared(refreshtoken.FieldConnectorData) {
-		fields = append(fields, refreshtoken.FieldConnectorData)
-	}
-	return fields
+func (m *UpstreamKeySetMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *RefreshTokenMutation) FieldCleared(name string) bool {
+func (m *UpstreamKeySetMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *RefreshTokenMutation) ClearField(name string) error {
-	switch name {
-	case refreshtoken.FieldScopes:
-		m.ClearScopes()
-		return nil
-	case refreshtoken.FieldClaimsGroups:
-		m.ClearClaimsGroups()
-		return nil
-	case refreshtoken.FieldConnectorData:
-		m.ClearConnectorData()
-		return nil
-	}
-	return fmt.Errorf("unknown RefreshToken nullable field %s", name)
+func (m *UpstreamKeySetMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown UpstreamKeySet nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *RefreshTokenMutation) ResetField(name string) error {
+func (m *UpstreamKeySetMutation) ResetField(name string) error {
 	switch name {
-	case refreshtoken.FieldClientID:
-		m.ResetClientID()
-		return nil
-	case refreshtoken.FieldScopes:
-		m.ResetScopes()
-		return nil
-	case refreshtoken.FieldNonce:
-		m.ResetNonce()
-		return nil
-	case refreshtoken.FieldClaimsUserID:
-		m.ResetClaimsUserID()
-		return nil
-	case refreshtoken.FieldClaimsUsername:
-		m.ResetClaimsUsername()
-		return nil
-	case refreshtoken.FieldClaimsEmail:
-		m.ResetClaimsEmail()
-		return nil
-	case refreshtoken.FieldClaimsEmailVerified:
-		m.ResetClaimsEmailVerified()
-		return nil
-	case refreshtoken.FieldClaimsGroups:
-		m.ResetClaimsGroups()
-		return nil
-	case refreshtoken.FieldClaimsPreferredUsername:
-		m.ResetClaimsPreferredUsername()
-		return nil
-	case refreshtoken.FieldConnectorID:
-		m.ResetConnectorID()
-		return nil
-	case refreshtoken.FieldConnectorData:
-		m.ResetConnectorData()
-		return nil
-	case refreshtoken.FieldToken:
-		m.ResetToken()
-		return nil
-	case refreshtoken.FieldObsoleteToken:
-		m.ResetObsoleteToken()
+	case upstreamkeyset.FieldKeySet:
+		m.ResetKeySet()
 		return nil
-	case refreshtoken.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case refreshtoken.FieldLastUsed:
-		m.ResetLastUsed()
+	case upstreamkeyset.FieldUpdatedAt:
+		m.ResetUpdatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown RefreshToken field %s", name)
+	return fmt.Errorf("unknown UpstreamKeySet field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *RefreshTokenMutation) AddedEdges() []string {
+func (m *UpstreamKeySetMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *RefreshTokenMutation) AddedIDs(name string) []ent.Value {
+func (m *UpstreamKeySetMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *RefreshTokenMutation) RemovedEdges() []string {
+func (m *UpstreamKeySetMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *RefreshTokenMutation) RemovedIDs(name string) []ent.Value {
+func (m *UpstreamKeySetMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *RefreshTokenMutation) ClearedEdges() []string {
+func (m *UpstreamKeySetMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *RefreshTokenMutation) EdgeCleared(name string) bool {
+func (m *UpstreamKeySetMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *RefreshTokenMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown RefreshToken unique edge %s", name)
+func (m *UpstreamKeySetMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown UpstreamKeySet unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *RefreshTokenMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown RefreshToken edge %s", name)
+func (m *UpstreamKeySetMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown UpstreamKeySet edge %s", name)
 }
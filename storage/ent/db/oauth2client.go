@@ -22,12 +22,30 @@ type OAuth2Client struct {
 	RedirectUris []string `json:"redirect_uris,omitempty"`
 	// TrustedPeers holds the value of the "trusted_peers" field.
 	TrustedPeers []string `json:"trusted_peers,omitempty"`
+	// Resources holds the value of the "resources" field.
+	Resources []string `json:"resources,omitempty"`
 	// Public holds the value of the "public" field.
 	Public bool `json:"public,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
 	// LogoURL holds the value of the "logo_url" field.
 	LogoURL string `json:"logo_url,omitempty"`
+	// HashGroups holds the value of the "hash_groups" field.
+	HashGroups bool `json:"hash_groups,omitempty"`
+	// GroupsSalt holds the value of the "groups_salt" field.
+	GroupsSalt string `json:"groups_salt,omitempty"`
+	// RequireVerifiedEmail holds the value of the "require_verified_email" field.
+	RequireVerifiedEmail bool `json:"require_verified_email,omitempty"`
+	// RedirectURIPathPattern holds the value of the "redirect_uri_path_pattern" field.
+	RedirectURIPathPattern string `json:"redirect_uri_path_pattern,omitempty"`
+	// ConsentText holds the value of the "consent_text" field.
+	ConsentText string `json:"consent_text,omitempty"`
+	// PrivacyPolicyURL holds the value of the "privacy_policy_url" field.
+	PrivacyPolicyURL string `json:"privacy_policy_url,omitempty"`
+	// AllowedGroupPrefixes holds the value of the "allowed_group_prefixes" field.
+	AllowedGroupPrefixes []string `json:"allowed_group_prefixes,omitempty"`
+	// AllowedGroupsPattern holds the value of the "allowed_groups_pattern" field.
+	AllowedGroupsPattern string `json:"allowed_groups_pattern,omitempty"`
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -35,11 +53,11 @@ func (*OAuth2Client) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case oauth2client.FieldRedirectUris, oauth2client.FieldTrustedPeers:
+		case oauth2client.FieldRedirectUris, oauth2client.FieldTrustedPeers, oauth2client.FieldResources, oauth2client.FieldAllowedGroupPrefixes:
 			values[i] = new([]byte)
-		case oauth2client.FieldPublic:
+		case oauth2client.FieldPublic, oauth2client.FieldHashGroups, oauth2client.FieldRequireVerifiedEmail:
 			values[i] = new(sql.NullBool)
-		case oauth2client.FieldID, oauth2client.FieldSecret, oauth2client.FieldName, oauth2client.FieldLogoURL:
+		case oauth2client.FieldID, oauth2client.FieldSecret, oauth2client.FieldName, oauth2client.FieldLogoURL, oauth2client.FieldGroupsSalt, oauth2client.FieldRedirectURIPathPattern, oauth2client.FieldConsentText, oauth2client.FieldPrivacyPolicyURL, oauth2client.FieldAllowedGroupsPattern:
 			values[i] = new(sql.NullString)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type OAuth2Client", columns[i])
@@ -84,6 +102,14 @@ func (o *OAuth2Client) assignValues(columns []string, values []interface{}) erro
 					return fmt.Errorf("unmarshal field trusted_peers: %w", err)
 				}
 			}
+		case oauth2client.FieldResources:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field resources", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.Resources); err != nil {
+					return fmt.Errorf("unmarshal field resources: %w", err)
+				}
+			}
 		case oauth2client.FieldPublic:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field public", values[i])
@@ -102,6 +128,56 @@ func (o *OAuth2Client) assignValues(columns []string, values []interface{}) erro
 			} else if value.Valid {
 				o.LogoURL = value.String
 			}
+		case oauth2client.FieldHashGroups:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field hash_groups", values[i])
+			} else if value.Valid {
+				o.HashGroups = value.Bool
+			}
+		case oauth2client.FieldGroupsSalt:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field groups_salt", values[i])
+			} else if value.Valid {
+				o.GroupsSalt = value.String
+			}
+		case oauth2client.FieldRequireVerifiedEmail:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_verified_email", values[i])
+			} else if value.Valid {
+				o.RequireVerifiedEmail = value.Bool
+			}
+		case oauth2client.FieldRedirectURIPathPattern:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field redirect_uri_path_pattern", values[i])
+			} else if value.Valid {
+				o.RedirectURIPathPattern = value.String
+			}
+		case oauth2client.FieldConsentText:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field consent_text", values[i])
+			} else if value.Valid {
+				o.ConsentText = value.String
+			}
+		case oauth2client.FieldPrivacyPolicyURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field privacy_policy_url", values[i])
+			} else if value.Valid {
+				o.PrivacyPolicyURL = value.String
+			}
+		case oauth2client.FieldAllowedGroupPrefixes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_group_prefixes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &o.AllowedGroupPrefixes); err != nil {
+					return fmt.Errorf("unmarshal field allowed_group_prefixes: %w", err)
+				}
+			}
+		case oauth2client.FieldAllowedGroupsPattern:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_groups_pattern", values[i])
+			} else if value.Valid {
+				o.AllowedGroupsPattern = value.String
+			}
 		}
 	}
 	return nil
@@ -136,12 +212,30 @@ func (o *OAuth2Client) String() string {
 	builder.WriteString(fmt.Sprintf("%v", o.RedirectUris))
 	builder.WriteString(", trusted_peers=")
 	builder.WriteString(fmt.Sprintf("%v", o.TrustedPeers))
+	builder.WriteString(", resources=")
+	builder.WriteString(fmt.Sprintf("%v", o.Resources))
 	builder.WriteString(", public=")
 	builder.WriteString(fmt.Sprintf("%v", o.Public))
 	builder.WriteString(", name=")
 	builder.WriteString(o.Name)
 	builder.WriteString(", logo_url=")
 	builder.WriteString(o.LogoURL)
+	builder.WriteString(", hash_groups=")
+	builder.WriteString(fmt.Sprintf("%v", o.HashGroups))
+	builder.WriteString(", groups_salt=")
+	builder.WriteString(o.GroupsSalt)
+	builder.WriteString(", require_verified_email=")
+	builder.WriteString(fmt.Sprintf("%v", o.RequireVerifiedEmail))
+	builder.WriteString(", redirect_uri_path_pattern=")
+	builder.WriteString(o.RedirectURIPathPattern)
+	builder.WriteString(", consent_text=")
+	builder.WriteString(o.ConsentText)
+	builder.WriteString(", privacy_policy_url=")
+	builder.WriteString(o.PrivacyPolicyURL)
+	builder.WriteString(", allowed_group_prefixes=")
+	builder.WriteString(fmt.Sprintf("%v", o.AllowedGroupPrefixes))
+	builder.WriteString(", allowed_groups_pattern=")
+	builder.WriteString(o.AllowedGroupsPattern)
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -13,12 +13,30 @@ const (
 	FieldRedirectUris = "redirect_uris"
 	// FieldTrustedPeers holds the string denoting the trusted_peers field in the database.
 	FieldTrustedPeers = "trusted_peers"
+	// FieldResources holds the string denoting the resources field in the database.
+	FieldResources = "resources"
 	// FieldPublic holds the string denoting the public field in the database.
 	FieldPublic = "public"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
 	// FieldLogoURL holds the string denoting the logo_url field in the database.
 	FieldLogoURL = "logo_url"
+	// FieldHashGroups holds the string denoting the hash_groups field in the database.
+	FieldHashGroups = "hash_groups"
+	// FieldGroupsSalt holds the string denoting the groups_salt field in the database.
+	FieldGroupsSalt = "groups_salt"
+	// FieldRequireVerifiedEmail holds the string denoting the require_verified_email field in the database.
+	FieldRequireVerifiedEmail = "require_verified_email"
+	// FieldRedirectURIPathPattern holds the string denoting the redirect_uri_path_pattern field in the database.
+	FieldRedirectURIPathPattern = "redirect_uri_path_pattern"
+	// FieldConsentText holds the string denoting the consent_text field in the database.
+	FieldConsentText = "consent_text"
+	// FieldPrivacyPolicyURL holds the string denoting the privacy_policy_url field in the database.
+	FieldPrivacyPolicyURL = "privacy_policy_url"
+	// FieldAllowedGroupPrefixes holds the string denoting the allowed_group_prefixes field in the database.
+	FieldAllowedGroupPrefixes = "allowed_group_prefixes"
+	// FieldAllowedGroupsPattern holds the string denoting the allowed_groups_pattern field in the database.
+	FieldAllowedGroupsPattern = "allowed_groups_pattern"
 	// Table holds the table name of the oauth2client in the database.
 	Table = "oauth2clients"
 )
@@ -29,9 +47,18 @@ var Columns = []string{
 	FieldSecret,
 	FieldRedirectUris,
 	FieldTrustedPeers,
+	FieldResources,
 	FieldPublic,
 	FieldName,
 	FieldLogoURL,
+	FieldHashGroups,
+	FieldGroupsSalt,
+	FieldRequireVerifiedEmail,
+	FieldRedirectURIPathPattern,
+	FieldConsentText,
+	FieldPrivacyPolicyURL,
+	FieldAllowedGroupPrefixes,
+	FieldAllowedGroupsPattern,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -51,6 +78,20 @@ var (
 	NameValidator func(string) error
 	// LogoURLValidator is a validator for the "logo_url" field. It is called by the builders before save.
 	LogoURLValidator func(string) error
+	// DefaultHashGroups holds the default value on creation for the "hash_groups" field.
+	DefaultHashGroups bool
+	// DefaultGroupsSalt holds the default value on creation for the "groups_salt" field.
+	DefaultGroupsSalt string
+	// DefaultRequireVerifiedEmail holds the default value on creation for the "require_verified_email" field.
+	DefaultRequireVerifiedEmail bool
+	// DefaultRedirectURIPathPattern holds the default value on creation for the "redirect_uri_path_pattern" field.
+	DefaultRedirectURIPathPattern string
+	// DefaultConsentText holds the default value on creation for the "consent_text" field.
+	DefaultConsentText string
+	// DefaultPrivacyPolicyURL holds the default value on creation for the "privacy_policy_url" field.
+	DefaultPrivacyPolicyURL string
+	// DefaultAllowedGroupsPattern holds the default value on creation for the "allowed_groups_pattern" field.
+	DefaultAllowedGroupsPattern string
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(string) error
 )
@@ -118,6 +118,55 @@ func LogoURL(v string) predicate.OAuth2Client {
 	})
 }
 
+// HashGroups applies equality check predicate on the "hash_groups" field. It's identical to HashGroupsEQ.
+func HashGroups(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldHashGroups), v))
+	})
+}
+
+// GroupsSalt applies equality check predicate on the "groups_salt" field. It's identical to GroupsSaltEQ.
+func GroupsSalt(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// RequireVerifiedEmail applies equality check predicate on the "require_verified_email" field. It's identical to RequireVerifiedEmailEQ.
+func RequireVerifiedEmail(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRequireVerifiedEmail), v))
+	})
+}
+
+// RedirectURIPathPattern applies equality check predicate on the "redirect_uri_path_pattern" field. It's identical to RedirectURIPathPatternEQ.
+func RedirectURIPathPattern(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// ConsentText applies equality check predicate on the "consent_text" field. It's identical to ConsentTextEQ.
+func ConsentText(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldConsentText), v))
+	})
+}
+
+// PrivacyPolicyURL applies equality check predicate on the "privacy_policy_url" field. It's identical to PrivacyPolicyURLEQ.
+func PrivacyPolicyURL(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// AllowedGroupsPattern applies equality check predicate on the "allowed_groups_pattern" field. It's identical to AllowedGroupsPatternEQ.
+func AllowedGroupsPattern(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
 // SecretEQ applies the EQ predicate on the "secret" field.
 func SecretEQ(v string) predicate.OAuth2Client {
 	return predicate.OAuth2Client(func(s *sql.Selector) {
@@ -257,6 +306,20 @@ func TrustedPeersNotNil() predicate.OAuth2Client {
 	})
 }
 
+// ResourcesIsNil applies the IsNil predicate on the "resources" field.
+func ResourcesIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldResources)))
+	})
+}
+
+// ResourcesNotNil applies the NotNil predicate on the "resources" field.
+func ResourcesNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldResources)))
+	})
+}
+
 // PublicEQ applies the EQ predicate on the "public" field.
 func PublicEQ(v bool) predicate.OAuth2Client {
 	return predicate.OAuth2Client(func(s *sql.Selector) {
@@ -493,6 +556,603 @@ func LogoURLContainsFold(v string) predicate.OAuth2Client {
 	})
 }
 
+// HashGroupsEQ applies the EQ predicate on the "hash_groups" field.
+func HashGroupsEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldHashGroups), v))
+	})
+}
+
+// HashGroupsNEQ applies the NEQ predicate on the "hash_groups" field.
+func HashGroupsNEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldHashGroups), v))
+	})
+}
+
+// GroupsSaltEQ applies the EQ predicate on the "groups_salt" field.
+func GroupsSaltEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltNEQ applies the NEQ predicate on the "groups_salt" field.
+func GroupsSaltNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltIn applies the In predicate on the "groups_salt" field.
+func GroupsSaltIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldGroupsSalt), v...))
+	})
+}
+
+// GroupsSaltNotIn applies the NotIn predicate on the "groups_salt" field.
+func GroupsSaltNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldGroupsSalt), v...))
+	})
+}
+
+// GroupsSaltGT applies the GT predicate on the "groups_salt" field.
+func GroupsSaltGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltGTE applies the GTE predicate on the "groups_salt" field.
+func GroupsSaltGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltLT applies the LT predicate on the "groups_salt" field.
+func GroupsSaltLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltLTE applies the LTE predicate on the "groups_salt" field.
+func GroupsSaltLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltContains applies the Contains predicate on the "groups_salt" field.
+func GroupsSaltContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltHasPrefix applies the HasPrefix predicate on the "groups_salt" field.
+func GroupsSaltHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltHasSuffix applies the HasSuffix predicate on the "groups_salt" field.
+func GroupsSaltHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltEqualFold applies the EqualFold predicate on the "groups_salt" field.
+func GroupsSaltEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// GroupsSaltContainsFold applies the ContainsFold predicate on the "groups_salt" field.
+func GroupsSaltContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldGroupsSalt), v))
+	})
+}
+
+// RequireVerifiedEmailEQ applies the EQ predicate on the "require_verified_email" field.
+func RequireVerifiedEmailEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRequireVerifiedEmail), v))
+	})
+}
+
+// RequireVerifiedEmailNEQ applies the NEQ predicate on the "require_verified_email" field.
+func RequireVerifiedEmailNEQ(v bool) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRequireVerifiedEmail), v))
+	})
+}
+
+// RedirectURIPathPatternEQ applies the EQ predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternNEQ applies the NEQ predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternIn applies the In predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldRedirectURIPathPattern), v...))
+	})
+}
+
+// RedirectURIPathPatternNotIn applies the NotIn predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldRedirectURIPathPattern), v...))
+	})
+}
+
+// RedirectURIPathPatternGT applies the GT predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternGTE applies the GTE predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternLT applies the LT predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternLTE applies the LTE predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternContains applies the Contains predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternHasPrefix applies the HasPrefix predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternHasSuffix applies the HasSuffix predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternEqualFold applies the EqualFold predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// RedirectURIPathPatternContainsFold applies the ContainsFold predicate on the "redirect_uri_path_pattern" field.
+func RedirectURIPathPatternContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldRedirectURIPathPattern), v))
+	})
+}
+
+// ConsentTextEQ applies the EQ predicate on the "consent_text" field.
+func ConsentTextEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextNEQ applies the NEQ predicate on the "consent_text" field.
+func ConsentTextNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextIn applies the In predicate on the "consent_text" field.
+func ConsentTextIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldConsentText), v...))
+	})
+}
+
+// ConsentTextNotIn applies the NotIn predicate on the "consent_text" field.
+func ConsentTextNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldConsentText), v...))
+	})
+}
+
+// ConsentTextGT applies the GT predicate on the "consent_text" field.
+func ConsentTextGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextGTE applies the GTE predicate on the "consent_text" field.
+func ConsentTextGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextLT applies the LT predicate on the "consent_text" field.
+func ConsentTextLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextLTE applies the LTE predicate on the "consent_text" field.
+func ConsentTextLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextContains applies the Contains predicate on the "consent_text" field.
+func ConsentTextContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextHasPrefix applies the HasPrefix predicate on the "consent_text" field.
+func ConsentTextHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextHasSuffix applies the HasSuffix predicate on the "consent_text" field.
+func ConsentTextHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextEqualFold applies the EqualFold predicate on the "consent_text" field.
+func ConsentTextEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldConsentText), v))
+	})
+}
+
+// ConsentTextContainsFold applies the ContainsFold predicate on the "consent_text" field.
+func ConsentTextContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldConsentText), v))
+	})
+}
+
+// PrivacyPolicyURLEQ applies the EQ predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLNEQ applies the NEQ predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLIn applies the In predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPrivacyPolicyURL), v...))
+	})
+}
+
+// PrivacyPolicyURLNotIn applies the NotIn predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPrivacyPolicyURL), v...))
+	})
+}
+
+// PrivacyPolicyURLGT applies the GT predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLGTE applies the GTE predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLLT applies the LT predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLLTE applies the LTE predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLContains applies the Contains predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLHasPrefix applies the HasPrefix predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLHasSuffix applies the HasSuffix predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLEqualFold applies the EqualFold predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// PrivacyPolicyURLContainsFold applies the ContainsFold predicate on the "privacy_policy_url" field.
+func PrivacyPolicyURLContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldPrivacyPolicyURL), v))
+	})
+}
+
+// AllowedGroupPrefixesIsNil applies the IsNil predicate on the "allowed_group_prefixes" field.
+func AllowedGroupPrefixesIsNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAllowedGroupPrefixes)))
+	})
+}
+
+// AllowedGroupPrefixesNotNil applies the NotNil predicate on the "allowed_group_prefixes" field.
+func AllowedGroupPrefixesNotNil() predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAllowedGroupPrefixes)))
+	})
+}
+
+// AllowedGroupsPatternEQ applies the EQ predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternNEQ applies the NEQ predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternNEQ(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternIn applies the In predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldAllowedGroupsPattern), v...))
+	})
+}
+
+// AllowedGroupsPatternNotIn applies the NotIn predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternNotIn(vs ...string) predicate.OAuth2Client {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldAllowedGroupsPattern), v...))
+	})
+}
+
+// AllowedGroupsPatternGT applies the GT predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternGT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternGTE applies the GTE predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternGTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternLT applies the LT predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternLT(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternLTE applies the LTE predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternLTE(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternContains applies the Contains predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternContains(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternHasPrefix applies the HasPrefix predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternHasPrefix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternHasSuffix applies the HasSuffix predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternHasSuffix(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternEqualFold applies the EqualFold predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternEqualFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
+// AllowedGroupsPatternContainsFold applies the ContainsFold predicate on the "allowed_groups_pattern" field.
+func AllowedGroupsPatternContainsFold(v string) predicate.OAuth2Client {
+	return predicate.OAuth2Client(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldAllowedGroupsPattern), v))
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.OAuth2Client) predicate.OAuth2Client {
 	return predicate.OAuth2Client(func(s *sql.Selector) {
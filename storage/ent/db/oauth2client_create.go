@@ -37,6 +37,12 @@ func (oc *OAuth2ClientCreate) SetTrustedPeers(s []string) *OAuth2ClientCreate {
 	return oc
 }
 
+// SetResources sets the "resources" field.
+func (oc *OAuth2ClientCreate) SetResources(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetResources(s)
+	return oc
+}
+
 // SetPublic sets the "public" field.
 func (oc *OAuth2ClientCreate) SetPublic(b bool) *OAuth2ClientCreate {
 	oc.mutation.SetPublic(b)
@@ -55,6 +61,110 @@ func (oc *OAuth2ClientCreate) SetLogoURL(s string) *OAuth2ClientCreate {
 	return oc
 }
 
+// SetHashGroups sets the "hash_groups" field.
+func (oc *OAuth2ClientCreate) SetHashGroups(b bool) *OAuth2ClientCreate {
+	oc.mutation.SetHashGroups(b)
+	return oc
+}
+
+// SetNillableHashGroups sets the "hash_groups" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableHashGroups(b *bool) *OAuth2ClientCreate {
+	if b != nil {
+		oc.SetHashGroups(*b)
+	}
+	return oc
+}
+
+// SetGroupsSalt sets the "groups_salt" field.
+func (oc *OAuth2ClientCreate) SetGroupsSalt(s string) *OAuth2ClientCreate {
+	oc.mutation.SetGroupsSalt(s)
+	return oc
+}
+
+// SetNillableGroupsSalt sets the "groups_salt" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableGroupsSalt(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetGroupsSalt(*s)
+	}
+	return oc
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (oc *OAuth2ClientCreate) SetRequireVerifiedEmail(b bool) *OAuth2ClientCreate {
+	oc.mutation.SetRequireVerifiedEmail(b)
+	return oc
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableRequireVerifiedEmail(b *bool) *OAuth2ClientCreate {
+	if b != nil {
+		oc.SetRequireVerifiedEmail(*b)
+	}
+	return oc
+}
+
+// SetRedirectURIPathPattern sets the "redirect_uri_path_pattern" field.
+func (oc *OAuth2ClientCreate) SetRedirectURIPathPattern(s string) *OAuth2ClientCreate {
+	oc.mutation.SetRedirectURIPathPattern(s)
+	return oc
+}
+
+// SetNillableRedirectURIPathPattern sets the "redirect_uri_path_pattern" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableRedirectURIPathPattern(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetRedirectURIPathPattern(*s)
+	}
+	return oc
+}
+
+// SetConsentText sets the "consent_text" field.
+func (oc *OAuth2ClientCreate) SetConsentText(s string) *OAuth2ClientCreate {
+	oc.mutation.SetConsentText(s)
+	return oc
+}
+
+// SetNillableConsentText sets the "consent_text" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableConsentText(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetConsentText(*s)
+	}
+	return oc
+}
+
+// SetPrivacyPolicyURL sets the "privacy_policy_url" field.
+func (oc *OAuth2ClientCreate) SetPrivacyPolicyURL(s string) *OAuth2ClientCreate {
+	oc.mutation.SetPrivacyPolicyURL(s)
+	return oc
+}
+
+// SetNillablePrivacyPolicyURL sets the "privacy_policy_url" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillablePrivacyPolicyURL(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetPrivacyPolicyURL(*s)
+	}
+	return oc
+}
+
+// SetAllowedGroupPrefixes sets the "allowed_group_prefixes" field.
+func (oc *OAuth2ClientCreate) SetAllowedGroupPrefixes(s []string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedGroupPrefixes(s)
+	return oc
+}
+
+// SetAllowedGroupsPattern sets the "allowed_groups_pattern" field.
+func (oc *OAuth2ClientCreate) SetAllowedGroupsPattern(s string) *OAuth2ClientCreate {
+	oc.mutation.SetAllowedGroupsPattern(s)
+	return oc
+}
+
+// SetNillableAllowedGroupsPattern sets the "allowed_groups_pattern" field if the given value is not nil.
+func (oc *OAuth2ClientCreate) SetNillableAllowedGroupsPattern(s *string) *OAuth2ClientCreate {
+	if s != nil {
+		oc.SetAllowedGroupsPattern(*s)
+	}
+	return oc
+}
+
 // SetID sets the "id" field.
 func (oc *OAuth2ClientCreate) SetID(s string) *OAuth2ClientCreate {
 	oc.mutation.SetID(s)
@@ -72,6 +182,7 @@ func (oc *OAuth2ClientCreate) Save(ctx context.Context) (*OAuth2Client, error) {
 		err  error
 		node *OAuth2Client
 	)
+	oc.defaults()
 	if len(oc.hooks) == 0 {
 		if err = oc.check(); err != nil {
 			return nil, err
@@ -129,6 +240,38 @@ func (oc *OAuth2ClientCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (oc *OAuth2ClientCreate) defaults() {
+	if _, ok := oc.mutation.HashGroups(); !ok {
+		v := oauth2client.DefaultHashGroups
+		oc.mutation.SetHashGroups(v)
+	}
+	if _, ok := oc.mutation.GroupsSalt(); !ok {
+		v := oauth2client.DefaultGroupsSalt
+		oc.mutation.SetGroupsSalt(v)
+	}
+	if _, ok := oc.mutation.RequireVerifiedEmail(); !ok {
+		v := oauth2client.DefaultRequireVerifiedEmail
+		oc.mutation.SetRequireVerifiedEmail(v)
+	}
+	if _, ok := oc.mutation.RedirectURIPathPattern(); !ok {
+		v := oauth2client.DefaultRedirectURIPathPattern
+		oc.mutation.SetRedirectURIPathPattern(v)
+	}
+	if _, ok := oc.mutation.ConsentText(); !ok {
+		v := oauth2client.DefaultConsentText
+		oc.mutation.SetConsentText(v)
+	}
+	if _, ok := oc.mutation.PrivacyPolicyURL(); !ok {
+		v := oauth2client.DefaultPrivacyPolicyURL
+		oc.mutation.SetPrivacyPolicyURL(v)
+	}
+	if _, ok := oc.mutation.AllowedGroupsPattern(); !ok {
+		v := oauth2client.DefaultAllowedGroupsPattern
+		oc.mutation.SetAllowedGroupsPattern(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (oc *OAuth2ClientCreate) check() error {
 	if _, ok := oc.mutation.Secret(); !ok {
@@ -158,6 +301,27 @@ func (oc *OAuth2ClientCreate) check() error {
 			return &ValidationError{Name: "logo_url", err: fmt.Errorf(`db: validator failed for field "OAuth2Client.logo_url": %w`, err)}
 		}
 	}
+	if _, ok := oc.mutation.HashGroups(); !ok {
+		return &ValidationError{Name: "hash_groups", err: errors.New(`db: missing required field "OAuth2Client.hash_groups"`)}
+	}
+	if _, ok := oc.mutation.GroupsSalt(); !ok {
+		return &ValidationError{Name: "groups_salt", err: errors.New(`db: missing required field "OAuth2Client.groups_salt"`)}
+	}
+	if _, ok := oc.mutation.RequireVerifiedEmail(); !ok {
+		return &ValidationError{Name: "require_verified_email", err: errors.New(`db: missing required field "OAuth2Client.require_verified_email"`)}
+	}
+	if _, ok := oc.mutation.RedirectURIPathPattern(); !ok {
+		return &ValidationError{Name: "redirect_uri_path_pattern", err: errors.New(`db: missing required field "OAuth2Client.redirect_uri_path_pattern"`)}
+	}
+	if _, ok := oc.mutation.ConsentText(); !ok {
+		return &ValidationError{Name: "consent_text", err: errors.New(`db: missing required field "OAuth2Client.consent_text"`)}
+	}
+	if _, ok := oc.mutation.PrivacyPolicyURL(); !ok {
+		return &ValidationError{Name: "privacy_policy_url", err: errors.New(`db: missing required field "OAuth2Client.privacy_policy_url"`)}
+	}
+	if _, ok := oc.mutation.AllowedGroupsPattern(); !ok {
+		return &ValidationError{Name: "allowed_groups_pattern", err: errors.New(`db: missing required field "OAuth2Client.allowed_groups_pattern"`)}
+	}
 	if v, ok := oc.mutation.ID(); ok {
 		if err := oauth2client.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`db: validator failed for field "OAuth2Client.id": %w`, err)}
@@ -223,6 +387,14 @@ func (oc *OAuth2ClientCreate) createSpec() (*OAuth2Client, *sqlgraph.CreateSpec)
 		})
 		_node.TrustedPeers = value
 	}
+	if value, ok := oc.mutation.Resources(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldResources,
+		})
+		_node.Resources = value
+	}
 	if value, ok := oc.mutation.Public(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -247,6 +419,70 @@ func (oc *OAuth2ClientCreate) createSpec() (*OAuth2Client, *sqlgraph.CreateSpec)
 		})
 		_node.LogoURL = value
 	}
+	if value, ok := oc.mutation.HashGroups(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldHashGroups,
+		})
+		_node.HashGroups = value
+	}
+	if value, ok := oc.mutation.GroupsSalt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldGroupsSalt,
+		})
+		_node.GroupsSalt = value
+	}
+	if value, ok := oc.mutation.RequireVerifiedEmail(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldRequireVerifiedEmail,
+		})
+		_node.RequireVerifiedEmail = value
+	}
+	if value, ok := oc.mutation.RedirectURIPathPattern(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRedirectURIPathPattern,
+		})
+		_node.RedirectURIPathPattern = value
+	}
+	if value, ok := oc.mutation.ConsentText(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldConsentText,
+		})
+		_node.ConsentText = value
+	}
+	if value, ok := oc.mutation.PrivacyPolicyURL(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldPrivacyPolicyURL,
+		})
+		_node.PrivacyPolicyURL = value
+	}
+	if value, ok := oc.mutation.AllowedGroupPrefixes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupPrefixes,
+		})
+		_node.AllowedGroupPrefixes = value
+	}
+	if value, ok := oc.mutation.AllowedGroupsPattern(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupsPattern,
+		})
+		_node.AllowedGroupsPattern = value
+	}
 	return _node, _spec
 }
 
@@ -264,6 +500,7 @@ func (ocb *OAuth2ClientCreateBulk) Save(ctx context.Context) ([]*OAuth2Client, e
 	for i := range ocb.builders {
 		func(i int, root context.Context) {
 			builder := ocb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*OAuth2ClientMutation)
 				if !ok {
@@ -57,6 +57,18 @@ func (ou *OAuth2ClientUpdate) ClearTrustedPeers() *OAuth2ClientUpdate {
 	return ou
 }
 
+// SetResources sets the "resources" field.
+func (ou *OAuth2ClientUpdate) SetResources(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetResources(s)
+	return ou
+}
+
+// ClearResources clears the value of the "resources" field.
+func (ou *OAuth2ClientUpdate) ClearResources() *OAuth2ClientUpdate {
+	ou.mutation.ClearResources()
+	return ou
+}
+
 // SetPublic sets the "public" field.
 func (ou *OAuth2ClientUpdate) SetPublic(b bool) *OAuth2ClientUpdate {
 	ou.mutation.SetPublic(b)
@@ -75,6 +87,116 @@ func (ou *OAuth2ClientUpdate) SetLogoURL(s string) *OAuth2ClientUpdate {
 	return ou
 }
 
+// SetHashGroups sets the "hash_groups" field.
+func (ou *OAuth2ClientUpdate) SetHashGroups(b bool) *OAuth2ClientUpdate {
+	ou.mutation.SetHashGroups(b)
+	return ou
+}
+
+// SetNillableHashGroups sets the "hash_groups" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableHashGroups(b *bool) *OAuth2ClientUpdate {
+	if b != nil {
+		ou.SetHashGroups(*b)
+	}
+	return ou
+}
+
+// SetGroupsSalt sets the "groups_salt" field.
+func (ou *OAuth2ClientUpdate) SetGroupsSalt(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetGroupsSalt(s)
+	return ou
+}
+
+// SetNillableGroupsSalt sets the "groups_salt" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableGroupsSalt(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetGroupsSalt(*s)
+	}
+	return ou
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (ou *OAuth2ClientUpdate) SetRequireVerifiedEmail(b bool) *OAuth2ClientUpdate {
+	ou.mutation.SetRequireVerifiedEmail(b)
+	return ou
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableRequireVerifiedEmail(b *bool) *OAuth2ClientUpdate {
+	if b != nil {
+		ou.SetRequireVerifiedEmail(*b)
+	}
+	return ou
+}
+
+// SetRedirectURIPathPattern sets the "redirect_uri_path_pattern" field.
+func (ou *OAuth2ClientUpdate) SetRedirectURIPathPattern(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetRedirectURIPathPattern(s)
+	return ou
+}
+
+// SetNillableRedirectURIPathPattern sets the "redirect_uri_path_pattern" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableRedirectURIPathPattern(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetRedirectURIPathPattern(*s)
+	}
+	return ou
+}
+
+// SetConsentText sets the "consent_text" field.
+func (ou *OAuth2ClientUpdate) SetConsentText(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetConsentText(s)
+	return ou
+}
+
+// SetNillableConsentText sets the "consent_text" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableConsentText(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetConsentText(*s)
+	}
+	return ou
+}
+
+// SetPrivacyPolicyURL sets the "privacy_policy_url" field.
+func (ou *OAuth2ClientUpdate) SetPrivacyPolicyURL(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetPrivacyPolicyURL(s)
+	return ou
+}
+
+// SetNillablePrivacyPolicyURL sets the "privacy_policy_url" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillablePrivacyPolicyURL(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetPrivacyPolicyURL(*s)
+	}
+	return ou
+}
+
+// SetAllowedGroupPrefixes sets the "allowed_group_prefixes" field.
+func (ou *OAuth2ClientUpdate) SetAllowedGroupPrefixes(s []string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedGroupPrefixes(s)
+	return ou
+}
+
+// ClearAllowedGroupPrefixes clears the value of the "allowed_group_prefixes" field.
+func (ou *OAuth2ClientUpdate) ClearAllowedGroupPrefixes() *OAuth2ClientUpdate {
+	ou.mutation.ClearAllowedGroupPrefixes()
+	return ou
+}
+
+// SetAllowedGroupsPattern sets the "allowed_groups_pattern" field.
+func (ou *OAuth2ClientUpdate) SetAllowedGroupsPattern(s string) *OAuth2ClientUpdate {
+	ou.mutation.SetAllowedGroupsPattern(s)
+	return ou
+}
+
+// SetNillableAllowedGroupsPattern sets the "allowed_groups_pattern" field if the given value is not nil.
+func (ou *OAuth2ClientUpdate) SetNillableAllowedGroupsPattern(s *string) *OAuth2ClientUpdate {
+	if s != nil {
+		ou.SetAllowedGroupsPattern(*s)
+	}
+	return ou
+}
+
 // Mutation returns the OAuth2ClientMutation object of the builder.
 func (ou *OAuth2ClientUpdate) Mutation() *OAuth2ClientMutation {
 	return ou.mutation
@@ -211,6 +333,19 @@ func (ou *OAuth2ClientUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: oauth2client.FieldTrustedPeers,
 		})
 	}
+	if value, ok := ou.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldResources,
+		})
+	}
+	if ou.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldResources,
+		})
+	}
 	if value, ok := ou.mutation.Public(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -232,6 +367,68 @@ func (ou *OAuth2ClientUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: oauth2client.FieldLogoURL,
 		})
 	}
+	if value, ok := ou.mutation.HashGroups(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldHashGroups,
+		})
+	}
+	if value, ok := ou.mutation.GroupsSalt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldGroupsSalt,
+		})
+	}
+	if value, ok := ou.mutation.RequireVerifiedEmail(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldRequireVerifiedEmail,
+		})
+	}
+	if value, ok := ou.mutation.RedirectURIPathPattern(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRedirectURIPathPattern,
+		})
+	}
+	if value, ok := ou.mutation.ConsentText(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldConsentText,
+		})
+	}
+	if value, ok := ou.mutation.PrivacyPolicyURL(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldPrivacyPolicyURL,
+		})
+	}
+	if value, ok := ou.mutation.AllowedGroupPrefixes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupPrefixes,
+		})
+	}
+	if ou.mutation.AllowedGroupPrefixesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedGroupPrefixes,
+		})
+	}
+	if value, ok := ou.mutation.AllowedGroupsPattern(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupsPattern,
+		})
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, ou.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{oauth2client.Label}
@@ -281,6 +478,18 @@ func (ouo *OAuth2ClientUpdateOne) ClearTrustedPeers() *OAuth2ClientUpdateOne {
 	return ouo
 }
 
+// SetResources sets the "resources" field.
+func (ouo *OAuth2ClientUpdateOne) SetResources(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetResources(s)
+	return ouo
+}
+
+// ClearResources clears the value of the "resources" field.
+func (ouo *OAuth2ClientUpdateOne) ClearResources() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearResources()
+	return ouo
+}
+
 // SetPublic sets the "public" field.
 func (ouo *OAuth2ClientUpdateOne) SetPublic(b bool) *OAuth2ClientUpdateOne {
 	ouo.mutation.SetPublic(b)
@@ -299,6 +508,116 @@ func (ouo *OAuth2ClientUpdateOne) SetLogoURL(s string) *OAuth2ClientUpdateOne {
 	return ouo
 }
 
+// SetHashGroups sets the "hash_groups" field.
+func (ouo *OAuth2ClientUpdateOne) SetHashGroups(b bool) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetHashGroups(b)
+	return ouo
+}
+
+// SetNillableHashGroups sets the "hash_groups" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableHashGroups(b *bool) *OAuth2ClientUpdateOne {
+	if b != nil {
+		ouo.SetHashGroups(*b)
+	}
+	return ouo
+}
+
+// SetGroupsSalt sets the "groups_salt" field.
+func (ouo *OAuth2ClientUpdateOne) SetGroupsSalt(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetGroupsSalt(s)
+	return ouo
+}
+
+// SetNillableGroupsSalt sets the "groups_salt" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableGroupsSalt(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetGroupsSalt(*s)
+	}
+	return ouo
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (ouo *OAuth2ClientUpdateOne) SetRequireVerifiedEmail(b bool) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetRequireVerifiedEmail(b)
+	return ouo
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableRequireVerifiedEmail(b *bool) *OAuth2ClientUpdateOne {
+	if b != nil {
+		ouo.SetRequireVerifiedEmail(*b)
+	}
+	return ouo
+}
+
+// SetRedirectURIPathPattern sets the "redirect_uri_path_pattern" field.
+func (ouo *OAuth2ClientUpdateOne) SetRedirectURIPathPattern(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetRedirectURIPathPattern(s)
+	return ouo
+}
+
+// SetNillableRedirectURIPathPattern sets the "redirect_uri_path_pattern" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableRedirectURIPathPattern(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetRedirectURIPathPattern(*s)
+	}
+	return ouo
+}
+
+// SetConsentText sets the "consent_text" field.
+func (ouo *OAuth2ClientUpdateOne) SetConsentText(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetConsentText(s)
+	return ouo
+}
+
+// SetNillableConsentText sets the "consent_text" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableConsentText(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetConsentText(*s)
+	}
+	return ouo
+}
+
+// SetPrivacyPolicyURL sets the "privacy_policy_url" field.
+func (ouo *OAuth2ClientUpdateOne) SetPrivacyPolicyURL(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetPrivacyPolicyURL(s)
+	return ouo
+}
+
+// SetNillablePrivacyPolicyURL sets the "privacy_policy_url" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillablePrivacyPolicyURL(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetPrivacyPolicyURL(*s)
+	}
+	return ouo
+}
+
+// SetAllowedGroupPrefixes sets the "allowed_group_prefixes" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedGroupPrefixes(s []string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedGroupPrefixes(s)
+	return ouo
+}
+
+// ClearAllowedGroupPrefixes clears the value of the "allowed_group_prefixes" field.
+func (ouo *OAuth2ClientUpdateOne) ClearAllowedGroupPrefixes() *OAuth2ClientUpdateOne {
+	ouo.mutation.ClearAllowedGroupPrefixes()
+	return ouo
+}
+
+// SetAllowedGroupsPattern sets the "allowed_groups_pattern" field.
+func (ouo *OAuth2ClientUpdateOne) SetAllowedGroupsPattern(s string) *OAuth2ClientUpdateOne {
+	ouo.mutation.SetAllowedGroupsPattern(s)
+	return ouo
+}
+
+// SetNillableAllowedGroupsPattern sets the "allowed_groups_pattern" field if the given value is not nil.
+func (ouo *OAuth2ClientUpdateOne) SetNillableAllowedGroupsPattern(s *string) *OAuth2ClientUpdateOne {
+	if s != nil {
+		ouo.SetAllowedGroupsPattern(*s)
+	}
+	return ouo
+}
+
 // Mutation returns the OAuth2ClientMutation object of the builder.
 func (ouo *OAuth2ClientUpdateOne) Mutation() *OAuth2ClientMutation {
 	return ouo.mutation
@@ -459,6 +778,19 @@ func (ouo *OAuth2ClientUpdateOne) sqlSave(ctx context.Context) (_node *OAuth2Cli
 			Column: oauth2client.FieldTrustedPeers,
 		})
 	}
+	if value, ok := ouo.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldResources,
+		})
+	}
+	if ouo.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldResources,
+		})
+	}
 	if value, ok := ouo.mutation.Public(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -480,6 +812,68 @@ func (ouo *OAuth2ClientUpdateOne) sqlSave(ctx context.Context) (_node *OAuth2Cli
 			Column: oauth2client.FieldLogoURL,
 		})
 	}
+	if value, ok := ouo.mutation.HashGroups(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldHashGroups,
+		})
+	}
+	if value, ok := ouo.mutation.GroupsSalt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldGroupsSalt,
+		})
+	}
+	if value, ok := ouo.mutation.RequireVerifiedEmail(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBool,
+			Value:  value,
+			Column: oauth2client.FieldRequireVerifiedEmail,
+		})
+	}
+	if value, ok := ouo.mutation.RedirectURIPathPattern(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldRedirectURIPathPattern,
+		})
+	}
+	if value, ok := ouo.mutation.ConsentText(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldConsentText,
+		})
+	}
+	if value, ok := ouo.mutation.PrivacyPolicyURL(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldPrivacyPolicyURL,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedGroupPrefixes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupPrefixes,
+		})
+	}
+	if ouo.mutation.AllowedGroupPrefixesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: oauth2client.FieldAllowedGroupPrefixes,
+		})
+	}
+	if value, ok := ouo.mutation.AllowedGroupsPattern(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: oauth2client.FieldAllowedGroupsPattern,
+		})
+	}
 	_node = &OAuth2Client{config: ouo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
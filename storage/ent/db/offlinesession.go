@@ -5,6 +5,7 @@ package db
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
@@ -23,6 +24,8 @@ type OfflineSession struct {
 	Refresh []byte `json:"refresh,omitempty"`
 	// ConnectorData holds the value of the "connector_data" field.
 	ConnectorData *[]byte `json:"connector_data,omitempty"`
+	// LastLogin holds the value of the "last_login" field.
+	LastLogin time.Time `json:"last_login,omitempty"`
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -34,6 +37,8 @@ func (*OfflineSession) scanValues(columns []string) ([]interface{}, error) {
 			values[i] = new([]byte)
 		case offlinesession.FieldID, offlinesession.FieldUserID, offlinesession.FieldConnID:
 			values[i] = new(sql.NullString)
+		case offlinesession.FieldLastLogin:
+			values[i] = new(sql.NullTime)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type OfflineSession", columns[i])
 		}
@@ -79,6 +84,12 @@ func (os *OfflineSession) assignValues(columns []string, values []interface{}) e
 			} else if value != nil {
 				os.ConnectorData = value
 			}
+		case offlinesession.FieldLastLogin:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_login", values[i])
+			} else if value.Valid {
+				os.LastLogin = value.Time
+			}
 		}
 	}
 	return nil
@@ -117,6 +128,8 @@ func (os *OfflineSession) String() string {
 		builder.WriteString(", connector_data=")
 		builder.WriteString(fmt.Sprintf("%v", *v))
 	}
+	builder.WriteString(", last_login=")
+	builder.WriteString(os.LastLogin.Format(time.ANSIC))
 	builder.WriteByte(')')
 	return builder.String()
 }
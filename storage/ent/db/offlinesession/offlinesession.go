@@ -15,6 +15,8 @@ const (
 	FieldRefresh = "refresh"
 	// FieldConnectorData holds the string denoting the connector_data field in the database.
 	FieldConnectorData = "connector_data"
+	// FieldLastLogin holds the string denoting the last_login field in the database.
+	FieldLastLogin = "last_login"
 	// Table holds the table name of the offlinesession in the database.
 	Table = "offline_sessions"
 )
@@ -26,6 +28,7 @@ var Columns = []string{
 	FieldConnID,
 	FieldRefresh,
 	FieldConnectorData,
+	FieldLastLogin,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -3,6 +3,8 @@
 package offlinesession
 
 import (
+	"time"
+
 	"entgo.io/ent/dialect/sql"
 	"github.com/dexidp/dex/storage/ent/db/predicate"
 )
@@ -118,6 +120,13 @@ func ConnectorData(v []byte) predicate.OfflineSession {
 	})
 }
 
+// LastLogin applies equality check predicate on the "last_login" field. It's identical to LastLoginEQ.
+func LastLogin(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastLogin), v))
+	})
+}
+
 // UserIDEQ applies the EQ predicate on the "user_id" field.
 func UserIDEQ(v string) predicate.OfflineSession {
 	return predicate.OfflineSession(func(s *sql.Selector) {
@@ -506,6 +515,96 @@ func ConnectorDataNotNil() predicate.OfflineSession {
 	})
 }
 
+// LastLoginEQ applies the EQ predicate on the "last_login" field.
+func LastLoginEQ(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginNEQ applies the NEQ predicate on the "last_login" field.
+func LastLoginNEQ(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginIn applies the In predicate on the "last_login" field.
+func LastLoginIn(vs ...time.Time) predicate.OfflineSession {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldLastLogin), v...))
+	})
+}
+
+// LastLoginNotIn applies the NotIn predicate on the "last_login" field.
+func LastLoginNotIn(vs ...time.Time) predicate.OfflineSession {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldLastLogin), v...))
+	})
+}
+
+// LastLoginGT applies the GT predicate on the "last_login" field.
+func LastLoginGT(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginGTE applies the GTE predicate on the "last_login" field.
+func LastLoginGTE(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginLT applies the LT predicate on the "last_login" field.
+func LastLoginLT(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginLTE applies the LTE predicate on the "last_login" field.
+func LastLoginLTE(v time.Time) predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldLastLogin), v))
+	})
+}
+
+// LastLoginIsNil applies the IsNil predicate on the "last_login" field.
+func LastLoginIsNil() predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldLastLogin)))
+	})
+}
+
+// LastLoginNotNil applies the NotNil predicate on the "last_login" field.
+func LastLoginNotNil() predicate.OfflineSession {
+	return predicate.OfflineSession(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldLastLogin)))
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.OfflineSession) predicate.OfflineSession {
 	return predicate.OfflineSession(func(s *sql.Selector) {
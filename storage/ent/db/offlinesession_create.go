@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -43,6 +44,20 @@ func (osc *OfflineSessionCreate) SetConnectorData(b []byte) *OfflineSessionCreat
 	return osc
 }
 
+// SetLastLogin sets the "last_login" field.
+func (osc *OfflineSessionCreate) SetLastLogin(t time.Time) *OfflineSessionCreate {
+	osc.mutation.SetLastLogin(t)
+	return osc
+}
+
+// SetNillableLastLogin sets the "last_login" field if the given value is not nil.
+func (osc *OfflineSessionCreate) SetNillableLastLogin(t *time.Time) *OfflineSessionCreate {
+	if t != nil {
+		osc.SetLastLogin(*t)
+	}
+	return osc
+}
+
 // SetID sets the "id" field.
 func (osc *OfflineSessionCreate) SetID(s string) *OfflineSessionCreate {
 	osc.mutation.SetID(s)
@@ -211,6 +226,14 @@ func (osc *OfflineSessionCreate) createSpec() (*OfflineSession, *sqlgraph.Create
 		})
 		_node.ConnectorData = &value
 	}
+	if value, ok := osc.mutation.LastLogin(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: offlinesession.FieldLastLogin,
+		})
+		_node.LastLogin = value
+	}
 	return _node, _spec
 }
 
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -57,6 +58,26 @@ func (osu *OfflineSessionUpdate) ClearConnectorData() *OfflineSessionUpdate {
 	return osu
 }
 
+// SetLastLogin sets the "last_login" field.
+func (osu *OfflineSessionUpdate) SetLastLogin(t time.Time) *OfflineSessionUpdate {
+	osu.mutation.SetLastLogin(t)
+	return osu
+}
+
+// SetNillableLastLogin sets the "last_login" field if the given value is not nil.
+func (osu *OfflineSessionUpdate) SetNillableLastLogin(t *time.Time) *OfflineSessionUpdate {
+	if t != nil {
+		osu.SetLastLogin(*t)
+	}
+	return osu
+}
+
+// ClearLastLogin clears the value of the "last_login" field.
+func (osu *OfflineSessionUpdate) ClearLastLogin() *OfflineSessionUpdate {
+	osu.mutation.ClearLastLogin()
+	return osu
+}
+
 // Mutation returns the OfflineSessionMutation object of the builder.
 func (osu *OfflineSessionUpdate) Mutation() *OfflineSessionMutation {
 	return osu.mutation
@@ -189,6 +210,19 @@ func (osu *OfflineSessionUpdate) sqlSave(ctx context.Context) (n int, err error)
 			Column: offlinesession.FieldConnectorData,
 		})
 	}
+	if value, ok := osu.mutation.LastLogin(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: offlinesession.FieldLastLogin,
+		})
+	}
+	if osu.mutation.LastLoginCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: offlinesession.FieldLastLogin,
+		})
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, osu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{offlinesession.Label}
@@ -238,6 +272,26 @@ func (osuo *OfflineSessionUpdateOne) ClearConnectorData() *OfflineSessionUpdateO
 	return osuo
 }
 
+// SetLastLogin sets the "last_login" field.
+func (osuo *OfflineSessionUpdateOne) SetLastLogin(t time.Time) *OfflineSessionUpdateOne {
+	osuo.mutation.SetLastLogin(t)
+	return osuo
+}
+
+// SetNillableLastLogin sets the "last_login" field if the given value is not nil.
+func (osuo *OfflineSessionUpdateOne) SetNillableLastLogin(t *time.Time) *OfflineSessionUpdateOne {
+	if t != nil {
+		osuo.SetLastLogin(*t)
+	}
+	return osuo
+}
+
+// ClearLastLogin clears the value of the "last_login" field.
+func (osuo *OfflineSessionUpdateOne) ClearLastLogin() *OfflineSessionUpdateOne {
+	osuo.mutation.ClearLastLogin()
+	return osuo
+}
+
 // Mutation returns the OfflineSessionMutation object of the builder.
 func (osuo *OfflineSessionUpdateOne) Mutation() *OfflineSessionMutation {
 	return osuo.mutation
@@ -394,6 +448,19 @@ func (osuo *OfflineSessionUpdateOne) sqlSave(ctx context.Context) (_node *Offlin
 			Column: offlinesession.FieldConnectorData,
 		})
 	}
+	if value, ok := osuo.mutation.LastLogin(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: offlinesession.FieldLastLogin,
+		})
+	}
+	if osuo.mutation.LastLoginCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: offlinesession.FieldLastLogin,
+		})
+	}
 	_node = &OfflineSession{config: osuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
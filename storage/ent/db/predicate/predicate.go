@@ -21,6 +21,9 @@ type DeviceRequest func(*sql.Selector)
 // DeviceToken is the predicate function for devicetoken builders.
 type DeviceToken func(*sql.Selector)
 
+// IssuedToken is the predicate function for issuedtoken builders.
+type IssuedToken func(*sql.Selector)
+
 // Keys is the predicate function for keys builders.
 type Keys func(*sql.Selector)
 
@@ -33,5 +36,11 @@ type OfflineSession func(*sql.Selector)
 // Password is the predicate function for password builders.
 type Password func(*sql.Selector)
 
+// PushedAuthRequest is the predicate function for pushedauthrequest builders.
+type PushedAuthRequest func(*sql.Selector)
+
 // RefreshToken is the predicate function for refreshtoken builders.
 type RefreshToken func(*sql.Selector)
+
+// UpstreamKeySet is the predicate function for upstreamkeyset builders.
+type UpstreamKeySet func(*sql.Selector)
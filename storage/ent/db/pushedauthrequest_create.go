@@ -0,0 +1,276 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
+)
+
+// PushedAuthRequestCreate is the builder for creating a PushedAuthRequest entity.
+type PushedAuthRequestCreate struct {
+	config
+	mutation *PushedAuthRequestMutation
+	hooks    []Hook
+}
+
+// SetClientID sets the "client_id" field.
+func (parc *PushedAuthRequestCreate) SetClientID(s string) *PushedAuthRequestCreate {
+	parc.mutation.SetClientID(s)
+	return parc
+}
+
+// SetParams sets the "params" field.
+func (parc *PushedAuthRequestCreate) SetParams(m map[string][]string) *PushedAuthRequestCreate {
+	parc.mutation.SetParams(m)
+	return parc
+}
+
+// SetExpiry sets the "expiry" field.
+func (parc *PushedAuthRequestCreate) SetExpiry(t time.Time) *PushedAuthRequestCreate {
+	parc.mutation.SetExpiry(t)
+	return parc
+}
+
+// SetID sets the "id" field.
+func (parc *PushedAuthRequestCreate) SetID(s string) *PushedAuthRequestCreate {
+	parc.mutation.SetID(s)
+	return parc
+}
+
+// Mutation returns the PushedAuthRequestMutation object of the builder.
+func (parc *PushedAuthRequestCreate) Mutation() *PushedAuthRequestMutation {
+	return parc.mutation
+}
+
+// Save creates the PushedAuthRequest in the database.
+func (parc *PushedAuthRequestCreate) Save(ctx context.Context) (*PushedAuthRequest, error) {
+	var (
+		err  error
+		node *PushedAuthRequest
+	)
+	if len(parc.hooks) == 0 {
+		if err = parc.check(); err != nil {
+			return nil, err
+		}
+		node, err = parc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*PushedAuthRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = parc.check(); err != nil {
+				return nil, err
+			}
+			parc.mutation = mutation
+			if node, err = parc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(parc.hooks) - 1; i >= 0; i-- {
+			if parc.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = parc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, parc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (parc *PushedAuthRequestCreate) SaveX(ctx context.Context) *PushedAuthRequest {
+	v, err := parc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (parc *PushedAuthRequestCreate) Exec(ctx context.Context) error {
+	_, err := parc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (parc *PushedAuthRequestCreate) ExecX(ctx context.Context) {
+	if err := parc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (parc *PushedAuthRequestCreate) check() error {
+	if _, ok := parc.mutation.ClientID(); !ok {
+		return &ValidationError{Name: "client_id", err: errors.New(`db: missing required field "PushedAuthRequest.client_id"`)}
+	}
+	if v, ok := parc.mutation.ClientID(); ok {
+		if err := pushedauthrequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "PushedAuthRequest.client_id": %w`, err)}
+		}
+	}
+	if _, ok := parc.mutation.Params(); !ok {
+		return &ValidationError{Name: "params", err: errors.New(`db: missing required field "PushedAuthRequest.params"`)}
+	}
+	if _, ok := parc.mutation.Expiry(); !ok {
+		return &ValidationError{Name: "expiry", err: errors.New(`db: missing required field "PushedAuthRequest.expiry"`)}
+	}
+	if v, ok := parc.mutation.ID(); ok {
+		if err := pushedauthrequest.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`db: validator failed for field "PushedAuthRequest.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (parc *PushedAuthRequestCreate) sqlSave(ctx context.Context) (*PushedAuthRequest, error) {
+	_node, _spec := parc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, parc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected PushedAuthRequest.ID type: %T", _spec.ID.Value)
+		}
+	}
+	return _node, nil
+}
+
+func (parc *PushedAuthRequestCreate) createSpec() (*PushedAuthRequest, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PushedAuthRequest{config: parc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: pushedauthrequest.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: pushedauthrequest.FieldID,
+			},
+		}
+	)
+	if id, ok := parc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := parc.mutation.ClientID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pushedauthrequest.FieldClientID,
+		})
+		_node.ClientID = value
+	}
+	if value, ok := parc.mutation.Params(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: pushedauthrequest.FieldParams,
+		})
+		_node.Params = value
+	}
+	if value, ok := parc.mutation.Expiry(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pushedauthrequest.FieldExpiry,
+		})
+		_node.Expiry = value
+	}
+	return _node, _spec
+}
+
+// PushedAuthRequestCreateBulk is the builder for creating many PushedAuthRequest entities in bulk.
+type PushedAuthRequestCreateBulk struct {
+	config
+	builders []*PushedAuthRequestCreate
+}
+
+// Save creates the PushedAuthRequest entities in the database.
+func (parcb *PushedAuthRequestCreateBulk) Save(ctx context.Context) ([]*PushedAuthRequest, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(parcb.builders))
+	nodes := make([]*PushedAuthRequest, len(parcb.builders))
+	mutators := make([]Mutator, len(parcb.builders))
+	for i := range parcb.builders {
+		func(i int, root context.Context) {
+			builder := parcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PushedAuthRequestMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, parcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, parcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{err.Error(), err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, parcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (parcb *PushedAuthRequestCreateBulk) SaveX(ctx context.Context) []*PushedAuthRequest {
+	v, err := parcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (parcb *PushedAuthRequestCreateBulk) Exec(ctx context.Context) error {
+	_, err := parcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (parcb *PushedAuthRequestCreateBulk) ExecX(ctx context.Context) {
+	if err := parcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
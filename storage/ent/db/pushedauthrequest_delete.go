@@ -0,0 +1,111 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
+)
+
+// PushedAuthRequestDelete is the builder for deleting a PushedAuthRequest entity.
+type PushedAuthRequestDelete struct {
+	config
+	hooks    []Hook
+	mutation *PushedAuthRequestMutation
+}
+
+// Where appends a list predicates to the PushedAuthRequestDelete builder.
+func (pard *PushedAuthRequestDelete) Where(ps ...predicate.PushedAuthRequest) *PushedAuthRequestDelete {
+	pard.mutation.Where(ps...)
+	return pard
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (pard *PushedAuthRequestDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(pard.hooks) == 0 {
+		affected, err = pard.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*PushedAuthRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			pard.mutation = mutation
+			affected, err = pard.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(pard.hooks) - 1; i >= 0; i-- {
+			if pard.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = pard.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, pard.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pard *PushedAuthRequestDelete) ExecX(ctx context.Context) int {
+	n, err := pard.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (pard *PushedAuthRequestDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: pushedauthrequest.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: pushedauthrequest.FieldID,
+			},
+		},
+	}
+	if ps := pard.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, pard.driver, _spec)
+}
+
+// PushedAuthRequestDeleteOne is the builder for deleting a single PushedAuthRequest entity.
+type PushedAuthRequestDeleteOne struct {
+	pard *PushedAuthRequestDelete
+}
+
+// Exec executes the deletion query.
+func (pardo *PushedAuthRequestDeleteOne) Exec(ctx context.Context) error {
+	n, err := pardo.pard.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{pushedauthrequest.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pardo *PushedAuthRequestDeleteOne) ExecX(ctx context.Context) {
+	pardo.pard.ExecX(ctx)
+}
@@ -0,0 +1,919 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
+)
+
+// PushedAuthRequestQuery is the builder for querying PushedAuthRequest entities.
+type PushedAuthRequestQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.PushedAuthRequest
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the PushedAuthRequestQuery builder.
+func (parq *PushedAuthRequestQuery) Where(ps ...predicate.PushedAuthRequest) *PushedAuthRequestQuery {
+	parq.predicates = append(parq.predicates, ps...)
+	return parq
+}
+
+// Limit adds a limit step to the query.
+func (parq *PushedAuthRequestQuery) Limit(limit int) *PushedAuthRequestQuery {
+	parq.limit = &limit
+	return parq
+}
+
+// Offset adds an offset step to the query.
+func (parq *PushedAuthRequestQuery) Offset(offset int) *PushedAuthRequestQuery {
+	parq.offset = &offset
+	return parq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (parq *PushedAuthRequestQuery) Unique(unique bool) *PushedAuthRequestQuery {
+	parq.unique = &unique
+	return parq
+}
+
+// Order adds an order step to the query.
+func (parq *PushedAuthRequestQuery) Order(o ...OrderFunc) *PushedAuthRequestQuery {
+	parq.order = append(parq.order, o...)
+	return parq
+}
+
+// First returns the first PushedAuthRequest entity from the query.
+// Returns a *NotFoundError when no PushedAuthRequest was found.
+func (parq *PushedAuthRequestQuery) First(ctx context.Context) (*PushedAuthRequest, error) {
+	nodes, err := parq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{pushedauthrequest.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) FirstX(ctx context.Context) *PushedAuthRequest {
+	node, err := parq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first PushedAuthRequest ID from the query.
+// Returns a *NotFoundError when no PushedAuthRequest ID was found.
+func (parq *PushedAuthRequestQuery) FirstID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = parq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{pushedauthrequest.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) FirstIDX(ctx context.Context) string {
+	id, err := parq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single PushedAuthRequest entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one PushedAuthRequest entity is found.
+// Returns a *NotFoundError when no PushedAuthRequest entities are found.
+func (parq *PushedAuthRequestQuery) Only(ctx context.Context) (*PushedAuthRequest, error) {
+	nodes, err := parq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{pushedauthrequest.Label}
+	default:
+		return nil, &NotSingularError{pushedauthrequest.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) OnlyX(ctx context.Context) *PushedAuthRequest {
+	node, err := parq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only PushedAuthRequest ID in the query.
+// Returns a *NotSingularError when more than one PushedAuthRequest ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (parq *PushedAuthRequestQuery) OnlyID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = parq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = &NotSingularError{pushedauthrequest.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) OnlyIDX(ctx context.Context) string {
+	id, err := parq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of PushedAuthRequests.
+func (parq *PushedAuthRequestQuery) All(ctx context.Context) ([]*PushedAuthRequest, error) {
+	if err := parq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return parq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) AllX(ctx context.Context) []*PushedAuthRequest {
+	nodes, err := parq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of PushedAuthRequest IDs.
+func (parq *PushedAuthRequestQuery) IDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := parq.Select(pushedauthrequest.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) IDsX(ctx context.Context) []string {
+	ids, err := parq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (parq *PushedAuthRequestQuery) Count(ctx context.Context) (int, error) {
+	if err := parq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return parq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) CountX(ctx context.Context) int {
+	count, err := parq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (parq *PushedAuthRequestQuery) Exist(ctx context.Context) (bool, error) {
+	if err := parq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return parq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (parq *PushedAuthRequestQuery) ExistX(ctx context.Context) bool {
+	exist, err := parq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the PushedAuthRequestQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (parq *PushedAuthRequestQuery) Clone() *PushedAuthRequestQuery {
+	if parq == nil {
+		return nil
+	}
+	return &PushedAuthRequestQuery{
+		config:     parq.config,
+		limit:      parq.limit,
+		offset:     parq.offset,
+		order:      append([]OrderFunc{}, parq.order...),
+		predicates: append([]predicate.PushedAuthRequest{}, parq.predicates...),
+		// clone intermediate query.
+		sql:    parq.sql.Clone(),
+		path:   parq.path,
+		unique: parq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		ClientID string `json:"client_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.PushedAuthRequest.Query().
+//		GroupBy(pushedauthrequest.FieldClientID).
+//		Aggregate(db.Count()).
+//		Scan(ctx, &v)
+func (parq *PushedAuthRequestQuery) GroupBy(field string, fields ...string) *PushedAuthRequestGroupBy {
+	group := &PushedAuthRequestGroupBy{config: parq.config}
+	group.fields = append([]string{field}, fields...)
+	group.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := parq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return parq.sqlQuery(ctx), nil
+	}
+	return group
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		ClientID string `json:"client_id,omitempty"`
+//	}
+//
+//	client.PushedAuthRequest.Query().
+//		Select(pushedauthrequest.FieldClientID).
+//		Scan(ctx, &v)
+func (parq *PushedAuthRequestQuery) Select(fields ...string) *PushedAuthRequestSelect {
+	parq.fields = append(parq.fields, fields...)
+	return &PushedAuthRequestSelect{PushedAuthRequestQuery: parq}
+}
+
+func (parq *PushedAuthRequestQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range parq.fields {
+		if !pushedauthrequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+		}
+	}
+	if parq.path != nil {
+		prev, err := parq.path(ctx)
+		if err != nil {
+			return err
+		}
+		parq.sql = prev
+	}
+	return nil
+}
+
+func (parq *PushedAuthRequestQuery) sqlAll(ctx context.Context) ([]*PushedAuthRequest, error) {
+	var (
+		nodes = []*PushedAuthRequest{}
+		_spec = parq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		node := &PushedAuthRequest{config: parq.config}
+		nodes = append(nodes, node)
+		return node.scanValues(columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		if len(nodes) == 0 {
+			return fmt.Errorf("db: Assign called without calling ScanValues")
+		}
+		node := nodes[len(nodes)-1]
+		return node.assignValues(columns, values)
+	}
+	if err := sqlgraph.QueryNodes(ctx, parq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (parq *PushedAuthRequestQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := parq.querySpec()
+	_spec.Node.Columns = parq.fields
+	if len(parq.fields) > 0 {
+		_spec.Unique = parq.unique != nil && *parq.unique
+	}
+	return sqlgraph.CountNodes(ctx, parq.driver, _spec)
+}
+
+func (parq *PushedAuthRequestQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := parq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("db: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (parq *PushedAuthRequestQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   pushedauthrequest.Table,
+			Columns: pushedauthrequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: pushedauthrequest.FieldID,
+			},
+		},
+		From:   parq.sql,
+		Unique: true,
+	}
+	if unique := parq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := parq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pushedauthrequest.FieldID)
+		for i := range fields {
+			if fields[i] != pushedauthrequest.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := parq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := parq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := parq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := parq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (parq *PushedAuthRequestQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(parq.driver.Dialect())
+	t1 := builder.Table(pushedauthrequest.Table)
+	columns := parq.fields
+	if len(columns) == 0 {
+		columns = pushedauthrequest.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if parq.sql != nil {
+		selector = parq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if parq.unique != nil && *parq.unique {
+		selector.Distinct()
+	}
+	for _, p := range parq.predicates {
+		p(selector)
+	}
+	for _, p := range parq.order {
+		p(selector)
+	}
+	if offset := parq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := parq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// PushedAuthRequestGroupBy is the group-by builder for PushedAuthRequest entities.
+type PushedAuthRequestGroupBy struct {
+	config
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (pargb *PushedAuthRequestGroupBy) Aggregate(fns ...AggregateFunc) *PushedAuthRequestGroupBy {
+	pargb.fns = append(pargb.fns, fns...)
+	return pargb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (pargb *PushedAuthRequestGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := pargb.path(ctx)
+	if err != nil {
+		return err
+	}
+	pargb.sql = query
+	return pargb.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) ScanX(ctx context.Context, v interface{}) {
+	if err := pargb.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Strings(ctx context.Context) ([]string, error) {
+	if len(pargb.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestGroupBy.Strings is not achievable when grouping more than 1 field")
+	}
+	var v []string
+	if err := pargb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) StringsX(ctx context.Context) []string {
+	v, err := pargb.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = pargb.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestGroupBy.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) StringX(ctx context.Context) string {
+	v, err := pargb.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Ints(ctx context.Context) ([]int, error) {
+	if len(pargb.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestGroupBy.Ints is not achievable when grouping more than 1 field")
+	}
+	var v []int
+	if err := pargb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) IntsX(ctx context.Context) []int {
+	v, err := pargb.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = pargb.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestGroupBy.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) IntX(ctx context.Context) int {
+	v, err := pargb.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Float64s(ctx context.Context) ([]float64, error) {
+	if len(pargb.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestGroupBy.Float64s is not achievable when grouping more than 1 field")
+	}
+	var v []float64
+	if err := pargb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) Float64sX(ctx context.Context) []float64 {
+	v, err := pargb.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = pargb.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestGroupBy.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) Float64X(ctx context.Context) float64 {
+	v, err := pargb.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from group-by.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Bools(ctx context.Context) ([]bool, error) {
+	if len(pargb.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestGroupBy.Bools is not achievable when grouping more than 1 field")
+	}
+	var v []bool
+	if err := pargb.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) BoolsX(ctx context.Context) []bool {
+	v, err := pargb.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a group-by query.
+// It is only allowed when executing a group-by query with one field.
+func (pargb *PushedAuthRequestGroupBy) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = pargb.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestGroupBy.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (pargb *PushedAuthRequestGroupBy) BoolX(ctx context.Context) bool {
+	v, err := pargb.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (pargb *PushedAuthRequestGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range pargb.fields {
+		if !pushedauthrequest.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := pargb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := pargb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (pargb *PushedAuthRequestGroupBy) sqlQuery() *sql.Selector {
+	selector := pargb.sql.Select()
+	aggregation := make([]string, 0, len(pargb.fns))
+	for _, fn := range pargb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(pargb.fields)+len(pargb.fns))
+		for _, f := range pargb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(pargb.fields...)...)
+}
+
+// PushedAuthRequestSelect is the builder for selecting fields of PushedAuthRequest entities.
+type PushedAuthRequestSelect struct {
+	*PushedAuthRequestQuery
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (pars *PushedAuthRequestSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := pars.prepareQuery(ctx); err != nil {
+		return err
+	}
+	pars.sql = pars.PushedAuthRequestQuery.sqlQuery(ctx)
+	return pars.sqlScan(ctx, v)
+}
+
+// ScanX is like Scan, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) ScanX(ctx context.Context, v interface{}) {
+	if err := pars.Scan(ctx, v); err != nil {
+		panic(err)
+	}
+}
+
+// Strings returns list of strings from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Strings(ctx context.Context) ([]string, error) {
+	if len(pars.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestSelect.Strings is not achievable when selecting more than 1 field")
+	}
+	var v []string
+	if err := pars.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// StringsX is like Strings, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) StringsX(ctx context.Context) []string {
+	v, err := pars.Strings(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns a single string from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) String(ctx context.Context) (_ string, err error) {
+	var v []string
+	if v, err = pars.Strings(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestSelect.Strings returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// StringX is like String, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) StringX(ctx context.Context) string {
+	v, err := pars.String(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Ints returns list of ints from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Ints(ctx context.Context) ([]int, error) {
+	if len(pars.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestSelect.Ints is not achievable when selecting more than 1 field")
+	}
+	var v []int
+	if err := pars.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// IntsX is like Ints, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) IntsX(ctx context.Context) []int {
+	v, err := pars.Ints(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Int returns a single int from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Int(ctx context.Context) (_ int, err error) {
+	var v []int
+	if v, err = pars.Ints(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestSelect.Ints returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// IntX is like Int, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) IntX(ctx context.Context) int {
+	v, err := pars.Int(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64s returns list of float64s from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Float64s(ctx context.Context) ([]float64, error) {
+	if len(pars.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestSelect.Float64s is not achievable when selecting more than 1 field")
+	}
+	var v []float64
+	if err := pars.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Float64sX is like Float64s, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) Float64sX(ctx context.Context) []float64 {
+	v, err := pars.Float64s(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Float64 returns a single float64 from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Float64(ctx context.Context) (_ float64, err error) {
+	var v []float64
+	if v, err = pars.Float64s(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestSelect.Float64s returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// Float64X is like Float64, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) Float64X(ctx context.Context) float64 {
+	v, err := pars.Float64(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bools returns list of bools from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Bools(ctx context.Context) ([]bool, error) {
+	if len(pars.fields) > 1 {
+		return nil, errors.New("db: PushedAuthRequestSelect.Bools is not achievable when selecting more than 1 field")
+	}
+	var v []bool
+	if err := pars.Scan(ctx, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// BoolsX is like Bools, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) BoolsX(ctx context.Context) []bool {
+	v, err := pars.Bools(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bool returns a single bool from a selector. It is only allowed when selecting one field.
+func (pars *PushedAuthRequestSelect) Bool(ctx context.Context) (_ bool, err error) {
+	var v []bool
+	if v, err = pars.Bools(ctx); err != nil {
+		return
+	}
+	switch len(v) {
+	case 1:
+		return v[0], nil
+	case 0:
+		err = &NotFoundError{pushedauthrequest.Label}
+	default:
+		err = fmt.Errorf("db: PushedAuthRequestSelect.Bools returned %d results when one was expected", len(v))
+	}
+	return
+}
+
+// BoolX is like Bool, but panics if an error occurs.
+func (pars *PushedAuthRequestSelect) BoolX(ctx context.Context) bool {
+	v, err := pars.Bool(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (pars *PushedAuthRequestSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := pars.sql.Query()
+	if err := pars.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,350 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
+)
+
+// PushedAuthRequestUpdate is the builder for updating PushedAuthRequest entities.
+type PushedAuthRequestUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PushedAuthRequestMutation
+}
+
+// Where appends a list predicates to the PushedAuthRequestUpdate builder.
+func (paru *PushedAuthRequestUpdate) Where(ps ...predicate.PushedAuthRequest) *PushedAuthRequestUpdate {
+	paru.mutation.Where(ps...)
+	return paru
+}
+
+// SetClientID sets the "client_id" field.
+func (paru *PushedAuthRequestUpdate) SetClientID(s string) *PushedAuthRequestUpdate {
+	paru.mutation.SetClientID(s)
+	return paru
+}
+
+// SetParams sets the "params" field.
+func (paru *PushedAuthRequestUpdate) SetParams(m map[string][]string) *PushedAuthRequestUpdate {
+	paru.mutation.SetParams(m)
+	return paru
+}
+
+// SetExpiry sets the "expiry" field.
+func (paru *PushedAuthRequestUpdate) SetExpiry(t time.Time) *PushedAuthRequestUpdate {
+	paru.mutation.SetExpiry(t)
+	return paru
+}
+
+// Mutation returns the PushedAuthRequestMutation object of the builder.
+func (paru *PushedAuthRequestUpdate) Mutation() *PushedAuthRequestMutation {
+	return paru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (paru *PushedAuthRequestUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(paru.hooks) == 0 {
+		if err = paru.check(); err != nil {
+			return 0, err
+		}
+		affected, err = paru.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*PushedAuthRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = paru.check(); err != nil {
+				return 0, err
+			}
+			paru.mutation = mutation
+			affected, err = paru.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(paru.hooks) - 1; i >= 0; i-- {
+			if paru.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = paru.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, paru.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (paru *PushedAuthRequestUpdate) SaveX(ctx context.Context) int {
+	affected, err := paru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (paru *PushedAuthRequestUpdate) Exec(ctx context.Context) error {
+	_, err := paru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (paru *PushedAuthRequestUpdate) ExecX(ctx context.Context) {
+	if err := paru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (paru *PushedAuthRequestUpdate) check() error {
+	if v, ok := paru.mutation.ClientID(); ok {
+		if err := pushedauthrequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "PushedAuthRequest.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (paru *PushedAuthRequestUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   pushedauthrequest.Table,
+			Columns: pushedauthrequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: pushedauthrequest.FieldID,
+			},
+		},
+	}
+	if ps := paru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := paru.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pushedauthrequest.FieldClientID,
+		})
+	}
+	if value, ok := paru.mutation.Params(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: pushedauthrequest.FieldParams,
+		})
+	}
+	if value, ok := paru.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pushedauthrequest.FieldExpiry,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, paru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pushedauthrequest.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// PushedAuthRequestUpdateOne is the builder for updating a single PushedAuthRequest entity.
+type PushedAuthRequestUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PushedAuthRequestMutation
+}
+
+// SetClientID sets the "client_id" field.
+func (paruo *PushedAuthRequestUpdateOne) SetClientID(s string) *PushedAuthRequestUpdateOne {
+	paruo.mutation.SetClientID(s)
+	return paruo
+}
+
+// SetParams sets the "params" field.
+func (paruo *PushedAuthRequestUpdateOne) SetParams(m map[string][]string) *PushedAuthRequestUpdateOne {
+	paruo.mutation.SetParams(m)
+	return paruo
+}
+
+// SetExpiry sets the "expiry" field.
+func (paruo *PushedAuthRequestUpdateOne) SetExpiry(t time.Time) *PushedAuthRequestUpdateOne {
+	paruo.mutation.SetExpiry(t)
+	return paruo
+}
+
+// Mutation returns the PushedAuthRequestMutation object of the builder.
+func (paruo *PushedAuthRequestUpdateOne) Mutation() *PushedAuthRequestMutation {
+	return paruo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (paruo *PushedAuthRequestUpdateOne) Select(field string, fields ...string) *PushedAuthRequestUpdateOne {
+	paruo.fields = append([]string{field}, fields...)
+	return paruo
+}
+
+// Save executes the query and returns the updated PushedAuthRequest entity.
+func (paruo *PushedAuthRequestUpdateOne) Save(ctx context.Context) (*PushedAuthRequest, error) {
+	var (
+		err  error
+		node *PushedAuthRequest
+	)
+	if len(paruo.hooks) == 0 {
+		if err = paruo.check(); err != nil {
+			return nil, err
+		}
+		node, err = paruo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*PushedAuthRequestMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = paruo.check(); err != nil {
+				return nil, err
+			}
+			paruo.mutation = mutation
+			node, err = paruo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(paruo.hooks) - 1; i >= 0; i-- {
+			if paruo.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = paruo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, paruo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (paruo *PushedAuthRequestUpdateOne) SaveX(ctx context.Context) *PushedAuthRequest {
+	node, err := paruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (paruo *PushedAuthRequestUpdateOne) Exec(ctx context.Context) error {
+	_, err := paruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (paruo *PushedAuthRequestUpdateOne) ExecX(ctx context.Context) {
+	if err := paruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (paruo *PushedAuthRequestUpdateOne) check() error {
+	if v, ok := paruo.mutation.ClientID(); ok {
+		if err := pushedauthrequest.ClientIDValidator(v); err != nil {
+			return &ValidationError{Name: "client_id", err: fmt.Errorf(`db: validator failed for field "PushedAuthRequest.client_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (paruo *PushedAuthRequestUpdateOne) sqlSave(ctx context.Context) (_node *PushedAuthRequest, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   pushedauthrequest.Table,
+			Columns: pushedauthrequest.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: pushedauthrequest.FieldID,
+			},
+		},
+	}
+	id, ok := paruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`db: missing "PushedAuthRequest.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := paruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pushedauthrequest.FieldID)
+		for _, f := range fields {
+			if !pushedauthrequest.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+			}
+			if f != pushedauthrequest.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := paruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := paruo.mutation.ClientID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: pushedauthrequest.FieldClientID,
+		})
+	}
+	if value, ok := paruo.mutation.Params(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: pushedauthrequest.FieldParams,
+		})
+	}
+	if value, ok := paruo.mutation.Expiry(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: pushedauthrequest.FieldExpiry,
+		})
+	}
+	_node = &PushedAuthRequest{config: paruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, paruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pushedauthrequest.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
 )
 
@@ -21,6 +22,8 @@ type RefreshToken struct {
 	ClientID string `json:"client_id,omitempty"`
 	// Scopes holds the value of the "scopes" field.
 	Scopes []string `json:"scopes,omitempty"`
+	// Resources holds the value of the "resources" field.
+	Resources []string `json:"resources,omitempty"`
 	// Nonce holds the value of the "nonce" field.
 	Nonce string `json:"nonce,omitempty"`
 	// ClaimsUserID holds the value of the "claims_user_id" field.
@@ -33,8 +36,16 @@ type RefreshToken struct {
 	ClaimsEmailVerified bool `json:"claims_email_verified,omitempty"`
 	// ClaimsGroups holds the value of the "claims_groups" field.
 	ClaimsGroups []string `json:"claims_groups,omitempty"`
+	// ClaimsOrganizations holds the value of the "claims_organizations" field.
+	ClaimsOrganizations []string `json:"claims_organizations,omitempty"`
+	// ClaimsAddress holds the value of the "claims_address" field.
+	ClaimsAddress *storage.Address `json:"claims_address,omitempty"`
+	// ClaimsCustom holds the value of the "claims_custom" field.
+	ClaimsCustom map[string]interface{} `json:"claims_custom,omitempty"`
 	// ClaimsPreferredUsername holds the value of the "claims_preferred_username" field.
 	ClaimsPreferredUsername string `json:"claims_preferred_username,omitempty"`
+	// ClaimsAuthTime holds the value of the "claims_auth_time" field.
+	ClaimsAuthTime time.Time `json:"claims_auth_time,omitempty"`
 	// ConnectorID holds the value of the "connector_id" field.
 	ConnectorID string `json:"connector_id,omitempty"`
 	// ConnectorData holds the value of the "connector_data" field.
@@ -54,13 +65,13 @@ func (*RefreshToken) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case refreshtoken.FieldScopes, refreshtoken.FieldClaimsGroups, refreshtoken.FieldConnectorData:
+		case refreshtoken.FieldScopes, refreshtoken.FieldResources, refreshtoken.FieldClaimsGroups, refreshtoken.FieldClaimsOrganizations, refreshtoken.FieldClaimsAddress, refreshtoken.FieldClaimsCustom, refreshtoken.FieldConnectorData:
 			values[i] = new([]byte)
 		case refreshtoken.FieldClaimsEmailVerified:
 			values[i] = new(sql.NullBool)
 		case refreshtoken.FieldID, refreshtoken.FieldClientID, refreshtoken.FieldNonce, refreshtoken.FieldClaimsUserID, refreshtoken.FieldClaimsUsername, refreshtoken.FieldClaimsEmail, refreshtoken.FieldClaimsPreferredUsername, refreshtoken.FieldConnectorID, refreshtoken.FieldToken, refreshtoken.FieldObsoleteToken:
 			values[i] = new(sql.NullString)
-		case refreshtoken.FieldCreatedAt, refreshtoken.FieldLastUsed:
+		case refreshtoken.FieldClaimsAuthTime, refreshtoken.FieldCreatedAt, refreshtoken.FieldLastUsed:
 			values[i] = new(sql.NullTime)
 		default:
 			return nil, fmt.Errorf("unexpected column %q for type RefreshToken", columns[i])
@@ -97,6 +108,14 @@ func (rt *RefreshToken) assignValues(columns []string, values []interface{}) err
 					return fmt.Errorf("unmarshal field scopes: %w", err)
 				}
 			}
+		case refreshtoken.FieldResources:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field resources", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &rt.Resources); err != nil {
+					return fmt.Errorf("unmarshal field resources: %w", err)
+				}
+			}
 		case refreshtoken.FieldNonce:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field nonce", values[i])
@@ -135,12 +154,42 @@ func (rt *RefreshToken) assignValues(columns []string, values []interface{}) err
 					return fmt.Errorf("unmarshal field claims_groups: %w", err)
 				}
 			}
+		case refreshtoken.FieldClaimsOrganizations:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_organizations", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &rt.ClaimsOrganizations); err != nil {
+					return fmt.Errorf("unmarshal field claims_organizations: %w", err)
+				}
+			}
+		case refreshtoken.FieldClaimsAddress:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_address", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &rt.ClaimsAddress); err != nil {
+					return fmt.Errorf("unmarshal field claims_address: %w", err)
+				}
+			}
+		case refreshtoken.FieldClaimsCustom:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_custom", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &rt.ClaimsCustom); err != nil {
+					return fmt.Errorf("unmarshal field claims_custom: %w", err)
+				}
+			}
 		case refreshtoken.FieldClaimsPreferredUsername:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field claims_preferred_username", values[i])
 			} else if value.Valid {
 				rt.ClaimsPreferredUsername = value.String
 			}
+		case refreshtoken.FieldClaimsAuthTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field claims_auth_time", values[i])
+			} else if value.Valid {
+				rt.ClaimsAuthTime = value.Time
+			}
 		case refreshtoken.FieldConnectorID:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field connector_id", values[i])
@@ -209,6 +258,8 @@ func (rt *RefreshToken) String() string {
 	builder.WriteString(rt.ClientID)
 	builder.WriteString(", scopes=")
 	builder.WriteString(fmt.Sprintf("%v", rt.Scopes))
+	builder.WriteString(", resources=")
+	builder.WriteString(fmt.Sprintf("%v", rt.Resources))
 	builder.WriteString(", nonce=")
 	builder.WriteString(rt.Nonce)
 	builder.WriteString(", claims_user_id=")
@@ -221,8 +272,16 @@ func (rt *RefreshToken) String() string {
 	builder.WriteString(fmt.Sprintf("%v", rt.ClaimsEmailVerified))
 	builder.WriteString(", claims_groups=")
 	builder.WriteString(fmt.Sprintf("%v", rt.ClaimsGroups))
+	builder.WriteString(", claims_organizations=")
+	builder.WriteString(fmt.Sprintf("%v", rt.ClaimsOrganizations))
+	builder.WriteString(", claims_address=")
+	builder.WriteString(fmt.Sprintf("%v", rt.ClaimsAddress))
+	builder.WriteString(", claims_custom=")
+	builder.WriteString(fmt.Sprintf("%v", rt.ClaimsCustom))
 	builder.WriteString(", claims_preferred_username=")
 	builder.WriteString(rt.ClaimsPreferredUsername)
+	builder.WriteString(", claims_auth_time=")
+	builder.WriteString(rt.ClaimsAuthTime.Format(time.ANSIC))
 	builder.WriteString(", connector_id=")
 	builder.WriteString(rt.ConnectorID)
 	if v := rt.ConnectorData; v != nil {
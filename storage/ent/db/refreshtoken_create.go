@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
 )
 
@@ -32,6 +33,12 @@ func (rtc *RefreshTokenCreate) SetScopes(s []string) *RefreshTokenCreate {
 	return rtc
 }
 
+// SetResources sets the "resources" field.
+func (rtc *RefreshTokenCreate) SetResources(s []string) *RefreshTokenCreate {
+	rtc.mutation.SetResources(s)
+	return rtc
+}
+
 // SetNonce sets the "nonce" field.
 func (rtc *RefreshTokenCreate) SetNonce(s string) *RefreshTokenCreate {
 	rtc.mutation.SetNonce(s)
@@ -68,6 +75,24 @@ func (rtc *RefreshTokenCreate) SetClaimsGroups(s []string) *RefreshTokenCreate {
 	return rtc
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (rtc *RefreshTokenCreate) SetClaimsOrganizations(s []string) *RefreshTokenCreate {
+	rtc.mutation.SetClaimsOrganizations(s)
+	return rtc
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (rtc *RefreshTokenCreate) SetClaimsAddress(s *storage.Address) *RefreshTokenCreate {
+	rtc.mutation.SetClaimsAddress(s)
+	return rtc
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (rtc *RefreshTokenCreate) SetClaimsCustom(m map[string]interface{}) *RefreshTokenCreate {
+	rtc.mutation.SetClaimsCustom(m)
+	return rtc
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (rtc *RefreshTokenCreate) SetClaimsPreferredUsername(s string) *RefreshTokenCreate {
 	rtc.mutation.SetClaimsPreferredUsername(s)
@@ -82,6 +107,20 @@ func (rtc *RefreshTokenCreate) SetNillableClaimsPreferredUsername(s *string) *Re
 	return rtc
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (rtc *RefreshTokenCreate) SetClaimsAuthTime(t time.Time) *RefreshTokenCreate {
+	rtc.mutation.SetClaimsAuthTime(t)
+	return rtc
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (rtc *RefreshTokenCreate) SetNillableClaimsAuthTime(t *time.Time) *RefreshTokenCreate {
+	if t != nil {
+		rtc.SetClaimsAuthTime(*t)
+	}
+	return rtc
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (rtc *RefreshTokenCreate) SetConnectorID(s string) *RefreshTokenCreate {
 	rtc.mutation.SetConnectorID(s)
@@ -374,6 +413,14 @@ func (rtc *RefreshTokenCreate) createSpec() (*RefreshToken, *sqlgraph.CreateSpec
 		})
 		_node.Scopes = value
 	}
+	if value, ok := rtc.mutation.Resources(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldResources,
+		})
+		_node.Resources = value
+	}
 	if value, ok := rtc.mutation.Nonce(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -422,6 +469,30 @@ func (rtc *RefreshTokenCreate) createSpec() (*RefreshToken, *sqlgraph.CreateSpec
 		})
 		_node.ClaimsGroups = value
 	}
+	if value, ok := rtc.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsOrganizations,
+		})
+		_node.ClaimsOrganizations = value
+	}
+	if value, ok := rtc.mutation.ClaimsAddress(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAddress,
+		})
+		_node.ClaimsAddress = value
+	}
+	if value, ok := rtc.mutation.ClaimsCustom(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsCustom,
+		})
+		_node.ClaimsCustom = value
+	}
 	if value, ok := rtc.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -430,6 +501,14 @@ func (rtc *RefreshTokenCreate) createSpec() (*RefreshToken, *sqlgraph.CreateSpec
 		})
 		_node.ClaimsPreferredUsername = value
 	}
+	if value, ok := rtc.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAuthTime,
+		})
+		_node.ClaimsAuthTime = value
+	}
 	if value, ok := rtc.mutation.ConnectorID(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
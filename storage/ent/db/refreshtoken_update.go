@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/ent/db/predicate"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
 )
@@ -46,6 +47,18 @@ func (rtu *RefreshTokenUpdate) ClearScopes() *RefreshTokenUpdate {
 	return rtu
 }
 
+// SetResources sets the "resources" field.
+func (rtu *RefreshTokenUpdate) SetResources(s []string) *RefreshTokenUpdate {
+	rtu.mutation.SetResources(s)
+	return rtu
+}
+
+// ClearResources clears the value of the "resources" field.
+func (rtu *RefreshTokenUpdate) ClearResources() *RefreshTokenUpdate {
+	rtu.mutation.ClearResources()
+	return rtu
+}
+
 // SetNonce sets the "nonce" field.
 func (rtu *RefreshTokenUpdate) SetNonce(s string) *RefreshTokenUpdate {
 	rtu.mutation.SetNonce(s)
@@ -88,6 +101,42 @@ func (rtu *RefreshTokenUpdate) ClearClaimsGroups() *RefreshTokenUpdate {
 	return rtu
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (rtu *RefreshTokenUpdate) SetClaimsOrganizations(s []string) *RefreshTokenUpdate {
+	rtu.mutation.SetClaimsOrganizations(s)
+	return rtu
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (rtu *RefreshTokenUpdate) ClearClaimsOrganizations() *RefreshTokenUpdate {
+	rtu.mutation.ClearClaimsOrganizations()
+	return rtu
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (rtu *RefreshTokenUpdate) SetClaimsAddress(s *storage.Address) *RefreshTokenUpdate {
+	rtu.mutation.SetClaimsAddress(s)
+	return rtu
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (rtu *RefreshTokenUpdate) ClearClaimsAddress() *RefreshTokenUpdate {
+	rtu.mutation.ClearClaimsAddress()
+	return rtu
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (rtu *RefreshTokenUpdate) SetClaimsCustom(m map[string]interface{}) *RefreshTokenUpdate {
+	rtu.mutation.SetClaimsCustom(m)
+	return rtu
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (rtu *RefreshTokenUpdate) ClearClaimsCustom() *RefreshTokenUpdate {
+	rtu.mutation.ClearClaimsCustom()
+	return rtu
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (rtu *RefreshTokenUpdate) SetClaimsPreferredUsername(s string) *RefreshTokenUpdate {
 	rtu.mutation.SetClaimsPreferredUsername(s)
@@ -102,6 +151,26 @@ func (rtu *RefreshTokenUpdate) SetNillableClaimsPreferredUsername(s *string) *Re
 	return rtu
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (rtu *RefreshTokenUpdate) SetClaimsAuthTime(t time.Time) *RefreshTokenUpdate {
+	rtu.mutation.SetClaimsAuthTime(t)
+	return rtu
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (rtu *RefreshTokenUpdate) SetNillableClaimsAuthTime(t *time.Time) *RefreshTokenUpdate {
+	if t != nil {
+		rtu.SetClaimsAuthTime(*t)
+	}
+	return rtu
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (rtu *RefreshTokenUpdate) ClearClaimsAuthTime() *RefreshTokenUpdate {
+	rtu.mutation.ClearClaimsAuthTime()
+	return rtu
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (rtu *RefreshTokenUpdate) SetConnectorID(s string) *RefreshTokenUpdate {
 	rtu.mutation.SetConnectorID(s)
@@ -314,6 +383,19 @@ func (rtu *RefreshTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: refreshtoken.FieldScopes,
 		})
 	}
+	if value, ok := rtu.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldResources,
+		})
+	}
+	if rtu.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldResources,
+		})
+	}
 	if value, ok := rtu.mutation.Nonce(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -362,6 +444,45 @@ func (rtu *RefreshTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: refreshtoken.FieldClaimsGroups,
 		})
 	}
+	if value, ok := rtu.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsOrganizations,
+		})
+	}
+	if rtu.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := rtu.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAddress,
+		})
+	}
+	if rtu.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsAddress,
+		})
+	}
+	if value, ok := rtu.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsCustom,
+		})
+	}
+	if rtu.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsCustom,
+		})
+	}
 	if value, ok := rtu.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -369,6 +490,19 @@ func (rtu *RefreshTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			Column: refreshtoken.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := rtu.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAuthTime,
+		})
+	}
+	if rtu.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: refreshtoken.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := rtu.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -454,6 +588,18 @@ func (rtuo *RefreshTokenUpdateOne) ClearScopes() *RefreshTokenUpdateOne {
 	return rtuo
 }
 
+// SetResources sets the "resources" field.
+func (rtuo *RefreshTokenUpdateOne) SetResources(s []string) *RefreshTokenUpdateOne {
+	rtuo.mutation.SetResources(s)
+	return rtuo
+}
+
+// ClearResources clears the value of the "resources" field.
+func (rtuo *RefreshTokenUpdateOne) ClearResources() *RefreshTokenUpdateOne {
+	rtuo.mutation.ClearResources()
+	return rtuo
+}
+
 // SetNonce sets the "nonce" field.
 func (rtuo *RefreshTokenUpdateOne) SetNonce(s string) *RefreshTokenUpdateOne {
 	rtuo.mutation.SetNonce(s)
@@ -496,6 +642,42 @@ func (rtuo *RefreshTokenUpdateOne) ClearClaimsGroups() *RefreshTokenUpdateOne {
 	return rtuo
 }
 
+// SetClaimsOrganizations sets the "claims_organizations" field.
+func (rtuo *RefreshTokenUpdateOne) SetClaimsOrganizations(s []string) *RefreshTokenUpdateOne {
+	rtuo.mutation.SetClaimsOrganizations(s)
+	return rtuo
+}
+
+// ClearClaimsOrganizations clears the value of the "claims_organizations" field.
+func (rtuo *RefreshTokenUpdateOne) ClearClaimsOrganizations() *RefreshTokenUpdateOne {
+	rtuo.mutation.ClearClaimsOrganizations()
+	return rtuo
+}
+
+// SetClaimsAddress sets the "claims_address" field.
+func (rtuo *RefreshTokenUpdateOne) SetClaimsAddress(s *storage.Address) *RefreshTokenUpdateOne {
+	rtuo.mutation.SetClaimsAddress(s)
+	return rtuo
+}
+
+// ClearClaimsAddress clears the value of the "claims_address" field.
+func (rtuo *RefreshTokenUpdateOne) ClearClaimsAddress() *RefreshTokenUpdateOne {
+	rtuo.mutation.ClearClaimsAddress()
+	return rtuo
+}
+
+// SetClaimsCustom sets the "claims_custom" field.
+func (rtuo *RefreshTokenUpdateOne) SetClaimsCustom(m map[string]interface{}) *RefreshTokenUpdateOne {
+	rtuo.mutation.SetClaimsCustom(m)
+	return rtuo
+}
+
+// ClearClaimsCustom clears the value of the "claims_custom" field.
+func (rtuo *RefreshTokenUpdateOne) ClearClaimsCustom() *RefreshTokenUpdateOne {
+	rtuo.mutation.ClearClaimsCustom()
+	return rtuo
+}
+
 // SetClaimsPreferredUsername sets the "claims_preferred_username" field.
 func (rtuo *RefreshTokenUpdateOne) SetClaimsPreferredUsername(s string) *RefreshTokenUpdateOne {
 	rtuo.mutation.SetClaimsPreferredUsername(s)
@@ -510,6 +692,26 @@ func (rtuo *RefreshTokenUpdateOne) SetNillableClaimsPreferredUsername(s *string)
 	return rtuo
 }
 
+// SetClaimsAuthTime sets the "claims_auth_time" field.
+func (rtuo *RefreshTokenUpdateOne) SetClaimsAuthTime(t time.Time) *RefreshTokenUpdateOne {
+	rtuo.mutation.SetClaimsAuthTime(t)
+	return rtuo
+}
+
+// SetNillableClaimsAuthTime sets the "claims_auth_time" field if the given value is not nil.
+func (rtuo *RefreshTokenUpdateOne) SetNillableClaimsAuthTime(t *time.Time) *RefreshTokenUpdateOne {
+	if t != nil {
+		rtuo.SetClaimsAuthTime(*t)
+	}
+	return rtuo
+}
+
+// ClearClaimsAuthTime clears the value of the "claims_auth_time" field.
+func (rtuo *RefreshTokenUpdateOne) ClearClaimsAuthTime() *RefreshTokenUpdateOne {
+	rtuo.mutation.ClearClaimsAuthTime()
+	return rtuo
+}
+
 // SetConnectorID sets the "connector_id" field.
 func (rtuo *RefreshTokenUpdateOne) SetConnectorID(s string) *RefreshTokenUpdateOne {
 	rtuo.mutation.SetConnectorID(s)
@@ -746,6 +948,19 @@ func (rtuo *RefreshTokenUpdateOne) sqlSave(ctx context.Context) (_node *RefreshT
 			Column: refreshtoken.FieldScopes,
 		})
 	}
+	if value, ok := rtuo.mutation.Resources(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldResources,
+		})
+	}
+	if rtuo.mutation.ResourcesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldResources,
+		})
+	}
 	if value, ok := rtuo.mutation.Nonce(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -794,6 +1009,45 @@ func (rtuo *RefreshTokenUpdateOne) sqlSave(ctx context.Context) (_node *RefreshT
 			Column: refreshtoken.FieldClaimsGroups,
 		})
 	}
+	if value, ok := rtuo.mutation.ClaimsOrganizations(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsOrganizations,
+		})
+	}
+	if rtuo.mutation.ClaimsOrganizationsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsOrganizations,
+		})
+	}
+	if value, ok := rtuo.mutation.ClaimsAddress(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAddress,
+		})
+	}
+	if rtuo.mutation.ClaimsAddressCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsAddress,
+		})
+	}
+	if value, ok := rtuo.mutation.ClaimsCustom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsCustom,
+		})
+	}
+	if rtuo.mutation.ClaimsCustomCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: refreshtoken.FieldClaimsCustom,
+		})
+	}
 	if value, ok := rtuo.mutation.ClaimsPreferredUsername(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -801,6 +1055,19 @@ func (rtuo *RefreshTokenUpdateOne) sqlSave(ctx context.Context) (_node *RefreshT
 			Column: refreshtoken.FieldClaimsPreferredUsername,
 		})
 	}
+	if value, ok := rtuo.mutation.ClaimsAuthTime(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: refreshtoken.FieldClaimsAuthTime,
+		})
+	}
+	if rtuo.mutation.ClaimsAuthTimeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: refreshtoken.FieldClaimsAuthTime,
+		})
+	}
 	if value, ok := rtuo.mutation.ConnectorID(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
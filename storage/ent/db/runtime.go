@@ -10,11 +10,14 @@ import (
 	"github.com/dexidp/dex/storage/ent/db/connector"
 	"github.com/dexidp/dex/storage/ent/db/devicerequest"
 	"github.com/dexidp/dex/storage/ent/db/devicetoken"
+	"github.com/dexidp/dex/storage/ent/db/issuedtoken"
 	"github.com/dexidp/dex/storage/ent/db/keys"
 	"github.com/dexidp/dex/storage/ent/db/oauth2client"
 	"github.com/dexidp/dex/storage/ent/db/offlinesession"
 	"github.com/dexidp/dex/storage/ent/db/password"
+	"github.com/dexidp/dex/storage/ent/db/pushedauthrequest"
 	"github.com/dexidp/dex/storage/ent/db/refreshtoken"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
 	"github.com/dexidp/dex/storage/ent/schema"
 )
 
@@ -29,39 +32,39 @@ func init() {
 	// authcode.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
 	authcode.ClientIDValidator = authcodeDescClientID.Validators[0].(func(string) error)
 	// authcodeDescNonce is the schema descriptor for nonce field.
-	authcodeDescNonce := authcodeFields[3].Descriptor()
+	authcodeDescNonce := authcodeFields[4].Descriptor()
 	// authcode.NonceValidator is a validator for the "nonce" field. It is called by the builders before save.
 	authcode.NonceValidator = authcodeDescNonce.Validators[0].(func(string) error)
 	// authcodeDescRedirectURI is the schema descriptor for redirect_uri field.
-	authcodeDescRedirectURI := authcodeFields[4].Descriptor()
+	authcodeDescRedirectURI := authcodeFields[5].Descriptor()
 	// authcode.RedirectURIValidator is a validator for the "redirect_uri" field. It is called by the builders before save.
 	authcode.RedirectURIValidator = authcodeDescRedirectURI.Validators[0].(func(string) error)
 	// authcodeDescClaimsUserID is the schema descriptor for claims_user_id field.
-	authcodeDescClaimsUserID := authcodeFields[5].Descriptor()
+	authcodeDescClaimsUserID := authcodeFields[6].Descriptor()
 	// authcode.ClaimsUserIDValidator is a validator for the "claims_user_id" field. It is called by the builders before save.
 	authcode.ClaimsUserIDValidator = authcodeDescClaimsUserID.Validators[0].(func(string) error)
 	// authcodeDescClaimsUsername is the schema descriptor for claims_username field.
-	authcodeDescClaimsUsername := authcodeFields[6].Descriptor()
+	authcodeDescClaimsUsername := authcodeFields[7].Descriptor()
 	// authcode.ClaimsUsernameValidator is a validator for the "claims_username" field. It is called by the builders before save.
 	authcode.ClaimsUsernameValidator = authcodeDescClaimsUsername.Validators[0].(func(string) error)
 	// authcodeDescClaimsEmail is the schema descriptor for claims_email field.
-	authcodeDescClaimsEmail := authcodeFields[7].Descriptor()
+	authcodeDescClaimsEmail := authcodeFields[8].Descriptor()
 	// authcode.ClaimsEmailValidator is a validator for the "claims_email" field. It is called by the builders before save.
 	authcode.ClaimsEmailValidator = authcodeDescClaimsEmail.Validators[0].(func(string) error)
 	// authcodeDescClaimsPreferredUsername is the schema descriptor for claims_preferred_username field.
-	authcodeDescClaimsPreferredUsername := authcodeFields[10].Descriptor()
+	authcodeDescClaimsPreferredUsername := authcodeFields[14].Descriptor()
 	// authcode.DefaultClaimsPreferredUsername holds the default value on creation for the claims_preferred_username field.
 	authcode.DefaultClaimsPreferredUsername = authcodeDescClaimsPreferredUsername.Default.(string)
 	// authcodeDescConnectorID is the schema descriptor for connector_id field.
-	authcodeDescConnectorID := authcodeFields[11].Descriptor()
+	authcodeDescConnectorID := authcodeFields[16].Descriptor()
 	// authcode.ConnectorIDValidator is a validator for the "connector_id" field. It is called by the builders before save.
 	authcode.ConnectorIDValidator = authcodeDescConnectorID.Validators[0].(func(string) error)
 	// authcodeDescCodeChallenge is the schema descriptor for code_challenge field.
-	authcodeDescCodeChallenge := authcodeFields[14].Descriptor()
+	authcodeDescCodeChallenge := authcodeFields[19].Descriptor()
 	// authcode.DefaultCodeChallenge holds the default value on creation for the code_challenge field.
 	authcode.DefaultCodeChallenge = authcodeDescCodeChallenge.Default.(string)
 	// authcodeDescCodeChallengeMethod is the schema descriptor for code_challenge_method field.
-	authcodeDescCodeChallengeMethod := authcodeFields[15].Descriptor()
+	authcodeDescCodeChallengeMethod := authcodeFields[20].Descriptor()
 	// authcode.DefaultCodeChallengeMethod holds the default value on creation for the code_challenge_method field.
 	authcode.DefaultCodeChallengeMethod = authcodeDescCodeChallengeMethod.Default.(string)
 	// authcodeDescID is the schema descriptor for id field.
@@ -70,16 +73,20 @@ func init() {
 	authcode.IDValidator = authcodeDescID.Validators[0].(func(string) error)
 	authrequestFields := schema.AuthRequest{}.Fields()
 	_ = authrequestFields
+	// authrequestDescPrompt is the schema descriptor for prompt field.
+	authrequestDescPrompt := authrequestFields[8].Descriptor()
+	// authrequest.DefaultPrompt holds the default value on creation for the prompt field.
+	authrequest.DefaultPrompt = authrequestDescPrompt.Default.(string)
 	// authrequestDescClaimsPreferredUsername is the schema descriptor for claims_preferred_username field.
-	authrequestDescClaimsPreferredUsername := authrequestFields[14].Descriptor()
+	authrequestDescClaimsPreferredUsername := authrequestFields[22].Descriptor()
 	// authrequest.DefaultClaimsPreferredUsername holds the default value on creation for the claims_preferred_username field.
 	authrequest.DefaultClaimsPreferredUsername = authrequestDescClaimsPreferredUsername.Default.(string)
 	// authrequestDescCodeChallenge is the schema descriptor for code_challenge field.
-	authrequestDescCodeChallenge := authrequestFields[18].Descriptor()
+	authrequestDescCodeChallenge := authrequestFields[27].Descriptor()
 	// authrequest.DefaultCodeChallenge holds the default value on creation for the code_challenge field.
 	authrequest.DefaultCodeChallenge = authrequestDescCodeChallenge.Default.(string)
 	// authrequestDescCodeChallengeMethod is the schema descriptor for code_challenge_method field.
-	authrequestDescCodeChallengeMethod := authrequestFields[19].Descriptor()
+	authrequestDescCodeChallengeMethod := authrequestFields[28].Descriptor()
 	// authrequest.DefaultCodeChallengeMethod holds the default value on creation for the code_challenge_method field.
 	authrequest.DefaultCodeChallengeMethod = authrequestDescCodeChallengeMethod.Default.(string)
 	// authrequestDescID is the schema descriptor for id field.
@@ -142,6 +149,16 @@ func init() {
 	devicetokenDescStatus := devicetokenFields[1].Descriptor()
 	// devicetoken.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	devicetoken.StatusValidator = devicetokenDescStatus.Validators[0].(func(string) error)
+	issuedtokenFields := schema.IssuedToken{}.Fields()
+	_ = issuedtokenFields
+	// issuedtokenDescClientID is the schema descriptor for client_id field.
+	issuedtokenDescClientID := issuedtokenFields[1].Descriptor()
+	// issuedtoken.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
+	issuedtoken.ClientIDValidator = issuedtokenDescClientID.Validators[0].(func(string) error)
+	// issuedtokenDescID is the schema descriptor for id field.
+	issuedtokenDescID := issuedtokenFields[0].Descriptor()
+	// issuedtoken.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	issuedtoken.IDValidator = issuedtokenDescID.Validators[0].(func(string) error)
 	keysFields := schema.Keys{}.Fields()
 	_ = keysFields
 	// keysDescID is the schema descriptor for id field.
@@ -155,13 +172,41 @@ func init() {
 	// oauth2client.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
 	oauth2client.SecretValidator = oauth2clientDescSecret.Validators[0].(func(string) error)
 	// oauth2clientDescName is the schema descriptor for name field.
-	oauth2clientDescName := oauth2clientFields[5].Descriptor()
+	oauth2clientDescName := oauth2clientFields[6].Descriptor()
 	// oauth2client.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	oauth2client.NameValidator = oauth2clientDescName.Validators[0].(func(string) error)
 	// oauth2clientDescLogoURL is the schema descriptor for logo_url field.
-	oauth2clientDescLogoURL := oauth2clientFields[6].Descriptor()
+	oauth2clientDescLogoURL := oauth2clientFields[7].Descriptor()
 	// oauth2client.LogoURLValidator is a validator for the "logo_url" field. It is called by the builders before save.
 	oauth2client.LogoURLValidator = oauth2clientDescLogoURL.Validators[0].(func(string) error)
+	// oauth2clientDescHashGroups is the schema descriptor for hash_groups field.
+	oauth2clientDescHashGroups := oauth2clientFields[8].Descriptor()
+	// oauth2client.DefaultHashGroups holds the default value on creation for the hash_groups field.
+	oauth2client.DefaultHashGroups = oauth2clientDescHashGroups.Default.(bool)
+	// oauth2clientDescGroupsSalt is the schema descriptor for groups_salt field.
+	oauth2clientDescGroupsSalt := oauth2clientFields[9].Descriptor()
+	// oauth2client.DefaultGroupsSalt holds the default value on creation for the groups_salt field.
+	oauth2client.DefaultGroupsSalt = oauth2clientDescGroupsSalt.Default.(string)
+	// oauth2clientDescRequireVerifiedEmail is the schema descriptor for require_verified_email field.
+	oauth2clientDescRequireVerifiedEmail := oauth2clientFields[10].Descriptor()
+	// oauth2client.DefaultRequireVerifiedEmail holds the default value on creation for the require_verified_email field.
+	oauth2client.DefaultRequireVerifiedEmail = oauth2clientDescRequireVerifiedEmail.Default.(bool)
+	// oauth2clientDescRedirectURIPathPattern is the schema descriptor for redirect_uri_path_pattern field.
+	oauth2clientDescRedirectURIPathPattern := oauth2clientFields[11].Descriptor()
+	// oauth2client.DefaultRedirectURIPathPattern holds the default value on creation for the redirect_uri_path_pattern field.
+	oauth2client.DefaultRedirectURIPathPattern = oauth2clientDescRedirectURIPathPattern.Default.(string)
+	// oauth2clientDescConsentText is the schema descriptor for consent_text field.
+	oauth2clientDescConsentText := oauth2clientFields[12].Descriptor()
+	// oauth2client.DefaultConsentText holds the default value on creation for the consent_text field.
+	oauth2client.DefaultConsentText = oauth2clientDescConsentText.Default.(string)
+	// oauth2clientDescPrivacyPolicyURL is the schema descriptor for privacy_policy_url field.
+	oauth2clientDescPrivacyPolicyURL := oauth2clientFields[13].Descriptor()
+	// oauth2client.DefaultPrivacyPolicyURL holds the default value on creation for the privacy_policy_url field.
+	oauth2client.DefaultPrivacyPolicyURL = oauth2clientDescPrivacyPolicyURL.Default.(string)
+	// oauth2clientDescAllowedGroupsPattern is the schema descriptor for allowed_groups_pattern field.
+	oauth2clientDescAllowedGroupsPattern := oauth2clientFields[15].Descriptor()
+	// oauth2client.DefaultAllowedGroupsPattern holds the default value on creation for the allowed_groups_pattern field.
+	oauth2client.DefaultAllowedGroupsPattern = oauth2clientDescAllowedGroupsPattern.Default.(string)
 	// oauth2clientDescID is the schema descriptor for id field.
 	oauth2clientDescID := oauth2clientFields[0].Descriptor()
 	// oauth2client.IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -208,6 +253,16 @@ func init() {
 	passwordDescUserID := passwordFields[3].Descriptor()
 	// password.UserIDValidator is a validator for the "user_id" field. It is called by the builders before save.
 	password.UserIDValidator = passwordDescUserID.Validators[0].(func(string) error)
+	pushedauthrequestFields := schema.PushedAuthRequest{}.Fields()
+	_ = pushedauthrequestFields
+	// pushedauthrequestDescClientID is the schema descriptor for client_id field.
+	pushedauthrequestDescClientID := pushedauthrequestFields[1].Descriptor()
+	// pushedauthrequest.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
+	pushedauthrequest.ClientIDValidator = pushedauthrequestDescClientID.Validators[0].(func(string) error)
+	// pushedauthrequestDescID is the schema descriptor for id field.
+	pushedauthrequestDescID := pushedauthrequestFields[0].Descriptor()
+	// pushedauthrequest.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	pushedauthrequest.IDValidator = pushedauthrequestDescID.Validators[0].(func(string) error)
 	refreshtokenFields := schema.RefreshToken{}.Fields()
 	_ = refreshtokenFields
 	// refreshtokenDescClientID is the schema descriptor for client_id field.
@@ -215,47 +270,67 @@ func init() {
 	// refreshtoken.ClientIDValidator is a validator for the "client_id" field. It is called by the builders before save.
 	refreshtoken.ClientIDValidator = refreshtokenDescClientID.Validators[0].(func(string) error)
 	// refreshtokenDescNonce is the schema descriptor for nonce field.
-	refreshtokenDescNonce := refreshtokenFields[3].Descriptor()
+	refreshtokenDescNonce := refreshtokenFields[4].Descriptor()
 	// refreshtoken.NonceValidator is a validator for the "nonce" field. It is called by the builders before save.
 	refreshtoken.NonceValidator = refreshtokenDescNonce.Validators[0].(func(string) error)
 	// refreshtokenDescClaimsUserID is the schema descriptor for claims_user_id field.
-	refreshtokenDescClaimsUserID := refreshtokenFields[4].Descriptor()
+	refreshtokenDescClaimsUserID := refreshtokenFields[5].Descriptor()
 	// refreshtoken.ClaimsUserIDValidator is a validator for the "claims_user_id" field. It is called by the builders before save.
 	refreshtoken.ClaimsUserIDValidator = refreshtokenDescClaimsUserID.Validators[0].(func(string) error)
 	// refreshtokenDescClaimsUsername is the schema descriptor for claims_username field.
-	refreshtokenDescClaimsUsername := refreshtokenFields[5].Descriptor()
+	refreshtokenDescClaimsUsername := refreshtokenFields[6].Descriptor()
 	// refreshtoken.ClaimsUsernameValidator is a validator for the "claims_username" field. It is called by the builders before save.
 	refreshtoken.ClaimsUsernameValidator = refreshtokenDescClaimsUsername.Validators[0].(func(string) error)
 	// refreshtokenDescClaimsEmail is the schema descriptor for claims_email field.
-	refreshtokenDescClaimsEmail := refreshtokenFields[6].Descriptor()
+	refreshtokenDescClaimsEmail := refreshtokenFields[7].Descriptor()
 	// refreshtoken.ClaimsEmailValidator is a validator for the "claims_email" field. It is called by the builders before save.
 	refreshtoken.ClaimsEmailValidator = refreshtokenDescClaimsEmail.Validators[0].(func(string) error)
 	// refreshtokenDescClaimsPreferredUsername is the schema descriptor for claims_preferred_username field.
-	refreshtokenDescClaimsPreferredUsername := refreshtokenFields[9].Descriptor()
+	refreshtokenDescClaimsPreferredUsername := refreshtokenFields[13].Descriptor()
 	// refreshtoken.DefaultClaimsPreferredUsername holds the default value on creation for the claims_preferred_username field.
 	refreshtoken.DefaultClaimsPreferredUsername = refreshtokenDescClaimsPreferredUsername.Default.(string)
 	// refreshtokenDescConnectorID is the schema descriptor for connector_id field.
-	refreshtokenDescConnectorID := refreshtokenFields[10].Descriptor()
+	refreshtokenDescConnectorID := refreshtokenFields[15].Descriptor()
 	// refreshtoken.ConnectorIDValidator is a validator for the "connector_id" field. It is called by the builders before save.
 	refreshtoken.ConnectorIDValidator = refreshtokenDescConnectorID.Validators[0].(func(string) error)
 	// refreshtokenDescToken is the schema descriptor for token field.
-	refreshtokenDescToken := refreshtokenFields[12].Descriptor()
+	refreshtokenDescToken := refreshtokenFields[17].Descriptor()
 	// refreshtoken.DefaultToken holds the default value on creation for the token field.
 	refreshtoken.DefaultToken = refreshtokenDescToken.Default.(string)
 	// refreshtokenDescObsoleteToken is the schema descriptor for obsolete_token field.
-	refreshtokenDescObsoleteToken := refreshtokenFields[13].Descriptor()
+	refreshtokenDescObsoleteToken := refreshtokenFields[18].Descriptor()
 	// refreshtoken.DefaultObsoleteToken holds the default value on creation for the obsolete_token field.
 	refreshtoken.DefaultObsoleteToken = refreshtokenDescObsoleteToken.Default.(string)
 	// refreshtokenDescCreatedAt is the schema descriptor for created_at field.
-	refreshtokenDescCreatedAt := refreshtokenFields[14].Descriptor()
+	refreshtokenDescCreatedAt := refreshtokenFields[19].Descriptor()
 	// refreshtoken.DefaultCreatedAt holds the default value on creation for the created_at field.
 	refreshtoken.DefaultCreatedAt = refreshtokenDescCreatedAt.Default.(func() time.Time)
 	// refreshtokenDescLastUsed is the schema descriptor for last_used field.
-	refreshtokenDescLastUsed := refreshtokenFields[15].Descriptor()
+	refreshtokenDescLastUsed := refreshtokenFields[20].Descriptor()
 	// refreshtoken.DefaultLastUsed holds the default value on creation for the last_used field.
 	refreshtoken.DefaultLastUsed = refreshtokenDescLastUsed.Default.(func() time.Time)
 	// refreshtokenDescID is the schema descriptor for id field.
 	refreshtokenDescID := refreshtokenFields[0].Descriptor()
 	// refreshtoken.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	refreshtoken.IDValidator = refreshtokenDescID.Validators[0].(func(string) error)
+	upstreamkeysetFields := schema.UpstreamKeySet{}.Fields()
+	_ = upstreamkeysetFields
+	// upstreamkeysetDescID is the schema descriptor for id field.
+	upstreamkeysetDescID := upstreamkeysetFields[0].Descriptor()
+	// upstreamkeyset.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	upstreamkeyset.IDValidator = func() func(string) error {
+		validators := upstreamkeysetDescID.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(id string) error {
+			for _, fn := range fns {
+				if err := fn(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
 }
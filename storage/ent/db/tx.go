@@ -22,6 +22,8 @@ type Tx struct {
 	DeviceRequest *DeviceRequestClient
 	// DeviceToken is the client for interacting with the DeviceToken builders.
 	DeviceToken *DeviceTokenClient
+	// IssuedToken is the client for interacting with the IssuedToken builders.
+	IssuedToken *IssuedTokenClient
 	// Keys is the client for interacting with the Keys builders.
 	Keys *KeysClient
 	// OAuth2Client is the client for interacting with the OAuth2Client builders.
@@ -30,8 +32,12 @@ type Tx struct {
 	OfflineSession *OfflineSessionClient
 	// Password is the client for interacting with the Password builders.
 	Password *PasswordClient
+	// PushedAuthRequest is the client for interacting with the PushedAuthRequest builders.
+	PushedAuthRequest *PushedAuthRequestClient
 	// RefreshToken is the client for interacting with the RefreshToken builders.
 	RefreshToken *RefreshTokenClient
+	// UpstreamKeySet is the client for interacting with the UpstreamKeySet builders.
+	UpstreamKeySet *UpstreamKeySetClient
 
 	// lazily loaded.
 	client     *Client
@@ -172,11 +178,14 @@ func (tx *Tx) init() {
 	tx.Connector = NewConnectorClient(tx.config)
 	tx.DeviceRequest = NewDeviceRequestClient(tx.config)
 	tx.DeviceToken = NewDeviceTokenClient(tx.config)
+	tx.IssuedToken = NewIssuedTokenClient(tx.config)
 	tx.Keys = NewKeysClient(tx.config)
 	tx.OAuth2Client = NewOAuth2ClientClient(tx.config)
 	tx.OfflineSession = NewOfflineSessionClient(tx.config)
 	tx.Password = NewPasswordClient(tx.config)
+	tx.PushedAuthRequest = NewPushedAuthRequestClient(tx.config)
 	tx.RefreshToken = NewRefreshTokenClient(tx.config)
+	tx.UpstreamKeySet = NewUpstreamKeySetClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -0,0 +1,291 @@
+// Code generated by entc, DO NOT EDIT.
+
+package upstreamkeyset
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(ids) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// KeySet applies equality check predicate on the "key_set" field. It's identical to KeySetEQ.
+func KeySet(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKeySet), v))
+	})
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// KeySetEQ applies the EQ predicate on the "key_set" field.
+func KeySetEQ(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldKeySet), v))
+	})
+}
+
+// KeySetNEQ applies the NEQ predicate on the "key_set" field.
+func KeySetNEQ(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldKeySet), v))
+	})
+}
+
+// KeySetIn applies the In predicate on the "key_set" field.
+func KeySetIn(vs ...[]byte) predicate.UpstreamKeySet {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldKeySet), v...))
+	})
+}
+
+// KeySetNotIn applies the NotIn predicate on the "key_set" field.
+func KeySetNotIn(vs ...[]byte) predicate.UpstreamKeySet {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldKeySet), v...))
+	})
+}
+
+// KeySetGT applies the GT predicate on the "key_set" field.
+func KeySetGT(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldKeySet), v))
+	})
+}
+
+// KeySetGTE applies the GTE predicate on the "key_set" field.
+func KeySetGTE(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldKeySet), v))
+	})
+}
+
+// KeySetLT applies the LT predicate on the "key_set" field.
+func KeySetLT(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldKeySet), v))
+	})
+}
+
+// KeySetLTE applies the LTE predicate on the "key_set" field.
+func KeySetLTE(v []byte) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldKeySet), v))
+	})
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.UpstreamKeySet {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldUpdatedAt), v...))
+	})
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.UpstreamKeySet {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldUpdatedAt), v...))
+	})
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldUpdatedAt), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.UpstreamKeySet) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.UpstreamKeySet) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.UpstreamKeySet) predicate.UpstreamKeySet {
+	return predicate.UpstreamKeySet(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,254 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
+)
+
+// UpstreamKeySetCreate is the builder for creating a UpstreamKeySet entity.
+type UpstreamKeySetCreate struct {
+	config
+	mutation *UpstreamKeySetMutation
+	hooks    []Hook
+}
+
+// SetKeySet sets the "key_set" field.
+func (uksc *UpstreamKeySetCreate) SetKeySet(b []byte) *UpstreamKeySetCreate {
+	uksc.mutation.SetKeySet(b)
+	return uksc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (uksc *UpstreamKeySetCreate) SetUpdatedAt(t time.Time) *UpstreamKeySetCreate {
+	uksc.mutation.SetUpdatedAt(t)
+	return uksc
+}
+
+// SetID sets the "id" field.
+func (uksc *UpstreamKeySetCreate) SetID(s string) *UpstreamKeySetCreate {
+	uksc.mutation.SetID(s)
+	return uksc
+}
+
+// Mutation returns the UpstreamKeySetMutation object of the builder.
+func (uksc *UpstreamKeySetCreate) Mutation() *UpstreamKeySetMutation {
+	return uksc.mutation
+}
+
+// Save creates the UpstreamKeySet in the database.
+func (uksc *UpstreamKeySetCreate) Save(ctx context.Context) (*UpstreamKeySet, error) {
+	var (
+		err  error
+		node *UpstreamKeySet
+	)
+	if len(uksc.hooks) == 0 {
+		if err = uksc.check(); err != nil {
+			return nil, err
+		}
+		node, err = uksc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UpstreamKeySetMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = uksc.check(); err != nil {
+				return nil, err
+			}
+			uksc.mutation = mutation
+			if node, err = uksc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(uksc.hooks) - 1; i >= 0; i-- {
+			if uksc.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = uksc.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, uksc.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (uksc *UpstreamKeySetCreate) SaveX(ctx context.Context) *UpstreamKeySet {
+	v, err := uksc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (uksc *UpstreamKeySetCreate) Exec(ctx context.Context) error {
+	_, err := uksc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uksc *UpstreamKeySetCreate) ExecX(ctx context.Context) {
+	if err := uksc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (uksc *UpstreamKeySetCreate) check() error {
+	if _, ok := uksc.mutation.KeySet(); !ok {
+		return &ValidationError{Name: "key_set", err: errors.New(`db: missing required field "UpstreamKeySet.key_set"`)}
+	}
+	if _, ok := uksc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`db: missing required field "UpstreamKeySet.updated_at"`)}
+	}
+	if v, ok := uksc.mutation.ID(); ok {
+		if err := upstreamkeyset.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`db: validator failed for field "UpstreamKeySet.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (uksc *UpstreamKeySetCreate) sqlSave(ctx context.Context) (*UpstreamKeySet, error) {
+	_node, _spec := uksc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, uksc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected UpstreamKeySet.ID type: %T", _spec.ID.Value)
+		}
+	}
+	return _node, nil
+}
+
+func (uksc *UpstreamKeySetCreate) createSpec() (*UpstreamKeySet, *sqlgraph.CreateSpec) {
+	var (
+		_node = &UpstreamKeySet{config: uksc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: upstreamkeyset.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: upstreamkeyset.FieldID,
+			},
+		}
+	)
+	if id, ok := uksc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := uksc.mutation.KeySet(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: upstreamkeyset.FieldKeySet,
+		})
+		_node.KeySet = value
+	}
+	if value, ok := uksc.mutation.UpdatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: upstreamkeyset.FieldUpdatedAt,
+		})
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// UpstreamKeySetCreateBulk is the builder for creating many UpstreamKeySet entities in bulk.
+type UpstreamKeySetCreateBulk struct {
+	config
+	builders []*UpstreamKeySetCreate
+}
+
+// Save creates the UpstreamKeySet entities in the database.
+func (ukscb *UpstreamKeySetCreateBulk) Save(ctx context.Context) ([]*UpstreamKeySet, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(ukscb.builders))
+	nodes := make([]*UpstreamKeySet, len(ukscb.builders))
+	mutators := make([]Mutator, len(ukscb.builders))
+	for i := range ukscb.builders {
+		func(i int, root context.Context) {
+			builder := ukscb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UpstreamKeySetMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ukscb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ukscb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{err.Error(), err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ukscb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ukscb *UpstreamKeySetCreateBulk) SaveX(ctx context.Context) []*UpstreamKeySet {
+	v, err := ukscb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ukscb *UpstreamKeySetCreateBulk) Exec(ctx context.Context) error {
+	_, err := ukscb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ukscb *UpstreamKeySetCreateBulk) ExecX(ctx context.Context) {
+	if err := ukscb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
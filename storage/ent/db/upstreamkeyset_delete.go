@@ -0,0 +1,111 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
+)
+
+// UpstreamKeySetDelete is the builder for deleting a UpstreamKeySet entity.
+type UpstreamKeySetDelete struct {
+	config
+	hooks    []Hook
+	mutation *UpstreamKeySetMutation
+}
+
+// Where appends a list predicates to the UpstreamKeySetDelete builder.
+func (uksd *UpstreamKeySetDelete) Where(ps ...predicate.UpstreamKeySet) *UpstreamKeySetDelete {
+	uksd.mutation.Where(ps...)
+	return uksd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (uksd *UpstreamKeySetDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(uksd.hooks) == 0 {
+		affected, err = uksd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UpstreamKeySetMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			uksd.mutation = mutation
+			affected, err = uksd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(uksd.hooks) - 1; i >= 0; i-- {
+			if uksd.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = uksd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, uksd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uksd *UpstreamKeySetDelete) ExecX(ctx context.Context) int {
+	n, err := uksd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (uksd *UpstreamKeySetDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: upstreamkeyset.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: upstreamkeyset.FieldID,
+			},
+		},
+	}
+	if ps := uksd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return sqlgraph.DeleteNodes(ctx, uksd.driver, _spec)
+}
+
+// UpstreamKeySetDeleteOne is the builder for deleting a single UpstreamKeySet entity.
+type UpstreamKeySetDeleteOne struct {
+	uksd *UpstreamKeySetDelete
+}
+
+// Exec executes the deletion query.
+func (uksdo *UpstreamKeySetDeleteOne) Exec(ctx context.Context) error {
+	n, err := uksdo.uksd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{upstreamkeyset.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uksdo *UpstreamKeySetDeleteOne) ExecX(ctx context.Context) {
+	uksdo.uksd.ExecX(ctx)
+}
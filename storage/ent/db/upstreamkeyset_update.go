@@ -0,0 +1,292 @@
+// Code generated by entc, DO NOT EDIT.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/dexidp/dex/storage/ent/db/predicate"
+	"github.com/dexidp/dex/storage/ent/db/upstreamkeyset"
+)
+
+// UpstreamKeySetUpdate is the builder for updating UpstreamKeySet entities.
+type UpstreamKeySetUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UpstreamKeySetMutation
+}
+
+// Where appends a list predicates to the UpstreamKeySetUpdate builder.
+func (uksu *UpstreamKeySetUpdate) Where(ps ...predicate.UpstreamKeySet) *UpstreamKeySetUpdate {
+	uksu.mutation.Where(ps...)
+	return uksu
+}
+
+// SetKeySet sets the "key_set" field.
+func (uksu *UpstreamKeySetUpdate) SetKeySet(b []byte) *UpstreamKeySetUpdate {
+	uksu.mutation.SetKeySet(b)
+	return uksu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (uksu *UpstreamKeySetUpdate) SetUpdatedAt(t time.Time) *UpstreamKeySetUpdate {
+	uksu.mutation.SetUpdatedAt(t)
+	return uksu
+}
+
+// Mutation returns the UpstreamKeySetMutation object of the builder.
+func (uksu *UpstreamKeySetUpdate) Mutation() *UpstreamKeySetMutation {
+	return uksu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (uksu *UpstreamKeySetUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(uksu.hooks) == 0 {
+		affected, err = uksu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UpstreamKeySetMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			uksu.mutation = mutation
+			affected, err = uksu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(uksu.hooks) - 1; i >= 0; i-- {
+			if uksu.hooks[i] == nil {
+				return 0, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = uksu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, uksu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (uksu *UpstreamKeySetUpdate) SaveX(ctx context.Context) int {
+	affected, err := uksu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (uksu *UpstreamKeySetUpdate) Exec(ctx context.Context) error {
+	_, err := uksu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uksu *UpstreamKeySetUpdate) ExecX(ctx context.Context) {
+	if err := uksu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (uksu *UpstreamKeySetUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   upstreamkeyset.Table,
+			Columns: upstreamkeyset.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: upstreamkeyset.FieldID,
+			},
+		},
+	}
+	if ps := uksu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := uksu.mutation.KeySet(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: upstreamkeyset.FieldKeySet,
+		})
+	}
+	if value, ok := uksu.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: upstreamkeyset.FieldUpdatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, uksu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{upstreamkeyset.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// UpstreamKeySetUpdateOne is the builder for updating a single UpstreamKeySet entity.
+type UpstreamKeySetUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UpstreamKeySetMutation
+}
+
+// SetKeySet sets the "key_set" field.
+func (uksuo *UpstreamKeySetUpdateOne) SetKeySet(b []byte) *UpstreamKeySetUpdateOne {
+	uksuo.mutation.SetKeySet(b)
+	return uksuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (uksuo *UpstreamKeySetUpdateOne) SetUpdatedAt(t time.Time) *UpstreamKeySetUpdateOne {
+	uksuo.mutation.SetUpdatedAt(t)
+	return uksuo
+}
+
+// Mutation returns the UpstreamKeySetMutation object of the builder.
+func (uksuo *UpstreamKeySetUpdateOne) Mutation() *UpstreamKeySetMutation {
+	return uksuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (uksuo *UpstreamKeySetUpdateOne) Select(field string, fields ...string) *UpstreamKeySetUpdateOne {
+	uksuo.fields = append([]string{field}, fields...)
+	return uksuo
+}
+
+// Save executes the query and returns the updated UpstreamKeySet entity.
+func (uksuo *UpstreamKeySetUpdateOne) Save(ctx context.Context) (*UpstreamKeySet, error) {
+	var (
+		err  error
+		node *UpstreamKeySet
+	)
+	if len(uksuo.hooks) == 0 {
+		node, err = uksuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*UpstreamKeySetMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			uksuo.mutation = mutation
+			node, err = uksuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(uksuo.hooks) - 1; i >= 0; i-- {
+			if uksuo.hooks[i] == nil {
+				return nil, fmt.Errorf("db: uninitialized hook (forgotten import db/runtime?)")
+			}
+			mut = uksuo.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, uksuo.mutation); err != nil {
+			return nil, err
+		}
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (uksuo *UpstreamKeySetUpdateOne) SaveX(ctx context.Context) *UpstreamKeySet {
+	node, err := uksuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (uksuo *UpstreamKeySetUpdateOne) Exec(ctx context.Context) error {
+	_, err := uksuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (uksuo *UpstreamKeySetUpdateOne) ExecX(ctx context.Context) {
+	if err := uksuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (uksuo *UpstreamKeySetUpdateOne) sqlSave(ctx context.Context) (_node *UpstreamKeySet, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   upstreamkeyset.Table,
+			Columns: upstreamkeyset.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: upstreamkeyset.FieldID,
+			},
+		},
+	}
+	id, ok := uksuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`db: missing "UpstreamKeySet.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := uksuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, upstreamkeyset.FieldID)
+		for _, f := range fields {
+			if !upstreamkeyset.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("db: invalid field %q for query", f)}
+			}
+			if f != upstreamkeyset.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := uksuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := uksuo.mutation.KeySet(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: upstreamkeyset.FieldKeySet,
+		})
+	}
+	if value, ok := uksuo.mutation.UpdatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: upstreamkeyset.FieldUpdatedAt,
+		})
+	}
+	_node = &UpstreamKeySet{config: uksuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, uksuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{upstreamkeyset.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{err.Error(), err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -84,6 +84,10 @@ func (m *MySQL) driver() (*entSQL.Driver, error) {
 		return nil, err
 	}
 
+	if m.ConnMaxLifetime != 0 {
+		drv.DB().SetConnMaxLifetime(time.Duration(m.ConnMaxLifetime) * time.Second)
+	}
+
 	if m.MaxIdleConns == 0 {
 		/* Override default behaviour to fix https://github.com/dexidp/dex/issues/1608 */
 		drv.DB().SetMaxIdleConns(0)
@@ -91,6 +95,11 @@ func (m *MySQL) driver() (*entSQL.Driver, error) {
 		drv.DB().SetMaxIdleConns(m.MaxIdleConns)
 	}
 
+	// Unlike Postgres, MySQL connections aren't limited unless configured.
+	if m.MaxOpenConns != 0 {
+		drv.DB().SetMaxOpenConns(m.MaxOpenConns)
+	}
+
 	return drv, nil
 }
 
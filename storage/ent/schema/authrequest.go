@@ -3,6 +3,8 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
+
+	"github.com/dexidp/dex/storage"
 )
 
 /* Original SQL table:
@@ -47,6 +49,8 @@ func (AuthRequest) Fields() []ent.Field {
 			SchemaType(textSchema),
 		field.JSON("scopes", []string{}).
 			Optional(),
+		field.JSON("resources", []string{}).
+			Optional(),
 		field.JSON("response_types", []string{}).
 			Optional(),
 		field.Text("redirect_uri").
@@ -55,6 +59,16 @@ func (AuthRequest) Fields() []ent.Field {
 			SchemaType(textSchema),
 		field.Text("state").
 			SchemaType(textSchema),
+		field.Text("prompt").
+			SchemaType(textSchema).
+			Default(""),
+		field.JSON("essential_claims", []string{}).
+			Optional(),
+		field.JSON("acr_values", []string{}).
+			Optional(),
+		field.Int("max_age").
+			Optional().
+			Nillable(),
 
 		field.Bool("force_approval_prompt"),
 		field.Bool("logged_in"),
@@ -68,9 +82,18 @@ func (AuthRequest) Fields() []ent.Field {
 		field.Bool("claims_email_verified"),
 		field.JSON("claims_groups", []string{}).
 			Optional(),
+		field.JSON("claims_organizations", []string{}).
+			Optional(),
+		field.JSON("claims_address", &storage.Address{}).
+			Optional(),
+		field.JSON("claims_custom", map[string]interface{}{}).
+			Optional(),
 		field.Text("claims_preferred_username").
 			SchemaType(textSchema).
 			Default(""),
+		field.Time("claims_auth_time").
+			SchemaType(timeSchema).
+			Optional(),
 
 		field.Text("connector_id").
 			SchemaType(textSchema),
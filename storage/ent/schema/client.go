@@ -38,6 +38,8 @@ func (OAuth2Client) Fields() []ent.Field {
 			Optional(),
 		field.JSON("trusted_peers", []string{}).
 			Optional(),
+		field.JSON("resources", []string{}).
+			Optional(),
 		field.Bool("public"),
 		field.Text("name").
 			SchemaType(textSchema).
@@ -45,6 +47,27 @@ func (OAuth2Client) Fields() []ent.Field {
 		field.Text("logo_url").
 			SchemaType(textSchema).
 			NotEmpty(),
+		field.Bool("hash_groups").
+			Default(false),
+		field.Text("groups_salt").
+			SchemaType(textSchema).
+			Default(""),
+		field.Bool("require_verified_email").
+			Default(false),
+		field.Text("redirect_uri_path_pattern").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("consent_text").
+			SchemaType(textSchema).
+			Default(""),
+		field.Text("privacy_policy_url").
+			SchemaType(textSchema).
+			Default(""),
+		field.JSON("allowed_group_prefixes", []string{}).
+			Optional(),
+		field.Text("allowed_groups_pattern").
+			SchemaType(textSchema).
+			Default(""),
 	}
 }
 
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+/* Original SQL table:
+create table issued_token
+(
+    id           text      not null primary key,
+    client_id    text      not null,
+    user_id      text      not null,
+    connector_id text      not null,
+    issued_at    timestamp not null,
+    expiry       timestamp not null
+);
+*/
+
+// IssuedToken holds the schema definition for the IssuedToken entity.
+type IssuedToken struct {
+	ent.Schema
+}
+
+// Fields of the IssuedToken.
+func (IssuedToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Text("id").
+			SchemaType(textSchema).
+			NotEmpty().
+			Unique(),
+		field.Text("client_id").
+			SchemaType(textSchema).
+			NotEmpty(),
+		field.Text("user_id").
+			SchemaType(textSchema),
+		field.Text("connector_id").
+			SchemaType(textSchema),
+		field.Time("issued_at").
+			SchemaType(timeSchema),
+		field.Time("expiry").
+			SchemaType(timeSchema),
+	}
+}
+
+// Edges of the IssuedToken.
+func (IssuedToken) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
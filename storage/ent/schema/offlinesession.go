@@ -37,6 +37,9 @@ func (OfflineSession) Fields() []ent.Field {
 			NotEmpty(),
 		field.Bytes("refresh"),
 		field.Bytes("connector_data").Nillable().Optional(),
+		field.Time("last_login").
+			SchemaType(timeSchema).
+			Optional(),
 	}
 }
 
@@ -5,6 +5,8 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
+
+	"github.com/dexidp/dex/storage"
 )
 
 /* Original SQL table:
@@ -46,6 +48,8 @@ func (RefreshToken) Fields() []ent.Field {
 			NotEmpty(),
 		field.JSON("scopes", []string{}).
 			Optional(),
+		field.JSON("resources", []string{}).
+			Optional(),
 		field.Text("nonce").
 			SchemaType(textSchema).
 			NotEmpty(),
@@ -62,9 +66,18 @@ func (RefreshToken) Fields() []ent.Field {
 		field.Bool("claims_email_verified"),
 		field.JSON("claims_groups", []string{}).
 			Optional(),
+		field.JSON("claims_organizations", []string{}).
+			Optional(),
+		field.JSON("claims_address", &storage.Address{}).
+			Optional(),
+		field.JSON("claims_custom", map[string]interface{}{}).
+			Optional(),
 		field.Text("claims_preferred_username").
 			SchemaType(textSchema).
 			Default(""),
+		field.Time("claims_auth_time").
+			SchemaType(timeSchema).
+			Optional(),
 
 		field.Text("connector_id").
 			SchemaType(textSchema).
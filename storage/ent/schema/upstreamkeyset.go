@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+/* Original SQL table:
+create table upstream_key_set
+(
+    connector_id text      not null primary key,
+    key_set      blob      not null,
+    updated_at   timestamp not null
+);
+*/
+
+// UpstreamKeySet holds the schema definition for the UpstreamKeySet entity.
+type UpstreamKeySet struct {
+	ent.Schema
+}
+
+// Fields of the UpstreamKeySet.
+func (UpstreamKeySet) Fields() []ent.Field {
+	return []ent.Field{
+		// The ID is the ID of the connector.
+		field.Text("id").
+			SchemaType(textSchema).
+			MaxLen(100).
+			NotEmpty().
+			Unique(),
+		field.Bytes("key_set"),
+		field.Time("updated_at").
+			SchemaType(timeSchema),
+	}
+}
+
+// Edges of the UpstreamKeySet.
+func (UpstreamKeySet) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
@@ -10,8 +10,8 @@ type NetworkDB struct {
 
 	ConnectionTimeout int // Seconds
 
-	MaxOpenConns    int // default: 5
-	MaxIdleConns    int // default: 5
+	MaxOpenConns    int // default: 5 for Postgres, unlimited for MySQL
+	MaxIdleConns    int // default: 5 for Postgres, none for MySQL
 	ConnMaxLifetime int // Seconds, default: not set
 }
 
@@ -24,6 +24,9 @@ const (
 	keysName             = "openid-connect-keys"
 	deviceRequestPrefix  = "device_req/"
 	deviceTokenPrefix    = "device_token/"
+	issuedTokenPrefix    = "issued_token/"
+	upstreamKeySetPrefix = "upstream_key_set/"
+	pushedAuthReqPrefix  = "pushed_auth_req/"
 
 	// defaultStorageTimeout will be applied to all storage's operations.
 	defaultStorageTimeout = 5 * time.Second
@@ -104,6 +107,36 @@ func (c *conn) GarbageCollect(now time.Time) (result storage.GCResult, err error
 			result.DeviceTokens++
 		}
 	}
+
+	issuedTokens, err := c.listIssuedTokens(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, issuedToken := range issuedTokens {
+		if now.After(issuedToken.Expiry) {
+			if err := c.deleteKey(ctx, keyID(issuedTokenPrefix, issuedToken.ID)); err != nil {
+				c.logger.Errorf("failed to delete issued token %v", err)
+				delErr = fmt.Errorf("failed to delete issued token: %v", err)
+			}
+			result.IssuedTokens++
+		}
+	}
+
+	pushedAuthReqs, err := c.listPushedAuthRequests(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pushedAuthReq := range pushedAuthReqs {
+		if now.After(pushedAuthReq.Expiry) {
+			if err := c.deleteKey(ctx, keyID(pushedAuthReqPrefix, pushedAuthReq.ID)); err != nil {
+				c.logger.Errorf("failed to delete pushed auth request %v", err)
+				delErr = fmt.Errorf("failed to delete pushed auth request: %v", err)
+			}
+			result.PushedAuthRequests++
+		}
+	}
 	return result, delErr
 }
 
@@ -641,3 +674,114 @@ func (c *conn) UpdateDeviceToken(deviceCode string, updater func(old storage.Dev
 		return json.Marshal(fromStorageDeviceToken(updated))
 	})
 }
+
+func (c *conn) CreateIssuedToken(t storage.IssuedToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnCreate(ctx, keyID(issuedTokenPrefix, t.ID), fromStorageIssuedToken(t))
+}
+
+func (c *conn) GetIssuedToken(id string) (t storage.IssuedToken, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var it IssuedToken
+	err = c.getKey(ctx, keyID(issuedTokenPrefix, id), &it)
+	if err == nil {
+		t = toStorageIssuedToken(it)
+	}
+	return t, err
+}
+
+func (c *conn) DeleteIssuedToken(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.deleteKey(ctx, keyID(issuedTokenPrefix, id))
+}
+
+func (c *conn) listIssuedTokens(ctx context.Context) (issuedTokens []IssuedToken, err error) {
+	res, err := c.db.Get(ctx, issuedTokenPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return issuedTokens, err
+	}
+	for _, v := range res.Kvs {
+		var t IssuedToken
+		if err = json.Unmarshal(v.Value, &t); err != nil {
+			return issuedTokens, err
+		}
+		issuedTokens = append(issuedTokens, t)
+	}
+	return issuedTokens, nil
+}
+
+func (c *conn) CreateUpstreamKeySet(k storage.UpstreamKeySet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnCreate(ctx, keyID(upstreamKeySetPrefix, k.ConnectorID), fromStorageUpstreamKeySet(k))
+}
+
+func (c *conn) GetUpstreamKeySet(connectorID string) (k storage.UpstreamKeySet, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var ks UpstreamKeySet
+	err = c.getKey(ctx, keyID(upstreamKeySetPrefix, connectorID), &ks)
+	if err == nil {
+		k = toStorageUpstreamKeySet(ks)
+	}
+	return k, err
+}
+
+func (c *conn) UpdateUpstreamKeySet(connectorID string, updater func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnUpdate(ctx, keyID(upstreamKeySetPrefix, connectorID), func(currentValue []byte) ([]byte, error) {
+		var current UpstreamKeySet
+		if len(currentValue) > 0 {
+			if err := json.Unmarshal(currentValue, &current); err != nil {
+				return nil, err
+			}
+		}
+		updated, err := updater(toStorageUpstreamKeySet(current))
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(fromStorageUpstreamKeySet(updated))
+	})
+}
+
+func (c *conn) CreatePushedAuthRequest(p storage.PushedAuthRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.txnCreate(ctx, keyID(pushedAuthReqPrefix, p.ID), fromStoragePushedAuthRequest(p))
+}
+
+func (c *conn) GetPushedAuthRequest(id string) (p storage.PushedAuthRequest, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	var req PushedAuthRequest
+	err = c.getKey(ctx, keyID(pushedAuthReqPrefix, id), &req)
+	if err == nil {
+		p = toStoragePushedAuthRequest(req)
+	}
+	return p, err
+}
+
+func (c *conn) DeletePushedAuthRequest(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultStorageTimeout)
+	defer cancel()
+	return c.deleteKey(ctx, keyID(pushedAuthReqPrefix, id))
+}
+
+func (c *conn) listPushedAuthRequests(ctx context.Context) (reqs []PushedAuthRequest, err error) {
+	res, err := c.db.Get(ctx, pushedAuthReqPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return reqs, err
+	}
+	for _, v := range res.Kvs {
+		var p PushedAuthRequest
+		if err = json.Unmarshal(v.Value, &p); err != nil {
+			return reqs, err
+		}
+		reqs = append(reqs, p)
+	}
+	return reqs, nil
+}
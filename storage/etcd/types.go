@@ -15,6 +15,7 @@ type AuthCode struct {
 	RedirectURI string   `json:"redirectURI"`
 	Nonce       string   `json:"nonce,omitempty"`
 	Scopes      []string `json:"scopes,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
 
 	ConnectorID   string `json:"connectorID,omitempty"`
 	ConnectorData []byte `json:"connectorData,omitempty"`
@@ -35,6 +36,7 @@ func toStorageAuthCode(a AuthCode) storage.AuthCode {
 		ConnectorData: a.ConnectorData,
 		Nonce:         a.Nonce,
 		Scopes:        a.Scopes,
+		Resources:     a.Resources,
 		Claims:        toStorageClaims(a.Claims),
 		Expiry:        a.Expiry,
 		PKCE: storage.PKCE{
@@ -53,6 +55,7 @@ func fromStorageAuthCode(a storage.AuthCode) AuthCode {
 		ConnectorData:       a.ConnectorData,
 		Nonce:               a.Nonce,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		Claims:              fromStorageClaims(a.Claims),
 		Expiry:              a.Expiry,
 		CodeChallenge:       a.PKCE.CodeChallenge,
@@ -70,6 +73,13 @@ type AuthRequest struct {
 	RedirectURI   string   `json:"redirect_uri"`
 	Nonce         string   `json:"nonce"`
 	State         string   `json:"state"`
+	Resources     []string `json:"resources,omitempty"`
+	Prompt        string   `json:"prompt,omitempty"`
+
+	EssentialClaims []string `json:"essential_claims,omitempty"`
+
+	ACRValues []string `json:"acr_values,omitempty"`
+	MaxAge    *int     `json:"max_age,omitempty"`
 
 	ForceApprovalPrompt bool `json:"force_approval_prompt"`
 
@@ -92,9 +102,14 @@ func fromStorageAuthRequest(a storage.AuthRequest) AuthRequest {
 		ClientID:            a.ClientID,
 		ResponseTypes:       a.ResponseTypes,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		RedirectURI:         a.RedirectURI,
 		Nonce:               a.Nonce,
 		State:               a.State,
+		Prompt:              a.Prompt,
+		EssentialClaims:     a.EssentialClaims,
+		ACRValues:           a.ACRValues,
+		MaxAge:              a.MaxAge,
 		ForceApprovalPrompt: a.ForceApprovalPrompt,
 		Expiry:              a.Expiry,
 		LoggedIn:            a.LoggedIn,
@@ -112,9 +127,14 @@ func toStorageAuthRequest(a AuthRequest) storage.AuthRequest {
 		ClientID:            a.ClientID,
 		ResponseTypes:       a.ResponseTypes,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		RedirectURI:         a.RedirectURI,
 		Nonce:               a.Nonce,
 		State:               a.State,
+		Prompt:              a.Prompt,
+		EssentialClaims:     a.EssentialClaims,
+		ACRValues:           a.ACRValues,
+		MaxAge:              a.MaxAge,
 		ForceApprovalPrompt: a.ForceApprovalPrompt,
 		LoggedIn:            a.LoggedIn,
 		ConnectorID:         a.ConnectorID,
@@ -144,7 +164,8 @@ type RefreshToken struct {
 	ConnectorData []byte `json:"connector_data"`
 	Claims        Claims `json:"claims"`
 
-	Scopes []string `json:"scopes"`
+	Scopes    []string `json:"scopes"`
+	Resources []string `json:"resources,omitempty"`
 
 	Nonce string `json:"nonce"`
 }
@@ -160,6 +181,7 @@ func toStorageRefreshToken(r RefreshToken) storage.RefreshToken {
 		ConnectorID:   r.ConnectorID,
 		ConnectorData: r.ConnectorData,
 		Scopes:        r.Scopes,
+		Resources:     r.Resources,
 		Nonce:         r.Nonce,
 		Claims:        toStorageClaims(r.Claims),
 	}
@@ -176,6 +198,7 @@ func fromStorageRefreshToken(r storage.RefreshToken) RefreshToken {
 		ConnectorID:   r.ConnectorID,
 		ConnectorData: r.ConnectorData,
 		Scopes:        r.Scopes,
+		Resources:     r.Resources,
 		Nonce:         r.Nonce,
 		Claims:        fromStorageClaims(r.Claims),
 	}
@@ -183,12 +206,16 @@ func fromStorageRefreshToken(r storage.RefreshToken) RefreshToken {
 
 // Claims is a mirrored struct from storage with JSON struct tags.
 type Claims struct {
-	UserID            string   `json:"userID"`
-	Username          string   `json:"username"`
-	PreferredUsername string   `json:"preferredUsername"`
-	Email             string   `json:"email"`
-	EmailVerified     bool     `json:"emailVerified"`
-	Groups            []string `json:"groups,omitempty"`
+	UserID            string                 `json:"userID"`
+	Username          string                 `json:"username"`
+	PreferredUsername string                 `json:"preferredUsername"`
+	Email             string                 `json:"email"`
+	EmailVerified     bool                   `json:"emailVerified"`
+	Groups            []string               `json:"groups,omitempty"`
+	Organizations     []string               `json:"organizations,omitempty"`
+	Address           *storage.Address       `json:"address,omitempty"`
+	CustomClaims      map[string]interface{} `json:"customClaims,omitempty"`
+	AuthTime          time.Time              `json:"authTime"`
 }
 
 func fromStorageClaims(i storage.Claims) Claims {
@@ -199,6 +226,10 @@ func fromStorageClaims(i storage.Claims) Claims {
 		Email:             i.Email,
 		EmailVerified:     i.EmailVerified,
 		Groups:            i.Groups,
+		Organizations:     i.Organizations,
+		Address:           i.Address,
+		CustomClaims:      i.CustomClaims,
+		AuthTime:          i.AuthTime,
 	}
 }
 
@@ -210,6 +241,10 @@ func toStorageClaims(i Claims) storage.Claims {
 		Email:             i.Email,
 		EmailVerified:     i.EmailVerified,
 		Groups:            i.Groups,
+		Organizations:     i.Organizations,
+		Address:           i.Address,
+		CustomClaims:      i.CustomClaims,
+		AuthTime:          i.AuthTime,
 	}
 }
 
@@ -227,6 +262,7 @@ type OfflineSessions struct {
 	ConnID        string                              `json:"conn_id,omitempty"`
 	Refresh       map[string]*storage.RefreshTokenRef `json:"refresh,omitempty"`
 	ConnectorData []byte                              `json:"connectorData,omitempty"`
+	LastLogin     time.Time                           `json:"last_login"`
 }
 
 func fromStorageOfflineSessions(o storage.OfflineSessions) OfflineSessions {
@@ -235,6 +271,7 @@ func fromStorageOfflineSessions(o storage.OfflineSessions) OfflineSessions {
 		ConnID:        o.ConnID,
 		Refresh:       o.Refresh,
 		ConnectorData: o.ConnectorData,
+		LastLogin:     o.LastLogin,
 	}
 }
 
@@ -244,6 +281,7 @@ func toStorageOfflineSessions(o OfflineSessions) storage.OfflineSessions {
 		ConnID:        o.ConnID,
 		Refresh:       o.Refresh,
 		ConnectorData: o.ConnectorData,
+		LastLogin:     o.LastLogin,
 	}
 	if s.Refresh == nil {
 		// Server code assumes this will be non-nil.
@@ -304,3 +342,84 @@ func toStorageDeviceToken(t DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollIntervalSeconds,
 	}
 }
+
+// IssuedToken is a mirrored struct from storage with JSON struct tags
+type IssuedToken struct {
+	ID          string    `json:"id"`
+	ClientID    string    `json:"client_id"`
+	UserID      string    `json:"user_id"`
+	ConnectorID string    `json:"connector_id"`
+	IssuedAt    time.Time `json:"issued_at"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+func fromStorageIssuedToken(t storage.IssuedToken) IssuedToken {
+	return IssuedToken{
+		ID:          t.ID,
+		ClientID:    t.ClientID,
+		UserID:      t.UserID,
+		ConnectorID: t.ConnectorID,
+		IssuedAt:    t.IssuedAt,
+		Expiry:      t.Expiry,
+	}
+}
+
+func toStorageIssuedToken(t IssuedToken) storage.IssuedToken {
+	return storage.IssuedToken{
+		ID:          t.ID,
+		ClientID:    t.ClientID,
+		UserID:      t.UserID,
+		ConnectorID: t.ConnectorID,
+		IssuedAt:    t.IssuedAt,
+		Expiry:      t.Expiry,
+	}
+}
+
+// UpstreamKeySet is a mirrored struct from storage with JSON struct tags
+type UpstreamKeySet struct {
+	ConnectorID string    `json:"connector_id"`
+	KeySet      []byte    `json:"key_set"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func fromStorageUpstreamKeySet(k storage.UpstreamKeySet) UpstreamKeySet {
+	return UpstreamKeySet{
+		ConnectorID: k.ConnectorID,
+		KeySet:      k.KeySet,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+func toStorageUpstreamKeySet(k UpstreamKeySet) storage.UpstreamKeySet {
+	return storage.UpstreamKeySet{
+		ConnectorID: k.ConnectorID,
+		KeySet:      k.KeySet,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+// PushedAuthRequest is a mirrored struct from storage with JSON struct tags
+type PushedAuthRequest struct {
+	ID       string              `json:"id"`
+	ClientID string              `json:"client_id"`
+	Params   map[string][]string `json:"params"`
+	Expiry   time.Time           `json:"expiry"`
+}
+
+func fromStoragePushedAuthRequest(p storage.PushedAuthRequest) PushedAuthRequest {
+	return PushedAuthRequest{
+		ID:       p.ID,
+		ClientID: p.ClientID,
+		Params:   p.Params,
+		Expiry:   p.Expiry,
+	}
+}
+
+func toStoragePushedAuthRequest(p PushedAuthRequest) storage.PushedAuthRequest {
+	return storage.PushedAuthRequest{
+		ID:       p.ID,
+		ClientID: p.ClientID,
+		Params:   p.Params,
+		Expiry:   p.Expiry,
+	}
+}
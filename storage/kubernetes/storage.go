@@ -25,6 +25,9 @@ const (
 	kindConnector       = "Connector"
 	kindDeviceRequest   = "DeviceRequest"
 	kindDeviceToken     = "DeviceToken"
+	kindIssuedToken     = "IssuedToken"
+	kindUpstreamKeySet  = "UpstreamKeySet"
+	kindPushedAuthReq   = "PushedAuthRequest"
 )
 
 const (
@@ -38,6 +41,9 @@ const (
 	resourceConnector       = "connectors"
 	resourceDeviceRequest   = "devicerequests"
 	resourceDeviceToken     = "devicetokens"
+	resourceIssuedToken     = "issuedtokens"
+	resourceUpstreamKeySet  = "upstreamkeysets"
+	resourcePushedAuthReq   = "pushedauthrequests"
 )
 
 // Config values for the Kubernetes storage type.
@@ -662,6 +668,36 @@ func (cli *client) GarbageCollect(now time.Time) (result storage.GCResult, err e
 		}
 	}
 
+	var issuedTokens IssuedTokenList
+	if err := cli.list(resourceIssuedToken, &issuedTokens); err != nil {
+		return result, fmt.Errorf("failed to list issued tokens: %v", err)
+	}
+
+	for _, issuedToken := range issuedTokens.IssuedTokens {
+		if now.After(issuedToken.Expiry) {
+			if err := cli.delete(resourceIssuedToken, issuedToken.ObjectMeta.Name); err != nil {
+				cli.logger.Errorf("failed to delete issued token: %v", err)
+				delErr = fmt.Errorf("failed to delete issued token: %v", err)
+			}
+			result.IssuedTokens++
+		}
+	}
+
+	var pushedAuthReqs PushedAuthRequestList
+	if err := cli.list(resourcePushedAuthReq, &pushedAuthReqs); err != nil {
+		return result, fmt.Errorf("failed to list pushed auth requests: %v", err)
+	}
+
+	for _, pushedAuthReq := range pushedAuthReqs.PushedAuthRequests {
+		if now.After(pushedAuthReq.Expiry) {
+			if err := cli.delete(resourcePushedAuthReq, pushedAuthReq.ObjectMeta.Name); err != nil {
+				cli.logger.Errorf("failed to delete pushed auth request: %v", err)
+				delErr = fmt.Errorf("failed to delete pushed auth request: %v", err)
+			}
+			result.PushedAuthRequests++
+		}
+	}
+
 	if delErr != nil {
 		return result, delErr
 	}
@@ -715,6 +751,64 @@ func (cli *client) UpdateDeviceToken(deviceCode string, updater func(old storage
 	})
 }
 
+func (cli *client) CreateIssuedToken(t storage.IssuedToken) error {
+	return cli.post(resourceIssuedToken, cli.fromStorageIssuedToken(t))
+}
+
+func (cli *client) GetIssuedToken(id string) (storage.IssuedToken, error) {
+	var token IssuedToken
+	if err := cli.get(resourceIssuedToken, id, &token); err != nil {
+		return storage.IssuedToken{}, err
+	}
+	return toStorageIssuedToken(token), nil
+}
+
+func (cli *client) DeleteIssuedToken(id string) error {
+	return cli.delete(resourceIssuedToken, id)
+}
+
+func (cli *client) CreateUpstreamKeySet(k storage.UpstreamKeySet) error {
+	return cli.post(resourceUpstreamKeySet, cli.fromStorageUpstreamKeySet(k))
+}
+
+func (cli *client) GetUpstreamKeySet(connectorID string) (storage.UpstreamKeySet, error) {
+	k, err := cli.getUpstreamKeySet(connectorID)
+	if err != nil {
+		return storage.UpstreamKeySet{}, err
+	}
+	return toStorageUpstreamKeySet(k), nil
+}
+
+func (cli *client) getUpstreamKeySet(connectorID string) (UpstreamKeySet, error) {
+	var k UpstreamKeySet
+	if err := cli.get(resourceUpstreamKeySet, cli.idToName(connectorID), &k); err != nil {
+		return UpstreamKeySet{}, err
+	}
+	if k.ConnectorID != connectorID {
+		return UpstreamKeySet{}, fmt.Errorf("get upstream key set: connector ID %q mapped to key set of connector %q", connectorID, k.ConnectorID)
+	}
+	return k, nil
+}
+
+func (cli *client) UpdateUpstreamKeySet(connectorID string, updater func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error)) error {
+	return retryOnConflict(context.TODO(), func() error {
+		k, err := cli.getUpstreamKeySet(connectorID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := updater(toStorageUpstreamKeySet(k))
+		if err != nil {
+			return err
+		}
+		updated.ConnectorID = connectorID
+
+		newKeySet := cli.fromStorageUpstreamKeySet(updated)
+		newKeySet.ObjectMeta = k.ObjectMeta
+		return cli.put(resourceUpstreamKeySet, k.ObjectMeta.Name, newKeySet)
+	})
+}
+
 func isKubernetesAPIConflictError(err error) bool {
 	if httpErr, ok := err.(httpError); ok {
 		if httpErr.StatusCode() == http.StatusConflict {
@@ -754,3 +848,19 @@ func retryOnConflict(ctx context.Context, action func() error) error {
 		}
 	}
 }
+
+func (cli *client) CreatePushedAuthRequest(p storage.PushedAuthRequest) error {
+	return cli.post(resourcePushedAuthReq, cli.fromStoragePushedAuthRequest(p))
+}
+
+func (cli *client) GetPushedAuthRequest(id string) (storage.PushedAuthRequest, error) {
+	var req PushedAuthRequest
+	if err := cli.get(resourcePushedAuthReq, id, &req); err != nil {
+		return storage.PushedAuthRequest{}, err
+	}
+	return toStoragePushedAuthRequest(req), nil
+}
+
+func (cli *client) DeletePushedAuthRequest(id string) error {
+	return cli.delete(resourcePushedAuthReq, id)
+}
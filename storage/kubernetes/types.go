@@ -226,6 +226,57 @@ func customResourceDefinitions(apiVersion string) []k8sapi.CustomResourceDefinit
 				},
 			},
 		},
+		{
+			ObjectMeta: k8sapi.ObjectMeta{
+				Name: "issuedtokens.dex.coreos.com",
+			},
+			TypeMeta: crdMeta,
+			Spec: k8sapi.CustomResourceDefinitionSpec{
+				Group:    apiGroup,
+				Version:  version,
+				Versions: versions,
+				Scope:    scope,
+				Names: k8sapi.CustomResourceDefinitionNames{
+					Plural:   "issuedtokens",
+					Singular: "issuedtoken",
+					Kind:     "IssuedToken",
+				},
+			},
+		},
+		{
+			ObjectMeta: k8sapi.ObjectMeta{
+				Name: "upstreamkeysets.dex.coreos.com",
+			},
+			TypeMeta: crdMeta,
+			Spec: k8sapi.CustomResourceDefinitionSpec{
+				Group:    apiGroup,
+				Version:  version,
+				Versions: versions,
+				Scope:    scope,
+				Names: k8sapi.CustomResourceDefinitionNames{
+					Plural:   "upstreamkeysets",
+					Singular: "upstreamkeyset",
+					Kind:     "UpstreamKeySet",
+				},
+			},
+		},
+		{
+			ObjectMeta: k8sapi.ObjectMeta{
+				Name: "pushedauthrequests.dex.coreos.com",
+			},
+			TypeMeta: crdMeta,
+			Spec: k8sapi.CustomResourceDefinitionSpec{
+				Group:    apiGroup,
+				Version:  version,
+				Versions: versions,
+				Scope:    scope,
+				Names: k8sapi.CustomResourceDefinitionNames{
+					Plural:   "pushedauthrequests",
+					Singular: "pushedauthrequest",
+					Kind:     "PushedAuthRequest",
+				},
+			},
+		},
 	}
 }
 
@@ -246,11 +297,25 @@ type Client struct {
 	Secret       string   `json:"secret,omitempty"`
 	RedirectURIs []string `json:"redirectURIs,omitempty"`
 	TrustedPeers []string `json:"trustedPeers,omitempty"`
+	Resources    []string `json:"resources,omitempty"`
 
 	Public bool `json:"public"`
 
 	Name    string `json:"name,omitempty"`
 	LogoURL string `json:"logoURL,omitempty"`
+
+	HashGroups bool   `json:"hashGroups,omitempty"`
+	GroupsSalt string `json:"groupsSalt,omitempty"`
+
+	RequireVerifiedEmail bool `json:"requireVerifiedEmail,omitempty"`
+
+	RedirectURIPathPattern string `json:"redirectURIPathPattern,omitempty"`
+
+	ConsentText      string `json:"consentText,omitempty"`
+	PrivacyPolicyURL string `json:"privacyPolicyURL,omitempty"`
+
+	AllowedGroupPrefixes []string `json:"allowedGroupPrefixes,omitempty"`
+	AllowedGroupsPattern string   `json:"allowedGroupsPattern,omitempty"`
 }
 
 // ClientList is a list of Clients.
@@ -274,9 +339,21 @@ func (cli *client) fromStorageClient(c storage.Client) Client {
 		Secret:       c.Secret,
 		RedirectURIs: c.RedirectURIs,
 		TrustedPeers: c.TrustedPeers,
+		Resources:    c.Resources,
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+		HashGroups:   c.HashGroups,
+		GroupsSalt:   c.GroupsSalt,
+
+		RequireVerifiedEmail:   c.RequireVerifiedEmail,
+		RedirectURIPathPattern: c.RedirectURIPathPattern,
+
+		ConsentText:      c.ConsentText,
+		PrivacyPolicyURL: c.PrivacyPolicyURL,
+
+		AllowedGroupPrefixes: c.AllowedGroupPrefixes,
+		AllowedGroupsPattern: c.AllowedGroupsPattern,
 	}
 }
 
@@ -286,20 +363,36 @@ func toStorageClient(c Client) storage.Client {
 		Secret:       c.Secret,
 		RedirectURIs: c.RedirectURIs,
 		TrustedPeers: c.TrustedPeers,
+		Resources:    c.Resources,
 		Public:       c.Public,
 		Name:         c.Name,
 		LogoURL:      c.LogoURL,
+		HashGroups:   c.HashGroups,
+		GroupsSalt:   c.GroupsSalt,
+
+		RequireVerifiedEmail:   c.RequireVerifiedEmail,
+		RedirectURIPathPattern: c.RedirectURIPathPattern,
+
+		ConsentText:      c.ConsentText,
+		PrivacyPolicyURL: c.PrivacyPolicyURL,
+
+		AllowedGroupPrefixes: c.AllowedGroupPrefixes,
+		AllowedGroupsPattern: c.AllowedGroupsPattern,
 	}
 }
 
 // Claims is a mirrored struct from storage with JSON struct tags.
 type Claims struct {
-	UserID            string   `json:"userID"`
-	Username          string   `json:"username"`
-	PreferredUsername string   `json:"preferredUsername"`
-	Email             string   `json:"email"`
-	EmailVerified     bool     `json:"emailVerified"`
-	Groups            []string `json:"groups,omitempty"`
+	UserID            string                 `json:"userID"`
+	Username          string                 `json:"username"`
+	PreferredUsername string                 `json:"preferredUsername"`
+	Email             string                 `json:"email"`
+	EmailVerified     bool                   `json:"emailVerified"`
+	Groups            []string               `json:"groups,omitempty"`
+	Organizations     []string               `json:"organizations,omitempty"`
+	Address           *storage.Address       `json:"address,omitempty"`
+	CustomClaims      map[string]interface{} `json:"customClaims,omitempty"`
+	AuthTime          time.Time              `json:"authTime"`
 }
 
 func fromStorageClaims(i storage.Claims) Claims {
@@ -310,6 +403,10 @@ func fromStorageClaims(i storage.Claims) Claims {
 		Email:             i.Email,
 		EmailVerified:     i.EmailVerified,
 		Groups:            i.Groups,
+		Organizations:     i.Organizations,
+		Address:           i.Address,
+		CustomClaims:      i.CustomClaims,
+		AuthTime:          i.AuthTime,
 	}
 }
 
@@ -321,6 +418,10 @@ func toStorageClaims(i Claims) storage.Claims {
 		Email:             i.Email,
 		EmailVerified:     i.EmailVerified,
 		Groups:            i.Groups,
+		Organizations:     i.Organizations,
+		Address:           i.Address,
+		CustomClaims:      i.CustomClaims,
+		AuthTime:          i.AuthTime,
 	}
 }
 
@@ -335,8 +436,15 @@ type AuthRequest struct {
 	Scopes        []string `json:"scopes,omitempty"`
 	RedirectURI   string   `json:"redirectURI"`
 
-	Nonce string `json:"nonce,omitempty"`
-	State string `json:"state,omitempty"`
+	Nonce     string   `json:"nonce,omitempty"`
+	State     string   `json:"state,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+	Prompt    string   `json:"prompt,omitempty"`
+
+	EssentialClaims []string `json:"essentialClaims,omitempty"`
+
+	ACRValues []string `json:"acrValues,omitempty"`
+	MaxAge    *int     `json:"maxAge,omitempty"`
 
 	// The client has indicated that the end user must be shown an approval prompt
 	// on all requests. The server cannot cache their initial action for subsequent
@@ -371,9 +479,14 @@ func toStorageAuthRequest(req AuthRequest) storage.AuthRequest {
 		ClientID:            req.ClientID,
 		ResponseTypes:       req.ResponseTypes,
 		Scopes:              req.Scopes,
+		Resources:           req.Resources,
 		RedirectURI:         req.RedirectURI,
 		Nonce:               req.Nonce,
 		State:               req.State,
+		Prompt:              req.Prompt,
+		EssentialClaims:     req.EssentialClaims,
+		ACRValues:           req.ACRValues,
+		MaxAge:              req.MaxAge,
 		ForceApprovalPrompt: req.ForceApprovalPrompt,
 		LoggedIn:            req.LoggedIn,
 		ConnectorID:         req.ConnectorID,
@@ -401,9 +514,14 @@ func (cli *client) fromStorageAuthRequest(a storage.AuthRequest) AuthRequest {
 		ClientID:            a.ClientID,
 		ResponseTypes:       a.ResponseTypes,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		RedirectURI:         a.RedirectURI,
 		Nonce:               a.Nonce,
 		State:               a.State,
+		Prompt:              a.Prompt,
+		EssentialClaims:     a.EssentialClaims,
+		ACRValues:           a.ACRValues,
+		MaxAge:              a.MaxAge,
 		LoggedIn:            a.LoggedIn,
 		ForceApprovalPrompt: a.ForceApprovalPrompt,
 		ConnectorID:         a.ConnectorID,
@@ -474,6 +592,7 @@ type AuthCode struct {
 
 	ClientID    string   `json:"clientID"`
 	Scopes      []string `json:"scopes,omitempty"`
+	Resources   []string `json:"resources,omitempty"`
 	RedirectURI string   `json:"redirectURI"`
 
 	Nonce string `json:"nonce,omitempty"`
@@ -513,6 +632,7 @@ func (cli *client) fromStorageAuthCode(a storage.AuthCode) AuthCode {
 		ConnectorData:       a.ConnectorData,
 		Nonce:               a.Nonce,
 		Scopes:              a.Scopes,
+		Resources:           a.Resources,
 		Claims:              fromStorageClaims(a.Claims),
 		Expiry:              a.Expiry,
 		CodeChallenge:       a.PKCE.CodeChallenge,
@@ -529,6 +649,7 @@ func toStorageAuthCode(a AuthCode) storage.AuthCode {
 		ConnectorData: a.ConnectorData,
 		Nonce:         a.Nonce,
 		Scopes:        a.Scopes,
+		Resources:     a.Resources,
 		Claims:        toStorageClaims(a.Claims),
 		Expiry:        a.Expiry,
 		PKCE: storage.PKCE{
@@ -547,8 +668,9 @@ type RefreshToken struct {
 	CreatedAt time.Time
 	LastUsed  time.Time
 
-	ClientID string   `json:"clientID"`
-	Scopes   []string `json:"scopes,omitempty"`
+	ClientID  string   `json:"clientID"`
+	Scopes    []string `json:"scopes,omitempty"`
+	Resources []string `json:"resources,omitempty"`
 
 	Token         string `json:"token,omitempty"`
 	ObsoleteToken string `json:"obsoleteToken,omitempty"`
@@ -578,6 +700,7 @@ func toStorageRefreshToken(r RefreshToken) storage.RefreshToken {
 		ConnectorID:   r.ConnectorID,
 		ConnectorData: r.ConnectorData,
 		Scopes:        r.Scopes,
+		Resources:     r.Resources,
 		Nonce:         r.Nonce,
 		Claims:        toStorageClaims(r.Claims),
 	}
@@ -601,6 +724,7 @@ func (cli *client) fromStorageRefreshToken(r storage.RefreshToken) RefreshToken
 		ConnectorID:   r.ConnectorID,
 		ConnectorData: r.ConnectorData,
 		Scopes:        r.Scopes,
+		Resources:     r.Resources,
 		Nonce:         r.Nonce,
 		Claims:        fromStorageClaims(r.Claims),
 	}
@@ -661,6 +785,7 @@ type OfflineSessions struct {
 	ConnID        string                              `json:"connID,omitempty"`
 	Refresh       map[string]*storage.RefreshTokenRef `json:"refresh,omitempty"`
 	ConnectorData []byte                              `json:"connectorData,omitempty"`
+	LastLogin     time.Time                           `json:"lastLogin"`
 }
 
 func (cli *client) fromStorageOfflineSessions(o storage.OfflineSessions) OfflineSessions {
@@ -677,6 +802,7 @@ func (cli *client) fromStorageOfflineSessions(o storage.OfflineSessions) Offline
 		ConnID:        o.ConnID,
 		Refresh:       o.Refresh,
 		ConnectorData: o.ConnectorData,
+		LastLogin:     o.LastLogin,
 	}
 }
 
@@ -686,6 +812,7 @@ func toStorageOfflineSessions(o OfflineSessions) storage.OfflineSessions {
 		ConnID:        o.ConnID,
 		Refresh:       o.Refresh,
 		ConnectorData: o.ConnectorData,
+		LastLogin:     o.LastLogin,
 	}
 	if s.Refresh == nil {
 		// Server code assumes this will be non-nil.
@@ -840,3 +967,130 @@ func toStorageDeviceToken(t DeviceToken) storage.DeviceToken {
 		PollIntervalSeconds: t.PollIntervalSeconds,
 	}
 }
+
+// IssuedToken is a mirrored struct from storage with JSON struct tags and
+// Kubernetes type metadata.
+type IssuedToken struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	ClientID    string    `json:"clientID"`
+	UserID      string    `json:"userID,omitempty"`
+	ConnectorID string    `json:"connectorID,omitempty"`
+	IssuedAt    time.Time `json:"issuedAt"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// IssuedTokenList is a list of IssuedTokens.
+type IssuedTokenList struct {
+	k8sapi.TypeMeta `json:",inline"`
+	k8sapi.ListMeta `json:"metadata,omitempty"`
+	IssuedTokens    []IssuedToken `json:"items"`
+}
+
+func (cli *client) fromStorageIssuedToken(t storage.IssuedToken) IssuedToken {
+	return IssuedToken{
+		TypeMeta: k8sapi.TypeMeta{
+			Kind:       kindIssuedToken,
+			APIVersion: cli.apiVersion,
+		},
+		ObjectMeta: k8sapi.ObjectMeta{
+			Name:      t.ID,
+			Namespace: cli.namespace,
+		},
+		ClientID:    t.ClientID,
+		UserID:      t.UserID,
+		ConnectorID: t.ConnectorID,
+		IssuedAt:    t.IssuedAt,
+		Expiry:      t.Expiry,
+	}
+}
+
+func toStorageIssuedToken(t IssuedToken) storage.IssuedToken {
+	return storage.IssuedToken{
+		ID:          t.ObjectMeta.Name,
+		ClientID:    t.ClientID,
+		UserID:      t.UserID,
+		ConnectorID: t.ConnectorID,
+		IssuedAt:    t.IssuedAt,
+		Expiry:      t.Expiry,
+	}
+}
+
+// UpstreamKeySet is a mirrored struct from storage with JSON struct tags and
+// Kubernetes type metadata.
+type UpstreamKeySet struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	ConnectorID string    `json:"connectorID,omitempty"`
+	KeySet      []byte    `json:"keySet,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (cli *client) fromStorageUpstreamKeySet(k storage.UpstreamKeySet) UpstreamKeySet {
+	return UpstreamKeySet{
+		TypeMeta: k8sapi.TypeMeta{
+			Kind:       kindUpstreamKeySet,
+			APIVersion: cli.apiVersion,
+		},
+		ObjectMeta: k8sapi.ObjectMeta{
+			Name:      cli.idToName(k.ConnectorID),
+			Namespace: cli.namespace,
+		},
+		ConnectorID: k.ConnectorID,
+		KeySet:      k.KeySet,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+func toStorageUpstreamKeySet(k UpstreamKeySet) storage.UpstreamKeySet {
+	return storage.UpstreamKeySet{
+		ConnectorID: k.ConnectorID,
+		KeySet:      k.KeySet,
+		UpdatedAt:   k.UpdatedAt,
+	}
+}
+
+// PushedAuthRequest is a mirrored struct from storage with JSON struct tags and
+// Kubernetes type metadata.
+type PushedAuthRequest struct {
+	k8sapi.TypeMeta   `json:",inline"`
+	k8sapi.ObjectMeta `json:"metadata,omitempty"`
+
+	ClientID string              `json:"clientID"`
+	Params   map[string][]string `json:"params,omitempty"`
+	Expiry   time.Time           `json:"expiry"`
+}
+
+// PushedAuthRequestList is a list of PushedAuthRequests.
+type PushedAuthRequestList struct {
+	k8sapi.TypeMeta    `json:",inline"`
+	k8sapi.ListMeta    `json:"metadata,omitempty"`
+	PushedAuthRequests []PushedAuthRequest `json:"items"`
+}
+
+func (cli *client) fromStoragePushedAuthRequest(p storage.PushedAuthRequest) PushedAuthRequest {
+	return PushedAuthRequest{
+		TypeMeta: k8sapi.TypeMeta{
+			Kind:       kindPushedAuthReq,
+			APIVersion: cli.apiVersion,
+		},
+		ObjectMeta: k8sapi.ObjectMeta{
+			Name:      p.ID,
+			Namespace: cli.namespace,
+		},
+		ClientID: p.ClientID,
+		Params:   p.Params,
+		Expiry:   p.Expiry,
+	}
+}
+
+func toStoragePushedAuthRequest(p PushedAuthRequest) storage.PushedAuthRequest {
+	return storage.PushedAuthRequest{
+		ID:       p.ObjectMeta.Name,
+		ClientID: p.ClientID,
+		Params:   p.Params,
+		Expiry:   p.Expiry,
+	}
+}
@@ -11,8 +11,8 @@ import (
 )
 
 // New returns an in memory storage.
-func New(logger log.Logger) storage.Storage {
-	return &memStorage{
+func New(logger log.Logger, opts ...Option) storage.Storage {
+	s := &memStorage{
 		clients:         make(map[string]storage.Client),
 		authCodes:       make(map[string]storage.AuthCode),
 		refreshTokens:   make(map[string]storage.RefreshToken),
@@ -22,8 +22,36 @@ func New(logger log.Logger) storage.Storage {
 		connectors:      make(map[string]storage.Connector),
 		deviceRequests:  make(map[string]storage.DeviceRequest),
 		deviceTokens:    make(map[string]storage.DeviceToken),
+		issuedTokens:    make(map[string]storage.IssuedToken),
+		upstreamKeySets: make(map[string]storage.UpstreamKeySet),
+		pushedAuthReqs:  make(map[string]storage.PushedAuthRequest),
 		logger:          logger,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option configures an in memory storage.
+type Option func(s *memStorage)
+
+// Eviction is an expired object garbage collection deleted.
+type Eviction struct {
+	// Kind is the kind of the object, like KindAuthRequest.
+	Kind string
+	ID   string
+	// ClientID is the client the object was created for, if it has one.
+	ClientID string
+}
+
+// WithEvictionCallback calls the callback with every object garbage collection
+// deletes, for example to tell which clients abandon their flows. It's called
+// after the collection, outside of the transactions of the storage.
+func WithEvictionCallback(callback func(Eviction)) Option {
+	return func(s *memStorage) {
+		s.onEviction = callback
+	}
 }
 
 // Config is an implementation of a storage configuration.
@@ -49,10 +77,16 @@ type memStorage struct {
 	connectors      map[string]storage.Connector
 	deviceRequests  map[string]storage.DeviceRequest
 	deviceTokens    map[string]storage.DeviceToken
+	issuedTokens    map[string]storage.IssuedToken
+	upstreamKeySets map[string]storage.UpstreamKeySet
+	pushedAuthReqs  map[string]storage.PushedAuthRequest
 
 	keys storage.Keys
 
 	logger log.Logger
+
+	// onEviction is called with the objects garbage collection deletes.
+	onEviction func(Eviction)
 }
 
 type offlineSessionID struct {
@@ -69,32 +103,70 @@ func (s *memStorage) tx(f func()) {
 func (s *memStorage) Close() error { return nil }
 
 func (s *memStorage) GarbageCollect(now time.Time) (result storage.GCResult, err error) {
+	var evictions []Eviction
+	evict := func(kind, id, clientID string) {
+		if s.onEviction != nil {
+			evictions = append(evictions, Eviction{Kind: kind, ID: id, ClientID: clientID})
+		}
+	}
 	s.tx(func() {
 		for id, a := range s.authCodes {
 			if now.After(a.Expiry) {
 				delete(s.authCodes, id)
 				result.AuthCodes++
+				evict(KindAuthCode, id, a.ClientID)
 			}
 		}
 		for id, a := range s.authReqs {
 			if now.After(a.Expiry) {
 				delete(s.authReqs, id)
 				result.AuthRequests++
+				evict(KindAuthRequest, id, a.ClientID)
 			}
 		}
 		for id, a := range s.deviceRequests {
 			if now.After(a.Expiry) {
 				delete(s.deviceRequests, id)
 				result.DeviceRequests++
+				evict(KindDeviceRequest, id, a.ClientID)
 			}
 		}
 		for id, a := range s.deviceTokens {
 			if now.After(a.Expiry) {
 				delete(s.deviceTokens, id)
 				result.DeviceTokens++
+				evict(KindDeviceToken, id, "")
+			}
+		}
+		for id, a := range s.issuedTokens {
+			if now.After(a.Expiry) {
+				delete(s.issuedTokens, id)
+				result.IssuedTokens++
+				evict(KindIssuedToken, id, a.ClientID)
+			}
+		}
+		for id, a := range s.pushedAuthReqs {
+			if now.After(a.Expiry) {
+				delete(s.pushedAuthReqs, id)
+				result.PushedAuthRequests++
+				evict(KindPushedAuthRequest, id, a.ClientID)
 			}
 		}
 	})
+
+	for kind, n := range map[string]int64{
+		KindAuthRequest:       result.AuthRequests,
+		KindAuthCode:          result.AuthCodes,
+		KindDeviceRequest:     result.DeviceRequests,
+		KindDeviceToken:       result.DeviceTokens,
+		KindIssuedToken:       result.IssuedTokens,
+		KindPushedAuthRequest: result.PushedAuthRequests,
+	} {
+		gcDeleted.WithLabelValues(kind).Add(float64(n))
+	}
+	for _, e := range evictions {
+		s.onEviction(e)
+	}
 	return result, nil
 }
 
@@ -538,3 +610,105 @@ func (s *memStorage) UpdateDeviceToken(deviceCode string, updater func(p storage
 	})
 	return
 }
+
+func (s *memStorage) CreateIssuedToken(t storage.IssuedToken) (err error) {
+	s.tx(func() {
+		if _, ok := s.issuedTokens[t.ID]; ok {
+			err = storage.ErrAlreadyExists
+		} else {
+			s.issuedTokens[t.ID] = t
+		}
+	})
+	return
+}
+
+func (s *memStorage) GetIssuedToken(id string) (t storage.IssuedToken, err error) {
+	s.tx(func() {
+		var ok bool
+		if t, ok = s.issuedTokens[id]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+	})
+	return
+}
+
+func (s *memStorage) DeleteIssuedToken(id string) (err error) {
+	s.tx(func() {
+		if _, ok := s.issuedTokens[id]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+		delete(s.issuedTokens, id)
+	})
+	return
+}
+
+func (s *memStorage) CreateUpstreamKeySet(k storage.UpstreamKeySet) (err error) {
+	s.tx(func() {
+		if _, ok := s.upstreamKeySets[k.ConnectorID]; ok {
+			err = storage.ErrAlreadyExists
+		} else {
+			s.upstreamKeySets[k.ConnectorID] = k
+		}
+	})
+	return
+}
+
+func (s *memStorage) GetUpstreamKeySet(connectorID string) (k storage.UpstreamKeySet, err error) {
+	s.tx(func() {
+		var ok bool
+		if k, ok = s.upstreamKeySets[connectorID]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+	})
+	return
+}
+
+func (s *memStorage) UpdateUpstreamKeySet(connectorID string, updater func(k storage.UpstreamKeySet) (storage.UpstreamKeySet, error)) (err error) {
+	s.tx(func() {
+		r, ok := s.upstreamKeySets[connectorID]
+		if !ok {
+			err = storage.ErrNotFound
+			return
+		}
+		if r, err = updater(r); err == nil {
+			s.upstreamKeySets[connectorID] = r
+		}
+	})
+	return
+}
+
+func (s *memStorage) CreatePushedAuthRequest(p storage.PushedAuthRequest) (err error) {
+	s.tx(func() {
+		if _, ok := s.pushedAuthReqs[p.ID]; ok {
+			err = storage.ErrAlreadyExists
+		} else {
+			s.pushedAuthReqs[p.ID] = p
+		}
+	})
+	return
+}
+
+func (s *memStorage) GetPushedAuthRequest(id string) (p storage.PushedAuthRequest, err error) {
+	s.tx(func() {
+		var ok bool
+		if p, ok = s.pushedAuthReqs[id]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+	})
+	return
+}
+
+func (s *memStorage) DeletePushedAuthRequest(id string) (err error) {
+	s.tx(func() {
+		if _, ok := s.pushedAuthReqs[id]; !ok {
+			err = storage.ErrNotFound
+			return
+		}
+		delete(s.pushedAuthReqs, id)
+	})
+	return
+}
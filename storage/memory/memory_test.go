@@ -3,8 +3,11 @@ package memory
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dexidp/dex/storage"
 	"github.com/dexidp/dex/storage/conformance"
@@ -22,3 +25,41 @@ func TestStorage(t *testing.T) {
 	}
 	conformance.RunTests(t, newStorage)
 }
+
+func TestGarbageCollectEvictions(t *testing.T) {
+	var evictions []Eviction
+	s := New(logrus.New(), WithEvictionCallback(func(e Eviction) {
+		evictions = append(evictions, e)
+	}))
+
+	now := time.Now()
+	expired, valid := now.Add(-time.Minute), now.Add(time.Minute)
+	require.NoError(t, s.CreateAuthRequest(storage.AuthRequest{ID: "expired-request", ClientID: "abandoned", Expiry: expired}))
+	require.NoError(t, s.CreateAuthRequest(storage.AuthRequest{ID: "valid-request", ClientID: "active", Expiry: valid}))
+	require.NoError(t, s.CreateAuthCode(storage.AuthCode{ID: "expired-code", ClientID: "unredeemed", Expiry: expired}))
+	require.NoError(t, s.CreateDeviceToken(storage.DeviceToken{DeviceCode: "expired-device-code", Expiry: expired}))
+
+	deleted := func(kind string) float64 {
+		return testutil.ToFloat64(gcDeleted.WithLabelValues(kind))
+	}
+	before := map[string]float64{}
+	for _, kind := range []string{KindAuthRequest, KindAuthCode, KindDeviceToken} {
+		before[kind] = deleted(kind)
+	}
+
+	result, err := s.GarbageCollect(now)
+	require.NoError(t, err)
+	require.Equal(t, storage.GCResult{AuthRequests: 1, AuthCodes: 1, DeviceTokens: 1}, result)
+
+	require.ElementsMatch(t, []Eviction{
+		{Kind: KindAuthRequest, ID: "expired-request", ClientID: "abandoned"},
+		{Kind: KindAuthCode, ID: "expired-code", ClientID: "unredeemed"},
+		{Kind: KindDeviceToken, ID: "expired-device-code"},
+	}, evictions)
+	for kind, n := range before {
+		require.Equal(t, n+1, deleted(kind), kind)
+	}
+
+	_, err = s.GetAuthRequest("valid-request")
+	require.NoError(t, err)
+}
@@ -0,0 +1,25 @@
+package memory
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics are the Prometheus metrics of the in memory storages. They have to
+// be registered to be exported.
+var Metrics prometheus.Collector = gcDeleted
+
+// gcDeleted counts the expired objects garbage collection deleted, by kind.
+// Expired auth requests, device requests and auth codes are flows the clients
+// or the users abandoned.
+var gcDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "memory_storage_gc_deleted_total",
+	Help: "Count of expired objects deleted by garbage collection of the in memory storage by kind.",
+}, []string{"kind"})
+
+// Kinds of the objects garbage collection deletes.
+const (
+	KindAuthRequest       = "auth_request"
+	KindAuthCode          = "auth_code"
+	KindDeviceRequest     = "device_request"
+	KindDeviceToken       = "device_token"
+	KindIssuedToken       = "issued_token"
+	KindPushedAuthRequest = "pushed_auth_request"
+)
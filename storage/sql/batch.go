@@ -0,0 +1,183 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dexidp/dex/storage"
+)
+
+// defaultMaxBatchSize limits the number of writes of a batch if no limit is
+// configured.
+const defaultMaxBatchSize = 100
+
+// errStorageClosed is returned for writes submitted after the storage was
+// closed.
+var errStorageClosed = errors.New("storage is closed")
+
+// WriteBatching configures coalescing the inserts of auth requests and auth
+// codes, which every login writes, into shared transactions. This relieves the
+// database during login spikes, at the cost of delaying each insert by up to
+// the batching window.
+type WriteBatching struct {
+	// Window is how long the first insert of a batch waits for more inserts,
+	// for example "5ms". Batching is disabled if unset.
+	Window string `json:"window"`
+
+	// MaxSize is the maximum number of inserts of a batch, 100 by default.
+	// Full batches are written without waiting for the end of the window.
+	MaxSize int `json:"maxSize"`
+}
+
+// wrap returns the storage batching the writes of the connection, if
+// configured.
+func (w WriteBatching) wrap(c *conn) (storage.Storage, error) {
+	if w.Window == "" {
+		return c, nil
+	}
+	window, err := time.ParseDuration(w.Window)
+	if err != nil || window <= 0 {
+		c.db.Close()
+		return nil, fmt.Errorf("invalid write batching window %q", w.Window)
+	}
+	maxSize := w.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxBatchSize
+	}
+	c.logger.Infof("sql: batching auth request and auth code inserts within %v, at most %d at a time", window, maxSize)
+	return &batchingConn{conn: c, batcher: newWriteBatcher(c, window, maxSize)}, nil
+}
+
+// batchingConn is a conn inserting auth requests and auth codes in batches.
+type batchingConn struct {
+	*conn
+	batcher *writeBatcher
+}
+
+func (c *batchingConn) CreateAuthRequest(a storage.AuthRequest) error {
+	return c.batcher.write(func(e execer) error { return c.createAuthRequest(e, a) })
+}
+
+func (c *batchingConn) CreateAuthCode(a storage.AuthCode) error {
+	return c.batcher.write(func(e execer) error { return c.createAuthCode(e, a) })
+}
+
+func (c *batchingConn) Close() error {
+	c.batcher.close()
+	return c.conn.Close()
+}
+
+// writeBatcher applies the writes submitted within a window in a single
+// transaction. Writes are applied in the order they're submitted, and only
+// return once committed, so they can be read right away.
+type writeBatcher struct {
+	c       *conn
+	window  time.Duration
+	maxSize int
+
+	writes    chan *batchedWrite
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+
+	// flushed is called with the size of each batch written, for tests.
+	flushed func(size int)
+}
+
+type batchedWrite struct {
+	exec func(e execer) error
+	err  chan error
+}
+
+func newWriteBatcher(c *conn, window time.Duration, maxSize int) *writeBatcher {
+	b := &writeBatcher{
+		c:       c,
+		window:  window,
+		maxSize: maxSize,
+		writes:  make(chan *batchedWrite),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// write submits the write and waits for its batch to be written.
+func (b *writeBatcher) write(exec func(e execer) error) error {
+	w := &batchedWrite{exec: exec, err: make(chan error, 1)}
+	select {
+	case b.writes <- w:
+	case <-b.done:
+		return errStorageClosed
+	}
+	return <-w.err
+}
+
+// close writes the pending batch, and rejects further writes.
+func (b *writeBatcher) close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+		<-b.stopped
+	})
+}
+
+func (b *writeBatcher) run() {
+	defer close(b.stopped)
+	for {
+		var batch []*batchedWrite
+		select {
+		case w := <-b.writes:
+			batch = append(batch, w)
+		case <-b.done:
+			return
+		}
+
+		timer := time.NewTimer(b.window)
+	collect:
+		for len(batch) < b.maxSize {
+			select {
+			case w := <-b.writes:
+				batch = append(batch, w)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		b.flush(batch)
+	}
+}
+
+func (b *writeBatcher) flush(batch []*batchedWrite) {
+	if b.flushed != nil {
+		b.flushed(len(batch))
+	}
+	if len(batch) == 1 {
+		batch[0].err <- batch[0].exec(b.c)
+		return
+	}
+
+	err := b.c.ExecTx(func(tx *trans) error {
+		for _, w := range batch {
+			if err := w.exec(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		for _, w := range batch {
+			w.err <- nil
+		}
+		return
+	}
+
+	// A write failed, for example on a conflicting ID, and rolled back the
+	// others. Apply them one by one, so each caller gets its own result.
+	b.c.logger.Debugf("sql: batch of %d writes failed, writing them one by one: %v", len(batch), err)
+	for _, w := range batch {
+		w.err <- w.exec(b.c)
+	}
+}
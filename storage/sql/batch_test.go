@@ -0,0 +1,129 @@
+//go:build cgo
+// +build cgo
+
+package sql
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dexidp/dex/storage"
+	"github.com/dexidp/dex/storage/conformance"
+)
+
+func newBatchingStorage(t *testing.T, window string) *batchingConn {
+	s, err := (&SQLite3{File: ":memory:", WriteBatching: WriteBatching{Window: window}}).Open(logger)
+	require.NoError(t, err)
+	c, ok := s.(*batchingConn)
+	require.True(t, ok, "expected a batching storage, got %T", s)
+	return c
+}
+
+func TestWriteBatchingConformance(t *testing.T) {
+	withTimeout(time.Minute, func() {
+		conformance.RunTests(t, func() storage.Storage {
+			return newBatchingStorage(t, "1ms")
+		})
+	})
+}
+
+func TestWriteBatching(t *testing.T) {
+	s := newBatchingStorage(t, "50ms")
+	defer s.Close()
+
+	var (
+		mu      sync.Mutex
+		batches []int
+	)
+	s.batcher.flushed = func(size int) {
+		mu.Lock()
+		batches = append(batches, size)
+		mu.Unlock()
+	}
+
+	authCode := func(id string) storage.AuthCode {
+		return storage.AuthCode{
+			ID:          id,
+			ClientID:    "client",
+			RedirectURI: "https://client.example.com/callback",
+			Expiry:      time.Now().Add(time.Minute).Round(time.Millisecond),
+			Claims:      storage.Claims{UserID: "user-" + id, Email: id + "@example.com"},
+		}
+	}
+
+	const n = 50
+	// The last code reuses the ID of the first, one of them must be rejected
+	// without losing the other writes of the batch.
+	errs := make([]error, n+1)
+	var wg sync.WaitGroup
+	for i := 0; i <= n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.CreateAuthCode(authCode(fmt.Sprintf("code-%d", i%n)))
+		}(i)
+	}
+	wg.Wait()
+
+	conflicts := 0
+	for i, err := range errs {
+		if i%n == 0 && err == storage.ErrAlreadyExists {
+			conflicts++
+			continue
+		}
+		require.NoError(t, err, "auth code %d", i)
+	}
+	require.Equal(t, 1, conflicts, "exactly one of the conflicting writes must fail")
+
+	for i := 0; i < n; i++ {
+		want := authCode(fmt.Sprintf("code-%d", i))
+		got, err := s.GetAuthCode(want.ID)
+		require.NoError(t, err)
+		require.Equal(t, want.Claims, got.Claims)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	total := 0
+	for _, size := range batches {
+		total += size
+	}
+	require.Equal(t, n+1, total)
+	require.Less(t, len(batches), n+1, "expected the writes to be batched, got batches of %v", batches)
+}
+
+func TestWriteBatchingMaxSize(t *testing.T) {
+	s := newBatchingStorage(t, "1h")
+	defer s.Close()
+	s.batcher.maxSize = 2
+
+	// Full batches are written without waiting for the window to end.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, s.CreateAuthRequest(storage.AuthRequest{
+				ID:       fmt.Sprintf("req-%d", i),
+				ClientID: "client",
+				Expiry:   time.Now().Add(time.Minute),
+			}))
+		}(i)
+	}
+	withTimeout(10*time.Second, wg.Wait)
+}
+
+func TestWriteBatchingClosed(t *testing.T) {
+	s := newBatchingStorage(t, "1ms")
+	require.NoError(t, s.Close())
+	require.Equal(t, errStorageClosed, s.CreateAuthCode(storage.AuthCode{ID: "code"}))
+}
+
+func TestInvalidWriteBatching(t *testing.T) {
+	_, err := (&SQLite3{File: ":memory:", WriteBatching: WriteBatching{Window: "soon"}}).Open(logger)
+	require.Error(t, err)
+}
@@ -62,9 +62,11 @@ type NetworkDB struct {
 	// database/sql tunables, see
 	// https://golang.org/pkg/database/sql/#DB.SetConnMaxLifetime and below
 	// Note: defaults will be set if these are 0
-	MaxOpenConns    int // default: 5
-	MaxIdleConns    int // default: 5
+	MaxOpenConns    int // default: 5 for Postgres, unlimited for MySQL
+	MaxIdleConns    int // default: 5 for Postgres, none for MySQL
 	ConnMaxLifetime int // Seconds, default: not set
+
+	WriteBatching WriteBatching
 }
 
 // SSL represents SSL options for network databases.
@@ -89,7 +91,7 @@ func (p *Postgres) Open(logger log.Logger) (storage.Storage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
+	return p.WriteBatching.wrap(conn)
 }
 
 var strEsc = regexp.MustCompile(`([\\'])`)
@@ -215,13 +217,32 @@ type MySQL struct {
 	params map[string]string
 }
 
+// setTunables sets the database/sql tunables of the connection pool.
+func (s *MySQL) setTunables(db *sql.DB) {
+	if s.ConnMaxLifetime != 0 {
+		db.SetConnMaxLifetime(time.Duration(s.ConnMaxLifetime) * time.Second)
+	}
+
+	if s.MaxIdleConns == 0 {
+		/*Override default behaviour to fix https://github.com/dexidp/dex/issues/1608*/
+		db.SetMaxIdleConns(0)
+	} else {
+		db.SetMaxIdleConns(s.MaxIdleConns)
+	}
+
+	// Unlike Postgres, MySQL connections aren't limited unless configured.
+	if s.MaxOpenConns != 0 {
+		db.SetMaxOpenConns(s.MaxOpenConns)
+	}
+}
+
 // Open creates a new storage implementation backed by MySQL.
 func (s *MySQL) Open(logger log.Logger) (storage.Storage, error) {
 	conn, err := s.open(logger)
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
+	return s.WriteBatching.wrap(conn)
 }
 
 func (s *MySQL) open(logger log.Logger) (*conn, error) {
@@ -272,13 +293,7 @@ func (s *MySQL) open(logger log.Logger) (*conn, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	if s.MaxIdleConns == 0 {
-		/*Override default behaviour to fix https://github.com/dexidp/dex/issues/1608*/
-		db.SetMaxIdleConns(0)
-	} else {
-		db.SetMaxIdleConns(s.MaxIdleConns)
-	}
+	s.setTunables(db)
 
 	err = db.Ping()
 	if err != nil {
@@ -295,6 +310,7 @@ func (s *MySQL) open(logger log.Logger) (*conn, error) {
 			if err != nil {
 				return nil, err
 			}
+			s.setTunables(db)
 		} else {
 			return nil, err
 		}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
 	"runtime"
@@ -248,6 +249,28 @@ func TestPostgres(t *testing.T) {
 
 const testMySQLEnv = "DEX_MYSQL_HOST"
 
+func TestMySQLTunables(t *testing.T) {
+	for _, tc := range []struct {
+		maxOpenConns int
+		want         int
+	}{
+		{maxOpenConns: 0, want: 0}, // unlimited
+		{maxOpenConns: 20, want: 20},
+	} {
+		// The connections are only opened when they're used.
+		db, err := sql.Open("mysql", "dex@tcp(127.0.0.1:3306)/dex")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := &MySQL{NetworkDB: NetworkDB{MaxOpenConns: tc.maxOpenConns, ConnMaxLifetime: 60}}
+		s.setTunables(db)
+		if got := db.Stats().MaxOpenConnections; got != tc.want {
+			t.Errorf("expected max open connections %d for maxOpenConns %d, got %d", tc.want, tc.maxOpenConns, got)
+		}
+		db.Close()
+	}
+}
+
 func TestMySQL(t *testing.T) {
 	host := os.Getenv(testMySQLEnv)
 	if host == "" {
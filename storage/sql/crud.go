@@ -41,7 +41,13 @@ func encoder(i interface{}) driver.Valuer {
 // decoder wraps the underlying value in a JSON unmarshaler which can then be passed
 // to a database Scan() method.
 func decoder(i interface{}) sql.Scanner {
-	return jsonDecoder{i}
+	return jsonDecoder{i: i}
+}
+
+// nullableDecoder is like decoder, but leaves the value untouched for NULL
+// columns, such as JSON columns added by later migrations.
+func nullableDecoder(i interface{}) sql.Scanner {
+	return jsonDecoder{i: i, nullable: true}
 }
 
 type jsonEncoder struct {
@@ -57,11 +63,15 @@ func (j jsonEncoder) Value() (driver.Value, error) {
 }
 
 type jsonDecoder struct {
-	i interface{}
+	i        interface{}
+	nullable bool
 }
 
 func (j jsonDecoder) Scan(dest interface{}) error {
 	if dest == nil {
+		if j.nullable {
+			return nil
+		}
 		return errors.New("nil value")
 	}
 	b, ok := dest.([]byte)
@@ -79,6 +89,11 @@ type querier interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
+// Abstract conn vs trans for writes.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 // Abstract row vs rows.
 type scanner interface {
 	Scan(dest ...interface{}) error
@@ -119,11 +134,31 @@ func (c *conn) GarbageCollect(now time.Time) (storage.GCResult, error) {
 		result.DeviceTokens = n
 	}
 
+	r, err = c.Exec(`delete from issued_token where expiry < $1`, now)
+	if err != nil {
+		return result, fmt.Errorf("gc issued_token: %v", err)
+	}
+	if n, err := r.RowsAffected(); err == nil {
+		result.IssuedTokens = n
+	}
+
+	r, err = c.Exec(`delete from pushed_auth_request where expiry < $1`, now)
+	if err != nil {
+		return result, fmt.Errorf("gc pushed_auth_request: %v", err)
+	}
+	if n, err := r.RowsAffected(); err == nil {
+		result.PushedAuthRequests = n
+	}
+
 	return result, err
 }
 
 func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
-	_, err := c.Exec(`
+	return c.createAuthRequest(c, a)
+}
+
+func (c *conn) createAuthRequest(e execer, a storage.AuthRequest) error {
+	_, err := e.Exec(`
 		insert into auth_request (
 			id, client_id, response_types, scopes, redirect_uri, nonce, state,
 			force_approval_prompt, logged_in,
@@ -131,10 +166,12 @@ func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data,
 			expiry,
-			code_challenge, code_challenge_method
+			code_challenge, code_challenge_method,
+			resources, prompt, claims_auth_time, essential_claims,
+			acr_values, max_age, claims_organizations, claims_address, claims_custom
 		)
 		values (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29
 		);
 	`,
 		a.ID, a.ClientID, encoder(a.ResponseTypes), encoder(a.Scopes), a.RedirectURI, a.Nonce, a.State,
@@ -144,6 +181,9 @@ func (c *conn) CreateAuthRequest(a storage.AuthRequest) error {
 		a.ConnectorID, a.ConnectorData,
 		a.Expiry,
 		a.PKCE.CodeChallenge, a.PKCE.CodeChallengeMethod,
+		encoder(a.Resources), a.Prompt, a.Claims.AuthTime, encoder(a.EssentialClaims),
+		encoder(a.ACRValues), a.MaxAge, encoder(a.Claims.Organizations), encoder(a.Claims.Address),
+		encoder(a.Claims.CustomClaims),
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
@@ -175,8 +215,12 @@ func (c *conn) UpdateAuthRequest(id string, updater func(a storage.AuthRequest)
 				claims_groups = $14,
 				connector_id = $15, connector_data = $16,
 				expiry = $17,
-				code_challenge = $18, code_challenge_method = $19
-			where id = $20;
+				code_challenge = $18, code_challenge_method = $19,
+				resources = $20, prompt = $21, claims_auth_time = $22,
+				essential_claims = $23, acr_values = $24, max_age = $25,
+				claims_organizations = $26, claims_address = $27,
+				claims_custom = $28
+			where id = $29;
 		`,
 			a.ClientID, encoder(a.ResponseTypes), encoder(a.Scopes), a.RedirectURI, a.Nonce, a.State,
 			a.ForceApprovalPrompt, a.LoggedIn,
@@ -186,6 +230,10 @@ func (c *conn) UpdateAuthRequest(id string, updater func(a storage.AuthRequest)
 			a.ConnectorID, a.ConnectorData,
 			a.Expiry,
 			a.PKCE.CodeChallenge, a.PKCE.CodeChallengeMethod,
+			encoder(a.Resources), a.Prompt, a.Claims.AuthTime,
+			encoder(a.EssentialClaims), encoder(a.ACRValues), a.MaxAge,
+			encoder(a.Claims.Organizations), encoder(a.Claims.Address),
+			encoder(a.Claims.CustomClaims),
 			r.ID,
 		)
 		if err != nil {
@@ -207,7 +255,9 @@ func getAuthRequest(q querier, id string) (a storage.AuthRequest, err error) {
 			claims_user_id, claims_username, claims_preferred_username,
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data, expiry,
-			code_challenge, code_challenge_method
+			code_challenge, code_challenge_method,
+			resources, prompt, claims_auth_time, essential_claims,
+			acr_values, max_age, claims_organizations, claims_address, claims_custom
 		from auth_request where id = $1;
 	`, id).Scan(
 		&a.ID, &a.ClientID, decoder(&a.ResponseTypes), decoder(&a.Scopes), &a.RedirectURI, &a.Nonce, &a.State,
@@ -217,6 +267,9 @@ func getAuthRequest(q querier, id string) (a storage.AuthRequest, err error) {
 		decoder(&a.Claims.Groups),
 		&a.ConnectorID, &a.ConnectorData, &a.Expiry,
 		&a.PKCE.CodeChallenge, &a.PKCE.CodeChallengeMethod,
+		nullableDecoder(&a.Resources), &a.Prompt, &a.Claims.AuthTime, nullableDecoder(&a.EssentialClaims),
+		nullableDecoder(&a.ACRValues), &a.MaxAge, nullableDecoder(&a.Claims.Organizations),
+		nullableDecoder(&a.Claims.Address), nullableDecoder(&a.Claims.CustomClaims),
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -228,21 +281,28 @@ func getAuthRequest(q querier, id string) (a storage.AuthRequest, err error) {
 }
 
 func (c *conn) CreateAuthCode(a storage.AuthCode) error {
-	_, err := c.Exec(`
+	return c.createAuthCode(c, a)
+}
+
+func (c *conn) createAuthCode(e execer, a storage.AuthCode) error {
+	_, err := e.Exec(`
 		insert into auth_code (
 			id, client_id, scopes, nonce, redirect_uri,
 			claims_user_id, claims_username, claims_preferred_username,
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data,
 			expiry,
-			code_challenge, code_challenge_method
+			code_challenge, code_challenge_method,
+			resources, claims_auth_time, claims_organizations, claims_address, claims_custom
 		)
-		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21);
 	`,
 		a.ID, a.ClientID, encoder(a.Scopes), a.Nonce, a.RedirectURI, a.Claims.UserID,
 		a.Claims.Username, a.Claims.PreferredUsername, a.Claims.Email, a.Claims.EmailVerified,
 		encoder(a.Claims.Groups), a.ConnectorID, a.ConnectorData, a.Expiry,
 		a.PKCE.CodeChallenge, a.PKCE.CodeChallengeMethod,
+		encoder(a.Resources), a.Claims.AuthTime, encoder(a.Claims.Organizations),
+		encoder(a.Claims.Address), encoder(a.Claims.CustomClaims),
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
@@ -261,13 +321,16 @@ func (c *conn) GetAuthCode(id string) (a storage.AuthCode, err error) {
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data,
 			expiry,
-			code_challenge, code_challenge_method
+			code_challenge, code_challenge_method,
+			resources, claims_auth_time, claims_organizations, claims_address, claims_custom
 		from auth_code where id = $1;
 	`, id).Scan(
 		&a.ID, &a.ClientID, decoder(&a.Scopes), &a.Nonce, &a.RedirectURI, &a.Claims.UserID,
 		&a.Claims.Username, &a.Claims.PreferredUsername, &a.Claims.Email, &a.Claims.EmailVerified,
 		decoder(&a.Claims.Groups), &a.ConnectorID, &a.ConnectorData, &a.Expiry,
 		&a.PKCE.CodeChallenge, &a.PKCE.CodeChallengeMethod,
+		nullableDecoder(&a.Resources), &a.Claims.AuthTime, nullableDecoder(&a.Claims.Organizations),
+		nullableDecoder(&a.Claims.Address), nullableDecoder(&a.Claims.CustomClaims),
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -285,9 +348,10 @@ func (c *conn) CreateRefresh(r storage.RefreshToken) error {
 			claims_user_id, claims_username, claims_preferred_username,
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data,
-			token, obsolete_token, created_at, last_used
+			token, obsolete_token, created_at, last_used,
+			resources, claims_auth_time, claims_organizations, claims_address, claims_custom
 		)
-		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21);
 	`,
 		r.ID, r.ClientID, encoder(r.Scopes), r.Nonce,
 		r.Claims.UserID, r.Claims.Username, r.Claims.PreferredUsername,
@@ -295,6 +359,8 @@ func (c *conn) CreateRefresh(r storage.RefreshToken) error {
 		encoder(r.Claims.Groups),
 		r.ConnectorID, r.ConnectorData,
 		r.Token, r.ObsoleteToken, r.CreatedAt, r.LastUsed,
+		encoder(r.Resources), r.Claims.AuthTime, encoder(r.Claims.Organizations),
+		encoder(r.Claims.Address), encoder(r.Claims.CustomClaims),
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
@@ -331,16 +397,24 @@ func (c *conn) UpdateRefreshToken(id string, updater func(old storage.RefreshTok
 				token = $12,
                 obsolete_token = $13,
 				created_at = $14,
-				last_used = $15
+				last_used = $15,
+				resources = $16,
+				claims_auth_time = $17,
+				claims_organizations = $18,
+				claims_address = $19,
+				claims_custom = $20
 			where
-				id = $16
+				id = $21
 		`,
 			r.ClientID, encoder(r.Scopes), r.Nonce,
 			r.Claims.UserID, r.Claims.Username, r.Claims.PreferredUsername,
 			r.Claims.Email, r.Claims.EmailVerified,
 			encoder(r.Claims.Groups),
 			r.ConnectorID, r.ConnectorData,
-			r.Token, r.ObsoleteToken, r.CreatedAt, r.LastUsed, id,
+			r.Token, r.ObsoleteToken, r.CreatedAt, r.LastUsed,
+			encoder(r.Resources), r.Claims.AuthTime,
+			encoder(r.Claims.Organizations), encoder(r.Claims.Address),
+			encoder(r.Claims.CustomClaims), id,
 		)
 		if err != nil {
 			return fmt.Errorf("update refresh token: %v", err)
@@ -361,7 +435,8 @@ func getRefresh(q querier, id string) (storage.RefreshToken, error) {
 			claims_email, claims_email_verified,
 			claims_groups,
 			connector_id, connector_data,
-			token, obsolete_token, created_at, last_used
+			token, obsolete_token, created_at, last_used,
+			resources, claims_auth_time, claims_organizations, claims_address, claims_custom
 		from refresh_token where id = $1;
 	`, id))
 }
@@ -373,7 +448,8 @@ func (c *conn) ListRefreshTokens() ([]storage.RefreshToken, error) {
 			claims_user_id, claims_username, claims_preferred_username,
 			claims_email, claims_email_verified, claims_groups,
 			connector_id, connector_data,
-			token, obsolete_token, created_at, last_used
+			token, obsolete_token, created_at, last_used,
+			resources, claims_auth_time, claims_organizations, claims_address, claims_custom
 		from refresh_token;
 	`)
 	if err != nil {
@@ -403,6 +479,8 @@ func scanRefresh(s scanner) (r storage.RefreshToken, err error) {
 		decoder(&r.Claims.Groups),
 		&r.ConnectorID, &r.ConnectorData,
 		&r.Token, &r.ObsoleteToken, &r.CreatedAt, &r.LastUsed,
+		nullableDecoder(&r.Resources), &r.Claims.AuthTime, nullableDecoder(&r.Claims.Organizations),
+		nullableDecoder(&r.Claims.Address), nullableDecoder(&r.Claims.CustomClaims),
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -508,9 +586,21 @@ func (c *conn) UpdateClient(id string, updater func(old storage.Client) (storage
 				trusted_peers = $3,
 				public = $4,
 				name = $5,
-				logo_url = $6
-			where id = $7;
-		`, nc.Secret, encoder(nc.RedirectURIs), encoder(nc.TrustedPeers), nc.Public, nc.Name, nc.LogoURL, id,
+				logo_url = $6,
+				hash_groups = $7,
+				groups_salt = $8,
+				resources = $9,
+				require_verified_email = $10,
+				redirect_uri_path_pattern = $11,
+				consent_text = $12,
+				privacy_policy_url = $13,
+				allowed_group_prefixes = $14,
+				allowed_groups_pattern = $15
+			where id = $16;
+		`, nc.Secret, encoder(nc.RedirectURIs), encoder(nc.TrustedPeers), nc.Public, nc.Name, nc.LogoURL,
+			nc.HashGroups, nc.GroupsSalt, encoder(nc.Resources), nc.RequireVerifiedEmail,
+			nc.RedirectURIPathPattern, nc.ConsentText, nc.PrivacyPolicyURL,
+			encoder(nc.AllowedGroupPrefixes), nc.AllowedGroupsPattern, id,
 		)
 		if err != nil {
 			return fmt.Errorf("update client: %v", err)
@@ -522,12 +612,18 @@ func (c *conn) UpdateClient(id string, updater func(old storage.Client) (storage
 func (c *conn) CreateClient(cli storage.Client) error {
 	_, err := c.Exec(`
 		insert into client (
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			hash_groups, groups_salt, resources, require_verified_email,
+			redirect_uri_path_pattern, consent_text, privacy_policy_url,
+			allowed_group_prefixes, allowed_groups_pattern
 		)
-		values ($1, $2, $3, $4, $5, $6, $7);
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
 	`,
 		cli.ID, cli.Secret, encoder(cli.RedirectURIs), encoder(cli.TrustedPeers),
-		cli.Public, cli.Name, cli.LogoURL,
+		cli.Public, cli.Name, cli.LogoURL, cli.HashGroups, cli.GroupsSalt,
+		encoder(cli.Resources), cli.RequireVerifiedEmail, cli.RedirectURIPathPattern,
+		cli.ConsentText, cli.PrivacyPolicyURL, encoder(cli.AllowedGroupPrefixes),
+		cli.AllowedGroupsPattern,
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
@@ -541,7 +637,10 @@ func (c *conn) CreateClient(cli storage.Client) error {
 func getClient(q querier, id string) (storage.Client, error) {
 	return scanClient(q.QueryRow(`
 		select
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			hash_groups, groups_salt, resources, require_verified_email,
+			redirect_uri_path_pattern, consent_text, privacy_policy_url,
+			allowed_group_prefixes, allowed_groups_pattern
 	    from client where id = $1;
 	`, id))
 }
@@ -553,7 +652,10 @@ func (c *conn) GetClient(id string) (storage.Client, error) {
 func (c *conn) ListClients() ([]storage.Client, error) {
 	rows, err := c.Query(`
 		select
-			id, secret, redirect_uris, trusted_peers, public, name, logo_url
+			id, secret, redirect_uris, trusted_peers, public, name, logo_url,
+			hash_groups, groups_salt, resources, require_verified_email,
+			redirect_uri_path_pattern, consent_text, privacy_policy_url,
+			allowed_group_prefixes, allowed_groups_pattern
 		from client;
 	`)
 	if err != nil {
@@ -578,7 +680,10 @@ func (c *conn) ListClients() ([]storage.Client, error) {
 func scanClient(s scanner) (cli storage.Client, err error) {
 	err = s.Scan(
 		&cli.ID, &cli.Secret, decoder(&cli.RedirectURIs), decoder(&cli.TrustedPeers),
-		&cli.Public, &cli.Name, &cli.LogoURL,
+		&cli.Public, &cli.Name, &cli.LogoURL, &cli.HashGroups, &cli.GroupsSalt,
+		nullableDecoder(&cli.Resources), &cli.RequireVerifiedEmail,
+		&cli.RedirectURIPathPattern, &cli.ConsentText, &cli.PrivacyPolicyURL,
+		nullableDecoder(&cli.AllowedGroupPrefixes), &cli.AllowedGroupsPattern,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -689,13 +794,13 @@ func scanPassword(s scanner) (p storage.Password, err error) {
 func (c *conn) CreateOfflineSessions(s storage.OfflineSessions) error {
 	_, err := c.Exec(`
 		insert into offline_session (
-			user_id, conn_id, refresh, connector_data
+			user_id, conn_id, refresh, connector_data, last_login
 		)
 		values (
-			$1, $2, $3, $4
+			$1, $2, $3, $4, $5
 		);
 	`,
-		s.UserID, s.ConnID, encoder(s.Refresh), s.ConnectorData,
+		s.UserID, s.ConnID, encoder(s.Refresh), s.ConnectorData, s.LastLogin,
 	)
 	if err != nil {
 		if c.alreadyExistsCheck(err) {
@@ -721,10 +826,11 @@ func (c *conn) UpdateOfflineSessions(userID string, connID string, updater func(
 			update offline_session
 			set
 				refresh = $1,
-				connector_data = $2
-			where user_id = $3 AND conn_id = $4;
+				connector_data = $2,
+				last_login = $3
+			where user_id = $4 AND conn_id = $5;
 		`,
-			encoder(newSession.Refresh), newSession.ConnectorData, s.UserID, s.ConnID,
+			encoder(newSession.Refresh), newSession.ConnectorData, newSession.LastLogin, s.UserID, s.ConnID,
 		)
 		if err != nil {
 			return fmt.Errorf("update offline session: %v", err)
@@ -740,7 +846,7 @@ func (c *conn) GetOfflineSessions(userID string, connID string) (storage.Offline
 func getOfflineSessions(q querier, userID string, connID string) (storage.OfflineSessions, error) {
 	return scanOfflineSessions(q.QueryRow(`
 		select
-			user_id, conn_id, refresh, connector_data
+			user_id, conn_id, refresh, connector_data, last_login
 		from offline_session
 		where user_id = $1 AND conn_id = $2;
 		`, userID, connID))
@@ -748,7 +854,7 @@ func getOfflineSessions(q querier, userID string, connID string) (storage.Offlin
 
 func scanOfflineSessions(s scanner) (o storage.OfflineSessions, err error) {
 	err = s.Scan(
-		&o.UserID, &o.ConnID, decoder(&o.Refresh), &o.ConnectorData,
+		&o.UserID, &o.ConnID, decoder(&o.Refresh), &o.ConnectorData, &o.LastLogin,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -866,7 +972,9 @@ func (c *conn) DeleteRefresh(id string) error     { return c.delete("refresh_tok
 func (c *conn) DeletePassword(email string) error {
 	return c.delete("password", "email", strings.ToLower(email))
 }
-func (c *conn) DeleteConnector(id string) error { return c.delete("connector", "id", id) }
+func (c *conn) DeleteConnector(id string) error         { return c.delete("connector", "id", id) }
+func (c *conn) DeleteIssuedToken(id string) error       { return c.delete("issued_token", "id", id) }
+func (c *conn) DeletePushedAuthRequest(id string) error { return c.delete("pushed_auth_request", "id", id) }
 
 func (c *conn) DeleteOfflineSessions(userID string, connID string) error {
 	result, err := c.Exec(`delete from offline_session where user_id = $1 AND conn_id = $2`, userID, connID)
@@ -1014,3 +1122,142 @@ func (c *conn) UpdateDeviceToken(deviceCode string, updater func(old storage.Dev
 		return nil
 	})
 }
+
+func (c *conn) CreateIssuedToken(t storage.IssuedToken) error {
+	_, err := c.Exec(`
+		insert into issued_token (
+			id, client_id, user_id, connector_id, issued_at, expiry
+		)
+		values (
+			$1, $2, $3, $4, $5, $6
+		);`,
+		t.ID, t.ClientID, t.UserID, t.ConnectorID, t.IssuedAt, t.Expiry,
+	)
+	if err != nil {
+		if c.alreadyExistsCheck(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert issued token: %v", err)
+	}
+	return nil
+}
+
+func (c *conn) GetIssuedToken(id string) (t storage.IssuedToken, err error) {
+	err = c.QueryRow(`
+		select
+			id, client_id, user_id, connector_id, issued_at, expiry
+		from issued_token where id = $1;
+	`, id).Scan(
+		&t.ID, &t.ClientID, &t.UserID, &t.ConnectorID, &t.IssuedAt, &t.Expiry,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return t, storage.ErrNotFound
+		}
+		return t, fmt.Errorf("select issued token: %v", err)
+	}
+	return t, nil
+}
+
+func (c *conn) CreateUpstreamKeySet(k storage.UpstreamKeySet) error {
+	_, err := c.Exec(`
+		insert into upstream_key_set (
+			connector_id, key_set, updated_at
+		)
+		values (
+			$1, $2, $3
+		);`,
+		k.ConnectorID, k.KeySet, k.UpdatedAt,
+	)
+	if err != nil {
+		if c.alreadyExistsCheck(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert upstream key set: %v", err)
+	}
+	return nil
+}
+
+func (c *conn) GetUpstreamKeySet(connectorID string) (storage.UpstreamKeySet, error) {
+	return getUpstreamKeySet(c, connectorID)
+}
+
+func getUpstreamKeySet(q querier, connectorID string) (k storage.UpstreamKeySet, err error) {
+	err = q.QueryRow(`
+		select
+			connector_id, key_set, updated_at
+		from upstream_key_set where connector_id = $1;
+	`, connectorID).Scan(
+		&k.ConnectorID, &k.KeySet, &k.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return k, storage.ErrNotFound
+		}
+		return k, fmt.Errorf("select upstream key set: %v", err)
+	}
+	return k, nil
+}
+
+func (c *conn) UpdateUpstreamKeySet(connectorID string, updater func(old storage.UpstreamKeySet) (storage.UpstreamKeySet, error)) error {
+	return c.ExecTx(func(tx *trans) error {
+		k, err := getUpstreamKeySet(tx, connectorID)
+		if err != nil {
+			return err
+		}
+
+		newKeySet, err := updater(k)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			update upstream_key_set
+			set
+				key_set = $1,
+				updated_at = $2
+			where connector_id = $3;
+		`,
+			newKeySet.KeySet, newKeySet.UpdatedAt, connectorID,
+		)
+		if err != nil {
+			return fmt.Errorf("update upstream key set: %v", err)
+		}
+		return nil
+	})
+}
+
+func (c *conn) CreatePushedAuthRequest(p storage.PushedAuthRequest) error {
+	_, err := c.Exec(`
+		insert into pushed_auth_request (
+			id, client_id, params, expiry
+		)
+		values (
+			$1, $2, $3, $4
+		);`,
+		p.ID, p.ClientID, encoder(p.Params), p.Expiry,
+	)
+	if err != nil {
+		if c.alreadyExistsCheck(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("insert pushed auth request: %v", err)
+	}
+	return nil
+}
+
+func (c *conn) GetPushedAuthRequest(id string) (p storage.PushedAuthRequest, err error) {
+	err = c.QueryRow(`
+		select
+			id, client_id, params, expiry
+		from pushed_auth_request where id = $1;
+	`, id).Scan(
+		&p.ID, &p.ClientID, decoder(&p.Params), &p.Expiry,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return p, storage.ErrNotFound
+		}
+		return p, fmt.Errorf("select pushed auth request: %v", err)
+	}
+	return p, nil
+}
@@ -281,4 +281,181 @@ var migrations = []migration{
 				add column obsolete_token text default '';`,
 		},
 	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column hash_groups boolean not null default false;`,
+			`
+			alter table client
+				add column groups_salt text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column resources bytea;`,
+			`
+			alter table auth_request
+				add column resources bytea;`,
+			`
+			alter table auth_code
+				add column resources bytea;`,
+			`
+			alter table refresh_token
+				add column resources bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column prompt text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create table issued_token (
+				id text not null primary key,
+				client_id text not null,
+				user_id text not null,
+				connector_id text not null,
+				issued_at timestamptz not null,
+				expiry timestamptz not null
+			);`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column claims_auth_time timestamptz not null default '0001-01-01 00:00:00 UTC';`,
+			`
+			alter table auth_code
+				add column claims_auth_time timestamptz not null default '0001-01-01 00:00:00 UTC';`,
+			`
+			alter table refresh_token
+				add column claims_auth_time timestamptz not null default '0001-01-01 00:00:00 UTC';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column require_verified_email boolean not null default false;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column essential_claims bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column acr_values bytea;`,
+			`
+			alter table auth_request
+				add column max_age integer;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column redirect_uri_path_pattern text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table offline_session
+				add column last_login timestamptz not null default '0001-01-01 00:00:00 UTC';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column claims_organizations bytea;`,
+			`
+			alter table auth_code
+				add column claims_organizations bytea;`,
+			`
+			alter table refresh_token
+				add column claims_organizations bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column claims_address bytea;`,
+			`
+			alter table auth_code
+				add column claims_address bytea;`,
+			`
+			alter table refresh_token
+				add column claims_address bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table auth_request
+				add column claims_custom bytea;`,
+			`
+			alter table auth_code
+				add column claims_custom bytea;`,
+			`
+			alter table refresh_token
+				add column claims_custom bytea;`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column consent_text text not null default '';`,
+			`
+			alter table client
+				add column privacy_policy_url text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create table upstream_key_set (
+				connector_id text not null primary key,
+				key_set bytea not null,
+				updated_at timestamptz not null
+			);`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			alter table client
+				add column allowed_group_prefixes bytea;`,
+			`
+			alter table client
+				add column allowed_groups_pattern text not null default '';`,
+		},
+	},
+	{
+		stmts: []string{
+			`
+			create table pushed_auth_request (
+				id text not null primary key,
+				client_id text not null,
+				params bytea not null,
+				expiry timestamptz not null
+			);`,
+		},
+	},
 }
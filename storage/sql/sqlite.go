@@ -16,6 +16,8 @@ import (
 type SQLite3 struct {
 	// File to
 	File string `json:"file"`
+
+	WriteBatching WriteBatching `json:"writeBatching"`
 }
 
 // Open creates a new storage implementation backed by SQLite3
@@ -24,7 +26,7 @@ func (s *SQLite3) Open(logger log.Logger) (storage.Storage, error) {
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
+	return s.WriteBatching.wrap(conn)
 }
 
 func (s *SQLite3) open(logger log.Logger) (*conn, error) {
@@ -49,10 +49,12 @@ func newSecureID(len int) string {
 
 // GCResult returns the number of objects deleted by garbage collection.
 type GCResult struct {
-	AuthRequests   int64
-	AuthCodes      int64
-	DeviceRequests int64
-	DeviceTokens   int64
+	AuthRequests       int64
+	AuthCodes          int64
+	DeviceRequests     int64
+	DeviceTokens       int64
+	IssuedTokens       int64
+	PushedAuthRequests int64
 }
 
 // IsEmpty returns whether the garbage collection result is empty or not.
@@ -60,7 +62,9 @@ func (g *GCResult) IsEmpty() bool {
 	return g.AuthRequests == 0 &&
 		g.AuthCodes == 0 &&
 		g.DeviceRequests == 0 &&
-		g.DeviceTokens == 0
+		g.DeviceTokens == 0 &&
+		g.IssuedTokens == 0 &&
+		g.PushedAuthRequests == 0
 }
 
 // Storage is the storage interface used by the server. Implementations are
@@ -79,6 +83,9 @@ type Storage interface {
 	CreateConnector(c Connector) error
 	CreateDeviceRequest(d DeviceRequest) error
 	CreateDeviceToken(d DeviceToken) error
+	CreateIssuedToken(t IssuedToken) error
+	CreateUpstreamKeySet(k UpstreamKeySet) error
+	CreatePushedAuthRequest(p PushedAuthRequest) error
 
 	// TODO(ericchiang): return (T, bool, error) so we can indicate not found
 	// requests that way instead of using ErrNotFound.
@@ -92,6 +99,9 @@ type Storage interface {
 	GetConnector(id string) (Connector, error)
 	GetDeviceRequest(userCode string) (DeviceRequest, error)
 	GetDeviceToken(deviceCode string) (DeviceToken, error)
+	GetIssuedToken(id string) (IssuedToken, error)
+	GetUpstreamKeySet(connectorID string) (UpstreamKeySet, error)
+	GetPushedAuthRequest(id string) (PushedAuthRequest, error)
 
 	ListClients() ([]Client, error)
 	ListRefreshTokens() ([]RefreshToken, error)
@@ -106,6 +116,8 @@ type Storage interface {
 	DeletePassword(email string) error
 	DeleteOfflineSessions(userID string, connID string) error
 	DeleteConnector(id string) error
+	DeleteIssuedToken(id string) error
+	DeletePushedAuthRequest(id string) error
 
 	// Update methods take a function for updating an object then performs that update within
 	// a transaction. "updater" functions may be called multiple times by a single update call.
@@ -129,9 +141,10 @@ type Storage interface {
 	UpdateOfflineSessions(userID string, connID string, updater func(s OfflineSessions) (OfflineSessions, error)) error
 	UpdateConnector(id string, updater func(c Connector) (Connector, error)) error
 	UpdateDeviceToken(deviceCode string, updater func(t DeviceToken) (DeviceToken, error)) error
+	UpdateUpstreamKeySet(connectorID string, updater func(k UpstreamKeySet) (UpstreamKeySet, error)) error
 
-	// GarbageCollect deletes all expired AuthCodes,
-	// AuthRequests, DeviceRequests, and DeviceTokens.
+	// GarbageCollect deletes all expired AuthCodes, AuthRequests,
+	// DeviceRequests, DeviceTokens, IssuedTokens, and PushedAuthRequests.
 	GarbageCollect(now time.Time) (GCResult, error)
 }
 
@@ -164,6 +177,37 @@ type Client struct {
 	// Name and LogoURL used when displaying this client to the end user.
 	Name    string `json:"name" yaml:"name"`
 	LogoURL string `json:"logoURL" yaml:"logoURL"`
+
+	// Resources are the resource indicators (RFC 8707) this client may request
+	// tokens for. Requested resources are added to the audience of issued tokens.
+	Resources []string `json:"resources" yaml:"resources"`
+
+	// HashGroups causes groups issued to this client to be replaced by salted
+	// hashes of the group names, keyed by the server's groups salt and GroupsSalt.
+	HashGroups bool   `json:"hashGroups" yaml:"hashGroups"`
+	GroupsSalt string `json:"groupsSalt" yaml:"groupsSalt"`
+
+	// RequireVerifiedEmail rejects logins to this client of users whose email
+	// address the connector didn't verify.
+	RequireVerifiedEmail bool `json:"requireVerifiedEmail" yaml:"requireVerifiedEmail"`
+
+	// RedirectURIPathPattern is a regular expression matching the paths of
+	// further redirect URIs. The scheme and host of such a URI must still be
+	// the ones of a registered redirect URI. It is ignored unless the server
+	// allows redirect URI patterns.
+	RedirectURIPathPattern string `json:"redirectURIPathPattern" yaml:"redirectURIPathPattern"`
+
+	// ConsentText and PrivacyPolicyURL are shown to the end user on the
+	// approval page, in addition to the requested scopes.
+	ConsentText      string `json:"consentText" yaml:"consentText"`
+	PrivacyPolicyURL string `json:"privacyPolicyURL" yaml:"privacyPolicyURL"`
+
+	// AllowedGroupPrefixes and AllowedGroupsPattern limit the groups issued to
+	// this client, for example to the groups of its tenant. Only the groups
+	// with one of the prefixes or fully matching the regular expression are
+	// issued. If neither is set, all the groups are issued.
+	AllowedGroupPrefixes []string `json:"allowedGroupPrefixes" yaml:"allowedGroupPrefixes"`
+	AllowedGroupsPattern string   `json:"allowedGroupsPattern" yaml:"allowedGroupsPattern"`
 }
 
 // Claims represents the ID Token claims supported by the server.
@@ -175,6 +219,30 @@ type Claims struct {
 	EmailVerified     bool
 
 	Groups []string
+
+	// Organizations the user is a member of, separate from the groups.
+	Organizations []string
+
+	// Address is the postal address of the user, if known.
+	Address *Address
+
+	// CustomClaims are additional claims added to the tokens of the user.
+	CustomClaims map[string]interface{}
+
+	// AuthTime is the time the user authenticated.
+	AuthTime time.Time
+}
+
+// Address is the structured postal address of a user.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#AddressClaim
+type Address struct {
+	Formatted     string `json:"formatted,omitempty"`
+	StreetAddress string `json:"street_address,omitempty"`
+	Locality      string `json:"locality,omitempty"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	Country       string `json:"country,omitempty"`
 }
 
 // PKCE is a container for the data needed to perform Proof Key for Code Exchange (RFC 7636) auth flow
@@ -200,6 +268,25 @@ type AuthRequest struct {
 	Nonce         string
 	State         string
 
+	// Resource indicators (RFC 8707) the client requested tokens for.
+	Resources []string
+
+	// Prompt holds the space delimited "prompt" parameter of the request, such
+	// as "login" or "select_account".
+	Prompt string
+
+	// EssentialClaims are the claims the client marked as essential in the
+	// "claims" parameter of the request.
+	EssentialClaims []string
+
+	// ACRValues are the authentication context class references the client
+	// requested with the "acr_values" parameter, in order of preference.
+	ACRValues []string
+
+	// MaxAge is the "max_age" parameter of the request in seconds, or nil if
+	// the client didn't send one.
+	MaxAge *int
+
 	// The client has indicated that the end user must be shown an approval prompt
 	// on all requests. The server cannot cache their initial action for subsequent
 	// attempts.
@@ -252,6 +339,10 @@ type AuthCode struct {
 	// Scopes authorized by the end user for the client.
 	Scopes []string
 
+	// Resource indicators (RFC 8707) granted to the client. Token requests may
+	// narrow this set, but never extend it.
+	Resources []string
+
 	// Authentication data provided by an upstream source.
 	ConnectorID   string
 	ConnectorData []byte
@@ -290,6 +381,10 @@ type RefreshToken struct {
 	// however those scopes must be encompassed by this set.
 	Scopes []string
 
+	// Resource indicators (RFC 8707) granted by the initial request. Refresh
+	// requests may only ask for tokens for resources in this set.
+	Resources []string
+
 	// Nonce value supplied during the initial redirect. This is required to be part
 	// of the claims of any future id_token generated by the client.
 	Nonce string
@@ -320,6 +415,10 @@ type OfflineSessions struct {
 
 	// Authentication data provided by an upstream source.
 	ConnectorData []byte
+
+	// LastLogin is the time the user last logged in through the connector, if
+	// the server tracks logins.
+	LastLogin time.Time
 }
 
 // Password is an email to password mapping managed by the storage.
@@ -428,3 +527,52 @@ type DeviceToken struct {
 	LastRequestTime     time.Time
 	PollIntervalSeconds int
 }
+
+// IssuedToken records a token signed by the server, so that it can be looked
+// up or revoked by its "jti" claim.
+type IssuedToken struct {
+	// The "jti" claim of the token.
+	ID string
+
+	// The client the token was issued to.
+	ClientID string
+
+	// The user the token was issued for and the connector they logged in with.
+	UserID      string
+	ConnectorID string
+
+	IssuedAt time.Time
+	Expiry   time.Time
+}
+
+// UpstreamKeySet is the signing key set of the upstream provider of a
+// connector, cached so that tokens can be verified while the provider is
+// unreachable, for example at startup.
+type UpstreamKeySet struct {
+	// The ID of the connector.
+	ConnectorID string
+
+	// The JSON Web Key Set published by the provider.
+	KeySet []byte
+
+	// When the key set was last fetched from the provider.
+	UpdatedAt time.Time
+}
+
+// PushedAuthRequest holds the parameters of an authorization request a client
+// pushed to the server, until the client sends the user to the authorization
+// endpoint with the request URI referencing them.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9126.html
+type PushedAuthRequest struct {
+	// The ID in the request URI returned to the client.
+	ID string
+
+	// The client which pushed the request, the only one which can use it.
+	ClientID string
+
+	// The parameters of the authorization request.
+	Params map[string][]string
+
+	Expiry time.Time
+}